@@ -0,0 +1,11 @@
+-- счета по умолчанию (Карта и Наличка) триггер регистрации открывал с нулевым балансом без opening_balance;
+-- если у пользователя несколько счетов с таким именем, счет по умолчанию не отличить - он остается без
+-- opening_balance и проверка баланса показывает его как непроверяемый
+ALTER TABLE Accounts ADD COLUMN IF NOT EXISTS opening_balance numeric(10, 2);
+
+UPDATE Accounts a
+SET opening_balance = 0
+WHERE a.opening_balance IS NULL
+  AND a.mean_payment IN ('Карта', 'Наличка')
+  AND (SELECT COUNT(*) FROM Accounts same
+       WHERE same.sharing_id = a.sharing_id AND same.mean_payment = a.mean_payment) = 1;
@@ -0,0 +1,11 @@
+-- роль owner появилась после UserAccount: владелец счета (sharing_id) получает роль owner,
+-- иначе после добавления колонки он остался бы editor и не смог бы управлять своим счетом
+ALTER TABLE UserAccount
+ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'editor' CHECK (role IN ('owner', 'editor', 'viewer', 'contributor'));
+
+UPDATE UserAccount ua
+SET role = 'owner'
+FROM Accounts a
+WHERE a.id = ua.account_id
+  AND a.sharing_id = ua.user_id
+  AND ua.role <> 'owner';
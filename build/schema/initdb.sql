@@ -12,18 +12,51 @@ CREATE TABLE IF NOT EXISTS Users (
 CREATE TABLE IF NOT EXISTS Accounts (
     id            UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
     balance numeric(10, 2),
+    opening_balance numeric(10, 2), -- balance без транзакций, NULL у старых счетов (см. build/migrations)
     sharing_id UUID REFERENCES Users(id), -- только он может что-то менять
     accumulation BOOLEAN,
     balance_enabled BOOLEAN,
-    mean_payment VARCHAR(30)
+    mean_payment VARCHAR(30),
+    archived_at TIMESTAMP, -- архивный счет скрыт из списков, транзакции остаются
+    kind             VARCHAR(10)    DEFAULT 'debit' NOT NULL CHECK (kind IN ('debit', 'credit')),
+    credit_limit     numeric(10, 2) DEFAULT 0       NOT NULL, -- у кредитной карты баланс может уйти в минус до лимита
+    grace_period     INT            DEFAULT 0       NOT NULL, -- льготный период, дней
+    statement_day    SMALLINT       DEFAULT 0       NOT NULL, -- день выписки 1..28, 0 у дебетового счета
+    overdraft_policy VARCHAR(10)    DEFAULT 'allow' NOT NULL CHECK (overdraft_policy IN ('reject', 'warn', 'allow'))
 );
 
 CREATE TABLE IF NOT EXISTS UserAccount (
     user_id    UUID REFERENCES Users(id),
     account_id UUID REFERENCES Accounts(id),
+    role       TEXT NOT NULL DEFAULT 'editor' CHECK (role IN ('owner', 'editor', 'viewer', 'contributor')), -- owner совпадает с sharing_id
     PRIMARY KEY (user_id, account_id)
 );
 
+-- UserAccount создается только принятым приглашением
+CREATE TABLE IF NOT EXISTS AccountInvitation (
+    id          UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    account_id  UUID REFERENCES Accounts(id) ON DELETE CASCADE NOT NULL,
+    inviter_id  UUID REFERENCES Users(id) ON DELETE CASCADE    NOT NULL,
+    invitee_id  UUID REFERENCES Users(id) ON DELETE CASCADE    NOT NULL,
+    role        TEXT                                           NOT NULL,
+    status      TEXT DEFAULT 'pending'                         NOT NULL, -- pending, accepted, declined, revoked
+    created_at  TIMESTAMP DEFAULT now()                        NOT NULL,
+    expires_at  TIMESTAMP                                      NOT NULL,
+    answered_at TIMESTAMP
+);
+
+-- история передачи счета, владелец меняется только после согласия преемника
+CREATE TABLE IF NOT EXISTS AccountOwnershipTransfer (
+    id           UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    account_id   UUID REFERENCES Accounts(id) ON DELETE CASCADE NOT NULL,
+    from_user_id UUID REFERENCES Users(id) ON DELETE CASCADE    NOT NULL,
+    to_user_id   UUID REFERENCES Users(id) ON DELETE CASCADE    NOT NULL,
+    leave        BOOLEAN DEFAULT false                          NOT NULL, -- прежний владелец выходит из счета
+    status       TEXT DEFAULT 'pending'                         NOT NULL, -- pending, accepted, declined, cancelled
+    created_at   TIMESTAMP DEFAULT now()                        NOT NULL,
+    answered_at  TIMESTAMP
+);
+
 CREATE TABLE IF NOT EXISTS category (
     id              UUID          DEFAULT uuid_generate_v4()   PRIMARY KEY,
     user_id         UUID          REFERENCES Users(id)    CONSTRAINT fk_user_category       NOT NULL,
@@ -36,6 +69,34 @@ CREATE TABLE IF NOT EXISTS category (
 );
 
 
+CREATE TABLE IF NOT EXISTS Payee (
+    id      UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id UUID REFERENCES Users(id) ON DELETE CASCADE NOT NULL,
+    "name"  VARCHAR(30)                                 NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS PayeeAlias (
+    user_id  UUID REFERENCES Users(id) ON DELETE CASCADE  NOT NULL,
+    alias    VARCHAR(30)                                  NOT NULL, -- нормализованная строка payer
+    payee_id UUID REFERENCES Payee(id) ON DELETE CASCADE  NOT NULL,
+    PRIMARY KEY (user_id, alias)
+);
+
+-- сверка счета с банковской выпиской на дату
+CREATE TABLE IF NOT EXISTS Reconciliation (
+    id              UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    account_id      UUID REFERENCES Accounts(id) ON DELETE CASCADE          NOT NULL,
+    user_id         UUID REFERENCES Users(id) ON DELETE CASCADE             NOT NULL,
+    statement_date  DATE                                                    NOT NULL,
+    closing_balance numeric(10, 2)                                          NOT NULL, -- баланс по выписке
+    status          VARCHAR(10) DEFAULT 'open'                              NOT NULL CHECK (status IN ('open', 'finished', 'cancelled')),
+    created_at      TIMESTAMP   DEFAULT now()                               NOT NULL,
+    finished_at     TIMESTAMP
+);
+
+-- у счета одна открытая сверка
+CREATE UNIQUE INDEX IF NOT EXISTS reconciliation_open_idx ON Reconciliation (account_id) WHERE status = 'open';
+
 CREATE TABLE IF NOT EXISTS Transaction (
 	id           UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
 	user_id      UUID REFERENCES Users(id),
@@ -45,7 +106,10 @@ CREATE TABLE IF NOT EXISTS Transaction (
     outcome      numeric(10, 2),
 	date         timestamp DEFAULT now(),
 	payer        VARCHAR(20),
-	description  VARCHAR(100)
+	payee_id     UUID REFERENCES Payee(id) ON DELETE SET NULL,
+	description  VARCHAR(100),
+	cleared      BOOLEAN DEFAULT false NOT NULL, -- отмечена в выписке
+	reconciliation_id UUID REFERENCES Reconciliation(id) ON DELETE SET NULL -- сверенная транзакция не меняется
 );
 
 CREATE TABLE IF NOT EXISTS TransactionCategory (
@@ -54,32 +118,196 @@ CREATE TABLE IF NOT EXISTS TransactionCategory (
     PRIMARY KEY (transaction_id, category_id)
 );
 
---CREATE TABLE IF NOT EXISTS goal (
---    id            UUID            DEFAULT uuid_generate_v4() PRIMARY KEY,
---    user_id       UUID            REFERENCES "user"(user_id)                                       NOT NULL,
---    "name"        TEXT                                       CHECK(LENGTH("name") <= 50)           NOT NULL,
---    "description" TEXT            DEFAULT ''                 CHECK(LENGTH("description") <= 255),
---    "target"      NUMERIC(10,2)                                                                    NOT NULL,
---    "date"        DATE,
---    created_at    TIMESTAMPTZ     DEFAULT CURRENT_TIMESTAMP                                        NOT NULL,
---    updated_at    TIMESTAMPTZ     DEFAULT CURRENT_TIMESTAMP                                        NOT NULL
---);
+-- сумма транзакций за день без переводов между счетами, пишется вместе с транзакцией;
+-- строка с нулевой категорией хранит итог дня, остальные - по категориям
+CREATE TABLE IF NOT EXISTS DailyTotals (
+    user_id     UUID REFERENCES Users(id) ON DELETE CASCADE     NOT NULL,
+    account_id  UUID REFERENCES Accounts(id) ON DELETE CASCADE  NOT NULL,
+    category_id UUID                                            NOT NULL,
+    day         DATE                                            NOT NULL,
+    income      numeric(12, 2) DEFAULT 0                        NOT NULL,
+    outcome     numeric(12, 2) DEFAULT 0                        NOT NULL,
+    PRIMARY KEY (user_id, account_id, category_id, day)
+);
 
---========================================================================
+CREATE TABLE IF NOT EXISTS AccountBalanceSnapshot (
+    account_id UUID REFERENCES Accounts(id) ON DELETE CASCADE,
+    date       DATE           NOT NULL,
+    balance    numeric(10, 2) NOT NULL,
+    PRIMARY KEY (account_id, date)
+);
 
---CREATE OR REPLACE FUNCTION public.moddatetime()
---    RETURNS TRIGGER AS $$
---BEGIN
---    NEW.updated_at = NOW();
---    RETURN NEW;
---END;
---$$ LANGUAGE plpgsql;
---
---CREATE OR REPLACE TRIGGER modify_updated_at
---    BEFORE UPDATE
---    ON goal
---    FOR EACH ROW
---EXECUTE PROCEDURE public.moddatetime(updated_at);
+CREATE TABLE IF NOT EXISTS Anomaly (
+    id             UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id        UUID REFERENCES Users(id) ON DELETE CASCADE             NOT NULL,
+    kind           VARCHAR(30)                                             NOT NULL,
+    fingerprint    VARCHAR(100)                                            NOT NULL, -- одна аномалия не поднимается дважды
+    transaction_id UUID REFERENCES Transaction(id) ON DELETE CASCADE,
+    category_id    UUID REFERENCES category(id) ON DELETE CASCADE,
+    amount         numeric(10, 2),
+    expected       numeric(10, 2),
+    explanation    VARCHAR(255)                                            NOT NULL,
+    detected_at    TIMESTAMP DEFAULT now()                                 NOT NULL,
+    dismissed      BOOLEAN   DEFAULT false                                 NOT NULL,
+    UNIQUE (user_id, fingerprint)
+);
+
+-- вклад на накопительном счете; проценты начисляются транзакциями дохода на этот счет
+CREATE TABLE IF NOT EXISTS Deposit (
+    id             UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id        UUID REFERENCES Users(id) ON DELETE CASCADE             NOT NULL,
+    account_id     UUID REFERENCES Accounts(id) ON DELETE CASCADE          NOT NULL,
+    bank           VARCHAR(30)    DEFAULT ''                               NOT NULL,
+    total          numeric(10, 2)                                          NOT NULL,
+    interest_rate  numeric(5, 2)                                           NOT NULL, -- годовых, в процентах
+    early_rate     numeric(5, 2)  DEFAULT 0                                NOT NULL, -- при досрочном закрытии
+    capitalization VARCHAR(10)    DEFAULT 'none'                           NOT NULL, -- none - простые проценты в конце срока
+    date_start     DATE                                                    NOT NULL,
+    date_end       DATE                                                    NOT NULL,
+    accrued        numeric(10, 2) DEFAULT 0                                NOT NULL, -- уже начислено
+    accrued_until  DATE                                                    NOT NULL,
+    closed_at      DATE,
+    CHECK (date_end > date_start)
+);
+
+-- кредит с ежемесячными платежами; график не хранится, он считается по условиям и досрочным погашениям
+CREATE TABLE IF NOT EXISTS Credit (
+    id            UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id       UUID REFERENCES Users(id) ON DELETE CASCADE             NOT NULL,
+    account_id    UUID REFERENCES Accounts(id) ON DELETE CASCADE          NOT NULL,
+    total         numeric(10, 2)                                          NOT NULL,
+    interest_rate numeric(5, 2)                                           NOT NULL, -- годовых, в процентах
+    date_start    DATE                                                    NOT NULL,
+    payments      INT                                                     NOT NULL, -- число ежемесячных платежей
+    is_annuity    BOOLEAN                                                 NOT NULL,
+    creditor      VARCHAR(30)    DEFAULT ''                               NOT NULL,
+    bank          VARCHAR(30)    DEFAULT ''                               NOT NULL,
+    description   VARCHAR(100)   DEFAULT ''                               NOT NULL
+);
+
+-- платеж по графику, оплаченный транзакцией
+CREATE TABLE IF NOT EXISTS CreditPaymentLink (
+    credit_id      UUID REFERENCES Credit(id) ON DELETE CASCADE           NOT NULL,
+    number         INT                                                    NOT NULL,
+    transaction_id UUID REFERENCES Transaction(id) ON DELETE CASCADE      NOT NULL,
+    PRIMARY KEY (credit_id, number),
+    CONSTRAINT credit_payment_link_transaction_unique UNIQUE (transaction_id)
+);
+
+CREATE TABLE IF NOT EXISTS CreditRepayment (
+    id             UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    credit_id      UUID REFERENCES Credit(id) ON DELETE CASCADE           NOT NULL,
+    date           DATE                                                   NOT NULL,
+    amount         numeric(10, 2)                                         NOT NULL,
+    reduce         VARCHAR(10)                                            NOT NULL, -- term - срок, payment - платеж
+    transaction_id UUID REFERENCES Transaction(id) ON DELETE SET NULL
+);
+
+-- долг с человеком: lent - дали в долг, borrowed - взяли в долг
+CREATE TABLE IF NOT EXISTS Debt (
+    id           UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id      UUID REFERENCES Users(id) ON DELETE CASCADE              NOT NULL,
+    counterparty VARCHAR(30)                                              NOT NULL,
+    direction    VARCHAR(10)                                              NOT NULL,
+    total        numeric(10, 2)                                           NOT NULL,
+    repaid       numeric(10, 2) DEFAULT 0                                 NOT NULL,
+    date         DATE                                                     NOT NULL,
+    due_date     DATE,
+    description  VARCHAR(100)   DEFAULT ''                                NOT NULL,
+    closed_at    DATE,
+    CHECK (repaid <= total)
+);
+
+-- частичное погашение, проведенное транзакцией по счету
+CREATE TABLE IF NOT EXISTS DebtRepayment (
+    id             UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    debt_id        UUID REFERENCES Debt(id) ON DELETE CASCADE             NOT NULL,
+    account_id     UUID REFERENCES Accounts(id) ON DELETE CASCADE         NOT NULL,
+    transaction_id UUID REFERENCES Transaction(id) ON DELETE CASCADE      NOT NULL,
+    amount         numeric(10, 2)                                         NOT NULL,
+    date           DATE                                                   NOT NULL
+);
+
+-- ценная бумага пользователя, позиция считается по лотам
+CREATE TABLE IF NOT EXISTS Holding (
+    id      UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id UUID REFERENCES Users(id) ON DELETE CASCADE                   NOT NULL,
+    ticker  VARCHAR(20)                                                   NOT NULL,
+    name    VARCHAR(50)    DEFAULT ''                                     NOT NULL,
+    UNIQUE (user_id, ticker)
+);
+
+CREATE TABLE IF NOT EXISTS InvestmentLot (
+    id         UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    holding_id UUID REFERENCES Holding(id) ON DELETE CASCADE              NOT NULL,
+    kind       VARCHAR(4)                                                 NOT NULL, -- buy, sell
+    quantity   numeric(14, 4)                                             NOT NULL,
+    price      numeric(12, 4)                                             NOT NULL,
+    fee        numeric(10, 2) DEFAULT 0                                   NOT NULL,
+    date       DATE                                                       NOT NULL
+);
+
+-- дивиденды, проведенные транзакцией по счету
+CREATE TABLE IF NOT EXISTS InvestmentDividend (
+    id             UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    holding_id     UUID REFERENCES Holding(id) ON DELETE CASCADE          NOT NULL,
+    account_id     UUID REFERENCES Accounts(id) ON DELETE CASCADE         NOT NULL,
+    transaction_id UUID REFERENCES Transaction(id) ON DELETE CASCADE      NOT NULL,
+    amount         numeric(10, 2)                                         NOT NULL,
+    date           DATE                                                   NOT NULL
+);
+
+-- стоимость портфеля по ценам на день, идет в чистые активы
+CREATE TABLE IF NOT EXISTS PortfolioSnapshot (
+    user_id UUID REFERENCES Users(id) ON DELETE CASCADE                   NOT NULL,
+    date    DATE                                                          NOT NULL,
+    value   numeric(12, 2)                                                NOT NULL,
+    PRIMARY KEY (user_id, date)
+);
+
+CREATE TABLE IF NOT EXISTS Goal (
+    id            UUID           DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id       UUID           REFERENCES Users(id) ON DELETE CASCADE NOT NULL,
+    "name"        VARCHAR(50)                                           NOT NULL,
+    "description" VARCHAR(255)   DEFAULT ''                             NOT NULL,
+    target        numeric(10, 2) CHECK (target > 0)                     NOT NULL,
+    "date"        DATE                                                  NOT NULL, -- к этой дате цель должна быть достигнута
+    created_at    TIMESTAMP      DEFAULT now()                          NOT NULL
+);
+
+-- накопительные счета цели, прогресс цели это сумма их балансов
+CREATE TABLE IF NOT EXISTS GoalAccount (
+    goal_id    UUID REFERENCES Goal(id) ON DELETE CASCADE     NOT NULL,
+    account_id UUID REFERENCES Accounts(id) ON DELETE CASCADE NOT NULL,
+    PRIMARY KEY (goal_id, account_id)
+);
+
+-- правило пополнения цели переводами со счета source_id на счет цели account_id
+CREATE TABLE IF NOT EXISTS GoalRule (
+    id         UUID           DEFAULT uuid_generate_v4()                       PRIMARY KEY,
+    goal_id    UUID                                                            NOT NULL,
+    kind       TEXT           CHECK (kind IN ('schedule', 'income', 'round_up')) NOT NULL,
+    source_id  UUID           REFERENCES Accounts(id) ON DELETE CASCADE        NOT NULL,
+    account_id UUID                                                            NOT NULL,
+    amount     numeric(10, 2) CHECK (amount > 0)                               NOT NULL, -- сумма перевода, процент дохода или шаг округления
+    period     TEXT           CHECK (period IN ('week', 'month')), -- только у schedule
+    date_start DATE, -- первый перевод по расписанию
+    next_date  DATE,
+    created_at TIMESTAMP      DEFAULT now()                                    NOT NULL, -- более ранние доходы и расходы не учитываются
+    FOREIGN KEY (goal_id, account_id) REFERENCES GoalAccount(goal_id, account_id) ON DELETE CASCADE
+);
+
+-- переводы по правилам, без transaction_id перевод пропущен из-за нехватки средств
+CREATE TABLE IF NOT EXISTS GoalContribution (
+    id                    UUID           DEFAULT uuid_generate_v4()               PRIMARY KEY,
+    goal_id               UUID           REFERENCES Goal(id) ON DELETE CASCADE    NOT NULL,
+    rule_id               UUID           REFERENCES GoalRule(id) ON DELETE SET NULL,
+    source_transaction_id UUID           REFERENCES Transaction(id) ON DELETE SET NULL, -- доход или расход, от которого посчитан перевод
+    transaction_id        UUID           REFERENCES Transaction(id) ON DELETE CASCADE,
+    amount                numeric(10, 2)                                          NOT NULL,
+    "date"                TIMESTAMP                                               NOT NULL,
+    UNIQUE (rule_id, source_transaction_id)
+);
 
 --========================================================================
 
@@ -109,17 +337,17 @@ BEGIN
     
     SELECT id INTO categoryID FROM category WHERE name = 'Продукты' AND user_id = NEW.id;
 
-    INSERT INTO accounts(balance, sharing_id, mean_payment, accumulation, balance_enabled)
-    VALUES (0, NEW.id, 'Карта', false, true) RETURNING id INTO accountCardID;
+    INSERT INTO accounts(balance, opening_balance, sharing_id, mean_payment, accumulation, balance_enabled)
+    VALUES (0, 0, NEW.id, 'Карта', false, true) RETURNING id INTO accountCardID;
            
-    INSERT INTO accounts(balance, sharing_id, mean_payment, accumulation, balance_enabled)
-    VALUES (0, NEW.id, 'Наличка', false, true) RETURNING id INTO accountCashID;
+    INSERT INTO accounts(balance, opening_balance, sharing_id, mean_payment, accumulation, balance_enabled)
+    VALUES (0, 0, NEW.id, 'Наличка', false, true) RETURNING id INTO accountCashID;
 
-    INSERT INTO userAccount(user_id, account_id)
-    VALUES (NEW.id, accountCardID);
+    INSERT INTO userAccount(user_id, account_id, role)
+    VALUES (NEW.id, accountCardID, 'owner');
 
-    INSERT INTO userAccount(user_id, account_id)
-    VALUES (NEW.id, accountCashID);
+    INSERT INTO userAccount(user_id, account_id, role)
+    VALUES (NEW.id, accountCashID, 'owner');
 
     INSERT INTO transaction(user_id, account_income, account_outcome, income, outcome, payer, description)
     VALUES (NEW.id, accountCardID,
@@ -133,6 +361,10 @@ BEGIN
     VALUES (transaction_idI, categoryID),
             (transaction_idO, categoryID);
 
+    INSERT INTO DailyTotals(user_id, account_id, category_id, day, income, outcome)
+    VALUES (NEW.id, accountCardID, '00000000-0000-0000-0000-000000000000', CURRENT_DATE, 100, 100),
+            (NEW.id, accountCardID, categoryID, CURRENT_DATE, 100, 100);
+
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
@@ -45,7 +45,16 @@ func run() (err error) {
 
 	accountRepo := accountRep.NewRepository(db, *log)
 
-	accountUsecase := accountUsecase.NewUsecase(accountRepo, *log)
+	var watchInterval time.Duration
+	if env := os.Getenv("ACCOUNT_WATCH_INTERVAL"); env != "" {
+		watchInterval, err = time.ParseDuration(env)
+		if err != nil {
+			log.Errorf("Error parsing ACCOUNT_WATCH_INTERVAL: %v", err)
+			return
+		}
+	}
+
+	accountUsecase := accountUsecase.NewUsecase(accountRepo, *log, watchInterval)
 
 	service := accountHandler.NewAccountGRPC(accountUsecase, *log)
 
@@ -17,6 +17,7 @@ import (
 	generatedAuth "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/auth/delivery/grpc/generated"
 	authDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/auth/delivery/http"
 	generatedCategory "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/grpc/generated"
+	generatedGoal "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
 
 	categoryDelivary "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/http"
 
@@ -31,6 +32,41 @@ import (
 	userRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/repository/postgresql"
 	userUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/usecase"
 
+	reportDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/delivery/http"
+	reportRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/repository/postgresql"
+	reportUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/usecase"
+
+	anomalyDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/delivery/http"
+	anomalyRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/repository/postgresql"
+	anomalyUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/usecase"
+	balanceDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/delivery/http"
+	balanceRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/repository/postgresql"
+	balanceUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/usecase"
+	creditDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit/delivery/http"
+	creditRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit/repository/postgresql"
+	creditUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit/usecase"
+	debtDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt/delivery/http"
+	debtRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt/repository/postgresql"
+	debtUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt/usecase"
+	depositDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/delivery/http"
+	depositRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/repository/postgresql"
+	depositUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/usecase"
+	goalDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/http"
+	investmentDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/delivery/http"
+	investmentPrices "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/prices"
+	investmentRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/repository/postgresql"
+	investmentUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/usecase"
+	invitationDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation/delivery/http"
+	invitationRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation/repository/postgresql"
+	invitationUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation/usecase"
+	reconciliationDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation/delivery/http"
+	reconciliationRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation/repository/postgresql"
+	reconciliationUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation/usecase"
+
+	payeeDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/delivery/http"
+	payeeRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/repository/postgresql"
+	payeeUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/usecase"
+
 	accountDelivery "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/http"
 	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
 	sessionRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/monolithic/sessions/repository/redis"
@@ -48,6 +84,7 @@ func Init(db *pgxpool.Pool, redis *redis.Client, log *logger.Logger) *mux.Router
 	authAddr := os.Getenv("AUTH_ADDR")
 	accountAddr := os.Getenv("ACCOUNT_ADDR")
 	categoryAddr := os.Getenv("CATEGORY_ADDR")
+	goalAddr := os.Getenv("GOAL_ADDR")
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -76,6 +113,14 @@ func Init(db *pgxpool.Pool, redis *redis.Client, log *logger.Logger) *mux.Router
 
 	categortClient := generatedCategory.NewCategoryServiceClient(categoryConn)
 
+	goalConn, err := grpc.Dial(goalAddr, opts...)
+
+	if err != nil {
+		log.Fatalf("Connection refused goal %v\n", err)
+	}
+
+	goalClient := generatedGoal.NewGoalServiceClient(goalConn)
+
 	accountClient := generatedAccount.NewAccountServiceClient(accountConn)
 	// authRep := authRep.NewRepository(db, *log)
 	sessionRep := sessionRep.NewSessionRepository(redis)
@@ -84,13 +129,33 @@ func Init(db *pgxpool.Pool, redis *redis.Client, log *logger.Logger) *mux.Router
 	transactionRep := transactionRep.NewRepository(db, *log)
 	//categoryRep := categoryRep.NewRepository(db, *log)
 	accountRep := accountRep.NewRepository(db, *log)
+	reportRep := reportRep.NewRepository(db, *log)
+	anomalyRep := anomalyRep.NewRepository(db, *log)
+	payeeRep := payeeRep.NewRepository(db, *log)
+	depositRep := depositRep.NewRepository(db, *log)
+	creditRep := creditRep.NewRepository(db, *log)
+	debtRep := debtRep.NewRepository(db, *log)
+	investmentRep := investmentRep.NewRepository(db, *log)
+	balanceRep := balanceRep.NewRepository(db, *log)
+	invitationRep := invitationRep.NewRepository(db, *log)
+	reconciliationRep := reconciliationRep.NewRepository(db, *log)
 
 	// authUsecase := authUsecase.NewUsecase(authRep, *log)
 	sessionUsecase := sessionUsecase.NewSessionUsecase(sessionRep)
-	userUsecase := userUsecase.NewUsecase(userRep, *log, accountRep)
-	transactionUsecase := transactionUsecase.NewUsecase(transactionRep, *log)
+	userUsecase := userUsecase.NewUsecase(userRep, *log)
+	payeeUsecase := payeeUsecase.NewUsecase(payeeRep, *log)
+	anomalyUsecase := anomalyUsecase.NewUsecase(anomalyRep, *log)
+	transactionUsecase := transactionUsecase.NewUsecase(transactionRep, *log, payeeUsecase, accountRep, anomalyUsecase)
 	//categoryUsecase := categoryUsecase.NewUsecase(categoryRep, *log)
 	csrfUsecase := csrfUsecase.NewUsecase(*log)
+	reportUsecase := reportUsecase.NewUsecase(reportRep, *log, userRep, payeeRep)
+	depositUsecase := depositUsecase.NewUsecase(depositRep, *log, accountRep, anomalyUsecase)
+	creditUsecase := creditUsecase.NewUsecase(creditRep, *log, accountRep)
+	debtUsecase := debtUsecase.NewUsecase(debtRep, *log, accountRep, anomalyUsecase)
+	investmentUsecase := investmentUsecase.NewUsecase(investmentRep, *log, investmentPrices.NewFileSource(os.Getenv("PRICES_FILE")), accountRep)
+	balanceUsecase := balanceUsecase.NewUsecase(balanceRep, *log)
+	invitationUsecase := invitationUsecase.NewUsecase(invitationRep, *log, accountRep, userRep)
+	reconciliationUsecase := reconciliationUsecase.NewUsecase(reconciliationRep, *log, accountRep)
 	// accountUsecase := accountUsecase.NewUsecase(accountRep, *log)
 
 	authHandler := authDelivery.NewHandler(sessionUsecase, authClient, *log)
@@ -99,12 +164,24 @@ func Init(db *pgxpool.Pool, redis *redis.Client, log *logger.Logger) *mux.Router
 	logMiddlewear := middleware.NewLoggingMiddleware(*log)
 	recoveryMiddlewear := middleware.NewRecoveryMiddleware(*log)
 	csrfMiddlewear := middleware.NewCSRFMiddleware(csrfUsecase, *log)
+	adminMiddlewear := middleware.NewAdminMiddleware(os.Getenv("ADMIN_IDS"), *log)
 
-	userHandler := userDelivery.NewHandler(userUsecase, *log)
+	userHandler := userDelivery.NewHandler(userUsecase, accountClient, *log)
 	transactionHandler := transactionDelivery.NewHandler(transactionUsecase, userUsecase, accountClient, *log)
 	categoryHandler := categoryDelivary.NewHandler(categortClient, *log)
 	csrfHandler := csrfDelivery.NewHandler(csrfUsecase, *log)
 	accountHandler := accountDelivery.NewHandler(accountClient, *log)
+	reportHandler := reportDelivery.NewHandler(reportUsecase, *log)
+	anomalyHandler := anomalyDelivery.NewHandler(anomalyUsecase, *log)
+	payeeHandler := payeeDelivery.NewHandler(payeeUsecase, *log)
+	depositHandler := depositDelivery.NewHandler(depositUsecase, *log)
+	creditHandler := creditDelivery.NewHandler(creditUsecase, *log)
+	debtHandler := debtDelivery.NewHandler(debtUsecase, *log)
+	investmentHandler := investmentDelivery.NewHandler(investmentUsecase, *log)
+	balanceHandler := balanceDelivery.NewHandler(balanceUsecase, *log)
+	invitationHandler := invitationDelivery.NewHandler(invitationUsecase, *log)
+	reconciliationHandler := reconciliationDelivery.NewHandler(reconciliationUsecase, *log)
+	goalHandler := goalDelivery.NewHandler(goalClient, *log)
 
 	return router.InitRouter(
 		authHandler,
@@ -113,10 +190,22 @@ func Init(db *pgxpool.Pool, redis *redis.Client, log *logger.Logger) *mux.Router
 		categoryHandler,
 		csrfHandler,
 		accountHandler,
+		reportHandler,
+		anomalyHandler,
+		payeeHandler,
+		depositHandler,
+		creditHandler,
+		debtHandler,
+		investmentHandler,
+		balanceHandler,
+		invitationHandler,
+		reconciliationHandler,
+		goalHandler,
 		logMiddlewear,
 		recoveryMiddlewear,
 		authMiddlewear,
 		csrfMiddlewear,
+		adminMiddlewear,
 	)
 
 }
@@ -10,9 +10,20 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/http"
+	anomaly "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/delivery/http"
 	auth "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/auth/delivery/http"
+	balance "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/delivery/http"
 	category "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/http"
+	credit "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit/delivery/http"
 	csrf "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/csrf/delivery/http"
+	debt "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt/delivery/http"
+	deposit "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/delivery/http"
+	goal "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/http"
+	investment "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/delivery/http"
+	invitation "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation/delivery/http"
+	payee "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/delivery/http"
+	reconciliation "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation/delivery/http"
+	report "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/delivery/http"
 	transaction "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/transaction/delivery/http"
 	user "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/delivery/http"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/middleware"
@@ -28,16 +39,28 @@ func InitRouter(auth *auth.Handler,
 	category *category.Handler,
 	csrf *csrf.Handler,
 	account *account.Handler,
+	report *report.Handler,
+	anomaly *anomaly.Handler,
+	payee *payee.Handler,
+	deposit *deposit.Handler,
+	credit *credit.Handler,
+	debt *debt.Handler,
+	investment *investment.Handler,
+	balance *balance.Handler,
+	invitation *invitation.Handler,
+	reconciliation *reconciliation.Handler,
+	goal *goal.Handler,
 	logMid *middleware.LoggingMiddleware,
 	recoveryMid *middleware.RecoveryMiddleware,
 	authMid *middleware.AuthMiddleware,
-	csrfMid *middleware.CSRFMiddleware) *mux.Router {
+	csrfMid *middleware.CSRFMiddleware,
+	adminMid *middleware.AdminMiddleware) *mux.Router {
 
 	r := mux.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(logMid.LoggingMiddleware)
 	r.Use(recoveryMid.Recoverer)
-	r.Use(middleware.Timeout(5 * time.Second))
+	r.Use(middleware.Timeout(5*time.Second, "/api/account/watch"))
 
 	http.Handle("/", r)
 
@@ -77,7 +100,12 @@ func InitRouter(auth *auth.Handler,
 	{
 		accountRouter.Methods("POST").Path("/create").HandlerFunc(account.Create)
 		accountRouter.Methods("PUT").Path("/update").HandlerFunc(account.Update)
+		accountRouter.Methods("GET").Path("/watch").HandlerFunc(account.Watch)
+		accountRouter.Methods("GET").Path("/{account_id}").HandlerFunc(account.Get)
 		accountRouter.Methods("DELETE").Path("/{account_id}/delete").HandlerFunc(account.Delete)
+		accountRouter.Methods("GET").Path("/{account_id}/delete/preview").HandlerFunc(account.DeletePreview)
+		accountRouter.Methods("PUT").Path("/{account_id}/archive").HandlerFunc(account.Archive)
+		accountRouter.Methods("PUT").Path("/{account_id}/restore").HandlerFunc(account.Restore)
 	}
 
 	userRouter := apiRouter.PathPrefix("/user").Subrouter()
@@ -86,10 +114,16 @@ func InitRouter(auth *auth.Handler,
 	{
 		userRouter.Methods("PUT").Path("/updatePhoto").HandlerFunc(user.UpdatePhoto)
 		userRouter.Methods("PUT").Path("/update").HandlerFunc(user.Update)
-		userRouter.Methods("POST").Path("/addUserInAccount").HandlerFunc(user.AddUserInAccount)
-		userRouter.Methods("PUT").Path("/unsubscribeAccount/{account_id}").HandlerFunc(user.Unsubscribe)
-		userRouter.Methods("DELETE").Path("/deleteUserInAccount").HandlerFunc(user.DeleteUserInAccount)
-		userRouter.Methods("GET").Path("/account/all").HandlerFunc(user.GetAccounts)
+		userRouter.Methods("PUT").Path("/unsubscribeAccount/{account_id}").HandlerFunc(account.Unsubscribe)
+		userRouter.Methods("DELETE").Path("/deleteUserInAccount").HandlerFunc(account.DeleteUserInAccount)
+		userRouter.Methods("PUT").Path("/setUserRole").HandlerFunc(account.SetUserRole)
+		userRouter.Methods("PUT").Path("/transferOwnership").HandlerFunc(account.TransferOwnership)
+		userRouter.Methods("GET").Path("/ownership/pending").HandlerFunc(account.GetOwnershipTransfers)
+		userRouter.Methods("PUT").Path("/ownership/{transfer_id}/accept").HandlerFunc(account.AcceptOwnershipTransfer)
+		userRouter.Methods("PUT").Path("/ownership/{transfer_id}/decline").HandlerFunc(account.DeclineOwnershipTransfer)
+		userRouter.Methods("GET").Path("/account/{account_id}/ownership").HandlerFunc(account.GetOwnershipHistory)
+		userRouter.Methods("GET").Path("/account/all").HandlerFunc(account.GetAccounts)
+		userRouter.Methods("GET").Path("/account/archived").HandlerFunc(account.GetArchivedAccounts)
 		userRouter.Methods("GET").Path("/feed").HandlerFunc(user.GetFeed)
 		userRouter.Methods("GET").Path("/").HandlerFunc(user.Get)
 		// userRouter.Methods("GET").Path("/balance").HandlerFunc(user.GetUserBalance)
@@ -118,8 +152,130 @@ func InitRouter(auth *auth.Handler,
 	{
 		categoryRouter.Methods("POST").Path("/create").HandlerFunc(category.CreateTag)
 		categoryRouter.Methods("GET").Path("/all").HandlerFunc(category.GetTags)
+		categoryRouter.Methods("GET").Path("/tree").HandlerFunc(category.GetTree)
 		categoryRouter.Methods("PUT").Path("/{tagID}/update").HandlerFunc(category.UpdateTag)
+		categoryRouter.Methods("POST").Path("/{tagID}/merge").HandlerFunc(category.MergeTags)
 		categoryRouter.Methods("DELETE").Path("/delete").HandlerFunc(category.DeleteTag)
 	}
+
+	reportRouter := apiRouter.PathPrefix("/report").Subrouter()
+	reportRouter.Use(authMid.Authentication)
+	reportRouter.Use(csrfMid.CheckCSRF)
+	{
+		reportRouter.Methods("GET").Path("/forecast").HandlerFunc(report.GetForecast)
+		reportRouter.Methods("GET").Path("/balance-history").HandlerFunc(report.GetBalanceHistory)
+		reportRouter.Methods("GET").Path("/summary").HandlerFunc(report.GetSummary)
+		reportRouter.Methods("GET").Path("/year-review").HandlerFunc(report.GetYearReview)
+		reportRouter.Methods("GET").Path("/account/{account_id}/members").HandlerFunc(report.GetMembersReport)
+	}
+
+	anomalyRouter := apiRouter.PathPrefix("/anomaly").Subrouter()
+	anomalyRouter.Use(authMid.Authentication)
+	anomalyRouter.Use(csrfMid.CheckCSRF)
+	{
+		anomalyRouter.Methods("GET").Path("/all").HandlerFunc(anomaly.GetAnomalies)
+		anomalyRouter.Methods("PUT").Path("/{anomaly_id}/dismiss").HandlerFunc(anomaly.Dismiss)
+	}
+
+	payeeRouter := apiRouter.PathPrefix("/payee").Subrouter()
+	payeeRouter.Use(authMid.Authentication)
+	payeeRouter.Use(csrfMid.CheckCSRF)
+	{
+		payeeRouter.Methods("GET").Path("/all").HandlerFunc(payee.GetPayees)
+		payeeRouter.Methods("GET").Path("/top").HandlerFunc(payee.GetTopPayees)
+		payeeRouter.Methods("POST").Path("/merge").HandlerFunc(payee.Merge)
+	}
+
+	depositRouter := apiRouter.PathPrefix("/deposit").Subrouter()
+	depositRouter.Use(authMid.Authentication)
+	depositRouter.Use(csrfMid.CheckCSRF)
+	{
+		depositRouter.Methods("POST").Path("/create").HandlerFunc(deposit.Create)
+		depositRouter.Methods("GET").Path("/all").HandlerFunc(deposit.GetDeposits)
+		depositRouter.Methods("GET").Path("/{deposit_id}/projection").HandlerFunc(deposit.GetProjection)
+		depositRouter.Methods("POST").Path("/{deposit_id}/withdraw").HandlerFunc(deposit.Withdraw)
+	}
+
+	creditRouter := apiRouter.PathPrefix("/credit").Subrouter()
+	creditRouter.Use(authMid.Authentication)
+	creditRouter.Use(csrfMid.CheckCSRF)
+	{
+		creditRouter.Methods("POST").Path("/create").HandlerFunc(credit.Create)
+		creditRouter.Methods("GET").Path("/all").HandlerFunc(credit.GetCredits)
+		creditRouter.Methods("GET").Path("/{credit_id}/schedule").HandlerFunc(credit.GetSchedule)
+		creditRouter.Methods("POST").Path("/{credit_id}/payment").HandlerFunc(credit.LinkPayment)
+		creditRouter.Methods("POST").Path("/{credit_id}/repay").HandlerFunc(credit.Repay)
+	}
+
+	debtRouter := apiRouter.PathPrefix("/debt").Subrouter()
+	debtRouter.Use(authMid.Authentication)
+	debtRouter.Use(csrfMid.CheckCSRF)
+	{
+		debtRouter.Methods("POST").Path("/create").HandlerFunc(debt.Create)
+		debtRouter.Methods("GET").Path("/all").HandlerFunc(debt.GetDebts)
+		debtRouter.Methods("GET").Path("/summary").HandlerFunc(debt.GetSummary)
+		debtRouter.Methods("GET").Path("/{debt_id}/repayments").HandlerFunc(debt.GetRepayments)
+		debtRouter.Methods("POST").Path("/{debt_id}/repay").HandlerFunc(debt.Repay)
+	}
+
+	investmentRouter := apiRouter.PathPrefix("/investment").Subrouter()
+	investmentRouter.Use(authMid.Authentication)
+	investmentRouter.Use(csrfMid.CheckCSRF)
+	{
+		investmentRouter.Methods("POST").Path("/create").HandlerFunc(investment.Create)
+		investmentRouter.Methods("GET").Path("/portfolio").HandlerFunc(investment.GetPortfolio)
+		investmentRouter.Methods("POST").Path("/{holding_id}/lot").HandlerFunc(investment.AddLot)
+		investmentRouter.Methods("GET").Path("/{holding_id}/lots").HandlerFunc(investment.GetLots)
+		investmentRouter.Methods("POST").Path("/{holding_id}/dividend").HandlerFunc(investment.PostDividend)
+	}
+
+	invitationRouter := apiRouter.PathPrefix("/invitation").Subrouter()
+	invitationRouter.Use(authMid.Authentication)
+	invitationRouter.Use(csrfMid.CheckCSRF)
+	{
+		invitationRouter.Methods("POST").Path("/create").HandlerFunc(invitation.Create)
+		invitationRouter.Methods("GET").Path("/pending").HandlerFunc(invitation.GetPending)
+		invitationRouter.Methods("GET").Path("/account/{account_id}").HandlerFunc(invitation.GetAccountInvitations)
+		invitationRouter.Methods("PUT").Path("/{invitation_id}/accept").HandlerFunc(invitation.Accept)
+		invitationRouter.Methods("PUT").Path("/{invitation_id}/decline").HandlerFunc(invitation.Decline)
+		invitationRouter.Methods("PUT").Path("/{invitation_id}/revoke").HandlerFunc(invitation.Revoke)
+	}
+
+	reconciliationRouter := apiRouter.PathPrefix("/reconciliation").Subrouter()
+	reconciliationRouter.Use(authMid.Authentication)
+	reconciliationRouter.Use(csrfMid.CheckCSRF)
+	{
+		reconciliationRouter.Methods("POST").Path("/start").HandlerFunc(reconciliation.Start)
+		reconciliationRouter.Methods("GET").Path("/account/{account_id}").HandlerFunc(reconciliation.GetAccountReconciliations)
+		reconciliationRouter.Methods("GET").Path("/{reconciliation_id}").HandlerFunc(reconciliation.Get)
+		reconciliationRouter.Methods("PUT").Path("/{reconciliation_id}/clear").HandlerFunc(reconciliation.Clear)
+		reconciliationRouter.Methods("PUT").Path("/{reconciliation_id}/finish").HandlerFunc(reconciliation.Finish)
+		reconciliationRouter.Methods("PUT").Path("/{reconciliation_id}/cancel").HandlerFunc(reconciliation.Cancel)
+	}
+
+	goalRouter := apiRouter.PathPrefix("/goal").Subrouter()
+	goalRouter.Use(authMid.Authentication)
+	goalRouter.Use(csrfMid.CheckCSRF)
+	{
+		goalRouter.Methods("POST").Path("/create").HandlerFunc(goal.CreateGoal)
+		goalRouter.Methods("GET").Path("/all").HandlerFunc(goal.GetGoals)
+		goalRouter.Methods("GET").Path("/completed").HandlerFunc(goal.CheckGoalsState)
+		goalRouter.Methods("PUT").Path("/{goal_id}/update").HandlerFunc(goal.UpdateGoal)
+		goalRouter.Methods("DELETE").Path("/{goal_id}/delete").HandlerFunc(goal.DeleteGoal)
+		goalRouter.Methods("GET").Path("/{goal_id}/projection").HandlerFunc(goal.GetProjection)
+
+		goalRouter.Methods("POST").Path("/{goal_id}/rule/create").HandlerFunc(goal.CreateRule)
+		goalRouter.Methods("GET").Path("/{goal_id}/rules").HandlerFunc(goal.GetRules)
+		goalRouter.Methods("DELETE").Path("/rule/{rule_id}/delete").HandlerFunc(goal.DeleteRule)
+	}
+
+	adminRouter := apiRouter.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(authMid.Authentication)
+	adminRouter.Use(adminMid.Admin)
+	adminRouter.Use(csrfMid.CheckCSRF)
+	{
+		adminRouter.Methods("GET").Path("/balance/check").HandlerFunc(balance.Check)
+		adminRouter.Methods("POST").Path("/balance/repair").HandlerFunc(balance.Repair)
+	}
 	return r
 }
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	balanceRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/repository/postgresql"
+	balanceUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/usecase"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "write the recomputed balances")
+	flag.Parse()
+
+	if err := run(*repair); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(repair bool) (err error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log := logger.NewLogger(ctx)
+
+	initCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	db, err := postgresql.InitPostgresDB(initCtx)
+	if err != nil {
+		log.Errorf("Error Initializing PostgreSQL database: %v", err)
+		return
+	}
+	defer db.Close()
+
+	balanceUsecase := balanceUsecase.NewUsecase(balanceRep.NewRepository(db, *log), *log)
+
+	report, err := balanceUsecase.CheckBalances(ctx, repair)
+	if err != nil {
+		log.Errorf("balance check failed: %v", err)
+		return
+	}
+
+	printReport(report)
+	return nil
+}
+
+func printReport(report *models.BalanceReport) {
+	fmt.Printf("accounts checked: %d, unverifiable: %d, discrepancies: %d\n",
+		report.Accounts, len(report.Unverifiable), len(report.Discrepancies))
+
+	for _, check := range report.Discrepancies {
+		fmt.Printf("%s\tstored %.2f\texpected %.2f\tdifference %.2f\n",
+			check.AccountID, check.Stored, check.Expected, check.Difference)
+	}
+
+	for _, check := range report.Unverifiable {
+		fmt.Printf("%s\tstored %.2f\tno opening balance\n", check.AccountID, check.Stored)
+	}
+
+	if report.Repaired {
+		fmt.Println("balances repaired")
+	}
+}
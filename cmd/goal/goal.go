@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	generatedGoal "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	anomalyRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/repository/postgresql"
+	anomalyUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/usecase"
+	goalHandler "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc"
+	goalRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/repository/postgres"
+	goalUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/usecase"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/middleware"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	if err := run(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	log := logger.NewLogger(ctx)
+	db, err := postgresql.InitPostgresDB(ctx)
+	if err != nil {
+		log.Errorf("Error Initializing PostgreSQL database: %v", err)
+		return
+	}
+	defer func() {
+		db.Close()
+
+		log.Info("Db closed without errors")
+	}()
+
+	log.Info("Db connection successfully")
+
+	goalRepo := goalRep.NewRepository(db, *log)
+	anomalyRepo := anomalyRep.NewRepository(db, *log)
+
+	anomalyUsecase := anomalyUsecase.NewUsecase(anomalyRepo, *log)
+	goalUsecase := goalUsecase.NewUsecase(goalRepo, *log, anomalyUsecase)
+
+	service := goalHandler.NewGoalGRPC(goalUsecase, *log)
+
+	srv, err := net.Listen("tcp", ":8040")
+	if err != nil {
+		log.Fatalln("can't listen port", err)
+	}
+
+	metricsMw := middleware.NewMetricsMiddleware()
+	metricsMw.Register(middleware.ServiceGoalName)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(metricsMw.ServerMetricsInterceptor))
+
+	generatedGoal.RegisterGoalServiceServer(server, service)
+	r := mux.NewRouter().PathPrefix("/api").Subrouter()
+	r.PathPrefix("/metrics").Handler(promhttp.Handler())
+
+	http.Handle("/", r)
+	httpSrv := http.Server{Handler: r, Addr: ":8041"}
+
+	go func() {
+		err := httpSrv.ListenAndServe()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	fmt.Print("goal running on: ", srv.Addr())
+	return server.Serve(srv)
+}
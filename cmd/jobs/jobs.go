@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	anomalyRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/repository/postgresql"
+	anomalyUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/usecase"
+	depositRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/repository/postgresql"
+	depositUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/usecase"
+	goalRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/repository/postgres"
+	goalUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/usecase"
+	investmentPrices "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/prices"
+	investmentRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/repository/postgresql"
+	investmentUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/usecase"
+	payeeRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/repository/postgresql"
+	payeeUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/usecase"
+	reportRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/repository/postgresql"
+	reportUsecase "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/usecase"
+	userRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/repository/postgresql"
+)
+
+// job is a periodic task, it runs on start and then every interval
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func main() {
+	// the backfills rebuild whole tables under a lock, they are run once by hand and not on every start
+	backfill := flag.Bool("backfill", false, "rebuild balance snapshots and daily totals from the transactions and exit")
+	flag.Parse()
+
+	if err := run(*backfill); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(backfill bool) (err error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log := logger.NewLogger(ctx)
+
+	initCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	db, err := postgresql.InitPostgresDB(initCtx)
+	if err != nil {
+		log.Errorf("Error Initializing PostgreSQL database: %v", err)
+		return
+	}
+	defer func() {
+		db.Close()
+
+		log.Info("Db closed without errors")
+	}()
+
+	log.Info("Db connection successfully")
+
+	accountRepo := accountRep.NewRepository(db, *log)
+	reportRepo := reportRep.NewRepository(db, *log)
+	anomalyRepo := anomalyRep.NewRepository(db, *log)
+	payeeRepo := payeeRep.NewRepository(db, *log)
+	userRepo := userRep.NewRepository(db, *log)
+	depositRepo := depositRep.NewRepository(db, *log)
+	investmentRepo := investmentRep.NewRepository(db, *log)
+	goalRepo := goalRep.NewRepository(db, *log)
+
+	reportUsecase := reportUsecase.NewUsecase(reportRepo, *log, userRepo, payeeRepo)
+
+	if backfill {
+		if err = reportUsecase.BackfillBalanceSnapshots(ctx); err != nil {
+			log.Errorf("balance snapshots backfill failed: %v", err)
+			return
+		}
+		if err = reportUsecase.BackfillDailyTotals(ctx); err != nil {
+			log.Errorf("daily totals backfill failed: %v", err)
+			return
+		}
+
+		log.Info("backfill done")
+		return nil
+	}
+
+	anomalyUsecase := anomalyUsecase.NewUsecase(anomalyRepo, *log)
+	payeeUsecase := payeeUsecase.NewUsecase(payeeRepo, *log)
+	depositUsecase := depositUsecase.NewUsecase(depositRepo, *log, accountRepo, anomalyUsecase)
+	investmentUsecase := investmentUsecase.NewUsecase(investmentRepo, *log, investmentPrices.NewFileSource(os.Getenv("PRICES_FILE")), accountRepo)
+	goalUsecase := goalUsecase.NewUsecase(goalRepo, *log, anomalyUsecase)
+
+	jobs := []job{
+		// today's snapshot is overwritten until the day ends
+		{name: "balance snapshots", interval: time.Hour, run: reportUsecase.TakeBalanceSnapshots},
+		{name: "anomaly detection", interval: 6 * time.Hour, run: anomalyUsecase.DetectAnomalies},
+		// links old transactions and the ones saved while payee resolving failed
+		{name: "payee backfill", interval: 24 * time.Hour, run: payeeUsecase.BackfillPayees},
+		// a missed day is caught up on the next run, posted periods are skipped
+		{name: "deposit interest", interval: 24 * time.Hour, run: depositUsecase.AccrueInterest},
+		// today's value is overwritten while the prices file is updated during the day
+		{name: "portfolio snapshots", interval: 6 * time.Hour, run: investmentUsecase.SnapshotPortfolios},
+		// scheduled transfers catch up missed dates, incomes and outcomes are handled once per rule
+		{name: "goal contributions", interval: time.Hour, run: goalUsecase.Contribute},
+	}
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j job) {
+			defer wg.Done()
+			schedule(ctx, j, log)
+		}(j)
+	}
+
+	log.Info("jobs running")
+	wg.Wait()
+	return nil
+}
+
+func schedule(ctx context.Context, j job, log *logger.Logger) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(ctx); err != nil {
+			log.Errorf("job %s failed: %v", j.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
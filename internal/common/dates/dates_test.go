@@ -0,0 +1,19 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateDay(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	assert.Equal(t, time.Date(2023, time.May, 4, 0, 0, 0, 0, time.UTC), TruncateDay(time.Date(2023, time.May, 4, 23, 30, 0, 0, moscow)))
+}
+
+func TestAddMonths(t *testing.T) {
+	assert.Equal(t, time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC), AddMonths(time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC), 1))
+	assert.Equal(t, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), AddMonths(time.Date(2023, time.December, 15, 0, 0, 0, 0, time.UTC), 1))
+	assert.Equal(t, time.Date(2023, time.November, 30, 0, 0, 0, 0, time.UTC), AddMonths(time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), -4))
+}
@@ -0,0 +1,8 @@
+package money
+
+import "math"
+
+// Round2 rounds an amount to kopecks
+func Round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -10,17 +10,46 @@ import (
 type Usecase interface {
 	CreateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) (uuid.UUID, error)
 	UpdateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) error
-	DeleteAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+	DeleteAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, confirm bool) error
+	ArchiveAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+	RestoreAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+	GetDeletePreview(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (*models.AccountDeletePreview, error)
+	GetAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (*models.Accounts, error)
+	GetAccounts(ctx context.Context, userID uuid.UUID, archived bool) ([]models.Accounts, error)
+	WatchAccounts(ctx context.Context, userID uuid.UUID, started func() error, send func(models.AccountBalance) error) error
+
+	DeleteUserInAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, adminID uuid.UUID) error
+	SetUserRole(ctx context.Context, roleInput models.SetAccountRole, adminID uuid.UUID) error
+	Unsubscribe(ctx context.Context, accountID uuid.UUID, userID uuid.UUID, successorID uuid.UUID) (uuid.UUID, error)
+	TransferOwnership(ctx context.Context, transferInput models.TransferOwnership, adminID uuid.UUID) (uuid.UUID, error)
+	GetOwnershipTransfers(ctx context.Context, userID uuid.UUID) ([]models.OwnershipTransfer, error)
+	GetOwnershipHistory(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) ([]models.OwnershipTransfer, error)
+	AcceptOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error
+	DeclineOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error
 }
 
 type Repository interface {
 	CreateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) (uuid.UUID, error)
 	UpdateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) error
 	DeleteAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+	ArchiveAccount(ctx context.Context, accountID uuid.UUID) error
+	RestoreAccount(ctx context.Context, accountID uuid.UUID) error
+	GetDeletePreview(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletePreview, error)
+	GetAccount(ctx context.Context, accountID uuid.UUID) (*models.Accounts, error)
+	GetAccounts(ctx context.Context, userID uuid.UUID, archived bool) ([]models.Accounts, error)
+	GetMembers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error)
+	GetBalances(ctx context.Context, userID uuid.UUID) ([]models.AccountBalance, error)
 	CheckForbidden(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error
-	SharingCheck(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error
-	AddUserInAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+	GetRole(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) (models.AccountRole, error)
+	IsArchived(ctx context.Context, accountID uuid.UUID) (bool, error)
+	SetUserRole(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, role models.AccountRole) error
 	Unsubscribe(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
 	DeleteUserInAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
 	CheckDuplicate(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+
+	CreateOwnershipTransfer(ctx context.Context, transfer *models.OwnershipTransfer) (uuid.UUID, error)
+	GetPendingOwnershipTransfers(ctx context.Context, userID uuid.UUID) ([]models.OwnershipTransfer, error)
+	GetOwnershipHistory(ctx context.Context, accountID uuid.UUID) ([]models.OwnershipTransfer, error)
+	AcceptOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error
+	DeclineOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error
 }
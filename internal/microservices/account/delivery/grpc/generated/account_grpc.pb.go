@@ -22,6 +22,21 @@ type AccountServiceClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
 	Update(ctx context.Context, in *UpdasteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Archive(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Restore(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeletePreview(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*DeletePreviewResponse, error)
+	Get(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*Account, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	DeleteMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetMemberRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	TransferOwnership(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	AcceptOwnership(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeclineOwnership(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	PendingOwnershipTransfers(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*OwnershipTransfers, error)
+	OwnershipHistory(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*OwnershipTransfers, error)
+	// pushes the balances of the user's accounts, all of them first and then the changed ones
+	WatchAccounts(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (AccountService_WatchAccountsClient, error)
 }
 
 type accountServiceClient struct {
@@ -59,6 +74,155 @@ func (c *accountServiceClient) Delete(ctx context.Context, in *DeleteRequest, op
 	return out, nil
 }
 
+func (c *accountServiceClient) Archive(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/account.AccountService/Archive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) Restore(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/account.AccountService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) DeletePreview(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*DeletePreviewResponse, error) {
+	out := new(DeletePreviewResponse)
+	err := c.cc.Invoke(ctx, "/account.AccountService/DeletePreview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) Get(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/account.AccountService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/account.AccountService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) DeleteMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/account.AccountService/DeleteMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) SetMemberRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/account.AccountService/SetMemberRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/account.AccountService/Unsubscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) TransferOwnership(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/account.AccountService/TransferOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) AcceptOwnership(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/account.AccountService/AcceptOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) DeclineOwnership(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/account.AccountService/DeclineOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) PendingOwnershipTransfers(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*OwnershipTransfers, error) {
+	out := new(OwnershipTransfers)
+	err := c.cc.Invoke(ctx, "/account.AccountService/PendingOwnershipTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) OwnershipHistory(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*OwnershipTransfers, error) {
+	out := new(OwnershipTransfers)
+	err := c.cc.Invoke(ctx, "/account.AccountService/OwnershipHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) WatchAccounts(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (AccountService_WatchAccountsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AccountService_ServiceDesc.Streams[0], "/account.AccountService/WatchAccounts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accountServiceWatchAccountsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AccountService_WatchAccountsClient interface {
+	Recv() (*AccountBalance, error)
+	grpc.ClientStream
+}
+
+type accountServiceWatchAccountsClient struct {
+	grpc.ClientStream
+}
+
+func (x *accountServiceWatchAccountsClient) Recv() (*AccountBalance, error) {
+	m := new(AccountBalance)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AccountServiceServer is the server API for AccountService service.
 // All implementations must embed UnimplementedAccountServiceServer
 // for forward compatibility
@@ -66,6 +230,21 @@ type AccountServiceServer interface {
 	Create(context.Context, *CreateRequest) (*CreateAccountResponse, error)
 	Update(context.Context, *UpdasteRequest) (*empty.Empty, error)
 	Delete(context.Context, *DeleteRequest) (*empty.Empty, error)
+	Archive(context.Context, *AccountRequest) (*empty.Empty, error)
+	Restore(context.Context, *AccountRequest) (*empty.Empty, error)
+	DeletePreview(context.Context, *AccountRequest) (*DeletePreviewResponse, error)
+	Get(context.Context, *AccountRequest) (*Account, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	DeleteMember(context.Context, *MemberRequest) (*empty.Empty, error)
+	SetMemberRole(context.Context, *MemberRequest) (*empty.Empty, error)
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*TransferResponse, error)
+	TransferOwnership(context.Context, *MemberRequest) (*TransferResponse, error)
+	AcceptOwnership(context.Context, *TransferRequest) (*empty.Empty, error)
+	DeclineOwnership(context.Context, *TransferRequest) (*empty.Empty, error)
+	PendingOwnershipTransfers(context.Context, *UserRequest) (*OwnershipTransfers, error)
+	OwnershipHistory(context.Context, *AccountRequest) (*OwnershipTransfers, error)
+	// pushes the balances of the user's accounts, all of them first and then the changed ones
+	WatchAccounts(*UserRequest, AccountService_WatchAccountsServer) error
 	mustEmbedUnimplementedAccountServiceServer()
 }
 
@@ -82,6 +261,48 @@ func (UnimplementedAccountServiceServer) Update(context.Context, *UpdasteRequest
 func (UnimplementedAccountServiceServer) Delete(context.Context, *DeleteRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedAccountServiceServer) Archive(context.Context, *AccountRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedAccountServiceServer) Restore(context.Context, *AccountRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedAccountServiceServer) DeletePreview(context.Context, *AccountRequest) (*DeletePreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePreview not implemented")
+}
+func (UnimplementedAccountServiceServer) Get(context.Context, *AccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAccountServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAccountServiceServer) DeleteMember(context.Context, *MemberRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMember not implemented")
+}
+func (UnimplementedAccountServiceServer) SetMemberRole(context.Context, *MemberRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemberRole not implemented")
+}
+func (UnimplementedAccountServiceServer) Unsubscribe(context.Context, *UnsubscribeRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedAccountServiceServer) TransferOwnership(context.Context, *MemberRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferOwnership not implemented")
+}
+func (UnimplementedAccountServiceServer) AcceptOwnership(context.Context, *TransferRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptOwnership not implemented")
+}
+func (UnimplementedAccountServiceServer) DeclineOwnership(context.Context, *TransferRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineOwnership not implemented")
+}
+func (UnimplementedAccountServiceServer) PendingOwnershipTransfers(context.Context, *UserRequest) (*OwnershipTransfers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PendingOwnershipTransfers not implemented")
+}
+func (UnimplementedAccountServiceServer) OwnershipHistory(context.Context, *AccountRequest) (*OwnershipTransfers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OwnershipHistory not implemented")
+}
+func (UnimplementedAccountServiceServer) WatchAccounts(*UserRequest, AccountService_WatchAccountsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAccounts not implemented")
+}
 func (UnimplementedAccountServiceServer) mustEmbedUnimplementedAccountServiceServer() {}
 
 // UnsafeAccountServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -149,6 +370,261 @@ func _AccountService_Delete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/Archive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Archive(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Restore(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_DeletePreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).DeletePreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/DeletePreview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).DeletePreview(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Get(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_DeleteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).DeleteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/DeleteMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).DeleteMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_SetMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).SetMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/SetMemberRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).SetMemberRole(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/Unsubscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_TransferOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).TransferOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/TransferOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).TransferOwnership(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_AcceptOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).AcceptOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/AcceptOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).AcceptOwnership(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_DeclineOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).DeclineOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/DeclineOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).DeclineOwnership(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_PendingOwnershipTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).PendingOwnershipTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/PendingOwnershipTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).PendingOwnershipTransfers(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_OwnershipHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).OwnershipHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/account.AccountService/OwnershipHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).OwnershipHistory(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_WatchAccounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccountServiceServer).WatchAccounts(m, &accountServiceWatchAccountsServer{stream})
+}
+
+type AccountService_WatchAccountsServer interface {
+	Send(*AccountBalance) error
+	grpc.ServerStream
+}
+
+type accountServiceWatchAccountsServer struct {
+	grpc.ServerStream
+}
+
+func (x *accountServiceWatchAccountsServer) Send(m *AccountBalance) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // AccountService_ServiceDesc is the grpc.ServiceDesc for AccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -168,7 +644,65 @@ var AccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _AccountService_Delete_Handler,
 		},
+		{
+			MethodName: "Archive",
+			Handler:    _AccountService_Archive_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _AccountService_Restore_Handler,
+		},
+		{
+			MethodName: "DeletePreview",
+			Handler:    _AccountService_DeletePreview_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _AccountService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _AccountService_List_Handler,
+		},
+		{
+			MethodName: "DeleteMember",
+			Handler:    _AccountService_DeleteMember_Handler,
+		},
+		{
+			MethodName: "SetMemberRole",
+			Handler:    _AccountService_SetMemberRole_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _AccountService_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "TransferOwnership",
+			Handler:    _AccountService_TransferOwnership_Handler,
+		},
+		{
+			MethodName: "AcceptOwnership",
+			Handler:    _AccountService_AcceptOwnership_Handler,
+		},
+		{
+			MethodName: "DeclineOwnership",
+			Handler:    _AccountService_DeclineOwnership_Handler,
+		},
+		{
+			MethodName: "PendingOwnershipTransfers",
+			Handler:    _AccountService_PendingOwnershipTransfers_Handler,
+		},
+		{
+			MethodName: "OwnershipHistory",
+			Handler:    _AccountService_OwnershipHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAccounts",
+			Handler:       _AccountService_WatchAccounts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "account.proto",
 }
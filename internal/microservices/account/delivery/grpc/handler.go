@@ -2,6 +2,12 @@ package grpc
 
 import (
 	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	proto "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/grpc/generated"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
@@ -34,6 +40,12 @@ func (a *accountGRPC) Create(ctx context.Context, in *proto.CreateRequest) (*pro
 		Accumulation:   in.Accumulation,
 		BalanceEnabled: in.BalanceEnabled,
 		MeanPayment:    in.MeanPayment,
+
+		Kind:            models.AccountKind(in.Kind),
+		CreditLimit:     in.CreditLimit,
+		GracePeriod:     int(in.GracePeriod),
+		StatementDay:    int(in.StatementDay),
+		OverdraftPolicy: models.OverdraftPolicy(in.OverdraftPolicy),
 	}
 	userID, _ := uuid.Parse(in.UserId)
 	accountID, err := a.AccountServices.CreateAccount(ctx, userID, &request)
@@ -49,6 +61,12 @@ func (a *accountGRPC) Update(ctx context.Context, in *proto.UpdasteRequest) (*em
 		Accumulation:   in.Accumulation,
 		BalanceEnabled: in.BalanceEnabled,
 		MeanPayment:    in.MeanPayment,
+
+		Kind:            models.AccountKind(in.Kind),
+		CreditLimit:     in.CreditLimit,
+		GracePeriod:     int(in.GracePeriod),
+		StatementDay:    int(in.StatementDay),
+		OverdraftPolicy: models.OverdraftPolicy(in.OverdraftPolicy),
 	}
 	userID, _ := uuid.Parse(in.UserId)
 
@@ -61,7 +79,253 @@ func (a *accountGRPC) Delete(ctx context.Context, in *proto.DeleteRequest) (*emp
 	AccountUUID, _ := uuid.Parse(in.AccountId)
 	userID, _ := uuid.Parse(in.UserId)
 
-	err := a.AccountServices.DeleteAccount(ctx, userID, AccountUUID)
+	err := a.AccountServices.DeleteAccount(ctx, userID, AccountUUID, in.Confirm)
+
+	return &empty.Empty{}, err
+}
+
+func (a *accountGRPC) Archive(ctx context.Context, in *proto.AccountRequest) (*empty.Empty, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	err := a.AccountServices.ArchiveAccount(ctx, userID, accountID)
+
+	return &empty.Empty{}, err
+}
+
+func (a *accountGRPC) Restore(ctx context.Context, in *proto.AccountRequest) (*empty.Empty, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	err := a.AccountServices.RestoreAccount(ctx, userID, accountID)
+
+	return &empty.Empty{}, err
+}
+
+func (a *accountGRPC) DeletePreview(ctx context.Context, in *proto.AccountRequest) (*proto.DeletePreviewResponse, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	preview, err := a.AccountServices.GetDeletePreview(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.DeletePreviewResponse{
+		Transactions:    int64(preview.Transactions),
+		Members:         int64(preview.Members),
+		Deposits:        int64(preview.Deposits),
+		Credits:         int64(preview.Credits),
+		DebtRepayments:  int64(preview.DebtRepayments),
+		Dividends:       int64(preview.Dividends),
+		Goals:           int64(preview.Goals),
+		GoalRules:       int64(preview.GoalRules),
+		Reconciliations: int64(preview.Reconciliations),
+		Snapshots:       int64(preview.Snapshots),
+		Invitations:     int64(preview.Invitations),
+	}, nil
+}
+
+func (a *accountGRPC) Get(ctx context.Context, in *proto.AccountRequest) (*proto.Account, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	account, err := a.AccountServices.GetAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountToProto(account), nil
+}
+
+func (a *accountGRPC) List(ctx context.Context, in *proto.ListRequest) (*proto.ListResponse, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	accounts, err := a.AccountServices.GetAccounts(ctx, userID, in.Archived)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &proto.ListResponse{Accounts: make([]*proto.Account, len(accounts))}
+	for i := range accounts {
+		response.Accounts[i] = accountToProto(&accounts[i])
+	}
+	return response, nil
+}
+
+func (a *accountGRPC) DeleteMember(ctx context.Context, in *proto.MemberRequest) (*empty.Empty, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+	memberID, _ := uuid.Parse(in.MemberId)
+
+	err := a.AccountServices.DeleteUserInAccount(ctx, memberID, accountID, userID)
+
+	return &empty.Empty{}, err
+}
+
+func (a *accountGRPC) SetMemberRole(ctx context.Context, in *proto.MemberRequest) (*empty.Empty, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+	memberID, _ := uuid.Parse(in.MemberId)
+
+	err := a.AccountServices.SetUserRole(ctx, models.SetAccountRole{
+		UserID:    memberID,
+		AccountID: accountID,
+		Role:      models.AccountRole(in.Role),
+	}, userID)
+
+	return &empty.Empty{}, err
+}
+
+func (a *accountGRPC) Unsubscribe(ctx context.Context, in *proto.UnsubscribeRequest) (*proto.TransferResponse, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+	successorID, _ := uuid.Parse(in.SuccessorId)
+
+	transferID, err := a.AccountServices.Unsubscribe(ctx, accountID, userID, successorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transferToProto(transferID), nil
+}
+
+func (a *accountGRPC) TransferOwnership(ctx context.Context, in *proto.MemberRequest) (*proto.TransferResponse, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+	memberID, _ := uuid.Parse(in.MemberId)
+
+	transferID, err := a.AccountServices.TransferOwnership(ctx, models.TransferOwnership{
+		AccountID: accountID,
+		UserID:    memberID,
+	}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transferToProto(transferID), nil
+}
+
+func (a *accountGRPC) AcceptOwnership(ctx context.Context, in *proto.TransferRequest) (*empty.Empty, error) {
+	transferID, _ := uuid.Parse(in.TransferId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	err := a.AccountServices.AcceptOwnershipTransfer(ctx, transferID, userID)
 
 	return &empty.Empty{}, err
 }
+
+func (a *accountGRPC) DeclineOwnership(ctx context.Context, in *proto.TransferRequest) (*empty.Empty, error) {
+	transferID, _ := uuid.Parse(in.TransferId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	err := a.AccountServices.DeclineOwnershipTransfer(ctx, transferID, userID)
+
+	return &empty.Empty{}, err
+}
+
+func (a *accountGRPC) PendingOwnershipTransfers(ctx context.Context, in *proto.UserRequest) (*proto.OwnershipTransfers, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	transfers, err := a.AccountServices.GetOwnershipTransfers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfersToProto(transfers), nil
+}
+
+func (a *accountGRPC) OwnershipHistory(ctx context.Context, in *proto.AccountRequest) (*proto.OwnershipTransfers, error) {
+	accountID, _ := uuid.Parse(in.AccountId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	transfers, err := a.AccountServices.GetOwnershipHistory(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfersToProto(transfers), nil
+}
+
+// WatchAccounts streams the balances of the user's accounts until the client goes away,
+// the header is sent as soon as the stream is accepted so the client knows it before the first balance
+func (a *accountGRPC) WatchAccounts(in *proto.UserRequest, stream proto.AccountService_WatchAccountsServer) error {
+	userID, _ := uuid.Parse(in.UserId)
+
+	err := a.AccountServices.WatchAccounts(stream.Context(), userID, func() error {
+		return stream.SendHeader(metadata.MD{})
+	}, func(balance models.AccountBalance) error {
+		return stream.Send(&proto.AccountBalance{
+			AccountId: balance.AccountID.String(),
+			Balance:   balance.Balance,
+		})
+	})
+
+	var errWatchLimit *models.AccountWatchLimitError
+	if errors.As(err, &errWatchLimit) {
+		return status.Error(codes.ResourceExhausted, errWatchLimit.Error())
+	}
+	return err
+}
+
+func accountToProto(account *models.Accounts) *proto.Account {
+	result := &proto.Account{
+		Id:             account.ID.String(),
+		Balance:        account.Balance,
+		Accumulation:   account.Accumulation,
+		SharingId:      account.SharingID.String(),
+		BalanceEnabled: account.BalanceEnabled,
+		MeanPayment:    account.MeanPayment,
+		Users:          make([]*proto.Member, len(account.Users)),
+
+		Kind:            string(account.Kind),
+		CreditLimit:     account.CreditLimit,
+		GracePeriod:     int32(account.GracePeriod),
+		StatementDay:    int32(account.StatementDay),
+		OverdraftPolicy: string(account.OverdraftPolicy),
+	}
+	if account.ArchivedAt != nil {
+		result.ArchivedAt = timestamppb.New(*account.ArchivedAt)
+	}
+
+	for i, user := range account.Users {
+		result.Users[i] = &proto.Member{
+			Id:        user.ID.String(),
+			Login:     user.Login,
+			AvatarUrl: user.AvatarURL.String(),
+			Role:      string(user.Role),
+		}
+	}
+	return result
+}
+
+// transferToProto leaves the transfer empty when the user left the account at once
+func transferToProto(transferID uuid.UUID) *proto.TransferResponse {
+	if transferID == uuid.Nil {
+		return &proto.TransferResponse{}
+	}
+	return &proto.TransferResponse{TransferId: transferID.String()}
+}
+
+func transfersToProto(transfers []models.OwnershipTransfer) *proto.OwnershipTransfers {
+	result := &proto.OwnershipTransfers{Transfers: make([]*proto.OwnershipTransfer, len(transfers))}
+
+	for i, transfer := range transfers {
+		result.Transfers[i] = &proto.OwnershipTransfer{
+			Id:          transfer.ID.String(),
+			AccountId:   transfer.AccountID.String(),
+			AccountName: transfer.AccountName,
+			FromUserId:  transfer.FromUserID.String(),
+			FromLogin:   transfer.FromLogin,
+			ToUserId:    transfer.ToUserID.String(),
+			ToLogin:     transfer.ToLogin,
+			Leave:       transfer.Leave,
+			Status:      transfer.Status,
+			CreatedAt:   timestamppb.New(transfer.CreatedAt),
+		}
+		if transfer.AnsweredAt != nil {
+			result.Transfers[i].AnsweredAt = timestamppb.New(*transfer.AnsweredAt)
+		}
+	}
+	return result
+}
@@ -3,14 +3,20 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	proto "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/grpc/generated"
 	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestCreateAccount(t *testing.T) {
@@ -83,7 +89,7 @@ func TestDeleteAccount(t *testing.T) {
 	mockAccountServices := mocks.NewMockUsecase(ctrl)
 
 	mockAccountServices.EXPECT().
-		DeleteAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		DeleteAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(errors.New("account not found"))
 
 	accountGRPC := NewAccountGRPC(mockAccountServices, *logger.NewLogger(context.TODO()))
@@ -92,3 +98,166 @@ func TestDeleteAccount(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestArchiveAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accountID := uuid.New()
+	userID := uuid.New()
+
+	mockAccountServices := mocks.NewMockUsecase(ctrl)
+
+	mockAccountServices.EXPECT().
+		ArchiveAccount(gomock.Any(), userID, accountID).
+		Return(nil)
+
+	accountGRPC := NewAccountGRPC(mockAccountServices, *logger.NewLogger(context.TODO()))
+
+	_, err := accountGRPC.Archive(context.Background(), &proto.AccountRequest{
+		AccountId: accountID.String(),
+		UserId:    userID.String(),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestDeletePreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accountID := uuid.New()
+
+	mockAccountServices := mocks.NewMockUsecase(ctrl)
+
+	mockAccountServices.EXPECT().
+		GetDeletePreview(gomock.Any(), gomock.Any(), accountID).
+		Return(&models.AccountDeletePreview{Transactions: 42, Members: 2, Goals: 1, Snapshots: 90}, nil)
+
+	accountGRPC := NewAccountGRPC(mockAccountServices, *logger.NewLogger(context.TODO()))
+
+	response, err := accountGRPC.DeletePreview(context.Background(), &proto.AccountRequest{AccountId: accountID.String()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), response.Transactions)
+	assert.Equal(t, int64(2), response.Members)
+	assert.Equal(t, int64(1), response.Goals)
+	assert.Equal(t, int64(90), response.Snapshots)
+
+	mockAccountServices.EXPECT().
+		GetDeletePreview(gomock.Any(), gomock.Any(), accountID).
+		Return(nil, errors.New("account not found"))
+
+	_, err = accountGRPC.DeletePreview(context.Background(), &proto.AccountRequest{AccountId: accountID.String()})
+
+	assert.Error(t, err)
+}
+
+func TestListAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userID := uuid.New()
+	archivedAt := time.Date(2023, 11, 5, 10, 0, 0, 0, time.UTC)
+
+	mockAccountServices := mocks.NewMockUsecase(ctrl)
+
+	mockAccountServices.EXPECT().
+		GetAccounts(gomock.Any(), userID, true).
+		Return([]models.Accounts{{
+			ID:          uuid.New(),
+			Balance:     12.5,
+			MeanPayment: "Old card",
+			ArchivedAt:  &archivedAt,
+			Users:       []models.SharingUser{{ID: userID, Login: "owner", Role: models.AccountOwner}},
+		}}, nil)
+
+	accountGRPC := NewAccountGRPC(mockAccountServices, *logger.NewLogger(context.TODO()))
+
+	response, err := accountGRPC.List(context.Background(), &proto.ListRequest{UserId: userID.String(), Archived: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Accounts, 1)
+	assert.Equal(t, 12.5, response.Accounts[0].Balance)
+	assert.Equal(t, archivedAt, response.Accounts[0].ArchivedAt.AsTime())
+	assert.Equal(t, "owner", response.Accounts[0].Users[0].Role)
+
+	mockAccountServices.EXPECT().
+		GetAccounts(gomock.Any(), userID, false).
+		Return(nil, errors.New("db is down"))
+
+	_, err = accountGRPC.List(context.Background(), &proto.ListRequest{UserId: userID.String()})
+
+	assert.Error(t, err)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accountID := uuid.New()
+	userID := uuid.New()
+	successorID := uuid.New()
+	transferID := uuid.New()
+
+	mockAccountServices := mocks.NewMockUsecase(ctrl)
+	accountGRPC := NewAccountGRPC(mockAccountServices, *logger.NewLogger(context.TODO()))
+
+	mockAccountServices.EXPECT().
+		Unsubscribe(gomock.Any(), accountID, userID, uuid.Nil).
+		Return(uuid.Nil, nil)
+
+	response, err := accountGRPC.Unsubscribe(context.Background(), &proto.UnsubscribeRequest{
+		AccountId: accountID.String(),
+		UserId:    userID.String(),
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, response.TransferId)
+
+	mockAccountServices.EXPECT().
+		Unsubscribe(gomock.Any(), accountID, userID, successorID).
+		Return(transferID, nil)
+
+	response, err = accountGRPC.Unsubscribe(context.Background(), &proto.UnsubscribeRequest{
+		AccountId:   accountID.String(),
+		UserId:      userID.String(),
+		SuccessorId: successorID.String(),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transferID.String(), response.TransferId)
+}
+
+func TestWatchAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userID := uuid.New()
+	balance := models.AccountBalance{AccountID: uuid.New(), Balance: 75}
+
+	mockAccountServices := mocks.NewMockUsecase(ctrl)
+	mockStream := mocks.NewMockAccountService_WatchAccountsServer(ctrl)
+
+	mockStream.EXPECT().Context().Return(context.Background())
+	mockStream.EXPECT().SendHeader(metadata.MD{}).Return(nil)
+	mockStream.EXPECT().Send(&proto.AccountBalance{AccountId: balance.AccountID.String(), Balance: 75}).Return(nil)
+	mockAccountServices.EXPECT().
+		WatchAccounts(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID uuid.UUID, started func() error, send func(models.AccountBalance) error) error {
+			if err := started(); err != nil {
+				return err
+			}
+			return send(balance)
+		})
+
+	accountGRPC := NewAccountGRPC(mockAccountServices, *logger.NewLogger(context.TODO()))
+
+	err := accountGRPC.WatchAccounts(&proto.UserRequest{UserId: userID.String()}, mockStream)
+
+	assert.NoError(t, err)
+
+	mockStream.EXPECT().Context().Return(context.Background())
+	mockAccountServices.EXPECT().
+		WatchAccounts(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("[usecase] %w", &models.AccountWatchLimitError{Limit: 3}))
+
+	err = accountGRPC.WatchAccounts(&proto.UserRequest{UserId: userID.String()}, mockStream)
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
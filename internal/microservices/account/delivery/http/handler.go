@@ -1,10 +1,18 @@
 package http
 
 import (
+	"context"
 	"errors"
-	"github.com/mailru/easyjson"
+	"fmt"
+	"io"
 	"net/http"
 
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 
@@ -14,7 +22,8 @@ import (
 )
 
 const (
-	accountID = "account_id"
+	accountID  = "account_id"
+	transferID = "transfer_id"
 )
 
 type Handler struct {
@@ -67,8 +76,20 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		Accumulation:   accountInput.Accumulation,
 		BalanceEnabled: accountInput.BalanceEnabled,
 		MeanPayment:    accountInput.MeanPayment,
+
+		Kind:            string(accountInput.Kind),
+		CreditLimit:     accountInput.CreditLimit,
+		GracePeriod:     int32(accountInput.GracePeriod),
+		StatementDay:    int32(accountInput.StatementDay),
+		OverdraftPolicy: string(accountInput.OverdraftPolicy),
 	})
 	if err != nil {
+		var errAccountOperation *models.AccountOperationError
+		if errors.As(err, &errAccountOperation) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errAccountOperation.Reason, h.logger)
+			return
+		}
+
 		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, AccountNotCreate, h.logger)
 		return
 	}
@@ -116,23 +137,14 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		Accumulation:   updateAccountInput.Accumulation,
 		BalanceEnabled: updateAccountInput.BalanceEnabled,
 		MeanPayment:    updateAccountInput.MeanPayment,
-	}); err != nil {
-		var errNoSuchaccount *models.NoSuchAccounts
-		if errors.As(err, &errNoSuchaccount) {
-			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, AccountNotSuch, h.logger)
-			return
-		}
-
-		var errForbiddenUser *models.ForbiddenUserError
-		if errors.As(err, &errForbiddenUser) {
-			commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
-			return
-		}
 
-		if err != nil {
-			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AccountCreateServerError, h.logger)
-			return
-		}
+		Kind:            string(updateAccountInput.Kind),
+		CreditLimit:     updateAccountInput.CreditLimit,
+		GracePeriod:     int32(updateAccountInput.GracePeriod),
+		StatementDay:    int32(updateAccountInput.StatementDay),
+		OverdraftPolicy: string(updateAccountInput.OverdraftPolicy),
+	}); h.accountError(w, err, AccountCreateServerError) {
+		return
 	}
 
 	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
@@ -141,8 +153,10 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 
 // @Summary		Delete Account
 // @Tags		Account
-// @Description	Delete account with chosen ID
+// @Description	Delete account with chosen ID and all its transactions, see the delete preview first
 // @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Param		confirm		query		bool	true	"Deletion confirmed"
 // @Success		200		{object}	Response[NilBody]	  	    "Account deleted"
 // @Failure		400		{object}	ResponseError				"Account error"
 // @Failure		401		{object}	ResponseError  			    "User unathorized"
@@ -163,27 +177,609 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("confirm") != "true" {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, errDeleteNotConfirmed, AccountDeleteNotConfirmed, h.logger)
+		return
+	}
+
 	_, err = h.client.Delete(r.Context(), &genAccount.DeleteRequest{
 		AccountId: accountID.String(),
 		UserId:    user.ID.String(),
+		Confirm:   true,
+	})
+	if h.accountError(w, err, AccountCreateServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Archive Account
+// @Tags		Account
+// @Description	Hide the account from the lists, its transactions stay in the history
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[NilBody]	  	    "Account archived"
+// @Failure		400		{object}	ResponseError				"Account error"
+// @Failure		401		{object}	ResponseError  			    "User unathorized"
+// @Failure		403		{object}	ResponseError				"User hasn't rights"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/account/{account_id}/archive [put]
+func (h *Handler) Archive(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	_, err = h.client.Archive(r.Context(), &genAccount.AccountRequest{
+		AccountId: accountID.String(),
+		UserId:    user.ID.String(),
+	})
+	if h.accountError(w, err, AccountArchiveServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Restore Account
+// @Tags		Account
+// @Description	Return the archived account to the lists
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[NilBody]	  	    "Account restored"
+// @Failure		400		{object}	ResponseError				"Account error"
+// @Failure		401		{object}	ResponseError  			    "User unathorized"
+// @Failure		403		{object}	ResponseError				"User hasn't rights"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/account/{account_id}/restore [put]
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	_, err = h.client.Restore(r.Context(), &genAccount.AccountRequest{
+		AccountId: accountID.String(),
+		UserId:    user.ID.String(),
+	})
+	if h.accountError(w, err, AccountRestoreServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Delete Account Preview
+// @Tags		Account
+// @Description	What the deletion of the account removes along with it
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[models.AccountDeletePreview]	"Rows to be removed"
+// @Failure		400		{object}	ResponseError							"Account error"
+// @Failure		401		{object}	ResponseError  			    			"User unathorized"
+// @Failure		403		{object}	ResponseError							"User hasn't rights"
+// @Failure		500		{object}	ResponseError							"Server error"
+// @Router		/api/account/{account_id}/delete/preview [get]
+func (h *Handler) DeletePreview(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	preview, err := h.client.DeletePreview(r.Context(), &genAccount.AccountRequest{
+		AccountId: accountID.String(),
+		UserId:    user.ID.String(),
+	})
+	if h.accountError(w, err, AccountPreviewServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, models.AccountDeletePreview{
+		Transactions:    int(preview.Transactions),
+		Members:         int(preview.Members),
+		Deposits:        int(preview.Deposits),
+		Credits:         int(preview.Credits),
+		DebtRepayments:  int(preview.DebtRepayments),
+		Dividends:       int(preview.Dividends),
+		Goals:           int(preview.Goals),
+		GoalRules:       int(preview.GoalRules),
+		Reconciliations: int(preview.Reconciliations),
+		Snapshots:       int(preview.Snapshots),
+		Invitations:     int(preview.Invitations),
+	})
+}
+
+// @Summary		PUT 	Unsibscribe in Account
+// @Tags				Account
+// @Description	A member leaves the account. The owner names a successor and leaves once the successor accepts the ownership
+// @Produce		json
+// @Param		account_id		path		string	true	"Account ID"
+// @Param		successor_id	query		string	false	"Member to hand the account over to, required for the owner"
+// @Success		200		{object}	Response[NilBody]				    "Unsibscribe in Account"
+// @Success		202		{object}	Response[OwnershipTransferResponse]	"Ownership transfer is waiting for the successor"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/unsubscribeAccount/{account_id} [put]
+func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	successorID, err := GetSuccessor(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, AccountInvalidSuccessor, h.logger)
+		return
+	}
+
+	transfer, err := h.client.Unsubscribe(r.Context(), &genAccount.UnsubscribeRequest{
+		AccountId:   accountID.String(),
+		UserId:      user.ID.String(),
+		SuccessorId: successorID.String(),
+	})
+	if h.accountError(w, err, AccountUnsubscribeServerError) {
+		return
+	}
+
+	if transfer.TransferId != "" {
+		transferID, _ := uuid.Parse(transfer.TransferId)
+		commonHttp.SuccessResponse(w, http.StatusAccepted, OwnershipTransferResponse{TransferID: transferID})
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		DELETE 	Delete user in Account
+// @Tags				Account
+// @Description	Post 	User
+// @Produce		json
+// @Param		User	body		models.DeleteInAccount		 true		    "Delete user in Account"
+// @Success		200		{object}	Response[NilBody]				    "Unsibscribe in Account"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/deleteUserInAccount [delete]
+func (h *Handler) DeleteUserInAccount(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var accountDelete models.DeleteInAccount
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &accountDelete); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	_, err = h.client.DeleteMember(r.Context(), &genAccount.MemberRequest{
+		AccountId: accountDelete.AccountID.String(),
+		UserId:    user.ID.String(),
+		MemberId:  accountDelete.UserID.String(),
+	})
+	if h.accountError(w, err, AccountMemberServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		PUT 	Set role of user in Account
+// @Tags				Account
+// @Description	Change the role of a member: editor, viewer or contributor
+// @Produce		json
+// @Param		User	body		models.SetAccountRole		 true		    "Role of user in account"
+// @Success		200		{object}	Response[NilBody]				    "Role changed"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/setUserRole [put]
+func (h *Handler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var roleInput models.SetAccountRole
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &roleInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	_, err = h.client.SetMemberRole(r.Context(), &genAccount.MemberRequest{
+		AccountId: roleInput.AccountID.String(),
+		UserId:    user.ID.String(),
+		MemberId:  roleInput.UserID.String(),
+		Role:      string(roleInput.Role),
+	})
+	if h.accountError(w, err, AccountRoleServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		PUT 	Transfer ownership of Account
+// @Tags				Account
+// @Description	The owner nominates a member, the member becomes the owner after accepting
+// @Accept 		json
+// @Produce		json
+// @Param		User	body		models.TransferOwnership		 true		    "Account and successor"
+// @Success		200		{object}	Response[OwnershipTransferResponse]	"Ownership transfer created"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/transferOwnership [put]
+func (h *Handler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var transferInput models.TransferOwnership
+
+	if err := easyjson.UnmarshalFromReader(r.Body, &transferInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	transfer, err := h.client.TransferOwnership(r.Context(), &genAccount.MemberRequest{
+		AccountId: transferInput.AccountID.String(),
+		UserId:    user.ID.String(),
+		MemberId:  transferInput.UserID.String(),
+	})
+	if h.accountError(w, err, AccountOwnershipServerError) {
+		return
+	}
+
+	transferID, _ := uuid.Parse(transfer.TransferId)
+	commonHttp.SuccessResponse(w, http.StatusOK, OwnershipTransferResponse{TransferID: transferID})
+}
+
+// @Summary		GET 	Pending ownership transfers
+// @Tags				Account
+// @Description	Accounts offered to the user, the latest first
+// @Produce		json
+// @Success		200		{object}	Response[[]models.OwnershipTransfer]	"Ownership transfers"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/ownership/pending [get]
+func (h *Handler) GetOwnershipTransfers(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	transfers, err := h.client.PendingOwnershipTransfers(r.Context(), &genAccount.UserRequest{UserId: user.ID.String()})
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AccountOwnershipServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, TransfersFromProto(transfers.Transfers))
+}
+
+// @Summary		GET 	Ownership history of Account
+// @Tags				Account
+// @Description	Every handover of the account with its status, for the members
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[[]models.OwnershipTransfer]	"Ownership history"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/account/{account_id}/ownership [get]
+func (h *Handler) GetOwnershipHistory(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	transfers, err := h.client.OwnershipHistory(r.Context(), &genAccount.AccountRequest{
+		AccountId: accountID.String(),
+		UserId:    user.ID.String(),
 	})
+	if h.accountError(w, err, AccountOwnershipServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, TransfersFromProto(transfers.Transfers))
+}
+
+// @Summary		PUT 	Accept ownership transfer
+// @Tags				Account
+// @Description	The successor becomes the owner, the former owner stays as an editor or leaves if it was asked on unsubscribe
+// @Produce		json
+// @Param		transfer_id	path		string	true	"Ownership transfer ID"
+// @Success		200		{object}	Response[NilBody]					"Ownership accepted"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/ownership/{transfer_id}/accept [put]
+func (h *Handler) AcceptOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	h.answerOwnershipTransfer(w, r, h.client.AcceptOwnership)
+}
+
+// @Summary		PUT 	Decline ownership transfer
+// @Tags				Account
+// @Description	The nominated member refuses the account
+// @Produce		json
+// @Param		transfer_id	path		string	true	"Ownership transfer ID"
+// @Success		200		{object}	Response[NilBody]					"Ownership declined"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/user/ownership/{transfer_id}/decline [put]
+func (h *Handler) DeclineOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	h.answerOwnershipTransfer(w, r, h.client.DeclineOwnership)
+}
+
+func (h *Handler) answerOwnershipTransfer(w http.ResponseWriter, r *http.Request,
+	answer func(context.Context, *genAccount.TransferRequest, ...grpc.CallOption) (*empty.Empty, error)) {
+	user, err := commonHttp.GetUserFromRequest(r)
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
 
+	transferID, err := commonHttp.GetIDFromRequest(transferID, r)
 	if err != nil {
-		var errNoSuchaccount *models.NoSuchAccounts
-		if errors.As(err, &errNoSuchaccount) {
-			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, AccountNotSuch, h.logger)
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	_, err = answer(r.Context(), &genAccount.TransferRequest{
+		TransferId: transferID.String(),
+		UserId:     user.ID.String(),
+	})
+	if h.accountError(w, err, AccountOwnershipServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// accountError writes the response for an error of an account operation, false if there is no error
+func (h *Handler) accountError(w http.ResponseWriter, err error, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	var errNoSuchaccount *models.NoSuchAccounts
+	if errors.As(err, &errNoSuchaccount) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, AccountNotSuch, h.logger)
+		return true
+	}
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return true
+	}
+
+	var errAccountOperation *models.AccountOperationError
+	if errors.As(err, &errAccountOperation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errAccountOperation.Reason, h.logger)
+		return true
+	}
+
+	commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.logger)
+	return true
+}
+
+// @Summary		Get Account
+// @Tags		Account
+// @Description	Account with its members
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[models.Accounts]	"Account"
+// @Failure		400		{object}	ResponseError				"Account error"
+// @Failure		401		{object}	ResponseError  			    "User unathorized"
+// @Failure		403		{object}	ResponseError				"User hasn't rights"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/account/{account_id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	account, err := h.client.Get(r.Context(), &genAccount.AccountRequest{
+		AccountId: accountID.String(),
+		UserId:    user.ID.String(),
+	})
+	if h.accountError(w, err, AccountGetServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, AccountFromProto(account))
+}
+
+// @Summary		Get User Accounts
+// @Tags		Account
+// @Description	Active accounts of the user with their members
+// @Produce		json
+// @Success		200		{object}	Response[AccountsResponse]	"Show actual accounts"
+// @Success		204		{object}	Response[string]			"The user has no accounts"
+// @Failure		401		{object}	ResponseError  			    "User unathorized"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/user/account/all [get]
+func (h *Handler) GetAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, ok := h.list(w, r, false)
+	if !ok {
+		return
+	}
+
+	if len(accounts) == 0 {
+		commonHttp.SuccessResponse(w, http.StatusNoContent, "")
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, AccountsResponse{Accounts: accounts})
+}
+
+// @Summary		Get Archived Accounts
+// @Tags		Account
+// @Description	Accounts hidden from the lists, they can be restored
+// @Produce		json
+// @Success		200		{object}	Response[AccountsResponse]	"Archived accounts"
+// @Failure		401		{object}	ResponseError  			    "User unathorized"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/user/account/archived [get]
+func (h *Handler) GetArchivedAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, ok := h.list(w, r, true)
+	if !ok {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, AccountsResponse{Accounts: accounts})
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request, archived bool) ([]models.Accounts, bool) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return nil, false
+	}
+
+	list, err := h.client.List(r.Context(), &genAccount.ListRequest{
+		UserId:   user.ID.String(),
+		Archived: archived,
+	})
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AccountListServerError, h.logger)
+		return nil, false
+	}
+
+	return AccountsFromProto(list.Accounts), true
+}
+
+// @Summary		Watch Accounts
+// @Tags		Account
+// @Description	Server-sent events with the balances of the user's accounts, every event is an account whose balance changed
+// @Produce		text/event-stream
+// @Success		200		{object}	models.AccountBalance		"Balance of an account"
+// @Failure		401		{object}	ResponseError  			    "User unathorized"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/account/watch [get]
+func (h *Handler) Watch(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	stream, err := h.client.WatchAccounts(r.Context(), &genAccount.UserRequest{UserId: user.ID.String()})
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AccountWatchServerError, h.logger)
+		return
+	}
+
+	// no header means the stream was refused, the reason comes with the first Recv
+	if header, _ := stream.Header(); header == nil {
+		_, err = stream.Recv()
+		if status.Code(err) == codes.ResourceExhausted {
+			commonHttp.ErrorResponse(w, http.StatusTooManyRequests, err, AccountWatchLimitError, h.logger)
 			return
 		}
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AccountWatchServerError, h.logger)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
 
-		var errForbiddenUser *models.ForbiddenUserError
-		if errors.As(err, &errForbiddenUser) {
-			commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+	flusher := http.NewResponseController(w)
+	if err := flusher.Flush(); err != nil {
+		h.logger.Errorf("[handler] can't flush account events: %v", err)
+		return
+	}
+
+	for {
+		balance, err := stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && r.Context().Err() == nil {
+				h.logger.Errorf("[handler] account events stopped: %v", err)
+			}
 			return
 		}
+
+		accountID, _ := uuid.Parse(balance.AccountId)
+		event, err := easyjson.Marshal(models.AccountBalance{AccountID: accountID, Balance: balance.Balance})
 		if err != nil {
-			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AccountCreateServerError, h.logger)
+			h.logger.Errorf("[handler] can't marshal account event: %v", err)
+			return
+		}
+
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", event); err != nil {
+			return
+		}
+		if err := flusher.Flush(); err != nil {
 			return
 		}
 	}
-	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
 }
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"errors"
 	"html"
+	"net/http"
 
 	valid "github.com/asaskevich/govalidator"
+	genAccount "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/grpc/generated"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
 )
@@ -12,20 +15,49 @@ const (
 	AccountNotCreate = "can't create account"
 	AccountNotSuch   = "can't such account"
 
-	AccountCreateServerError     = "can't get account"
-	TransactionDeleteServerError = "cat't delete transaction"
+	AccountCreateServerError      = "can't get account"
+	TransactionDeleteServerError  = "cat't delete transaction"
+	AccountArchiveServerError     = "can't archive account"
+	AccountRestoreServerError     = "can't restore account"
+	AccountPreviewServerError     = "can't get delete preview"
+	AccountDeleteNotConfirmed     = "account deletion must be confirmed"
+	AccountGetServerError         = "can't get account"
+	AccountListServerError        = "can't get accounts"
+	AccountWatchServerError       = "can't watch accounts"
+	AccountWatchLimitError        = "too many account streams"
+	AccountUnsubscribeServerError = "can't unsubscribe from account"
+	AccountMemberServerError      = "can't delete user in account"
+	AccountRoleServerError        = "can't set user role"
+	AccountOwnershipServerError   = "can't transfer ownership"
+	AccountInvalidSuccessor       = "invalid successor"
 )
 
+var errDeleteNotConfirmed = errors.New("confirm query parameter is not set")
+
 type AccountCreateResponse struct {
 	AccountID uuid.UUID `json:"account_id"`
 }
 
+type AccountsResponse struct {
+	Accounts []models.Accounts `json:"accounts"`
+}
+
+type OwnershipTransferResponse struct {
+	TransferID uuid.UUID `json:"transfer_id"`
+}
+
 //easyjson:json
 type CreateAccount struct {
 	Balance        float64 `json:"balance" valid:"-"`
 	Accumulation   bool    `json:"accumulation" valid:"-"`
 	BalanceEnabled bool    `json:"balance_enabled" valid:"-"`
 	MeanPayment    string  `json:"mean_payment" valid:"required,length(1|30)"`
+
+	Kind            models.AccountKind     `json:"kind" valid:"-"`
+	CreditLimit     float64                `json:"credit_limit" valid:"-"`
+	GracePeriod     int                    `json:"grace_period" valid:"-"`
+	StatementDay    int                    `json:"statement_day" valid:"-"`
+	OverdraftPolicy models.OverdraftPolicy `json:"overdraft_policy" valid:"-"`
 }
 
 //easyjson:json
@@ -35,6 +67,12 @@ type UpdateAccount struct {
 	Accumulation   bool      `json:"accumulation" valid:""`
 	BalanceEnabled bool      `json:"balance_enabled" valid:""`
 	MeanPayment    string    `json:"mean_payment" valid:""`
+
+	Kind            models.AccountKind     `json:"kind" valid:""`
+	CreditLimit     float64                `json:"credit_limit" valid:""`
+	GracePeriod     int                    `json:"grace_period" valid:""`
+	StatementDay    int                    `json:"statement_day" valid:""`
+	OverdraftPolicy models.OverdraftPolicy `json:"overdraft_policy" valid:""`
 }
 
 func (cr *CreateAccount) ToAccount() *models.Accounts {
@@ -43,6 +81,12 @@ func (cr *CreateAccount) ToAccount() *models.Accounts {
 		Accumulation:   cr.Accumulation,
 		BalanceEnabled: cr.BalanceEnabled,
 		MeanPayment:    cr.MeanPayment,
+
+		Kind:            cr.Kind,
+		CreditLimit:     cr.CreditLimit,
+		GracePeriod:     cr.GracePeriod,
+		StatementDay:    cr.StatementDay,
+		OverdraftPolicy: cr.OverdraftPolicy,
 	}
 }
 
@@ -53,6 +97,12 @@ func (au *UpdateAccount) ToAccount() *models.Accounts {
 		Accumulation:   au.Accumulation,
 		BalanceEnabled: au.BalanceEnabled,
 		MeanPayment:    au.MeanPayment,
+
+		Kind:            au.Kind,
+		CreditLimit:     au.CreditLimit,
+		GracePeriod:     au.GracePeriod,
+		StatementDay:    au.StatementDay,
+		OverdraftPolicy: au.OverdraftPolicy,
 	}
 }
 
@@ -70,3 +120,87 @@ func (ca *UpdateAccount) CheckValid() error {
 
 	return err
 }
+
+// GetSuccessor reads the optional successor_id the owner names to leave the account
+func GetSuccessor(r *http.Request) (uuid.UUID, error) {
+	successorStr := r.URL.Query().Get("successor_id")
+	if successorStr == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(successorStr)
+}
+
+func AccountFromProto(account *genAccount.Account) models.Accounts {
+	id, _ := uuid.Parse(account.Id)
+	sharingID, _ := uuid.Parse(account.SharingId)
+
+	result := models.Accounts{
+		ID:             id,
+		Balance:        account.Balance,
+		Accumulation:   account.Accumulation,
+		SharingID:      sharingID,
+		BalanceEnabled: account.BalanceEnabled,
+		MeanPayment:    account.MeanPayment,
+		Users:          make([]models.SharingUser, len(account.Users)),
+
+		Kind:            models.AccountKind(account.Kind),
+		CreditLimit:     account.CreditLimit,
+		GracePeriod:     int(account.GracePeriod),
+		StatementDay:    int(account.StatementDay),
+		OverdraftPolicy: models.OverdraftPolicy(account.OverdraftPolicy),
+	}
+	if account.ArchivedAt != nil {
+		archivedAt := account.ArchivedAt.AsTime()
+		result.ArchivedAt = &archivedAt
+	}
+
+	for i, user := range account.Users {
+		userID, _ := uuid.Parse(user.Id)
+		avatarURL, _ := uuid.Parse(user.AvatarUrl)
+		result.Users[i] = models.SharingUser{
+			ID:        userID,
+			Login:     user.Login,
+			AvatarURL: avatarURL,
+			Role:      models.AccountRole(user.Role),
+		}
+	}
+	return result
+}
+
+// AccountsFromProto converts the accounts listed by the account service, the feed uses it as well
+func AccountsFromProto(accounts []*genAccount.Account) []models.Accounts {
+	result := make([]models.Accounts, len(accounts))
+	for i, account := range accounts {
+		result[i] = AccountFromProto(account)
+	}
+	return result
+}
+
+func TransfersFromProto(transfers []*genAccount.OwnershipTransfer) []models.OwnershipTransfer {
+	result := make([]models.OwnershipTransfer, len(transfers))
+	for i, transfer := range transfers {
+		id, _ := uuid.Parse(transfer.Id)
+		accountID, _ := uuid.Parse(transfer.AccountId)
+		fromUserID, _ := uuid.Parse(transfer.FromUserId)
+		toUserID, _ := uuid.Parse(transfer.ToUserId)
+
+		result[i] = models.OwnershipTransfer{
+			ID:          id,
+			AccountID:   accountID,
+			AccountName: transfer.AccountName,
+			FromUserID:  fromUserID,
+			FromLogin:   transfer.FromLogin,
+			ToUserID:    toUserID,
+			ToLogin:     transfer.ToLogin,
+			Leave:       transfer.Leave,
+			Status:      transfer.Status,
+			CreatedAt:   transfer.CreatedAt.AsTime(),
+		}
+		if transfer.AnsweredAt != nil {
+			answeredAt := transfer.AnsweredAt.AsTime()
+			result[i].AnsweredAt = &answeredAt
+		}
+	}
+	return result
+}
@@ -4,6 +4,7 @@ package http
 
 import (
 	json "encoding/json"
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -48,6 +49,16 @@ func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 			out.BalanceEnabled = bool(in.Bool())
 		case "mean_payment":
 			out.MeanPayment = string(in.String())
+		case "kind":
+			out.Kind = models.AccountKind(in.String())
+		case "credit_limit":
+			out.CreditLimit = float64(in.Float64())
+		case "grace_period":
+			out.GracePeriod = int(in.Int())
+		case "statement_day":
+			out.StatementDay = int(in.Int())
+		case "overdraft_policy":
+			out.OverdraftPolicy = models.OverdraftPolicy(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -87,6 +98,31 @@ func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 		out.RawString(prefix)
 		out.String(string(in.MeanPayment))
 	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"credit_limit\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.CreditLimit))
+	}
+	{
+		const prefix string = ",\"grace_period\":"
+		out.RawString(prefix)
+		out.Int(int(in.GracePeriod))
+	}
+	{
+		const prefix string = ",\"statement_day\":"
+		out.RawString(prefix)
+		out.Int(int(in.StatementDay))
+	}
+	{
+		const prefix string = ",\"overdraft_policy\":"
+		out.RawString(prefix)
+		out.String(string(in.OverdraftPolicy))
+	}
 	out.RawByte('}')
 }
 
@@ -140,6 +176,16 @@ func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 			out.BalanceEnabled = bool(in.Bool())
 		case "mean_payment":
 			out.MeanPayment = string(in.String())
+		case "kind":
+			out.Kind = models.AccountKind(in.String())
+		case "credit_limit":
+			out.CreditLimit = float64(in.Float64())
+		case "grace_period":
+			out.GracePeriod = int(in.Int())
+		case "statement_day":
+			out.StatementDay = int(in.Int())
+		case "overdraft_policy":
+			out.OverdraftPolicy = models.OverdraftPolicy(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -174,6 +220,31 @@ func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 		out.RawString(prefix)
 		out.String(string(in.MeanPayment))
 	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"credit_limit\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.CreditLimit))
+	}
+	{
+		const prefix string = ",\"grace_period\":"
+		out.RawString(prefix)
+		out.Int(int(in.GracePeriod))
+	}
+	{
+		const prefix string = ",\"statement_day\":"
+		out.RawString(prefix)
+		out.Int(int(in.StatementDay))
+	}
+	{
+		const prefix string = ",\"overdraft_policy\":"
+		out.RawString(prefix)
+		out.String(string(in.OverdraftPolicy))
+	}
 	out.RawByte('}')
 }
 
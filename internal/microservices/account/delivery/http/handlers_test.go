@@ -3,10 +3,13 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	genAccount "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/grpc/generated"
@@ -15,7 +18,11 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -76,6 +83,36 @@ func TestHandler_CreateAccount(t *testing.T) {
 			},
 			requestPayload: `{"balance": 100, "accumulation": true, "balance_enabled": true, "meanPayment": "monthly"}`,
 		},
+		{
+			name:         "Credit card",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"account_id":"` + uuidTest.String() + `"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Create(gomock.Any(), &genAccount.CreateRequest{
+					UserId:          uuidTest.String(),
+					Balance:         0,
+					MeanPayment:     "Visa",
+					Kind:            "credit",
+					CreditLimit:     1000,
+					GracePeriod:     55,
+					StatementDay:    10,
+					OverdraftPolicy: "reject",
+				}).Return(&genAccount.CreateAccountResponse{AccountId: uuidTest.String()}, nil)
+			},
+			requestPayload: `{"mean_payment": "Visa", "kind": "credit", "credit_limit": 1000, "grace_period": 55, "statement_day": 10, "overdraft_policy": "reject"}`,
+		},
+		{
+			name:         "Wrong credit terms",
+			user:         user,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"credit limit must be positive"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "credit limit must be positive"}))
+			},
+			requestPayload: `{"mean_payment": "Visa", "kind": "credit", "statement_day": 10}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -227,6 +264,7 @@ func TestHandler_DeleteAccount(t *testing.T) {
 		accountUrl     string
 		mockUsecaseFn  func(*mocks.MockAccountServiceClient)
 		requestPayload string
+		notConfirmed   bool
 	}{
 		{
 			name:         "Successful Account Deletion",
@@ -235,10 +273,24 @@ func TestHandler_DeleteAccount(t *testing.T) {
 			expectedBody: `{"status":200,"body":{}}`,
 			accountUrl:   "account_id",
 			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
-				mockUsecase.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&emptypb.Empty{}, nil)
+				mockUsecase.EXPECT().Delete(gomock.Any(), &genAccount.DeleteRequest{
+					AccountId: uuidTest.String(),
+					UserId:    uuidTest.String(),
+					Confirm:   true,
+				}).Return(&emptypb.Empty{}, nil)
 			},
 			requestPayload: `{"accountID": "` + uuidTest.String() + `"}`,
 		},
+		{
+			name:           "Deletion is not confirmed",
+			user:           user,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"account deletion must be confirmed"}`,
+			accountUrl:     "account_id",
+			mockUsecaseFn:  func(mockUsecase *mocks.MockAccountServiceClient) {},
+			requestPayload: `{"accountID": "` + uuidTest.String() + `"}`,
+			notConfirmed:   true,
+		},
 		{
 			name:           "Unauthorized Request",
 			user:           nil,
@@ -303,8 +355,11 @@ func TestHandler_DeleteAccount(t *testing.T) {
 
 			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
 
-			url := "/api/account/delete"
-			req := httptest.NewRequest("GET", url, nil)
+			url := "/api/account/delete?confirm=true"
+			if tt.notConfirmed {
+				url = "/api/account/delete"
+			}
+			req := httptest.NewRequest("DELETE", url, nil)
 			req = mux.SetURLVars(req, map[string]string{tt.accountUrl: uuidTest.String()})
 
 			if tt.user != nil {
@@ -322,3 +377,748 @@ func TestHandler_DeleteAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ArchiveAccount(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		accountUrl    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:         "Successful Account Archive",
+			user:         user,
+			accountUrl:   "account_id",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Archive(gomock.Any(), &genAccount.AccountRequest{
+					AccountId: uuidTest.String(),
+					UserId:    uuidTest.String(),
+				}).Return(&emptypb.Empty{}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			accountUrl:   "account_id",
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+			},
+		},
+		{
+			name:         "Invalid Url Parameter",
+			user:         user,
+			accountUrl:   "account",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+			},
+		},
+		{
+			name:         "Already archived",
+			user:         user,
+			accountUrl:   "account_id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"account is already archived"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Archive(gomock.Any(), gomock.Any()).
+					Return(nil, &models.AccountOperationError{Reason: "account is already archived"})
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			accountUrl:   "account_id",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Archive(gomock.Any(), gomock.Any()).Return(nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			accountUrl:   "account_id",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't archive account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Archive(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/account/archive", nil)
+			req = mux.SetURLVars(req, map[string]string{tt.accountUrl: uuidTest.String()})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+			mockHandler.Archive(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_DeletePreview(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:         "Successful Delete Preview",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"transactions":42,"members":2,"deposits":1,"credits":0,"debt_repayments":3,"dividends":0,` +
+				`"goals":1,"goal_rules":0,"reconciliations":2,"snapshots":90,"invitations":1}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().DeletePreview(gomock.Any(), gomock.Any()).
+					Return(&genAccount.DeletePreviewResponse{Transactions: 42, Members: 2, Deposits: 1, DebtRepayments: 3,
+						Goals: 1, Reconciliations: 2, Snapshots: 90, Invitations: 1}, nil)
+			},
+		},
+		{
+			name:         "Internal server error",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get delete preview"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().DeletePreview(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/account/delete/preview", nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": uuidTest.String()})
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+			mockHandler.DeletePreview(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetAccount(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:         "Successful Account Get",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"id":"` + uuidTest.String() + `","balance":120.5,"accumulation":false,"sharing_id":"` +
+				uuidTest.String() + `","balance_enabled":true,"mean_payment":"Card","archived_at":null,"users":[{"id":"` +
+				uuidTest.String() + `","login":"owner","avatar_url":"00000000-0000-0000-0000-000000000000","role":"owner"}],` +
+				`"kind":"credit","credit_limit":1000,"grace_period":55,"statement_day":10,"overdraft_policy":"warn"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Get(gomock.Any(), &genAccount.AccountRequest{
+					AccountId: uuidTest.String(),
+					UserId:    uuidTest.String(),
+				}).Return(&genAccount.Account{
+					Id:             uuidTest.String(),
+					Balance:        120.5,
+					SharingId:      uuidTest.String(),
+					BalanceEnabled: true,
+					MeanPayment:    "Card",
+					Users: []*genAccount.Member{{
+						Id:        uuidTest.String(),
+						Login:     "owner",
+						AvatarUrl: uuid.Nil.String(),
+						Role:      "owner",
+					}},
+					Kind:            "credit",
+					CreditLimit:     1000,
+					GracePeriod:     55,
+					StatementDay:    10,
+					OverdraftPolicy: "warn",
+				}, nil)
+			},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:         "Not a member",
+			user:         user,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/account/"+uuidTest.String(), nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": uuidTest.String()})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+			mockHandler.Get(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetAccounts(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	archivedAt := time.Date(2023, 11, 5, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		user          *models.User
+		archived      bool
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:         "Successful call to GetAccounts",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"accounts":[{"id":"` + uuidTest.String() + `","balance":10,"accumulation":false,"sharing_id":"` +
+				uuidTest.String() + `","balance_enabled":false,"mean_payment":"Cash","archived_at":null,"users":[],"kind":"","credit_limit":0,"grace_period":0,"statement_day":0,"overdraft_policy":""}]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().List(gomock.Any(), &genAccount.ListRequest{UserId: uuidTest.String()}).
+					Return(&genAccount.ListResponse{Accounts: []*genAccount.Account{{
+						Id:          uuidTest.String(),
+						Balance:     10,
+						SharingId:   uuidTest.String(),
+						MeanPayment: "Cash",
+					}}}, nil)
+			},
+		},
+		{
+			name:         "No accounts found",
+			user:         user,
+			expectedCode: http.StatusNoContent,
+			expectedBody: `{"status":204,"body":""}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().List(gomock.Any(), gomock.Any()).Return(&genAccount.ListResponse{}, nil)
+			},
+		},
+		{
+			name:         "Archived accounts",
+			user:         user,
+			archived:     true,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"accounts":[{"id":"` + uuidTest.String() + `","balance":0,"accumulation":false,"sharing_id":"` +
+				uuidTest.String() + `","balance_enabled":false,"mean_payment":"Old card","archived_at":"2023-11-05T10:00:00Z","users":[],"kind":"","credit_limit":0,"grace_period":0,"statement_day":0,"overdraft_policy":""}]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().List(gomock.Any(), &genAccount.ListRequest{UserId: uuidTest.String(), Archived: true}).
+					Return(&genAccount.ListResponse{Accounts: []*genAccount.Account{{
+						Id:          uuidTest.String(),
+						SharingId:   uuidTest.String(),
+						MeanPayment: "Old card",
+						ArchivedAt:  timestamppb.New(archivedAt),
+					}}}, nil)
+			},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get accounts"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/user/account/all", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+			if tt.archived {
+				mockHandler.GetArchivedAccounts(recorder, req)
+			} else {
+				mockHandler.GetAccounts(recorder, req)
+			}
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Watch(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	accountID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAccountServiceClient(ctrl)
+	mockStream := mocks.NewMockAccountService_WatchAccountsClient(ctrl)
+
+	mockService.EXPECT().WatchAccounts(gomock.Any(), &genAccount.UserRequest{UserId: uuidTest.String()}).Return(mockStream, nil)
+	gomock.InOrder(
+		mockStream.EXPECT().Header().Return(metadata.MD{}, nil),
+		mockStream.EXPECT().Recv().Return(&genAccount.AccountBalance{AccountId: accountID.String(), Balance: 150}, nil),
+		mockStream.EXPECT().Recv().Return(&genAccount.AccountBalance{AccountId: accountID.String(), Balance: 90.5}, nil),
+		mockStream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+	req := httptest.NewRequest("GET", "/api/account/watch", nil)
+	req = req.WithContext(context.WithValue(req.Context(), models.ContextKeyUserType{}, user))
+
+	recorder := httptest.NewRecorder()
+	mockHandler.Watch(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Equal(t,
+		`data: {"account_id":"`+accountID.String()+`","balance":150}`+"\n\n"+
+			`data: {"account_id":"`+accountID.String()+`","balance":90.5}`+"\n\n",
+		recorder.Body.String())
+
+	mockService.EXPECT().WatchAccounts(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	recorder = httptest.NewRecorder()
+	mockHandler.Watch(recorder, req)
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	mockService.EXPECT().WatchAccounts(gomock.Any(), gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Header().Return(nil, nil)
+	mockStream.EXPECT().Recv().Return(nil, status.Error(codes.ResourceExhausted, "too many balance streams"))
+	recorder = httptest.NewRecorder()
+	mockHandler.Watch(recorder, req)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
+
+func TestHandler_Unsubscribe(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	successorID := uuid.New()
+	transferID := uuid.MustParse("5e2c9a71-3b8d-4f06-a1e7-9c4d2b6f8a13")
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		accountID     string
+		query         string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:          "Invalid URL parameter",
+			user:          user,
+			accountID:     uuid.Nil.String(),
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			accountID:     "account_id",
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:         "Successful Unsubscribe",
+			user:         user,
+			accountID:    "account_id",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Unsubscribe(gomock.Any(), &genAccount.UnsubscribeRequest{
+					AccountId:   uuidTest.String(),
+					UserId:      uuidTest.String(),
+					SuccessorId: uuid.Nil.String(),
+				}).Return(&genAccount.TransferResponse{}, nil)
+			},
+		},
+		{
+			name:         "Owner without successor",
+			user:         user,
+			accountID:    "account_id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"the owner must name a successor to leave the account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Unsubscribe(gomock.Any(), gomock.Any()).
+					Return(nil, &models.AccountOperationError{Reason: "the owner must name a successor to leave the account"})
+			},
+		},
+		{
+			name:         "Owner names a successor",
+			user:         user,
+			accountID:    "account_id",
+			query:        "?successor_id=" + successorID.String(),
+			expectedCode: http.StatusAccepted,
+			expectedBody: `{"status":202,"body":{"transfer_id":"5e2c9a71-3b8d-4f06-a1e7-9c4d2b6f8a13"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Unsubscribe(gomock.Any(), &genAccount.UnsubscribeRequest{
+					AccountId:   uuidTest.String(),
+					UserId:      uuidTest.String(),
+					SuccessorId: successorID.String(),
+				}).Return(&genAccount.TransferResponse{TransferId: transferID.String()}, nil)
+			},
+		},
+		{
+			name:          "Invalid successor",
+			user:          user,
+			accountID:     "account_id",
+			query:         "?successor_id=someone",
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid successor"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:         "Error unsubscribing user - Internal Server Error",
+			user:         user,
+			accountID:    "account_id",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't unsubscribe from account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().Unsubscribe(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/user/unsubscribeAccount"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{tt.accountID: uuidTest.String()})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Unsubscribe(recorder, req)
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_DeleteUserInAccount(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	accountID := uuid.New()
+	memberID := uuid.New()
+	body := `{"user_id": "` + memberID.String() + `", "account_id": "` + accountID.String() + `"}`
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		requestBody   io.Reader
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			requestBody:   strings.NewReader(body),
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:         "Successful User Deletion in Account",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().DeleteMember(gomock.Any(), &genAccount.MemberRequest{
+					AccountId: accountID.String(),
+					UserId:    uuidTest.String(),
+					MemberId:  memberID.String(),
+				}).Return(&emptypb.Empty{}, nil)
+			},
+		},
+		{
+			name:         "Owner removes himself",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"the owner can't be removed from the account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().DeleteMember(gomock.Any(), gomock.Any()).
+					Return(nil, &models.AccountOperationError{Reason: "the owner can't be removed from the account"})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't delete user in account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().DeleteMember(gomock.Any(), gomock.Any()).Return(nil, errors.New("some"))
+			},
+		},
+		{
+			name:         "Forbidden User - Forbidden",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().DeleteMember(gomock.Any(), gomock.Any()).Return(nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:          "Invalid JSON body",
+			user:          user,
+			requestBody:   strings.NewReader("invalid json data"),
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("DELETE", "/api/user/deleteUserInAccount", tt.requestBody)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.DeleteUserInAccount(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_SetUserRole(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	accountID := uuid.New()
+	memberID := uuid.New()
+	body := `{"user_id": "` + memberID.String() + `", "account_id": "` + accountID.String() + `", "role": "viewer"}`
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		requestBody   io.Reader
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockAccountServiceClient)
+	}{
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			requestBody:   strings.NewReader(body),
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+		{
+			name:         "Successful role change",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().SetMemberRole(gomock.Any(), &genAccount.MemberRequest{
+					AccountId: accountID.String(),
+					UserId:    uuidTest.String(),
+					MemberId:  memberID.String(),
+					Role:      "viewer",
+				}).Return(&emptypb.Empty{}, nil)
+			},
+		},
+		{
+			name:         "Invalid role",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"role must be editor, viewer or contributor"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().SetMemberRole(gomock.Any(), gomock.Any()).
+					Return(nil, &models.AccountOperationError{Reason: "role must be editor, viewer or contributor"})
+			},
+		},
+		{
+			name:         "Forbidden User",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().SetMemberRole(gomock.Any(), gomock.Any()).Return(nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Server error",
+			user:         user,
+			requestBody:  strings.NewReader(body),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't set user role"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {
+				mockUsecase.EXPECT().SetMemberRole(gomock.Any(), gomock.Any()).Return(nil, errors.New("some"))
+			},
+		},
+		{
+			name:          "Invalid JSON body",
+			user:          user,
+			requestBody:   strings.NewReader("invalid json data"),
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockAccountServiceClient) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockAccountServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/user/setUserRole", tt.requestBody)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.SetUserRole(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockAccountServiceClient is a mock of AccountServiceClient interface.
@@ -37,6 +38,46 @@ func (m *MockAccountServiceClient) EXPECT() *MockAccountServiceClientMockRecorde
 	return m.recorder
 }
 
+// AcceptOwnership mocks base method.
+func (m *MockAccountServiceClient) AcceptOwnership(ctx context.Context, in *__.TransferRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AcceptOwnership", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptOwnership indicates an expected call of AcceptOwnership.
+func (mr *MockAccountServiceClientMockRecorder) AcceptOwnership(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptOwnership", reflect.TypeOf((*MockAccountServiceClient)(nil).AcceptOwnership), varargs...)
+}
+
+// Archive mocks base method.
+func (m *MockAccountServiceClient) Archive(ctx context.Context, in *__.AccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Archive", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockAccountServiceClientMockRecorder) Archive(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockAccountServiceClient)(nil).Archive), varargs...)
+}
+
 // Create mocks base method.
 func (m *MockAccountServiceClient) Create(ctx context.Context, in *__.CreateRequest, opts ...grpc.CallOption) (*__.CreateAccountResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +98,26 @@ func (mr *MockAccountServiceClientMockRecorder) Create(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountServiceClient)(nil).Create), varargs...)
 }
 
+// DeclineOwnership mocks base method.
+func (m *MockAccountServiceClient) DeclineOwnership(ctx context.Context, in *__.TransferRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeclineOwnership", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineOwnership indicates an expected call of DeclineOwnership.
+func (mr *MockAccountServiceClientMockRecorder) DeclineOwnership(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineOwnership", reflect.TypeOf((*MockAccountServiceClient)(nil).DeclineOwnership), varargs...)
+}
+
 // Delete mocks base method.
 func (m *MockAccountServiceClient) Delete(ctx context.Context, in *__.DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +138,206 @@ func (mr *MockAccountServiceClientMockRecorder) Delete(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAccountServiceClient)(nil).Delete), varargs...)
 }
 
+// DeleteMember mocks base method.
+func (m *MockAccountServiceClient) DeleteMember(ctx context.Context, in *__.MemberRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteMember", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockAccountServiceClientMockRecorder) DeleteMember(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockAccountServiceClient)(nil).DeleteMember), varargs...)
+}
+
+// DeletePreview mocks base method.
+func (m *MockAccountServiceClient) DeletePreview(ctx context.Context, in *__.AccountRequest, opts ...grpc.CallOption) (*__.DeletePreviewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeletePreview", varargs...)
+	ret0, _ := ret[0].(*__.DeletePreviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePreview indicates an expected call of DeletePreview.
+func (mr *MockAccountServiceClientMockRecorder) DeletePreview(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreview", reflect.TypeOf((*MockAccountServiceClient)(nil).DeletePreview), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockAccountServiceClient) Get(ctx context.Context, in *__.AccountRequest, opts ...grpc.CallOption) (*__.Account, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(*__.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountServiceClientMockRecorder) Get(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountServiceClient)(nil).Get), varargs...)
+}
+
+// List mocks base method.
+func (m *MockAccountServiceClient) List(ctx context.Context, in *__.ListRequest, opts ...grpc.CallOption) (*__.ListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(*__.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAccountServiceClientMockRecorder) List(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAccountServiceClient)(nil).List), varargs...)
+}
+
+// OwnershipHistory mocks base method.
+func (m *MockAccountServiceClient) OwnershipHistory(ctx context.Context, in *__.AccountRequest, opts ...grpc.CallOption) (*__.OwnershipTransfers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "OwnershipHistory", varargs...)
+	ret0, _ := ret[0].(*__.OwnershipTransfers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OwnershipHistory indicates an expected call of OwnershipHistory.
+func (mr *MockAccountServiceClientMockRecorder) OwnershipHistory(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnershipHistory", reflect.TypeOf((*MockAccountServiceClient)(nil).OwnershipHistory), varargs...)
+}
+
+// PendingOwnershipTransfers mocks base method.
+func (m *MockAccountServiceClient) PendingOwnershipTransfers(ctx context.Context, in *__.UserRequest, opts ...grpc.CallOption) (*__.OwnershipTransfers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PendingOwnershipTransfers", varargs...)
+	ret0, _ := ret[0].(*__.OwnershipTransfers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingOwnershipTransfers indicates an expected call of PendingOwnershipTransfers.
+func (mr *MockAccountServiceClientMockRecorder) PendingOwnershipTransfers(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingOwnershipTransfers", reflect.TypeOf((*MockAccountServiceClient)(nil).PendingOwnershipTransfers), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockAccountServiceClient) Restore(ctx context.Context, in *__.AccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAccountServiceClientMockRecorder) Restore(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAccountServiceClient)(nil).Restore), varargs...)
+}
+
+// SetMemberRole mocks base method.
+func (m *MockAccountServiceClient) SetMemberRole(ctx context.Context, in *__.MemberRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetMemberRole", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMemberRole indicates an expected call of SetMemberRole.
+func (mr *MockAccountServiceClientMockRecorder) SetMemberRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*MockAccountServiceClient)(nil).SetMemberRole), varargs...)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockAccountServiceClient) TransferOwnership(ctx context.Context, in *__.MemberRequest, opts ...grpc.CallOption) (*__.TransferResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TransferOwnership", varargs...)
+	ret0, _ := ret[0].(*__.TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockAccountServiceClientMockRecorder) TransferOwnership(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockAccountServiceClient)(nil).TransferOwnership), varargs...)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockAccountServiceClient) Unsubscribe(ctx context.Context, in *__.UnsubscribeRequest, opts ...grpc.CallOption) (*__.TransferResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Unsubscribe", varargs...)
+	ret0, _ := ret[0].(*__.TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockAccountServiceClientMockRecorder) Unsubscribe(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockAccountServiceClient)(nil).Unsubscribe), varargs...)
+}
+
 // Update mocks base method.
 func (m *MockAccountServiceClient) Update(ctx context.Context, in *__.UpdasteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +358,149 @@ func (mr *MockAccountServiceClientMockRecorder) Update(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAccountServiceClient)(nil).Update), varargs...)
 }
 
+// WatchAccounts mocks base method.
+func (m *MockAccountServiceClient) WatchAccounts(ctx context.Context, in *__.UserRequest, opts ...grpc.CallOption) (__.AccountService_WatchAccountsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchAccounts", varargs...)
+	ret0, _ := ret[0].(__.AccountService_WatchAccountsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchAccounts indicates an expected call of WatchAccounts.
+func (mr *MockAccountServiceClientMockRecorder) WatchAccounts(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchAccounts", reflect.TypeOf((*MockAccountServiceClient)(nil).WatchAccounts), varargs...)
+}
+
+// MockAccountService_WatchAccountsClient is a mock of AccountService_WatchAccountsClient interface.
+type MockAccountService_WatchAccountsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountService_WatchAccountsClientMockRecorder
+}
+
+// MockAccountService_WatchAccountsClientMockRecorder is the mock recorder for MockAccountService_WatchAccountsClient.
+type MockAccountService_WatchAccountsClientMockRecorder struct {
+	mock *MockAccountService_WatchAccountsClient
+}
+
+// NewMockAccountService_WatchAccountsClient creates a new mock instance.
+func NewMockAccountService_WatchAccountsClient(ctrl *gomock.Controller) *MockAccountService_WatchAccountsClient {
+	mock := &MockAccountService_WatchAccountsClient{ctrl: ctrl}
+	mock.recorder = &MockAccountService_WatchAccountsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService_WatchAccountsClient) EXPECT() *MockAccountService_WatchAccountsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAccountService_WatchAccountsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAccountService_WatchAccountsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAccountService_WatchAccountsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAccountService_WatchAccountsClient) Recv() (*__.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*__.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAccountService_WatchAccountsClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAccountService_WatchAccountsClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAccountService_WatchAccountsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAccountService_WatchAccountsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAccountService_WatchAccountsClient)(nil).Trailer))
+}
+
 // MockAccountServiceServer is a mock of AccountServiceServer interface.
 type MockAccountServiceServer struct {
 	ctrl     *gomock.Controller
@@ -120,6 +524,36 @@ func (m *MockAccountServiceServer) EXPECT() *MockAccountServiceServerMockRecorde
 	return m.recorder
 }
 
+// AcceptOwnership mocks base method.
+func (m *MockAccountServiceServer) AcceptOwnership(arg0 context.Context, arg1 *__.TransferRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptOwnership", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptOwnership indicates an expected call of AcceptOwnership.
+func (mr *MockAccountServiceServerMockRecorder) AcceptOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptOwnership", reflect.TypeOf((*MockAccountServiceServer)(nil).AcceptOwnership), arg0, arg1)
+}
+
+// Archive mocks base method.
+func (m *MockAccountServiceServer) Archive(arg0 context.Context, arg1 *__.AccountRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockAccountServiceServerMockRecorder) Archive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockAccountServiceServer)(nil).Archive), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockAccountServiceServer) Create(arg0 context.Context, arg1 *__.CreateRequest) (*__.CreateAccountResponse, error) {
 	m.ctrl.T.Helper()
@@ -135,6 +569,21 @@ func (mr *MockAccountServiceServerMockRecorder) Create(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountServiceServer)(nil).Create), arg0, arg1)
 }
 
+// DeclineOwnership mocks base method.
+func (m *MockAccountServiceServer) DeclineOwnership(arg0 context.Context, arg1 *__.TransferRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineOwnership", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineOwnership indicates an expected call of DeclineOwnership.
+func (mr *MockAccountServiceServerMockRecorder) DeclineOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineOwnership", reflect.TypeOf((*MockAccountServiceServer)(nil).DeclineOwnership), arg0, arg1)
+}
+
 // Delete mocks base method.
 func (m *MockAccountServiceServer) Delete(arg0 context.Context, arg1 *__.DeleteRequest) (*empty.Empty, error) {
 	m.ctrl.T.Helper()
@@ -150,6 +599,156 @@ func (mr *MockAccountServiceServerMockRecorder) Delete(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAccountServiceServer)(nil).Delete), arg0, arg1)
 }
 
+// DeleteMember mocks base method.
+func (m *MockAccountServiceServer) DeleteMember(arg0 context.Context, arg1 *__.MemberRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockAccountServiceServerMockRecorder) DeleteMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockAccountServiceServer)(nil).DeleteMember), arg0, arg1)
+}
+
+// DeletePreview mocks base method.
+func (m *MockAccountServiceServer) DeletePreview(arg0 context.Context, arg1 *__.AccountRequest) (*__.DeletePreviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreview", arg0, arg1)
+	ret0, _ := ret[0].(*__.DeletePreviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePreview indicates an expected call of DeletePreview.
+func (mr *MockAccountServiceServerMockRecorder) DeletePreview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreview", reflect.TypeOf((*MockAccountServiceServer)(nil).DeletePreview), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockAccountServiceServer) Get(arg0 context.Context, arg1 *__.AccountRequest) (*__.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*__.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountServiceServerMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountServiceServer)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockAccountServiceServer) List(arg0 context.Context, arg1 *__.ListRequest) (*__.ListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*__.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAccountServiceServerMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAccountServiceServer)(nil).List), arg0, arg1)
+}
+
+// OwnershipHistory mocks base method.
+func (m *MockAccountServiceServer) OwnershipHistory(arg0 context.Context, arg1 *__.AccountRequest) (*__.OwnershipTransfers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnershipHistory", arg0, arg1)
+	ret0, _ := ret[0].(*__.OwnershipTransfers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OwnershipHistory indicates an expected call of OwnershipHistory.
+func (mr *MockAccountServiceServerMockRecorder) OwnershipHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnershipHistory", reflect.TypeOf((*MockAccountServiceServer)(nil).OwnershipHistory), arg0, arg1)
+}
+
+// PendingOwnershipTransfers mocks base method.
+func (m *MockAccountServiceServer) PendingOwnershipTransfers(arg0 context.Context, arg1 *__.UserRequest) (*__.OwnershipTransfers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingOwnershipTransfers", arg0, arg1)
+	ret0, _ := ret[0].(*__.OwnershipTransfers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingOwnershipTransfers indicates an expected call of PendingOwnershipTransfers.
+func (mr *MockAccountServiceServerMockRecorder) PendingOwnershipTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingOwnershipTransfers", reflect.TypeOf((*MockAccountServiceServer)(nil).PendingOwnershipTransfers), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockAccountServiceServer) Restore(arg0 context.Context, arg1 *__.AccountRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAccountServiceServerMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAccountServiceServer)(nil).Restore), arg0, arg1)
+}
+
+// SetMemberRole mocks base method.
+func (m *MockAccountServiceServer) SetMemberRole(arg0 context.Context, arg1 *__.MemberRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMemberRole indicates an expected call of SetMemberRole.
+func (mr *MockAccountServiceServerMockRecorder) SetMemberRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*MockAccountServiceServer)(nil).SetMemberRole), arg0, arg1)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockAccountServiceServer) TransferOwnership(arg0 context.Context, arg1 *__.MemberRequest) (*__.TransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", arg0, arg1)
+	ret0, _ := ret[0].(*__.TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockAccountServiceServerMockRecorder) TransferOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockAccountServiceServer)(nil).TransferOwnership), arg0, arg1)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockAccountServiceServer) Unsubscribe(arg0 context.Context, arg1 *__.UnsubscribeRequest) (*__.TransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", arg0, arg1)
+	ret0, _ := ret[0].(*__.TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockAccountServiceServerMockRecorder) Unsubscribe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockAccountServiceServer)(nil).Unsubscribe), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockAccountServiceServer) Update(arg0 context.Context, arg1 *__.UpdasteRequest) (*empty.Empty, error) {
 	m.ctrl.T.Helper()
@@ -165,6 +764,20 @@ func (mr *MockAccountServiceServerMockRecorder) Update(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAccountServiceServer)(nil).Update), arg0, arg1)
 }
 
+// WatchAccounts mocks base method.
+func (m *MockAccountServiceServer) WatchAccounts(arg0 *__.UserRequest, arg1 __.AccountService_WatchAccountsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchAccounts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchAccounts indicates an expected call of WatchAccounts.
+func (mr *MockAccountServiceServerMockRecorder) WatchAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchAccounts", reflect.TypeOf((*MockAccountServiceServer)(nil).WatchAccounts), arg0, arg1)
+}
+
 // mustEmbedUnimplementedAccountServiceServer mocks base method.
 func (m *MockAccountServiceServer) mustEmbedUnimplementedAccountServiceServer() {
 	m.ctrl.T.Helper()
@@ -211,3 +824,122 @@ func (mr *MockUnsafeAccountServiceServerMockRecorder) mustEmbedUnimplementedAcco
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAccountServiceServer", reflect.TypeOf((*MockUnsafeAccountServiceServer)(nil).mustEmbedUnimplementedAccountServiceServer))
 }
+
+// MockAccountService_WatchAccountsServer is a mock of AccountService_WatchAccountsServer interface.
+type MockAccountService_WatchAccountsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountService_WatchAccountsServerMockRecorder
+}
+
+// MockAccountService_WatchAccountsServerMockRecorder is the mock recorder for MockAccountService_WatchAccountsServer.
+type MockAccountService_WatchAccountsServerMockRecorder struct {
+	mock *MockAccountService_WatchAccountsServer
+}
+
+// NewMockAccountService_WatchAccountsServer creates a new mock instance.
+func NewMockAccountService_WatchAccountsServer(ctrl *gomock.Controller) *MockAccountService_WatchAccountsServer {
+	mock := &MockAccountService_WatchAccountsServer{ctrl: ctrl}
+	mock.recorder = &MockAccountService_WatchAccountsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService_WatchAccountsServer) EXPECT() *MockAccountService_WatchAccountsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAccountService_WatchAccountsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAccountService_WatchAccountsServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAccountService_WatchAccountsServer) Send(arg0 *__.AccountBalance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAccountService_WatchAccountsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAccountService_WatchAccountsServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAccountService_WatchAccountsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAccountService_WatchAccountsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAccountService_WatchAccountsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAccountService_WatchAccountsServer)(nil).SetTrailer), arg0)
+}
@@ -36,6 +36,34 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AcceptOwnershipTransfer mocks base method.
+func (m *MockUsecase) AcceptOwnershipTransfer(ctx context.Context, transferID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptOwnershipTransfer", ctx, transferID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptOwnershipTransfer indicates an expected call of AcceptOwnershipTransfer.
+func (mr *MockUsecaseMockRecorder) AcceptOwnershipTransfer(ctx, transferID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptOwnershipTransfer", reflect.TypeOf((*MockUsecase)(nil).AcceptOwnershipTransfer), ctx, transferID, userID)
+}
+
+// ArchiveAccount mocks base method.
+func (m *MockUsecase) ArchiveAccount(ctx context.Context, userID, accountID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveAccount", ctx, userID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveAccount indicates an expected call of ArchiveAccount.
+func (mr *MockUsecaseMockRecorder) ArchiveAccount(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveAccount", reflect.TypeOf((*MockUsecase)(nil).ArchiveAccount), ctx, userID, accountID)
+}
+
 // CreateAccount mocks base method.
 func (m *MockUsecase) CreateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -51,18 +79,179 @@ func (mr *MockUsecaseMockRecorder) CreateAccount(ctx, userID, account interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockUsecase)(nil).CreateAccount), ctx, userID, account)
 }
 
+// DeclineOwnershipTransfer mocks base method.
+func (m *MockUsecase) DeclineOwnershipTransfer(ctx context.Context, transferID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineOwnershipTransfer", ctx, transferID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineOwnershipTransfer indicates an expected call of DeclineOwnershipTransfer.
+func (mr *MockUsecaseMockRecorder) DeclineOwnershipTransfer(ctx, transferID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineOwnershipTransfer", reflect.TypeOf((*MockUsecase)(nil).DeclineOwnershipTransfer), ctx, transferID, userID)
+}
+
 // DeleteAccount mocks base method.
-func (m *MockUsecase) DeleteAccount(ctx context.Context, userID, accountID uuid.UUID) error {
+func (m *MockUsecase) DeleteAccount(ctx context.Context, userID, accountID uuid.UUID, confirm bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, accountID)
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, accountID, confirm)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockUsecaseMockRecorder) DeleteAccount(ctx, userID, accountID interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) DeleteAccount(ctx, userID, accountID, confirm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUsecase)(nil).DeleteAccount), ctx, userID, accountID, confirm)
+}
+
+// DeleteUserInAccount mocks base method.
+func (m *MockUsecase) DeleteUserInAccount(ctx context.Context, userID, accountID, adminID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserInAccount", ctx, userID, accountID, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserInAccount indicates an expected call of DeleteUserInAccount.
+func (mr *MockUsecaseMockRecorder) DeleteUserInAccount(ctx, userID, accountID, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserInAccount", reflect.TypeOf((*MockUsecase)(nil).DeleteUserInAccount), ctx, userID, accountID, adminID)
+}
+
+// GetAccount mocks base method.
+func (m *MockUsecase) GetAccount(ctx context.Context, userID, accountID uuid.UUID) (*models.Accounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, userID, accountID)
+	ret0, _ := ret[0].(*models.Accounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockUsecaseMockRecorder) GetAccount(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUsecase)(nil).GetAccount), ctx, userID, accountID)
+}
+
+// GetAccounts mocks base method.
+func (m *MockUsecase) GetAccounts(ctx context.Context, userID uuid.UUID, archived bool) ([]models.Accounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", ctx, userID, archived)
+	ret0, _ := ret[0].([]models.Accounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockUsecaseMockRecorder) GetAccounts(ctx, userID, archived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockUsecase)(nil).GetAccounts), ctx, userID, archived)
+}
+
+// GetDeletePreview mocks base method.
+func (m *MockUsecase) GetDeletePreview(ctx context.Context, userID, accountID uuid.UUID) (*models.AccountDeletePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletePreview", ctx, userID, accountID)
+	ret0, _ := ret[0].(*models.AccountDeletePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletePreview indicates an expected call of GetDeletePreview.
+func (mr *MockUsecaseMockRecorder) GetDeletePreview(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletePreview", reflect.TypeOf((*MockUsecase)(nil).GetDeletePreview), ctx, userID, accountID)
+}
+
+// GetOwnershipHistory mocks base method.
+func (m *MockUsecase) GetOwnershipHistory(ctx context.Context, accountID, userID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnershipHistory", ctx, accountID, userID)
+	ret0, _ := ret[0].([]models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnershipHistory indicates an expected call of GetOwnershipHistory.
+func (mr *MockUsecaseMockRecorder) GetOwnershipHistory(ctx, accountID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnershipHistory", reflect.TypeOf((*MockUsecase)(nil).GetOwnershipHistory), ctx, accountID, userID)
+}
+
+// GetOwnershipTransfers mocks base method.
+func (m *MockUsecase) GetOwnershipTransfers(ctx context.Context, userID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnershipTransfers", ctx, userID)
+	ret0, _ := ret[0].([]models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnershipTransfers indicates an expected call of GetOwnershipTransfers.
+func (mr *MockUsecaseMockRecorder) GetOwnershipTransfers(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnershipTransfers", reflect.TypeOf((*MockUsecase)(nil).GetOwnershipTransfers), ctx, userID)
+}
+
+// RestoreAccount mocks base method.
+func (m *MockUsecase) RestoreAccount(ctx context.Context, userID, accountID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAccount", ctx, userID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAccount indicates an expected call of RestoreAccount.
+func (mr *MockUsecaseMockRecorder) RestoreAccount(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccount", reflect.TypeOf((*MockUsecase)(nil).RestoreAccount), ctx, userID, accountID)
+}
+
+// SetUserRole mocks base method.
+func (m *MockUsecase) SetUserRole(ctx context.Context, roleInput models.SetAccountRole, adminID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, roleInput, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockUsecaseMockRecorder) SetUserRole(ctx, roleInput, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockUsecase)(nil).SetUserRole), ctx, roleInput, adminID)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockUsecase) TransferOwnership(ctx context.Context, transferInput models.TransferOwnership, adminID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", ctx, transferInput, adminID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockUsecaseMockRecorder) TransferOwnership(ctx, transferInput, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockUsecase)(nil).TransferOwnership), ctx, transferInput, adminID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockUsecase) Unsubscribe(ctx context.Context, accountID, userID, successorID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", ctx, accountID, userID, successorID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockUsecaseMockRecorder) Unsubscribe(ctx, accountID, userID, successorID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUsecase)(nil).DeleteAccount), ctx, userID, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockUsecase)(nil).Unsubscribe), ctx, accountID, userID, successorID)
 }
 
 // UpdateAccount mocks base method.
@@ -79,6 +268,20 @@ func (mr *MockUsecaseMockRecorder) UpdateAccount(ctx, userID, account interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockUsecase)(nil).UpdateAccount), ctx, userID, account)
 }
 
+// WatchAccounts mocks base method.
+func (m *MockUsecase) WatchAccounts(ctx context.Context, userID uuid.UUID, started func() error, send func(models.AccountBalance) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchAccounts", ctx, userID, started, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchAccounts indicates an expected call of WatchAccounts.
+func (mr *MockUsecaseMockRecorder) WatchAccounts(ctx, userID, started, send interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchAccounts", reflect.TypeOf((*MockUsecase)(nil).WatchAccounts), ctx, userID, started, send)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -102,18 +305,32 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// AddUserInAccount mocks base method.
-func (m *MockRepository) AddUserInAccount(ctx context.Context, userID, accountID uuid.UUID) error {
+// AcceptOwnershipTransfer mocks base method.
+func (m *MockRepository) AcceptOwnershipTransfer(ctx context.Context, transferID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptOwnershipTransfer", ctx, transferID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptOwnershipTransfer indicates an expected call of AcceptOwnershipTransfer.
+func (mr *MockRepositoryMockRecorder) AcceptOwnershipTransfer(ctx, transferID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptOwnershipTransfer", reflect.TypeOf((*MockRepository)(nil).AcceptOwnershipTransfer), ctx, transferID, userID)
+}
+
+// ArchiveAccount mocks base method.
+func (m *MockRepository) ArchiveAccount(ctx context.Context, accountID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUserInAccount", ctx, userID, accountID)
+	ret := m.ctrl.Call(m, "ArchiveAccount", ctx, accountID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddUserInAccount indicates an expected call of AddUserInAccount.
-func (mr *MockRepositoryMockRecorder) AddUserInAccount(ctx, userID, accountID interface{}) *gomock.Call {
+// ArchiveAccount indicates an expected call of ArchiveAccount.
+func (mr *MockRepositoryMockRecorder) ArchiveAccount(ctx, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserInAccount", reflect.TypeOf((*MockRepository)(nil).AddUserInAccount), ctx, userID, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveAccount", reflect.TypeOf((*MockRepository)(nil).ArchiveAccount), ctx, accountID)
 }
 
 // CheckDuplicate mocks base method.
@@ -159,6 +376,35 @@ func (mr *MockRepositoryMockRecorder) CreateAccount(ctx, userID, account interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockRepository)(nil).CreateAccount), ctx, userID, account)
 }
 
+// CreateOwnershipTransfer mocks base method.
+func (m *MockRepository) CreateOwnershipTransfer(ctx context.Context, transfer *models.OwnershipTransfer) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOwnershipTransfer", ctx, transfer)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOwnershipTransfer indicates an expected call of CreateOwnershipTransfer.
+func (mr *MockRepositoryMockRecorder) CreateOwnershipTransfer(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOwnershipTransfer", reflect.TypeOf((*MockRepository)(nil).CreateOwnershipTransfer), ctx, transfer)
+}
+
+// DeclineOwnershipTransfer mocks base method.
+func (m *MockRepository) DeclineOwnershipTransfer(ctx context.Context, transferID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineOwnershipTransfer", ctx, transferID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineOwnershipTransfer indicates an expected call of DeclineOwnershipTransfer.
+func (mr *MockRepositoryMockRecorder) DeclineOwnershipTransfer(ctx, transferID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineOwnershipTransfer", reflect.TypeOf((*MockRepository)(nil).DeclineOwnershipTransfer), ctx, transferID, userID)
+}
+
 // DeleteAccount mocks base method.
 func (m *MockRepository) DeleteAccount(ctx context.Context, userID, accountID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -187,18 +433,167 @@ func (mr *MockRepositoryMockRecorder) DeleteUserInAccount(ctx, userID, accountID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserInAccount", reflect.TypeOf((*MockRepository)(nil).DeleteUserInAccount), ctx, userID, accountID)
 }
 
-// SharingCheck mocks base method.
-func (m *MockRepository) SharingCheck(ctx context.Context, accountID, userID uuid.UUID) error {
+// GetAccount mocks base method.
+func (m *MockRepository) GetAccount(ctx context.Context, accountID uuid.UUID) (*models.Accounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, accountID)
+	ret0, _ := ret[0].(*models.Accounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockRepositoryMockRecorder) GetAccount(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockRepository)(nil).GetAccount), ctx, accountID)
+}
+
+// GetAccounts mocks base method.
+func (m *MockRepository) GetAccounts(ctx context.Context, userID uuid.UUID, archived bool) ([]models.Accounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", ctx, userID, archived)
+	ret0, _ := ret[0].([]models.Accounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockRepositoryMockRecorder) GetAccounts(ctx, userID, archived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockRepository)(nil).GetAccounts), ctx, userID, archived)
+}
+
+// GetBalances mocks base method.
+func (m *MockRepository) GetBalances(ctx context.Context, userID uuid.UUID) ([]models.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", ctx, userID)
+	ret0, _ := ret[0].([]models.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockRepositoryMockRecorder) GetBalances(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockRepository)(nil).GetBalances), ctx, userID)
+}
+
+// GetDeletePreview mocks base method.
+func (m *MockRepository) GetDeletePreview(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletePreview", ctx, accountID)
+	ret0, _ := ret[0].(*models.AccountDeletePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletePreview indicates an expected call of GetDeletePreview.
+func (mr *MockRepositoryMockRecorder) GetDeletePreview(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletePreview", reflect.TypeOf((*MockRepository)(nil).GetDeletePreview), ctx, accountID)
+}
+
+// GetMembers mocks base method.
+func (m *MockRepository) GetMembers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, accountID)
+	ret0, _ := ret[0].([]models.SharingUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockRepositoryMockRecorder) GetMembers(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockRepository)(nil).GetMembers), ctx, accountID)
+}
+
+// GetOwnershipHistory mocks base method.
+func (m *MockRepository) GetOwnershipHistory(ctx context.Context, accountID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnershipHistory", ctx, accountID)
+	ret0, _ := ret[0].([]models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnershipHistory indicates an expected call of GetOwnershipHistory.
+func (mr *MockRepositoryMockRecorder) GetOwnershipHistory(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnershipHistory", reflect.TypeOf((*MockRepository)(nil).GetOwnershipHistory), ctx, accountID)
+}
+
+// GetPendingOwnershipTransfers mocks base method.
+func (m *MockRepository) GetPendingOwnershipTransfers(ctx context.Context, userID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingOwnershipTransfers", ctx, userID)
+	ret0, _ := ret[0].([]models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingOwnershipTransfers indicates an expected call of GetPendingOwnershipTransfers.
+func (mr *MockRepositoryMockRecorder) GetPendingOwnershipTransfers(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingOwnershipTransfers", reflect.TypeOf((*MockRepository)(nil).GetPendingOwnershipTransfers), ctx, userID)
+}
+
+// GetRole mocks base method.
+func (m *MockRepository) GetRole(ctx context.Context, accountID, userID uuid.UUID) (models.AccountRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, accountID, userID)
+	ret0, _ := ret[0].(models.AccountRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockRepositoryMockRecorder) GetRole(ctx, accountID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRepository)(nil).GetRole), ctx, accountID, userID)
+}
+
+// IsArchived mocks base method.
+func (m *MockRepository) IsArchived(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsArchived", ctx, accountID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsArchived indicates an expected call of IsArchived.
+func (mr *MockRepositoryMockRecorder) IsArchived(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArchived", reflect.TypeOf((*MockRepository)(nil).IsArchived), ctx, accountID)
+}
+
+// RestoreAccount mocks base method.
+func (m *MockRepository) RestoreAccount(ctx context.Context, accountID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAccount", ctx, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAccount indicates an expected call of RestoreAccount.
+func (mr *MockRepositoryMockRecorder) RestoreAccount(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccount", reflect.TypeOf((*MockRepository)(nil).RestoreAccount), ctx, accountID)
+}
+
+// SetUserRole mocks base method.
+func (m *MockRepository) SetUserRole(ctx context.Context, userID, accountID uuid.UUID, role models.AccountRole) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SharingCheck", ctx, accountID, userID)
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, userID, accountID, role)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SharingCheck indicates an expected call of SharingCheck.
-func (mr *MockRepositoryMockRecorder) SharingCheck(ctx, accountID, userID interface{}) *gomock.Call {
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockRepositoryMockRecorder) SetUserRole(ctx, userID, accountID, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharingCheck", reflect.TypeOf((*MockRepository)(nil).SharingCheck), ctx, accountID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockRepository)(nil).SetUserRole), ctx, userID, accountID, role)
 }
 
 // Unsubscribe mocks base method.
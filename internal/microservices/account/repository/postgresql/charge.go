@@ -0,0 +1,57 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// locks the account so the policy is checked against the balance the charge is taken from
+	AccountChargeLock = `SELECT id, balance, kind, credit_limit, overdraft_policy
+						 FROM Accounts WHERE id = $1
+						 FOR UPDATE;`
+
+	AccountCharge = "UPDATE Accounts SET balance = balance - $1 WHERE id = $2;"
+)
+
+// ChargeAccount takes the amount from the account within tx by its overdraft policy, every writer
+// of balances goes through it. A negative amount is put on the account. Over the available funds
+// reject fails with OverdraftError and leaves the balance, warn charges and returns the overdraft
+// for the anomaly service, allow charges silently
+func ChargeAccount(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, amount float64) (*models.Overdraft, error) {
+	var account models.Accounts
+	err := tx.QueryRow(ctx, AccountChargeLock, accountID).Scan(
+		&account.ID,
+		&account.Balance,
+		&account.Kind,
+		&account.CreditLimit,
+		&account.OverdraftPolicy,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchAccounts{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to lock account: %w", err)
+	}
+
+	var overdraft *models.Overdraft
+	if available := account.Available(); amount > 0 && amount > available {
+		switch account.OverdraftPolicy {
+		case models.OverdraftReject:
+			return nil, fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: account.ID, Available: available})
+		case models.OverdraftWarn:
+			overdraft = &models.Overdraft{AccountID: account.ID, Amount: amount, Available: available}
+		}
+	}
+
+	if _, err := tx.Exec(ctx, AccountCharge, amount, accountID); err != nil {
+		return nil, fmt.Errorf("[repo] failed to update account balance: %w", err)
+	}
+
+	return overdraft, nil
+}
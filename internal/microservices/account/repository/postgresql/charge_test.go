@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChargeAccount(t *testing.T) {
+	accountID := uuid.New()
+	columns := []string{"id", "balance", "kind", "credit_limit", "overdraft_policy"}
+
+	testCases := []struct {
+		name              string
+		amount            float64
+		rows              *pgxmock.Rows
+		rowsErr           error
+		charged           bool
+		execErr           error
+		expectedOverdraft *models.Overdraft
+		expectedErr       error
+	}{
+		{
+			name:    "Enough funds",
+			amount:  100,
+			rows:    pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountDebit, 0.0, models.OverdraftReject),
+			charged: true,
+		},
+		{
+			name:    "Within the credit limit",
+			amount:  700,
+			rows:    pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountCredit, 300.0, models.OverdraftReject),
+			charged: true,
+		},
+		{
+			name:        "Rejected",
+			amount:      700,
+			rows:        pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountDebit, 0.0, models.OverdraftReject),
+			expectedErr: fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: accountID, Available: 500}),
+		},
+		{
+			name:        "Credit limit exceeded",
+			amount:      900,
+			rows:        pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountCredit, 300.0, models.OverdraftReject),
+			expectedErr: fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: accountID, Available: 800}),
+		},
+		{
+			name:              "Warned",
+			amount:            700,
+			rows:              pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountDebit, 0.0, models.OverdraftWarn),
+			charged:           true,
+			expectedOverdraft: &models.Overdraft{AccountID: accountID, Amount: 700, Available: 500},
+		},
+		{
+			name:    "Allowed",
+			amount:  700,
+			rows:    pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountDebit, 0.0, models.OverdraftAllow),
+			charged: true,
+		},
+		{
+			name:    "Credited",
+			amount:  -700,
+			rows:    pgxmock.NewRows(columns).AddRow(accountID, -500.0, models.AccountDebit, 0.0, models.OverdraftReject),
+			charged: true,
+		},
+		{
+			name:        "No such account",
+			amount:      100,
+			rows:        pgxmock.NewRows(columns),
+			rowsErr:     pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchAccounts{}),
+		},
+		{
+			name:        "Update error",
+			amount:      100,
+			rows:        pgxmock.NewRows(columns).AddRow(accountID, 500.0, models.AccountDebit, 0.0, models.OverdraftReject),
+			charged:     true,
+			execErr:     errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to update account balance: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(AccountChargeLock)).
+				WithArgs(accountID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsErr)
+			if tc.charged {
+				charge := mock.ExpectExec(regexp.QuoteMeta(AccountCharge)).WithArgs(tc.amount, accountID)
+				if tc.execErr != nil {
+					charge.WillReturnError(tc.execErr)
+				} else {
+					charge.WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				}
+			}
+
+			tx, _ := mock.Begin(context.Background())
+			overdraft, err := ChargeAccount(context.Background(), tx, accountID, tc.amount)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expectedOverdraft, overdraft)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
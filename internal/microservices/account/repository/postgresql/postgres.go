@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
@@ -12,18 +13,89 @@ import (
 )
 
 const (
-	AccountGetUserByID = ` SELECT * FROM UserAccount 
-    						WHERE account_id = $1 AND user_id = $2;`
+	AccountGetUserByID = `SELECT EXISTS (SELECT 1 FROM UserAccount
+							WHERE account_id = $1 AND user_id = $2);`
 
-	AccountSharingCheck       = `SELECT COUNT(*) FROM accounts WHERE sharing_id = $1 AND id = $2;`
-	AccountUpdate             = "UPDATE accounts SET balance = $1, accumulation = $2, balance_enabled = $3, mean_payment = $4 WHERE id = $5;"
+	AccountRoleGet            = `SELECT role FROM UserAccount WHERE account_id = $1 AND user_id = $2;`
+	AccountArchivedGet        = `SELECT archived_at IS NOT NULL FROM Accounts WHERE id = $1;`
+	AccountRoleUpdate         = `UPDATE UserAccount SET role = $3 WHERE account_id = $1 AND user_id = $2 AND role <> 'owner';`
+	AccountUpdate             = "UPDATE accounts SET opening_balance = opening_balance + $1 - balance, balance = $1, accumulation = $2, balance_enabled = $3, mean_payment = $4, kind = $6, credit_limit = $7, grace_period = $8, statement_day = $9, overdraft_policy = $10 WHERE id = $5;"
 	AccountDelete             = "DELETE FROM accounts WHERE id = $1;"
 	UserAccountDelete         = "DELETE FROM userAccount WHERE account_id = $1;"
-	AccountCreate             = "INSERT INTO accounts (balance, accumulation, balance_enabled, mean_payment, sharing_id) VALUES ($1, $2, $3, $4, $5) RETURNING id;"
-	AccountUserCreate         = "INSERT INTO userAccount (user_id, account_id) VALUES ($1, $2);"
+	AccountCreate             = "INSERT INTO accounts (balance, opening_balance, accumulation, balance_enabled, mean_payment, sharing_id, kind, credit_limit, grace_period, statement_day, overdraft_policy) VALUES ($1, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id;"
+	AccountOwnerCreate        = "INSERT INTO userAccount (user_id, account_id, role) VALUES ($1, $2, 'owner');"
 	TransactionCategoryDelete = "DELETE FROM TransactionCategory WHERE transaction_id IN (SELECT id FROM Transaction WHERE account_income = $1 OR account_outcome = $1)"
 	AccountTransactionDelete  = "DELETE FROM Transaction WHERE account_income = $1 OR account_outcome = $1"
 	Unsubscribe               = "DELETE FROM userAccount WHERE account_id = $1 AND user_id = $2"
+
+	accountSelect = `SELECT a.id, a.balance, a.sharing_id, a.accumulation, a.balance_enabled, a.mean_payment, a.archived_at,
+							a.kind, a.credit_limit, a.grace_period, a.statement_day, a.overdraft_policy
+					 FROM Accounts a`
+
+	AccountGet = accountSelect + `
+					 WHERE a.id = $1;`
+	AccountsGet = accountSelect + `
+					 JOIN UserAccount ua ON a.id = ua.account_id
+					 WHERE ua.user_id = $1 AND a.archived_at IS NULL;`
+	AccountsGetArchived = accountSelect + `
+					 JOIN UserAccount ua ON a.id = ua.account_id
+					 WHERE ua.user_id = $1 AND a.archived_at IS NOT NULL
+					 ORDER BY a.archived_at DESC;`
+
+	AccountMembersGet = `SELECT u.id, u.login, u.avatar_url, ua.role
+						 FROM Users u
+						 JOIN UserAccount ua ON u.id = ua.user_id
+						 WHERE ua.account_id = $1;`
+
+	AccountBalancesGet = `SELECT a.id, a.balance
+						  FROM Accounts a
+						  JOIN UserAccount ua ON a.id = ua.account_id
+						  WHERE ua.user_id = $1 AND a.archived_at IS NULL;`
+
+	AccountArchive = "UPDATE accounts SET archived_at = now() WHERE id = $1 AND archived_at IS NULL;"
+	AccountRestore = "UPDATE accounts SET archived_at = NULL WHERE id = $1 AND archived_at IS NOT NULL;"
+
+	AccountDeletePreview = `SELECT (SELECT COUNT(*) FROM Transaction WHERE account_income = $1 OR account_outcome = $1),
+								   (SELECT COUNT(*) FROM UserAccount WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM Deposit WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM Credit WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM DebtRepayment WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM InvestmentDividend WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM GoalAccount WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM GoalRule WHERE source_id = $1 OR account_id = $1),
+								   (SELECT COUNT(*) FROM Reconciliation WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM AccountBalanceSnapshot WHERE account_id = $1),
+								   (SELECT COUNT(*) FROM AccountInvitation WHERE account_id = $1);`
+
+	// a new nomination replaces the pending one
+	OwnershipTransferCancel = `UPDATE AccountOwnershipTransfer SET status = 'cancelled', answered_at = now()
+							   WHERE account_id = $1 AND status = 'pending';`
+	OwnershipTransferCreate = `INSERT INTO AccountOwnershipTransfer (account_id, from_user_id, to_user_id, leave)
+							   VALUES ($1, $2, $3, $4) RETURNING id;`
+
+	ownershipTransferSelect = `SELECT t.id, t.account_id, COALESCE(a.mean_payment, ''), t.from_user_id, owner.login,
+									  t.to_user_id, successor.login, t.leave, t.status, t.created_at, t.answered_at
+							   FROM AccountOwnershipTransfer t
+							   JOIN Accounts a ON a.id = t.account_id
+							   JOIN Users owner ON owner.id = t.from_user_id
+							   JOIN Users successor ON successor.id = t.to_user_id`
+
+	OwnershipTransfersPendingGet = ownershipTransferSelect + `
+							   WHERE t.to_user_id = $1 AND t.status = 'pending'
+							   ORDER BY t.created_at DESC;`
+	OwnershipHistoryGet = ownershipTransferSelect + `
+							   WHERE t.account_id = $1
+							   ORDER BY t.created_at DESC;`
+
+	OwnershipTransferAccept = `UPDATE AccountOwnershipTransfer SET status = 'accepted', answered_at = now()
+							   WHERE id = $1 AND to_user_id = $2 AND status = 'pending'
+							   RETURNING account_id, from_user_id, leave;`
+	OwnershipTransferDecline = `UPDATE AccountOwnershipTransfer SET status = 'declined', answered_at = now()
+								WHERE id = $1 AND to_user_id = $2 AND status = 'pending';`
+
+	AccountOwnerStepDown = `UPDATE UserAccount SET role = 'editor' WHERE account_id = $1 AND user_id = $2 AND role = 'owner';`
+	AccountOwnerSet      = `UPDATE UserAccount SET role = 'owner' WHERE account_id = $1 AND user_id = $2;`
+	AccountSharingUpdate = `UPDATE Accounts SET sharing_id = $2 WHERE id = $1;`
 )
 
 type AccountRep struct {
@@ -38,18 +110,170 @@ func NewRepository(db postgresql.DbConn, log logger.Logger) *AccountRep {
 	}
 }
 
-func (r *AccountRep) SharingCheck(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error {
-	var count int
-	row := r.db.QueryRow(ctx, AccountSharingCheck, userID, accountID)
+// GetRole returns the role of the member, a user out of the account has no rights
+func (r *AccountRep) GetRole(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) (models.AccountRole, error) {
+	var role models.AccountRole
+	row := r.db.QueryRow(ctx, AccountRoleGet, accountID, userID)
+
+	err := row.Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("[repo] failed %w", &models.ForbiddenUserError{})
+	}
+	if err != nil {
+		return "", fmt.Errorf("[repo] failed to get role: %w", err)
+	}
+
+	return role, nil
+}
+
+// IsArchived tells if the account is archived, an archived account takes no transactions
+func (r *AccountRep) IsArchived(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	var archived bool
+	err := r.db.QueryRow(ctx, AccountArchivedGet, accountID).Scan(&archived)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("[repo] %w", &models.NoSuchAccounts{})
+	}
+	if err != nil {
+		return false, fmt.Errorf("[repo] failed to get archived: %w", err)
+	}
+
+	return archived, nil
+}
+
+// GetAccount returns the account with its members
+func (r *AccountRep) GetAccount(ctx context.Context, accountID uuid.UUID) (*models.Accounts, error) {
+	account, err := scanAccount(r.db.QueryRow(ctx, AccountGet, accountID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchAccounts{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	account.Users, err = r.GetMembers(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// GetAccounts returns the accounts of the user with their members, the archived ones
+// the latest archived first
+func (r *AccountRep) GetAccounts(ctx context.Context, userID uuid.UUID, archived bool) ([]models.Accounts, error) {
+	query := AccountsGet
+	if archived {
+		query = AccountsGetArchived
+	}
+
+	accounts := []models.Accounts{}
 
-	err := row.Scan(&count)
+	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
-		return fmt.Errorf("[repo] failed %w, %v", &models.ForbiddenUserError{}, err)
+		return nil, fmt.Errorf("[repo] %w", err)
 	}
-	if count == 0 {
-		return fmt.Errorf("[repo] failed %w", &models.ForbiddenUserError{})
+	defer rows.Close()
+
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
 	}
 
+	for i := range accounts {
+		accounts[i].Users, err = r.GetMembers(ctx, accounts[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return accounts, nil
+}
+
+// GetMembers returns the members of the account with their roles
+func (r *AccountRep) GetMembers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error) {
+	members := []models.SharingUser{}
+
+	rows, err := r.db.Query(ctx, AccountMembersGet, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member models.SharingUser
+		if err := rows.Scan(&member.ID, &member.Login, &member.AvatarURL, &member.Role); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return members, nil
+}
+
+// GetBalances returns the balances of the active accounts of the user
+func (r *AccountRep) GetBalances(ctx context.Context, userID uuid.UUID) ([]models.AccountBalance, error) {
+	balances := []models.AccountBalance{}
+
+	rows, err := r.db.Query(ctx, AccountBalancesGet, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var balance models.AccountBalance
+		if err := rows.Scan(&balance.AccountID, &balance.Balance); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		balances = append(balances, balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return balances, nil
+}
+
+func scanAccount(row pgx.Row) (models.Accounts, error) {
+	var account models.Accounts
+	err := row.Scan(
+		&account.ID,
+		&account.Balance,
+		&account.SharingID,
+		&account.Accumulation,
+		&account.BalanceEnabled,
+		&account.MeanPayment,
+		&account.ArchivedAt,
+		&account.Kind,
+		&account.CreditLimit,
+		&account.GracePeriod,
+		&account.StatementDay,
+		&account.OverdraftPolicy,
+	)
+	return account, err
+}
+
+// SetUserRole changes the role of the member, the owner keeps the role
+func (r *AccountRep) SetUserRole(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, role models.AccountRole) error {
+	tag, err := r.db.Exec(ctx, AccountRoleUpdate, accountID, userID, role)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to update role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "user is not a member of the account or is its owner"})
+	}
 	return nil
 }
 
@@ -70,18 +294,15 @@ func (r *AccountRep) DeleteUserInAccount(ctx context.Context, userID uuid.UUID,
 }
 
 func (r *AccountRep) CheckDuplicate(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error {
-	row := r.db.QueryRow(ctx, AccountGetUserByID, accountID, userID)
-
-	err := row.Scan(&userID, &accountID)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil
-		} else {
-			return fmt.Errorf("[repo] query error: %w", err)
-		}
+	var exists bool
+	if err := r.db.QueryRow(ctx, AccountGetUserByID, accountID, userID).Scan(&exists); err != nil {
+		return fmt.Errorf("[repo] query error: %w", err)
 	}
 
-	return &models.DuplicateError{}
+	if exists {
+		return &models.DuplicateError{}
+	}
+	return nil
 }
 
 func (r *AccountRep) CheckForbidden(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error {
@@ -109,7 +330,8 @@ func (r *AccountRep) CreateAccount(ctx context.Context, userID uuid.UUID, accoun
 		}
 	}()
 
-	row := tx.QueryRow(ctx, AccountCreate, account.Balance, account.Accumulation, account.BalanceEnabled, account.MeanPayment, userID)
+	row := tx.QueryRow(ctx, AccountCreate, account.Balance, account.Accumulation, account.BalanceEnabled, account.MeanPayment, userID,
+		account.Kind, account.CreditLimit, account.GracePeriod, account.StatementDay, account.OverdraftPolicy)
 	var id uuid.UUID
 
 	err = row.Scan(&id)
@@ -117,9 +339,9 @@ func (r *AccountRep) CreateAccount(ctx context.Context, userID uuid.UUID, accoun
 		return id, fmt.Errorf("[repo] error request %s, %w", AccountCreate, err)
 	}
 
-	_, err = tx.Exec(ctx, AccountUserCreate, userID, id)
+	_, err = tx.Exec(ctx, AccountOwnerCreate, userID, id)
 	if err != nil {
-		return id, fmt.Errorf("[repo] can't create accountUser %s, %w", AccountUserCreate, err)
+		return id, fmt.Errorf("[repo] can't create accountUser %s, %w", AccountOwnerCreate, err)
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -129,16 +351,9 @@ func (r *AccountRep) CreateAccount(ctx context.Context, userID uuid.UUID, accoun
 	return id, nil
 }
 
-func (r *AccountRep) AddUserInAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error {
-	_, err := r.db.Exec(ctx, AccountUserCreate, userID, accountID)
-	if err != nil {
-		return fmt.Errorf("[repo] can't create accountUser %s, %w", AccountUserCreate, err)
-	}
-	return nil
-}
-
 func (r *AccountRep) UpdateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) error {
-	_, err := r.db.Exec(ctx, AccountUpdate, account.Balance, account.Accumulation, account.BalanceEnabled, account.MeanPayment, account.ID)
+	_, err := r.db.Exec(ctx, AccountUpdate, account.Balance, account.Accumulation, account.BalanceEnabled, account.MeanPayment, account.ID,
+		account.Kind, account.CreditLimit, account.GracePeriod, account.StatementDay, account.OverdraftPolicy)
 	if err != nil {
 		return fmt.Errorf("[repo] failed update account %w", err)
 	}
@@ -187,3 +402,199 @@ func (r *AccountRep) DeleteAccount(ctx context.Context, userID uuid.UUID, accoun
 
 	return nil
 }
+
+// ArchiveAccount hides the account from the lists, its transactions stay in the history
+func (r *AccountRep) ArchiveAccount(ctx context.Context, accountID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, AccountArchive, accountID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to archive account: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "account is already archived"})
+	}
+	return nil
+}
+
+func (r *AccountRep) RestoreAccount(ctx context.Context, accountID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, AccountRestore, accountID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to restore account: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "account is not archived"})
+	}
+	return nil
+}
+
+// GetDeletePreview counts the rows DeleteAccount removes with the account
+func (r *AccountRep) GetDeletePreview(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletePreview, error) {
+	var preview models.AccountDeletePreview
+
+	err := r.db.QueryRow(ctx, AccountDeletePreview, accountID).Scan(
+		&preview.Transactions,
+		&preview.Members,
+		&preview.Deposits,
+		&preview.Credits,
+		&preview.DebtRepayments,
+		&preview.Dividends,
+		&preview.Goals,
+		&preview.GoalRules,
+		&preview.Reconciliations,
+		&preview.Snapshots,
+		&preview.Invitations,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to count account rows: %w", err)
+	}
+
+	return &preview, nil
+}
+
+// CreateOwnershipTransfer nominates a successor, the pending nomination on the account is cancelled
+func (r *AccountRep) CreateOwnershipTransfer(ctx context.Context, transfer *models.OwnershipTransfer) (uuid.UUID, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, OwnershipTransferCancel, transfer.AccountID); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to cancel ownership transfer: %w", err)
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, OwnershipTransferCreate, transfer.AccountID, transfer.FromUserID, transfer.ToUserID, transfer.Leave).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create ownership transfer: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *AccountRep) GetPendingOwnershipTransfers(ctx context.Context, userID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	return r.getOwnershipTransfers(ctx, OwnershipTransfersPendingGet, userID)
+}
+
+func (r *AccountRep) GetOwnershipHistory(ctx context.Context, accountID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	return r.getOwnershipTransfers(ctx, OwnershipHistoryGet, accountID)
+}
+
+// AcceptOwnershipTransfer makes the successor the owner and the former owner an editor,
+// the former owner leaves the account if the transfer was asked on unsubscribe
+func (r *AccountRep) AcceptOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	var (
+		accountID uuid.UUID
+		ownerID   uuid.UUID
+		leave     bool
+	)
+	err = tx.QueryRow(ctx, OwnershipTransferAccept, transferID, userID).Scan(&accountID, &ownerID, &leave)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "no pending ownership transfer"})
+	}
+	if err != nil {
+		return fmt.Errorf("[repo] failed to accept ownership transfer: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, AccountOwnerStepDown, accountID, ownerID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to update role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = &models.AccountOperationError{Reason: "the nominating user is no longer the owner"}
+		return fmt.Errorf("[repo] %w", err)
+	}
+
+	tag, err = tx.Exec(ctx, AccountOwnerSet, accountID, userID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to update role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = &models.AccountOperationError{Reason: "the successor is no longer a member of the account"}
+		return fmt.Errorf("[repo] %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, AccountSharingUpdate, accountID, userID); err != nil {
+		return fmt.Errorf("[repo] failed to update account owner: %w", err)
+	}
+
+	if leave {
+		if _, err = tx.Exec(ctx, Unsubscribe, accountID, ownerID); err != nil {
+			return fmt.Errorf("[repo] failed to delete from UserAccount table: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccountRep) DeclineOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, OwnershipTransferDecline, transferID, userID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to decline ownership transfer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "no pending ownership transfer"})
+	}
+	return nil
+}
+
+func (r *AccountRep) getOwnershipTransfers(ctx context.Context, query string, id uuid.UUID) ([]models.OwnershipTransfer, error) {
+	transfers := []models.OwnershipTransfer{}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transfer models.OwnershipTransfer
+		if err := rows.Scan(
+			&transfer.ID,
+			&transfer.AccountID,
+			&transfer.AccountName,
+			&transfer.FromUserID,
+			&transfer.FromLogin,
+			&transfer.ToUserID,
+			&transfer.ToLogin,
+			&transfer.Leave,
+			&transfer.Status,
+			&transfer.CreatedAt,
+			&transfer.AnsweredAt,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return transfers, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
@@ -15,29 +17,35 @@ import (
 	"github.com/pashagolub/pgxmock"
 )
 
-func Test_SharingCheck(t *testing.T) {
+func Test_GetRole(t *testing.T) {
 	accountID := uuid.New()
 	userID := uuid.New()
 	testCases := []struct {
 		name        string
 		rows        *pgxmock.Rows
 		rowsError   error
+		expected    models.AccountRole
 		expectedErr error
 	}{
 		{
-			name: "Success",
-			rows: pgxmock.NewRows([]string{"count"}).
-				AddRow(1),
+			name:        "Success",
+			rows:        pgxmock.NewRows([]string{"role"}).AddRow(models.AccountViewer),
 			rowsError:   nil,
+			expected:    models.AccountViewer,
 			expectedErr: nil,
 		},
 		{
-			name: "ForbiddenUserError",
-			rows: pgxmock.NewRows([]string{"count"}).
-				AddRow(0),
-			rowsError:   nil,
+			name:        "ForbiddenUserError",
+			rows:        pgxmock.NewRows([]string{"role"}),
+			rowsError:   pgx.ErrNoRows,
 			expectedErr: fmt.Errorf("[repo] failed %w", &models.ForbiddenUserError{}),
 		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"role"}),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to get role: %w", errors.New("err")),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -47,13 +55,131 @@ func Test_SharingCheck(t *testing.T) {
 			logger := *logger.NewLogger(context.TODO())
 			repo := NewRepository(mock, logger)
 
-			escapedQuery := regexp.QuoteMeta(AccountSharingCheck)
+			escapedQuery := regexp.QuoteMeta(AccountRoleGet)
 			mock.ExpectQuery(escapedQuery).
-				WithArgs(userID, accountID).
+				WithArgs(accountID, userID).
 				WillReturnRows(tc.rows).
 				WillReturnError(tc.rowsError)
 
-			err := repo.SharingCheck(context.Background(), accountID, userID)
+			role, err := repo.GetRole(context.Background(), accountID, userID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if role != tc.expected {
+				t.Errorf("Expected role %s, but got: %s", tc.expected, role)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_IsArchived(t *testing.T) {
+	accountID := uuid.New()
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name:     "Archived",
+			rows:     pgxmock.NewRows([]string{"archived"}).AddRow(true),
+			expected: true,
+		},
+		{
+			name:     "Active",
+			rows:     pgxmock.NewRows([]string{"archived"}).AddRow(false),
+			expected: false,
+		},
+		{
+			name:        "No such account",
+			rows:        pgxmock.NewRows([]string{"archived"}),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchAccounts{}),
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"archived"}),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to get archived: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(AccountArchivedGet)).
+				WithArgs(accountID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			archived, err := repo.IsArchived(context.Background(), accountID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if archived != tc.expected {
+				t.Errorf("Expected archived %v, but got: %v", tc.expected, archived)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_SetUserRole(t *testing.T) {
+	accountID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		execResult  pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			execResult:  pgconn.CommandTag("UPDATE 1"),
+			expectedErr: nil,
+		},
+		{
+			name:        "Not a member",
+			execResult:  pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "user is not a member of the account or is its owner"}),
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to update role: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectExec(regexp.QuoteMeta(AccountRoleUpdate)).
+				WithArgs(accountID, userID, models.AccountContributor).
+				WillReturnResult(tc.execResult).
+				WillReturnError(tc.execError)
+
+			err := repo.SetUserRole(context.Background(), userID, accountID, models.AccountContributor)
 
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
@@ -232,9 +358,22 @@ func Test_CheckForbidden(t *testing.T) {
 	}
 }
 
-func Test_AddUserInAccount(t *testing.T) {
+func Test_UpdateAccount(t *testing.T) {
 	accountID := uuid.New()
 	userID := uuid.New()
+	account := &models.Accounts{
+		ID:             accountID,
+		Balance:        100.0,
+		Accumulation:   true,
+		BalanceEnabled: true,
+		MeanPayment:    "account",
+
+		Kind:            models.AccountCredit,
+		CreditLimit:     1000,
+		GracePeriod:     30,
+		StatementDay:    5,
+		OverdraftPolicy: models.OverdraftReject,
+	}
 
 	testCases := []struct {
 		name        string
@@ -252,7 +391,7 @@ func Test_AddUserInAccount(t *testing.T) {
 			name:        "Error",
 			execResult:  pgconn.CommandTag{},
 			execError:   errors.New("Some error"),
-			expectedErr: fmt.Errorf("[repo] can't create accountUser %s, %w", AccountUserCreate, errors.New("Some error")),
+			expectedErr: fmt.Errorf("[repo] failed update account %w", errors.New("Some error")),
 		},
 	}
 
@@ -263,13 +402,14 @@ func Test_AddUserInAccount(t *testing.T) {
 			logger := *logger.NewLogger(context.TODO())
 			repo := NewRepository(mock, logger)
 
-			// Expect a call to execute the AccountUserCreate query with the specified parameters
-			mock.ExpectExec(regexp.QuoteMeta(AccountUserCreate)).
-				WithArgs(userID, accountID).
+			// Expect a call to execute the AccountUpdate query with the specified parameters
+			mock.ExpectExec(regexp.QuoteMeta(AccountUpdate)).
+				WithArgs(account.Balance, account.Accumulation, account.BalanceEnabled, account.MeanPayment, account.ID,
+					account.Kind, account.CreditLimit, account.GracePeriod, account.StatementDay, account.OverdraftPolicy).
 				WillReturnResult(tc.execResult).
 				WillReturnError(tc.execError)
 
-			err := repo.AddUserInAccount(context.Background(), userID, accountID)
+			err := repo.UpdateAccount(context.Background(), userID, account)
 
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
@@ -282,34 +422,104 @@ func Test_AddUserInAccount(t *testing.T) {
 	}
 }
 
-func Test_UpdateAccount(t *testing.T) {
+func Test_CheckDuplicate(t *testing.T) {
 	accountID := uuid.New()
 	userID := uuid.New()
-	account := &models.Accounts{
-		ID:             accountID,
-		Balance:        100.0,
-		Accumulation:   true,
-		BalanceEnabled: true,
-		MeanPayment:    "account",
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedErr error
+	}{
+		{
+			name:        "NoDuplicate",
+			rows:        pgxmock.NewRows([]string{"exists"}).AddRow(false),
+			rowsError:   nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "Duplicate",
+			rows:        pgxmock.NewRows([]string{"exists"}).AddRow(true),
+			rowsError:   nil,
+			expectedErr: &models.DuplicateError{},
+		},
+		{
+			name:        "QueryError",
+			rows:        pgxmock.NewRows([]string{}),
+			rowsError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] query error: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			// Expect a call to execute the AccountGetUserByID query with the specified parameters
+			mock.ExpectQuery(regexp.QuoteMeta(AccountGetUserByID)).
+				WithArgs(accountID, userID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			err := repo.CheckDuplicate(context.Background(), userID, accountID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
 	}
+}
+
+func Test_ArchiveAccount(t *testing.T) {
+	accountID := uuid.New()
 
 	testCases := []struct {
 		name        string
+		query       string
+		restore     bool
 		execResult  pgconn.CommandTag
 		execError   error
 		expectedErr error
 	}{
 		{
-			name:        "Success",
-			execResult:  pgconn.CommandTag{},
-			execError:   nil,
+			name:        "Archive",
+			query:       AccountArchive,
+			execResult:  pgconn.CommandTag("UPDATE 1"),
 			expectedErr: nil,
 		},
 		{
-			name:        "Error",
-			execResult:  pgconn.CommandTag{},
+			name:        "Already archived",
+			query:       AccountArchive,
+			execResult:  pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "account is already archived"}),
+		},
+		{
+			name:        "Archive error",
+			query:       AccountArchive,
 			execError:   errors.New("Some error"),
-			expectedErr: fmt.Errorf("[repo] failed update account %w", errors.New("Some error")),
+			expectedErr: fmt.Errorf("[repo] failed to archive account: %w", errors.New("Some error")),
+		},
+		{
+			name:        "Restore",
+			query:       AccountRestore,
+			restore:     true,
+			execResult:  pgconn.CommandTag("UPDATE 1"),
+			expectedErr: nil,
+		},
+		{
+			name:        "Not archived",
+			query:       AccountRestore,
+			restore:     true,
+			execResult:  pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "account is not archived"}),
 		},
 	}
 
@@ -320,13 +530,17 @@ func Test_UpdateAccount(t *testing.T) {
 			logger := *logger.NewLogger(context.TODO())
 			repo := NewRepository(mock, logger)
 
-			// Expect a call to execute the AccountUpdate query with the specified parameters
-			mock.ExpectExec(regexp.QuoteMeta(AccountUpdate)).
-				WithArgs(account.Balance, account.Accumulation, account.BalanceEnabled, account.MeanPayment, account.ID).
+			mock.ExpectExec(regexp.QuoteMeta(tc.query)).
+				WithArgs(accountID).
 				WillReturnResult(tc.execResult).
 				WillReturnError(tc.execError)
 
-			err := repo.UpdateAccount(context.Background(), userID, account)
+			var err error
+			if tc.restore {
+				err = repo.RestoreAccount(context.Background(), accountID)
+			} else {
+				err = repo.ArchiveAccount(context.Background(), accountID)
+			}
 
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
@@ -339,33 +553,30 @@ func Test_UpdateAccount(t *testing.T) {
 	}
 }
 
-func Test_CheckDuplicate(t *testing.T) {
+func Test_GetDeletePreview(t *testing.T) {
 	accountID := uuid.New()
-	userID := uuid.New()
+	columns := []string{"transactions", "members", "deposits", "credits", "debt_repayments", "dividends",
+		"goals", "goal_rules", "reconciliations", "snapshots", "invitations"}
 
 	testCases := []struct {
 		name        string
 		rows        *pgxmock.Rows
 		rowsError   error
+		expected    *models.AccountDeletePreview
 		expectedErr error
 	}{
 		{
-			name:        "NoDuplicate",
-			rows:        pgxmock.NewRows([]string{}),
-			rowsError:   pgx.ErrNoRows,
+			name: "Success",
+			rows: pgxmock.NewRows(columns).AddRow(42, 2, 1, 0, 3, 0, 1, 2, 4, 90, 1),
+			expected: &models.AccountDeletePreview{Transactions: 42, Members: 2, Deposits: 1, DebtRepayments: 3,
+				Goals: 1, GoalRules: 2, Reconciliations: 4, Snapshots: 90, Invitations: 1},
 			expectedErr: nil,
 		},
 		{
-			name:        "Duplicate",
-			rows:        pgxmock.NewRows([]string{"user_id", "account_id"}).AddRow(userID, accountID),
-			rowsError:   nil,
-			expectedErr: &models.DuplicateError{},
-		},
-		{
-			name:        "QueryError",
-			rows:        pgxmock.NewRows([]string{}),
-			rowsError:   errors.New("Some error"),
-			expectedErr: fmt.Errorf("[repo] query error: %w", errors.New("Some error")),
+			name:        "Error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to count account rows: %w", errors.New("err")),
 		},
 	}
 
@@ -376,13 +587,188 @@ func Test_CheckDuplicate(t *testing.T) {
 			logger := *logger.NewLogger(context.TODO())
 			repo := NewRepository(mock, logger)
 
-			// Expect a call to execute the AccountGetUserByID query with the specified parameters
-			mock.ExpectQuery(regexp.QuoteMeta(AccountGetUserByID)).
-				WithArgs(accountID, userID).
+			mock.ExpectQuery(regexp.QuoteMeta(AccountDeletePreview)).
+				WithArgs(accountID).
 				WillReturnRows(tc.rows).
 				WillReturnError(tc.rowsError)
 
-			err := repo.CheckDuplicate(context.Background(), userID, accountID)
+			preview, err := repo.GetDeletePreview(context.Background(), accountID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && *preview != *tc.expected {
+				t.Errorf("Expected preview %v, but got: %v", tc.expected, preview)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_CreateOwnershipTransfer(t *testing.T) {
+	transferID := uuid.New()
+	transfer := &models.OwnershipTransfer{AccountID: uuid.New(), FromUserID: uuid.New(), ToUserID: uuid.New(), Leave: true}
+
+	testCases := []struct {
+		name        string
+		cancelErr   error
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			expected:    transferID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Cancel error",
+			cancelErr:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to cancel ownership transfer: %w", errors.New("err")),
+		},
+		{
+			name:        "Create error",
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create ownership transfer: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			cancel := mock.ExpectExec(regexp.QuoteMeta(OwnershipTransferCancel)).WithArgs(transfer.AccountID)
+			if tc.cancelErr != nil {
+				cancel.WillReturnError(tc.cancelErr)
+				mock.ExpectRollback()
+			} else {
+				cancel.WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				rows := pgxmock.NewRows([]string{"id"})
+				if tc.rowsError == nil {
+					rows.AddRow(transferID)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(OwnershipTransferCreate)).
+					WithArgs(transfer.AccountID, transfer.FromUserID, transfer.ToUserID, transfer.Leave).
+					WillReturnRows(rows).
+					WillReturnError(tc.rowsError)
+				if tc.rowsError != nil {
+					mock.ExpectRollback()
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+
+			id, err := repo.CreateOwnershipTransfer(context.Background(), transfer)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if id != tc.expected {
+				t.Errorf("Expected transfer %s, but got: %s", tc.expected, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_AcceptOwnershipTransfer(t *testing.T) {
+	transferID := uuid.New()
+	accountID := uuid.New()
+	ownerID := uuid.New()
+	successorID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		acceptErr   error
+		leave       bool
+		stepDown    pgconn.CommandTag
+		ownerSet    pgconn.CommandTag
+		expectedErr error
+	}{
+		{
+			name:     "Owner stays as editor",
+			stepDown: pgconn.CommandTag("UPDATE 1"),
+			ownerSet: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:     "Owner leaves",
+			leave:    true,
+			stepDown: pgconn.CommandTag("UPDATE 1"),
+			ownerSet: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:        "No pending transfer",
+			acceptErr:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "no pending ownership transfer"}),
+		},
+		{
+			name:        "Owner has changed",
+			stepDown:    pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "the nominating user is no longer the owner"}),
+		},
+		{
+			name:        "Successor has left",
+			stepDown:    pgconn.CommandTag("UPDATE 1"),
+			ownerSet:    pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "the successor is no longer a member of the account"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			rows := pgxmock.NewRows([]string{"account_id", "from_user_id", "leave"})
+			if tc.acceptErr == nil {
+				rows.AddRow(accountID, ownerID, tc.leave)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(OwnershipTransferAccept)).
+				WithArgs(transferID, successorID).
+				WillReturnRows(rows).
+				WillReturnError(tc.acceptErr)
+
+			if tc.acceptErr == nil {
+				mock.ExpectExec(regexp.QuoteMeta(AccountOwnerStepDown)).
+					WithArgs(accountID, ownerID).
+					WillReturnResult(tc.stepDown)
+				if tc.stepDown.RowsAffected() != 0 {
+					mock.ExpectExec(regexp.QuoteMeta(AccountOwnerSet)).
+						WithArgs(accountID, successorID).
+						WillReturnResult(tc.ownerSet)
+				}
+			}
+
+			if tc.expectedErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(AccountSharingUpdate)).
+					WithArgs(accountID, successorID).
+					WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				if tc.leave {
+					mock.ExpectExec(regexp.QuoteMeta(Unsubscribe)).
+						WithArgs(accountID, ownerID).
+						WillReturnResult(pgconn.CommandTag("DELETE 1"))
+				}
+				mock.ExpectCommit()
+			}
+
+			err := repo.AcceptOwnershipTransfer(context.Background(), transferID, successorID)
 
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
@@ -394,3 +780,199 @@ func Test_CheckDuplicate(t *testing.T) {
 		})
 	}
 }
+
+func Test_DeclineOwnershipTransfer(t *testing.T) {
+	transferID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			result:      pgconn.CommandTag("UPDATE 1"),
+			expectedErr: nil,
+		},
+		{
+			name:        "No pending transfer",
+			result:      pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.AccountOperationError{Reason: "no pending ownership transfer"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectExec(regexp.QuoteMeta(OwnershipTransferDecline)).
+				WithArgs(transferID, userID).
+				WillReturnResult(tc.result)
+
+			err := repo.DeclineOwnershipTransfer(context.Background(), transferID, userID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetAccounts(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	archivedAt := time.Date(2023, 11, 5, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment", "archived_at",
+		"kind", "credit_limit", "grace_period", "statement_day", "overdraft_policy"}
+	memberColumns := []string{"id", "login", "avatar_url", "role"}
+
+	testCases := []struct {
+		name        string
+		archived    bool
+		query       string
+		rows        *pgxmock.Rows
+		members     *pgxmock.Rows
+		expected    []models.Accounts
+		expectedErr error
+	}{
+		{
+			name:    "Active accounts",
+			query:   AccountsGet,
+			rows:    pgxmock.NewRows(columns).AddRow(accountID, 100.0, accountID, false, true, "Card", nil, models.AccountDebit, 0.0, 0, 0, models.OverdraftAllow),
+			members: pgxmock.NewRows(memberColumns).AddRow(userID, "owner", uuid.Nil, models.AccountOwner),
+			expected: []models.Accounts{{
+				ID: accountID, Balance: 100, SharingID: accountID, BalanceEnabled: true, MeanPayment: "Card",
+				Kind: models.AccountDebit, OverdraftPolicy: models.OverdraftAllow,
+				Users: []models.SharingUser{{ID: userID, Login: "owner", AvatarURL: uuid.Nil, Role: models.AccountOwner}},
+			}},
+		},
+		{
+			name:     "Archived accounts",
+			archived: true,
+			query:    AccountsGetArchived,
+			rows:     pgxmock.NewRows(columns).AddRow(accountID, 0.0, accountID, false, false, "Old card", &archivedAt, models.AccountDebit, 0.0, 0, 0, models.OverdraftReject),
+			members:  pgxmock.NewRows(memberColumns),
+			expected: []models.Accounts{{
+				ID: accountID, SharingID: accountID, MeanPayment: "Old card", ArchivedAt: &archivedAt, Users: []models.SharingUser{},
+				Kind: models.AccountDebit, OverdraftPolicy: models.OverdraftReject,
+			}},
+		},
+		{
+			name:     "No accounts",
+			query:    AccountsGet,
+			rows:     pgxmock.NewRows(columns),
+			expected: []models.Accounts{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
+				WithArgs(userID).
+				WillReturnRows(tc.rows)
+			if tc.members != nil {
+				mock.ExpectQuery(regexp.QuoteMeta(AccountMembersGet)).
+					WithArgs(accountID).
+					WillReturnRows(tc.members)
+			}
+
+			accounts, err := repo.GetAccounts(context.Background(), userID, tc.archived)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(tc.expected, accounts) {
+				t.Errorf("Expected accounts %+v, but got: %+v", tc.expected, accounts)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetAccount(t *testing.T) {
+	accountID := uuid.New()
+	columns := []string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment", "archived_at",
+		"kind", "credit_limit", "grace_period", "statement_day", "overdraft_policy"}
+
+	mock, _ := pgxmock.NewPool()
+	repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+	mock.ExpectQuery(regexp.QuoteMeta(AccountGet)).
+		WithArgs(accountID).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(accountID, -10.0, accountID, false, true, "Credit card", nil, models.AccountCredit, 500.0, 55, 10, models.OverdraftWarn))
+	mock.ExpectQuery(regexp.QuoteMeta(AccountMembersGet)).
+		WithArgs(accountID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "login", "avatar_url", "role"}))
+
+	account, err := repo.GetAccount(context.Background(), accountID)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if account.ID != accountID || account.MeanPayment != "Credit card" || account.Available() != 490 || account.StatementDay != 10 {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(AccountGet)).
+		WithArgs(accountID).
+		WillReturnRows(pgxmock.NewRows(columns)).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = repo.GetAccount(context.Background(), accountID)
+	var errNoSuchAccounts *models.NoSuchAccounts
+	if !errors.As(err, &errNoSuchAccounts) {
+		t.Errorf("Expected NoSuchAccounts, but got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_GetBalances(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	mock, _ := pgxmock.NewPool()
+	repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+	mock.ExpectQuery(regexp.QuoteMeta(AccountBalancesGet)).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "balance"}).AddRow(accountID, 42.5))
+
+	balances, err := repo.GetBalances(context.Background(), userID)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual([]models.AccountBalance{{AccountID: accountID, Balance: 42.5}}, balances) {
+		t.Errorf("Unexpected balances: %+v", balances)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(AccountBalancesGet)).
+		WithArgs(userID).
+		WillReturnError(errors.New("err"))
+
+	_, err = repo.GetBalances(context.Background(), userID)
+	if err == nil {
+		t.Errorf("Expected error, but got nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
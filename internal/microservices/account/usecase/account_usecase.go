@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	logging "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
@@ -10,21 +13,48 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// defaultWatchInterval is how often every open stream of WatchAccounts reads the balances
+	// when the service is not given another interval, see NewUsecase
+	defaultWatchInterval = 2 * time.Second
+
+	// watchMaxStreams is how many streams of WatchAccounts a user can keep open at once,
+	// one per browser tab is enough and the cap bounds the polling load of a single user
+	watchMaxStreams = 3
+)
+
 type Usecase struct {
 	accountRepo account.Repository
 	logger      logging.Logger
+
+	watchInterval time.Duration
+	watchMu       sync.Mutex
+	watchers      map[uuid.UUID]int
 }
 
+// NewUsecase creates the account usecase. watchInterval is how often WatchAccounts polls
+// the balances, zero or less means defaultWatchInterval
 func NewUsecase(
 	ar account.Repository,
-	log logging.Logger) *Usecase {
+	log logging.Logger,
+	watchInterval time.Duration) *Usecase {
+	if watchInterval <= 0 {
+		watchInterval = defaultWatchInterval
+	}
 	return &Usecase{
-		accountRepo: ar,
-		logger:      log,
+		accountRepo:   ar,
+		logger:        log,
+		watchInterval: watchInterval,
+		watchers:      map[uuid.UUID]int{},
 	}
 }
 
 func (a *Usecase) CreateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) (uuid.UUID, error) {
+	account.Normalize()
+	if err := account.CheckCredit(); err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", err)
+	}
+
 	accountID, err := a.accountRepo.CreateAccount(ctx, userID, account)
 
 	if err != nil {
@@ -34,11 +64,16 @@ func (a *Usecase) CreateAccount(ctx context.Context, userID uuid.UUID, account *
 }
 
 func (a *Usecase) UpdateAccount(ctx context.Context, userID uuid.UUID, account *models.Accounts) error {
-	err := a.accountRepo.SharingCheck(ctx, account.ID, userID)
+	err := a.checkRole(ctx, account.ID, userID, models.AccountRole.CanEdit)
 	if err != nil {
 		return fmt.Errorf("[usecase] can't be update by user: %w", err)
 	}
 
+	account.Normalize()
+	if err = account.CheckCredit(); err != nil {
+		return fmt.Errorf("[usecase] %w", err)
+	}
+
 	err = a.accountRepo.UpdateAccount(ctx, userID, account)
 	if err != nil {
 		return fmt.Errorf("[usecase] can't update account into repository: %w", err)
@@ -46,15 +81,301 @@ func (a *Usecase) UpdateAccount(ctx context.Context, userID uuid.UUID, account *
 	return nil
 }
 
-func (a *Usecase) DeleteAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error {
-	err := a.accountRepo.SharingCheck(ctx, accountID, userID)
+// DeleteAccount removes the account with its transactions, it has to be confirmed
+// after GetDeletePreview. ArchiveAccount keeps the history
+func (a *Usecase) DeleteAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, confirm bool) error {
+	err := a.checkRole(ctx, accountID, userID, models.AccountRole.CanManage)
 	if err != nil {
 		return fmt.Errorf("[usecase] can't be delete by user: %w", err)
 	}
 
+	if !confirm {
+		return fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "account deletion is not confirmed"})
+	}
+
 	err = a.accountRepo.DeleteAccount(ctx, userID, accountID)
 	if err != nil {
 		return fmt.Errorf("[usecase] can't delete account into repository: %w", err)
 	}
 	return nil
 }
+
+func (a *Usecase) ArchiveAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error {
+	err := a.checkRole(ctx, accountID, userID, models.AccountRole.CanManage)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't be archived by user: %w", err)
+	}
+
+	err = a.accountRepo.ArchiveAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't archive account into repository: %w", err)
+	}
+	return nil
+}
+
+func (a *Usecase) RestoreAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error {
+	err := a.checkRole(ctx, accountID, userID, models.AccountRole.CanManage)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't be restored by user: %w", err)
+	}
+
+	err = a.accountRepo.RestoreAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't restore account into repository: %w", err)
+	}
+	return nil
+}
+
+func (a *Usecase) GetDeletePreview(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (*models.AccountDeletePreview, error) {
+	err := a.checkRole(ctx, accountID, userID, models.AccountRole.CanManage)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't be delete by user: %w", err)
+	}
+
+	preview, err := a.accountRepo.GetDeletePreview(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get delete preview from repository: %w", err)
+	}
+	return preview, nil
+}
+
+// GetAccount returns the account to its member
+func (a *Usecase) GetAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (*models.Accounts, error) {
+	if _, err := a.accountRepo.GetRole(ctx, accountID, userID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't get account: %w", err)
+	}
+
+	account, err := a.accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get account from repository %w", err)
+	}
+	return account, nil
+}
+
+func (a *Usecase) GetAccounts(ctx context.Context, userID uuid.UUID, archived bool) ([]models.Accounts, error) {
+	accounts, err := a.accountRepo.GetAccounts(ctx, userID, archived)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get accounts from repository %w", err)
+	}
+	return accounts, nil
+}
+
+// WatchAccounts sends the balances of the user's accounts and then every balance that changes,
+// until the context is done or send fails. started is called once the stream is accepted,
+// a user over watchMaxStreams open streams gets AccountWatchLimitError instead.
+// Balances are written by other services straight into the database, so the stream polls
+// them every watchInterval: a change reaches the client at most that late and every open
+// stream costs one query per interval
+func (a *Usecase) WatchAccounts(ctx context.Context, userID uuid.UUID, started func() error, send func(models.AccountBalance) error) error {
+	if !a.acquireWatch(userID) {
+		return fmt.Errorf("[usecase] %w", &models.AccountWatchLimitError{Limit: watchMaxStreams})
+	}
+	defer a.releaseWatch(userID)
+
+	if err := started(); err != nil {
+		return fmt.Errorf("[usecase] can't start stream %w", err)
+	}
+
+	ticker := time.NewTicker(a.watchInterval)
+	defer ticker.Stop()
+
+	sent := map[uuid.UUID]float64{}
+	for {
+		balances, err := a.accountRepo.GetBalances(ctx, userID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("[usecase] can't get balances from repository %w", err)
+		}
+
+		for _, balance := range balances {
+			if last, ok := sent[balance.AccountID]; ok && last == balance.Balance {
+				continue
+			}
+			if err := send(balance); err != nil {
+				return fmt.Errorf("[usecase] can't send balance %w", err)
+			}
+			sent[balance.AccountID] = balance.Balance
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Usecase) acquireWatch(userID uuid.UUID) bool {
+	a.watchMu.Lock()
+	defer a.watchMu.Unlock()
+
+	if a.watchers[userID] >= watchMaxStreams {
+		return false
+	}
+	a.watchers[userID]++
+	return true
+}
+
+func (a *Usecase) releaseWatch(userID uuid.UUID) {
+	a.watchMu.Lock()
+	defer a.watchMu.Unlock()
+
+	a.watchers[userID]--
+	if a.watchers[userID] <= 0 {
+		delete(a.watchers, userID)
+	}
+}
+
+// Unsubscribe takes the user out of the account. The owner names a successor instead and
+// leaves once the successor accepts the ownership, the returned transfer is uuid.Nil for a member
+func (a *Usecase) Unsubscribe(ctx context.Context, accountID uuid.UUID, userID uuid.UUID, successorID uuid.UUID) (uuid.UUID, error) {
+	role, err := a.accountRepo.GetRole(ctx, accountID, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't unsubscribe: %w", err)
+	}
+
+	if role != models.AccountOwner {
+		err = a.accountRepo.DeleteUserInAccount(ctx, userID, accountID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("[usecase] can't delete user in account: %w", err)
+		}
+		return uuid.Nil, nil
+	}
+
+	if successorID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the owner must name a successor to leave the account"})
+	}
+
+	return a.nominate(ctx, accountID, userID, successorID, true)
+}
+
+func (a *Usecase) DeleteUserInAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, adminID uuid.UUID) error {
+	err := a.checkRole(ctx, accountID, adminID, models.AccountRole.CanManage)
+	if err != nil {
+		return fmt.Errorf("[usecase] is not admin user: %w", err)
+	}
+
+	if userID == adminID {
+		return fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the owner can't be removed from the account"})
+	}
+
+	err = a.accountRepo.DeleteUserInAccount(ctx, userID, accountID)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't delete account %w", err)
+	}
+
+	return nil
+}
+
+// SetUserRole changes the role of a member, only the owner does it and the owner's role stays
+func (a *Usecase) SetUserRole(ctx context.Context, roleInput models.SetAccountRole, adminID uuid.UUID) error {
+	err := a.checkRole(ctx, roleInput.AccountID, adminID, models.AccountRole.CanManage)
+	if err != nil {
+		return fmt.Errorf("[usecase] is not admin user: %w", err)
+	}
+
+	if err := checkMemberRole(roleInput.Role); err != nil {
+		return fmt.Errorf("[usecase] %w", err)
+	}
+
+	err = a.accountRepo.SetUserRole(ctx, roleInput.UserID, roleInput.AccountID, roleInput.Role)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't set user role %w", err)
+	}
+
+	return nil
+}
+
+// TransferOwnership nominates a member as the next owner, the owner changes when the member accepts
+func (a *Usecase) TransferOwnership(ctx context.Context, transferInput models.TransferOwnership, adminID uuid.UUID) (uuid.UUID, error) {
+	err := a.checkRole(ctx, transferInput.AccountID, adminID, models.AccountRole.CanManage)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] is not admin user: %w", err)
+	}
+
+	return a.nominate(ctx, transferInput.AccountID, adminID, transferInput.UserID, false)
+}
+
+func (a *Usecase) GetOwnershipTransfers(ctx context.Context, userID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	transfers, err := a.accountRepo.GetPendingOwnershipTransfers(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get ownership transfers from repository %w", err)
+	}
+	return transfers, nil
+}
+
+// GetOwnershipHistory returns the handovers of the account to its members
+func (a *Usecase) GetOwnershipHistory(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) ([]models.OwnershipTransfer, error) {
+	if _, err := a.accountRepo.GetRole(ctx, accountID, userID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't get ownership history: %w", err)
+	}
+
+	transfers, err := a.accountRepo.GetOwnershipHistory(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get ownership history from repository %w", err)
+	}
+	return transfers, nil
+}
+
+func (a *Usecase) AcceptOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error {
+	if err := a.accountRepo.AcceptOwnershipTransfer(ctx, transferID, userID); err != nil {
+		return fmt.Errorf("[usecase] can't accept ownership transfer %w", err)
+	}
+	return nil
+}
+
+func (a *Usecase) DeclineOwnershipTransfer(ctx context.Context, transferID uuid.UUID, userID uuid.UUID) error {
+	if err := a.accountRepo.DeclineOwnershipTransfer(ctx, transferID, userID); err != nil {
+		return fmt.Errorf("[usecase] can't decline ownership transfer %w", err)
+	}
+	return nil
+}
+
+// nominate records the ownership transfer to another member of the account
+func (a *Usecase) nominate(ctx context.Context, accountID uuid.UUID, ownerID uuid.UUID, successorID uuid.UUID, leave bool) (uuid.UUID, error) {
+	if successorID == ownerID {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the successor must be another member of the account"})
+	}
+
+	_, err := a.accountRepo.GetRole(ctx, accountID, successorID)
+	var errForbidden *models.ForbiddenUserError
+	if errors.As(err, &errForbidden) {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the successor must be another member of the account"})
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't get role of the successor: %w", err)
+	}
+
+	id, err := a.accountRepo.CreateOwnershipTransfer(ctx, &models.OwnershipTransfer{
+		AccountID:  accountID,
+		FromUserID: ownerID,
+		ToUserID:   successorID,
+		Leave:      leave,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create ownership transfer %w", err)
+	}
+	return id, nil
+}
+
+// checkMemberRole allows the roles given to members, there is one owner of the account
+func checkMemberRole(role models.AccountRole) error {
+	if !role.Assignable() {
+		return &models.AccountOperationError{Reason: "role must be editor, viewer or contributor"}
+	}
+	return nil
+}
+
+// checkRole fails with ForbiddenUserError when the role of the member does not allow the action
+func (a *Usecase) checkRole(ctx context.Context, accountID uuid.UUID, userID uuid.UUID, allowed func(models.AccountRole) bool) error {
+	role, err := a.accountRepo.GetRole(ctx, accountID, userID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return fmt.Errorf("[usecase] %s can't do it: %w", role, &models.ForbiddenUserError{})
+	}
+	return nil
+}
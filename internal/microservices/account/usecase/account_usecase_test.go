@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
@@ -18,6 +20,7 @@ func TestUsecase_CreateAccount(t *testing.T) {
 	testUUID := uuid.New()
 	testCases := []struct {
 		name        string
+		account     models.Accounts
 		expectedID  uuid.UUID
 		expectedErr error
 		mockRepoFn  func(*mock.MockRepository)
@@ -38,6 +41,42 @@ func TestUsecase_CreateAccount(t *testing.T) {
 				mockRepository.EXPECT().CreateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errors.New("some error"))
 			},
 		},
+		{
+			name:       "Credit card",
+			account:    models.Accounts{Kind: models.AccountCredit, CreditLimit: 1000, GracePeriod: 55, StatementDay: 10, OverdraftPolicy: models.OverdraftReject},
+			expectedID: testUUID,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().CreateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(testUUID, nil)
+			},
+		},
+		{
+			name:        "Credit card without limit",
+			account:     models.Accounts{Kind: models.AccountCredit, StatementDay: 10},
+			expectedID:  uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] credit limit must be positive"),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Wrong statement day",
+			account:     models.Accounts{Kind: models.AccountCredit, CreditLimit: 1000, StatementDay: 31},
+			expectedID:  uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] statement day must be between 1 and 28"),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Debit account with credit limit",
+			account:     models.Accounts{CreditLimit: 1000},
+			expectedID:  uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] a debit account has no credit terms"),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Unknown overdraft policy",
+			account:     models.Accounts{OverdraftPolicy: "ignore"},
+			expectedID:  uuid.Nil,
+			expectedErr: fmt.Errorf(`[usecase] unknown overdraft policy "ignore"`),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -48,12 +87,12 @@ func TestUsecase_CreateAccount(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
 
 			userID := uuid.New()
-			account := &models.Accounts{} // You should create an instance of your account model here
+			account := tc.account
 
-			accountID, err := mockUsecase.CreateAccount(context.Background(), userID, account)
+			accountID, err := mockUsecase.CreateAccount(context.Background(), userID, &account)
 
 			assert.Equal(t, tc.expectedID, accountID)
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
@@ -73,7 +112,7 @@ func TestUsecase_UpdateAccount(t *testing.T) {
 			name:        "Successful TestUsecase_UpdateAccount",
 			expectedErr: nil,
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().SharingCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
 				mockRepository.EXPECT().UpdateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
@@ -81,14 +120,29 @@ func TestUsecase_UpdateAccount(t *testing.T) {
 			name:        "Forbidden Error in TestUsecase_UpdateAccount",
 			expectedErr: fmt.Errorf("[usecase] can't be update by user: some forbidden error"),
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().SharingCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some forbidden error"))
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountRole(""), errors.New("some forbidden error"))
+			},
+		},
+		{
+			name:        "Editor in TestUsecase_UpdateAccount",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().UpdateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "Viewer in TestUsecase_UpdateAccount",
+			expectedErr: fmt.Errorf("[usecase] can't be update by user: [usecase] viewer can't do it: user has no rights"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountViewer, nil)
 			},
 		},
 		{
 			name:        "Update Error in TestUsecase_UpdateAccount",
 			expectedErr: fmt.Errorf("[usecase] can't update account into repository: some update error"),
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().SharingCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
 				mockRepository.EXPECT().UpdateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some update error"))
 			},
 		},
@@ -102,7 +156,7 @@ func TestUsecase_UpdateAccount(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
 
 			userID := uuid.New()
 			account := &models.Accounts{ID: uuid.New()} // Assuming ID is a required field for account
@@ -122,29 +176,41 @@ func TestUsecase_DeleteAccount(t *testing.T) {
 
 	testCases := []struct {
 		name        string
+		confirm     bool
 		expectedErr error
 		mockRepoFn  func(*mock.MockRepository)
 	}{
 		{
 			name:        "Successful deletion",
+			confirm:     true,
 			expectedErr: nil,
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().SharingCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
 				mockRepository.EXPECT().DeleteAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
 		{
 			name:        "Error in CheckForbidden",
+			confirm:     true,
 			expectedErr: fmt.Errorf("[usecase] can't be delete by user: %w", errors.New("forbidden")),
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().SharingCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("forbidden"))
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountRole(""), errors.New("forbidden"))
+			},
+		},
+		{
+			name:        "Deletion is not confirmed",
+			confirm:     false,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "account deletion is not confirmed"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
 			},
 		},
 		{
 			name:        "Error in DeleteAccount",
+			confirm:     true,
 			expectedErr: fmt.Errorf("[usecase] can't delete account into repository: %w", errors.New("repository error")),
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().SharingCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
 				mockRepository.EXPECT().DeleteAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
 			},
 		},
@@ -158,9 +224,70 @@ func TestUsecase_DeleteAccount(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
+
+			err := mockUsecase.DeleteAccount(context.Background(), userIDTest, accountIDTest, tc.confirm)
+
+			if (tc.expectedErr == nil && err != nil) ||
+				(tc.expectedErr != nil && err == nil) ||
+				(tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_ArchiveAccount(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful archive",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().ArchiveAccount(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "Error in GetRole",
+			expectedErr: fmt.Errorf("[usecase] can't be archived by user: %w", errors.New("forbidden")),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountRole(""), errors.New("forbidden"))
+			},
+		},
+		{
+			name:        "Editor can't archive",
+			expectedErr: fmt.Errorf("[usecase] can't be archived by user: [usecase] editor can't do it: %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name: "Already archived",
+			expectedErr: fmt.Errorf("[usecase] can't archive account into repository: %w",
+				&models.AccountOperationError{Reason: "account is already archived"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().ArchiveAccount(gomock.Any(), gomock.Any()).
+					Return(&models.AccountOperationError{Reason: "account is already archived"})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
 
-			err := mockUsecase.DeleteAccount(context.Background(), userIDTest, accountIDTest)
+			err := mockUsecase.ArchiveAccount(context.Background(), uuid.New(), uuid.New())
 
 			if (tc.expectedErr == nil && err != nil) ||
 				(tc.expectedErr != nil && err == nil) ||
@@ -170,3 +297,500 @@ func TestUsecase_DeleteAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_RestoreAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
+
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	mockRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+	mockRepo.EXPECT().RestoreAccount(gomock.Any(), accountID).Return(nil)
+	assert.NoError(t, mockUsecase.RestoreAccount(context.Background(), userID, accountID))
+
+	mockRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+	mockRepo.EXPECT().RestoreAccount(gomock.Any(), accountID).Return(&models.AccountOperationError{Reason: "account is not archived"})
+	err := mockUsecase.RestoreAccount(context.Background(), userID, accountID)
+	var errAccountOperation *models.AccountOperationError
+	assert.ErrorAs(t, err, &errAccountOperation)
+}
+
+func TestUsecase_GetDeletePreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
+
+	userID := uuid.New()
+	accountID := uuid.New()
+	preview := &models.AccountDeletePreview{Transactions: 12, Members: 2}
+
+	mockRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+	mockRepo.EXPECT().GetDeletePreview(gomock.Any(), accountID).Return(preview, nil)
+	actual, err := mockUsecase.GetDeletePreview(context.Background(), userID, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, preview, actual)
+
+	mockRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+	_, err = mockUsecase.GetDeletePreview(context.Background(), userID, accountID)
+	var errForbiddenUser *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbiddenUser)
+}
+
+func TestUsecase_GetAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
+
+	userID := uuid.New()
+	account := &models.Accounts{ID: uuid.New(), MeanPayment: "Card", Users: []models.SharingUser{{ID: userID, Role: models.AccountViewer}}}
+
+	mockRepo.EXPECT().GetRole(gomock.Any(), account.ID, userID).Return(models.AccountViewer, nil)
+	mockRepo.EXPECT().GetAccount(gomock.Any(), account.ID).Return(account, nil)
+	actual, err := mockUsecase.GetAccount(context.Background(), userID, account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, account, actual)
+
+	mockRepo.EXPECT().GetRole(gomock.Any(), account.ID, userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+	_, err = mockUsecase.GetAccount(context.Background(), userID, account.ID)
+	var errForbiddenUser *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbiddenUser)
+}
+
+func TestUsecase_GetAccounts(t *testing.T) {
+	uuidTest := uuid.New()
+	testCases := []struct {
+		name             string
+		archived         bool
+		expectedAccounts []models.Accounts
+		expectedErr      error
+		mockRepoFn       func(*mock.MockRepository)
+	}{
+		{
+			name:     "Successful accounts retrieval",
+			archived: false,
+			expectedAccounts: []models.Accounts{
+				{ID: uuidTest, Balance: 100.0, MeanPayment: "Account1"},
+				{ID: uuidTest, Balance: 200.0, MeanPayment: "Account2"},
+			},
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any(), false).Return([]models.Accounts{
+					{ID: uuidTest, Balance: 100.0, MeanPayment: "Account1"},
+					{ID: uuidTest, Balance: 200.0, MeanPayment: "Account2"}}, nil)
+			},
+		},
+		{
+			name:             "Archived accounts",
+			archived:         true,
+			expectedAccounts: []models.Accounts{{ID: uuidTest, MeanPayment: "Old card"}},
+			expectedErr:      nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any(), true).Return([]models.Accounts{{ID: uuidTest, MeanPayment: "Old card"}}, nil)
+			},
+		},
+		{
+			name:             "Error in accounts retrieval",
+			expectedAccounts: nil,
+			expectedErr:      fmt.Errorf("[usecase] can't get accounts from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any(), false).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
+
+			accounts, err := mockUsecase.GetAccounts(context.Background(), uuid.New(), tc.archived)
+
+			assert.Equal(t, tc.expectedAccounts, accounts)
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_WatchAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 10*time.Millisecond)
+
+	userID := uuid.New()
+	first := models.AccountBalance{AccountID: uuid.New(), Balance: 100}
+	second := models.AccountBalance{AccountID: uuid.New(), Balance: 50}
+	changed := models.AccountBalance{AccountID: second.AccountID, Balance: 75}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetBalances(gomock.Any(), userID).Return([]models.AccountBalance{first, second}, nil),
+		mockRepo.EXPECT().GetBalances(gomock.Any(), userID).Return([]models.AccountBalance{first, changed}, nil),
+	)
+
+	started := func() error { return nil }
+
+	var sent []models.AccountBalance
+	err := mockUsecase.WatchAccounts(ctx, userID, started, func(balance models.AccountBalance) error {
+		sent = append(sent, balance)
+		if balance == changed {
+			cancel()
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AccountBalance{first, second, changed}, sent)
+
+	mockRepo.EXPECT().GetBalances(gomock.Any(), userID).Return([]models.AccountBalance{first}, nil)
+	err = mockUsecase.WatchAccounts(context.Background(), userID, started, func(models.AccountBalance) error {
+		return errors.New("stream closed")
+	})
+	assert.EqualError(t, err, "[usecase] can't send balance stream closed")
+}
+
+func TestUsecase_WatchAccountsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), 0)
+
+	userID := uuid.New()
+	mockRepo.EXPECT().GetBalances(gomock.Any(), userID).Return(nil, nil).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	opened := make(chan struct{}, watchMaxStreams)
+	for i := 0; i < watchMaxStreams; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := mockUsecase.WatchAccounts(ctx, userID, func() error {
+				opened <- struct{}{}
+				return nil
+			}, func(models.AccountBalance) error { return nil })
+			assert.NoError(t, err)
+		}()
+	}
+	for i := 0; i < watchMaxStreams; i++ {
+		<-opened
+	}
+
+	started := func() error { return nil }
+	send := func(models.AccountBalance) error { return nil }
+
+	err := mockUsecase.WatchAccounts(ctx, userID, started, send)
+	var errWatchLimit *models.AccountWatchLimitError
+	assert.ErrorAs(t, err, &errWatchLimit)
+
+	cancel()
+	wg.Wait()
+
+	done, stop := context.WithCancel(context.Background())
+	stop()
+	assert.NoError(t, mockUsecase.WatchAccounts(done, userID, started, send))
+}
+
+func TestUsecase_DeleteUserInAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockAccountRepo, *logger.NewLogger(context.TODO()), 0)
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, adminID).Return(models.AccountOwner, nil)
+	mockAccountRepo.EXPECT().DeleteUserInAccount(gomock.Any(), userID, accountID).Return(nil)
+	assert.NoError(t, mockUsecase.DeleteUserInAccount(context.Background(), userID, accountID, adminID))
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, adminID).Return(models.AccountOwner, nil)
+	err := mockUsecase.DeleteUserInAccount(context.Background(), adminID, accountID, adminID)
+	var errAccountOperation *models.AccountOperationError
+	assert.ErrorAs(t, err, &errAccountOperation)
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountViewer, nil)
+	err = mockUsecase.DeleteUserInAccount(context.Background(), adminID, accountID, userID)
+	var errForbiddenUser *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbiddenUser)
+}
+
+func TestUsecase_SetUserRole(t *testing.T) {
+	adminID := uuid.New()
+	roleInput := models.SetAccountRole{UserID: uuid.New(), AccountID: uuid.New(), Role: models.AccountViewer}
+
+	testCases := []struct {
+		name        string
+		role        models.AccountRole
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful set",
+			role:        models.AccountViewer,
+			expectedErr: nil,
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), roleInput.AccountID, adminID).Return(models.AccountOwner, nil)
+				mockAccountRepository.EXPECT().SetUserRole(gomock.Any(), roleInput.UserID, roleInput.AccountID, models.AccountViewer).Return(nil)
+			},
+		},
+		{
+			name:        "Unknown role",
+			role:        models.AccountRole("admin"),
+			expectedErr: fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "role must be editor, viewer or contributor"}),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), roleInput.AccountID, adminID).Return(models.AccountOwner, nil)
+			},
+		},
+		{
+			name:        "Not a member",
+			role:        models.AccountViewer,
+			expectedErr: fmt.Errorf("[usecase] is not admin user: %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), roleInput.AccountID, adminID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Repository error",
+			role:        models.AccountEditor,
+			expectedErr: fmt.Errorf("[usecase] can't set user role %w", errors.New("some error")),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), roleInput.AccountID, adminID).Return(models.AccountOwner, nil)
+				mockAccountRepository.EXPECT().SetUserRole(gomock.Any(), roleInput.UserID, roleInput.AccountID, models.AccountEditor).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockAccountRepo, *logger.NewLogger(context.TODO()), 0)
+
+			input := roleInput
+			input.Role = tc.role
+			err := mockUsecase.SetUserRole(context.Background(), input, adminID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_Unsubscribe(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	successorID := uuid.New()
+	transferID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		successorID uuid.UUID
+		expected    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Member leaves",
+			successorID: uuid.Nil,
+			expected:    uuid.Nil,
+			expectedErr: nil,
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountViewer, nil)
+				mockAccountRepository.EXPECT().DeleteUserInAccount(gomock.Any(), userID, accountID).Return(nil)
+			},
+		},
+		{
+			name:        "Owner without successor",
+			successorID: uuid.Nil,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the owner must name a successor to leave the account"}),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+			},
+		},
+		{
+			name:        "Successor is not a member",
+			successorID: successorID,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the successor must be another member of the account"}),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, successorID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Owner names a successor",
+			successorID: successorID,
+			expected:    transferID,
+			expectedErr: nil,
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, successorID).Return(models.AccountEditor, nil)
+				mockAccountRepository.EXPECT().CreateOwnershipTransfer(gomock.Any(), &models.OwnershipTransfer{
+					AccountID:  accountID,
+					FromUserID: userID,
+					ToUserID:   successorID,
+					Leave:      true,
+				}).Return(transferID, nil)
+			},
+		},
+		{
+			name:        "Not a member",
+			successorID: uuid.Nil,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't unsubscribe: %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockAccountRepo, *logger.NewLogger(context.TODO()), 0)
+
+			id, err := mockUsecase.Unsubscribe(context.Background(), accountID, userID, tc.successorID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestUsecase_TransferOwnership(t *testing.T) {
+	adminID := uuid.New()
+	transferInput := models.TransferOwnership{AccountID: uuid.New(), UserID: uuid.New()}
+	transferID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		successorID uuid.UUID
+		expected    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful nomination",
+			successorID: transferInput.UserID,
+			expected:    transferID,
+			expectedErr: nil,
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), transferInput.AccountID, adminID).Return(models.AccountOwner, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), transferInput.AccountID, transferInput.UserID).Return(models.AccountViewer, nil)
+				mockAccountRepository.EXPECT().CreateOwnershipTransfer(gomock.Any(), &models.OwnershipTransfer{
+					AccountID:  transferInput.AccountID,
+					FromUserID: adminID,
+					ToUserID:   transferInput.UserID,
+				}).Return(transferID, nil)
+			},
+		},
+		{
+			name:        "Not the owner",
+			successorID: transferInput.UserID,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] is not admin user: %w",
+				fmt.Errorf("[usecase] %s can't do it: %w", models.AccountEditor, &models.ForbiddenUserError{})),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), transferInput.AccountID, adminID).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:        "Nominate yourself",
+			successorID: adminID,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.AccountOperationError{Reason: "the successor must be another member of the account"}),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), transferInput.AccountID, adminID).Return(models.AccountOwner, nil)
+			},
+		},
+		{
+			name:        "Repository error",
+			successorID: transferInput.UserID,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't create ownership transfer %w", errors.New("some error")),
+			mockRepoFn: func(mockAccountRepository *mock.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), transferInput.AccountID, adminID).Return(models.AccountOwner, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), transferInput.AccountID, transferInput.UserID).Return(models.AccountEditor, nil)
+				mockAccountRepository.EXPECT().CreateOwnershipTransfer(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockAccountRepo, *logger.NewLogger(context.TODO()), 0)
+
+			input := transferInput
+			input.UserID = tc.successorID
+			id, err := mockUsecase.TransferOwnership(context.Background(), input, adminID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestUsecase_GetOwnershipHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockAccountRepo, *logger.NewLogger(context.TODO()), 0)
+
+	userID := uuid.New()
+	accountID := uuid.New()
+	history := []models.OwnershipTransfer{{ID: uuid.New(), AccountID: accountID, Status: models.OwnershipTransferAccepted}}
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountViewer, nil)
+	mockAccountRepo.EXPECT().GetOwnershipHistory(gomock.Any(), accountID).Return(history, nil)
+	result, err := mockUsecase.GetOwnershipHistory(context.Background(), accountID, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, history, result)
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+	_, err = mockUsecase.GetOwnershipHistory(context.Background(), accountID, userID)
+	var errForbidden *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbidden)
+}
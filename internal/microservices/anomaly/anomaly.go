@@ -0,0 +1,26 @@
+package anomaly
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	GetAnomalies(ctx context.Context, userID uuid.UUID, withDismissed bool) ([]models.Anomaly, error)
+	DismissAnomaly(ctx context.Context, userID uuid.UUID, anomalyID uuid.UUID) error
+
+	DetectAnomalies(ctx context.Context) error
+	RecordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) error
+}
+
+type Repository interface {
+	GetAnomalies(ctx context.Context, userID uuid.UUID, withDismissed bool) ([]models.Anomaly, error)
+	DismissAnomaly(ctx context.Context, userID uuid.UUID, anomalyID uuid.UUID) error
+
+	GetUsers(ctx context.Context) ([]uuid.UUID, error)
+	GetOutcomeTransactions(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.AnomalyTransaction, error)
+	CreateAnomalies(ctx context.Context, anomalies []models.Anomaly) (int64, error)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+type Handler struct {
+	anomalyService anomaly.Usecase
+	logger         logger.Logger
+}
+
+func NewHandler(au anomaly.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		anomalyService: au,
+		logger:         l,
+	}
+}
+
+// @Summary		Get spending anomalies
+// @Tags		Anomaly
+// @Description	Category spikes, unusually large payments and new subscriptions found in the transactions
+// @Produce		json
+// @Param		dismissed	query		bool	false	"Include dismissed anomalies"
+// @Success		200		{object}	Response[[]models.Anomaly]	"Anomalies"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/anomaly/all [get]
+func (h *Handler) GetAnomalies(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	withDismissed, err := getWithDismissed(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	anomalies, err := h.anomalyService.GetAnomalies(r.Context(), user.ID, withDismissed)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AnomalyGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, anomalies)
+}
+
+// @Summary		Dismiss anomaly
+// @Tags		Anomaly
+// @Description	Hide the anomaly, it is not raised again
+// @Produce		json
+// @Param		anomaly_id	path		string	true	"Anomaly ID"
+// @Success		200		{object}	Response[NilBody]	"Anomaly dismissed"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/anomaly/{anomaly_id}/dismiss [put]
+func (h *Handler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	anomalyID, err := commonHttp.GetIDFromRequest(anomalyID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	err = h.anomalyService.DismissAnomaly(r.Context(), user.ID, anomalyID)
+
+	var errNoSuchAnomaly *models.NoSuchAnomalyError
+	if errors.As(err, &errNoSuchAnomaly) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, AnomalyNotSuch, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, AnomalyDismissServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	anomalyID = "anomaly_id"
+
+	AnomalyGetServerError     = "can't get anomalies"
+	AnomalyDismissServerError = "can't dismiss anomaly"
+	AnomalyNotSuch            = "no such anomaly"
+)
+
+var errInvalidDismissed = errors.New("invalid value for dismissed")
+
+func getWithDismissed(r *http.Request) (bool, error) {
+	dismissedStr := r.URL.Query().Get("dismissed")
+	if dismissedStr == "" {
+		return false, nil
+	}
+
+	dismissed, err := strconv.ParseBool(dismissedStr)
+	if err != nil {
+		return false, errInvalidDismissed
+	}
+
+	return dismissed, nil
+}
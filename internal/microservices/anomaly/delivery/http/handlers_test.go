@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetAnomalies(t *testing.T) {
+	uuidTest := uuid.New()
+	anomalyID := uuid.MustParse("9b4a9e8d-3a52-4a0b-9a1e-6c2a5c1c7d01")
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetAnomalies",
+			user:         user,
+			queryParam:   "dismissed=true",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[{"id":"9b4a9e8d-3a52-4a0b-9a1e-6c2a5c1c7d01","kind":"large_payment","amount":1000,"expected":100,"explanation":"payment","detected_at":"0001-01-01T00:00:00Z","dismissed":true}]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetAnomalies(gomock.Any(), uuidTest, true).Return([]models.Anomaly{
+					{ID: anomalyID, Kind: "large_payment", Amount: 1000, Expected: 100, Explanation: "payment", Dismissed: true},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				// No service calls are expected for unauthorized request.
+			},
+		},
+		{
+			name:         "Invalid dismissed",
+			user:         user,
+			queryParam:   "dismissed=maybe",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get anomalies"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetAnomalies(gomock.Any(), uuidTest, false).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/anomaly/all?"+tt.queryParam, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetAnomalies(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Dismiss(t *testing.T) {
+	uuidTest := uuid.New()
+	anomalyID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		anomalyID     string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Dismiss",
+			user:         user,
+			anomalyID:    anomalyID.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().DismissAnomaly(gomock.Any(), uuidTest, anomalyID).Return(nil)
+			},
+		},
+		{
+			name:         "Invalid anomaly id",
+			user:         user,
+			anomalyID:    "invalid",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			anomalyID:    anomalyID.String(),
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				// No service calls are expected for unauthorized request.
+			},
+		},
+		{
+			name:         "No such anomaly",
+			user:         user,
+			anomalyID:    anomalyID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such anomaly"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().DismissAnomaly(gomock.Any(), uuidTest, anomalyID).Return(&models.NoSuchAnomalyError{AnomalyID: anomalyID})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			anomalyID:    anomalyID.String(),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't dismiss anomaly"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().DismissAnomaly(gomock.Any(), uuidTest, anomalyID).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/anomaly/"+tt.anomalyID+"/dismiss", nil)
+			req = mux.SetURLVars(req, map[string]string{"anomaly_id": tt.anomalyID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Dismiss(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
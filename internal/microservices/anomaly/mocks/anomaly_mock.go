@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: anomaly.go
+
+// Package mock_anomaly is a generated GoMock package.
+package mock_anomaly
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DetectAnomalies mocks base method.
+func (m *MockUsecase) DetectAnomalies(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectAnomalies", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetectAnomalies indicates an expected call of DetectAnomalies.
+func (mr *MockUsecaseMockRecorder) DetectAnomalies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectAnomalies", reflect.TypeOf((*MockUsecase)(nil).DetectAnomalies), ctx)
+}
+
+// DismissAnomaly mocks base method.
+func (m *MockUsecase) DismissAnomaly(ctx context.Context, userID, anomalyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissAnomaly", ctx, userID, anomalyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissAnomaly indicates an expected call of DismissAnomaly.
+func (mr *MockUsecaseMockRecorder) DismissAnomaly(ctx, userID, anomalyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissAnomaly", reflect.TypeOf((*MockUsecase)(nil).DismissAnomaly), ctx, userID, anomalyID)
+}
+
+// GetAnomalies mocks base method.
+func (m *MockUsecase) GetAnomalies(ctx context.Context, userID uuid.UUID, withDismissed bool) ([]models.Anomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnomalies", ctx, userID, withDismissed)
+	ret0, _ := ret[0].([]models.Anomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnomalies indicates an expected call of GetAnomalies.
+func (mr *MockUsecaseMockRecorder) GetAnomalies(ctx, userID, withDismissed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnomalies", reflect.TypeOf((*MockUsecase)(nil).GetAnomalies), ctx, userID, withDismissed)
+}
+
+// RecordOverdraft mocks base method.
+func (m *MockUsecase) RecordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOverdraft", ctx, userID, overdraft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOverdraft indicates an expected call of RecordOverdraft.
+func (mr *MockUsecaseMockRecorder) RecordOverdraft(ctx, userID, overdraft interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOverdraft", reflect.TypeOf((*MockUsecase)(nil).RecordOverdraft), ctx, userID, overdraft)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAnomalies mocks base method.
+func (m *MockRepository) CreateAnomalies(ctx context.Context, anomalies []models.Anomaly) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnomalies", ctx, anomalies)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnomalies indicates an expected call of CreateAnomalies.
+func (mr *MockRepositoryMockRecorder) CreateAnomalies(ctx, anomalies interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnomalies", reflect.TypeOf((*MockRepository)(nil).CreateAnomalies), ctx, anomalies)
+}
+
+// DismissAnomaly mocks base method.
+func (m *MockRepository) DismissAnomaly(ctx context.Context, userID, anomalyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissAnomaly", ctx, userID, anomalyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissAnomaly indicates an expected call of DismissAnomaly.
+func (mr *MockRepositoryMockRecorder) DismissAnomaly(ctx, userID, anomalyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissAnomaly", reflect.TypeOf((*MockRepository)(nil).DismissAnomaly), ctx, userID, anomalyID)
+}
+
+// GetAnomalies mocks base method.
+func (m *MockRepository) GetAnomalies(ctx context.Context, userID uuid.UUID, withDismissed bool) ([]models.Anomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnomalies", ctx, userID, withDismissed)
+	ret0, _ := ret[0].([]models.Anomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnomalies indicates an expected call of GetAnomalies.
+func (mr *MockRepositoryMockRecorder) GetAnomalies(ctx, userID, withDismissed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnomalies", reflect.TypeOf((*MockRepository)(nil).GetAnomalies), ctx, userID, withDismissed)
+}
+
+// GetOutcomeTransactions mocks base method.
+func (m *MockRepository) GetOutcomeTransactions(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.AnomalyTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutcomeTransactions", ctx, userID, since)
+	ret0, _ := ret[0].([]models.AnomalyTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutcomeTransactions indicates an expected call of GetOutcomeTransactions.
+func (mr *MockRepositoryMockRecorder) GetOutcomeTransactions(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutcomeTransactions", reflect.TypeOf((*MockRepository)(nil).GetOutcomeTransactions), ctx, userID, since)
+}
+
+// GetUsers mocks base method.
+func (m *MockRepository) GetUsers(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockRepositoryMockRecorder) GetUsers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockRepository)(nil).GetUsers), ctx)
+}
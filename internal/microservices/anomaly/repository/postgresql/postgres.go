@@ -0,0 +1,197 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	AnomalyGetAll = `SELECT id, kind, transaction_id, category_id, amount, expected, explanation, detected_at, dismissed
+					 FROM Anomaly
+					 WHERE user_id = $1 AND (dismissed = false OR $2)
+					 ORDER BY detected_at DESC;`
+
+	AnomalyDismiss = "UPDATE Anomaly SET dismissed = true WHERE id = $1 AND user_id = $2;"
+
+	AnomalyGetUsers = "SELECT id FROM Users;"
+
+	// spending only: transfers between accounts are skipped, one category per transaction
+	AnomalyOutcomeTransactions = `SELECT DISTINCT ON (t.id)
+									t.id,
+									t.outcome,
+									t.date,
+									COALESCE(t.payer, ''),
+									COALESCE(c.id, '00000000-0000-0000-0000-000000000000'),
+									COALESCE(c.name, '')
+								  FROM Transaction t
+								  LEFT JOIN TransactionCategory tc ON tc.transaction_id = t.id
+								  LEFT JOIN category c ON c.id = tc.category_id
+								  WHERE t.account_outcome IN (SELECT account_id FROM UserAccount WHERE user_id = $1)
+								  AND t.account_income = t.account_outcome
+								  AND t.outcome > 0
+								  AND t.date >= $2
+								  ORDER BY t.id, c.name;`
+
+	// an anomaly is raised once, a dismissed one is not raised again
+	AnomalyCreate = `INSERT INTO Anomaly (user_id, kind, fingerprint, transaction_id, category_id, amount, expected, explanation)
+					 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+					 ON CONFLICT (user_id, fingerprint) DO NOTHING;`
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) GetAnomalies(ctx context.Context, userID uuid.UUID, withDismissed bool) ([]models.Anomaly, error) {
+	anomalies := []models.Anomaly{}
+
+	rows, err := r.db.Query(ctx, AnomalyGetAll, userID, withDismissed)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		anomaly := models.Anomaly{UserID: userID}
+		if err := rows.Scan(
+			&anomaly.ID,
+			&anomaly.Kind,
+			&anomaly.TransactionID,
+			&anomaly.CategoryID,
+			&anomaly.Amount,
+			&anomaly.Expected,
+			&anomaly.Explanation,
+			&anomaly.DetectedAt,
+			&anomaly.Dismissed,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return anomalies, nil
+}
+
+func (r *Repository) DismissAnomaly(ctx context.Context, userID uuid.UUID, anomalyID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, AnomalyDismiss, anomalyID, userID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to dismiss anomaly: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.NoSuchAnomalyError{AnomalyID: anomalyID})
+	}
+
+	return nil
+}
+
+func (r *Repository) GetUsers(ctx context.Context) ([]uuid.UUID, error) {
+	var users []uuid.UUID
+
+	rows, err := r.db.Query(ctx, AnomalyGetUsers)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		users = append(users, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *Repository) GetOutcomeTransactions(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.AnomalyTransaction, error) {
+	var transactions []models.AnomalyTransaction
+
+	rows, err := r.db.Query(ctx, AnomalyOutcomeTransactions, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction models.AnomalyTransaction
+		if err := rows.Scan(
+			&transaction.ID,
+			&transaction.Outcome,
+			&transaction.Date,
+			&transaction.Payer,
+			&transaction.CategoryID,
+			&transaction.CategoryName,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *Repository) CreateAnomalies(ctx context.Context, anomalies []models.Anomaly) (count int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("Rollback transaction Error: %v", errRollback)
+			}
+		}
+	}()
+
+	for _, anomaly := range anomalies {
+		tag, err := tx.Exec(ctx, AnomalyCreate,
+			anomaly.UserID,
+			anomaly.Kind,
+			anomaly.Fingerprint,
+			anomaly.TransactionID,
+			anomaly.CategoryID,
+			anomaly.Amount,
+			anomaly.Expected,
+			anomaly.Explanation,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("[repo] failed to insert anomaly: %w", err)
+		}
+		count += tag.RowsAffected()
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	return count, nil
+}
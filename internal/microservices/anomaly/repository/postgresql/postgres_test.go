@@ -0,0 +1,279 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+)
+
+func Test_GetAnomalies(t *testing.T) {
+	userID := uuid.New()
+	transactionID := uuid.New()
+
+	columns := []string{"id", "kind", "transaction_id", "category_id", "amount", "expected", "explanation", "detected_at", "dismissed"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(uuid.New(), "large_payment", &transactionID, nil, 1000.0, 100.0, "payment", time.Now(), false),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Empty",
+			rows:        pgxmock.NewRows(columns),
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(AnomalyGetAll)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, false).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			anomalies, err := repo.GetAnomalies(context.Background(), userID, false)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(anomalies) != tc.expectedLen {
+				t.Errorf("Expected %d anomalies, but got: %d", tc.expectedLen, len(anomalies))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_DismissAnomaly(t *testing.T) {
+	userID := uuid.New()
+	anomalyID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		execResult  pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			execResult:  pgconn.CommandTag("UPDATE 1"),
+			execError:   nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "NoSuchAnomaly",
+			execResult:  pgconn.CommandTag("UPDATE 0"),
+			execError:   nil,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchAnomalyError{AnomalyID: anomalyID}),
+		},
+		{
+			name:        "Error",
+			execResult:  pgconn.CommandTag{},
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to dismiss anomaly: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(AnomalyDismiss)
+			mock.ExpectExec(escapedQuery).
+				WithArgs(anomalyID, userID).
+				WillReturnResult(tc.execResult).
+				WillReturnError(tc.execError)
+
+			err := repo.DismissAnomaly(context.Background(), userID, anomalyID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetUsers(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+
+	logger := *logger.NewLogger(context.TODO())
+	repo := NewRepository(mock, logger)
+
+	mock.ExpectQuery(regexp.QuoteMeta(AnomalyGetUsers)).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+	users, err := repo.GetUsers(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Errorf("Expected 2 users, but got: %d", len(users))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_GetOutcomeTransactions(t *testing.T) {
+	userID := uuid.New()
+	since := time.Now()
+
+	columns := []string{"id", "outcome", "date", "payer", "category_id", "name"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(uuid.New(), 100.0, since, "Shop", uuid.New(), "Продукты"),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(AnomalyOutcomeTransactions)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, since).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			transactions, err := repo.GetOutcomeTransactions(context.Background(), userID, since)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(transactions) != tc.expectedLen {
+				t.Errorf("Expected %d transactions, but got: %d", tc.expectedLen, len(transactions))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_CreateAnomalies(t *testing.T) {
+	categoryID := uuid.New()
+	anomalies := []models.Anomaly{
+		{UserID: uuid.New(), Kind: "category_spike", Fingerprint: "category_spike:1", CategoryID: &categoryID, Amount: 200, Expected: 100, Explanation: "spike"},
+		{UserID: uuid.New(), Kind: "new_subscription", Fingerprint: "new_subscription:music", Amount: 299, Expected: 299, Explanation: "subscription"},
+	}
+
+	testCases := []struct {
+		name          string
+		execError     error
+		expectedCount int64
+		expectedErr   error
+	}{
+		{
+			name:          "Success",
+			execError:     nil,
+			expectedCount: 2,
+			expectedErr:   nil,
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to insert anomaly: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(AnomalyCreate)
+			mock.ExpectBegin()
+			if tc.execError == nil {
+				for _, anomaly := range anomalies {
+					mock.ExpectExec(escapedQuery).
+						WithArgs(anomaly.UserID, anomaly.Kind, anomaly.Fingerprint, anomaly.TransactionID, anomaly.CategoryID, anomaly.Amount, anomaly.Expected, anomaly.Explanation).
+						WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				}
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectExec(escapedQuery).WillReturnError(tc.execError)
+				mock.ExpectRollback()
+			}
+
+			count, err := repo.CreateAnomalies(context.Background(), anomalies)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if count != tc.expectedCount {
+				t.Errorf("Expected %d anomalies, but got: %d", tc.expectedCount, count)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	AnomalyCategorySpike   = "category_spike"
+	AnomalyLargePayment    = "large_payment"
+	AnomalyNewSubscription = "new_subscription"
+	AnomalyOverdraft       = "overdraft"
+
+	// the last window is compared with the trailing ones
+	anomalyWindowDays       = 30
+	anomalyTrailingWindows  = 3
+	AnomalyHistoryDays      = anomalyWindowDays * (anomalyTrailingWindows + 1)
+	spikeRatio              = 2.0
+	largePaymentRatio       = 3.0
+	largePaymentDeviations  = 3.0
+	largePaymentMinHistory  = 10
+	subscriptionMinCharges  = 2
+	subscriptionMaxSpread   = 0.1
+	subscriptionMinInterval = 6
+	subscriptionMaxInterval = 40
+	subscriptionNewDays     = 75
+)
+
+func detectAnomalies(now time.Time, userID uuid.UUID, history []models.AnomalyTransaction) []models.Anomaly {
+	today := dates.TruncateDay(now)
+	windowStart := today.AddDate(0, 0, -anomalyWindowDays)
+
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Date.Before(history[j].Date) })
+
+	var recent, trailing []models.AnomalyTransaction
+	for _, t := range history {
+		if t.Date.Before(windowStart) {
+			trailing = append(trailing, t)
+		} else {
+			recent = append(recent, t)
+		}
+	}
+
+	anomalies := categorySpikes(today, windowStart, recent, trailing)
+	anomalies = append(anomalies, largePayments(recent, trailing)...)
+	anomalies = append(anomalies, newSubscriptions(today, history)...)
+
+	for i := range anomalies {
+		anomalies[i].UserID = userID
+	}
+	return anomalies
+}
+
+// categorySpikes compares category spending over the last window with the trailing average
+func categorySpikes(today, windowStart time.Time, recent, trailing []models.AnomalyTransaction) []models.Anomaly {
+	if len(trailing) == 0 {
+		return nil
+	}
+
+	// the user may have less history than all the trailing windows
+	windows := math.Ceil(windowStart.Sub(dates.TruncateDay(trailing[0].Date)).Hours() / 24 / anomalyWindowDays)
+	windows = math.Max(1, math.Min(windows, anomalyTrailingWindows))
+
+	before := make(map[uuid.UUID]float64)
+	for _, t := range trailing {
+		before[t.CategoryID] += t.Outcome
+	}
+
+	current := make(map[uuid.UUID]float64)
+	names := make(map[uuid.UUID]string)
+	var order []uuid.UUID
+	for _, t := range recent {
+		if t.CategoryID == uuid.Nil {
+			continue
+		}
+		if _, ok := current[t.CategoryID]; !ok {
+			order = append(order, t.CategoryID)
+		}
+		current[t.CategoryID] += t.Outcome
+		names[t.CategoryID] = t.CategoryName
+	}
+
+	var anomalies []models.Anomaly
+	for _, categoryID := range order {
+		average := before[categoryID] / windows
+		if average == 0 || current[categoryID] < spikeRatio*average {
+			continue
+		}
+
+		categoryID := categoryID
+		anomalies = append(anomalies, models.Anomaly{
+			Kind:        AnomalyCategorySpike,
+			Fingerprint: fmt.Sprintf("%s:%s:%s", AnomalyCategorySpike, categoryID, today.Format("2006-01")),
+			CategoryID:  &categoryID,
+			Amount:      money.Round2(current[categoryID]),
+			Expected:    money.Round2(average),
+			Explanation: fmt.Sprintf("spending in %q is %.2f over the last %d days, %.1f times the usual %.2f",
+				names[categoryID], current[categoryID], anomalyWindowDays, current[categoryID]/average, average),
+		})
+	}
+
+	return anomalies
+}
+
+// largePayments finds recent payments far above the usual payment size
+func largePayments(recent, trailing []models.AnomalyTransaction) []models.Anomaly {
+	if len(trailing) < largePaymentMinHistory {
+		return nil
+	}
+
+	var sum float64
+	for _, t := range trailing {
+		sum += t.Outcome
+	}
+	mean := sum / float64(len(trailing))
+
+	var variance float64
+	for _, t := range trailing {
+		variance += (t.Outcome - mean) * (t.Outcome - mean)
+	}
+	deviation := math.Sqrt(variance / float64(len(trailing)))
+
+	threshold := math.Max(largePaymentRatio*mean, mean+largePaymentDeviations*deviation)
+
+	var anomalies []models.Anomaly
+	for _, t := range recent {
+		if t.Outcome < threshold {
+			continue
+		}
+
+		description := fmt.Sprintf("payment of %.2f", t.Outcome)
+		if t.Payer != "" {
+			description += fmt.Sprintf(" to %q", t.Payer)
+		}
+
+		transactionID := t.ID
+		anomaly := models.Anomaly{
+			Kind:          AnomalyLargePayment,
+			Fingerprint:   fmt.Sprintf("%s:%s", AnomalyLargePayment, t.ID),
+			TransactionID: &transactionID,
+			Amount:        money.Round2(t.Outcome),
+			Expected:      money.Round2(mean),
+			Explanation:   fmt.Sprintf("%s is %.1f times the average payment of %.2f", description, t.Outcome/mean, mean),
+		}
+		if t.CategoryID != uuid.Nil {
+			categoryID := t.CategoryID
+			anomaly.CategoryID = &categoryID
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	return anomalies
+}
+
+// newSubscriptions finds payers that appeared recently and charge the same amount regularly
+func newSubscriptions(today time.Time, history []models.AnomalyTransaction) []models.Anomaly {
+	newSince := today.AddDate(0, 0, -subscriptionNewDays)
+
+	charges := make(map[string][]models.AnomalyTransaction)
+	var order []string
+	for _, t := range history {
+		payer := normalizePayer(t.Payer)
+		if payer == "" {
+			continue
+		}
+		if _, ok := charges[payer]; !ok {
+			order = append(order, payer)
+		}
+		charges[payer] = append(charges[payer], t)
+	}
+
+	var anomalies []models.Anomaly
+	for _, payer := range order {
+		group := charges[payer]
+		if len(group) < subscriptionMinCharges || group[0].Date.Before(newSince) {
+			continue
+		}
+
+		interval, ok := subscriptionInterval(group)
+		if !ok {
+			continue
+		}
+
+		last := group[len(group)-1]
+		anomalies = append(anomalies, models.Anomaly{
+			Kind:        AnomalyNewSubscription,
+			Fingerprint: fmt.Sprintf("%s:%s", AnomalyNewSubscription, payer),
+			Amount:      money.Round2(last.Outcome),
+			Expected:    money.Round2(last.Outcome),
+			Explanation: fmt.Sprintf("new payer %q charged %.2f %d times about every %d days, it looks like a subscription",
+				last.Payer, last.Outcome, len(group), interval),
+		})
+	}
+
+	return anomalies
+}
+
+// subscriptionInterval reports the mean interval if the charges have close amounts and regular dates
+func subscriptionInterval(group []models.AnomalyTransaction) (int, bool) {
+	var sum float64
+	for _, t := range group {
+		sum += t.Outcome
+	}
+	mean := sum / float64(len(group))
+
+	for _, t := range group {
+		if math.Abs(t.Outcome-mean) > subscriptionMaxSpread*mean {
+			return 0, false
+		}
+	}
+
+	var days float64
+	for i := 1; i < len(group); i++ {
+		interval := dates.TruncateDay(group[i].Date).Sub(dates.TruncateDay(group[i-1].Date)).Hours() / 24
+		if interval < subscriptionMinInterval || interval > subscriptionMaxInterval {
+			return 0, false
+		}
+		days += interval
+	}
+
+	return int(math.Round(days / float64(len(group)-1))), true
+}
+
+func normalizePayer(payer string) string {
+	return strings.Join(strings.Fields(strings.ToLower(payer)), " ")
+}
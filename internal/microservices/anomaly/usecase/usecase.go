@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	anomalyRepo anomaly.Repository
+	logger      logger.Logger
+}
+
+func NewUsecase(ar anomaly.Repository, log logger.Logger) *Usecase {
+	return &Usecase{
+		anomalyRepo: ar,
+		logger:      log,
+	}
+}
+
+func (u *Usecase) GetAnomalies(ctx context.Context, userID uuid.UUID, withDismissed bool) ([]models.Anomaly, error) {
+	anomalies, err := u.anomalyRepo.GetAnomalies(ctx, userID, withDismissed)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get anomalies from repository %w", err)
+	}
+	return anomalies, nil
+}
+
+func (u *Usecase) DismissAnomaly(ctx context.Context, userID uuid.UUID, anomalyID uuid.UUID) error {
+	if err := u.anomalyRepo.DismissAnomaly(ctx, userID, anomalyID); err != nil {
+		return fmt.Errorf("[usecase] can't dismiss anomaly %w", err)
+	}
+	return nil
+}
+
+// DetectAnomalies checks the spending of every user, a failure for one user does not stop the others
+func (u *Usecase) DetectAnomalies(ctx context.Context) error {
+	users, err := u.anomalyRepo.GetUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get users from repository %w", err)
+	}
+
+	now := time.Now()
+	since := dates.TruncateDay(now).AddDate(0, 0, -AnomalyHistoryDays)
+
+	var failed int
+	for _, userID := range users {
+		history, err := u.anomalyRepo.GetOutcomeTransactions(ctx, userID, since)
+		if err != nil {
+			u.logger.Errorf("[usecase] can't get transactions of user %s: %v", userID, err)
+			failed++
+			continue
+		}
+
+		anomalies := detectAnomalies(now, userID, history)
+		if len(anomalies) == 0 {
+			continue
+		}
+
+		if _, err := u.anomalyRepo.CreateAnomalies(ctx, anomalies); err != nil {
+			u.logger.Errorf("[usecase] can't save anomalies of user %s: %v", userID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[usecase] anomaly detection failed for %d of %d users", failed, len(users))
+	}
+	return nil
+}
+
+// RecordOverdraft raises an anomaly for a charge the warn policy of the account let through,
+// the transaction is the fingerprint so a repeated record is dropped
+func (u *Usecase) RecordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) error {
+	transactionID := overdraft.TransactionID
+	anomaly := models.Anomaly{
+		UserID:        userID,
+		Kind:          AnomalyOverdraft,
+		Fingerprint:   fmt.Sprintf("%s:%s", AnomalyOverdraft, transactionID),
+		TransactionID: &transactionID,
+		Amount:        overdraft.Amount,
+		Expected:      overdraft.Available,
+		Explanation: fmt.Sprintf("outcome exceeds the available funds of the account by %.2f",
+			overdraft.Amount-overdraft.Available),
+	}
+
+	if _, err := u.anomalyRepo.CreateAnomalies(ctx, []models.Anomaly{anomaly}); err != nil {
+		return fmt.Errorf("[usecase] can't save overdraft anomaly %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_GetAnomalies(t *testing.T) {
+	testAnomalies := []models.Anomaly{{ID: uuid.New(), Kind: AnomalyLargePayment}}
+
+	testCases := []struct {
+		name              string
+		expectedAnomalies []models.Anomaly
+		expectedErr       error
+		mockRepoFn        func(*mock.MockRepository)
+	}{
+		{
+			name:              "Successful TestUsecase_GetAnomalies",
+			expectedAnomalies: testAnomalies,
+			expectedErr:       nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAnomalies(gomock.Any(), gomock.Any(), true).Return(testAnomalies, nil)
+			},
+		},
+		{
+			name:              "Error in TestUsecase_GetAnomalies",
+			expectedAnomalies: nil,
+			expectedErr:       fmt.Errorf("[usecase] can't get anomalies from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAnomalies(gomock.Any(), gomock.Any(), true).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			anomalies, err := mockUsecase.GetAnomalies(context.Background(), uuid.New(), true)
+
+			assert.Equal(t, tc.expectedAnomalies, anomalies)
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_DismissAnomaly(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_DismissAnomaly",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().DismissAnomaly(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "Error in TestUsecase_DismissAnomaly",
+			expectedErr: fmt.Errorf("[usecase] can't dismiss anomaly some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().DismissAnomaly(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			err := mockUsecase.DismissAnomaly(context.Background(), uuid.New(), uuid.New())
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_RecordOverdraft(t *testing.T) {
+	userID := uuid.New()
+	overdraft := &models.Overdraft{AccountID: uuid.New(), TransactionID: uuid.New(), Amount: 100, Available: 40}
+	expected := []models.Anomaly{{
+		UserID:        userID,
+		Kind:          AnomalyOverdraft,
+		Fingerprint:   "overdraft:" + overdraft.TransactionID.String(),
+		TransactionID: &overdraft.TransactionID,
+		Amount:        100,
+		Expected:      40,
+		Explanation:   "outcome exceeds the available funds of the account by 60.00",
+	}}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_RecordOverdraft",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().CreateAnomalies(gomock.Any(), expected).Return(int64(1), nil)
+			},
+		},
+		{
+			name:        "Error in TestUsecase_RecordOverdraft",
+			expectedErr: fmt.Errorf("[usecase] can't save overdraft anomaly some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().CreateAnomalies(gomock.Any(), expected).Return(int64(0), errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			err := mockUsecase.RecordOverdraft(context.Background(), userID, overdraft)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_DetectAnomalies(t *testing.T) {
+	userID := uuid.New()
+	otherID := uuid.New()
+	now := time.Now()
+
+	// a new weekly payer is always detected
+	subscription := []models.AnomalyTransaction{
+		{ID: uuid.New(), Outcome: 299, Date: now.AddDate(0, 0, -14), Payer: "Music"},
+		{ID: uuid.New(), Outcome: 299, Date: now.AddDate(0, 0, -7), Payer: "Music"},
+	}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_DetectAnomalies",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUsers(gomock.Any()).Return([]uuid.UUID{userID, otherID}, nil)
+				mockRepository.EXPECT().GetOutcomeTransactions(gomock.Any(), userID, gomock.Any()).Return(subscription, nil)
+				mockRepository.EXPECT().GetOutcomeTransactions(gomock.Any(), otherID, gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().CreateAnomalies(gomock.Any(), gomock.Len(1)).Return(int64(1), nil)
+			},
+		},
+		{
+			name:        "Users Error in TestUsecase_DetectAnomalies",
+			expectedErr: fmt.Errorf("[usecase] can't get users from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUsers(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "One user fails in TestUsecase_DetectAnomalies",
+			expectedErr: fmt.Errorf("[usecase] anomaly detection failed for 1 of 2 users"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUsers(gomock.Any()).Return([]uuid.UUID{userID, otherID}, nil)
+				mockRepository.EXPECT().GetOutcomeTransactions(gomock.Any(), userID, gomock.Any()).Return(nil, errors.New("some error"))
+				mockRepository.EXPECT().GetOutcomeTransactions(gomock.Any(), otherID, gomock.Any()).Return(subscription, nil)
+				mockRepository.EXPECT().CreateAnomalies(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			err := mockUsecase.DetectAnomalies(context.Background())
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	now := time.Date(2023, time.December, 15, 10, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	food := uuid.New()
+	fun := uuid.New()
+	big := uuid.New()
+
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+
+	var history []models.AnomalyTransaction
+	// steady food spending 1000 per 30 days for the whole history, doubled recently
+	for d := 115; d > 30; d -= 10 {
+		history = append(history, models.AnomalyTransaction{ID: uuid.New(), Outcome: 333.33, Date: daysAgo(d), Payer: "Shop", CategoryID: food, CategoryName: "Продукты"})
+	}
+	for d := 25; d > 0; d -= 6 {
+		history = append(history, models.AnomalyTransaction{ID: uuid.New(), Outcome: 650, Date: daysAgo(d), Payer: "Shop", CategoryID: food, CategoryName: "Продукты"})
+	}
+	// steady fun spending stays the same
+	history = append(history,
+		models.AnomalyTransaction{ID: uuid.New(), Outcome: 300, Date: daysAgo(80), Payer: "Cinema", CategoryID: fun, CategoryName: "Досуг"},
+		models.AnomalyTransaction{ID: uuid.New(), Outcome: 300, Date: daysAgo(50), Payer: "Cinema", CategoryID: fun, CategoryName: "Досуг"},
+		models.AnomalyTransaction{ID: uuid.New(), Outcome: 300, Date: daysAgo(5), Payer: "Cinema", CategoryID: fun, CategoryName: "Досуг"},
+	)
+	// one huge payment without a category
+	history = append(history, models.AnomalyTransaction{ID: big, Outcome: 20000, Date: daysAgo(3), Payer: "Furniture"})
+	// new monthly subscription
+	history = append(history,
+		models.AnomalyTransaction{ID: uuid.New(), Outcome: 499, Date: daysAgo(40), Payer: "Video Plus"},
+		models.AnomalyTransaction{ID: uuid.New(), Outcome: 499, Date: daysAgo(10), Payer: "video  plus"},
+	)
+
+	anomalies := detectAnomalies(now, userID, history)
+
+	byKind := make(map[string][]models.Anomaly)
+	for _, anomaly := range anomalies {
+		assert.Equal(t, userID, anomaly.UserID)
+		byKind[anomaly.Kind] = append(byKind[anomaly.Kind], anomaly)
+	}
+
+	if assert.Len(t, byKind[AnomalyCategorySpike], 1) {
+		spike := byKind[AnomalyCategorySpike][0]
+		assert.Equal(t, food, *spike.CategoryID)
+		assert.Equal(t, 3250.0, spike.Amount)
+		assert.Equal(t, fmt.Sprintf("%s:%s:2023-12", AnomalyCategorySpike, food), spike.Fingerprint)
+	}
+
+	if assert.Len(t, byKind[AnomalyLargePayment], 1) {
+		large := byKind[AnomalyLargePayment][0]
+		assert.Equal(t, big, *large.TransactionID)
+		assert.Nil(t, large.CategoryID)
+		assert.Contains(t, large.Explanation, `payment of 20000.00 to "Furniture"`)
+	}
+
+	if assert.Len(t, byKind[AnomalyNewSubscription], 1) {
+		subscription := byKind[AnomalyNewSubscription][0]
+		assert.Equal(t, AnomalyNewSubscription+":video plus", subscription.Fingerprint)
+		assert.Equal(t, 499.0, subscription.Amount)
+	}
+}
+
+func TestDetectAnomalies_NoHistory(t *testing.T) {
+	now := time.Now()
+	history := []models.AnomalyTransaction{
+		{ID: uuid.New(), Outcome: 100000, Date: now.AddDate(0, 0, -1), Payer: "Car", CategoryID: uuid.New(), CategoryName: "Авто"},
+	}
+
+	assert.Empty(t, detectAnomalies(now, uuid.New(), history))
+}
+
+func TestSubscriptionInterval(t *testing.T) {
+	day := func(d int, amount float64) models.AnomalyTransaction {
+		return models.AnomalyTransaction{Outcome: amount, Date: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, d)}
+	}
+
+	interval, ok := subscriptionInterval([]models.AnomalyTransaction{day(0, 100), day(30, 100), day(61, 105)})
+	assert.True(t, ok)
+	assert.Equal(t, 31, interval)
+
+	_, ok = subscriptionInterval([]models.AnomalyTransaction{day(0, 100), day(30, 200)})
+	assert.False(t, ok)
+
+	_, ok = subscriptionInterval([]models.AnomalyTransaction{day(0, 100), day(2, 100)})
+	assert.False(t, ok)
+}
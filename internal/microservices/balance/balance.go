@@ -0,0 +1,16 @@
+package balance
+
+import (
+	"context"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+type Usecase interface {
+	CheckBalances(ctx context.Context, repair bool) (*models.BalanceReport, error)
+}
+
+type Repository interface {
+	GetBalanceChecks(ctx context.Context) ([]models.BalanceCheck, error)
+	RepairBalances(ctx context.Context, checks []models.BalanceCheck) error
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance"
+)
+
+type Handler struct {
+	balanceService balance.Usecase
+	logger         logger.Logger
+}
+
+func NewHandler(bu balance.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		balanceService: bu,
+		logger:         l,
+	}
+}
+
+// @Summary		Check balances
+// @Tags		Admin
+// @Description	Recompute every account balance from the opening balance and the transactions
+// @Produce		json
+// @Success		200		{object}	Response[models.BalanceReport]	"Discrepancies"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Not an admin"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/admin/balance/check [get]
+func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
+	report, err := h.balanceService.CheckBalances(r.Context(), false)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, BalanceCheckServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, report)
+}
+
+// @Summary		Repair balances
+// @Tags		Admin
+// @Description	Recompute every account balance and write the recomputed ones in one transaction
+// @Produce		json
+// @Success		200		{object}	Response[models.BalanceReport]	"Repaired discrepancies"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Not an admin"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/admin/balance/repair [post]
+func (h *Handler) Repair(w http.ResponseWriter, r *http.Request) {
+	report, err := h.balanceService.CheckBalances(r.Context(), true)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, BalanceRepairServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, report)
+}
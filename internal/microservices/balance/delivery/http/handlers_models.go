@@ -0,0 +1,6 @@
+package http
+
+const (
+	BalanceCheckServerError  = "can't check balances"
+	BalanceRepairServerError = "can't repair balances"
+)
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Check(t *testing.T) {
+	accountID := uuid.MustParse("8d4c2b1a-7e6f-4a3b-9c8d-1e2f3a4b5c6d")
+	opening := 50.0
+	tests := []struct {
+		name          string
+		repair        bool
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful check",
+			repair:       false,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"accounts":1,"unverifiable":[],"discrepancies":[{"account_id":"8d4c2b1a-7e6f-4a3b-9c8d-1e2f3a4b5c6d","stored":95.5,"opening_balance":50,"income":40,"outcome":10,"expected":80,"difference":15.5}],"repaired":false}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CheckBalances(gomock.Any(), false).Return(&models.BalanceReport{
+					Accounts:     1,
+					Unverifiable: []models.BalanceCheck{},
+					Discrepancies: []models.BalanceCheck{
+						{AccountID: accountID, Stored: 95.5, Opening: &opening, Income: 40, Outcome: 10, Expected: 80, Difference: 15.5},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:         "Successful repair",
+			repair:       true,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"accounts":2,"unverifiable":[{"account_id":"8d4c2b1a-7e6f-4a3b-9c8d-1e2f3a4b5c6d","stored":30,"opening_balance":null,"income":0,"outcome":0,"expected":0,"difference":0}],"discrepancies":[],"repaired":true}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CheckBalances(gomock.Any(), true).Return(&models.BalanceReport{
+					Accounts:      2,
+					Unverifiable:  []models.BalanceCheck{{AccountID: accountID, Stored: 30}},
+					Discrepancies: []models.BalanceCheck{},
+					Repaired:      true,
+				}, nil)
+			},
+		},
+		{
+			name:         "Check server error",
+			repair:       false,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't check balances"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CheckBalances(gomock.Any(), false).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:         "Repair server error",
+			repair:       true,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't repair balances"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CheckBalances(gomock.Any(), true).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			recorder := httptest.NewRecorder()
+			if tt.repair {
+				mockHandler.Repair(recorder, httptest.NewRequest("POST", "/api/admin/balance/repair", nil))
+			} else {
+				mockHandler.Check(recorder, httptest.NewRequest("GET", "/api/admin/balance/check", nil))
+			}
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: balance.go
+
+// Package mock_balance is a generated GoMock package.
+package mock_balance
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CheckBalances mocks base method.
+func (m *MockUsecase) CheckBalances(ctx context.Context, repair bool) (*models.BalanceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBalances", ctx, repair)
+	ret0, _ := ret[0].(*models.BalanceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckBalances indicates an expected call of CheckBalances.
+func (mr *MockUsecaseMockRecorder) CheckBalances(ctx, repair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBalances", reflect.TypeOf((*MockUsecase)(nil).CheckBalances), ctx, repair)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBalanceChecks mocks base method.
+func (m *MockRepository) GetBalanceChecks(ctx context.Context) ([]models.BalanceCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceChecks", ctx)
+	ret0, _ := ret[0].([]models.BalanceCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceChecks indicates an expected call of GetBalanceChecks.
+func (mr *MockRepositoryMockRecorder) GetBalanceChecks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceChecks", reflect.TypeOf((*MockRepository)(nil).GetBalanceChecks), ctx)
+}
+
+// RepairBalances mocks base method.
+func (m *MockRepository) RepairBalances(ctx context.Context, checks []models.BalanceCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairBalances", ctx, checks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairBalances indicates an expected call of RepairBalances.
+func (mr *MockRepositoryMockRecorder) RepairBalances(ctx, checks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairBalances", reflect.TypeOf((*MockRepository)(nil).RepairBalances), ctx, checks)
+}
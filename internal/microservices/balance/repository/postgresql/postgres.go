@@ -0,0 +1,107 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+const (
+	// a transaction adds its income to account_income and takes its outcome from account_outcome,
+	// the same way updateAccountBalances does
+	BalanceChecksGet = `SELECT a.id, COALESCE(a.balance, 0), a.opening_balance, COALESCE(t.income, 0), COALESCE(t.outcome, 0)
+						FROM Accounts a
+						LEFT JOIN (
+							SELECT account_id, SUM(income) AS income, SUM(outcome) AS outcome
+							FROM (
+								SELECT account_income AS account_id, COALESCE(income, 0) AS income, 0 AS outcome FROM Transaction
+								UNION ALL
+								SELECT account_outcome, 0, COALESCE(outcome, 0) FROM Transaction
+							) movements
+							GROUP BY account_id
+						) t ON t.account_id = a.id
+						ORDER BY a.id;`
+
+	// the stored balance is compared to skip an account changed after the check
+	BalanceRepair = `UPDATE Accounts SET balance = $2, opening_balance = $3
+					 WHERE id = $1 AND COALESCE(balance, 0) = $4;`
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) GetBalanceChecks(ctx context.Context) ([]models.BalanceCheck, error) {
+	checks := []models.BalanceCheck{}
+
+	rows, err := r.db.Query(ctx, BalanceChecksGet)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var check models.BalanceCheck
+		if err := rows.Scan(
+			&check.AccountID,
+			&check.Stored,
+			&check.Opening,
+			&check.Income,
+			&check.Outcome,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		checks = append(checks, check)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return checks, nil
+}
+
+// RepairBalances writes the expected balances in one transaction, nothing is written
+// if any of the accounts has changed since the check
+func (r *Repository) RepairBalances(ctx context.Context, checks []models.BalanceCheck) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	for _, check := range checks {
+		tag, errExec := tx.Exec(ctx, BalanceRepair, check.AccountID, check.Expected, check.Opening, check.Stored)
+		if errExec != nil {
+			err = errExec
+			return fmt.Errorf("[repo] failed to repair balance of account %s: %w", check.AccountID, err)
+		}
+		if tag.RowsAffected() == 0 {
+			err = fmt.Errorf("account %s has changed during the check", check.AccountID)
+			return fmt.Errorf("[repo] %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
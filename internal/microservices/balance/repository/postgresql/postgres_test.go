@@ -0,0 +1,147 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var balanceRowColumns = []string{"id", "balance", "opening_balance", "income", "outcome"}
+
+func Test_GetBalanceChecks(t *testing.T) {
+	accountID := uuid.New()
+	legacyID := uuid.New()
+	opening := 100.0
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    []models.BalanceCheck
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(balanceRowColumns).
+				AddRow(accountID, 150.0, &opening, 70.0, 20.0).
+				AddRow(legacyID, 30.0, nil, 0.0, 10.0),
+			expected: []models.BalanceCheck{
+				{AccountID: accountID, Stored: 150, Opening: &opening, Income: 70, Outcome: 20},
+				{AccountID: legacyID, Stored: 30, Income: 0, Outcome: 10},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows(balanceRowColumns),
+			rowsError:   errors.New("err"),
+			expected:    nil,
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(BalanceChecksGet)).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			checks, err := repo.GetBalanceChecks(context.Background())
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			assert.Equal(t, tc.expected, checks)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_RepairBalances(t *testing.T) {
+	firstID := uuid.New()
+	secondID := uuid.New()
+	firstOpening := 100.0
+	secondOpening := 40.0
+
+	checks := []models.BalanceCheck{
+		{AccountID: firstID, Stored: 170, Opening: &firstOpening, Expected: 150},
+		{AccountID: secondID, Stored: 30, Opening: &secondOpening, Expected: 30},
+	}
+
+	testCases := []struct {
+		name        string
+		secondTag   pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			secondTag:   pgconn.CommandTag("UPDATE 1"),
+			expectedErr: nil,
+		},
+		{
+			name:        "Changed",
+			secondTag:   pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", fmt.Errorf("account %s has changed during the check", secondID)),
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to repair balance of account %s: %w", secondID, errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(BalanceRepair)).
+				WithArgs(firstID, 150.0, &firstOpening, 170.0).
+				WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+			second := mock.ExpectExec(regexp.QuoteMeta(BalanceRepair)).
+				WithArgs(secondID, 30.0, &secondOpening, 30.0)
+			if tc.execError != nil {
+				second.WillReturnError(tc.execError)
+			} else {
+				second.WillReturnResult(tc.secondTag)
+			}
+			if tc.expectedErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err := repo.RepairBalances(context.Background(), checks)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+// balanceEpsilon is less than a kopeck, the balances are stored with two decimals
+const balanceEpsilon = 0.005
+
+type Usecase struct {
+	balanceRepo balance.Repository
+	logger      logger.Logger
+}
+
+func NewUsecase(br balance.Repository, log logger.Logger) *Usecase {
+	return &Usecase{
+		balanceRepo: br,
+		logger:      log,
+	}
+}
+
+// CheckBalances recomputes every account balance as the opening balance plus the income and minus
+// the outcome of its transactions. An account created before the opening balance was stored can't be
+// checked, it is listed as unverifiable and left as is. With repair the expected balances are written
+func (u *Usecase) CheckBalances(ctx context.Context, repair bool) (*models.BalanceReport, error) {
+	checks, err := u.balanceRepo.GetBalanceChecks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get balances from repository %w", err)
+	}
+
+	report := &models.BalanceReport{
+		Accounts:      len(checks),
+		Unverifiable:  []models.BalanceCheck{},
+		Discrepancies: []models.BalanceCheck{},
+	}
+
+	var fixes []models.BalanceCheck
+	for _, check := range checks {
+		movement := check.Income - check.Outcome
+
+		if check.Opening == nil {
+			report.Unverifiable = append(report.Unverifiable, check)
+			continue
+		}
+
+		check.Expected = money.Round2(*check.Opening + movement)
+		check.Difference = money.Round2(check.Stored - check.Expected)
+		if math.Abs(check.Difference) < balanceEpsilon {
+			continue
+		}
+
+		report.Discrepancies = append(report.Discrepancies, check)
+		fixes = append(fixes, check)
+	}
+
+	if !repair || len(fixes) == 0 {
+		return report, nil
+	}
+
+	if err := u.balanceRepo.RepairBalances(ctx, fixes); err != nil {
+		return nil, fmt.Errorf("[usecase] can't repair balances %w", err)
+	}
+	report.Repaired = true
+
+	u.logger.Infof("[usecase] balances repaired: %d discrepancies, %d accounts unverifiable",
+		len(report.Discrepancies), len(report.Unverifiable))
+	return report, nil
+}
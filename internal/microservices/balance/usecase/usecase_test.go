@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/balance/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	validID  = uuid.New()
+	driftID  = uuid.New()
+	legacyID = uuid.New()
+)
+
+func float(value float64) *float64 {
+	return &value
+}
+
+func testChecks() []models.BalanceCheck {
+	return []models.BalanceCheck{
+		{AccountID: validID, Stored: 150, Opening: float(100), Income: 70, Outcome: 20},
+		{AccountID: driftID, Stored: 95.5, Opening: float(50), Income: 40, Outcome: 10},
+		{AccountID: legacyID, Stored: 30, Income: 0.1, Outcome: 10.2},
+	}
+}
+
+func TestUsecase_CheckBalances(t *testing.T) {
+	drift := models.BalanceCheck{AccountID: driftID, Stored: 95.5, Opening: float(50), Income: 40, Outcome: 10, Expected: 80, Difference: 15.5}
+	legacy := models.BalanceCheck{AccountID: legacyID, Stored: 30, Income: 0.1, Outcome: 10.2}
+
+	testCases := []struct {
+		name        string
+		repair      bool
+		expected    *models.BalanceReport
+		expectedErr string
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:   "Check in TestUsecase_CheckBalances",
+			repair: false,
+			expected: &models.BalanceReport{
+				Accounts:      3,
+				Unverifiable:  []models.BalanceCheck{legacy},
+				Discrepancies: []models.BalanceCheck{drift},
+			},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetBalanceChecks(gomock.Any()).Return(testChecks(), nil)
+			},
+		},
+		{
+			name:   "Repair in TestUsecase_CheckBalances",
+			repair: true,
+			expected: &models.BalanceReport{
+				Accounts:      3,
+				Unverifiable:  []models.BalanceCheck{legacy},
+				Discrepancies: []models.BalanceCheck{drift},
+				Repaired:      true,
+			},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetBalanceChecks(gomock.Any()).Return(testChecks(), nil)
+				mockRepository.EXPECT().RepairBalances(gomock.Any(), []models.BalanceCheck{drift}).Return(nil)
+			},
+		},
+		{
+			name:   "Nothing to repair in TestUsecase_CheckBalances",
+			repair: true,
+			expected: &models.BalanceReport{
+				Accounts:      1,
+				Unverifiable:  []models.BalanceCheck{},
+				Discrepancies: []models.BalanceCheck{},
+			},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetBalanceChecks(gomock.Any()).Return(testChecks()[:1], nil)
+			},
+		},
+		{
+			name:   "Unverifiable only in TestUsecase_CheckBalances",
+			repair: true,
+			expected: &models.BalanceReport{
+				Accounts:      1,
+				Unverifiable:  []models.BalanceCheck{legacy},
+				Discrepancies: []models.BalanceCheck{},
+			},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetBalanceChecks(gomock.Any()).Return(testChecks()[2:], nil)
+			},
+		},
+		{
+			name:        "Get error in TestUsecase_CheckBalances",
+			expectedErr: "[usecase] can't get balances from repository some error",
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetBalanceChecks(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Repair error in TestUsecase_CheckBalances",
+			repair:      true,
+			expectedErr: "[usecase] can't repair balances some error",
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetBalanceChecks(gomock.Any()).Return(testChecks(), nil)
+				mockRepository.EXPECT().RepairBalances(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			report, err := mockUsecase.CheckBalances(context.Background(), tc.repair)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, report)
+		})
+	}
+}
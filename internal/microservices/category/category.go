@@ -2,6 +2,7 @@ package category
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
@@ -10,15 +11,20 @@ import (
 type Usecase interface {
 	CreateTag(ctx context.Context, tag TagInput) (uuid.UUID, error)
 	UpdateTag(ctx context.Context, tag *models.Category) error
-	DeleteTag(ctx context.Context, tagId uuid.UUID, userId uuid.UUID) error
+	DeleteTag(ctx context.Context, tagId uuid.UUID, userId uuid.UUID, strategy string, targetId uuid.UUID) (models.CategoryDeletion, error)
 	GetTags(ctx context.Context, userId uuid.UUID) ([]models.Category, error)
+	GetTree(ctx context.Context, userId uuid.UUID, startDate, endDate time.Time) ([]models.CategoryNode, error)
+	MergeTags(ctx context.Context, userId uuid.UUID, targetId uuid.UUID, sourceIds []uuid.UUID) (models.CategoryMerge, error)
 }
 
 type Repository interface {
 	CreateTag(ctx context.Context, category models.Category) (uuid.UUID, error)
 	UpdateTag(ctx context.Context, tag *models.Category) error
-	DeleteTag(ctx context.Context, tagId uuid.UUID) error
+	DeleteTag(ctx context.Context, tagId uuid.UUID) (models.CategoryDeletion, error)
 	GetTags(ctx context.Context, userId uuid.UUID) ([]models.Category, error)
+	GetTotals(ctx context.Context, userId uuid.UUID, startDate, endDate time.Time) ([]models.CategoryTotal, error)
+	MergeTags(ctx context.Context, targetId uuid.UUID, sourceIds []uuid.UUID) (models.CategoryMerge, error)
+	GetUsage(ctx context.Context, tagId uuid.UUID) (int64, int64, error)
 
 	CheckNameUniq(ctx context.Context, userId uuid.UUID, parentId uuid.UUID, name string) (bool, error)
 	CheckExist(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) (bool, error)
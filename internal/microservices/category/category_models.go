@@ -27,7 +27,13 @@ type (
 	}
 
 	TagDeleteInput struct {
-		ID uuid.UUID `json:"id" valid:"-"`
+		ID       uuid.UUID `json:"id" valid:"-"`
+		Strategy string    `json:"strategy" valid:"-"`  // refuse, reassign or move_up, refuse by default
+		TargetID uuid.UUID `json:"target_id" valid:"-"` // category to reassign to
+	}
+
+	TagMergeInput struct {
+		SourceIDs []uuid.UUID `json:"source_ids"`
 	}
 
 	CategoryCreateResponse struct {
@@ -4,6 +4,7 @@ package category
 
 import (
 	json "encoding/json"
+	uuid "github.com/google/uuid"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -122,7 +123,107 @@ func (v *TagUpdateInput) UnmarshalJSON(data []byte) error {
 func (v *TagUpdateInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory(l, v)
 }
-func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(in *jlexer.Lexer, out *TagInput) {
+func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(in *jlexer.Lexer, out *TagMergeInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "source_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.SourceIDs = nil
+			} else {
+				in.Delim('[')
+				if out.SourceIDs == nil {
+					if !in.IsDelim(']') {
+						out.SourceIDs = make([]uuid.UUID, 0, 4)
+					} else {
+						out.SourceIDs = []uuid.UUID{}
+					}
+				} else {
+					out.SourceIDs = (out.SourceIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 uuid.UUID
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((v1).UnmarshalText(data))
+					}
+					out.SourceIDs = append(out.SourceIDs, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(out *jwriter.Writer, in TagMergeInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"source_ids\":"
+		out.RawString(prefix[1:])
+		if in.SourceIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.SourceIDs {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.RawText((v3).MarshalText())
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TagMergeInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TagMergeInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TagMergeInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TagMergeInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(l, v)
+}
+func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(in *jlexer.Lexer, out *TagInput) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -167,7 +268,7 @@ func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 		in.Consumed()
 	}
 }
-func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(out *jwriter.Writer, in TagInput) {
+func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(out *jwriter.Writer, in TagInput) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -207,27 +308,27 @@ func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 // MarshalJSON supports json.Marshaler interface
 func (v TagInput) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(&w, v)
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v TagInput) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(w, v)
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *TagInput) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(&r, v)
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *TagInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory1(l, v)
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(l, v)
 }
-func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(in *jlexer.Lexer, out *TagDeleteInput) {
+func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(in *jlexer.Lexer, out *TagDeleteInput) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -250,6 +351,12 @@ func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 			if data := in.UnsafeBytes(); in.Ok() {
 				in.AddError((out.ID).UnmarshalText(data))
 			}
+		case "strategy":
+			out.Strategy = string(in.String())
+		case "target_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.TargetID).UnmarshalText(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -260,7 +367,7 @@ func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 		in.Consumed()
 	}
 }
-func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(out *jwriter.Writer, in TagDeleteInput) {
+func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(out *jwriter.Writer, in TagDeleteInput) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -269,33 +376,43 @@ func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 		out.RawString(prefix[1:])
 		out.RawText((in.ID).MarshalText())
 	}
+	{
+		const prefix string = ",\"strategy\":"
+		out.RawString(prefix)
+		out.String(string(in.Strategy))
+	}
+	{
+		const prefix string = ",\"target_id\":"
+		out.RawString(prefix)
+		out.RawText((in.TargetID).MarshalText())
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v TagDeleteInput) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(&w, v)
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v TagDeleteInput) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(w, v)
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *TagDeleteInput) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(&r, v)
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *TagDeleteInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory2(l, v)
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(l, v)
 }
-func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(in *jlexer.Lexer, out *CategoryCreateResponse) {
+func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory4(in *jlexer.Lexer, out *CategoryCreateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -328,7 +445,7 @@ func easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 		in.Consumed()
 	}
 }
-func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(out *jwriter.Writer, in CategoryCreateResponse) {
+func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory4(out *jwriter.Writer, in CategoryCreateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -343,23 +460,23 @@ func easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservice
 // MarshalJSON supports json.Marshaler interface
 func (v CategoryCreateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(&w, v)
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CategoryCreateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(w, v)
+	easyjson12bfe9e7EncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CategoryCreateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(&r, v)
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CategoryCreateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory3(l, v)
+	easyjson12bfe9e7DecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCategory4(l, v)
 }
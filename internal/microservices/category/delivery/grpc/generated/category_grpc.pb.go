@@ -4,7 +4,6 @@ package generated
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -22,7 +21,9 @@ type CategoryServiceClient interface {
 	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
 	GetTags(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
 	UpdateTag(ctx context.Context, in *Category, opts ...grpc.CallOption) (*Category, error)
-	DeleteTag(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteTag(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	GetTree(ctx context.Context, in *TreeRequest, opts ...grpc.CallOption) (*TreeResponse, error)
+	MergeTags(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error)
 }
 
 type categoryServiceClient struct {
@@ -60,8 +61,8 @@ func (c *categoryServiceClient) UpdateTag(ctx context.Context, in *Category, opt
 	return out, nil
 }
 
-func (c *categoryServiceClient) DeleteTag(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *categoryServiceClient) DeleteTag(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
 	err := c.cc.Invoke(ctx, "/category.CategoryService/DeleteTag", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -69,6 +70,24 @@ func (c *categoryServiceClient) DeleteTag(ctx context.Context, in *DeleteRequest
 	return out, nil
 }
 
+func (c *categoryServiceClient) GetTree(ctx context.Context, in *TreeRequest, opts ...grpc.CallOption) (*TreeResponse, error) {
+	out := new(TreeResponse)
+	err := c.cc.Invoke(ctx, "/category.CategoryService/GetTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) MergeTags(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error) {
+	out := new(MergeResponse)
+	err := c.cc.Invoke(ctx, "/category.CategoryService/MergeTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CategoryServiceServer is the server API for CategoryService service.
 // All implementations must embed UnimplementedCategoryServiceServer
 // for forward compatibility
@@ -76,7 +95,9 @@ type CategoryServiceServer interface {
 	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
 	GetTags(context.Context, *UserIdRequest) (*GetTagsResponse, error)
 	UpdateTag(context.Context, *Category) (*Category, error)
-	DeleteTag(context.Context, *DeleteRequest) (*empty.Empty, error)
+	DeleteTag(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	GetTree(context.Context, *TreeRequest) (*TreeResponse, error)
+	MergeTags(context.Context, *MergeRequest) (*MergeResponse, error)
 	mustEmbedUnimplementedCategoryServiceServer()
 }
 
@@ -93,9 +114,15 @@ func (UnimplementedCategoryServiceServer) GetTags(context.Context, *UserIdReques
 func (UnimplementedCategoryServiceServer) UpdateTag(context.Context, *Category) (*Category, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
 }
-func (UnimplementedCategoryServiceServer) DeleteTag(context.Context, *DeleteRequest) (*empty.Empty, error) {
+func (UnimplementedCategoryServiceServer) DeleteTag(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
 }
+func (UnimplementedCategoryServiceServer) GetTree(context.Context, *TreeRequest) (*TreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTree not implemented")
+}
+func (UnimplementedCategoryServiceServer) MergeTags(context.Context, *MergeRequest) (*MergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
 func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
 
 // UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +208,42 @@ func _CategoryService_DeleteTag_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_GetTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/category.CategoryService/GetTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetTree(ctx, req.(*TreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/category.CategoryService/MergeTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).MergeTags(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -204,6 +267,14 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTag",
 			Handler:    _CategoryService_DeleteTag_Handler,
 		},
+		{
+			MethodName: "GetTree",
+			Handler:    _CategoryService_GetTree_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _CategoryService_MergeTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "category.proto",
@@ -2,13 +2,15 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category"
 	proto "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/grpc/generated"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type categoryGRPC struct {
@@ -38,7 +40,7 @@ func (c *categoryGRPC) CreateTag(ctx context.Context, in *proto.CreateTagRequest
 	}
 	id, err := c.CategoryServices.CreateTag(ctx, request)
 
-	return &proto.CreateTagResponse{TagId: id.String()}, err
+	return &proto.CreateTagResponse{TagId: id.String()}, categoryStatus(err)
 }
 
 func (c *categoryGRPC) GetTags(ctx context.Context, in *proto.UserIdRequest) (*proto.GetTagsResponse, error) {
@@ -51,18 +53,8 @@ func (c *categoryGRPC) GetTags(ctx context.Context, in *proto.UserIdRequest) (*p
 
 	var generatedCategories []*proto.Category
 
-	for _, tag := range tags {
-		generatedCategory := &proto.Category{
-			Id:          tag.ID.String(),
-			UserId:      tag.UserID.String(),
-			ParentId:    tag.ParentID.String(),
-			Name:        tag.Name,
-			ShowIncome:  tag.ShowIncome,
-			ShowOutcome: tag.ShowOutcome,
-			Regular:     tag.Regular,
-		}
-
-		generatedCategories = append(generatedCategories, generatedCategory)
+	for i := range tags {
+		generatedCategories = append(generatedCategories, CategoryToProto(&tags[i]))
 	}
 
 	return &proto.GetTagsResponse{Categories: generatedCategories}, nil
@@ -72,6 +64,10 @@ func (c *categoryGRPC) UpdateTag(ctx context.Context, in *proto.Category) (*prot
 	cId, _ := uuid.Parse(in.Id)
 	cUserId, _ := uuid.Parse(in.UserId)
 	cParentId, _ := uuid.Parse(in.ParentId)
+	if cParentId != uuid.Nil && cParentId == cId {
+		return nil, status.Error(codes.InvalidArgument, "category can't be nested into itself")
+	}
+
 	tag := &models.Category{
 		ID:          cId,
 		UserID:      cUserId,
@@ -83,7 +79,91 @@ func (c *categoryGRPC) UpdateTag(ctx context.Context, in *proto.Category) (*prot
 	}
 	err := c.CategoryServices.UpdateTag(ctx, tag)
 
-	updatedProtoCategory := &proto.Category{
+	return CategoryToProto(tag), categoryStatus(err)
+}
+
+func (c *categoryGRPC) DeleteTag(ctx context.Context, in *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	cId, _ := uuid.Parse(in.TagId)
+	cUserId, _ := uuid.Parse(in.UserId)
+	cTargetId, _ := uuid.Parse(in.TargetId)
+
+	deletion, err := c.CategoryServices.DeleteTag(ctx, cId, cUserId, in.Strategy, cTargetId)
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+
+	return &proto.DeleteResponse{
+		Strategy:     deletion.Strategy,
+		Transactions: deletion.Transactions,
+		Unlinked:     deletion.Unlinked,
+		Children:     deletion.Children,
+		Anomalies:    deletion.Anomalies,
+	}, nil
+}
+
+func (c *categoryGRPC) GetTree(ctx context.Context, in *proto.TreeRequest) (*proto.TreeResponse, error) {
+	userId, _ := uuid.Parse(in.UserId)
+
+	tree, err := c.CategoryServices.GetTree(ctx, userId, in.StartDate.AsTime(), in.EndDate.AsTime())
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+
+	nodes := make([]*proto.CategoryNode, 0, len(tree))
+	for i := range tree {
+		nodes = append(nodes, NodeToProto(&tree[i]))
+	}
+
+	return &proto.TreeResponse{Categories: nodes}, nil
+}
+
+func (c *categoryGRPC) MergeTags(ctx context.Context, in *proto.MergeRequest) (*proto.MergeResponse, error) {
+	userId, _ := uuid.Parse(in.UserId)
+	targetId, _ := uuid.Parse(in.TargetId)
+
+	sourceIds := make([]uuid.UUID, 0, len(in.SourceIds))
+	for _, source := range in.SourceIds {
+		sourceId, err := uuid.Parse(source)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid source category id")
+		}
+		sourceIds = append(sourceIds, sourceId)
+	}
+
+	merge, err := c.CategoryServices.MergeTags(ctx, userId, targetId, sourceIds)
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+
+	return &proto.MergeResponse{
+		TargetId:     merge.TargetID.String(),
+		Transactions: merge.Transactions,
+		Children:     merge.Children,
+		Anomalies:    merge.Anomalies,
+	}, nil
+}
+
+// categoryStatus turns the errors of the request into NotFound and InvalidArgument, other errors are passed as is
+func categoryStatus(err error) error {
+	var errNoSuchCategory *models.NoSuchCategoryError
+	if errors.As(err, &errNoSuchCategory) {
+		return status.Error(codes.NotFound, errNoSuchCategory.Error())
+	}
+
+	var errOperation *models.CategoryOperationError
+	if errors.As(err, &errOperation) {
+		return status.Error(codes.InvalidArgument, errOperation.Error())
+	}
+
+	var errHierarchy *models.CategoryHierarchyError
+	if errors.As(err, &errHierarchy) {
+		return status.Error(codes.InvalidArgument, errHierarchy.Error())
+	}
+	return err
+}
+
+func CategoryToProto(tag *models.Category) *proto.Category {
+	return &proto.Category{
 		Id:          tag.ID.String(),
 		UserId:      tag.UserID.String(),
 		ParentId:    tag.ParentID.String(),
@@ -92,15 +172,52 @@ func (c *categoryGRPC) UpdateTag(ctx context.Context, in *proto.Category) (*prot
 		ShowOutcome: tag.ShowOutcome,
 		Regular:     tag.Regular,
 	}
+}
+
+func CategoryFromProto(in *proto.Category) models.Category {
+	id, _ := uuid.Parse(in.Id)
+	userID, _ := uuid.Parse(in.UserId)
+	parentID, _ := uuid.Parse(in.ParentId)
 
-	return updatedProtoCategory, err
+	return models.Category{
+		ID:          id,
+		UserID:      userID,
+		ParentID:    parentID,
+		Name:        in.Name,
+		ShowIncome:  in.ShowIncome,
+		ShowOutcome: in.ShowOutcome,
+		Regular:     in.Regular,
+	}
 }
 
-func (c *categoryGRPC) DeleteTag(ctx context.Context, in *proto.DeleteRequest) (*empty.Empty, error) {
-	cId, _ := uuid.Parse(in.TagId)
-	cUserId, _ := uuid.Parse(in.UserId)
+func NodeToProto(node *models.CategoryNode) *proto.CategoryNode {
+	children := make([]*proto.CategoryNode, 0, len(node.Children))
+	for i := range node.Children {
+		children = append(children, NodeToProto(&node.Children[i]))
+	}
+
+	return &proto.CategoryNode{
+		Category:     CategoryToProto(&node.Category),
+		Income:       node.Income,
+		Outcome:      node.Outcome,
+		TotalIncome:  node.TotalIncome,
+		TotalOutcome: node.TotalOutcome,
+		Children:     children,
+	}
+}
 
-	err := c.CategoryServices.DeleteTag(ctx, cId, cUserId)
+func NodeFromProto(in *proto.CategoryNode) models.CategoryNode {
+	children := make([]models.CategoryNode, 0, len(in.Children))
+	for _, child := range in.Children {
+		children = append(children, NodeFromProto(child))
+	}
 
-	return &empty.Empty{}, err
+	return models.CategoryNode{
+		Category:     CategoryFromProto(in.GetCategory()),
+		Income:       in.Income,
+		Outcome:      in.Outcome,
+		TotalIncome:  in.TotalIncome,
+		TotalOutcome: in.TotalOutcome,
+		Children:     children,
+	}
 }
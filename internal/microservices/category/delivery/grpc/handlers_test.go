@@ -3,15 +3,19 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	proto "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/grpc/generated"
 	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/mocks"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/golang/mock/gomock"
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"testing"
+	"time"
 )
 
 func TestCreateTag(t *testing.T) {
@@ -143,15 +147,19 @@ func TestDeleteTag(t *testing.T) {
 	expectedTagID := uuid.New()
 	expectedUserID := uuid.New()
 
+	expectedTargetID := uuid.New()
+
 	request := &proto.DeleteRequest{
-		TagId:  expectedTagID.String(),
-		UserId: expectedUserID.String(),
+		TagId:    expectedTagID.String(),
+		UserId:   expectedUserID.String(),
+		Strategy: models.CategoryDeleteReassign,
+		TargetId: expectedTargetID.String(),
 	}
 
 	mockCategoryServices := mocks.NewMockUsecase(ctrl)
 	mockCategoryServices.EXPECT().
-		DeleteTag(gomock.Any(), expectedTagID, expectedUserID).
-		Return(nil) // Предполагаем, что удаление прошло успешно.
+		DeleteTag(gomock.Any(), expectedTagID, expectedUserID, models.CategoryDeleteReassign, expectedTargetID).
+		Return(models.CategoryDeletion{Strategy: models.CategoryDeleteReassign, Transactions: 5, Unlinked: 1, Children: 1, Anomalies: 2}, nil) // Предполагаем, что удаление прошло успешно.
 
 	categoryGRPC := NewCategoryGRPC(mockCategoryServices, *logger.NewLogger(context.TODO()))
 
@@ -161,5 +169,180 @@ func TestDeleteTag(t *testing.T) {
 	assert.NotNil(t, response)
 
 	// Проверим, что возвращенные данные соответствуют ожидаемым.
-	assert.Equal(t, &empty.Empty{}, response)
+	assert.Equal(t, models.CategoryDeleteReassign, response.Strategy)
+	assert.Equal(t, int64(5), response.Transactions)
+	assert.Equal(t, int64(1), response.Unlinked)
+	assert.Equal(t, int64(1), response.Children)
+	assert.Equal(t, int64(2), response.Anomalies)
+}
+
+func TestDeleteTag_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryServices := mocks.NewMockUsecase(ctrl)
+	mockCategoryServices.EXPECT().
+		DeleteTag(gomock.Any(), gomock.Any(), gomock.Any(), "", uuid.Nil).
+		Return(models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{Reason: "category is used by 3 transactions and 0 child categories"}))
+
+	categoryGRPC := NewCategoryGRPC(mockCategoryServices, *logger.NewLogger(context.TODO()))
+
+	_, err := categoryGRPC.DeleteTag(context.Background(), &proto.DeleteRequest{TagId: uuid.NewString(), UserId: uuid.NewString()})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "category is used by 3 transactions and 0 child categories", status.Convert(err).Message())
+}
+
+func TestUpdateTag_Hierarchy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tagID := uuid.New()
+	parentID := uuid.New()
+
+	mockCategoryServices := mocks.NewMockUsecase(ctrl)
+	mockCategoryServices.EXPECT().
+		UpdateTag(gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("[usecase] %w", &models.CategoryHierarchyError{Reason: "categories can't be nested deeper than 3 levels"}))
+
+	categoryGRPC := NewCategoryGRPC(mockCategoryServices, *logger.NewLogger(context.TODO()))
+
+	_, err := categoryGRPC.UpdateTag(context.Background(), &proto.Category{Id: tagID.String(), ParentId: parentID.String()})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "categories can't be nested deeper than 3 levels", status.Convert(err).Message())
+
+	// a category under itself is rejected before the usecase
+	_, err = categoryGRPC.UpdateTag(context.Background(), &proto.Category{Id: tagID.String(), ParentId: tagID.String()})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	startDate := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC)
+	child := models.CategoryNode{
+		Category:     models.Category{ID: uuid.New(), UserID: userID, Name: "Кафе"},
+		Outcome:      300,
+		TotalOutcome: 300,
+		Children:     []models.CategoryNode{},
+	}
+	root := models.CategoryNode{
+		Category:     models.Category{ID: uuid.New(), UserID: userID, Name: "Еда"},
+		Outcome:      700,
+		TotalOutcome: 1000,
+		Children:     []models.CategoryNode{child},
+	}
+	root.Children[0].ParentID = root.ID
+
+	mockCategoryServices := mocks.NewMockUsecase(ctrl)
+	mockCategoryServices.EXPECT().
+		GetTree(gomock.Any(), userID, startDate, endDate).
+		Return([]models.CategoryNode{root}, nil)
+
+	categoryGRPC := NewCategoryGRPC(mockCategoryServices, *logger.NewLogger(context.TODO()))
+
+	response, err := categoryGRPC.GetTree(context.Background(), &proto.TreeRequest{
+		UserId:    userID.String(),
+		StartDate: timestamppb.New(startDate),
+		EndDate:   timestamppb.New(endDate),
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, response.Categories, 1) {
+		assert.Equal(t, root, NodeFromProto(response.Categories[0]))
+	}
+}
+
+func TestGetTree_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	categoryID := uuid.New()
+
+	mockCategoryServices := mocks.NewMockUsecase(ctrl)
+	mockCategoryServices.EXPECT().
+		GetTree(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("[usecase] %w", &models.NoSuchCategoryError{CategoryID: categoryID}))
+
+	categoryGRPC := NewCategoryGRPC(mockCategoryServices, *logger.NewLogger(context.TODO()))
+
+	response, err := categoryGRPC.GetTree(context.Background(), &proto.TreeRequest{
+		UserId:    userID.String(),
+		StartDate: timestamppb.Now(),
+		EndDate:   timestamppb.Now(),
+	})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMergeTags(t *testing.T) {
+	userID := uuid.New()
+	targetID := uuid.New()
+	sourceID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		sourceIds    []string
+		usecaseErr   error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Success",
+			sourceIds:    []string{sourceID.String()},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Category of another user",
+			sourceIds:    []string{sourceID.String()},
+			usecaseErr:   fmt.Errorf("[usecase] %w", &models.NoSuchCategoryError{CategoryID: sourceID}),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Merged into itself",
+			sourceIds:    []string{sourceID.String()},
+			usecaseErr:   fmt.Errorf("[usecase] %w", &models.CategoryOperationError{Reason: "category can't be merged into itself"}),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Invalid source",
+			sourceIds:    []string{"source"},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCategoryServices := mocks.NewMockUsecase(ctrl)
+			if tc.name != "Invalid source" {
+				mockCategoryServices.EXPECT().
+					MergeTags(gomock.Any(), userID, targetID, []uuid.UUID{sourceID}).
+					Return(models.CategoryMerge{TargetID: targetID, Transactions: 4, Children: 1, Anomalies: 3}, tc.usecaseErr)
+			}
+
+			categoryGRPC := NewCategoryGRPC(mockCategoryServices, *logger.NewLogger(context.TODO()))
+
+			response, err := categoryGRPC.MergeTags(context.Background(), &proto.MergeRequest{
+				UserId:    userID.String(),
+				TargetId:  targetID.String(),
+				SourceIds: tc.sourceIds,
+			})
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode == codes.OK {
+				assert.Equal(t, int64(4), response.Transactions)
+				assert.Equal(t, int64(1), response.Children)
+				assert.Equal(t, int64(3), response.Anomalies)
+			}
+		})
+	}
 }
@@ -8,9 +8,13 @@ import (
 	response "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category"
+	categoryGRPC "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/grpc"
 	genCategory "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/grpc/generated"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Handler struct {
@@ -68,6 +72,10 @@ func (h *Handler) CreateTag(w http.ResponseWriter, r *http.Request) {
 		h.log.WithField(
 			"Request-Id", contextutils.GetReqID(r.Context()),
 		).Errorf("[handler] Error: %v", err)
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			response.ErrorResponse(w, http.StatusBadRequest, err, st.Message(), h.log)
+			return
+		}
 		response.ErrorResponse(w, http.StatusTooManyRequests, err, "Can't create tag", h.log)
 		return
 	}
@@ -127,6 +135,47 @@ func (h *Handler) GetTags(w http.ResponseWriter, r *http.Request) {
 	response.SuccessResponse(w, http.StatusOK, tags)
 }
 
+// @Summary		Get Tag Tree
+// @Tags			Category
+// @Description	Get tags nested under their parents with the totals of each tag and of its subtree
+// @Produce		json
+// @Param		start_date	query		string	false	"Start of the period (RFC3339), month before end_date by default"
+// @Param		end_date	query		string	false	"End of the period (RFC3339), now by default"
+// @Success		200		{object}	Response[[]models.CategoryNode]	"tag tree"
+// @Failure		400		{object}	ResponseError					"Incorrect Input"
+// @Failure		401		{object}	ResponseError					"auth error relogin"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/tag/tree	[get]
+func (h *Handler) GetTree(w http.ResponseWriter, r *http.Request) {
+	user, err := response.GetUserFromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, http.StatusUnauthorized, err, response.ErrUnauthorized.Error(), h.log)
+		return
+	}
+
+	startDate, endDate, err := getTreePeriod(r)
+	if err != nil {
+		response.ErrorResponse(w, http.StatusBadRequest, err, response.InvalidURLParameter, h.log)
+		return
+	}
+
+	tree, err := h.client.GetTree(r.Context(), &genCategory.TreeRequest{
+		UserId:    user.ID.String(),
+		StartDate: timestamppb.New(startDate),
+		EndDate:   timestamppb.New(endDate),
+	})
+	if h.tagError(w, r, err, TreeServerError) {
+		return
+	}
+
+	nodes := make([]models.CategoryNode, 0, len(tree.Categories))
+	for _, node := range tree.Categories {
+		nodes = append(nodes, categoryGRPC.NodeFromProto(node))
+	}
+
+	response.SuccessResponse(w, http.StatusOK, nodes)
+}
+
 // @Summary		Update Tag
 // @Tags			Category
 // @Description	Update Tag
@@ -175,6 +224,10 @@ func (h *Handler) UpdateTag(w http.ResponseWriter, r *http.Request) {
 		h.log.WithField(
 			"Request-Id", contextutils.GetReqID(r.Context()),
 		).Errorf("[handler] Update Error: %v", err)
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			response.ErrorResponse(w, http.StatusBadRequest, err, st.Message(), h.log)
+			return
+		}
 		response.ErrorResponse(w, http.StatusBadRequest, err, "Can't Update tag", h.log)
 		return
 	}
@@ -196,14 +249,14 @@ func (h *Handler) UpdateTag(w http.ResponseWriter, r *http.Request) {
 
 // @Summary		Delete Tag
 // @Tags			Category
-// @Description	delete tag
+// @Description	Delete tag refusing if it is in use, reassigning its transactions and children to another tag or moving them up a level
 // @Accept 		json
 // @Produce		json
-// @Param			tag		body		string				true		"tag id"
-// @Success		200		{object}	Response[models.Category]		"tag slice"
+// @Param			tag		body		category.TagDeleteInput		true		"tag id and strategy"
+// @Success		200		{object}	Response[models.CategoryDeletion]	"transactions and children affected"
 // @Failure		400		{object}	ResponseError					"Incorrect Input"
 // @Failure		401		{object}	ResponseError					"auth error relogin"
-// @Failure		429		{object}	ResponseError					"Server error"
+// @Failure		500		{object}	ResponseError					"Server error"
 // @Router		/api/tag/delete	[delete]
 func (h *Handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
 	user, err := response.GetUserFromRequest(r)
@@ -226,14 +279,107 @@ func (h *Handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	_, err = h.client.DeleteTag(r.Context(), &genCategory.DeleteRequest{TagId: tagId.ID.String(), UserId: user.ID.String()})
+	if err := checkDeleteInput(tagId); err != nil {
+		response.ErrorResponse(w, http.StatusBadRequest, err, err.Error(), h.log)
+		return
+	}
+
+	request := &genCategory.DeleteRequest{TagId: tagId.ID.String(), UserId: user.ID.String(), Strategy: tagId.Strategy}
+	if tagId.TargetID != uuid.Nil {
+		request.TargetId = tagId.TargetID.String()
+	}
+
+	deletion, err := h.client.DeleteTag(r.Context(), request)
+	if h.tagError(w, r, err, DeleteServerError) {
+		return
+	}
+
+	response.SuccessResponse(w, http.StatusOK, models.CategoryDeletion{
+		Strategy:     deletion.Strategy,
+		Transactions: deletion.Transactions,
+		Unlinked:     deletion.Unlinked,
+		Children:     deletion.Children,
+		Anomalies:    deletion.Anomalies,
+	})
+}
+
+// @Summary		Merge Tags
+// @Tags			Category
+// @Description	Moves transactions and children of the source tags to the tag and deletes the sources
+// @Accept 		json
+// @Produce		json
+// @Param		tagID	path		string					true	"Target tag ID"
+// @Param		tags	body		category.TagMergeInput	true	"source tags"
+// @Success		200		{object}	Response[models.CategoryMerge]	"tags merged"
+// @Failure		400		{object}	ResponseError					"Incorrect Input"
+// @Failure		401		{object}	ResponseError					"auth error relogin"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/tag/{tagID}/merge	[post]
+func (h *Handler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	user, err := response.GetUserFromRequest(r)
 	if err != nil {
-		h.log.WithField(
-			"Request-Id", contextutils.GetReqID(r.Context()),
-		).Errorf("[handler] Delete Error: %v", err)
-		response.ErrorResponse(w, http.StatusBadRequest, err, "Can't Delete tag", h.log)
+		response.ErrorResponse(w, http.StatusUnauthorized, err, response.ErrUnauthorized.Error(), h.log)
+		return
+	}
+
+	targetID, err := response.GetIDFromRequest(tagID, r)
+	if err != nil {
+		response.ErrorResponse(w, http.StatusBadRequest, err, response.InvalidURLParameter, h.log)
+		return
+	}
+
+	var input category.TagMergeInput
+	if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+		response.ErrorResponse(w, http.StatusBadRequest, err, response.InvalidBodyRequest, h.log)
 		return
 	}
+	defer r.Body.Close()
 
-	response.SuccessResponse(w, http.StatusOK, tagId)
+	if len(input.SourceIDs) == 0 {
+		response.ErrorResponse(w, http.StatusBadRequest, errInvalidSources, errInvalidSources.Error(), h.log)
+		return
+	}
+
+	sourceIDs := make([]string, 0, len(input.SourceIDs))
+	for _, id := range input.SourceIDs {
+		sourceIDs = append(sourceIDs, id.String())
+	}
+
+	merge, err := h.client.MergeTags(r.Context(), &genCategory.MergeRequest{
+		UserId:    user.ID.String(),
+		TargetId:  targetID.String(),
+		SourceIds: sourceIDs,
+	})
+	if h.tagError(w, r, err, MergeServerError) {
+		return
+	}
+
+	mergedID, _ := uuid.Parse(merge.TargetId)
+	response.SuccessResponse(w, http.StatusOK, models.CategoryMerge{
+		TargetID:     mergedID,
+		Transactions: merge.Transactions,
+		Children:     merge.Children,
+		Anomalies:    merge.Anomalies,
+	})
+}
+
+// tagError writes the response for an error of the category service, it is false if there is no error
+func (h *Handler) tagError(w http.ResponseWriter, r *http.Request, err error, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	h.log.WithField(
+		"Request-Id", contextutils.GetReqID(r.Context()),
+	).Errorf("[handler] Error: %v", err)
+
+	switch st := status.Convert(err); st.Code() {
+	case codes.NotFound:
+		response.ErrorResponse(w, http.StatusBadRequest, err, TagNotSuch, h.log)
+	case codes.InvalidArgument:
+		response.ErrorResponse(w, http.StatusBadRequest, err, st.Message(), h.log)
+	default:
+		response.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.log)
+	}
+	return true
 }
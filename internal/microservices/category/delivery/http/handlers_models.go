@@ -0,0 +1,71 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const tagID = "tagID"
+
+const (
+	TreeServerError   = "can't get tag tree"
+	MergeServerError  = "can't merge tags"
+	DeleteServerError = "can't delete tag"
+	TagNotSuch        = "no such tag"
+)
+
+// TreeDefaultDays is the period of the totals when start_date is not set
+const TreeDefaultDays = 30
+
+var (
+	errInvalidPeriod  = errors.New("invalid period")
+	errInvalidSources = errors.New("source_ids are required")
+
+	errInvalidStrategy = errors.New("strategy must be refuse, reassign or move_up")
+	errInvalidTarget   = errors.New("target_id is required to reassign")
+)
+
+// getTreePeriod reads start_date and end_date, by default it is the last month up to now
+func getTreePeriod(r *http.Request) (time.Time, time.Time, error) {
+	query, err := commonHttp.GetQueryParam(r)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	endDate := query.EndDate
+	if endDate.IsZero() {
+		endDate = time.Now()
+	}
+
+	startDate := query.StartDate
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, 0, -(TreeDefaultDays - 1))
+	}
+
+	if startDate.After(endDate) {
+		return time.Time{}, time.Time{}, errInvalidPeriod
+	}
+
+	return startDate, endDate, nil
+}
+
+// checkDeleteInput validates the strategy of the deletion
+func checkDeleteInput(input category.TagDeleteInput) error {
+	switch input.Strategy {
+	case "", models.CategoryDeleteRefuse, models.CategoryDeleteMoveUp:
+		return nil
+	case models.CategoryDeleteReassign:
+		if input.TargetID == uuid.Nil {
+			return errInvalidTarget
+		}
+		return nil
+	default:
+		return errInvalidStrategy
+	}
+}
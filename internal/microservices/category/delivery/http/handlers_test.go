@@ -10,11 +10,15 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandler_CreateTag(t *testing.T) {
@@ -297,15 +301,61 @@ func TestHandler_DeleteTag(t *testing.T) {
 			user:         user,
 			tagID:        tagID,
 			expectedCode: http.StatusOK,
-			expectedBody: `{"status":200,"body":{"id":"` + tagID.String() + `"}}`,
+			expectedBody: `{"status":200,"body":{"strategy":"refuse","transactions":0,"unlinked":0,"children":0,"anomalies":0}}`,
 			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
 				mockUsecase.EXPECT().DeleteTag(gomock.Any(), &genCategory.DeleteRequest{
 					TagId:  tagID.String(),
 					UserId: user.ID.String(),
-				}).Return(nil, nil)
+				}).Return(&genCategory.DeleteResponse{Strategy: "refuse"}, nil)
 			},
 			requestPayload: `{"id": "` + tagID.String() + `"}`,
 		},
+		{
+			name:         "Successful Tag Reassign",
+			user:         user,
+			tagID:        tagID,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"strategy":"reassign","transactions":15,"unlinked":0,"children":2,"anomalies":1}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().DeleteTag(gomock.Any(), &genCategory.DeleteRequest{
+					TagId:    tagID.String(),
+					UserId:   user.ID.String(),
+					Strategy: "reassign",
+					TargetId: uuidTest.String(),
+				}).Return(&genCategory.DeleteResponse{Strategy: "reassign", Transactions: 15, Children: 2, Anomalies: 1}, nil)
+			},
+			requestPayload: `{"id": "` + tagID.String() + `", "strategy": "reassign", "target_id": "` + uuidTest.String() + `"}`,
+		},
+		{
+			name:           "Reassign Without Target",
+			user:           user,
+			tagID:          tagID,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"target_id is required to reassign"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockCategoryServiceClient) {},
+			requestPayload: `{"id": "` + tagID.String() + `", "strategy": "reassign"}`,
+		},
+		{
+			name:           "Unknown Strategy",
+			user:           user,
+			tagID:          tagID,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"strategy must be refuse, reassign or move_up"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockCategoryServiceClient) {},
+			requestPayload: `{"id": "` + tagID.String() + `", "strategy": "cascade"}`,
+		},
+		{
+			name:         "Tag In Use",
+			user:         user,
+			tagID:        tagID,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"category is used by 3 transactions and 0 child categories"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().DeleteTag(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "category is used by 3 transactions and 0 child categories"))
+			},
+			requestPayload: `{"id": "` + tagID.String() + `", "strategy": "refuse"}`,
+		},
 		{
 			name:           "Unauthorized Request",
 			user:           nil,
@@ -328,8 +378,8 @@ func TestHandler_DeleteTag(t *testing.T) {
 			name:         "Error in Tag Deletion",
 			user:         user,
 			tagID:        tagID,
-			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"status":400,"message":"Can't Delete tag"}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't delete tag"}`,
 			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
 				mockUsecase.EXPECT().DeleteTag(gomock.Any(), &genCategory.DeleteRequest{
 					TagId:  tagID.String(),
@@ -370,3 +420,216 @@ func TestHandler_DeleteTag(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetTree(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		query         string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(mockUsecase *mocks.MockCategoryServiceClient)
+	}{
+		{
+			name:         "Successful Get Tree",
+			user:         user,
+			query:        "?start_date=2023-11-01T00:00:00Z&end_date=2023-11-30T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[{"income":0,"outcome":100,"total_income":0,"total_outcome":400,"children":[],"id":"` + uuidTest.String() + `","user_id":"` + uuidTest.String() + `","parent_id":"` + uuid.Nil.String() + `","name":"Еда","show_income":false,"show_outcome":true,"regular":false}]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().GetTree(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *genCategory.TreeRequest, _ ...interface{}) (*genCategory.TreeResponse, error) {
+						assert.Equal(t, "2023-11-01T00:00:00Z", in.StartDate.AsTime().Format(time.RFC3339))
+						return &genCategory.TreeResponse{
+							Categories: []*genCategory.CategoryNode{{
+								Category: &genCategory.Category{
+									Id:          uuidTest.String(),
+									UserId:      uuidTest.String(),
+									Name:        "Еда",
+									ShowOutcome: true,
+								},
+								Outcome:      100,
+								TotalOutcome: 400,
+							}},
+						}, nil
+					})
+			},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {},
+		},
+		{
+			name:          "Invalid period",
+			user:          user,
+			query:         "?start_date=2023-11-30T00:00:00Z&end_date=2023-11-01T00:00:00Z",
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {},
+		},
+		{
+			name:         "Error in Get Tree",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get tag tree"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().GetTree(gomock.Any(), gomock.Any()).Return(nil, errors.New("error getting tree"))
+			},
+		},
+		{
+			name:         "Invalid argument in Get Tree",
+			user:         user,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"categories can't be nested deeper than 3 levels"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().GetTree(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "categories can't be nested deeper than 3 levels"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockCategoryServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/tag/tree"+tt.query, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetTree(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_MergeTags(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	targetID := uuid.New()
+	sourceID := uuid.New()
+	tests := []struct {
+		name          string
+		user          *models.User
+		target        string
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(mockUsecase *mocks.MockCategoryServiceClient)
+	}{
+		{
+			name:         "Successful Merge",
+			user:         user,
+			target:       targetID.String(),
+			body:         `{"source_ids":["` + sourceID.String() + `"]}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"target_id":"` + targetID.String() + `","transactions":12,"children":1,"anomalies":2}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().MergeTags(gomock.Any(), &genCategory.MergeRequest{
+					UserId:    uuidTest.String(),
+					TargetId:  targetID.String(),
+					SourceIds: []string{sourceID.String()},
+				}).Return(&genCategory.MergeResponse{TargetId: targetID.String(), Transactions: 12, Children: 1, Anomalies: 2}, nil)
+			},
+		},
+		{
+			name:          "Invalid target",
+			user:          user,
+			target:        "target",
+			body:          `{"source_ids":["` + sourceID.String() + `"]}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {},
+		},
+		{
+			name:          "No sources",
+			user:          user,
+			target:        targetID.String(),
+			body:          `{"source_ids":[]}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"source_ids are required"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {},
+		},
+		{
+			name:         "Tag of another user",
+			user:         user,
+			target:       targetID.String(),
+			body:         `{"source_ids":["` + sourceID.String() + `"]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such tag"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().MergeTags(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "no such category"))
+			},
+		},
+		{
+			name:         "Too deep",
+			user:         user,
+			target:       targetID.String(),
+			body:         `{"source_ids":["` + sourceID.String() + `"]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"categories can't be nested deeper than 3 levels"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().MergeTags(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "categories can't be nested deeper than 3 levels"))
+			},
+		},
+		{
+			name:         "Server error",
+			user:         user,
+			target:       targetID.String(),
+			body:         `{"source_ids":["` + sourceID.String() + `"]}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't merge tags"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockCategoryServiceClient) {
+				mockUsecase.EXPECT().MergeTags(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockCategoryServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/tag/"+tt.target+"/merge", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{tagID: tt.target})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.MergeTags(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 
 	generated "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category/delivery/grpc/generated"
 	gomock "github.com/golang/mock/gomock"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 )
 
@@ -58,14 +57,14 @@ func (mr *MockCategoryServiceClientMockRecorder) CreateTag(ctx, in interface{},
 }
 
 // DeleteTag mocks base method.
-func (m *MockCategoryServiceClient) DeleteTag(ctx context.Context, in *generated.DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (m *MockCategoryServiceClient) DeleteTag(ctx context.Context, in *generated.DeleteRequest, opts ...grpc.CallOption) (*generated.DeleteResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "DeleteTag", varargs...)
-	ret0, _ := ret[0].(*empty.Empty)
+	ret0, _ := ret[0].(*generated.DeleteResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -97,6 +96,46 @@ func (mr *MockCategoryServiceClientMockRecorder) GetTags(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockCategoryServiceClient)(nil).GetTags), varargs...)
 }
 
+// GetTree mocks base method.
+func (m *MockCategoryServiceClient) GetTree(ctx context.Context, in *generated.TreeRequest, opts ...grpc.CallOption) (*generated.TreeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTree", varargs...)
+	ret0, _ := ret[0].(*generated.TreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockCategoryServiceClientMockRecorder) GetTree(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockCategoryServiceClient)(nil).GetTree), varargs...)
+}
+
+// MergeTags mocks base method.
+func (m *MockCategoryServiceClient) MergeTags(ctx context.Context, in *generated.MergeRequest, opts ...grpc.CallOption) (*generated.MergeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergeTags", varargs...)
+	ret0, _ := ret[0].(*generated.MergeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockCategoryServiceClientMockRecorder) MergeTags(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockCategoryServiceClient)(nil).MergeTags), varargs...)
+}
+
 // UpdateTag mocks base method.
 func (m *MockCategoryServiceClient) UpdateTag(ctx context.Context, in *generated.Category, opts ...grpc.CallOption) (*generated.Category, error) {
 	m.ctrl.T.Helper()
@@ -156,10 +195,10 @@ func (mr *MockCategoryServiceServerMockRecorder) CreateTag(arg0, arg1 interface{
 }
 
 // DeleteTag mocks base method.
-func (m *MockCategoryServiceServer) DeleteTag(arg0 context.Context, arg1 *generated.DeleteRequest) (*empty.Empty, error) {
+func (m *MockCategoryServiceServer) DeleteTag(arg0 context.Context, arg1 *generated.DeleteRequest) (*generated.DeleteResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1)
-	ret0, _ := ret[0].(*empty.Empty)
+	ret0, _ := ret[0].(*generated.DeleteResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -185,6 +224,36 @@ func (mr *MockCategoryServiceServerMockRecorder) GetTags(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockCategoryServiceServer)(nil).GetTags), arg0, arg1)
 }
 
+// GetTree mocks base method.
+func (m *MockCategoryServiceServer) GetTree(arg0 context.Context, arg1 *generated.TreeRequest) (*generated.TreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", arg0, arg1)
+	ret0, _ := ret[0].(*generated.TreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockCategoryServiceServerMockRecorder) GetTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockCategoryServiceServer)(nil).GetTree), arg0, arg1)
+}
+
+// MergeTags mocks base method.
+func (m *MockCategoryServiceServer) MergeTags(arg0 context.Context, arg1 *generated.MergeRequest) (*generated.MergeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0, arg1)
+	ret0, _ := ret[0].(*generated.MergeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockCategoryServiceServerMockRecorder) MergeTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockCategoryServiceServer)(nil).MergeTags), arg0, arg1)
+}
+
 // UpdateTag mocks base method.
 func (m *MockCategoryServiceServer) UpdateTag(arg0 context.Context, arg1 *generated.Category) (*generated.Category, error) {
 	m.ctrl.T.Helper()
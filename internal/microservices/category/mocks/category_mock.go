@@ -7,6 +7,7 @@ package mock_category
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	category "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category"
 	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
@@ -53,17 +54,18 @@ func (mr *MockUsecaseMockRecorder) CreateTag(ctx, tag interface{}) *gomock.Call
 }
 
 // DeleteTag mocks base method.
-func (m *MockUsecase) DeleteTag(ctx context.Context, tagId, userId uuid.UUID) error {
+func (m *MockUsecase) DeleteTag(ctx context.Context, tagId, userId uuid.UUID, strategy string, targetId uuid.UUID) (models.CategoryDeletion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTag", ctx, tagId, userId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, tagId, userId, strategy, targetId)
+	ret0, _ := ret[0].(models.CategoryDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteTag indicates an expected call of DeleteTag.
-func (mr *MockUsecaseMockRecorder) DeleteTag(ctx, tagId, userId interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) DeleteTag(ctx, tagId, userId, strategy, targetId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockUsecase)(nil).DeleteTag), ctx, tagId, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockUsecase)(nil).DeleteTag), ctx, tagId, userId, strategy, targetId)
 }
 
 // GetTags mocks base method.
@@ -81,6 +83,36 @@ func (mr *MockUsecaseMockRecorder) GetTags(ctx, userId interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockUsecase)(nil).GetTags), ctx, userId)
 }
 
+// GetTree mocks base method.
+func (m *MockUsecase) GetTree(ctx context.Context, userId uuid.UUID, startDate, endDate time.Time) ([]models.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx, userId, startDate, endDate)
+	ret0, _ := ret[0].([]models.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockUsecaseMockRecorder) GetTree(ctx, userId, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockUsecase)(nil).GetTree), ctx, userId, startDate, endDate)
+}
+
+// MergeTags mocks base method.
+func (m *MockUsecase) MergeTags(ctx context.Context, userId, targetId uuid.UUID, sourceIds []uuid.UUID) (models.CategoryMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, userId, targetId, sourceIds)
+	ret0, _ := ret[0].(models.CategoryMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockUsecaseMockRecorder) MergeTags(ctx, userId, targetId, sourceIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockUsecase)(nil).MergeTags), ctx, userId, targetId, sourceIds)
+}
+
 // UpdateTag mocks base method.
 func (m *MockUsecase) UpdateTag(ctx context.Context, tag *models.Category) error {
 	m.ctrl.T.Helper()
@@ -164,11 +196,12 @@ func (mr *MockRepositoryMockRecorder) CreateTag(ctx, category interface{}) *gomo
 }
 
 // DeleteTag mocks base method.
-func (m *MockRepository) DeleteTag(ctx context.Context, tagId uuid.UUID) error {
+func (m *MockRepository) DeleteTag(ctx context.Context, tagId uuid.UUID) (models.CategoryDeletion, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteTag", ctx, tagId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(models.CategoryDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteTag indicates an expected call of DeleteTag.
@@ -192,6 +225,52 @@ func (mr *MockRepositoryMockRecorder) GetTags(ctx, userId interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockRepository)(nil).GetTags), ctx, userId)
 }
 
+// GetTotals mocks base method.
+func (m *MockRepository) GetTotals(ctx context.Context, userId uuid.UUID, startDate, endDate time.Time) ([]models.CategoryTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotals", ctx, userId, startDate, endDate)
+	ret0, _ := ret[0].([]models.CategoryTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotals indicates an expected call of GetTotals.
+func (mr *MockRepositoryMockRecorder) GetTotals(ctx, userId, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotals", reflect.TypeOf((*MockRepository)(nil).GetTotals), ctx, userId, startDate, endDate)
+}
+
+// GetUsage mocks base method.
+func (m *MockRepository) GetUsage(ctx context.Context, tagId uuid.UUID) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, tagId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockRepositoryMockRecorder) GetUsage(ctx, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockRepository)(nil).GetUsage), ctx, tagId)
+}
+
+// MergeTags mocks base method.
+func (m *MockRepository) MergeTags(ctx context.Context, targetId uuid.UUID, sourceIds []uuid.UUID) (models.CategoryMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, targetId, sourceIds)
+	ret0, _ := ret[0].(models.CategoryMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockRepositoryMockRecorder) MergeTags(ctx, targetId, sourceIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockRepository)(nil).MergeTags), ctx, targetId, sourceIds)
+}
+
 // UpdateTag mocks base method.
 func (m *MockRepository) UpdateTag(ctx context.Context, tag *models.Category) error {
 	m.ctrl.T.Helper()
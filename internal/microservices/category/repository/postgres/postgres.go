@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
@@ -23,6 +25,18 @@ const (
 
 	CategoryDelete = "DELETE FROM category WHERE id = $1;"
 
+	CategoryUsage = `SELECT (SELECT COUNT(*) FROM TransactionCategory WHERE category_id = $1),
+							(SELECT COUNT(*) FROM category WHERE parent_tag = $1);`
+
+	CategoryLinksCount = `SELECT COUNT(*) FROM TransactionCategory WHERE category_id = $1;`
+
+	CategoryChildrenRoot = `UPDATE category SET parent_tag = NULL WHERE parent_tag = $1;`
+
+	CategoryOwnDailyTotalsDelete = `DELETE FROM DailyTotals WHERE category_id = $1;`
+
+	// the anomalies of the category are deleted with it by the cascade
+	CategoryAnomaliesCount = `SELECT COUNT(*) FROM Anomaly WHERE category_id = $1;`
+
 	CategoeyAll = `SELECT * FROM category WHERE user_id = $1;`
 
 	CategoryNameCheck = `SELECT EXISTS (
@@ -35,7 +49,42 @@ const (
 						WHERE user_id = $1 AND id = $2
 					);`
 
-	// transactionAssociationDelete = "DELETE FROM TransactionCategory WHERE category_id = $1;"
+	// totals of the own transactions of every category, transfers are not in daily totals
+	CategoryTotalsGet = `SELECT c.id, c.name, SUM(d.income), SUM(d.outcome)
+						 FROM DailyTotals d
+						 JOIN category c ON c.id = d.category_id
+						 WHERE c.user_id = $1
+						 AND d.account_id IN (SELECT account_id FROM UserAccount WHERE user_id = $1)
+						 AND d.day BETWEEN $2 AND $3
+						 GROUP BY c.id, c.name;`
+
+	// moves the links of the sources to the target, a transaction already linked to it keeps one link
+	CategoryLinksMove = `WITH moved AS (
+							DELETE FROM TransactionCategory WHERE category_id = ANY($2::uuid[])
+							RETURNING transaction_id
+						 ), linked AS (
+							INSERT INTO TransactionCategory (transaction_id, category_id)
+							SELECT DISTINCT transaction_id, $1::uuid FROM moved
+							ON CONFLICT DO NOTHING
+						 )
+						 SELECT COUNT(DISTINCT transaction_id) FROM moved;`
+
+	CategoryChildrenMove = `UPDATE category SET parent_tag = $1 WHERE parent_tag = ANY($2::uuid[]) AND id <> ALL($2::uuid[]);`
+
+	CategoryAnomaliesMove = `UPDATE Anomaly SET category_id = $1 WHERE category_id = ANY($2::uuid[]);`
+
+	CategoryDailyTotalsDelete = `DELETE FROM DailyTotals WHERE category_id = ANY($1::uuid[]);`
+
+	// counts the category totals again from its transactions, the way they are written with a transaction
+	CategoryDailyTotalsRebuild = `INSERT INTO DailyTotals (user_id, account_id, category_id, day, income, outcome)
+								  SELECT t.user_id, t.account_income, tc.category_id, t.date::date,
+									  SUM(COALESCE(t.income, 0)), SUM(COALESCE(t.outcome, 0))
+								  FROM Transaction t
+								  JOIN TransactionCategory tc ON tc.transaction_id = t.id
+								  WHERE tc.category_id = $1 AND t.account_income = t.account_outcome AND t.user_id IS NOT NULL
+								  GROUP BY t.user_id, t.account_income, tc.category_id, t.date::date;`
+
+	CategoryDeleteMany = `DELETE FROM category WHERE id = ANY($1::uuid[]);`
 )
 
 type Repository struct {
@@ -104,44 +153,64 @@ func (r *Repository) UpdateTag(ctx context.Context, tag *models.Category) error
 	return nil
 }
 
-func (r *Repository) DeleteTag(ctx context.Context, tagId uuid.UUID) error {
-	/*var exists bool
-
-	row := r.db.QueryRow(ctx, CategoryGet, tagId)
-	err := row.Scan(&exists)
-	if errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("[repo] tag doesn't exist Error: %v", err)
-	} else if err != nil {
-		return fmt.Errorf("[repo] failed request db %s, %w", CategoryGet, err)
-	}
-	*/
+// DeleteTag deletes the category, its children become roots, its transactions are left without it and its anomalies are deleted
+func (r *Repository) DeleteTag(ctx context.Context, tagId uuid.UUID) (models.CategoryDeletion, error) {
+	var deletion models.CategoryDeletion
 
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("[repo] failed to start db transaction: %w", err)
+		return deletion, fmt.Errorf("[repo] failed to start db transaction: %w", err)
 	}
 
 	defer func() {
 		if err != nil {
-			if err = tx.Rollback(ctx); err != nil {
-				r.log.Fatal("Rollback transaction Error: %w", err)
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.log.Errorf("[repo] rollback failed: %v", errRollback)
 			}
 		}
 	}()
 
-	//if err = r.deleteTransactionAssociations(ctx, tx, tagId); err != nil {
-	//	return err
-	//}
+	if err = tx.QueryRow(ctx, CategoryLinksCount, tagId).Scan(&deletion.Unlinked); err != nil {
+		err = fmt.Errorf("[repo] failed to count category transactions: %w", err)
+		return deletion, err
+	}
 
-	_, err = r.db.Exec(ctx, CategoryDelete, tagId)
+	children, err := tx.Exec(ctx, CategoryChildrenRoot, tagId)
 	if err != nil {
-		return fmt.Errorf("[repo] failed to delete category %s, %w", CategoryDelete, err)
+		err = fmt.Errorf("[repo] failed to move children: %w", err)
+		return deletion, err
+	}
+	deletion.Children = children.RowsAffected()
+
+	if err = tx.QueryRow(ctx, CategoryAnomaliesCount, tagId).Scan(&deletion.Anomalies); err != nil {
+		err = fmt.Errorf("[repo] failed to count category anomalies: %w", err)
+		return deletion, err
+	}
+
+	if _, err = tx.Exec(ctx, CategoryOwnDailyTotalsDelete, tagId); err != nil {
+		err = fmt.Errorf("[repo] failed to delete daily totals: %w", err)
+		return deletion, err
+	}
+
+	if _, err = tx.Exec(ctx, CategoryDelete, tagId); err != nil {
+		err = fmt.Errorf("[repo] failed to delete category %s, %w", CategoryDelete, err)
+		return deletion, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("[repo] failed to commit db transaction: %w", err)
+		err = fmt.Errorf("[repo] failed to commit db transaction: %w", err)
+		return deletion, err
 	}
-	return nil
+	return deletion, nil
+}
+
+// GetUsage counts the transactions and the children of the category
+func (r *Repository) GetUsage(ctx context.Context, tagId uuid.UUID) (int64, int64, error) {
+	var transactions, children int64
+	if err := r.db.QueryRow(ctx, CategoryUsage, tagId).Scan(&transactions, &children); err != nil {
+		return 0, 0, fmt.Errorf("[repo] failed to get category usage: %w", err)
+	}
+	return transactions, children, nil
 }
 
 func (r *Repository) GetTags(ctx context.Context, userID uuid.UUID) ([]models.Category, error) {
@@ -180,6 +249,93 @@ func (r *Repository) GetTags(ctx context.Context, userID uuid.UUID) ([]models.Ca
 	return categories, nil
 }
 
+func (r *Repository) GetTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.CategoryTotal, error) {
+	totals := []models.CategoryTotal{}
+
+	rows, err := r.db.Query(ctx, CategoryTotalsGet, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to get category totals: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var total models.CategoryTotal
+		if err := rows.Scan(&total.ID, &total.Name, &total.Income, &total.Outcome); err != nil {
+			return nil, fmt.Errorf("[repo] failed to scan category total: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] category totals rows error: %w", err)
+	}
+	return totals, nil
+}
+
+// MergeTags moves the transactions, the children and the anomalies of the sources to the target and deletes the sources
+func (r *Repository) MergeTags(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID) (models.CategoryMerge, error) {
+	merge := models.CategoryMerge{TargetID: targetID}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return merge, fmt.Errorf("[repo] failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.log.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	if err = tx.QueryRow(ctx, CategoryLinksMove, targetID, sourceIDs).Scan(&merge.Transactions); err != nil {
+		err = fmt.Errorf("[repo] failed to move transactions: %w", err)
+		return merge, err
+	}
+
+	children, err := tx.Exec(ctx, CategoryChildrenMove, targetID, sourceIDs)
+	if err != nil {
+		err = fmt.Errorf("[repo] failed to move children: %w", err)
+		return merge, err
+	}
+	merge.Children = children.RowsAffected()
+
+	anomalies, err := tx.Exec(ctx, CategoryAnomaliesMove, targetID, sourceIDs)
+	if err != nil {
+		err = fmt.Errorf("[repo] failed to move anomalies: %w", err)
+		return merge, err
+	}
+	merge.Anomalies = anomalies.RowsAffected()
+
+	if err = r.rebuildDailyTotals(ctx, tx, targetID, append([]uuid.UUID{targetID}, sourceIDs...)); err != nil {
+		return merge, err
+	}
+
+	if _, err = tx.Exec(ctx, CategoryDeleteMany, sourceIDs); err != nil {
+		err = fmt.Errorf("[repo] failed to delete merged categories: %w", err)
+		return merge, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		err = fmt.Errorf("[repo] failed to commit db transaction: %w", err)
+		return merge, err
+	}
+	return merge, nil
+}
+
+// rebuildDailyTotals drops the daily totals of the categories and counts the ones of the target again
+func (r *Repository) rebuildDailyTotals(ctx context.Context, tx pgx.Tx, targetID uuid.UUID, categoryIDs []uuid.UUID) error {
+	if _, err := tx.Exec(ctx, CategoryDailyTotalsDelete, categoryIDs); err != nil {
+		return fmt.Errorf("[repo] failed to delete daily totals: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, CategoryDailyTotalsRebuild, targetID); err != nil {
+		return fmt.Errorf("[repo] failed to rebuild daily totals: %w", err)
+	}
+	return nil
+}
+
 func (r *Repository) CheckNameUniq(ctx context.Context, userId uuid.UUID, parentId uuid.UUID, name string) (bool, error) {
 	var exist bool
 	err := r.db.QueryRow(ctx, CategoryNameCheck, userId, parentId, name).Scan(&exist)
@@ -205,12 +361,3 @@ func (r *Repository) CheckExist(ctx context.Context, userId uuid.UUID, tagId uui
 	}
 	return true, nil
 }
-
-//
-// func (r *Repository) deleteTransactionAssociations(ctx context.Context, tx pgx.Tx, tagID uuid.UUID) error {
-// 	_, err := tx.Exec(ctx, transactionAssociationDelete, tagID)
-// 	if err != nil {
-// 		return fmt.Errorf("[repo] failed to delete existing transaction associations: %w", err)
-// 	}
-// 	return nil
-// }
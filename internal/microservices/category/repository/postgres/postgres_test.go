@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
@@ -132,82 +133,103 @@ func Test_UpdateTag(t *testing.T) {
 	}
 }
 
-/*
-	func Test_DeleteTag(t *testing.T) {
-		testCases := []struct {
-			name        string
-			tagID       uuid.UUID
-			rowExists   bool
-			execError   error
-			expectedErr error
-			sqlNoRows   error
-			transaction bool
-		}{
-			{
-				name:        "Success",
-				tagID:       uuid.New(),
-				rowExists:   true,
-				execError:   nil,
-				expectedErr: nil,
-				transaction: true,
-			},
-			{
-				name:        "TagNotFound",
-				tagID:       uuid.New(),
-				rowExists:   false,
-				execError:   nil,
-				expectedErr: fmt.Errorf("[repo] tag doesn't exist Error: %v", sql.ErrNoRows),
-				sqlNoRows:   sql.ErrNoRows,
-				transaction: false,
-			},
-			{
-				name:        "DeleteError",
-				tagID:       uuid.New(),
-				rowExists:   true,
-				execError:   errors.New("some database error"),
-				expectedErr: fmt.Errorf("[repo] failed to delete category %s, %w", CategoryDelete, errors.New("some database error")),
-				transaction: false,
-			},
-		}
+func Test_DeleteTag(t *testing.T) {
+	tagID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		deleteErr error
+		expected  models.CategoryDeletion
+		err       error
+	}{
+		{
+			name:     "Success",
+			expected: models.CategoryDeletion{Unlinked: 4, Children: 2, Anomalies: 1},
+		},
+		{
+			name:      "DeleteError",
+			deleteErr: errors.New("some database error"),
+			expected:  models.CategoryDeletion{Unlinked: 4, Children: 2, Anomalies: 1},
+			err:       fmt.Errorf("[repo] failed to delete category %s, %w", CategoryDelete, errors.New("some database error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
 
-		for _, tc := range testCases {
-			t.Run(tc.name, func(t *testing.T) {
-				mock, _ := pgxmock.NewPool()
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
 
-				logger := *logger.NewLogger(context.TODO())
-				repo := NewRepository(mock, logger)
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(CategoryLinksCount)).
+				WithArgs(tagID).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(4)))
+			mock.ExpectExec(regexp.QuoteMeta(CategoryChildrenRoot)).
+				WithArgs(tagID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+			mock.ExpectQuery(regexp.QuoteMeta(CategoryAnomaliesCount)).
+				WithArgs(tagID).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(1)))
+			mock.ExpectExec(regexp.QuoteMeta(CategoryOwnDailyTotalsDelete)).
+				WithArgs(tagID).
+				WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+			if tc.deleteErr != nil {
+				mock.ExpectExec(regexp.QuoteMeta(CategoryDelete)).
+					WithArgs(tagID).
+					WillReturnError(tc.deleteErr)
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(CategoryDelete)).
+					WithArgs(tagID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mock.ExpectCommit()
+			}
 
-				escapedQuery := regexp.QuoteMeta(CategoryGet)
-				mock.ExpectQuery(escapedQuery).
-					WithArgs(tc.tagID).
-					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(tc.rowExists)).
-					WillReturnError(tc.sqlNoRows)
+			deletion, err := repo.DeleteTag(context.Background(), tagID)
+
+			if (tc.err == nil && err != nil) || (tc.err != nil && err == nil) || (tc.err != nil && err != nil && tc.err.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.err, err)
+			}
 
-				mock.ExpectBegin()
-				if tc.transaction {
-					mock.ExpectCommit()
-				} else {
-					mock.ExpectRollback()
-				}
-
-				escapedQuery = regexp.QuoteMeta(CategoryDelete)
-				mock.ExpectExec(escapedQuery).
-					WithArgs(tc.tagID).
-					WillReturnResult(pgxmock.NewResult("DELETE", 1)).
-					WillReturnError(tc.execError)
-
-				err := repo.DeleteTag(context.Background(), tc.tagID)
-				if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
-					t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
-				}
-
-				if err := mock.ExpectationsWereMet(); err != nil {
-					t.Errorf("There were unfulfilled expectations: %s", err)
-				}
-			})
-		}
+			if !reflect.DeepEqual(tc.expected, deletion) {
+				t.Errorf("Expected deletion: %v, got: %v", tc.expected, deletion)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetUsage(t *testing.T) {
+	tagID := uuid.New()
+
+	mock, _ := pgxmock.NewPool()
+
+	logger := *logger.NewLogger(context.TODO())
+	repo := NewRepository(mock, logger)
+
+	mock.ExpectQuery(regexp.QuoteMeta(CategoryUsage)).
+		WithArgs(tagID).
+		WillReturnRows(pgxmock.NewRows([]string{"transactions", "children"}).AddRow(int64(3), int64(1)))
+
+	transactions, children, err := repo.GetUsage(context.Background(), tagID)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
-*/
+	if transactions != 3 || children != 1 {
+		t.Errorf("Expected usage 3 and 1, got: %d and %d", transactions, children)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
 func Test_GetTags(t *testing.T) {
 	tagId := uuid.New()
 	userId := uuid.New()
@@ -436,3 +458,140 @@ func Test_CheckExist(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetTotals(t *testing.T) {
+	userID := uuid.New()
+	startDate := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC)
+	total := models.CategoryTotal{ID: uuid.New(), Name: "Кафе", Income: 0, Outcome: 1500}
+
+	testCases := []struct {
+		name     string
+		rows     *pgxmock.Rows
+		rowsErr  error
+		expected []models.CategoryTotal
+		err      error
+	}{
+		{
+			name:     "Success",
+			rows:     pgxmock.NewRows([]string{"id", "name", "income", "outcome"}).AddRow(total.ID, total.Name, total.Income, total.Outcome),
+			expected: []models.CategoryTotal{total},
+		},
+		{
+			name:     "No transactions",
+			rows:     pgxmock.NewRows([]string{"id", "name", "income", "outcome"}),
+			expected: []models.CategoryTotal{},
+		},
+		{
+			name:    "Query error",
+			rowsErr: errors.New("some database error"),
+			err:     fmt.Errorf("[repo] failed to get category totals: %w", errors.New("some database error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			expect := mock.ExpectQuery(regexp.QuoteMeta(CategoryTotalsGet)).
+				WithArgs(userID, startDate, endDate)
+			if tc.rowsErr != nil {
+				expect.WillReturnError(tc.rowsErr)
+			} else {
+				expect.WillReturnRows(tc.rows)
+			}
+
+			totals, err := repo.GetTotals(context.Background(), userID, startDate, endDate)
+
+			if (tc.err == nil && err != nil) || (tc.err != nil && err == nil) || (tc.err != nil && err != nil && tc.err.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, totals) {
+				t.Errorf("Expected totals: %v, got: %v", tc.expected, totals)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_MergeTags(t *testing.T) {
+	targetID := uuid.New()
+	sourceIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	testCases := []struct {
+		name      string
+		childErr  error
+		expected  models.CategoryMerge
+		expectErr bool
+	}{
+		{
+			name:     "Success",
+			expected: models.CategoryMerge{TargetID: targetID, Transactions: 7, Children: 2, Anomalies: 3},
+		},
+		{
+			name:      "Children move error",
+			childErr:  errors.New("some database error"),
+			expected:  models.CategoryMerge{TargetID: targetID, Transactions: 7},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(CategoryLinksMove)).
+				WithArgs(targetID, sourceIDs).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(7)))
+
+			if tc.childErr != nil {
+				mock.ExpectExec(regexp.QuoteMeta(CategoryChildrenMove)).
+					WithArgs(targetID, sourceIDs).
+					WillReturnError(tc.childErr)
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(CategoryChildrenMove)).
+					WithArgs(targetID, sourceIDs).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+				mock.ExpectExec(regexp.QuoteMeta(CategoryAnomaliesMove)).
+					WithArgs(targetID, sourceIDs).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+				mock.ExpectExec(regexp.QuoteMeta(CategoryDailyTotalsDelete)).
+					WithArgs(append([]uuid.UUID{targetID}, sourceIDs...)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 5))
+				mock.ExpectExec(regexp.QuoteMeta(CategoryDailyTotalsRebuild)).
+					WithArgs(targetID).
+					WillReturnResult(pgxmock.NewResult("INSERT", 3))
+				mock.ExpectExec(regexp.QuoteMeta(CategoryDeleteMany)).
+					WithArgs(sourceIDs).
+					WillReturnResult(pgxmock.NewResult("DELETE", 2))
+				mock.ExpectCommit()
+			}
+
+			merge, err := repo.MergeTags(context.Background(), targetID, sourceIDs)
+
+			if tc.expectErr != (err != nil) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectErr, err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, merge) {
+				t.Errorf("Expected merge: %v, got: %v", tc.expected, merge)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
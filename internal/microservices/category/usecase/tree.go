@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+// checkHierarchy fails if the parent is the category itself or one of its descendants,
+// or if the category with its subtree would go deeper than CategoryMaxDepth
+func checkHierarchy(tags []models.Category, tagID, parentID uuid.UUID) error {
+	if parentID == uuid.Nil {
+		return nil
+	}
+
+	parents := make(map[uuid.UUID]uuid.UUID, len(tags))
+	children := make(map[uuid.UUID][]uuid.UUID, len(tags))
+	for _, tag := range tags {
+		parents[tag.ID] = tag.ParentID
+		children[tag.ParentID] = append(children[tag.ParentID], tag.ID)
+	}
+
+	if _, ok := parents[parentID]; !ok {
+		return &models.CategoryHierarchyError{Reason: "parent category doesn't exist"}
+	}
+
+	// level of the parent, the chain is cut at the number of categories in case it is already looped
+	level := 0
+	for id := parentID; id != uuid.Nil && level <= len(tags); id = parents[id] {
+		if id == tagID {
+			return &models.CategoryHierarchyError{Reason: "category can't be nested into itself"}
+		}
+		level++
+	}
+
+	depth := level + 1 + subtreeHeight(children, tagID)
+	if depth > models.CategoryMaxDepth {
+		return &models.CategoryHierarchyError{
+			Reason: fmt.Sprintf("categories can't be nested deeper than %d levels", models.CategoryMaxDepth),
+		}
+	}
+	return nil
+}
+
+// subtreeHeight is the number of levels below the category, zero for a new one
+func subtreeHeight(children map[uuid.UUID][]uuid.UUID, tagID uuid.UUID) int {
+	if tagID == uuid.Nil {
+		return 0
+	}
+
+	height := 0
+	visited := map[uuid.UUID]bool{tagID: true}
+	for level := children[tagID]; ; height++ {
+		var next []uuid.UUID
+		seen := false
+		for _, id := range level {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			seen = true
+			next = append(next, children[id]...)
+		}
+		if !seen {
+			return height
+		}
+		level = next
+	}
+}
+
+// buildTree nests the categories under their parents and rolls the totals up to the roots,
+// a category with a missing parent or left in a loop becomes a root
+func buildTree(tags []models.Category, totals []models.CategoryTotal) []models.CategoryNode {
+	byID := make(map[uuid.UUID]bool, len(tags))
+	for _, tag := range tags {
+		byID[tag.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]models.Category, len(tags))
+	var roots []models.Category
+	for _, tag := range tags {
+		if tag.ParentID == uuid.Nil || !byID[tag.ParentID] {
+			roots = append(roots, tag)
+			continue
+		}
+		children[tag.ParentID] = append(children[tag.ParentID], tag)
+	}
+
+	own := make(map[uuid.UUID]models.CategoryTotal, len(totals))
+	for _, total := range totals {
+		own[total.ID] = total
+	}
+
+	visited := make(map[uuid.UUID]bool, len(tags))
+	var build func(tag models.Category) models.CategoryNode
+	build = func(tag models.Category) models.CategoryNode {
+		visited[tag.ID] = true
+
+		node := models.CategoryNode{
+			Category: tag,
+			Income:   own[tag.ID].Income,
+			Outcome:  own[tag.ID].Outcome,
+			Children: []models.CategoryNode{},
+		}
+		node.TotalIncome = node.Income
+		node.TotalOutcome = node.Outcome
+
+		for _, child := range children[tag.ID] {
+			if visited[child.ID] {
+				continue
+			}
+			childNode := build(child)
+			node.TotalIncome += childNode.TotalIncome
+			node.TotalOutcome += childNode.TotalOutcome
+			node.Children = append(node.Children, childNode)
+		}
+		return node
+	}
+
+	tree := make([]models.CategoryNode, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+
+	for _, tag := range tags {
+		if !visited[tag.ID] {
+			tree = append(tree, build(tag))
+		}
+	}
+	return tree
+}
+
+// checkMerge fails if the target is inside one of the sources or if the children
+// of the sources would go deeper than CategoryMaxDepth under the target
+func checkMerge(tags []models.Category, targetID uuid.UUID, sourceIDs []uuid.UUID) error {
+	sources := make(map[uuid.UUID]bool, len(sourceIDs))
+	for _, id := range sourceIDs {
+		sources[id] = true
+	}
+
+	parents := make(map[uuid.UUID]uuid.UUID, len(tags))
+	for _, tag := range tags {
+		parents[tag.ID] = tag.ParentID
+	}
+
+	for id, steps := parents[targetID], 0; id != uuid.Nil && steps <= len(tags); id, steps = parents[id], steps+1 {
+		if sources[id] {
+			return &models.CategoryHierarchyError{Reason: "category can't be merged into its child"}
+		}
+	}
+
+	merged := make([]models.Category, 0, len(tags))
+	for _, tag := range tags {
+		if sources[tag.ID] {
+			continue
+		}
+		if sources[tag.ParentID] {
+			tag.ParentID = targetID
+		}
+		merged = append(merged, tag)
+	}
+
+	children := make(map[uuid.UUID][]uuid.UUID, len(merged))
+	for _, tag := range merged {
+		children[tag.ParentID] = append(children[tag.ParentID], tag.ID)
+	}
+
+	level := 0
+	for id, steps := targetID, 0; id != uuid.Nil && steps <= len(tags); id, steps = parents[id], steps+1 {
+		level++
+	}
+
+	if level+subtreeHeight(children, targetID) > models.CategoryMaxDepth {
+		return &models.CategoryHierarchyError{
+			Reason: fmt.Sprintf("categories can't be nested deeper than %d levels", models.CategoryMaxDepth),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHierarchy(t *testing.T) {
+	food := models.Category{ID: uuid.New(), Name: "Еда"}
+	cafe := models.Category{ID: uuid.New(), ParentID: food.ID, Name: "Кафе"}
+	coffee := models.Category{ID: uuid.New(), ParentID: cafe.ID, Name: "Кофе"}
+	taxi := models.Category{ID: uuid.New(), Name: "Такси"}
+	tags := []models.Category{food, cafe, coffee, taxi}
+
+	testCases := []struct {
+		name     string
+		tagID    uuid.UUID
+		parentID uuid.UUID
+		reason   string
+	}{
+		{
+			name: "Root category",
+		},
+		{
+			name:     "New category on the second level",
+			parentID: food.ID,
+		},
+		{
+			name:     "New category too deep",
+			parentID: coffee.ID,
+			reason:   "categories can't be nested deeper than 3 levels",
+		},
+		{
+			name:     "Missing parent",
+			parentID: uuid.New(),
+			reason:   "parent category doesn't exist",
+		},
+		{
+			name:     "Parent is the category itself",
+			tagID:    cafe.ID,
+			parentID: cafe.ID,
+			reason:   "category can't be nested into itself",
+		},
+		{
+			name:     "Parent is a descendant",
+			tagID:    food.ID,
+			parentID: coffee.ID,
+			reason:   "category can't be nested into itself",
+		},
+		{
+			name:     "Subtree moved too deep",
+			tagID:    food.ID,
+			parentID: taxi.ID,
+			reason:   "categories can't be nested deeper than 3 levels",
+		},
+		{
+			name:     "Leaf moved under another root",
+			tagID:    coffee.ID,
+			parentID: taxi.ID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkHierarchy(tags, tc.tagID, tc.parentID)
+
+			if tc.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errHierarchy *models.CategoryHierarchyError
+			if assert.ErrorAs(t, err, &errHierarchy) {
+				assert.Equal(t, tc.reason, errHierarchy.Reason)
+			}
+		})
+	}
+}
+
+func TestBuildTree(t *testing.T) {
+	food := models.Category{ID: uuid.New(), Name: "Еда"}
+	cafe := models.Category{ID: uuid.New(), ParentID: food.ID, Name: "Кафе"}
+	coffee := models.Category{ID: uuid.New(), ParentID: cafe.ID, Name: "Кофе"}
+	orphan := models.Category{ID: uuid.New(), ParentID: uuid.New(), Name: "Такси"}
+
+	totals := []models.CategoryTotal{
+		{ID: food.ID, Outcome: 1000},
+		{ID: cafe.ID, Outcome: 500},
+		{ID: coffee.ID, Income: 50, Outcome: 200},
+	}
+
+	tree := buildTree([]models.Category{coffee, cafe, food, orphan}, totals)
+
+	if !assert.Len(t, tree, 2) {
+		return
+	}
+
+	root := tree[0]
+	assert.Equal(t, food.ID, root.ID)
+	assert.Equal(t, 1000.0, root.Outcome)
+	assert.Equal(t, 1700.0, root.TotalOutcome)
+	assert.Equal(t, 50.0, root.TotalIncome)
+	if assert.Len(t, root.Children, 1) {
+		assert.Equal(t, 700.0, root.Children[0].TotalOutcome)
+		if assert.Len(t, root.Children[0].Children, 1) {
+			assert.Equal(t, coffee.ID, root.Children[0].Children[0].ID)
+			assert.Empty(t, root.Children[0].Children[0].Children)
+		}
+	}
+
+	assert.Equal(t, orphan.ID, tree[1].ID)
+	assert.Zero(t, tree[1].TotalOutcome)
+}
+
+func TestBuildTree_Loop(t *testing.T) {
+	first := models.Category{ID: uuid.New(), Name: "Первая"}
+	second := models.Category{ID: uuid.New(), ParentID: first.ID, Name: "Вторая"}
+	first.ParentID = second.ID
+
+	tree := buildTree([]models.Category{first, second}, nil)
+
+	if assert.Len(t, tree, 1) {
+		assert.Equal(t, first.ID, tree[0].ID)
+		assert.Len(t, tree[0].Children, 1)
+	}
+}
+
+func TestCheckMerge(t *testing.T) {
+	cafes := models.Category{ID: uuid.New(), Name: "Кафе"}
+	restaurants := models.Category{ID: uuid.New(), Name: "Рестораны"}
+	cafesAndRestaurants := models.Category{ID: uuid.New(), Name: "Кафе и рестораны"}
+	coffee := models.Category{ID: uuid.New(), ParentID: cafes.ID, Name: "Кофе"}
+	beans := models.Category{ID: uuid.New(), ParentID: coffee.ID, Name: "Зерно"}
+	food := models.Category{ID: uuid.New(), Name: "Еда"}
+	fastFood := models.Category{ID: uuid.New(), ParentID: food.ID, Name: "Фастфуд"}
+	tags := []models.Category{cafes, restaurants, cafesAndRestaurants, coffee, beans, food, fastFood}
+
+	testCases := []struct {
+		name      string
+		targetID  uuid.UUID
+		sourceIDs []uuid.UUID
+		reason    string
+	}{
+		{
+			name:      "Roots merged",
+			targetID:  cafesAndRestaurants.ID,
+			sourceIDs: []uuid.UUID{cafes.ID, restaurants.ID},
+		},
+		{
+			name:      "Target inside a source",
+			targetID:  beans.ID,
+			sourceIDs: []uuid.UUID{cafes.ID},
+			reason:    "category can't be merged into its child",
+		},
+		{
+			name:      "Children too deep under the target",
+			targetID:  fastFood.ID,
+			sourceIDs: []uuid.UUID{cafes.ID},
+			reason:    "categories can't be nested deeper than 3 levels",
+		},
+		{
+			name:      "Leaf merged into a child",
+			targetID:  fastFood.ID,
+			sourceIDs: []uuid.UUID{restaurants.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkMerge(tags, tc.targetID, tc.sourceIDs)
+
+			if tc.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errHierarchy *models.CategoryHierarchyError
+			if assert.ErrorAs(t, err, &errHierarchy) {
+				assert.Equal(t, tc.reason, errHierarchy.Reason)
+			}
+		})
+	}
+}
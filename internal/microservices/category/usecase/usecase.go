@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category"
@@ -31,6 +32,10 @@ func (u *Usecase) CreateTag(ctx context.Context, tag category.TagInput) (uuid.UU
 		return uuid.Nil, fmt.Errorf("[usecase] Error category alredy exist")
 	}
 
+	if err := u.checkParent(ctx, tag.UserId, uuid.Nil, tag.ParentId); err != nil {
+		return uuid.Nil, err
+	}
+
 	var newTag models.Category
 
 	newTag.Name = tag.Name
@@ -56,25 +61,95 @@ func (u *Usecase) UpdateTag(ctx context.Context, tag *models.Category) error {
 		return fmt.Errorf("[usecase] Error tag doesn't exist can't update")
 	}
 
+	if err := u.checkParent(ctx, tag.UserID, tag.ID, tag.ParentID); err != nil {
+		return err
+	}
+
 	if err := u.categoryRepo.UpdateTag(ctx, tag); err != nil {
 		return fmt.Errorf("[usecase] update tag Error: %v", err)
 	}
 	return nil
 }
 
-func (u *Usecase) DeleteTag(ctx context.Context, tagId uuid.UUID, userId uuid.UUID) error {
-	ok, err := u.categoryRepo.CheckExist(ctx, userId, tagId)
+func (u *Usecase) DeleteTag(ctx context.Context, tagId uuid.UUID, userId uuid.UUID, strategy string, targetId uuid.UUID) (models.CategoryDeletion, error) {
+	if strategy == "" {
+		strategy = models.CategoryDeleteRefuse
+	}
+
+	tags, err := u.categoryRepo.GetTags(ctx, userId)
 	if err != nil {
-		return fmt.Errorf("[usecase] Error in check tag existens: %v", err)
+		return models.CategoryDeletion{}, fmt.Errorf("[usecase] Error getting user tags: %w", err)
 	}
+
+	tag, ok := findTag(tags, tagId)
 	if !ok {
-		return fmt.Errorf("[usecase] Error tag doesn't exist can't delete")
+		return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.NoSuchCategoryError{CategoryID: tagId})
 	}
 
-	if err := u.categoryRepo.DeleteTag(ctx, tagId); err != nil {
-		return fmt.Errorf("[usecase] Error in tag deletion: %v", err)
+	var deletion models.CategoryDeletion
+	switch strategy {
+	case models.CategoryDeleteRefuse:
+		var transactions, children int64
+		transactions, children, err = u.categoryRepo.GetUsage(ctx, tagId)
+		if err != nil {
+			return models.CategoryDeletion{}, fmt.Errorf("[usecase] Error getting tag usage: %w", err)
+		}
+		if transactions > 0 || children > 0 {
+			return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{
+				Reason: fmt.Sprintf("category is used by %d transactions and %d child categories", transactions, children),
+			})
+		}
+		deletion, err = u.categoryRepo.DeleteTag(ctx, tagId)
+
+	case models.CategoryDeleteReassign:
+		if targetId == tagId {
+			return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{Reason: "category can't be reassigned to itself"})
+		}
+		if _, ok := findTag(tags, targetId); !ok {
+			return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.NoSuchCategoryError{CategoryID: targetId})
+		}
+		if err := checkMerge(tags, targetId, []uuid.UUID{tagId}); err != nil {
+			return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", err)
+		}
+		deletion, err = u.mergeInto(ctx, targetId, tagId)
+
+	case models.CategoryDeleteMoveUp:
+		if tag.ParentID == uuid.Nil {
+			// a root has no parent to take its transactions, only its children move up
+			var transactions int64
+			transactions, _, err = u.categoryRepo.GetUsage(ctx, tagId)
+			if err != nil {
+				return models.CategoryDeletion{}, fmt.Errorf("[usecase] Error getting tag usage: %w", err)
+			}
+			if transactions > 0 {
+				return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{
+					Reason: fmt.Sprintf("root category is used by %d transactions, reassign them to a target", transactions),
+				})
+			}
+			deletion, err = u.categoryRepo.DeleteTag(ctx, tagId)
+		} else {
+			deletion, err = u.mergeInto(ctx, tag.ParentID, tagId)
+		}
+
+	default:
+		return models.CategoryDeletion{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{Reason: "unknown delete strategy " + strategy})
 	}
-	return nil
+
+	if err != nil {
+		return models.CategoryDeletion{}, fmt.Errorf("[usecase] Error in tag deletion: %w", err)
+	}
+
+	deletion.Strategy = strategy
+	return deletion, nil
+}
+
+// mergeInto deletes the category moving its transactions and children to the target
+func (u *Usecase) mergeInto(ctx context.Context, targetId uuid.UUID, tagId uuid.UUID) (models.CategoryDeletion, error) {
+	merge, err := u.categoryRepo.MergeTags(ctx, targetId, []uuid.UUID{tagId})
+	if err != nil {
+		return models.CategoryDeletion{}, err
+	}
+	return models.CategoryDeletion{Transactions: merge.Transactions, Children: merge.Children, Anomalies: merge.Anomalies}, nil
 }
 
 func (u *Usecase) GetTags(ctx context.Context, userId uuid.UUID) ([]models.Category, error) {
@@ -85,3 +160,81 @@ func (u *Usecase) GetTags(ctx context.Context, userId uuid.UUID) ([]models.Categ
 
 	return tags, nil
 }
+
+func (u *Usecase) GetTree(ctx context.Context, userId uuid.UUID, startDate, endDate time.Time) ([]models.CategoryNode, error) {
+	tags, err := u.categoryRepo.GetTags(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] Error getting user tags: %w", err)
+	}
+
+	totals, err := u.categoryRepo.GetTotals(ctx, userId, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] Error getting tag totals: %w", err)
+	}
+
+	return buildTree(tags, totals), nil
+}
+
+func (u *Usecase) MergeTags(ctx context.Context, userId uuid.UUID, targetId uuid.UUID, sourceIds []uuid.UUID) (models.CategoryMerge, error) {
+	sources := make([]uuid.UUID, 0, len(sourceIds))
+	seen := map[uuid.UUID]bool{}
+	for _, id := range sourceIds {
+		if id == targetId {
+			return models.CategoryMerge{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{Reason: "category can't be merged into itself"})
+		}
+		if !seen[id] {
+			seen[id] = true
+			sources = append(sources, id)
+		}
+	}
+	if len(sources) == 0 {
+		return models.CategoryMerge{}, fmt.Errorf("[usecase] %w", &models.CategoryOperationError{Reason: "no categories to merge"})
+	}
+
+	tags, err := u.categoryRepo.GetTags(ctx, userId)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("[usecase] Error getting user tags: %w", err)
+	}
+
+	for _, id := range append([]uuid.UUID{targetId}, sources...) {
+		if _, ok := findTag(tags, id); !ok {
+			return models.CategoryMerge{}, fmt.Errorf("[usecase] %w", &models.NoSuchCategoryError{CategoryID: id})
+		}
+	}
+
+	if err := checkMerge(tags, targetId, sources); err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("[usecase] %w", err)
+	}
+
+	merge, err := u.categoryRepo.MergeTags(ctx, targetId, sources)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("[usecase] Error in tags merge: %w", err)
+	}
+	return merge, nil
+}
+
+func findTag(tags []models.Category, tagId uuid.UUID) (models.Category, bool) {
+	for _, tag := range tags {
+		if tag.ID == tagId {
+			return tag, true
+		}
+	}
+	return models.Category{}, false
+}
+
+// checkParent validates the place of the category in the tree, tagId is nil for a new one
+func (u *Usecase) checkParent(ctx context.Context, userId uuid.UUID, tagId uuid.UUID, parentId uuid.UUID) error {
+	if parentId == uuid.Nil {
+		return nil
+	}
+
+	tags, err := u.categoryRepo.GetTags(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("[usecase] Error getting user tags: %w", err)
+	}
+
+	if err := checkHierarchy(tags, tagId, parentId); err != nil {
+		return fmt.Errorf("[usecase] %w", err)
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/category"
@@ -144,39 +146,114 @@ func TestUsecase_UpdateTag(t *testing.T) {
 }
 
 func TestUsecase_DeleteTag(t *testing.T) {
+	userId := uuid.New()
+	parent := models.Category{ID: uuid.New(), UserID: userId, Name: "Еда"}
+	tag := models.Category{ID: uuid.New(), UserID: userId, ParentID: parent.ID, Name: "Кафе"}
+	root := models.Category{ID: uuid.New(), UserID: userId, Name: "Такси"}
+	tags := []models.Category{parent, tag, root}
+
 	testCases := []struct {
 		name        string
+		tagId       uuid.UUID
+		strategy    string
+		targetId    uuid.UUID
+		expected    models.CategoryDeletion
 		expectedErr error
 		mockRepoFn  func(*mock.MockRepository)
 	}{
 		{
-			name:        "Successful delete tag",
-			expectedErr: nil,
+			name:     "Unused tag deleted by default",
+			tagId:    tag.ID,
+			expected: models.CategoryDeletion{Strategy: models.CategoryDeleteRefuse},
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().CheckExist(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
-				mockRepository.EXPECT().DeleteTag(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepository.EXPECT().GetUsage(gomock.Any(), tag.ID).Return(int64(0), int64(0), nil)
+				mockRepository.EXPECT().DeleteTag(gomock.Any(), tag.ID).Return(models.CategoryDeletion{}, nil)
 			},
 		},
 		{
-			name:        "Error in check tag existence",
-			expectedErr: fmt.Errorf("[usecase] Error in check tag existens: %v", "some error"),
+			name:        "Tag in use refused",
+			tagId:       tag.ID,
+			strategy:    models.CategoryDeleteRefuse,
+			expectedErr: fmt.Errorf("[usecase] category is used by 3 transactions and 1 child categories"),
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().CheckExist(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("some error"))
+				mockRepository.EXPECT().GetUsage(gomock.Any(), tag.ID).Return(int64(3), int64(1), nil)
 			},
 		},
 		{
-			name:        "Error tag doesn't exist can't delete",
-			expectedErr: fmt.Errorf("[usecase] Error tag doesn't exist can't delete"),
+			name:     "Transactions reassigned",
+			tagId:    tag.ID,
+			strategy: models.CategoryDeleteReassign,
+			targetId: root.ID,
+			expected: models.CategoryDeletion{Strategy: models.CategoryDeleteReassign, Transactions: 3, Children: 1, Anomalies: 2},
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().CheckExist(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepository.EXPECT().MergeTags(gomock.Any(), root.ID, []uuid.UUID{tag.ID}).
+					Return(models.CategoryMerge{TargetID: root.ID, Transactions: 3, Children: 1, Anomalies: 2}, nil)
+			},
+		},
+		{
+			name:        "Reassigned to itself",
+			tagId:       tag.ID,
+			strategy:    models.CategoryDeleteReassign,
+			targetId:    tag.ID,
+			expectedErr: fmt.Errorf("[usecase] category can't be reassigned to itself"),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Reassigned to a missing tag",
+			tagId:       tag.ID,
+			strategy:    models.CategoryDeleteReassign,
+			expectedErr: fmt.Errorf("[usecase] No Such category: %s doesn't exist", uuid.Nil),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:     "Moved up to the parent",
+			tagId:    tag.ID,
+			strategy: models.CategoryDeleteMoveUp,
+			expected: models.CategoryDeletion{Strategy: models.CategoryDeleteMoveUp, Transactions: 2},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().MergeTags(gomock.Any(), parent.ID, []uuid.UUID{tag.ID}).
+					Return(models.CategoryMerge{TargetID: parent.ID, Transactions: 2}, nil)
+			},
+		},
+		{
+			name:     "Root moved up",
+			tagId:    root.ID,
+			strategy: models.CategoryDeleteMoveUp,
+			expected: models.CategoryDeletion{Strategy: models.CategoryDeleteMoveUp, Children: 2},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUsage(gomock.Any(), root.ID).Return(int64(0), int64(2), nil)
+				mockRepository.EXPECT().DeleteTag(gomock.Any(), root.ID).Return(models.CategoryDeletion{Children: 2}, nil)
 			},
 		},
+		{
+			name:        "Root with transactions moved up refused",
+			tagId:       root.ID,
+			strategy:    models.CategoryDeleteMoveUp,
+			expectedErr: fmt.Errorf("[usecase] root category is used by 4 transactions, reassign them to a target"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUsage(gomock.Any(), root.ID).Return(int64(4), int64(0), nil)
+			},
+		},
+		{
+			name:        "Unknown strategy",
+			tagId:       tag.ID,
+			strategy:    "cascade",
+			expectedErr: fmt.Errorf("[usecase] unknown delete strategy cascade"),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Error tag doesn't exist can't delete",
+			tagId:       uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] No Such category: %s doesn't exist", uuid.Nil),
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
 		{
 			name:        "Error in tag deletion",
+			tagId:       root.ID,
 			expectedErr: fmt.Errorf("[usecase] Error in tag deletion: %v", "some error"),
 			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().CheckExist(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
-				mockRepository.EXPECT().DeleteTag(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+				mockRepository.EXPECT().GetUsage(gomock.Any(), root.ID).Return(int64(0), int64(0), nil)
+				mockRepository.EXPECT().DeleteTag(gomock.Any(), root.ID).Return(models.CategoryDeletion{}, errors.New("some error"))
 			},
 		},
 	}
@@ -187,18 +264,20 @@ func TestUsecase_DeleteTag(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock.NewMockRepository(ctrl)
+			mockRepo.EXPECT().GetTags(gomock.Any(), userId).Return(tags, nil)
 			tc.mockRepoFn(mockRepo)
 
 			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
-			tagId := uuid.New()
-			userId := uuid.New()
-
-			err := mockUsecase.DeleteTag(context.Background(), tagId, userId)
+			deletion, err := mockUsecase.DeleteTag(context.Background(), tc.tagId, userId, tc.strategy, tc.targetId)
 
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, got: %v", tc.expectedErr, err)
 			}
+
+			if !reflect.DeepEqual(deletion, tc.expected) {
+				t.Errorf("Expected deletion: %v, got: %v", tc.expected, deletion)
+			}
 		})
 	}
 }
@@ -252,3 +331,202 @@ func TestUsecase_GetTags(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_UpdateTag_Parent(t *testing.T) {
+	userId := uuid.New()
+	parent := models.Category{ID: uuid.New(), UserID: userId}
+	tag := models.Category{ID: uuid.New(), UserID: userId, ParentID: parent.ID}
+	other := models.Category{ID: uuid.New(), UserID: userId}
+	tags := []models.Category{parent, tag, other}
+
+	testCases := []struct {
+		name        string
+		tagID       uuid.UUID
+		parentID    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:     "Moved under another category",
+			tagID:    tag.ID,
+			parentID: other.ID,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return(tags, nil)
+				mockRepository.EXPECT().UpdateTag(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "Moved under its child",
+			tagID:       parent.ID,
+			parentID:    tag.ID,
+			expectedErr: fmt.Errorf("[usecase] category can't be nested into itself"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return(tags, nil)
+			},
+		},
+		{
+			name:        "Error getting user tags",
+			tagID:       tag.ID,
+			parentID:    other.ID,
+			expectedErr: fmt.Errorf("[usecase] Error getting user tags: %v", "some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockRepo.EXPECT().CheckExist(gomock.Any(), userId, tc.tagID).Return(true, nil)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			err := mockUsecase.UpdateTag(context.Background(), &models.Category{ID: tc.tagID, UserID: userId, ParentID: tc.parentID})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_GetTree(t *testing.T) {
+	userId := uuid.New()
+	startDate := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC)
+	root := models.Category{ID: uuid.New(), UserID: userId, Name: "Еда"}
+	child := models.Category{ID: uuid.New(), UserID: userId, ParentID: root.ID, Name: "Кафе"}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		expectedLen int
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful get tree",
+			expectedLen: 1,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return([]models.Category{root, child}, nil)
+				mockRepository.EXPECT().GetTotals(gomock.Any(), userId, startDate, endDate).Return([]models.CategoryTotal{{ID: child.ID, Outcome: 300}}, nil)
+			},
+		},
+		{
+			name:        "Error getting totals",
+			expectedErr: fmt.Errorf("[usecase] Error getting tag totals: %v", "some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return([]models.Category{root, child}, nil)
+				mockRepository.EXPECT().GetTotals(gomock.Any(), userId, startDate, endDate).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			tree, err := mockUsecase.GetTree(context.Background(), userId, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectedErr, err)
+			}
+
+			if len(tree) != tc.expectedLen {
+				t.Errorf("Expected %d roots, got: %d", tc.expectedLen, len(tree))
+			}
+			if tc.expectedLen > 0 && tree[0].TotalOutcome != 300 {
+				t.Errorf("Expected rolled up outcome 300, got: %v", tree[0].TotalOutcome)
+			}
+		})
+	}
+}
+
+func TestUsecase_MergeTags(t *testing.T) {
+	userId := uuid.New()
+	target := models.Category{ID: uuid.New(), UserID: userId, Name: "Кафе и рестораны"}
+	cafes := models.Category{ID: uuid.New(), UserID: userId, Name: "Кафе"}
+	restaurants := models.Category{ID: uuid.New(), UserID: userId, Name: "Рестораны"}
+	tags := []models.Category{target, cafes, restaurants}
+	merge := models.CategoryMerge{TargetID: target.ID, Transactions: 12, Children: 1}
+	foreignID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		sourceIds   []uuid.UUID
+		expected    models.CategoryMerge
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:      "Successful merge",
+			sourceIds: []uuid.UUID{cafes.ID, restaurants.ID, cafes.ID},
+			expected:  merge,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return(tags, nil)
+				mockRepository.EXPECT().MergeTags(gomock.Any(), target.ID, []uuid.UUID{cafes.ID, restaurants.ID}).Return(merge, nil)
+			},
+		},
+		{
+			name:        "Merged into itself",
+			sourceIds:   []uuid.UUID{cafes.ID, target.ID},
+			expectedErr: &models.CategoryOperationError{Reason: "category can't be merged into itself"},
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Nothing to merge",
+			expectedErr: &models.CategoryOperationError{Reason: "no categories to merge"},
+			mockRepoFn:  func(mockRepository *mock.MockRepository) {},
+		},
+		{
+			name:        "Category of another user",
+			sourceIds:   []uuid.UUID{foreignID},
+			expectedErr: &models.NoSuchCategoryError{CategoryID: foreignID},
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return(tags, nil)
+			},
+		},
+		{
+			name:        "Error in merge",
+			sourceIds:   []uuid.UUID{cafes.ID},
+			expectedErr: errors.New("some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTags(gomock.Any(), userId).Return(tags, nil)
+				mockRepository.EXPECT().MergeTags(gomock.Any(), target.ID, []uuid.UUID{cafes.ID}).Return(models.CategoryMerge{}, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			actual, err := mockUsecase.MergeTags(context.Background(), userId, target.ID, tc.sourceIds)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) ||
+				(tc.expectedErr != nil && err != nil && !strings.HasSuffix(err.Error(), tc.expectedErr.Error())) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected merge: %v, got: %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package credit
+
+import (
+	"context"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	CreateCredit(ctx context.Context, userID uuid.UUID, credit *models.Credit) (uuid.UUID, error)
+	GetCredits(ctx context.Context, userID uuid.UUID) ([]models.CreditOverview, error)
+	GetSchedule(ctx context.Context, userID uuid.UUID, creditID uuid.UUID) (*models.CreditSchedule, error)
+	LinkPayment(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, link models.CreditPaymentLink) error
+	Repay(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, repayment *models.CreditRepayment) (*models.CreditSchedule, error)
+}
+
+type Repository interface {
+	CreateCredit(ctx context.Context, credit *models.Credit) (uuid.UUID, error)
+	GetCredits(ctx context.Context, userID uuid.UUID) ([]models.Credit, error)
+	GetCredit(ctx context.Context, userID uuid.UUID, creditID uuid.UUID) (*models.Credit, error)
+
+	GetPaymentLinks(ctx context.Context, creditID uuid.UUID) ([]models.CreditPaymentLink, error)
+	LinkPayment(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, link models.CreditPaymentLink) error
+	GetRepayments(ctx context.Context, creditID uuid.UUID) ([]models.CreditRepayment, error)
+	CreateRepayment(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, repayment *models.CreditRepayment) (uuid.UUID, error)
+}
@@ -0,0 +1,252 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	creditService credit.Usecase
+	logger        logger.Logger
+}
+
+func NewHandler(cu credit.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		creditService: cu,
+		logger:        l,
+	}
+}
+
+// @Summary		Create credit
+// @Tags		Credit
+// @Description	Add a credit with monthly annuity or differentiated payments
+// @Accept 		json
+// @Produce		json
+// @Param		credit	body		CreateCredit					true	"Credit terms"
+// @Success		200		{object}	Response[CreditCreateResponse]	"Credit created"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Forbidden user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/credit/create [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var creditInput CreateCredit
+	if err := easyjson.UnmarshalFromReader(r.Body, &creditInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := creditInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	creditID, err := h.creditService.CreateCredit(r.Context(), user.ID, creditInput.ToCredit())
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, CreditCreateServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, CreditCreateResponse{CreditID: creditID})
+}
+
+// @Summary		Get credits
+// @Tags		Credit
+// @Description	Credits of the user with the remaining principal, the interest paid and the next payment
+// @Produce		json
+// @Success		200		{object}	Response[[]models.CreditOverview]	"Credits"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/credit/all [get]
+func (h *Handler) GetCredits(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	credits, err := h.creditService.GetCredits(r.Context(), user.ID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, CreditGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, credits)
+}
+
+// @Summary		Get credit schedule
+// @Tags		Credit
+// @Description	Payment schedule recalculated after the early repayments, paid payments have a transaction
+// @Produce		json
+// @Param		credit_id	path		string	true	"Credit ID"
+// @Success		200		{object}	Response[models.CreditSchedule]	"Credit schedule"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/credit/{credit_id}/schedule [get]
+func (h *Handler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	creditID, err := commonHttp.GetIDFromRequest(creditID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	schedule, err := h.creditService.GetSchedule(r.Context(), user.ID, creditID)
+	if h.creditError(w, err, CreditScheduleServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, schedule)
+}
+
+// @Summary		Link credit payment
+// @Tags		Credit
+// @Description	Mark a payment of the schedule as paid by a transaction
+// @Accept 		json
+// @Produce		json
+// @Param		credit_id	path		string		true	"Credit ID"
+// @Param		payment		body		LinkPayment	true	"Payment number and transaction"
+// @Success		200		{object}	Response[NilBody]	"Payment linked"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/credit/{credit_id}/payment [post]
+func (h *Handler) LinkPayment(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	creditID, err := commonHttp.GetIDFromRequest(creditID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var linkInput LinkPayment
+	if err := easyjson.UnmarshalFromReader(r.Body, &linkInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := linkInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	err = h.creditService.LinkPayment(r.Context(), user.ID, creditID, models.CreditPaymentLink{
+		Number:        linkInput.Number,
+		TransactionID: linkInput.TransactionID,
+	})
+
+	var errNoSuchTransaction *models.NoSuchTransactionError
+	if errors.As(err, &errNoSuchTransaction) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, CreditTransactionNotSuch, h.logger)
+		return
+	}
+
+	if h.creditError(w, err, CreditLinkServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Repay credit early
+// @Tags		Credit
+// @Description	Record an early partial repayment, the schedule is recalculated with a shorter term or a lower payment
+// @Accept 		json
+// @Produce		json
+// @Param		credit_id	path		string	true	"Credit ID"
+// @Param		repayment	body		Repay	true	"Repayment"
+// @Success		200		{object}	Response[models.CreditSchedule]	"New schedule"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/credit/{credit_id}/repay [post]
+func (h *Handler) Repay(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	creditID, err := commonHttp.GetIDFromRequest(creditID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var repayInput Repay
+	if err := easyjson.UnmarshalFromReader(r.Body, &repayInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := repayInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	schedule, err := h.creditService.Repay(r.Context(), user.ID, creditID, repayInput.ToRepayment())
+
+	var errNoSuchTransaction *models.NoSuchTransactionError
+	if errors.As(err, &errNoSuchTransaction) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, CreditTransactionNotSuch, h.logger)
+		return
+	}
+
+	if h.creditError(w, err, CreditRepayServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, schedule)
+}
+
+// creditError writes the response for an error of a credit operation, false if there is no error
+func (h *Handler) creditError(w http.ResponseWriter, err error, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	var errNoSuchCredit *models.NoSuchCreditError
+	if errors.As(err, &errNoSuchCredit) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, CreditNotSuch, h.logger)
+		return true
+	}
+
+	var errCreditOperation *models.CreditOperationError
+	if errors.As(err, &errCreditOperation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errCreditOperation.Reason, h.logger)
+		return true
+	}
+
+	commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.logger)
+	return true
+}
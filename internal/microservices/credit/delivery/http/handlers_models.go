@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"html"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	creditID = "credit_id"
+
+	CreditCreateServerError   = "can't create credit"
+	CreditGetServerError      = "can't get credits"
+	CreditScheduleServerError = "can't get credit schedule"
+	CreditLinkServerError     = "can't link payment"
+	CreditRepayServerError    = "can't repay credit"
+	CreditNotSuch             = "no such credit"
+	CreditTransactionNotSuch  = "no such transaction"
+
+	CreditNameMaxLen        = 30
+	CreditDescriptionMaxLen = 100
+	CreditMaxPayments       = 600
+	CreditMaxRate           = 100
+)
+
+var (
+	errInvalidAccount     = errors.New("account is required")
+	errInvalidTotal       = errors.New("total must be positive")
+	errInvalidRate        = errors.New("invalid interest rate")
+	errInvalidPayments    = errors.New("invalid number of payments")
+	errInvalidName        = errors.New("creditor, bank or description is too long")
+	errInvalidTransaction = errors.New("transaction is required")
+	errInvalidReduce      = errors.New("invalid value for reduce")
+)
+
+type CreditCreateResponse struct {
+	CreditID uuid.UUID `json:"credit_id"`
+}
+
+//easyjson:json
+type CreateCredit struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	Total        float64   `json:"total"`
+	InterestRate float64   `json:"interest_rate"`
+	DateStart    time.Time `json:"date_start"`
+	Payments     int       `json:"payments"`
+	IsAnnuity    bool      `json:"is_annuity"`
+	Creditor     string    `json:"creditor"`
+	Bank         string    `json:"bank"`
+	Description  string    `json:"description"`
+}
+
+//easyjson:json
+type LinkPayment struct {
+	Number        int       `json:"number"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+//easyjson:json
+type Repay struct {
+	Amount        float64    `json:"amount"`
+	Date          time.Time  `json:"date"`
+	Reduce        string     `json:"reduce"`
+	TransactionID *uuid.UUID `json:"transaction_id"`
+}
+
+// CheckValid fills the defaults: a credit starting today
+func (cc *CreateCredit) CheckValid() error {
+	cc.Creditor = html.EscapeString(cc.Creditor)
+	cc.Bank = html.EscapeString(cc.Bank)
+	cc.Description = html.EscapeString(cc.Description)
+
+	if cc.DateStart.IsZero() {
+		cc.DateStart = time.Now()
+	}
+
+	switch {
+	case cc.AccountID == uuid.Nil:
+		return errInvalidAccount
+	case cc.Total <= 0:
+		return errInvalidTotal
+	case cc.InterestRate < 0 || cc.InterestRate > CreditMaxRate:
+		return errInvalidRate
+	case cc.Payments < 1 || cc.Payments > CreditMaxPayments:
+		return errInvalidPayments
+	case utf8.RuneCountInString(cc.Creditor) > CreditNameMaxLen ||
+		utf8.RuneCountInString(cc.Bank) > CreditNameMaxLen ||
+		utf8.RuneCountInString(cc.Description) > CreditDescriptionMaxLen:
+		return errInvalidName
+	}
+
+	return nil
+}
+
+func (cc *CreateCredit) ToCredit() *models.Credit {
+	return &models.Credit{
+		AccountID:    cc.AccountID,
+		Total:        cc.Total,
+		InterestRate: cc.InterestRate,
+		DateStart:    cc.DateStart,
+		Payments:     cc.Payments,
+		IsAnnuity:    cc.IsAnnuity,
+		Creditor:     cc.Creditor,
+		Bank:         cc.Bank,
+		Description:  cc.Description,
+	}
+}
+
+func (lp *LinkPayment) CheckValid() error {
+	if lp.TransactionID == uuid.Nil {
+		return errInvalidTransaction
+	}
+	return nil
+}
+
+// CheckValid fills the defaults: a repayment made today that shortens the term
+func (rp *Repay) CheckValid() error {
+	if rp.Reduce == "" {
+		rp.Reduce = models.RepaymentReduceTerm
+	}
+	if rp.Date.IsZero() {
+		rp.Date = time.Now()
+	}
+
+	switch {
+	case rp.Amount <= 0:
+		return errInvalidTotal
+	case rp.Reduce != models.RepaymentReduceTerm && rp.Reduce != models.RepaymentReducePayment:
+		return errInvalidReduce
+	}
+
+	return nil
+}
+
+func (rp *Repay) ToRepayment() *models.CreditRepayment {
+	return &models.CreditRepayment{
+		Amount:        rp.Amount,
+		Date:          rp.Date,
+		Reduce:        rp.Reduce,
+		TransactionID: rp.TransactionID,
+	}
+}
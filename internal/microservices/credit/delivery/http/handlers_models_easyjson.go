@@ -0,0 +1,324 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp(in *jlexer.Lexer, out *Repay) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "amount":
+			out.Amount = float64(in.Float64())
+		case "date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Date).UnmarshalJSON(data))
+			}
+		case "reduce":
+			out.Reduce = string(in.String())
+		case "transaction_id":
+			if in.IsNull() {
+				in.Skip()
+				out.TransactionID = nil
+			} else {
+				if out.TransactionID == nil {
+					out.TransactionID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.TransactionID).UnmarshalText(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp(out *jwriter.Writer, in Repay) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Amount))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((in.Date).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"reduce\":"
+		out.RawString(prefix)
+		out.String(string(in.Reduce))
+	}
+	{
+		const prefix string = ",\"transaction_id\":"
+		out.RawString(prefix)
+		if in.TransactionID == nil {
+			out.RawString("null")
+		} else {
+			out.RawText((*in.TransactionID).MarshalText())
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Repay) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Repay) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Repay) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Repay) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp(l, v)
+}
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp1(in *jlexer.Lexer, out *LinkPayment) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "number":
+			out.Number = int(in.Int())
+		case "transaction_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.TransactionID).UnmarshalText(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp1(out *jwriter.Writer, in LinkPayment) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"number\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Number))
+	}
+	{
+		const prefix string = ",\"transaction_id\":"
+		out.RawString(prefix)
+		out.RawText((in.TransactionID).MarshalText())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LinkPayment) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LinkPayment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LinkPayment) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LinkPayment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp1(l, v)
+}
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp2(in *jlexer.Lexer, out *CreateCredit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		case "total":
+			out.Total = float64(in.Float64())
+		case "interest_rate":
+			out.InterestRate = float64(in.Float64())
+		case "date_start":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DateStart).UnmarshalJSON(data))
+			}
+		case "payments":
+			out.Payments = int(in.Int())
+		case "is_annuity":
+			out.IsAnnuity = bool(in.Bool())
+		case "creditor":
+			out.Creditor = string(in.String())
+		case "bank":
+			out.Bank = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp2(out *jwriter.Writer, in CreateCredit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Total))
+	}
+	{
+		const prefix string = ",\"interest_rate\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.InterestRate))
+	}
+	{
+		const prefix string = ",\"date_start\":"
+		out.RawString(prefix)
+		out.Raw((in.DateStart).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"payments\":"
+		out.RawString(prefix)
+		out.Int(int(in.Payments))
+	}
+	{
+		const prefix string = ",\"is_annuity\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsAnnuity))
+	}
+	{
+		const prefix string = ",\"creditor\":"
+		out.RawString(prefix)
+		out.String(string(in.Creditor))
+	}
+	{
+		const prefix string = ",\"bank\":"
+		out.RawString(prefix)
+		out.String(string(in.Bank))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateCredit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateCredit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateCredit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateCredit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesCreditDeliveryHttp2(l, v)
+}
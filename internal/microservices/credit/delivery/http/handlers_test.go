@@ -0,0 +1,412 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Create(t *testing.T) {
+	uuidTest := uuid.New()
+	accountID := uuid.New()
+	creditID := uuid.MustParse("5d2e8a1c-6b4f-4e3a-9c7d-1f0a2b3c4d5e")
+	user := &models.User{ID: uuidTest}
+	validBody := fmt.Sprintf(`{"account_id":"%s","total":120000,"interest_rate":12,"payments":12,"is_annuity":true,"bank":"Сбербанк"}`, accountID)
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Create",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"credit_id":"5d2e8a1c-6b4f-4e3a-9c7d-1f0a2b3c4d5e"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateCredit(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, credit *models.Credit) (uuid.UUID, error) {
+						assert.Equal(t, accountID, credit.AccountID)
+						assert.True(t, credit.IsAnnuity)
+						assert.False(t, credit.DateStart.IsZero())
+						return creditID, nil
+					})
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid body",
+			user:         user,
+			body:         `{"account_id":`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No payments",
+			user:         user,
+			body:         fmt.Sprintf(`{"account_id":"%s","total":120000,"interest_rate":12,"payments":0}`, accountID),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateCredit(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[usecase] account of another user %w", &models.ForbiddenUserError{}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't create credit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateCredit(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/credit/create", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Create(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetCredits(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get credits"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetCredits(gomock.Any(), uuidTest).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/credit/all", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetCredits(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_LinkPayment(t *testing.T) {
+	uuidTest := uuid.New()
+	creditID := uuid.New()
+	transactionID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	validBody := fmt.Sprintf(`{"number":2,"transaction_id":"%s"}`, transactionID)
+	link := models.CreditPaymentLink{Number: 2, TransactionID: transactionID}
+	tests := []struct {
+		name          string
+		user          *models.User
+		creditID      string
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to LinkPayment",
+			user:         user,
+			creditID:     creditID.String(),
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().LinkPayment(gomock.Any(), uuidTest, creditID, link).Return(nil)
+			},
+		},
+		{
+			name:         "Invalid credit id",
+			user:         user,
+			creditID:     "credit",
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No transaction",
+			user:         user,
+			creditID:     creditID.String(),
+			body:         `{"number":2}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Transaction of another user",
+			user:         user,
+			creditID:     creditID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such transaction"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().LinkPayment(gomock.Any(), uuidTest, creditID, link).
+					Return(fmt.Errorf("[usecase] can't link payment %w", &models.NoSuchTransactionError{UserID: uuidTest}))
+			},
+		},
+		{
+			name:         "No such payment",
+			user:         user,
+			creditID:     creditID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such payment in the schedule"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().LinkPayment(gomock.Any(), uuidTest, creditID, link).
+					Return(fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "no such payment in the schedule"}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			creditID:     creditID.String(),
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't link payment"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().LinkPayment(gomock.Any(), uuidTest, creditID, link).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/credit/"+tt.creditID+"/payment", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"credit_id": tt.creditID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.LinkPayment(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Repay(t *testing.T) {
+	uuidTest := uuid.New()
+	creditID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	validBody := `{"amount":30000,"date":"2023-03-10T00:00:00Z"}`
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Repay",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, creditID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ uuid.UUID, repayment *models.CreditRepayment) (*models.CreditSchedule, error) {
+						assert.Equal(t, models.RepaymentReduceTerm, repayment.Reduce)
+						assert.Equal(t, 30000.0, repayment.Amount)
+						return &models.CreditSchedule{}, nil
+					})
+			},
+		},
+		{
+			name:         "Invalid reduce",
+			user:         user,
+			body:         `{"amount":30000,"reduce":"interest"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No such credit",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such credit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, creditID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] can't get credit from repository %w", &models.NoSuchCreditError{CreditID: creditID}))
+			},
+		},
+		{
+			name:         "Repayment over balance",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"repayment exceeds the remaining principal"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, creditID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment exceeds the remaining principal"}))
+			},
+		},
+		{
+			name:         "Transaction of another user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such transaction"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, creditID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] can't create repayment %w", &models.NoSuchTransactionError{UserID: uuidTest}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't repay credit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, creditID, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/credit/"+creditID.String()+"/repay", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"credit_id": creditID.String()})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Repay(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: credit.go
+
+// Package mock_credit is a generated GoMock package.
+package mock_credit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCredit mocks base method.
+func (m *MockUsecase) CreateCredit(ctx context.Context, userID uuid.UUID, credit *models.Credit) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredit", ctx, userID, credit)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredit indicates an expected call of CreateCredit.
+func (mr *MockUsecaseMockRecorder) CreateCredit(ctx, userID, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredit", reflect.TypeOf((*MockUsecase)(nil).CreateCredit), ctx, userID, credit)
+}
+
+// GetCredits mocks base method.
+func (m *MockUsecase) GetCredits(ctx context.Context, userID uuid.UUID) ([]models.CreditOverview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredits", ctx, userID)
+	ret0, _ := ret[0].([]models.CreditOverview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredits indicates an expected call of GetCredits.
+func (mr *MockUsecaseMockRecorder) GetCredits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredits", reflect.TypeOf((*MockUsecase)(nil).GetCredits), ctx, userID)
+}
+
+// GetSchedule mocks base method.
+func (m *MockUsecase) GetSchedule(ctx context.Context, userID, creditID uuid.UUID) (*models.CreditSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, userID, creditID)
+	ret0, _ := ret[0].(*models.CreditSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockUsecaseMockRecorder) GetSchedule(ctx, userID, creditID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockUsecase)(nil).GetSchedule), ctx, userID, creditID)
+}
+
+// LinkPayment mocks base method.
+func (m *MockUsecase) LinkPayment(ctx context.Context, userID, creditID uuid.UUID, link models.CreditPaymentLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkPayment", ctx, userID, creditID, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkPayment indicates an expected call of LinkPayment.
+func (mr *MockUsecaseMockRecorder) LinkPayment(ctx, userID, creditID, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkPayment", reflect.TypeOf((*MockUsecase)(nil).LinkPayment), ctx, userID, creditID, link)
+}
+
+// Repay mocks base method.
+func (m *MockUsecase) Repay(ctx context.Context, userID, creditID uuid.UUID, repayment *models.CreditRepayment) (*models.CreditSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repay", ctx, userID, creditID, repayment)
+	ret0, _ := ret[0].(*models.CreditSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Repay indicates an expected call of Repay.
+func (mr *MockUsecaseMockRecorder) Repay(ctx, userID, creditID, repayment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repay", reflect.TypeOf((*MockUsecase)(nil).Repay), ctx, userID, creditID, repayment)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCredit mocks base method.
+func (m *MockRepository) CreateCredit(ctx context.Context, credit *models.Credit) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredit", ctx, credit)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredit indicates an expected call of CreateCredit.
+func (mr *MockRepositoryMockRecorder) CreateCredit(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredit", reflect.TypeOf((*MockRepository)(nil).CreateCredit), ctx, credit)
+}
+
+// CreateRepayment mocks base method.
+func (m *MockRepository) CreateRepayment(ctx context.Context, userID, creditID uuid.UUID, repayment *models.CreditRepayment) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRepayment", ctx, userID, creditID, repayment)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRepayment indicates an expected call of CreateRepayment.
+func (mr *MockRepositoryMockRecorder) CreateRepayment(ctx, userID, creditID, repayment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepayment", reflect.TypeOf((*MockRepository)(nil).CreateRepayment), ctx, userID, creditID, repayment)
+}
+
+// GetCredit mocks base method.
+func (m *MockRepository) GetCredit(ctx context.Context, userID, creditID uuid.UUID) (*models.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredit", ctx, userID, creditID)
+	ret0, _ := ret[0].(*models.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredit indicates an expected call of GetCredit.
+func (mr *MockRepositoryMockRecorder) GetCredit(ctx, userID, creditID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredit", reflect.TypeOf((*MockRepository)(nil).GetCredit), ctx, userID, creditID)
+}
+
+// GetCredits mocks base method.
+func (m *MockRepository) GetCredits(ctx context.Context, userID uuid.UUID) ([]models.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredits", ctx, userID)
+	ret0, _ := ret[0].([]models.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredits indicates an expected call of GetCredits.
+func (mr *MockRepositoryMockRecorder) GetCredits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredits", reflect.TypeOf((*MockRepository)(nil).GetCredits), ctx, userID)
+}
+
+// GetPaymentLinks mocks base method.
+func (m *MockRepository) GetPaymentLinks(ctx context.Context, creditID uuid.UUID) ([]models.CreditPaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentLinks", ctx, creditID)
+	ret0, _ := ret[0].([]models.CreditPaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentLinks indicates an expected call of GetPaymentLinks.
+func (mr *MockRepositoryMockRecorder) GetPaymentLinks(ctx, creditID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentLinks", reflect.TypeOf((*MockRepository)(nil).GetPaymentLinks), ctx, creditID)
+}
+
+// GetRepayments mocks base method.
+func (m *MockRepository) GetRepayments(ctx context.Context, creditID uuid.UUID) ([]models.CreditRepayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepayments", ctx, creditID)
+	ret0, _ := ret[0].([]models.CreditRepayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepayments indicates an expected call of GetRepayments.
+func (mr *MockRepositoryMockRecorder) GetRepayments(ctx, creditID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepayments", reflect.TypeOf((*MockRepository)(nil).GetRepayments), ctx, creditID)
+}
+
+// LinkPayment mocks base method.
+func (m *MockRepository) LinkPayment(ctx context.Context, userID, creditID uuid.UUID, link models.CreditPaymentLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkPayment", ctx, userID, creditID, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkPayment indicates an expected call of LinkPayment.
+func (mr *MockRepositoryMockRecorder) LinkPayment(ctx, userID, creditID, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkPayment", reflect.TypeOf((*MockRepository)(nil).LinkPayment), ctx, userID, creditID, link)
+}
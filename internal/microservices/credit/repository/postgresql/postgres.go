@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	CreditCreate = `INSERT INTO Credit (user_id, account_id, total, interest_rate, date_start, payments, is_annuity, creditor, bank, description)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+					RETURNING id;`
+
+	creditColumns = `SELECT id, user_id, account_id, total, interest_rate, date_start, payments, is_annuity, creditor, bank, description
+					 FROM Credit `
+
+	CreditGetAll = creditColumns + "WHERE user_id = $1 ORDER BY date_start;"
+	CreditGet    = creditColumns + "WHERE id = $1 AND user_id = $2;"
+
+	CreditPaymentLinksGet = "SELECT number, transaction_id FROM CreditPaymentLink WHERE credit_id = $1 ORDER BY number;"
+
+	// only a transaction of the user is linked, a payment can be relinked
+	CreditPaymentLink = `INSERT INTO CreditPaymentLink (credit_id, number, transaction_id)
+						 SELECT $1, $2, t.id FROM Transaction t WHERE t.id = $3 AND t.user_id = $4
+						 ON CONFLICT (credit_id, number) DO UPDATE SET transaction_id = EXCLUDED.transaction_id;`
+
+	CreditRepaymentsGet = `SELECT id, date, amount, reduce, transaction_id
+						   FROM CreditRepayment
+						   WHERE credit_id = $1
+						   ORDER BY date;`
+
+	// a repayment is recorded without a transaction or with a transaction of the user
+	CreditRepaymentCreate = `INSERT INTO CreditRepayment (credit_id, date, amount, reduce, transaction_id)
+							 SELECT $1, $2, $3, $4, $5::uuid
+							 WHERE $5::uuid IS NULL OR EXISTS (SELECT 1 FROM Transaction t WHERE t.id = $5 AND t.user_id = $6)
+							 RETURNING id;`
+)
+
+const errorTransactionLinked = "credit_payment_link_transaction_unique"
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) CreateCredit(ctx context.Context, credit *models.Credit) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	err := r.db.QueryRow(ctx, CreditCreate,
+		credit.UserID,
+		credit.AccountID,
+		credit.Total,
+		credit.InterestRate,
+		credit.DateStart,
+		credit.Payments,
+		credit.IsAnnuity,
+		credit.Creditor,
+		credit.Bank,
+		credit.Description,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create credit: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetCredits(ctx context.Context, userID uuid.UUID) ([]models.Credit, error) {
+	credits := []models.Credit{}
+
+	rows, err := r.db.Query(ctx, CreditGetAll, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		credit, err := scanCredit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		credits = append(credits, credit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return credits, nil
+}
+
+func (r *Repository) GetCredit(ctx context.Context, userID uuid.UUID, creditID uuid.UUID) (*models.Credit, error) {
+	credit, err := scanCredit(r.db.QueryRow(ctx, CreditGet, creditID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchCreditError{CreditID: creditID})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &credit, nil
+}
+
+func (r *Repository) GetPaymentLinks(ctx context.Context, creditID uuid.UUID) ([]models.CreditPaymentLink, error) {
+	var links []models.CreditPaymentLink
+
+	rows, err := r.db.Query(ctx, CreditPaymentLinksGet, creditID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link models.CreditPaymentLink
+		if err := rows.Scan(&link.Number, &link.TransactionID); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return links, nil
+}
+
+func (r *Repository) LinkPayment(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, link models.CreditPaymentLink) error {
+	res, err := r.db.Exec(ctx, CreditPaymentLink, creditID, link.Number, link.TransactionID, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == errorTransactionLinked {
+			return fmt.Errorf("[repo] %w", &models.CreditOperationError{Reason: "transaction is already linked to a payment"})
+		}
+		return fmt.Errorf("[repo] failed to link payment: %w", err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.NoSuchTransactionError{UserID: userID})
+	}
+
+	return nil
+}
+
+func (r *Repository) GetRepayments(ctx context.Context, creditID uuid.UUID) ([]models.CreditRepayment, error) {
+	var repayments []models.CreditRepayment
+
+	rows, err := r.db.Query(ctx, CreditRepaymentsGet, creditID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var repayment models.CreditRepayment
+		if err := rows.Scan(
+			&repayment.ID,
+			&repayment.Date,
+			&repayment.Amount,
+			&repayment.Reduce,
+			&repayment.TransactionID,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		repayments = append(repayments, repayment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return repayments, nil
+}
+
+func (r *Repository) CreateRepayment(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, repayment *models.CreditRepayment) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	err := r.db.QueryRow(ctx, CreditRepaymentCreate,
+		creditID,
+		repayment.Date,
+		repayment.Amount,
+		repayment.Reduce,
+		repayment.TransactionID,
+		userID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("[repo] %w", &models.NoSuchTransactionError{UserID: userID})
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create repayment: %w", err)
+	}
+
+	return id, nil
+}
+
+func scanCredit(row pgx.Row) (models.Credit, error) {
+	var credit models.Credit
+	err := row.Scan(
+		&credit.ID,
+		&credit.UserID,
+		&credit.AccountID,
+		&credit.Total,
+		&credit.InterestRate,
+		&credit.DateStart,
+		&credit.Payments,
+		&credit.IsAnnuity,
+		&credit.Creditor,
+		&credit.Bank,
+		&credit.Description,
+	)
+	return credit, err
+}
@@ -0,0 +1,392 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var creditRowColumns = []string{"id", "user_id", "account_id", "total", "interest_rate", "date_start", "payments",
+	"is_annuity", "creditor", "bank", "description"}
+
+func testCredit() models.Credit {
+	return models.Credit{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		AccountID:    uuid.New(),
+		Total:        120000,
+		InterestRate: 12,
+		DateStart:    time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+		Payments:     12,
+		IsAnnuity:    true,
+		Creditor:     "Иван",
+		Bank:         "Сбербанк",
+		Description:  "Ноутбук",
+	}
+}
+
+func addCreditRow(rows *pgxmock.Rows, c models.Credit) *pgxmock.Rows {
+	return rows.AddRow(c.ID, c.UserID, c.AccountID, c.Total, c.InterestRate, c.DateStart, c.Payments,
+		c.IsAnnuity, c.Creditor, c.Bank, c.Description)
+}
+
+func Test_CreateCredit(t *testing.T) {
+	credit := testCredit()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        pgxmock.NewRows([]string{"id"}).AddRow(credit.ID),
+			expected:    credit.ID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create credit: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(CreditCreate)).
+				WithArgs(credit.UserID, credit.AccountID, credit.Total, credit.InterestRate, credit.DateStart,
+					credit.Payments, credit.IsAnnuity, credit.Creditor, credit.Bank, credit.Description).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			id, err := repo.CreateCredit(context.Background(), &credit)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("Expected credit %s, but got: %s", tc.expected, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetCredits(t *testing.T) {
+	credit := testCredit()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        addCreditRow(addCreditRow(pgxmock.NewRows(creditRowColumns), credit), testCredit()),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(creditRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(CreditGetAll)).
+				WithArgs(credit.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			credits, err := repo.GetCredits(context.Background(), credit.UserID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(credits) != tc.expectedLen {
+				t.Errorf("Expected %d credits, but got: %d", tc.expectedLen, len(credits))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetCredit(t *testing.T) {
+	credit := testCredit()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        addCreditRow(pgxmock.NewRows(creditRowColumns), credit),
+			expectedErr: nil,
+		},
+		{
+			name:        "No such credit",
+			rows:        pgxmock.NewRows(creditRowColumns),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchCreditError{CreditID: credit.ID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(creditRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(CreditGet)).
+				WithArgs(credit.ID, credit.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			actual, err := repo.GetCredit(context.Background(), credit.UserID, credit.ID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Equal(t, &credit, actual)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_LinkPayment(t *testing.T) {
+	userID := uuid.New()
+	creditID := uuid.New()
+	link := models.CreditPaymentLink{Number: 3, TransactionID: uuid.New()}
+
+	testCases := []struct {
+		name        string
+		execResult  pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			execResult:  pgconn.CommandTag("INSERT 0 1"),
+			expectedErr: nil,
+		},
+		{
+			name:        "Transaction of another user",
+			execResult:  pgconn.CommandTag("INSERT 0 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchTransactionError{UserID: userID}),
+		},
+		{
+			name:        "Transaction already linked",
+			execResult:  pgconn.CommandTag{},
+			execError:   &pgconn.PgError{ConstraintName: errorTransactionLinked},
+			expectedErr: fmt.Errorf("[repo] %w", &models.CreditOperationError{Reason: "transaction is already linked to a payment"}),
+		},
+		{
+			name:        "Error",
+			execResult:  pgconn.CommandTag{},
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to link payment: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectExec(regexp.QuoteMeta(CreditPaymentLink)).
+				WithArgs(creditID, link.Number, link.TransactionID, userID).
+				WillReturnResult(tc.execResult).
+				WillReturnError(tc.execError)
+
+			err := repo.LinkPayment(context.Background(), userID, creditID, link)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetRepayments(t *testing.T) {
+	creditID := uuid.New()
+	transactionID := uuid.New()
+
+	columns := []string{"id", "date", "amount", "reduce", "transaction_id"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(uuid.New(), time.Now(), 10000.0, models.RepaymentReduceTerm, &transactionID).
+				AddRow(uuid.New(), time.Now(), 5000.0, models.RepaymentReducePayment, nil),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(CreditRepaymentsGet)).
+				WithArgs(creditID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			repayments, err := repo.GetRepayments(context.Background(), creditID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(repayments) != tc.expectedLen {
+				t.Errorf("Expected %d repayments, but got: %d", tc.expectedLen, len(repayments))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_CreateRepayment(t *testing.T) {
+	userID := uuid.New()
+	creditID := uuid.New()
+	repaymentID := uuid.New()
+	transactionID := uuid.New()
+	repayment := &models.CreditRepayment{
+		Date:          time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		Amount:        10000,
+		Reduce:        models.RepaymentReduceTerm,
+		TransactionID: &transactionID,
+	}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsErr     error
+		expectedID  uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:       "Success",
+			rows:       pgxmock.NewRows([]string{"id"}).AddRow(repaymentID),
+			expectedID: repaymentID,
+		},
+		{
+			name:        "Transaction of another user",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsErr:     pgx.ErrNoRows,
+			expectedID:  uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchTransactionError{UserID: userID}),
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsErr:     errors.New("Some error"),
+			expectedID:  uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create repayment: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(CreditRepaymentCreate)).
+				WithArgs(creditID, repayment.Date, repayment.Amount, repayment.Reduce, repayment.TransactionID, userID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsErr)
+
+			id, err := repo.CreateRepayment(context.Background(), userID, creditID, repayment)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if id != tc.expectedID {
+				t.Errorf("Expected id: %v, but got: %v", tc.expectedID, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
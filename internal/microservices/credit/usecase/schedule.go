@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const monthsInYear = 12
+
+// buildSchedule generates the monthly payments: annuity payments are equal, differentiated ones
+// repay equal parts of the principal. A repayment made before a payment date lowers the balance
+// before that payment's interest is counted; it keeps the payment and shortens the term,
+// or keeps the term and lowers the payment
+func buildSchedule(credit *models.Credit, repayments []models.CreditRepayment, links []models.CreditPaymentLink) *models.CreditSchedule {
+	rate := credit.InterestRate / 100 / monthsInYear
+	start := dates.TruncateDay(credit.DateStart)
+
+	sorted := make([]models.CreditRepayment, len(repayments))
+	copy(sorted, repayments)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	schedule := &models.CreditSchedule{
+		Credit:     *credit,
+		Payments:   []models.CreditPayment{},
+		Repayments: sorted,
+	}
+
+	balance := credit.Total
+	annuity := annuityPayment(balance, rate, credit.Payments)
+	part := money.Round2(balance / float64(credit.Payments))
+
+	var repaid float64
+	next := 0
+	for number := 1; balance > 0 && number <= credit.Payments; number++ {
+		date := dates.AddMonths(start, number)
+
+		for ; next < len(sorted) && sorted[next].Date.Before(date); next++ {
+			amount := math.Min(sorted[next].Amount, balance)
+			balance = money.Round2(balance - amount)
+			repaid += amount
+
+			if left := credit.Payments - number + 1; sorted[next].Reduce == models.RepaymentReducePayment && balance > 0 {
+				annuity = annuityPayment(balance, rate, left)
+				part = money.Round2(balance / float64(left))
+			}
+		}
+		if balance <= 0 {
+			break
+		}
+
+		interest := money.Round2(balance * rate)
+		principal := part
+		if credit.IsAnnuity {
+			principal = money.Round2(annuity - interest)
+		}
+		if principal >= balance || number == credit.Payments {
+			principal = balance
+		}
+		balance = money.Round2(balance - principal)
+
+		schedule.Payments = append(schedule.Payments, models.CreditPayment{
+			Number:    number,
+			Date:      date,
+			Payment:   money.Round2(principal + interest),
+			Principal: principal,
+			Interest:  interest,
+			Balance:   balance,
+		})
+		schedule.InterestTotal += interest
+	}
+
+	paid := make(map[int]uuid.UUID, len(links))
+	for _, link := range links {
+		paid[link.Number] = link.TransactionID
+	}
+
+	for i := range schedule.Payments {
+		payment := &schedule.Payments[i]
+		transactionID, ok := paid[payment.Number]
+		if !ok {
+			continue
+		}
+		payment.TransactionID = &transactionID
+		schedule.InterestPaid += payment.Interest
+		schedule.PrincipalPaid += payment.Principal
+	}
+
+	schedule.InterestTotal = money.Round2(schedule.InterestTotal)
+	schedule.InterestPaid = money.Round2(schedule.InterestPaid)
+	schedule.PrincipalPaid = money.Round2(schedule.PrincipalPaid + repaid)
+	schedule.RemainingPrincipal = money.Round2(credit.Total - schedule.PrincipalPaid)
+
+	return schedule
+}
+
+// balanceBefore is the principal left right before date: the balance after the last payment
+// and the repayments made since then
+func balanceBefore(schedule *models.CreditSchedule, date time.Time) float64 {
+	balance := schedule.Credit.Total
+	since := time.Time{}
+	for _, payment := range schedule.Payments {
+		if !payment.Date.Before(date) {
+			break
+		}
+		balance = payment.Balance
+		since = payment.Date
+	}
+	for _, repayment := range schedule.Repayments {
+		if !repayment.Date.Before(since) && repayment.Date.Before(date) {
+			balance -= repayment.Amount
+		}
+	}
+	return money.Round2(math.Max(balance, 0))
+}
+
+// nextPayment is the first payment without a transaction
+func nextPayment(schedule *models.CreditSchedule) *models.CreditPayment {
+	for i := range schedule.Payments {
+		if schedule.Payments[i].TransactionID == nil {
+			return &schedule.Payments[i]
+		}
+	}
+	return nil
+}
+
+// lastPaidPayment is the latest payment with a transaction
+func lastPaidPayment(schedule *models.CreditSchedule) *models.CreditPayment {
+	for i := len(schedule.Payments) - 1; i >= 0; i-- {
+		if schedule.Payments[i].TransactionID != nil {
+			return &schedule.Payments[i]
+		}
+	}
+	return nil
+}
+
+func annuityPayment(balance, rate float64, payments int) float64 {
+	if rate == 0 {
+		return money.Round2(balance / float64(payments))
+	}
+	return money.Round2(balance * rate / (1 - math.Pow(1+rate, -float64(payments))))
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	creditRepo  credit.Repository
+	logger      logger.Logger
+	accountRepo account.Repository
+}
+
+func NewUsecase(cr credit.Repository, log logger.Logger, ar account.Repository) *Usecase {
+	return &Usecase{
+		creditRepo:  cr,
+		logger:      log,
+		accountRepo: ar,
+	}
+}
+
+// CreateCredit adds a credit paid from an account of the user
+func (u *Usecase) CreateCredit(ctx context.Context, userID uuid.UUID, credit *models.Credit) (uuid.UUID, error) {
+	role, err := u.accountRepo.GetRole(ctx, credit.AccountID, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't check account %w", err)
+	}
+	if !role.CanEdit() {
+		return uuid.Nil, fmt.Errorf("[usecase] %s can't pay a credit from the account %w", role, &models.ForbiddenUserError{})
+	}
+
+	credit.UserID = userID
+	credit.DateStart = dates.TruncateDay(credit.DateStart)
+
+	id, err := u.creditRepo.CreateCredit(ctx, credit)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create credit %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetCredits(ctx context.Context, userID uuid.UUID) ([]models.CreditOverview, error) {
+	credits, err := u.creditRepo.GetCredits(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get credits from repository %w", err)
+	}
+
+	overviews := make([]models.CreditOverview, 0, len(credits))
+	for i := range credits {
+		schedule, err := u.schedule(ctx, &credits[i])
+		if err != nil {
+			return nil, err
+		}
+
+		overviews = append(overviews, models.CreditOverview{
+			Credit:             credits[i],
+			InterestPaid:       schedule.InterestPaid,
+			RemainingPrincipal: schedule.RemainingPrincipal,
+			NextPayment:        nextPayment(schedule),
+		})
+	}
+
+	return overviews, nil
+}
+
+func (u *Usecase) GetSchedule(ctx context.Context, userID uuid.UUID, creditID uuid.UUID) (*models.CreditSchedule, error) {
+	credit, err := u.creditRepo.GetCredit(ctx, userID, creditID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get credit from repository %w", err)
+	}
+	return u.schedule(ctx, credit)
+}
+
+// LinkPayment marks a payment of the schedule as paid by a transaction of the user
+func (u *Usecase) LinkPayment(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, link models.CreditPaymentLink) error {
+	schedule, err := u.GetSchedule(ctx, userID, creditID)
+	if err != nil {
+		return err
+	}
+
+	if link.Number < 1 || link.Number > len(schedule.Payments) {
+		return fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "no such payment in the schedule"})
+	}
+
+	if err := u.creditRepo.LinkPayment(ctx, userID, creditID, link); err != nil {
+		return fmt.Errorf("[usecase] can't link payment %w", err)
+	}
+	return nil
+}
+
+// Repay records an early repayment and returns the recalculated schedule
+func (u *Usecase) Repay(ctx context.Context, userID uuid.UUID, creditID uuid.UUID, repayment *models.CreditRepayment) (*models.CreditSchedule, error) {
+	schedule, err := u.GetSchedule(ctx, userID, creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	repayment.Date = dates.TruncateDay(repayment.Date)
+	if repayment.Date.Before(schedule.Credit.DateStart) {
+		return nil, fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment before the credit start"})
+	}
+	// the split of a paid payment can't change, a repayment is dated after the last paid one
+	if paid := lastPaidPayment(schedule); paid != nil && !repayment.Date.After(paid.Date) {
+		return nil, fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment on or before a paid payment"})
+	}
+	if repayment.Amount > balanceBefore(schedule, repayment.Date) {
+		return nil, fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment exceeds the remaining principal"})
+	}
+
+	if _, err := u.creditRepo.CreateRepayment(ctx, userID, creditID, repayment); err != nil {
+		return nil, fmt.Errorf("[usecase] can't create repayment %w", err)
+	}
+
+	return u.schedule(ctx, &schedule.Credit)
+}
+
+func (u *Usecase) schedule(ctx context.Context, credit *models.Credit) (*models.CreditSchedule, error) {
+	repayments, err := u.creditRepo.GetRepayments(ctx, credit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get repayments from repository %w", err)
+	}
+
+	links, err := u.creditRepo.GetPaymentLinks(ctx, credit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get payment links from repository %w", err)
+	}
+
+	return buildSchedule(credit, repayments, links), nil
+}
@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/credit/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCredit(annuity bool) *models.Credit {
+	return &models.Credit{
+		ID:           uuid.New(),
+		AccountID:    uuid.New(),
+		Total:        120000,
+		InterestRate: 12,
+		DateStart:    time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+		Payments:     12,
+		IsAnnuity:    annuity,
+	}
+}
+
+func TestUsecase_CreateCredit(t *testing.T) {
+	userID := uuid.New()
+	creditID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		expected    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_CreateCredit",
+			expected:    creditID,
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, credit *models.Credit) (uuid.UUID, error) {
+						assert.Equal(t, userID, credit.UserID)
+						assert.Equal(t, time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC), credit.DateStart)
+						return creditID, nil
+					})
+			},
+		},
+		{
+			name:        "Account of another user in TestUsecase_CreateCredit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't check account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Viewer in TestUsecase_CreateCredit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] viewer can't pay a credit from the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:        "Role Error in TestUsecase_CreateCredit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't check account some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountRole(""), errors.New("some error"))
+			},
+		},
+		{
+			name:        "Create Error in TestUsecase_CreateCredit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't create credit some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo)
+
+			credit := testCredit(true)
+			credit.DateStart = credit.DateStart.Add(15 * time.Hour)
+			id, err := mockUsecase.CreateCredit(context.Background(), userID, credit)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestUsecase_GetCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl))
+
+	credit := testCredit(true)
+	mockRepo.EXPECT().GetCredits(gomock.Any(), gomock.Any()).Return([]models.Credit{*credit}, nil)
+	mockRepo.EXPECT().GetRepayments(gomock.Any(), credit.ID).Return(nil, nil)
+	mockRepo.EXPECT().GetPaymentLinks(gomock.Any(), credit.ID).Return([]models.CreditPaymentLink{{Number: 1, TransactionID: uuid.New()}}, nil)
+	overviews, err := mockUsecase.GetCredits(context.Background(), uuid.New())
+	assert.NoError(t, err)
+	assert.Len(t, overviews, 1)
+	assert.Equal(t, 1200.0, overviews[0].InterestPaid)
+	assert.Equal(t, 2, overviews[0].NextPayment.Number)
+
+	mockRepo.EXPECT().GetCredits(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	_, err = mockUsecase.GetCredits(context.Background(), uuid.New())
+	assert.EqualError(t, err, "[usecase] can't get credits from repository some error")
+}
+
+func TestUsecase_GetSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl))
+
+	credit := testCredit(false)
+	mockRepo.EXPECT().GetCredit(gomock.Any(), gomock.Any(), credit.ID).Return(credit, nil)
+	mockRepo.EXPECT().GetRepayments(gomock.Any(), credit.ID).Return(nil, nil)
+	mockRepo.EXPECT().GetPaymentLinks(gomock.Any(), credit.ID).Return(nil, nil)
+	schedule, err := mockUsecase.GetSchedule(context.Background(), uuid.New(), credit.ID)
+	assert.NoError(t, err)
+	assert.Len(t, schedule.Payments, 12)
+
+	mockRepo.EXPECT().GetCredit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	_, err = mockUsecase.GetSchedule(context.Background(), uuid.New(), uuid.New())
+	assert.EqualError(t, err, "[usecase] can't get credit from repository some error")
+}
+
+func TestUsecase_LinkPayment(t *testing.T) {
+	userID := uuid.New()
+	credit := testCredit(true)
+
+	testCases := []struct {
+		name        string
+		number      int
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_LinkPayment",
+			number:      12,
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().LinkPayment(gomock.Any(), userID, credit.ID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "No such payment in TestUsecase_LinkPayment",
+			number:      13,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "no such payment in the schedule"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+			},
+		},
+		{
+			name:        "Link Error in TestUsecase_LinkPayment",
+			number:      1,
+			expectedErr: fmt.Errorf("[usecase] can't link payment some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().LinkPayment(gomock.Any(), userID, credit.ID, gomock.Any()).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockRepo.EXPECT().GetCredit(gomock.Any(), userID, credit.ID).Return(credit, nil)
+			mockRepo.EXPECT().GetRepayments(gomock.Any(), credit.ID).Return(nil, nil)
+			mockRepo.EXPECT().GetPaymentLinks(gomock.Any(), credit.ID).Return(nil, nil)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl))
+
+			err := mockUsecase.LinkPayment(context.Background(), userID, credit.ID,
+				models.CreditPaymentLink{Number: tc.number, TransactionID: uuid.New()})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_Repay(t *testing.T) {
+	userID := uuid.New()
+	credit := testCredit(true)
+
+	testCases := []struct {
+		name        string
+		repayment   models.CreditRepayment
+		links       []models.CreditPaymentLink
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name: "Successful TestUsecase_Repay",
+			repayment: models.CreditRepayment{
+				Date:   time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC),
+				Amount: 30000,
+				Reduce: models.RepaymentReduceTerm,
+			},
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				repayment := models.CreditRepayment{
+					Date:   time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+					Amount: 30000,
+					Reduce: models.RepaymentReduceTerm,
+				}
+				mockRepository.EXPECT().CreateRepayment(gomock.Any(), userID, credit.ID, &repayment).Return(uuid.New(), nil)
+				mockRepository.EXPECT().GetRepayments(gomock.Any(), credit.ID).Return([]models.CreditRepayment{repayment}, nil)
+				mockRepository.EXPECT().GetPaymentLinks(gomock.Any(), credit.ID).Return(nil, nil)
+			},
+		},
+		{
+			name: "Repayment before start in TestUsecase_Repay",
+			repayment: models.CreditRepayment{
+				Date:   time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC),
+				Amount: 1000,
+			},
+			expectedErr: fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment before the credit start"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+			},
+		},
+		{
+			name: "Repayment before a paid payment in TestUsecase_Repay",
+			repayment: models.CreditRepayment{
+				Date:   time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+				Amount: 1000,
+			},
+			links:       []models.CreditPaymentLink{{Number: 1, TransactionID: uuid.New()}, {Number: 2, TransactionID: uuid.New()}},
+			expectedErr: fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment on or before a paid payment"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+			},
+		},
+		{
+			name: "Repayment on a paid payment date in TestUsecase_Repay",
+			repayment: models.CreditRepayment{
+				Date:   time.Date(2023, time.February, 28, 15, 0, 0, 0, time.UTC),
+				Amount: 1000,
+			},
+			links:       []models.CreditPaymentLink{{Number: 1, TransactionID: uuid.New()}},
+			expectedErr: fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment on or before a paid payment"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+			},
+		},
+		{
+			name: "Repayment over balance in TestUsecase_Repay",
+			repayment: models.CreditRepayment{
+				Date:   time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+				Amount: 120000,
+			},
+			expectedErr: fmt.Errorf("[usecase] %w", &models.CreditOperationError{Reason: "repayment exceeds the remaining principal"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+			},
+		},
+		{
+			name: "Create Error in TestUsecase_Repay",
+			repayment: models.CreditRepayment{
+				Date:   time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+				Amount: 1000,
+			},
+			expectedErr: fmt.Errorf("[usecase] can't create repayment some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().CreateRepayment(gomock.Any(), userID, credit.ID, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockRepo.EXPECT().GetCredit(gomock.Any(), userID, credit.ID).Return(credit, nil)
+			mockRepo.EXPECT().GetRepayments(gomock.Any(), credit.ID).Return(nil, nil)
+			mockRepo.EXPECT().GetPaymentLinks(gomock.Any(), credit.ID).Return(tc.links, nil)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl))
+
+			repayment := tc.repayment
+			schedule, err := mockUsecase.Repay(context.Background(), userID, credit.ID, &repayment)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Less(t, len(schedule.Payments), credit.Payments)
+			}
+		})
+	}
+}
+
+func TestBuildSchedule(t *testing.T) {
+	t.Run("Annuity", func(t *testing.T) {
+		schedule := buildSchedule(testCredit(true), nil, nil)
+
+		assert.Len(t, schedule.Payments, 12)
+		assert.Equal(t, models.CreditPayment{Number: 1, Date: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Payment: 10661.85, Principal: 9461.85, Interest: 1200, Balance: 110538.15}, schedule.Payments[0])
+		assert.Equal(t, time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC), schedule.Payments[1].Date)
+		assert.Equal(t, 0.0, schedule.Payments[11].Balance)
+		assert.InDelta(t, 10661.85, schedule.Payments[11].Payment, 0.1)
+		assert.Equal(t, 120000.0, schedule.RemainingPrincipal)
+	})
+
+	t.Run("Differentiated", func(t *testing.T) {
+		schedule := buildSchedule(testCredit(false), nil, nil)
+
+		assert.Len(t, schedule.Payments, 12)
+		assert.Equal(t, 11200.0, schedule.Payments[0].Payment)
+		assert.Equal(t, 10100.0, schedule.Payments[11].Payment)
+		assert.Equal(t, 7800.0, schedule.InterestTotal)
+	})
+
+	t.Run("Differentiated pays less interest", func(t *testing.T) {
+		annuity := buildSchedule(testCredit(true), nil, nil)
+		differentiated := buildSchedule(testCredit(false), nil, nil)
+
+		assert.Less(t, differentiated.InterestTotal, annuity.InterestTotal)
+	})
+
+	t.Run("Repayment shortens the term", func(t *testing.T) {
+		plain := buildSchedule(testCredit(true), nil, nil)
+		schedule := buildSchedule(testCredit(true), []models.CreditRepayment{{
+			Date:   time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+			Amount: 30000,
+			Reduce: models.RepaymentReduceTerm,
+		}}, nil)
+
+		assert.Less(t, len(schedule.Payments), 12)
+		assert.Equal(t, plain.Payments[2].Payment, schedule.Payments[2].Payment)
+		assert.Less(t, schedule.InterestTotal, plain.InterestTotal)
+		assert.Equal(t, 30000.0, schedule.PrincipalPaid)
+		assert.Equal(t, 90000.0, schedule.RemainingPrincipal)
+	})
+
+	t.Run("Repayment lowers the payment", func(t *testing.T) {
+		plain := buildSchedule(testCredit(true), nil, nil)
+		schedule := buildSchedule(testCredit(true), []models.CreditRepayment{{
+			Date:   time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC),
+			Amount: 30000,
+			Reduce: models.RepaymentReducePayment,
+		}}, nil)
+
+		assert.Len(t, schedule.Payments, 12)
+		assert.Less(t, schedule.Payments[2].Payment, plain.Payments[2].Payment)
+		assert.Equal(t, schedule.Payments[2].Payment, schedule.Payments[3].Payment)
+	})
+
+	t.Run("Linked payments", func(t *testing.T) {
+		transactionID := uuid.New()
+		schedule := buildSchedule(testCredit(false), nil, []models.CreditPaymentLink{{Number: 1, TransactionID: transactionID}})
+
+		assert.Equal(t, &transactionID, schedule.Payments[0].TransactionID)
+		assert.Equal(t, 1200.0, schedule.InterestPaid)
+		assert.Equal(t, 10000.0, schedule.PrincipalPaid)
+		assert.Equal(t, 110000.0, schedule.RemainingPrincipal)
+		assert.Equal(t, 2, nextPayment(schedule).Number)
+	})
+}
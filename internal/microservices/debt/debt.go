@@ -0,0 +1,26 @@
+package debt
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	CreateDebt(ctx context.Context, userID uuid.UUID, debt *models.Debt) (uuid.UUID, error)
+	GetDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error)
+	GetRepayments(ctx context.Context, userID uuid.UUID, debtID uuid.UUID) ([]models.DebtRepayment, error)
+	Repay(ctx context.Context, userID uuid.UUID, debtID uuid.UUID, repayment *models.DebtRepayment) (*models.Debt, error)
+	GetSummary(ctx context.Context, userID uuid.UUID, now time.Time) (*models.DebtSummary, error)
+}
+
+type Repository interface {
+	CreateDebt(ctx context.Context, debt *models.Debt) (uuid.UUID, error)
+	GetDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error)
+	GetOpenDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error)
+	GetDebt(ctx context.Context, userID uuid.UUID, debtID uuid.UUID) (*models.Debt, error)
+	GetRepayments(ctx context.Context, debtID uuid.UUID) ([]models.DebtRepayment, error)
+	Repay(ctx context.Context, debt *models.Debt, repayment *models.DebtRepayment) (uuid.UUID, *models.Overdraft, error)
+}
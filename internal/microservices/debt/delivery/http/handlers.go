@@ -0,0 +1,221 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	debtService debt.Usecase
+	logger      logger.Logger
+}
+
+func NewHandler(du debt.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		debtService: du,
+		logger:      l,
+	}
+}
+
+// @Summary		Create debt
+// @Tags		Debt
+// @Description	Record money lent to or borrowed from a person
+// @Accept 		json
+// @Produce		json
+// @Param		debt	body		CreateDebt					true	"Debt"
+// @Success		200		{object}	Response[DebtCreateResponse]	"Debt created"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/debt/create [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var debtInput CreateDebt
+	if err := easyjson.UnmarshalFromReader(r.Body, &debtInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := debtInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	debtID, err := h.debtService.CreateDebt(r.Context(), user.ID, debtInput.ToDebt())
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DebtCreateServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, DebtCreateResponse{DebtID: debtID})
+}
+
+// @Summary		Get debts
+// @Tags		Debt
+// @Description	Debts of the user, the open ones first by due date
+// @Produce		json
+// @Success		200		{object}	Response[[]models.Debt]	"Debts"
+// @Failure     401    	{object}    ResponseError  			"Unauthorized user"
+// @Failure		500		{object}	ResponseError			"Server error"
+// @Router		/api/debt/all [get]
+func (h *Handler) GetDebts(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	debts, err := h.debtService.GetDebts(r.Context(), user.ID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DebtGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, debts)
+}
+
+// @Summary		Get debt repayments
+// @Tags		Debt
+// @Description	Repayments of the debt with their transactions
+// @Produce		json
+// @Param		debt_id	path		string	true	"Debt ID"
+// @Success		200		{object}	Response[[]models.DebtRepayment]	"Repayments"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/debt/{debt_id}/repayments [get]
+func (h *Handler) GetRepayments(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	debtID, err := commonHttp.GetIDFromRequest(debtID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	repayments, err := h.debtService.GetRepayments(r.Context(), user.ID, debtID)
+
+	var errNoSuchDebt *models.NoSuchDebtError
+	if errors.As(err, &errNoSuchDebt) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, DebtNotSuch, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DebtRepaymentsServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, repayments)
+}
+
+// @Summary		Repay debt
+// @Tags		Debt
+// @Description	Record a partial repayment as a transaction on the account, the debt is closed when repaid in full
+// @Accept 		json
+// @Produce		json
+// @Param		debt_id		path		string		true	"Debt ID"
+// @Param		repayment	body		RepayDebt	true	"Repayment"
+// @Success		200		{object}	Response[models.Debt]	"Updated debt"
+// @Failure		400		{object}	ResponseError			"Client error"
+// @Failure     401    	{object}    ResponseError  			"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  			"Forbidden user"
+// @Failure		500		{object}	ResponseError			"Server error"
+// @Router		/api/debt/{debt_id}/repay [post]
+func (h *Handler) Repay(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	debtID, err := commonHttp.GetIDFromRequest(debtID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var repayInput RepayDebt
+	if err := easyjson.UnmarshalFromReader(r.Body, &repayInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := repayInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	debt, err := h.debtService.Repay(r.Context(), user.ID, debtID, repayInput.ToRepayment())
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	var errNoSuchDebt *models.NoSuchDebtError
+	if errors.As(err, &errNoSuchDebt) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, DebtNotSuch, h.logger)
+		return
+	}
+
+	var errDebtOperation *models.DebtOperationError
+	if errors.As(err, &errDebtOperation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errDebtOperation.Reason, h.logger)
+		return
+	}
+
+	var errOverdraft *models.OverdraftError
+	if errors.As(err, &errOverdraft) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errOverdraft.Error(), h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DebtRepayServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, debt)
+}
+
+// @Summary		Get debts summary
+// @Tags		Debt
+// @Description	Outstanding amounts of the open debts in total and per counterparty with the overdue part
+// @Produce		json
+// @Success		200		{object}	Response[models.DebtSummary]	"Debts summary"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/debt/summary [get]
+func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	summary, err := h.debtService.GetSummary(r.Context(), user.ID, time.Now())
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DebtSummaryServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, summary)
+}
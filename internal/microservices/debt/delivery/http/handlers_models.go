@@ -0,0 +1,116 @@
+package http
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	debtID = "debt_id"
+
+	DebtCreateServerError     = "can't create debt"
+	DebtGetServerError        = "can't get debts"
+	DebtRepaymentsServerError = "can't get debt repayments"
+	DebtRepayServerError      = "can't repay debt"
+	DebtSummaryServerError    = "can't get debts summary"
+	DebtNotSuch               = "no such debt"
+
+	DebtCounterpartyMaxLen = 30
+	DebtDescriptionMaxLen  = 100
+)
+
+var (
+	errInvalidCounterparty = errors.New("counterparty is required")
+	errInvalidDirection    = errors.New("direction must be lent or borrowed")
+	errInvalidTotal        = errors.New("amount must be positive")
+	errInvalidDueDate      = errors.New("due date before the debt date")
+	errInvalidDescription  = errors.New("description is too long")
+	errInvalidAccount      = errors.New("account is required")
+)
+
+type DebtCreateResponse struct {
+	DebtID uuid.UUID `json:"debt_id"`
+}
+
+//easyjson:json
+type CreateDebt struct {
+	Counterparty string     `json:"counterparty"`
+	Direction    string     `json:"direction"`
+	Total        float64    `json:"total"`
+	Date         time.Time  `json:"date"`
+	DueDate      *time.Time `json:"due_date"`
+	Description  string     `json:"description"`
+}
+
+//easyjson:json
+type RepayDebt struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Amount    float64   `json:"amount"`
+	Date      time.Time `json:"date"`
+}
+
+// CheckValid fills the defaults: a debt made today
+func (cd *CreateDebt) CheckValid() error {
+	cd.Counterparty = html.EscapeString(strings.TrimSpace(cd.Counterparty))
+	cd.Description = html.EscapeString(cd.Description)
+
+	if cd.Date.IsZero() {
+		cd.Date = time.Now()
+	}
+
+	switch {
+	case cd.Counterparty == "" || utf8.RuneCountInString(cd.Counterparty) > DebtCounterpartyMaxLen:
+		return errInvalidCounterparty
+	case cd.Direction != models.DebtLent && cd.Direction != models.DebtBorrowed:
+		return errInvalidDirection
+	case cd.Total <= 0:
+		return errInvalidTotal
+	case cd.DueDate != nil && cd.DueDate.Before(cd.Date):
+		return errInvalidDueDate
+	case utf8.RuneCountInString(cd.Description) > DebtDescriptionMaxLen:
+		return errInvalidDescription
+	}
+
+	return nil
+}
+
+func (cd *CreateDebt) ToDebt() *models.Debt {
+	return &models.Debt{
+		Counterparty: cd.Counterparty,
+		Direction:    cd.Direction,
+		Total:        cd.Total,
+		Date:         cd.Date,
+		DueDate:      cd.DueDate,
+		Description:  cd.Description,
+	}
+}
+
+// CheckValid fills the defaults: a repayment made today
+func (rd *RepayDebt) CheckValid() error {
+	if rd.Date.IsZero() {
+		rd.Date = time.Now()
+	}
+
+	switch {
+	case rd.AccountID == uuid.Nil:
+		return errInvalidAccount
+	case rd.Amount <= 0:
+		return errInvalidTotal
+	}
+
+	return nil
+}
+
+func (rd *RepayDebt) ToRepayment() *models.DebtRepayment {
+	return &models.DebtRepayment{
+		AccountID: rd.AccountID,
+		Amount:    rd.Amount,
+		Date:      rd.Date,
+	}
+}
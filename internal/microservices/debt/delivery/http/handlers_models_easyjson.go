@@ -0,0 +1,221 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp(in *jlexer.Lexer, out *RepayDebt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		case "amount":
+			out.Amount = float64(in.Float64())
+		case "date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Date).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp(out *jwriter.Writer, in RepayDebt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Amount))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((in.Date).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepayDebt) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepayDebt) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepayDebt) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepayDebt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp(l, v)
+}
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp1(in *jlexer.Lexer, out *CreateDebt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "counterparty":
+			out.Counterparty = string(in.String())
+		case "direction":
+			out.Direction = string(in.String())
+		case "total":
+			out.Total = float64(in.Float64())
+		case "date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Date).UnmarshalJSON(data))
+			}
+		case "due_date":
+			if in.IsNull() {
+				in.Skip()
+				out.DueDate = nil
+			} else {
+				if out.DueDate == nil {
+					out.DueDate = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.DueDate).UnmarshalJSON(data))
+				}
+			}
+		case "description":
+			out.Description = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp1(out *jwriter.Writer, in CreateDebt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"counterparty\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Counterparty))
+	}
+	{
+		const prefix string = ",\"direction\":"
+		out.RawString(prefix)
+		out.String(string(in.Direction))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Total))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((in.Date).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"due_date\":"
+		out.RawString(prefix)
+		if in.DueDate == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.DueDate).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateDebt) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateDebt) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateDebt) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateDebt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDebtDeliveryHttp1(l, v)
+}
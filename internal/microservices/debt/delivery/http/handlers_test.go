@@ -0,0 +1,340 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Create(t *testing.T) {
+	uuidTest := uuid.New()
+	debtID := uuid.MustParse("8a3f5c2e-1d4b-4f6a-9b8c-0e7d6c5b4a39")
+	user := &models.User{ID: uuidTest}
+	validBody := `{"counterparty":" Аня ","direction":"lent","total":3000,"date":"2023-02-01T00:00:00Z","due_date":"2023-03-01T00:00:00Z"}`
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Create",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"debt_id":"8a3f5c2e-1d4b-4f6a-9b8c-0e7d6c5b4a39"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateDebt(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, debt *models.Debt) (uuid.UUID, error) {
+						assert.Equal(t, "Аня", debt.Counterparty)
+						assert.Equal(t, models.DebtLent, debt.Direction)
+						assert.Equal(t, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), *debt.DueDate)
+						return debtID, nil
+					})
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid body",
+			user:         user,
+			body:         `{"counterparty":`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid direction",
+			user:         user,
+			body:         `{"counterparty":"Аня","direction":"given","total":3000}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Due date before the debt",
+			user:         user,
+			body:         `{"counterparty":"Аня","direction":"lent","total":3000,"date":"2023-02-01T00:00:00Z","due_date":"2023-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't create debt"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateDebt(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/debt/create", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Create(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Repay(t *testing.T) {
+	uuidTest := uuid.New()
+	debtID := uuid.New()
+	accountID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	validBody := fmt.Sprintf(`{"account_id":"%s","amount":1000,"date":"2023-02-10T00:00:00Z"}`, accountID)
+	tests := []struct {
+		name          string
+		user          *models.User
+		debtID        string
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Repay",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"id":"00000000-0000-0000-0000-000000000000","counterparty":"Аня","direction":"lent","total":3000,"repaid":1000,"outstanding":2000,"date":"2023-02-01T00:00:00Z","due_date":null,"description":"","closed_at":null}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, debtID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ uuid.UUID, repayment *models.DebtRepayment) (*models.Debt, error) {
+						assert.Equal(t, accountID, repayment.AccountID)
+						assert.Equal(t, 1000.0, repayment.Amount)
+						return &models.Debt{
+							Counterparty: "Аня", Direction: models.DebtLent, Total: 3000, Repaid: 1000, Outstanding: 2000,
+							Date: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+						}, nil
+					})
+			},
+		},
+		{
+			name:         "Invalid debt id",
+			user:         user,
+			debtID:       "debt",
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No account",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         `{"amount":1000}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         validBody,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, debtID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] account of another user %w", &models.ForbiddenUserError{}))
+			},
+		},
+		{
+			name:         "No such debt",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such debt"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, debtID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] can't get debt from repository %w", &models.NoSuchDebtError{DebtID: debtID}))
+			},
+		},
+		{
+			name:         "Repayment over outstanding",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"repayment exceeds the outstanding amount"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, debtID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "repayment exceeds the outstanding amount"}))
+			},
+		},
+		{
+			name:         "Overdraft rejected",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"not enough funds on account ` + accountID.String() + `: 500.00 available"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, debtID, gomock.Any()).
+					Return(nil, fmt.Errorf("[usecase] can't repay debt [repo] %w", &models.OverdraftError{AccountID: accountID, Available: 500}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			debtID:       debtID.String(),
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't repay debt"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Repay(gomock.Any(), uuidTest, debtID, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/debt/"+tt.debtID+"/repay", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"debt_id": tt.debtID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Repay(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetSummary(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetSummary",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"lent":3000,"borrowed":1000,"net":2000,"overdue_lent":0,"overdue_borrowed":0,"counterparties":[{"counterparty":"Аня","lent":3000,"borrowed":1000,"net":2000,"overdue":0,"next_due":null}]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetSummary(gomock.Any(), uuidTest, gomock.Any()).Return(&models.DebtSummary{
+					Lent: 3000, Borrowed: 1000, Net: 2000,
+					Counterparties: []models.DebtCounterparty{{Counterparty: "Аня", Lent: 3000, Borrowed: 1000, Net: 2000}},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get debts summary"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetSummary(gomock.Any(), uuidTest, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/debt/summary", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetSummary(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
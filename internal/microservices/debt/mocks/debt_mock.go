@@ -0,0 +1,227 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: debt.go
+
+// Package mock_debt is a generated GoMock package.
+package mock_debt
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateDebt mocks base method.
+func (m *MockUsecase) CreateDebt(ctx context.Context, userID uuid.UUID, debt *models.Debt) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDebt", ctx, userID, debt)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDebt indicates an expected call of CreateDebt.
+func (mr *MockUsecaseMockRecorder) CreateDebt(ctx, userID, debt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebt", reflect.TypeOf((*MockUsecase)(nil).CreateDebt), ctx, userID, debt)
+}
+
+// GetDebts mocks base method.
+func (m *MockUsecase) GetDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebts", ctx, userID)
+	ret0, _ := ret[0].([]models.Debt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDebts indicates an expected call of GetDebts.
+func (mr *MockUsecaseMockRecorder) GetDebts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebts", reflect.TypeOf((*MockUsecase)(nil).GetDebts), ctx, userID)
+}
+
+// GetRepayments mocks base method.
+func (m *MockUsecase) GetRepayments(ctx context.Context, userID, debtID uuid.UUID) ([]models.DebtRepayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepayments", ctx, userID, debtID)
+	ret0, _ := ret[0].([]models.DebtRepayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepayments indicates an expected call of GetRepayments.
+func (mr *MockUsecaseMockRecorder) GetRepayments(ctx, userID, debtID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepayments", reflect.TypeOf((*MockUsecase)(nil).GetRepayments), ctx, userID, debtID)
+}
+
+// GetSummary mocks base method.
+func (m *MockUsecase) GetSummary(ctx context.Context, userID uuid.UUID, now time.Time) (*models.DebtSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummary", ctx, userID, now)
+	ret0, _ := ret[0].(*models.DebtSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockUsecaseMockRecorder) GetSummary(ctx, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockUsecase)(nil).GetSummary), ctx, userID, now)
+}
+
+// Repay mocks base method.
+func (m *MockUsecase) Repay(ctx context.Context, userID, debtID uuid.UUID, repayment *models.DebtRepayment) (*models.Debt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repay", ctx, userID, debtID, repayment)
+	ret0, _ := ret[0].(*models.Debt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Repay indicates an expected call of Repay.
+func (mr *MockUsecaseMockRecorder) Repay(ctx, userID, debtID, repayment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repay", reflect.TypeOf((*MockUsecase)(nil).Repay), ctx, userID, debtID, repayment)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDebt mocks base method.
+func (m *MockRepository) CreateDebt(ctx context.Context, debt *models.Debt) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDebt", ctx, debt)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDebt indicates an expected call of CreateDebt.
+func (mr *MockRepositoryMockRecorder) CreateDebt(ctx, debt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebt", reflect.TypeOf((*MockRepository)(nil).CreateDebt), ctx, debt)
+}
+
+// GetDebt mocks base method.
+func (m *MockRepository) GetDebt(ctx context.Context, userID, debtID uuid.UUID) (*models.Debt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebt", ctx, userID, debtID)
+	ret0, _ := ret[0].(*models.Debt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDebt indicates an expected call of GetDebt.
+func (mr *MockRepositoryMockRecorder) GetDebt(ctx, userID, debtID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebt", reflect.TypeOf((*MockRepository)(nil).GetDebt), ctx, userID, debtID)
+}
+
+// GetDebts mocks base method.
+func (m *MockRepository) GetDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebts", ctx, userID)
+	ret0, _ := ret[0].([]models.Debt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDebts indicates an expected call of GetDebts.
+func (mr *MockRepositoryMockRecorder) GetDebts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebts", reflect.TypeOf((*MockRepository)(nil).GetDebts), ctx, userID)
+}
+
+// GetOpenDebts mocks base method.
+func (m *MockRepository) GetOpenDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenDebts", ctx, userID)
+	ret0, _ := ret[0].([]models.Debt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenDebts indicates an expected call of GetOpenDebts.
+func (mr *MockRepositoryMockRecorder) GetOpenDebts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenDebts", reflect.TypeOf((*MockRepository)(nil).GetOpenDebts), ctx, userID)
+}
+
+// GetRepayments mocks base method.
+func (m *MockRepository) GetRepayments(ctx context.Context, debtID uuid.UUID) ([]models.DebtRepayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepayments", ctx, debtID)
+	ret0, _ := ret[0].([]models.DebtRepayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepayments indicates an expected call of GetRepayments.
+func (mr *MockRepositoryMockRecorder) GetRepayments(ctx, debtID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepayments", reflect.TypeOf((*MockRepository)(nil).GetRepayments), ctx, debtID)
+}
+
+// Repay mocks base method.
+func (m *MockRepository) Repay(ctx context.Context, debt *models.Debt, repayment *models.DebtRepayment) (uuid.UUID, *models.Overdraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repay", ctx, debt, repayment)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(*models.Overdraft)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Repay indicates an expected call of Repay.
+func (mr *MockRepositoryMockRecorder) Repay(ctx, debt, repayment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repay", reflect.TypeOf((*MockRepository)(nil).Repay), ctx, debt, repayment)
+}
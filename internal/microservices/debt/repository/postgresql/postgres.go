@@ -0,0 +1,243 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	DebtCreate = `INSERT INTO Debt (user_id, counterparty, direction, total, date, due_date, description)
+				  VALUES ($1, $2, $3, $4, $5, $6, $7)
+				  RETURNING id;`
+
+	debtColumns = `SELECT id, user_id, counterparty, direction, total, repaid, date, due_date, description, closed_at
+				   FROM Debt `
+
+	DebtGetAll  = debtColumns + "WHERE user_id = $1 ORDER BY closed_at DESC NULLS FIRST, due_date NULLS LAST, date;"
+	DebtGetOpen = debtColumns + "WHERE user_id = $1 AND closed_at IS NULL ORDER BY counterparty, due_date NULLS LAST;"
+	DebtGet     = debtColumns + "WHERE id = $1 AND user_id = $2;"
+
+	DebtRepaymentsGet = `SELECT id, account_id, transaction_id, amount, date
+						 FROM DebtRepayment
+						 WHERE debt_id = $1
+						 ORDER BY date;`
+
+	DebtTransactionCreate = `INSERT INTO Transaction (user_id, account_income, account_outcome, income, outcome, date, payer, description)
+							 VALUES ($1, $2, $2, $3, $4, $5, $6, $7)
+							 RETURNING id;`
+
+	// a repayment has no category, only the total of the day is counted
+	DebtDailyTotals = `INSERT INTO DailyTotals (user_id, account_id, category_id, day, income, outcome)
+					   VALUES ($1, $2, '00000000-0000-0000-0000-000000000000', $3::date, $4, $5)
+					   ON CONFLICT (user_id, account_id, category_id, day) DO UPDATE
+					   SET income = DailyTotals.income + EXCLUDED.income,
+						   outcome = DailyTotals.outcome + EXCLUDED.outcome;`
+
+	DebtRepaymentCreate = `INSERT INTO DebtRepayment (debt_id, account_id, transaction_id, amount, date)
+						   VALUES ($1, $2, $3, $4, $5)
+						   RETURNING id;`
+
+	// the debt is closed by the repayment that covers the rest of it
+	DebtRepaid = `UPDATE Debt
+				  SET repaid = repaid + $2, closed_at = CASE WHEN repaid + $2 >= total THEN $3::date END
+				  WHERE id = $1;`
+
+	// the payer column is shorter than the counterparty name
+	debtPayerMaxLen         = 20
+	debtLentDescription     = "Возврат долга"
+	debtBorrowedDescription = "Погашение долга"
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) CreateDebt(ctx context.Context, debt *models.Debt) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	err := r.db.QueryRow(ctx, DebtCreate,
+		debt.UserID,
+		debt.Counterparty,
+		debt.Direction,
+		debt.Total,
+		debt.Date,
+		debt.DueDate,
+		debt.Description,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create debt: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error) {
+	return r.queryDebts(ctx, DebtGetAll, userID)
+}
+
+func (r *Repository) GetOpenDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error) {
+	return r.queryDebts(ctx, DebtGetOpen, userID)
+}
+
+func (r *Repository) GetDebt(ctx context.Context, userID uuid.UUID, debtID uuid.UUID) (*models.Debt, error) {
+	debt, err := scanDebt(r.db.QueryRow(ctx, DebtGet, debtID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchDebtError{DebtID: debtID})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &debt, nil
+}
+
+func (r *Repository) GetRepayments(ctx context.Context, debtID uuid.UUID) ([]models.DebtRepayment, error) {
+	repayments := []models.DebtRepayment{}
+
+	rows, err := r.db.Query(ctx, DebtRepaymentsGet, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var repayment models.DebtRepayment
+		if err := rows.Scan(
+			&repayment.ID,
+			&repayment.AccountID,
+			&repayment.TransactionID,
+			&repayment.Amount,
+			&repayment.Date,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		repayments = append(repayments, repayment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return repayments, nil
+}
+
+// Repay posts the repayment as a transaction on the account: money lent comes back as income,
+// money borrowed is paid back as outcome charged by the overdraft policy of the account, the overdraft
+// the warn policy let through is returned
+func (r *Repository) Repay(ctx context.Context, debt *models.Debt, repayment *models.DebtRepayment) (uuid.UUID, *models.Overdraft, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	income, outcome, description := 0.0, repayment.Amount, debtBorrowedDescription
+	if debt.Direction == models.DebtLent {
+		income, outcome, description = repayment.Amount, 0, debtLentDescription
+	}
+
+	payer := []rune(debt.Counterparty)
+	if len(payer) > debtPayerMaxLen {
+		payer = payer[:debtPayerMaxLen]
+	}
+
+	var transactionID uuid.UUID
+	if err = tx.QueryRow(ctx, DebtTransactionCreate, debt.UserID, repayment.AccountID, income, outcome,
+		repayment.Date, string(payer), description).Scan(&transactionID); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("[repo] failed to create repayment transaction: %w", err)
+	}
+
+	overdraft, err := accountRep.ChargeAccount(ctx, tx, repayment.AccountID, outcome-income)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	if overdraft != nil {
+		overdraft.TransactionID = transactionID
+	}
+
+	if _, err = tx.Exec(ctx, DebtDailyTotals, debt.UserID, repayment.AccountID, repayment.Date, income, outcome); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("[repo] failed to update daily totals: %w", err)
+	}
+
+	var id uuid.UUID
+	if err = tx.QueryRow(ctx, DebtRepaymentCreate, debt.ID, repayment.AccountID, transactionID,
+		repayment.Amount, repayment.Date).Scan(&id); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("[repo] failed to create repayment: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, DebtRepaid, debt.ID, repayment.Amount, repayment.Date); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("[repo] failed to update debt: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	repayment.TransactionID = transactionID
+	return id, overdraft, nil
+}
+
+func (r *Repository) queryDebts(ctx context.Context, query string, userID uuid.UUID) ([]models.Debt, error) {
+	debts := []models.Debt{}
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		debt, err := scanDebt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		debts = append(debts, debt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return debts, nil
+}
+
+func scanDebt(row pgx.Row) (models.Debt, error) {
+	var debt models.Debt
+	err := row.Scan(
+		&debt.ID,
+		&debt.UserID,
+		&debt.Counterparty,
+		&debt.Direction,
+		&debt.Total,
+		&debt.Repaid,
+		&debt.Date,
+		&debt.DueDate,
+		&debt.Description,
+		&debt.ClosedAt,
+	)
+	debt.Outstanding = math.Round((debt.Total-debt.Repaid)*100) / 100
+	return debt, err
+}
@@ -0,0 +1,352 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var debtRowColumns = []string{"id", "user_id", "counterparty", "direction", "total", "repaid", "date", "due_date",
+	"description", "closed_at"}
+
+func testDebt() models.Debt {
+	dueDate := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return models.Debt{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		Counterparty: "Константин Константинопольский",
+		Direction:    models.DebtLent,
+		Total:        5000,
+		Repaid:       1500,
+		Outstanding:  3500,
+		Date:         time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:      &dueDate,
+		Description:  "На билеты",
+	}
+}
+
+func addDebtRow(rows *pgxmock.Rows, d models.Debt) *pgxmock.Rows {
+	return rows.AddRow(d.ID, d.UserID, d.Counterparty, d.Direction, d.Total, d.Repaid, d.Date, d.DueDate,
+		d.Description, d.ClosedAt)
+}
+
+func Test_CreateDebt(t *testing.T) {
+	debt := testDebt()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        pgxmock.NewRows([]string{"id"}).AddRow(debt.ID),
+			expected:    debt.ID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create debt: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(DebtCreate)).
+				WithArgs(debt.UserID, debt.Counterparty, debt.Direction, debt.Total, debt.Date, debt.DueDate, debt.Description).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			id, err := repo.CreateDebt(context.Background(), &debt)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("Expected debt %s, but got: %s", tc.expected, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetDebts(t *testing.T) {
+	debt := testDebt()
+
+	testCases := []struct {
+		name        string
+		query       string
+		open        bool
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:        "All",
+			query:       DebtGetAll,
+			rows:        addDebtRow(addDebtRow(pgxmock.NewRows(debtRowColumns), debt), testDebt()),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Open",
+			query:       DebtGetOpen,
+			open:        true,
+			rows:        addDebtRow(pgxmock.NewRows(debtRowColumns), debt),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			query:       DebtGetAll,
+			rows:        pgxmock.NewRows(debtRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
+				WithArgs(debt.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			var debts []models.Debt
+			var err error
+			if tc.open {
+				debts, err = repo.GetOpenDebts(context.Background(), debt.UserID)
+			} else {
+				debts, err = repo.GetDebts(context.Background(), debt.UserID)
+			}
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(debts) != tc.expectedLen {
+				t.Errorf("Expected %d debts, but got: %d", tc.expectedLen, len(debts))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetDebt(t *testing.T) {
+	debt := testDebt()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        addDebtRow(pgxmock.NewRows(debtRowColumns), debt),
+			expectedErr: nil,
+		},
+		{
+			name:        "No such debt",
+			rows:        pgxmock.NewRows(debtRowColumns),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchDebtError{DebtID: debt.ID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(debtRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(DebtGet)).
+				WithArgs(debt.ID, debt.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			actual, err := repo.GetDebt(context.Background(), debt.UserID, debt.ID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Equal(t, &debt, actual)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_Repay(t *testing.T) {
+	accountID := uuid.New()
+	transactionID := uuid.New()
+	repaymentID := uuid.New()
+	date := time.Date(2023, time.February, 15, 0, 0, 0, 0, time.UTC)
+
+	chargeColumns := []string{"id", "balance", "kind", "credit_limit", "overdraft_policy"}
+
+	testCases := []struct {
+		name              string
+		direction         string
+		balance           float64
+		policy            models.OverdraftPolicy
+		execError         error
+		expected          uuid.UUID
+		expectedOverdraft *models.Overdraft
+		expectedErr       error
+	}{
+		{
+			name:      "Lent money comes back",
+			direction: models.DebtLent,
+			balance:   0,
+			policy:    models.OverdraftReject,
+			expected:  repaymentID,
+		},
+		{
+			name:      "Borrowed money is paid back",
+			direction: models.DebtBorrowed,
+			balance:   5000,
+			policy:    models.OverdraftReject,
+			expected:  repaymentID,
+		},
+		{
+			name:      "Borrowed money is paid back over the balance",
+			direction: models.DebtBorrowed,
+			balance:   500,
+			policy:    models.OverdraftWarn,
+			expected:  repaymentID,
+			expectedOverdraft: &models.Overdraft{
+				AccountID: accountID, TransactionID: transactionID, Amount: 1000, Available: 500,
+			},
+		},
+		{
+			name:        "Overdraft rejected",
+			direction:   models.DebtBorrowed,
+			balance:     500,
+			policy:      models.OverdraftReject,
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: accountID, Available: 500}),
+		},
+		{
+			name:        "Error",
+			direction:   models.DebtLent,
+			policy:      models.OverdraftReject,
+			execError:   errors.New("Some error"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to update account balance: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			debt := testDebt()
+			debt.Direction = tc.direction
+			repayment := models.DebtRepayment{AccountID: accountID, Amount: 1000, Date: date}
+
+			income, outcome, description := 1000.0, 0.0, debtLentDescription
+			if tc.direction == models.DebtBorrowed {
+				income, outcome, description = 0, 1000, debtBorrowedDescription
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(DebtTransactionCreate)).
+				WithArgs(debt.UserID, accountID, income, outcome, date, "Константин Константи", description).
+				WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(transactionID))
+			mock.ExpectQuery(regexp.QuoteMeta(accountRep.AccountChargeLock)).
+				WithArgs(accountID).
+				WillReturnRows(pgxmock.NewRows(chargeColumns).
+					AddRow(accountID, tc.balance, models.AccountDebit, 0.0, tc.policy))
+			if tc.policy == models.OverdraftReject && outcome > tc.balance {
+				mock.ExpectRollback()
+			} else {
+				balance := mock.ExpectExec(regexp.QuoteMeta(accountRep.AccountCharge)).
+					WithArgs(outcome-income, accountID)
+				if tc.execError != nil {
+					balance.WillReturnError(tc.execError)
+					mock.ExpectRollback()
+				} else {
+					balance.WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+					mock.ExpectExec(regexp.QuoteMeta(DebtDailyTotals)).
+						WithArgs(debt.UserID, accountID, date, income, outcome).
+						WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+					mock.ExpectQuery(regexp.QuoteMeta(DebtRepaymentCreate)).
+						WithArgs(debt.ID, accountID, transactionID, 1000.0, date).
+						WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(repaymentID))
+					mock.ExpectExec(regexp.QuoteMeta(DebtRepaid)).
+						WithArgs(debt.ID, 1000.0, date).
+						WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+					mock.ExpectCommit()
+				}
+			}
+
+			id, overdraft, err := repo.Repay(context.Background(), &debt, &repayment)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("Expected repayment %s, but got: %s", tc.expected, id)
+			}
+			assert.Equal(t, tc.expectedOverdraft, overdraft)
+			if tc.expectedErr == nil {
+				assert.Equal(t, transactionID, repayment.TransactionID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
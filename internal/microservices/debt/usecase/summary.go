@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+// buildSummary sums the outstanding amounts of the open debts per counterparty,
+// a debt is overdue when its due date is before today
+func buildSummary(debts []models.Debt, now time.Time) *models.DebtSummary {
+	today := dates.TruncateDay(now)
+
+	summary := &models.DebtSummary{Counterparties: []models.DebtCounterparty{}}
+	index := make(map[string]int)
+
+	for _, debt := range debts {
+		i, ok := index[debt.Counterparty]
+		if !ok {
+			i = len(summary.Counterparties)
+			index[debt.Counterparty] = i
+			summary.Counterparties = append(summary.Counterparties, models.DebtCounterparty{Counterparty: debt.Counterparty})
+		}
+		counterparty := &summary.Counterparties[i]
+
+		overdue := debt.DueDate != nil && debt.DueDate.Before(today)
+		if debt.Direction == models.DebtLent {
+			counterparty.Lent += debt.Outstanding
+			summary.Lent += debt.Outstanding
+			if overdue {
+				counterparty.Overdue += debt.Outstanding
+				summary.OverdueLent += debt.Outstanding
+			}
+		} else {
+			counterparty.Borrowed += debt.Outstanding
+			summary.Borrowed += debt.Outstanding
+			if overdue {
+				counterparty.Overdue -= debt.Outstanding
+				summary.OverdueBorrowed += debt.Outstanding
+			}
+		}
+
+		if debt.DueDate != nil && (counterparty.NextDue == nil || debt.DueDate.Before(*counterparty.NextDue)) {
+			dueDate := *debt.DueDate
+			counterparty.NextDue = &dueDate
+		}
+	}
+
+	for i := range summary.Counterparties {
+		counterparty := &summary.Counterparties[i]
+		counterparty.Lent = money.Round2(counterparty.Lent)
+		counterparty.Borrowed = money.Round2(counterparty.Borrowed)
+		counterparty.Overdue = money.Round2(counterparty.Overdue)
+		counterparty.Net = money.Round2(counterparty.Lent - counterparty.Borrowed)
+	}
+
+	sort.SliceStable(summary.Counterparties, func(i, j int) bool {
+		return math.Abs(summary.Counterparties[i].Net) > math.Abs(summary.Counterparties[j].Net)
+	})
+
+	summary.Lent = money.Round2(summary.Lent)
+	summary.Borrowed = money.Round2(summary.Borrowed)
+	summary.Net = money.Round2(summary.Lent - summary.Borrowed)
+	summary.OverdueLent = money.Round2(summary.OverdueLent)
+	summary.OverdueBorrowed = money.Round2(summary.OverdueBorrowed)
+
+	return summary
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	debtRepo       debt.Repository
+	logger         logger.Logger
+	accountRepo    account.Repository
+	anomalyService anomaly.Usecase
+}
+
+func NewUsecase(dr debt.Repository, log logger.Logger, ar account.Repository, au anomaly.Usecase) *Usecase {
+	return &Usecase{
+		debtRepo:       dr,
+		logger:         log,
+		accountRepo:    ar,
+		anomalyService: au,
+	}
+}
+
+func (u *Usecase) CreateDebt(ctx context.Context, userID uuid.UUID, debt *models.Debt) (uuid.UUID, error) {
+	debt.UserID = userID
+	debt.Date = dates.TruncateDay(debt.Date)
+	if debt.DueDate != nil {
+		dueDate := dates.TruncateDay(*debt.DueDate)
+		debt.DueDate = &dueDate
+	}
+
+	id, err := u.debtRepo.CreateDebt(ctx, debt)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create debt %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetDebts(ctx context.Context, userID uuid.UUID) ([]models.Debt, error) {
+	debts, err := u.debtRepo.GetDebts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get debts from repository %w", err)
+	}
+	return debts, nil
+}
+
+func (u *Usecase) GetRepayments(ctx context.Context, userID uuid.UUID, debtID uuid.UUID) ([]models.DebtRepayment, error) {
+	if _, err := u.debtRepo.GetDebt(ctx, userID, debtID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't get debt from repository %w", err)
+	}
+
+	repayments, err := u.debtRepo.GetRepayments(ctx, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get repayments from repository %w", err)
+	}
+	return repayments, nil
+}
+
+// Repay records a partial repayment on an account of the user and returns the updated debt
+func (u *Usecase) Repay(ctx context.Context, userID uuid.UUID, debtID uuid.UUID, repayment *models.DebtRepayment) (*models.Debt, error) {
+	debt, err := u.debtRepo.GetDebt(ctx, userID, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get debt from repository %w", err)
+	}
+
+	// a repayment of a lent debt is income on the account, of a borrowed one an outcome
+	income := 0.0
+	if debt.Direction == models.DebtLent {
+		income = repayment.Amount
+	}
+
+	role, err := u.accountRepo.GetRole(ctx, repayment.AccountID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't check account %w", err)
+	}
+	if !role.CanAdd(income) {
+		return nil, fmt.Errorf("[usecase] %s can't repay from the account %w", role, &models.ForbiddenUserError{})
+	}
+
+	repayment.Date = dates.TruncateDay(repayment.Date)
+	switch {
+	case debt.ClosedAt != nil:
+		return nil, fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "debt is already repaid"})
+	case repayment.Date.Before(debt.Date):
+		return nil, fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "repayment before the debt date"})
+	case repayment.Amount > debt.Outstanding:
+		return nil, fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "repayment exceeds the outstanding amount"})
+	}
+
+	_, overdraft, err := u.debtRepo.Repay(ctx, debt, repayment)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't repay debt %w", err)
+	}
+	u.recordOverdraft(ctx, userID, overdraft)
+
+	debt.Repaid = money.Round2(debt.Repaid + repayment.Amount)
+	debt.Outstanding = money.Round2(debt.Total - debt.Repaid)
+	if debt.Outstanding <= 0 {
+		debt.ClosedAt = &repayment.Date
+	}
+	return debt, nil
+}
+
+// recordOverdraft raises the anomaly of an overdraft the warn policy let through,
+// the money is already moved so a failure is only logged
+func (u *Usecase) recordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) {
+	if overdraft == nil {
+		return
+	}
+	if err := u.anomalyService.RecordOverdraft(ctx, userID, overdraft); err != nil {
+		u.logger.Errorf("[usecase] can't record overdraft of account %s: %v", overdraft.AccountID, err)
+	}
+}
+
+func (u *Usecase) GetSummary(ctx context.Context, userID uuid.UUID, now time.Time) (*models.DebtSummary, error) {
+	debts, err := u.debtRepo.GetOpenDebts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get open debts from repository %w", err)
+	}
+	return buildSummary(debts, now), nil
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mock_anomaly "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/debt/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDebt(counterparty string, direction string, outstanding float64, dueDate *time.Time) models.Debt {
+	return models.Debt{
+		ID:           uuid.New(),
+		Counterparty: counterparty,
+		Direction:    direction,
+		Total:        outstanding,
+		Outstanding:  outstanding,
+		Date:         time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:      dueDate,
+	}
+}
+
+func TestUsecase_CreateDebt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+	userID := uuid.New()
+	debtID := uuid.New()
+	dueDate := time.Date(2023, time.March, 1, 18, 30, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().CreateDebt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, debt *models.Debt) (uuid.UUID, error) {
+			assert.Equal(t, userID, debt.UserID)
+			assert.Equal(t, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), *debt.DueDate)
+			return debtID, nil
+		})
+	id, err := mockUsecase.CreateDebt(context.Background(), userID, &models.Debt{Date: time.Now(), DueDate: &dueDate})
+	assert.NoError(t, err)
+	assert.Equal(t, debtID, id)
+
+	mockRepo.EXPECT().CreateDebt(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+	_, err = mockUsecase.CreateDebt(context.Background(), userID, &models.Debt{})
+	assert.EqualError(t, err, "[usecase] can't create debt some error")
+}
+
+func TestUsecase_GetRepayments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+	debtID := uuid.New()
+
+	mockRepo.EXPECT().GetDebt(gomock.Any(), gomock.Any(), debtID).Return(&models.Debt{ID: debtID}, nil)
+	mockRepo.EXPECT().GetRepayments(gomock.Any(), debtID).Return([]models.DebtRepayment{{Amount: 1000}}, nil)
+	repayments, err := mockUsecase.GetRepayments(context.Background(), uuid.New(), debtID)
+	assert.NoError(t, err)
+	assert.Len(t, repayments, 1)
+
+	mockRepo.EXPECT().GetDebt(gomock.Any(), gomock.Any(), debtID).Return(nil, &models.NoSuchDebtError{DebtID: debtID})
+	_, err = mockUsecase.GetRepayments(context.Background(), uuid.New(), debtID)
+	var errNoSuchDebt *models.NoSuchDebtError
+	assert.ErrorAs(t, err, &errNoSuchDebt)
+}
+
+func TestUsecase_Repay(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	closedAt := time.Date(2023, time.February, 10, 0, 0, 0, 0, time.UTC)
+	overdraft := &models.Overdraft{AccountID: accountID, TransactionID: uuid.New(), Amount: 1000, Available: 500}
+
+	testCases := []struct {
+		name        string
+		debt        models.Debt
+		amount      float64
+		date        time.Time
+		overdraft   *models.Overdraft
+		expected    *models.Debt
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name:   "Partial repayment in TestUsecase_Repay",
+			debt:   models.Debt{Total: 5000, Repaid: 1000, Outstanding: 4000, Date: closedAt.AddDate(0, 0, -9)},
+			amount: 1500,
+			date:   time.Date(2023, time.February, 10, 15, 0, 0, 0, time.UTC),
+			expected: &models.Debt{
+				Total: 5000, Repaid: 2500, Outstanding: 2500, Date: closedAt.AddDate(0, 0, -9),
+			},
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().Repay(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *models.Debt, repayment *models.DebtRepayment) (uuid.UUID, *models.Overdraft, error) {
+						assert.Equal(t, closedAt, repayment.Date)
+						return uuid.New(), nil, nil
+					})
+			},
+		},
+		{
+			name:   "Full repayment closes the debt in TestUsecase_Repay",
+			debt:   models.Debt{Total: 5000, Repaid: 1000, Outstanding: 4000, Date: closedAt.AddDate(0, 0, -9)},
+			amount: 4000,
+			date:   closedAt,
+			expected: &models.Debt{
+				Total: 5000, Repaid: 5000, Outstanding: 0, Date: closedAt.AddDate(0, 0, -9), ClosedAt: &closedAt,
+			},
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().Repay(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), nil, nil)
+			},
+		},
+		{
+			name:   "Repayment over the balance raises an overdraft in TestUsecase_Repay",
+			debt:   models.Debt{Total: 5000, Outstanding: 5000, Direction: models.DebtBorrowed, Date: closedAt},
+			amount: 1000,
+			date:   closedAt,
+			expected: &models.Debt{
+				Total: 5000, Repaid: 1000, Outstanding: 4000, Direction: models.DebtBorrowed, Date: closedAt,
+			},
+			overdraft: overdraft,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().Repay(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), overdraft, nil)
+			},
+		},
+		{
+			name:        "Account of another user in TestUsecase_Repay",
+			debt:        models.Debt{Total: 5000, Outstanding: 5000},
+			amount:      1000,
+			date:        closedAt,
+			expectedErr: fmt.Errorf("[usecase] can't check account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Viewer can't repay in TestUsecase_Repay",
+			debt:        models.Debt{Total: 5000, Outstanding: 5000},
+			amount:      1000,
+			date:        closedAt,
+			expectedErr: fmt.Errorf("[usecase] viewer can't repay from the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:        "Contributor can't take a lent repayment in TestUsecase_Repay",
+			debt:        models.Debt{Direction: models.DebtLent, Total: 5000, Outstanding: 5000},
+			amount:      1000,
+			date:        closedAt,
+			expectedErr: fmt.Errorf("[usecase] contributor can't repay from the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountContributor, nil)
+			},
+		},
+		{
+			name:        "Closed debt in TestUsecase_Repay",
+			debt:        models.Debt{Total: 5000, Repaid: 5000, ClosedAt: &closedAt},
+			amount:      1000,
+			date:        closedAt,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "debt is already repaid"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:        "Repayment before the debt in TestUsecase_Repay",
+			debt:        models.Debt{Total: 5000, Outstanding: 5000, Date: closedAt},
+			amount:      1000,
+			date:        closedAt.AddDate(0, 0, -1),
+			expectedErr: fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "repayment before the debt date"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:        "Repayment over outstanding in TestUsecase_Repay",
+			debt:        models.Debt{Total: 5000, Repaid: 4500, Outstanding: 500},
+			amount:      1000,
+			date:        closedAt,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.DebtOperationError{Reason: "repayment exceeds the outstanding amount"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:        "Repay Error in TestUsecase_Repay",
+			debt:        models.Debt{Total: 5000, Outstanding: 5000},
+			amount:      1000,
+			date:        closedAt,
+			expectedErr: fmt.Errorf("[usecase] can't repay debt some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().Repay(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.Nil, nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			debt := tc.debt
+			mockRepo.EXPECT().GetDebt(gomock.Any(), userID, gomock.Any()).Return(&debt, nil)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockAccountRepo)
+			mockAnomalyUsecase := mock_anomaly.NewMockUsecase(ctrl)
+			if tc.overdraft != nil {
+				mockAnomalyUsecase.EXPECT().RecordOverdraft(gomock.Any(), userID, tc.overdraft).Return(nil)
+			}
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo, mockAnomalyUsecase)
+
+			actual, err := mockUsecase.Repay(context.Background(), userID, uuid.New(),
+				&models.DebtRepayment{AccountID: accountID, Amount: tc.amount, Date: tc.date})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUsecase_GetSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+	mockRepo.EXPECT().GetOpenDebts(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	_, err := mockUsecase.GetSummary(context.Background(), uuid.New(), time.Now())
+	assert.EqualError(t, err, "[usecase] can't get open debts from repository some error")
+}
+
+func TestBuildSummary(t *testing.T) {
+	now := time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC)
+	past := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Per counterparty", func(t *testing.T) {
+		summary := buildSummary([]models.Debt{
+			testDebt("Аня", models.DebtLent, 3000, &future),
+			testDebt("Аня", models.DebtBorrowed, 1000, &past),
+			testDebt("Боря", models.DebtLent, 500, &past),
+			testDebt("Вова", models.DebtBorrowed, 7000.5, nil),
+			testDebt("Боря", models.DebtLent, 200, &today),
+		}, now)
+
+		assert.Equal(t, 3700.0, summary.Lent)
+		assert.Equal(t, 8000.5, summary.Borrowed)
+		assert.Equal(t, -4300.5, summary.Net)
+		assert.Equal(t, 500.0, summary.OverdueLent)
+		assert.Equal(t, 1000.0, summary.OverdueBorrowed)
+
+		assert.Equal(t, []models.DebtCounterparty{
+			{Counterparty: "Вова", Borrowed: 7000.5, Net: -7000.5},
+			{Counterparty: "Аня", Lent: 3000, Borrowed: 1000, Net: 2000, Overdue: -1000, NextDue: &past},
+			{Counterparty: "Боря", Lent: 700, Net: 700, Overdue: 500, NextDue: &past},
+		}, summary.Counterparties)
+	})
+
+	t.Run("No debts", func(t *testing.T) {
+		summary := buildSummary(nil, now)
+
+		assert.Equal(t, 0.0, summary.Net)
+		assert.Empty(t, summary.Counterparties)
+	})
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	depositService deposit.Usecase
+	logger         logger.Logger
+}
+
+func NewHandler(du deposit.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		depositService: du,
+		logger:         l,
+	}
+}
+
+// @Summary		Create deposit
+// @Tags		Deposit
+// @Description	Open a deposit on an accumulation account, the interest is posted to the account as income
+// @Accept 		json
+// @Produce		json
+// @Param		deposit	body		CreateDeposit						true	"Deposit terms"
+// @Success		200		{object}	Response[DepositCreateResponse]		"Deposit created"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/deposit/create [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var depositInput CreateDeposit
+	if err := easyjson.UnmarshalFromReader(r.Body, &depositInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := depositInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	depositID, err := h.depositService.CreateDeposit(r.Context(), user.ID, depositInput.ToDeposit())
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	var errNotAccumulation *models.NotAccumulationAccountError
+	if errors.As(err, &errNotAccumulation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, DepositNotAccumulation, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DepositCreateServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, DepositCreateResponse{DepositID: depositID})
+}
+
+// @Summary		Get deposits
+// @Tags		Deposit
+// @Description	Deposits of the user, the open ones first
+// @Produce		json
+// @Success		200		{object}	Response[[]models.Deposit]	"Deposits"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/deposit/all [get]
+func (h *Handler) GetDeposits(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	deposits, err := h.depositService.GetDeposits(r.Context(), user.ID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DepositGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, deposits)
+}
+
+// @Summary		Get deposit projection
+// @Tags		Deposit
+// @Description	Interest of every capitalization period up to the end of the term
+// @Produce		json
+// @Param		deposit_id	path		string	true	"Deposit ID"
+// @Success		200		{object}	Response[models.DepositProjection]	"Deposit projection"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/deposit/{deposit_id}/projection [get]
+func (h *Handler) GetProjection(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	depositID, err := commonHttp.GetIDFromRequest(depositID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	projection, err := h.depositService.GetProjection(r.Context(), user.ID, depositID)
+
+	var errNoSuchDeposit *models.NoSuchDepositError
+	if errors.As(err, &errNoSuchDeposit) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, DepositNotSuch, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DepositProjectionServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, projection)
+}
+
+// @Summary		Withdraw deposit
+// @Tags		Deposit
+// @Description	Close the deposit before the end of the term, the interest is recounted with the early rate
+// @Produce		json
+// @Param		deposit_id	path		string	true	"Deposit ID"
+// @Success		200		{object}	Response[models.DepositWithdrawal]	"Deposit closed"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  						"Forbidden user"
+// @Failure		409		{object}	ResponseError						"Deposit changed meanwhile"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/deposit/{deposit_id}/withdraw [post]
+func (h *Handler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	depositID, err := commonHttp.GetIDFromRequest(depositID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	withdrawal, err := h.depositService.Withdraw(r.Context(), user.ID, depositID)
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	var errNoSuchDeposit *models.NoSuchDepositError
+	if errors.As(err, &errNoSuchDeposit) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, DepositNotSuch, h.logger)
+		return
+	}
+
+	var errDepositChanged *models.DepositChangedError
+	if errors.As(err, &errDepositChanged) {
+		commonHttp.ErrorResponse(w, http.StatusConflict, err, DepositChanged, h.logger)
+		return
+	}
+
+	var errOverdraft *models.OverdraftError
+	if errors.As(err, &errOverdraft) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errOverdraft.Error(), h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, DepositWithdrawServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, withdrawal)
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"errors"
+	"html"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/usecase"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	depositID = "deposit_id"
+
+	DepositCreateServerError     = "can't create deposit"
+	DepositGetServerError        = "can't get deposits"
+	DepositProjectionServerError = "can't get deposit projection"
+	DepositWithdrawServerError   = "can't withdraw deposit"
+	DepositNotSuch               = "no such deposit"
+	DepositChanged               = "deposit was changed, try again"
+	DepositNotAccumulation       = "deposit needs an accumulation account"
+
+	DepositBankMaxLen = 30
+	DepositMaxRate    = 100
+)
+
+var (
+	errInvalidAccount        = errors.New("account is required")
+	errInvalidTotal          = errors.New("total must be positive")
+	errInvalidRate           = errors.New("invalid interest rate")
+	errInvalidCapitalization = errors.New("invalid capitalization")
+	errInvalidTerm           = errors.New("deposit must end after it starts")
+	errInvalidBank           = errors.New("bank name is too long")
+)
+
+type DepositCreateResponse struct {
+	DepositID uuid.UUID `json:"deposit_id"`
+}
+
+//easyjson:json
+type CreateDeposit struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	Bank           string    `json:"bank"`
+	Total          float64   `json:"total"`
+	InterestRate   float64   `json:"interest_rate"`
+	EarlyRate      float64   `json:"early_rate"`
+	Capitalization string    `json:"capitalization"`
+	DateStart      time.Time `json:"date_start"`
+	DateEnd        time.Time `json:"date_end"`
+}
+
+// CheckValid fills the defaults: no capitalization and a deposit starting today
+func (cd *CreateDeposit) CheckValid() error {
+	cd.Bank = html.EscapeString(cd.Bank)
+
+	if cd.Capitalization == "" {
+		cd.Capitalization = models.CapitalizationNone
+	}
+	if cd.DateStart.IsZero() {
+		cd.DateStart = time.Now()
+	}
+
+	switch {
+	case cd.AccountID == uuid.Nil:
+		return errInvalidAccount
+	case cd.Total <= 0:
+		return errInvalidTotal
+	case cd.InterestRate <= 0 || cd.InterestRate > DepositMaxRate:
+		return errInvalidRate
+	case cd.EarlyRate < 0 || cd.EarlyRate > cd.InterestRate:
+		return errInvalidRate
+	case !usecase.IsCapitalization(cd.Capitalization):
+		return errInvalidCapitalization
+	case !cd.DateEnd.After(cd.DateStart):
+		return errInvalidTerm
+	case utf8.RuneCountInString(cd.Bank) > DepositBankMaxLen:
+		return errInvalidBank
+	}
+
+	return nil
+}
+
+func (cd *CreateDeposit) ToDeposit() *models.Deposit {
+	return &models.Deposit{
+		AccountID:      cd.AccountID,
+		Bank:           cd.Bank,
+		Total:          cd.Total,
+		InterestRate:   cd.InterestRate,
+		EarlyRate:      cd.EarlyRate,
+		Capitalization: cd.Capitalization,
+		DateStart:      cd.DateStart,
+		DateEnd:        cd.DateEnd,
+	}
+}
@@ -0,0 +1,140 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDepositDeliveryHttp(in *jlexer.Lexer, out *CreateDeposit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		case "bank":
+			out.Bank = string(in.String())
+		case "total":
+			out.Total = float64(in.Float64())
+		case "interest_rate":
+			out.InterestRate = float64(in.Float64())
+		case "early_rate":
+			out.EarlyRate = float64(in.Float64())
+		case "capitalization":
+			out.Capitalization = string(in.String())
+		case "date_start":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DateStart).UnmarshalJSON(data))
+			}
+		case "date_end":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DateEnd).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDepositDeliveryHttp(out *jwriter.Writer, in CreateDeposit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"bank\":"
+		out.RawString(prefix)
+		out.String(string(in.Bank))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Total))
+	}
+	{
+		const prefix string = ",\"interest_rate\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.InterestRate))
+	}
+	{
+		const prefix string = ",\"early_rate\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.EarlyRate))
+	}
+	{
+		const prefix string = ",\"capitalization\":"
+		out.RawString(prefix)
+		out.String(string(in.Capitalization))
+	}
+	{
+		const prefix string = ",\"date_start\":"
+		out.RawString(prefix)
+		out.Raw((in.DateStart).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"date_end\":"
+		out.RawString(prefix)
+		out.Raw((in.DateEnd).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateDeposit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDepositDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateDeposit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDepositDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateDeposit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDepositDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateDeposit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesDepositDeliveryHttp(l, v)
+}
@@ -0,0 +1,429 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Create(t *testing.T) {
+	uuidTest := uuid.New()
+	accountID := uuid.New()
+	depositID := uuid.MustParse("0b7c1e4a-2f3d-4a5b-8c6d-7e8f9a0b1c2d")
+	user := &models.User{ID: uuidTest}
+	validBody := fmt.Sprintf(`{"account_id":"%s","bank":"Сбербанк","total":100000,"interest_rate":12,"capitalization":"monthly","date_start":"2023-01-15T00:00:00Z","date_end":"2024-01-15T00:00:00Z"}`, accountID)
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Create",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"deposit_id":"0b7c1e4a-2f3d-4a5b-8c6d-7e8f9a0b1c2d"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateDeposit(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, deposit *models.Deposit) (uuid.UUID, error) {
+						assert.Equal(t, accountID, deposit.AccountID)
+						assert.Equal(t, models.CapitalizationMonthly, deposit.Capitalization)
+						return depositID, nil
+					})
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid body",
+			user:         user,
+			body:         `{"account_id":`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Ends before start",
+			user:         user,
+			body:         fmt.Sprintf(`{"account_id":"%s","total":100000,"interest_rate":12,"date_start":"2024-01-15T00:00:00Z","date_end":"2023-01-15T00:00:00Z"}`, accountID),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Unknown capitalization",
+			user:         user,
+			body:         fmt.Sprintf(`{"account_id":"%s","total":100000,"interest_rate":12,"capitalization":"daily","date_end":"2100-01-15T00:00:00Z"}`, accountID),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Not accumulation account",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"deposit needs an accumulation account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateDeposit(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[usecase] %w", &models.NotAccumulationAccountError{AccountID: accountID}))
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateDeposit(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[usecase] %w", &models.ForbiddenUserError{}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't create deposit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateDeposit(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/deposit/create", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Create(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetDeposits(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetDeposits",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetDeposits(gomock.Any(), uuidTest).Return([]models.Deposit{}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get deposits"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetDeposits(gomock.Any(), uuidTest).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/deposit/all", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetDeposits(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetProjection(t *testing.T) {
+	uuidTest := uuid.New()
+	depositID := uuid.MustParse("0b7c1e4a-2f3d-4a5b-8c6d-7e8f9a0b1c2d")
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		depositID     string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetProjection",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"deposit_id":"0b7c1e4a-2f3d-4a5b-8c6d-7e8f9a0b1c2d","total":100000,"interest":12000,"final_balance":112000,"payouts":[]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetProjection(gomock.Any(), uuidTest, depositID).Return(&models.DepositProjection{
+					DepositID:    depositID,
+					Total:        100000,
+					Interest:     12000,
+					FinalBalance: 112000,
+					Payouts:      []models.DepositPayout{},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid deposit id",
+			user:         user,
+			depositID:    "invalid",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No such deposit",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such deposit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetProjection(gomock.Any(), uuidTest, depositID).
+					Return(nil, fmt.Errorf("[usecase] %w", &models.NoSuchDepositError{DepositID: depositID}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get deposit projection"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetProjection(gomock.Any(), uuidTest, depositID).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/deposit/"+tt.depositID+"/projection", nil)
+			req = mux.SetURLVars(req, map[string]string{"deposit_id": tt.depositID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetProjection(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Withdraw(t *testing.T) {
+	uuidTest := uuid.New()
+	depositID := uuid.New()
+	accountID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		depositID     string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Withdraw",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"interest":273.97,"adjustment":-1726.03}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Withdraw(gomock.Any(), uuidTest, depositID).
+					Return(&models.DepositWithdrawal{Interest: 273.97, Adjustment: -1726.03}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Closed deposit",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such deposit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Withdraw(gomock.Any(), uuidTest, depositID).
+					Return(nil, fmt.Errorf("[usecase] deposit is closed %w", &models.NoSuchDepositError{DepositID: depositID}))
+			},
+		},
+		{
+			name:         "Viewer of the account",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Withdraw(gomock.Any(), uuidTest, depositID).
+					Return(nil, fmt.Errorf("[usecase] viewer can't manage deposits of the account %w", &models.ForbiddenUserError{}))
+			},
+		},
+		{
+			name:         "Deposit accrued meanwhile",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"status":409,"message":"deposit was changed, try again"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Withdraw(gomock.Any(), uuidTest, depositID).
+					Return(nil, fmt.Errorf("[usecase] can't close deposit [repo] %w", &models.DepositChangedError{DepositID: depositID}))
+			},
+		},
+		{
+			name:         "Overdraft rejected",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"not enough funds on account ` + accountID.String() + `: 500.00 available"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Withdraw(gomock.Any(), uuidTest, depositID).
+					Return(nil, fmt.Errorf("[usecase] can't withdraw deposit [repo] %w", &models.OverdraftError{AccountID: accountID, Available: 500}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			depositID:    depositID.String(),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't withdraw deposit"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Withdraw(gomock.Any(), uuidTest, depositID).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/deposit/"+tt.depositID+"/withdraw", nil)
+			req = mux.SetURLVars(req, map[string]string{"deposit_id": tt.depositID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Withdraw(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package deposit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	CreateDeposit(ctx context.Context, userID uuid.UUID, deposit *models.Deposit) (uuid.UUID, error)
+	GetDeposits(ctx context.Context, userID uuid.UUID) ([]models.Deposit, error)
+	GetProjection(ctx context.Context, userID uuid.UUID, depositID uuid.UUID) (*models.DepositProjection, error)
+	Withdraw(ctx context.Context, userID uuid.UUID, depositID uuid.UUID) (*models.DepositWithdrawal, error)
+
+	AccrueInterest(ctx context.Context) error
+}
+
+type Repository interface {
+	CreateDeposit(ctx context.Context, deposit *models.Deposit) (uuid.UUID, error)
+	GetDeposits(ctx context.Context, userID uuid.UUID) ([]models.Deposit, error)
+	GetDeposit(ctx context.Context, userID uuid.UUID, depositID uuid.UUID) (*models.Deposit, error)
+	IsAccumulationAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (bool, error)
+
+	GetOpenDeposits(ctx context.Context) ([]models.Deposit, error)
+	PostInterest(ctx context.Context, deposit *models.Deposit, amount float64, date time.Time, closed bool) (*models.Overdraft, error)
+}
@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deposit.go
+
+// Package mock_deposit is a generated GoMock package.
+package mock_deposit
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AccrueInterest mocks base method.
+func (m *MockUsecase) AccrueInterest(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueInterest", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AccrueInterest indicates an expected call of AccrueInterest.
+func (mr *MockUsecaseMockRecorder) AccrueInterest(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueInterest", reflect.TypeOf((*MockUsecase)(nil).AccrueInterest), ctx)
+}
+
+// CreateDeposit mocks base method.
+func (m *MockUsecase) CreateDeposit(ctx context.Context, userID uuid.UUID, deposit *models.Deposit) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeposit", ctx, userID, deposit)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeposit indicates an expected call of CreateDeposit.
+func (mr *MockUsecaseMockRecorder) CreateDeposit(ctx, userID, deposit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeposit", reflect.TypeOf((*MockUsecase)(nil).CreateDeposit), ctx, userID, deposit)
+}
+
+// GetDeposits mocks base method.
+func (m *MockUsecase) GetDeposits(ctx context.Context, userID uuid.UUID) ([]models.Deposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeposits", ctx, userID)
+	ret0, _ := ret[0].([]models.Deposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeposits indicates an expected call of GetDeposits.
+func (mr *MockUsecaseMockRecorder) GetDeposits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeposits", reflect.TypeOf((*MockUsecase)(nil).GetDeposits), ctx, userID)
+}
+
+// GetProjection mocks base method.
+func (m *MockUsecase) GetProjection(ctx context.Context, userID, depositID uuid.UUID) (*models.DepositProjection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjection", ctx, userID, depositID)
+	ret0, _ := ret[0].(*models.DepositProjection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjection indicates an expected call of GetProjection.
+func (mr *MockUsecaseMockRecorder) GetProjection(ctx, userID, depositID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjection", reflect.TypeOf((*MockUsecase)(nil).GetProjection), ctx, userID, depositID)
+}
+
+// Withdraw mocks base method.
+func (m *MockUsecase) Withdraw(ctx context.Context, userID, depositID uuid.UUID) (*models.DepositWithdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, userID, depositID)
+	ret0, _ := ret[0].(*models.DepositWithdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockUsecaseMockRecorder) Withdraw(ctx, userID, depositID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockUsecase)(nil).Withdraw), ctx, userID, depositID)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDeposit mocks base method.
+func (m *MockRepository) CreateDeposit(ctx context.Context, deposit *models.Deposit) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeposit", ctx, deposit)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeposit indicates an expected call of CreateDeposit.
+func (mr *MockRepositoryMockRecorder) CreateDeposit(ctx, deposit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeposit", reflect.TypeOf((*MockRepository)(nil).CreateDeposit), ctx, deposit)
+}
+
+// GetDeposit mocks base method.
+func (m *MockRepository) GetDeposit(ctx context.Context, userID, depositID uuid.UUID) (*models.Deposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeposit", ctx, userID, depositID)
+	ret0, _ := ret[0].(*models.Deposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeposit indicates an expected call of GetDeposit.
+func (mr *MockRepositoryMockRecorder) GetDeposit(ctx, userID, depositID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeposit", reflect.TypeOf((*MockRepository)(nil).GetDeposit), ctx, userID, depositID)
+}
+
+// GetDeposits mocks base method.
+func (m *MockRepository) GetDeposits(ctx context.Context, userID uuid.UUID) ([]models.Deposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeposits", ctx, userID)
+	ret0, _ := ret[0].([]models.Deposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeposits indicates an expected call of GetDeposits.
+func (mr *MockRepositoryMockRecorder) GetDeposits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeposits", reflect.TypeOf((*MockRepository)(nil).GetDeposits), ctx, userID)
+}
+
+// GetOpenDeposits mocks base method.
+func (m *MockRepository) GetOpenDeposits(ctx context.Context) ([]models.Deposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenDeposits", ctx)
+	ret0, _ := ret[0].([]models.Deposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenDeposits indicates an expected call of GetOpenDeposits.
+func (mr *MockRepositoryMockRecorder) GetOpenDeposits(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenDeposits", reflect.TypeOf((*MockRepository)(nil).GetOpenDeposits), ctx)
+}
+
+// IsAccumulationAccount mocks base method.
+func (m *MockRepository) IsAccumulationAccount(ctx context.Context, userID, accountID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccumulationAccount", ctx, userID, accountID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccumulationAccount indicates an expected call of IsAccumulationAccount.
+func (mr *MockRepositoryMockRecorder) IsAccumulationAccount(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccumulationAccount", reflect.TypeOf((*MockRepository)(nil).IsAccumulationAccount), ctx, userID, accountID)
+}
+
+// PostInterest mocks base method.
+func (m *MockRepository) PostInterest(ctx context.Context, deposit *models.Deposit, amount float64, date time.Time, closed bool) (*models.Overdraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInterest", ctx, deposit, amount, date, closed)
+	ret0, _ := ret[0].(*models.Overdraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInterest indicates an expected call of PostInterest.
+func (mr *MockRepositoryMockRecorder) PostInterest(ctx, deposit, amount, date, closed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInterest", reflect.TypeOf((*MockRepository)(nil).PostInterest), ctx, deposit, amount, date, closed)
+}
@@ -0,0 +1,248 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	DepositCreate = `INSERT INTO Deposit (user_id, account_id, bank, total, interest_rate, early_rate, capitalization, date_start, date_end, accrued_until)
+					 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $8)
+					 RETURNING id;`
+
+	depositColumns = `SELECT id, user_id, account_id, bank, total, interest_rate, early_rate, capitalization,
+						date_start, date_end, accrued, accrued_until, closed_at
+					  FROM Deposit `
+
+	DepositGetAll  = depositColumns + "WHERE user_id = $1 ORDER BY closed_at DESC NULLS FIRST, date_end;"
+	DepositGet     = depositColumns + "WHERE id = $1 AND user_id = $2;"
+	DepositGetOpen = depositColumns + "WHERE closed_at IS NULL;"
+
+	DepositAccountAccumulation = `SELECT COALESCE(a.accumulation, false)
+								  FROM Accounts a
+								  JOIN UserAccount ua ON ua.account_id = a.id
+								  WHERE ua.user_id = $1 AND a.id = $2;`
+
+	DepositInterestCreate = `INSERT INTO Transaction (user_id, account_income, account_outcome, income, outcome, date, payer, description)
+							 VALUES ($1, $2, $2, $3, $4, $5, $6, $7)
+							 RETURNING id;`
+
+	// interest has no category, only the total of the day is counted
+	DepositDailyTotals = `INSERT INTO DailyTotals (user_id, account_id, category_id, day, income, outcome)
+						  VALUES ($1, $2, '00000000-0000-0000-0000-000000000000', $3::date, $4, $5)
+						  ON CONFLICT (user_id, account_id, category_id, day) DO UPDATE
+						  SET income = DailyTotals.income + EXCLUDED.income,
+							  outcome = DailyTotals.outcome + EXCLUDED.outcome;`
+
+	// applies only to the state the amount was counted from, the row stays locked until the interest is posted
+	DepositAccrue = `UPDATE Deposit
+					 SET accrued = accrued + $2, accrued_until = $3, closed_at = CASE WHEN $4 THEN $3::date END
+					 WHERE id = $1 AND closed_at IS NULL AND accrued_until = $5;`
+
+	// the payer column is shorter than the bank name
+	depositPayerMaxLen = 20
+	depositDescription = "Проценты по вкладу"
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) CreateDeposit(ctx context.Context, deposit *models.Deposit) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	err := r.db.QueryRow(ctx, DepositCreate,
+		deposit.UserID,
+		deposit.AccountID,
+		deposit.Bank,
+		deposit.Total,
+		deposit.InterestRate,
+		deposit.EarlyRate,
+		deposit.Capitalization,
+		deposit.DateStart,
+		deposit.DateEnd,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create deposit: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetDeposits(ctx context.Context, userID uuid.UUID) ([]models.Deposit, error) {
+	rows, err := r.db.Query(ctx, DepositGetAll, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	return scanDeposits(rows)
+}
+
+func (r *Repository) GetOpenDeposits(ctx context.Context) ([]models.Deposit, error) {
+	rows, err := r.db.Query(ctx, DepositGetOpen)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	return scanDeposits(rows)
+}
+
+func (r *Repository) GetDeposit(ctx context.Context, userID uuid.UUID, depositID uuid.UUID) (*models.Deposit, error) {
+	deposit, err := scanDeposit(r.db.QueryRow(ctx, DepositGet, depositID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchDepositError{DepositID: depositID})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &deposit, nil
+}
+
+// IsAccumulationAccount returns ForbiddenUserError if the user is not a member of the account
+func (r *Repository) IsAccumulationAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (bool, error) {
+	var accumulation bool
+
+	err := r.db.QueryRow(ctx, DepositAccountAccumulation, userID, accountID).Scan(&accumulation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("[repo] account of another user: %w", &models.ForbiddenUserError{})
+	}
+	if err != nil {
+		return false, fmt.Errorf("[repo] %w", err)
+	}
+
+	return accumulation, nil
+}
+
+// PostInterest records the interest as a transaction on the deposit account, a negative amount
+// takes back the interest accrued above the early rate by the overdraft policy of the account and
+// returns the overdraft the warn policy let through; closed marks the deposit as closed on date.
+// DepositChangedError is returned when the deposit was accrued or closed after it was read
+func (r *Repository) PostInterest(ctx context.Context, deposit *models.Deposit, amount float64, date time.Time, closed bool) (*models.Overdraft, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, DepositAccrue, deposit.ID, amount, date, closed, deposit.AccruedUntil)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to update deposit: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = &models.DepositChangedError{DepositID: deposit.ID}
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	var overdraft *models.Overdraft
+	if amount != 0 {
+		if overdraft, err = r.insertInterest(ctx, tx, deposit, amount, date); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	deposit.Accrued += amount
+	deposit.AccruedUntil = date
+	return overdraft, nil
+}
+
+func (r *Repository) insertInterest(ctx context.Context, tx pgx.Tx, deposit *models.Deposit, amount float64, date time.Time) (*models.Overdraft, error) {
+	var income, outcome float64
+	if amount > 0 {
+		income = amount
+	} else {
+		outcome = -amount
+	}
+
+	payer := []rune(deposit.Bank)
+	if len(payer) > depositPayerMaxLen {
+		payer = payer[:depositPayerMaxLen]
+	}
+
+	var transactionID uuid.UUID
+	if err := tx.QueryRow(ctx, DepositInterestCreate, deposit.UserID, deposit.AccountID, income, outcome,
+		date, string(payer), depositDescription).Scan(&transactionID); err != nil {
+		return nil, fmt.Errorf("[repo] failed to create interest transaction: %w", err)
+	}
+
+	overdraft, err := accountRep.ChargeAccount(ctx, tx, deposit.AccountID, -amount)
+	if err != nil {
+		return nil, err
+	}
+	if overdraft != nil {
+		overdraft.TransactionID = transactionID
+	}
+
+	if _, err := tx.Exec(ctx, DepositDailyTotals, deposit.UserID, deposit.AccountID, date, income, outcome); err != nil {
+		return nil, fmt.Errorf("[repo] failed to update daily totals: %w", err)
+	}
+
+	return overdraft, nil
+}
+
+func scanDeposits(rows pgx.Rows) ([]models.Deposit, error) {
+	deposits := []models.Deposit{}
+
+	for rows.Next() {
+		deposit, err := scanDeposit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return deposits, nil
+}
+
+func scanDeposit(row pgx.Row) (models.Deposit, error) {
+	var deposit models.Deposit
+	err := row.Scan(
+		&deposit.ID,
+		&deposit.UserID,
+		&deposit.AccountID,
+		&deposit.Bank,
+		&deposit.Total,
+		&deposit.InterestRate,
+		&deposit.EarlyRate,
+		&deposit.Capitalization,
+		&deposit.DateStart,
+		&deposit.DateEnd,
+		&deposit.Accrued,
+		&deposit.AccruedUntil,
+		&deposit.ClosedAt,
+	)
+	return deposit, err
+}
@@ -0,0 +1,420 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var depositRowColumns = []string{"id", "user_id", "account_id", "bank", "total", "interest_rate", "early_rate", "capitalization",
+	"date_start", "date_end", "accrued", "accrued_until", "closed_at"}
+
+func testDeposit() models.Deposit {
+	start := time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC)
+	return models.Deposit{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		AccountID:      uuid.New(),
+		Bank:           "Сбербанк",
+		Total:          100000,
+		InterestRate:   12,
+		EarlyRate:      1,
+		Capitalization: models.CapitalizationMonthly,
+		DateStart:      start,
+		DateEnd:        start.AddDate(1, 0, 0),
+		AccruedUntil:   start,
+	}
+}
+
+func addDepositRow(rows *pgxmock.Rows, d models.Deposit) *pgxmock.Rows {
+	return rows.AddRow(d.ID, d.UserID, d.AccountID, d.Bank, d.Total, d.InterestRate, d.EarlyRate, d.Capitalization,
+		d.DateStart, d.DateEnd, d.Accrued, d.AccruedUntil, d.ClosedAt)
+}
+
+func Test_CreateDeposit(t *testing.T) {
+	deposit := testDeposit()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        pgxmock.NewRows([]string{"id"}).AddRow(deposit.ID),
+			expected:    deposit.ID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create deposit: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(DepositCreate)).
+				WithArgs(deposit.UserID, deposit.AccountID, deposit.Bank, deposit.Total, deposit.InterestRate,
+					deposit.EarlyRate, deposit.Capitalization, deposit.DateStart, deposit.DateEnd).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			id, err := repo.CreateDeposit(context.Background(), &deposit)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("Expected deposit %s, but got: %s", tc.expected, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetDeposits(t *testing.T) {
+	deposit := testDeposit()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        addDepositRow(pgxmock.NewRows(depositRowColumns), deposit),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(depositRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(DepositGetAll)).
+				WithArgs(deposit.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			deposits, err := repo.GetDeposits(context.Background(), deposit.UserID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(deposits) != tc.expectedLen {
+				t.Errorf("Expected %d deposits, but got: %d", tc.expectedLen, len(deposits))
+			}
+			if tc.expectedLen > 0 {
+				assert.Equal(t, deposit, deposits[0])
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetOpenDeposits(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+
+	logger := *logger.NewLogger(context.TODO())
+	repo := NewRepository(mock, logger)
+
+	rows := addDepositRow(addDepositRow(pgxmock.NewRows(depositRowColumns), testDeposit()), testDeposit())
+	mock.ExpectQuery(regexp.QuoteMeta(DepositGetOpen)).WillReturnRows(rows)
+
+	deposits, err := repo.GetOpenDeposits(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(deposits) != 2 {
+		t.Errorf("Expected 2 deposits, but got: %d", len(deposits))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_GetDeposit(t *testing.T) {
+	deposit := testDeposit()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        addDepositRow(pgxmock.NewRows(depositRowColumns), deposit),
+			expectedErr: nil,
+		},
+		{
+			name:        "No such deposit",
+			rows:        pgxmock.NewRows(depositRowColumns),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchDepositError{DepositID: deposit.ID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(depositRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(DepositGet)).
+				WithArgs(deposit.ID, deposit.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			actual, err := repo.GetDeposit(context.Background(), deposit.UserID, deposit.ID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Equal(t, &deposit, actual)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_IsAccumulationAccount(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name:        "Accumulation",
+			rows:        pgxmock.NewRows([]string{"accumulation"}).AddRow(true),
+			expected:    true,
+			expectedErr: nil,
+		},
+		{
+			name:        "Account of another user",
+			rows:        pgxmock.NewRows([]string{"accumulation"}),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] account of another user: %w", &models.ForbiddenUserError{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(DepositAccountAccumulation)).
+				WithArgs(userID, accountID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			accumulation, err := repo.IsAccumulationAccount(context.Background(), userID, accountID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, accumulation)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_PostInterest(t *testing.T) {
+	date := time.Date(2023, time.February, 15, 0, 0, 0, 0, time.UTC)
+	transactionID := uuid.New()
+	chargeColumns := []string{"id", "balance", "kind", "credit_limit", "overdraft_policy"}
+
+	testCases := []struct {
+		name              string
+		amount            float64
+		closed            bool
+		policy            models.OverdraftPolicy
+		changed           bool
+		execError         error
+		expectedOverdraft *models.Overdraft
+		expectedErr       error
+	}{
+		{
+			name:        "Interest",
+			amount:      1019.18,
+			policy:      models.OverdraftReject,
+			expectedErr: nil,
+		},
+		{
+			name:        "Early withdrawal takes interest back",
+			amount:      -500,
+			closed:      true,
+			policy:      models.OverdraftAllow,
+			expectedErr: nil,
+		},
+		{
+			name:   "Interest taken back over the balance",
+			amount: -500,
+			closed: true,
+			policy: models.OverdraftWarn,
+			expectedOverdraft: &models.Overdraft{
+				TransactionID: transactionID, Amount: 500, Available: 100,
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "Nothing to post",
+			amount:      0,
+			closed:      true,
+			expectedErr: nil,
+		},
+		{
+			name:    "Accrued or closed meanwhile",
+			amount:  1019.18,
+			changed: true,
+		},
+		{
+			name:        "Error",
+			amount:      1019.18,
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to create interest transaction: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			deposit := testDeposit()
+			deposit.Bank = "Газпромбанк (Акционерное общество)"
+			accruedUntil := deposit.AccruedUntil
+
+			income, outcome := tc.amount, 0.0
+			if tc.amount < 0 {
+				income, outcome = 0, -tc.amount
+			}
+
+			mock.ExpectBegin()
+			accrue := mock.ExpectExec(regexp.QuoteMeta(DepositAccrue)).
+				WithArgs(deposit.ID, tc.amount, date, tc.closed, accruedUntil)
+			if tc.changed {
+				accrue.WillReturnResult(pgconn.CommandTag("UPDATE 0"))
+				mock.ExpectRollback()
+			} else {
+				accrue.WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+			}
+
+			if tc.amount != 0 && !tc.changed {
+				interest := mock.ExpectQuery(regexp.QuoteMeta(DepositInterestCreate)).
+					WithArgs(deposit.UserID, deposit.AccountID, income, outcome, date, "Газпромбанк (Акционе", depositDescription)
+				if tc.execError != nil {
+					interest.WillReturnError(tc.execError)
+					mock.ExpectRollback()
+				} else {
+					interest.WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(transactionID))
+					mock.ExpectQuery(regexp.QuoteMeta(accountRep.AccountChargeLock)).
+						WithArgs(deposit.AccountID).
+						WillReturnRows(pgxmock.NewRows(chargeColumns).
+							AddRow(deposit.AccountID, 100.0, models.AccountDebit, 0.0, tc.policy))
+					mock.ExpectExec(regexp.QuoteMeta(accountRep.AccountCharge)).
+						WithArgs(-tc.amount, deposit.AccountID).
+						WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+					mock.ExpectExec(regexp.QuoteMeta(DepositDailyTotals)).
+						WithArgs(deposit.UserID, deposit.AccountID, date, income, outcome).
+						WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				}
+			}
+			if tc.execError == nil && !tc.changed {
+				mock.ExpectCommit()
+			}
+
+			overdraft, err := repo.PostInterest(context.Background(), &deposit, tc.amount, date, tc.closed)
+
+			expectedErr := tc.expectedErr
+			if tc.changed {
+				expectedErr = fmt.Errorf("[repo] %w", &models.DepositChangedError{DepositID: deposit.ID})
+			}
+			if (expectedErr == nil && err != nil) || (expectedErr != nil && err == nil) || (expectedErr != nil && err != nil && expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", expectedErr, err)
+			}
+
+			if tc.expectedOverdraft != nil {
+				tc.expectedOverdraft.AccountID = deposit.AccountID
+			}
+			assert.Equal(t, tc.expectedOverdraft, overdraft)
+
+			// the next period of the same run is checked against the posted one
+			if expectedErr == nil {
+				assert.Equal(t, date, deposit.AccruedUntil)
+			} else {
+				assert.Equal(t, accruedUntil, deposit.AccruedUntil)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
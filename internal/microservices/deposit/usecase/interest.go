@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+const daysInYear = 365
+
+var capitalizationMonths = map[string]int{
+	models.CapitalizationNone:      0,
+	models.CapitalizationMonthly:   1,
+	models.CapitalizationQuarterly: 3,
+	models.CapitalizationYearly:    12,
+}
+
+// IsCapitalization checks the capitalization schedule name
+func IsCapitalization(capitalization string) bool {
+	_, ok := capitalizationMonths[capitalization]
+	return ok
+}
+
+// payoutDates are the ends of the capitalization periods, the last one is the end of the term
+func payoutDates(deposit *models.Deposit) []time.Time {
+	start, end := dates.TruncateDay(deposit.DateStart), dates.TruncateDay(deposit.DateEnd)
+
+	var payouts []time.Time
+	if months := capitalizationMonths[deposit.Capitalization]; months > 0 {
+		for period := 1; ; period++ {
+			date := dates.AddMonths(start, period*months)
+			if !date.Before(end) {
+				break
+			}
+			payouts = append(payouts, date)
+		}
+	}
+
+	return append(payouts, end)
+}
+
+// buildSchedule counts the interest of every period, the capitalized interest earns interest
+// in the next periods; periods up to accrued_until are marked as posted
+func buildSchedule(deposit *models.Deposit) []models.DepositPayout {
+	payouts := payoutDates(deposit)
+	compound := deposit.Capitalization != models.CapitalizationNone
+
+	schedule := make([]models.DepositPayout, 0, len(payouts))
+	balance := deposit.Total
+	from := dates.TruncateDay(deposit.DateStart)
+
+	for _, date := range payouts {
+		base := deposit.Total
+		if compound {
+			base = balance
+		}
+
+		interest := interestFor(base, deposit.InterestRate, from, date)
+		balance = money.Round2(balance + interest)
+
+		schedule = append(schedule, models.DepositPayout{
+			Date:     date,
+			Interest: interest,
+			Balance:  balance,
+			Posted:   !date.After(dates.TruncateDay(deposit.AccruedUntil)),
+		})
+		from = date
+	}
+
+	return schedule
+}
+
+func buildProjection(deposit *models.Deposit) *models.DepositProjection {
+	projection := &models.DepositProjection{
+		DepositID:    deposit.ID,
+		Total:        deposit.Total,
+		FinalBalance: deposit.Total,
+		Payouts:      buildSchedule(deposit),
+	}
+
+	for _, payout := range projection.Payouts {
+		projection.Interest += payout.Interest
+	}
+	projection.Interest = money.Round2(projection.Interest)
+	if len(projection.Payouts) > 0 {
+		projection.FinalBalance = projection.Payouts[len(projection.Payouts)-1].Balance
+	}
+
+	return projection
+}
+
+// earlyWithdrawal recounts the interest with the early rate as simple interest up to the day of closing
+func earlyWithdrawal(deposit *models.Deposit, date time.Time) *models.DepositWithdrawal {
+	interest := interestFor(deposit.Total, deposit.EarlyRate, dates.TruncateDay(deposit.DateStart), dates.TruncateDay(date))
+	return &models.DepositWithdrawal{
+		Interest:   interest,
+		Adjustment: money.Round2(interest - deposit.Accrued),
+	}
+}
+
+func interestFor(base, rate float64, from, to time.Time) float64 {
+	days := to.Sub(from).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return money.Round2(base * rate / 100 * days / daysInYear)
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	depositRepo    deposit.Repository
+	logger         logger.Logger
+	accountRepo    account.Repository
+	anomalyService anomaly.Usecase
+}
+
+func NewUsecase(dr deposit.Repository, log logger.Logger, ar account.Repository, au anomaly.Usecase) *Usecase {
+	return &Usecase{
+		depositRepo:    dr,
+		logger:         log,
+		accountRepo:    ar,
+		anomalyService: au,
+	}
+}
+
+// CreateDeposit opens a deposit on an accumulation account of the user
+func (u *Usecase) CreateDeposit(ctx context.Context, userID uuid.UUID, deposit *models.Deposit) (uuid.UUID, error) {
+	if err := u.checkEditor(ctx, deposit.AccountID, userID); err != nil {
+		return uuid.Nil, err
+	}
+
+	accumulation, err := u.depositRepo.IsAccumulationAccount(ctx, userID, deposit.AccountID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't check account %w", err)
+	}
+	if !accumulation {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.NotAccumulationAccountError{AccountID: deposit.AccountID})
+	}
+
+	deposit.UserID = userID
+	deposit.DateStart = dates.TruncateDay(deposit.DateStart)
+	deposit.DateEnd = dates.TruncateDay(deposit.DateEnd)
+
+	id, err := u.depositRepo.CreateDeposit(ctx, deposit)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create deposit %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetDeposits(ctx context.Context, userID uuid.UUID) ([]models.Deposit, error) {
+	deposits, err := u.depositRepo.GetDeposits(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get deposits from repository %w", err)
+	}
+	return deposits, nil
+}
+
+func (u *Usecase) GetProjection(ctx context.Context, userID uuid.UUID, depositID uuid.UUID) (*models.DepositProjection, error) {
+	deposit, err := u.depositRepo.GetDeposit(ctx, userID, depositID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get deposit from repository %w", err)
+	}
+	return buildProjection(deposit), nil
+}
+
+// Withdraw closes the deposit before the end of the term
+func (u *Usecase) Withdraw(ctx context.Context, userID uuid.UUID, depositID uuid.UUID) (*models.DepositWithdrawal, error) {
+	deposit, err := u.depositRepo.GetDeposit(ctx, userID, depositID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get deposit from repository %w", err)
+	}
+
+	if err := u.checkEditor(ctx, deposit.AccountID, userID); err != nil {
+		return nil, err
+	}
+
+	today := dates.TruncateDay(time.Now())
+	if deposit.ClosedAt != nil || !today.Before(dates.TruncateDay(deposit.DateEnd)) {
+		return nil, fmt.Errorf("[usecase] deposit is closed %w", &models.NoSuchDepositError{DepositID: depositID})
+	}
+
+	withdrawal := earlyWithdrawal(deposit, today)
+	overdraft, err := u.depositRepo.PostInterest(ctx, deposit, withdrawal.Adjustment, today, true)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't close deposit %w", err)
+	}
+	u.recordOverdraft(ctx, userID, overdraft)
+	return withdrawal, nil
+}
+
+// AccrueInterest posts the interest of the finished periods of every open deposit,
+// a failure for one deposit does not stop the others
+func (u *Usecase) AccrueInterest(ctx context.Context) error {
+	deposits, err := u.depositRepo.GetOpenDeposits(ctx)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get open deposits from repository %w", err)
+	}
+
+	today := dates.TruncateDay(time.Now())
+
+	var failed, posted int
+	for i := range deposits {
+		deposit := &deposits[i]
+		end := dates.TruncateDay(deposit.DateEnd)
+
+		for _, payout := range buildSchedule(deposit) {
+			if payout.Posted || payout.Date.After(today) {
+				continue
+			}
+
+			// interest only adds to the account, there is no overdraft to record
+			if _, err := u.depositRepo.PostInterest(ctx, deposit, payout.Interest, payout.Date, payout.Date.Equal(end)); err != nil {
+				u.logger.Errorf("[usecase] can't post interest of deposit %s: %v", deposit.ID, err)
+				failed++
+				break
+			}
+			posted++
+		}
+	}
+
+	if posted > 0 {
+		u.logger.Infof("[usecase] %d interest payouts posted", posted)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[usecase] interest accrual failed for %d of %d deposits", failed, len(deposits))
+	}
+	return nil
+}
+
+// recordOverdraft raises the anomaly of an overdraft the warn policy let through,
+// the money is already moved so a failure is only logged
+func (u *Usecase) recordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) {
+	if overdraft == nil {
+		return
+	}
+	if err := u.anomalyService.RecordOverdraft(ctx, userID, overdraft); err != nil {
+		u.logger.Errorf("[usecase] can't record overdraft of account %s: %v", overdraft.AccountID, err)
+	}
+}
+
+// checkEditor fails unless the user can change the balance of the deposit account
+func (u *Usecase) checkEditor(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error {
+	role, err := u.accountRepo.GetRole(ctx, accountID, userID)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't check account %w", err)
+	}
+	if !role.CanEdit() {
+		return fmt.Errorf("[usecase] %s can't manage deposits of the account %w", role, &models.ForbiddenUserError{})
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mock_anomaly "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/deposit/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDeposit(capitalization string) *models.Deposit {
+	start := time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC)
+	return &models.Deposit{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		Total:          100000,
+		InterestRate:   12,
+		EarlyRate:      1,
+		Capitalization: capitalization,
+		DateStart:      start,
+		DateEnd:        start.AddDate(1, 0, 0),
+		AccruedUntil:   start,
+	}
+}
+
+func TestUsecase_CreateDeposit(t *testing.T) {
+	userID := uuid.New()
+	depositID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		expected    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_CreateDeposit",
+			expected:    depositID,
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().IsAccumulationAccount(gomock.Any(), userID, gomock.Any()).Return(true, nil)
+				mockRepository.EXPECT().CreateDeposit(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, deposit *models.Deposit) (uuid.UUID, error) {
+						assert.Equal(t, userID, deposit.UserID)
+						return depositID, nil
+					})
+			},
+		},
+		{
+			name:        "Forbidden account in TestUsecase_CreateDeposit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't check account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Viewer in TestUsecase_CreateDeposit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] viewer can't manage deposits of the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:        "Not accumulation account in TestUsecase_CreateDeposit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.NotAccumulationAccountError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().IsAccumulationAccount(gomock.Any(), userID, gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:        "Create Error in TestUsecase_CreateDeposit",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't create deposit some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).Return(models.AccountOwner, nil)
+				mockRepository.EXPECT().IsAccumulationAccount(gomock.Any(), userID, gomock.Any()).Return(true, nil)
+				mockRepository.EXPECT().CreateDeposit(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo, mock_anomaly.NewMockUsecase(ctrl))
+
+			id, err := mockUsecase.CreateDeposit(context.Background(), userID, &models.Deposit{})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestUsecase_GetDeposits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+	mockRepo.EXPECT().GetDeposits(gomock.Any(), gomock.Any()).Return([]models.Deposit{*testDeposit(models.CapitalizationNone)}, nil)
+	deposits, err := mockUsecase.GetDeposits(context.Background(), uuid.New())
+	assert.NoError(t, err)
+	assert.Len(t, deposits, 1)
+
+	mockRepo.EXPECT().GetDeposits(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	_, err = mockUsecase.GetDeposits(context.Background(), uuid.New())
+	assert.EqualError(t, err, "[usecase] can't get deposits from repository some error")
+}
+
+func TestUsecase_GetProjection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+	mockRepo.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(testDeposit(models.CapitalizationNone), nil)
+	projection, err := mockUsecase.GetProjection(context.Background(), uuid.New(), uuid.New())
+	assert.NoError(t, err)
+	assert.Equal(t, 12000.0, projection.Interest)
+
+	mockRepo.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	_, err = mockUsecase.GetProjection(context.Background(), uuid.New(), uuid.New())
+	assert.EqualError(t, err, "[usecase] can't get deposit from repository some error")
+}
+
+func TestUsecase_Withdraw(t *testing.T) {
+	today := dates.TruncateDay(time.Now())
+
+	open := testDeposit(models.CapitalizationMonthly)
+	open.DateStart = today.AddDate(0, 0, -100)
+	open.DateEnd = today.AddDate(0, 0, 265)
+	open.Accrued = 2000
+
+	finished := testDeposit(models.CapitalizationMonthly)
+	overdraft := &models.Overdraft{AccountID: open.AccountID, TransactionID: uuid.New(), Amount: 1726.03, Available: 1000}
+
+	testCases := []struct {
+		name        string
+		overdraft   *models.Overdraft
+		expected    *models.DepositWithdrawal
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_Withdraw",
+			expected:    &models.DepositWithdrawal{Interest: 273.97, Adjustment: -1726.03},
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(open, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), open.AccountID, gomock.Any()).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().PostInterest(gomock.Any(), open, -1726.03, today, true).Return(nil, nil)
+			},
+		},
+		{
+			name:        "Adjustment over the balance in TestUsecase_Withdraw",
+			overdraft:   overdraft,
+			expected:    &models.DepositWithdrawal{Interest: 273.97, Adjustment: -1726.03},
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(open, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), open.AccountID, gomock.Any()).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().PostInterest(gomock.Any(), open, -1726.03, today, true).Return(overdraft, nil)
+			},
+		},
+		{
+			name:        "Finished deposit in TestUsecase_Withdraw",
+			expectedErr: fmt.Errorf("[usecase] deposit is closed %w", &models.NoSuchDepositError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(finished, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), finished.AccountID, gomock.Any()).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:        "Viewer in TestUsecase_Withdraw",
+			expectedErr: fmt.Errorf("[usecase] viewer can't manage deposits of the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(open, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), open.AccountID, gomock.Any()).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:        "Post Error in TestUsecase_Withdraw",
+			expectedErr: fmt.Errorf("[usecase] can't close deposit some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetDeposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(open, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), open.AccountID, gomock.Any()).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().PostInterest(gomock.Any(), open, gomock.Any(), today, true).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockAccountRepo)
+			userID := uuid.New()
+			mockAnomalyUsecase := mock_anomaly.NewMockUsecase(ctrl)
+			if tc.overdraft != nil {
+				mockAnomalyUsecase.EXPECT().RecordOverdraft(gomock.Any(), userID, tc.overdraft).Return(nil)
+			}
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo, mockAnomalyUsecase)
+
+			var depositID uuid.UUID
+			withdrawal, err := mockUsecase.Withdraw(context.Background(), userID, depositID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, withdrawal)
+		})
+	}
+}
+
+func TestUsecase_AccrueInterest(t *testing.T) {
+	today := dates.TruncateDay(time.Now())
+
+	// two periods have passed, the first one is posted already
+	monthly := testDeposit(models.CapitalizationMonthly)
+	monthly.DateStart = dates.AddMonths(today, -2)
+	monthly.DateEnd = dates.AddMonths(monthly.DateStart, 12)
+	monthly.AccruedUntil = dates.AddMonths(monthly.DateStart, 1)
+
+	// the term is over today
+	simple := testDeposit(models.CapitalizationNone)
+	simple.DateStart = today.AddDate(-1, 0, 0)
+	simple.DateEnd = today
+	simple.AccruedUntil = simple.DateStart
+
+	t.Run("Successful TestUsecase_AccrueInterest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockRepository(ctrl)
+		mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+		mockRepo.EXPECT().GetOpenDeposits(gomock.Any()).Return([]models.Deposit{*monthly, *simple}, nil)
+		mockRepo.EXPECT().PostInterest(gomock.Any(), gomock.Any(), gomock.Any(), dates.AddMonths(monthly.DateStart, 2), false).Return(nil, nil)
+		mockRepo.EXPECT().PostInterest(gomock.Any(), gomock.Any(), gomock.Any(), today, true).Return(nil, nil)
+
+		assert.NoError(t, mockUsecase.AccrueInterest(context.Background()))
+	})
+
+	t.Run("Post Error in TestUsecase_AccrueInterest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockRepository(ctrl)
+		mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+		mockRepo.EXPECT().GetOpenDeposits(gomock.Any()).Return([]models.Deposit{*monthly, *simple}, nil)
+		mockRepo.EXPECT().PostInterest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, errors.New("some error"))
+		mockRepo.EXPECT().PostInterest(gomock.Any(), gomock.Any(), gomock.Any(), today, true).Return(nil, nil)
+
+		assert.EqualError(t, mockUsecase.AccrueInterest(context.Background()), "[usecase] interest accrual failed for 1 of 2 deposits")
+	})
+
+	t.Run("Deposits Error in TestUsecase_AccrueInterest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockRepository(ctrl)
+		mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_account.NewMockRepository(ctrl), mock_anomaly.NewMockUsecase(ctrl))
+
+		mockRepo.EXPECT().GetOpenDeposits(gomock.Any()).Return(nil, errors.New("some error"))
+
+		assert.EqualError(t, mockUsecase.AccrueInterest(context.Background()), "[usecase] can't get open deposits from repository some error")
+	})
+}
+
+func TestBuildSchedule(t *testing.T) {
+	t.Run("Monthly capitalization", func(t *testing.T) {
+		deposit := testDeposit(models.CapitalizationMonthly)
+		deposit.AccruedUntil = time.Date(2023, time.February, 15, 0, 0, 0, 0, time.UTC)
+
+		schedule := buildSchedule(deposit)
+
+		assert.Len(t, schedule, 12)
+		assert.Equal(t, models.DepositPayout{Date: deposit.AccruedUntil, Interest: 1019.18, Balance: 101019.18, Posted: true}, schedule[0])
+		assert.Equal(t, models.DepositPayout{Date: time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC), Interest: 929.93, Balance: 101949.11}, schedule[1])
+		assert.Equal(t, deposit.DateEnd, schedule[11].Date)
+	})
+
+	t.Run("Quarterly capitalization", func(t *testing.T) {
+		deposit := testDeposit(models.CapitalizationQuarterly)
+		deposit.DateEnd = time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+
+		schedule := buildSchedule(deposit)
+
+		assert.Len(t, schedule, 3)
+		assert.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC), schedule[0].Date)
+		assert.Equal(t, time.Date(2023, time.July, 15, 0, 0, 0, 0, time.UTC), schedule[1].Date)
+		assert.Equal(t, deposit.DateEnd, schedule[2].Date)
+	})
+
+	t.Run("Simple interest", func(t *testing.T) {
+		projection := buildProjection(testDeposit(models.CapitalizationNone))
+
+		assert.Len(t, projection.Payouts, 1)
+		assert.Equal(t, 12000.0, projection.Interest)
+		assert.Equal(t, 112000.0, projection.FinalBalance)
+	})
+
+	t.Run("Compound interest earns more", func(t *testing.T) {
+		simple := buildProjection(testDeposit(models.CapitalizationNone))
+		compound := buildProjection(testDeposit(models.CapitalizationMonthly))
+
+		assert.Greater(t, compound.Interest, simple.Interest)
+		assert.Equal(t, money.Round2(compound.Total+compound.Interest), compound.FinalBalance)
+	})
+}
@@ -0,0 +1,390 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package generated
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GoalServiceClient is the client API for GoalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GoalServiceClient interface {
+	CreateGoal(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*CreateGoalResponse, error)
+	UpdateGoal(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteGoal(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetGoals(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*GoalsResponse, error)
+	CheckGoalsState(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*GoalsResponse, error)
+	GetProjection(ctx context.Context, in *GoalIdRequest, opts ...grpc.CallOption) (*GoalProjection, error)
+	CreateRule(ctx context.Context, in *GoalRule, opts ...grpc.CallOption) (*CreateRuleResponse, error)
+	GetRules(ctx context.Context, in *GoalIdRequest, opts ...grpc.CallOption) (*RulesResponse, error)
+	DeleteRule(ctx context.Context, in *RuleIdRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type goalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGoalServiceClient(cc grpc.ClientConnInterface) GoalServiceClient {
+	return &goalServiceClient{cc}
+}
+
+func (c *goalServiceClient) CreateGoal(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*CreateGoalResponse, error) {
+	out := new(CreateGoalResponse)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/CreateGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) UpdateGoal(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/UpdateGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) DeleteGoal(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/DeleteGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) GetGoals(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*GoalsResponse, error) {
+	out := new(GoalsResponse)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/GetGoals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) CheckGoalsState(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*GoalsResponse, error) {
+	out := new(GoalsResponse)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/CheckGoalsState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) GetProjection(ctx context.Context, in *GoalIdRequest, opts ...grpc.CallOption) (*GoalProjection, error) {
+	out := new(GoalProjection)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/GetProjection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) CreateRule(ctx context.Context, in *GoalRule, opts ...grpc.CallOption) (*CreateRuleResponse, error) {
+	out := new(CreateRuleResponse)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/CreateRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) GetRules(ctx context.Context, in *GoalIdRequest, opts ...grpc.CallOption) (*RulesResponse, error) {
+	out := new(RulesResponse)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/GetRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goalServiceClient) DeleteRule(ctx context.Context, in *RuleIdRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/goal.GoalService/DeleteRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GoalServiceServer is the server API for GoalService service.
+// All implementations must embed UnimplementedGoalServiceServer
+// for forward compatibility
+type GoalServiceServer interface {
+	CreateGoal(context.Context, *Goal) (*CreateGoalResponse, error)
+	UpdateGoal(context.Context, *Goal) (*empty.Empty, error)
+	DeleteGoal(context.Context, *DeleteRequest) (*empty.Empty, error)
+	GetGoals(context.Context, *UserIdRequest) (*GoalsResponse, error)
+	CheckGoalsState(context.Context, *UserIdRequest) (*GoalsResponse, error)
+	GetProjection(context.Context, *GoalIdRequest) (*GoalProjection, error)
+	CreateRule(context.Context, *GoalRule) (*CreateRuleResponse, error)
+	GetRules(context.Context, *GoalIdRequest) (*RulesResponse, error)
+	DeleteRule(context.Context, *RuleIdRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedGoalServiceServer()
+}
+
+// UnimplementedGoalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGoalServiceServer struct {
+}
+
+func (UnimplementedGoalServiceServer) CreateGoal(context.Context, *Goal) (*CreateGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGoal not implemented")
+}
+func (UnimplementedGoalServiceServer) UpdateGoal(context.Context, *Goal) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGoal not implemented")
+}
+func (UnimplementedGoalServiceServer) DeleteGoal(context.Context, *DeleteRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGoal not implemented")
+}
+func (UnimplementedGoalServiceServer) GetGoals(context.Context, *UserIdRequest) (*GoalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGoals not implemented")
+}
+func (UnimplementedGoalServiceServer) CheckGoalsState(context.Context, *UserIdRequest) (*GoalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckGoalsState not implemented")
+}
+func (UnimplementedGoalServiceServer) GetProjection(context.Context, *GoalIdRequest) (*GoalProjection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjection not implemented")
+}
+func (UnimplementedGoalServiceServer) CreateRule(context.Context, *GoalRule) (*CreateRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRule not implemented")
+}
+func (UnimplementedGoalServiceServer) GetRules(context.Context, *GoalIdRequest) (*RulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRules not implemented")
+}
+func (UnimplementedGoalServiceServer) DeleteRule(context.Context, *RuleIdRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRule not implemented")
+}
+func (UnimplementedGoalServiceServer) mustEmbedUnimplementedGoalServiceServer() {}
+
+// UnsafeGoalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GoalServiceServer will
+// result in compilation errors.
+type UnsafeGoalServiceServer interface {
+	mustEmbedUnimplementedGoalServiceServer()
+}
+
+func RegisterGoalServiceServer(s grpc.ServiceRegistrar, srv GoalServiceServer) {
+	s.RegisterService(&GoalService_ServiceDesc, srv)
+}
+
+func _GoalService_CreateGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Goal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).CreateGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/CreateGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).CreateGoal(ctx, req.(*Goal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_UpdateGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Goal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).UpdateGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/UpdateGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).UpdateGoal(ctx, req.(*Goal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_DeleteGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).DeleteGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/DeleteGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).DeleteGoal(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_GetGoals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).GetGoals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/GetGoals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).GetGoals(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_CheckGoalsState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).CheckGoalsState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/CheckGoalsState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).CheckGoalsState(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_GetProjection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).GetProjection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/GetProjection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).GetProjection(ctx, req.(*GoalIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_CreateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalRule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).CreateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/CreateRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).CreateRule(ctx, req.(*GoalRule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_GetRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).GetRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/GetRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).GetRules(ctx, req.(*GoalIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoalService_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/goal.GoalService/DeleteRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).DeleteRule(ctx, req.(*RuleIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GoalService_ServiceDesc is the grpc.ServiceDesc for GoalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GoalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goal.GoalService",
+	HandlerType: (*GoalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGoal",
+			Handler:    _GoalService_CreateGoal_Handler,
+		},
+		{
+			MethodName: "UpdateGoal",
+			Handler:    _GoalService_UpdateGoal_Handler,
+		},
+		{
+			MethodName: "DeleteGoal",
+			Handler:    _GoalService_DeleteGoal_Handler,
+		},
+		{
+			MethodName: "GetGoals",
+			Handler:    _GoalService_GetGoals_Handler,
+		},
+		{
+			MethodName: "CheckGoalsState",
+			Handler:    _GoalService_CheckGoalsState_Handler,
+		},
+		{
+			MethodName: "GetProjection",
+			Handler:    _GoalService_GetProjection_Handler,
+		},
+		{
+			MethodName: "CreateRule",
+			Handler:    _GoalService_CreateRule_Handler,
+		},
+		{
+			MethodName: "GetRules",
+			Handler:    _GoalService_GetRules_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _GoalService_DeleteRule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "goal.proto",
+}
@@ -0,0 +1,295 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal"
+	proto "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type goalGRPC struct {
+	GoalServices goal.Usecase
+	logger       logger.Logger
+
+	proto.UnimplementedGoalServiceServer
+}
+
+func NewGoalGRPC(goalServices goal.Usecase, logger logger.Logger) *goalGRPC {
+	return &goalGRPC{
+		GoalServices: goalServices,
+		logger:       logger,
+	}
+}
+
+func (g *goalGRPC) CreateGoal(ctx context.Context, in *proto.Goal) (*proto.CreateGoalResponse, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	id, err := g.GoalServices.CreateGoal(ctx, userID, GoalFromProto(in))
+	if err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return &proto.CreateGoalResponse{GoalId: id.String()}, nil
+}
+
+func (g *goalGRPC) UpdateGoal(ctx context.Context, in *proto.Goal) (*empty.Empty, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	if err := g.GoalServices.UpdateGoal(ctx, userID, GoalFromProto(in)); err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (g *goalGRPC) DeleteGoal(ctx context.Context, in *proto.DeleteRequest) (*empty.Empty, error) {
+	goalID, _ := uuid.Parse(in.GoalId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	if err := g.GoalServices.DeleteGoal(ctx, userID, goalID); err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (g *goalGRPC) GetGoals(ctx context.Context, in *proto.UserIdRequest) (*proto.GoalsResponse, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	goals, err := g.GoalServices.GetGoals(ctx, userID)
+	if err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return goalsToProto(goals), nil
+}
+
+func (g *goalGRPC) CheckGoalsState(ctx context.Context, in *proto.UserIdRequest) (*proto.GoalsResponse, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	goals, err := g.GoalServices.CheckGoalsState(ctx, userID)
+	if err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return goalsToProto(goals), nil
+}
+
+func (g *goalGRPC) GetProjection(ctx context.Context, in *proto.GoalIdRequest) (*proto.GoalProjection, error) {
+	goalID, _ := uuid.Parse(in.GoalId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	projection, err := g.GoalServices.GetProjection(ctx, userID, goalID)
+	if err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return ProjectionToProto(projection), nil
+}
+
+func (g *goalGRPC) CreateRule(ctx context.Context, in *proto.GoalRule) (*proto.CreateRuleResponse, error) {
+	userID, _ := uuid.Parse(in.UserId)
+
+	id, err := g.GoalServices.CreateRule(ctx, userID, RuleFromProto(in))
+	if err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return &proto.CreateRuleResponse{RuleId: id.String()}, nil
+}
+
+func (g *goalGRPC) GetRules(ctx context.Context, in *proto.GoalIdRequest) (*proto.RulesResponse, error) {
+	goalID, _ := uuid.Parse(in.GoalId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	rules, err := g.GoalServices.GetRules(ctx, userID, goalID)
+	if err != nil {
+		return nil, goalStatus(err)
+	}
+
+	response := &proto.RulesResponse{Rules: make([]*proto.GoalRule, len(rules))}
+	for i := range rules {
+		response.Rules[i] = RuleToProto(&rules[i])
+	}
+	return response, nil
+}
+
+func (g *goalGRPC) DeleteRule(ctx context.Context, in *proto.RuleIdRequest) (*empty.Empty, error) {
+	ruleID, _ := uuid.Parse(in.RuleId)
+	userID, _ := uuid.Parse(in.UserId)
+
+	if err := g.GoalServices.DeleteRule(ctx, userID, ruleID); err != nil {
+		return nil, goalStatus(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// goalStatus keeps the reason of a client error for the api, the message of the status is shown to the user
+func goalStatus(err error) error {
+	var errNoSuchGoal *models.NoSuchGoalError
+	if errors.As(err, &errNoSuchGoal) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	var errNoSuchRule *models.NoSuchGoalRuleError
+	if errors.As(err, &errNoSuchRule) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	var errNotAccumulation *models.NotAccumulationAccountError
+	if errors.As(err, &errNotAccumulation) {
+		return status.Error(codes.InvalidArgument, errNotAccumulation.Error())
+	}
+
+	var errRule *models.GoalRuleOperationError
+	if errors.As(err, &errRule) {
+		return status.Error(codes.InvalidArgument, errRule.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+func GoalFromProto(in *proto.Goal) *models.Goal {
+	id, _ := uuid.Parse(in.Id)
+	userID, _ := uuid.Parse(in.UserId)
+
+	accounts := make([]uuid.UUID, 0, len(in.Accounts))
+	for _, account := range in.Accounts {
+		accountID, _ := uuid.Parse(account)
+		accounts = append(accounts, accountID)
+	}
+
+	return &models.Goal{
+		ID:          id,
+		UserId:      userID,
+		Name:        in.Name,
+		Description: in.Description,
+		Target:      in.Target,
+		Date:        in.Date.AsTime(),
+		Accounts:    accounts,
+		Progress:    in.Progress,
+		Completed:   in.Completed,
+		Status:      in.Status,
+	}
+}
+
+func GoalToProto(goal *models.Goal) *proto.Goal {
+	accounts := make([]string, 0, len(goal.Accounts))
+	for _, accountID := range goal.Accounts {
+		accounts = append(accounts, accountID.String())
+	}
+
+	return &proto.Goal{
+		Id:          goal.ID.String(),
+		UserId:      goal.UserId.String(),
+		Name:        goal.Name,
+		Description: goal.Description,
+		Target:      goal.Target,
+		Date:        timestamppb.New(goal.Date),
+		Accounts:    accounts,
+		Progress:    goal.Progress,
+		Completed:   goal.Completed,
+		Status:      goal.Status,
+	}
+}
+
+func goalsToProto(goals []models.Goal) *proto.GoalsResponse {
+	response := &proto.GoalsResponse{Goals: make([]*proto.Goal, len(goals))}
+	for i := range goals {
+		response.Goals[i] = GoalToProto(&goals[i])
+	}
+	return response
+}
+
+func ProjectionFromProto(in *proto.GoalProjection) *models.GoalProjection {
+	goalID, _ := uuid.Parse(in.GoalId)
+
+	projection := &models.GoalProjection{
+		GoalID:          goalID,
+		Remaining:       in.Remaining,
+		MonthlyRequired: in.MonthlyRequired,
+		MonthlyPace:     in.MonthlyPace,
+		Status:          in.Status,
+	}
+	if in.ExpectedDate != nil {
+		expected := in.ExpectedDate.AsTime()
+		projection.ExpectedDate = &expected
+	}
+	return projection
+}
+
+func ProjectionToProto(projection *models.GoalProjection) *proto.GoalProjection {
+	out := &proto.GoalProjection{
+		GoalId:          projection.GoalID.String(),
+		Remaining:       projection.Remaining,
+		MonthlyRequired: projection.MonthlyRequired,
+		MonthlyPace:     projection.MonthlyPace,
+		Status:          projection.Status,
+	}
+	if projection.ExpectedDate != nil {
+		out.ExpectedDate = timestamppb.New(*projection.ExpectedDate)
+	}
+	return out
+}
+
+func RuleFromProto(in *proto.GoalRule) *models.GoalRule {
+	id, _ := uuid.Parse(in.Id)
+	goalID, _ := uuid.Parse(in.GoalId)
+	userID, _ := uuid.Parse(in.UserId)
+	sourceID, _ := uuid.Parse(in.SourceId)
+	accountID, _ := uuid.Parse(in.AccountId)
+
+	rule := &models.GoalRule{
+		ID:        id,
+		GoalID:    goalID,
+		UserID:    userID,
+		Kind:      in.Kind,
+		SourceID:  sourceID,
+		AccountID: accountID,
+		Amount:    in.Amount,
+		Period:    in.Period,
+	}
+	if in.DateStart != nil {
+		dateStart := in.DateStart.AsTime()
+		rule.DateStart = &dateStart
+	}
+	if in.NextDate != nil {
+		nextDate := in.NextDate.AsTime()
+		rule.NextDate = &nextDate
+	}
+	return rule
+}
+
+func RuleToProto(rule *models.GoalRule) *proto.GoalRule {
+	out := &proto.GoalRule{
+		Id:        rule.ID.String(),
+		GoalId:    rule.GoalID.String(),
+		UserId:    rule.UserID.String(),
+		Kind:      rule.Kind,
+		SourceId:  rule.SourceID.String(),
+		AccountId: rule.AccountID.String(),
+		Amount:    rule.Amount,
+		Period:    rule.Period,
+	}
+	if rule.DateStart != nil {
+		out.DateStart = timestamppb.New(*rule.DateStart)
+	}
+	if rule.NextDate != nil {
+		out.NextDate = timestamppb.New(*rule.NextDate)
+	}
+	return out
+}
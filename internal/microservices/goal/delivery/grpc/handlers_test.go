@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	proto "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateGoal(t *testing.T) {
+	userID := uuid.New()
+	goalID := uuid.New()
+	accountID := uuid.New()
+	goal := &models.Goal{
+		Name:     "Отпуск",
+		Target:   150000,
+		Date:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Accounts: []uuid.UUID{accountID},
+	}
+
+	testCases := []struct {
+		name         string
+		usecaseErr   error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Success",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Not an accumulation account",
+			usecaseErr:   fmt.Errorf("[usecase] %w", &models.NotAccumulationAccountError{AccountID: accountID}),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Account of another user",
+			usecaseErr:   &models.ForbiddenUserError{},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Server error",
+			usecaseErr:   errors.New("err"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGoalServices := mocks.NewMockUsecase(ctrl)
+			mockGoalServices.EXPECT().
+				CreateGoal(gomock.Any(), userID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, in *models.Goal) (uuid.UUID, error) {
+					assert.Equal(t, goal.Accounts, in.Accounts)
+					assert.True(t, goal.Date.Equal(in.Date))
+					return goalID, tc.usecaseErr
+				})
+
+			goalGRPC := NewGoalGRPC(mockGoalServices, *logger.NewLogger(context.TODO()))
+
+			request := GoalToProto(goal)
+			request.UserId = userID.String()
+			response, err := goalGRPC.CreateGoal(context.Background(), request)
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode == codes.OK {
+				assert.Equal(t, goalID.String(), response.GoalId)
+			}
+			if tc.expectedCode == codes.InvalidArgument {
+				assert.Equal(t, "account "+accountID.String()+" is not an accumulation account", status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestDeleteGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	goalID := uuid.New()
+
+	mockGoalServices := mocks.NewMockUsecase(ctrl)
+	mockGoalServices.EXPECT().
+		DeleteGoal(gomock.Any(), userID, goalID).
+		Return(&models.NoSuchGoalError{GoalID: goalID})
+
+	goalGRPC := NewGoalGRPC(mockGoalServices, *logger.NewLogger(context.TODO()))
+
+	_, err := goalGRPC.DeleteGoal(context.Background(), &proto.DeleteRequest{GoalId: goalID.String(), UserId: userID.String()})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCheckGoalsState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	goals := []models.Goal{{ID: uuid.New(), Target: 1000, Progress: 1000, Completed: true, Accounts: []uuid.UUID{uuid.New()}}}
+
+	mockGoalServices := mocks.NewMockUsecase(ctrl)
+	mockGoalServices.EXPECT().
+		CheckGoalsState(gomock.Any(), userID).
+		Return(goals, nil)
+
+	goalGRPC := NewGoalGRPC(mockGoalServices, *logger.NewLogger(context.TODO()))
+
+	response, err := goalGRPC.CheckGoalsState(context.Background(), &proto.UserIdRequest{UserId: userID.String()})
+
+	assert.NoError(t, err)
+	if assert.Len(t, response.Goals, 1) {
+		assert.Equal(t, goals[0].ID.String(), response.Goals[0].Id)
+		assert.True(t, response.Goals[0].Completed)
+		assert.Equal(t, goals[0].Accounts, GoalFromProto(response.Goals[0]).Accounts)
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	userID := uuid.New()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rule := &models.GoalRule{
+		GoalID:    uuid.New(),
+		UserID:    userID,
+		Kind:      models.GoalRuleSchedule,
+		SourceID:  uuid.New(),
+		AccountID: uuid.New(),
+		Amount:    5000,
+		Period:    models.GoalPeriodMonth,
+		DateStart: &start,
+	}
+
+	testCases := []struct {
+		name         string
+		usecaseErr   error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Success",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Account not linked to the goal",
+			usecaseErr:   fmt.Errorf("[usecase] %w", &models.GoalRuleOperationError{Reason: "account is not linked to the goal"}),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Goal of another user",
+			usecaseErr:   &models.NoSuchGoalError{GoalID: rule.GoalID},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ruleID := uuid.New()
+			mockGoalServices := mocks.NewMockUsecase(ctrl)
+			mockGoalServices.EXPECT().
+				CreateRule(gomock.Any(), userID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, in *models.GoalRule) (uuid.UUID, error) {
+					assert.Equal(t, rule, in)
+					return ruleID, tc.usecaseErr
+				})
+
+			goalGRPC := NewGoalGRPC(mockGoalServices, *logger.NewLogger(context.TODO()))
+
+			response, err := goalGRPC.CreateRule(context.Background(), RuleToProto(rule))
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode == codes.OK {
+				assert.Equal(t, ruleID.String(), response.RuleId)
+			}
+			if tc.expectedCode == codes.InvalidArgument {
+				assert.Equal(t, "account is not linked to the goal", status.Convert(err).Message())
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package http
+
+import (
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	goalGRPC "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc"
+	genGoal "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Handler struct {
+	client genGoal.GoalServiceClient
+	logger logger.Logger
+}
+
+func NewHandler(client genGoal.GoalServiceClient, l logger.Logger) *Handler {
+	return &Handler{
+		client: client,
+		logger: l,
+	}
+}
+
+// @Summary		Create goal
+// @Tags		Goal
+// @Description	Create a goal saved up on accumulation accounts
+// @Accept 		json
+// @Produce		json
+// @Param		goal	body		GoalInput						true	"Goal"
+// @Success		200		{object}	Response[GoalCreateResponse]	"Goal created"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Forbidden user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/goal/create [post]
+func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var goalInput GoalInput
+	if err := easyjson.UnmarshalFromReader(r.Body, &goalInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := goalInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	goal := goalInput.ToGoal()
+	goal.UserId = user.ID
+
+	created, err := h.client.CreateGoal(r.Context(), goalGRPC.GoalToProto(goal))
+	if h.goalError(w, err, GoalNotSuch, GoalCreateServerError) {
+		return
+	}
+
+	id, _ := uuid.Parse(created.GoalId)
+	commonHttp.SuccessResponse(w, http.StatusOK, GoalCreateResponse{GoalID: id})
+}
+
+// @Summary		Update goal
+// @Tags		Goal
+// @Description	Replace the goal and its accounts
+// @Accept 		json
+// @Produce		json
+// @Param		goal_id	path		string				true	"Goal ID"
+// @Param		goal	body		GoalInput			true	"Goal"
+// @Success		200		{object}	Response[NilBody]	"Goal updated"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  		"Forbidden user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/goal/{goal_id}/update [put]
+func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(goalID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var goalInput GoalInput
+	if err := easyjson.UnmarshalFromReader(r.Body, &goalInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := goalInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	goal := goalInput.ToGoal()
+	goal.ID = id
+	goal.UserId = user.ID
+
+	_, err = h.client.UpdateGoal(r.Context(), goalGRPC.GoalToProto(goal))
+	if h.goalError(w, err, GoalNotSuch, GoalUpdateServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Delete goal
+// @Tags		Goal
+// @Description	Delete the goal, the accounts stay as they are
+// @Produce		json
+// @Param		goal_id	path		string				true	"Goal ID"
+// @Success		200		{object}	Response[NilBody]	"Goal deleted"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/goal/{goal_id}/delete [delete]
+func (h *Handler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(goalID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	_, err = h.client.DeleteGoal(r.Context(), &genGoal.DeleteRequest{
+		GoalId: id.String(),
+		UserId: user.ID.String(),
+	})
+	if h.goalError(w, err, GoalNotSuch, GoalDeleteServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Get goals
+// @Tags		Goal
+// @Description	Goals of the user with their progress and whether they are on track, the nearest date first
+// @Produce		json
+// @Success		200		{object}	Response[[]models.Goal]	"Goals"
+// @Failure     401    	{object}    ResponseError  			"Unauthorized user"
+// @Failure		500		{object}	ResponseError			"Server error"
+// @Router		/api/goal/all [get]
+func (h *Handler) GetGoals(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	goals, err := h.client.GetGoals(r.Context(), &genGoal.UserIdRequest{UserId: user.ID.String()})
+	if h.goalError(w, err, GoalNotSuch, GoalGetServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, goalsFromProto(goals))
+}
+
+// @Summary		Check goals state
+// @Tags		Goal
+// @Description	Goals whose accounts reached the total
+// @Produce		json
+// @Success		200		{object}	Response[[]models.Goal]	"Completed goals"
+// @Failure     401    	{object}    ResponseError  			"Unauthorized user"
+// @Failure		500		{object}	ResponseError			"Server error"
+// @Router		/api/goal/completed [get]
+func (h *Handler) CheckGoalsState(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	goals, err := h.client.CheckGoalsState(r.Context(), &genGoal.UserIdRequest{UserId: user.ID.String()})
+	if h.goalError(w, err, GoalNotSuch, GoalStateServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, goalsFromProto(goals))
+}
+
+// @Summary		Get goal projection
+// @Tags		Goal
+// @Description	Monthly saving needed to reach the goal by its date and when it is reached at the pace of the last months
+// @Produce		json
+// @Param		goal_id	path		string							true	"Goal ID"
+// @Success		200		{object}	Response[models.GoalProjection]	"Projection"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/goal/{goal_id}/projection [get]
+func (h *Handler) GetProjection(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(goalID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	projection, err := h.client.GetProjection(r.Context(), &genGoal.GoalIdRequest{
+		GoalId: id.String(),
+		UserId: user.ID.String(),
+	})
+	if h.goalError(w, err, GoalNotSuch, GoalProjectionError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, goalGRPC.ProjectionFromProto(projection))
+}
+
+// @Summary		Create goal rule
+// @Tags		Goal
+// @Description	Fund an account of the goal by a fixed sum on a schedule, a percent of every salary or round-ups of outcomes
+// @Accept 		json
+// @Produce		json
+// @Param		goal_id	path		string								true	"Goal ID"
+// @Param		rule	body		GoalRuleInput						true	"Rule"
+// @Success		200		{object}	Response[GoalRuleCreateResponse]	"Rule created"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/goal/{goal_id}/rule/create [post]
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(goalID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var ruleInput GoalRuleInput
+	if err := easyjson.UnmarshalFromReader(r.Body, &ruleInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := ruleInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	rule := ruleInput.ToRule(id)
+	rule.UserID = user.ID
+
+	created, err := h.client.CreateRule(r.Context(), goalGRPC.RuleToProto(rule))
+	if h.goalError(w, err, GoalNotSuch, GoalRuleCreateServerError) {
+		return
+	}
+
+	ruleID, _ := uuid.Parse(created.RuleId)
+	commonHttp.SuccessResponse(w, http.StatusOK, GoalRuleCreateResponse{RuleID: ruleID})
+}
+
+// @Summary		Get goal rules
+// @Tags		Goal
+// @Description	Contribution rules of the goal
+// @Produce		json
+// @Param		goal_id	path		string						true	"Goal ID"
+// @Success		200		{object}	Response[[]models.GoalRule]	"Rules"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/goal/{goal_id}/rules [get]
+func (h *Handler) GetRules(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(goalID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	response, err := h.client.GetRules(r.Context(), &genGoal.GoalIdRequest{
+		GoalId: id.String(),
+		UserId: user.ID.String(),
+	})
+	if h.goalError(w, err, GoalNotSuch, GoalRuleGetServerError) {
+		return
+	}
+
+	rules := make([]models.GoalRule, len(response.Rules))
+	for i, rule := range response.Rules {
+		rules[i] = *goalGRPC.RuleFromProto(rule)
+	}
+	commonHttp.SuccessResponse(w, http.StatusOK, rules)
+}
+
+// @Summary		Delete goal rule
+// @Tags		Goal
+// @Description	Stop the rule, the transfers it made stay
+// @Produce		json
+// @Param		rule_id	path		string				true	"Rule ID"
+// @Success		200		{object}	Response[NilBody]	"Rule deleted"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/goal/rule/{rule_id}/delete [delete]
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(ruleID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	_, err = h.client.DeleteRule(r.Context(), &genGoal.RuleIdRequest{
+		RuleId: id.String(),
+		UserId: user.ID.String(),
+	})
+	if h.goalError(w, err, GoalRuleNotSuch, GoalRuleDeleteServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// goalError writes the response for an error of the goal service, false if there is no error
+func (h *Handler) goalError(w http.ResponseWriter, err error, notSuch string, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	switch st := status.Convert(err); st.Code() {
+	case codes.NotFound:
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, notSuch, h.logger)
+	case codes.PermissionDenied:
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+	case codes.InvalidArgument:
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, st.Message(), h.logger)
+	default:
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.logger)
+	}
+	return true
+}
+
+func goalsFromProto(response *genGoal.GoalsResponse) []models.Goal {
+	goals := make([]models.Goal, len(response.Goals))
+	for i, goal := range response.Goals {
+		goals[i] = *goalGRPC.GoalFromProto(goal)
+	}
+	return goals
+}
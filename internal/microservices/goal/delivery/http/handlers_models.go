@@ -0,0 +1,173 @@
+package http
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	goalID = "goal_id"
+	ruleID = "rule_id"
+
+	GoalCreateServerError = "can't create goal"
+	GoalUpdateServerError = "can't update goal"
+	GoalDeleteServerError = "can't delete goal"
+	GoalGetServerError    = "can't get goals"
+	GoalStateServerError  = "can't check goals state"
+	GoalProjectionError   = "can't get goal projection"
+	GoalNotSuch           = "no such goal"
+
+	GoalRuleCreateServerError = "can't create goal rule"
+	GoalRuleGetServerError    = "can't get goal rules"
+	GoalRuleDeleteServerError = "can't delete goal rule"
+	GoalRuleNotSuch           = "no such goal rule"
+
+	GoalRulePercentMax = 100
+
+	GoalNameMaxLen        = 50
+	GoalDescriptionMaxLen = 255
+)
+
+var (
+	errInvalidName        = errors.New("name is required")
+	errInvalidDescription = errors.New("description is too long")
+	errInvalidTotal       = errors.New("total must be positive")
+	errInvalidDate        = errors.New("date is required")
+	errInvalidAccounts    = errors.New("accounts are required")
+
+	errInvalidRuleKind     = errors.New("kind must be schedule, income or round_up")
+	errInvalidRuleAccounts = errors.New("source_id and account_id are required")
+	errInvalidRuleAmount   = errors.New("amount must be positive")
+	errInvalidRulePercent  = errors.New("percent of income must be up to 100")
+	errInvalidRuleStep     = errors.New("round-up step must be 10 or 100")
+	errInvalidRulePeriod   = errors.New("period must be week or month")
+	errInvalidRuleDate     = errors.New("date_start is required")
+)
+
+// roundUpSteps are the sums outcomes are rounded up to
+var roundUpSteps = map[float64]bool{10: true, 100: true}
+
+type GoalCreateResponse struct {
+	GoalID uuid.UUID `json:"goal_id"`
+}
+
+type GoalRuleCreateResponse struct {
+	RuleID uuid.UUID `json:"rule_id"`
+}
+
+// GoalInput is a goal to create or the new state of a goal, progress comes from the accounts
+//
+//easyjson:json
+type GoalInput struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Total       float64     `json:"total"`
+	Date        time.Time   `json:"date"`
+	Accounts    []uuid.UUID `json:"accounts"`
+}
+
+func (gi *GoalInput) CheckValid() error {
+	gi.Name = html.EscapeString(strings.TrimSpace(gi.Name))
+	gi.Description = html.EscapeString(gi.Description)
+
+	switch {
+	case gi.Name == "" || utf8.RuneCountInString(gi.Name) > GoalNameMaxLen:
+		return errInvalidName
+	case utf8.RuneCountInString(gi.Description) > GoalDescriptionMaxLen:
+		return errInvalidDescription
+	case gi.Total <= 0:
+		return errInvalidTotal
+	case gi.Date.IsZero():
+		return errInvalidDate
+	case len(gi.Accounts) == 0:
+		return errInvalidAccounts
+	}
+
+	// an account linked twice is counted once
+	seen := make(map[uuid.UUID]bool, len(gi.Accounts))
+	unique := gi.Accounts[:0]
+	for _, id := range gi.Accounts {
+		if id == uuid.Nil {
+			return errInvalidAccounts
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	gi.Accounts = unique
+
+	return nil
+}
+
+func (gi *GoalInput) ToGoal() *models.Goal {
+	return &models.Goal{
+		Name:        gi.Name,
+		Description: gi.Description,
+		Target:      gi.Total,
+		Date:        gi.Date,
+		Accounts:    gi.Accounts,
+	}
+}
+
+// GoalRuleInput is a contribution rule, amount is the sum of a scheduled transfer,
+// the percent of a salary income or the round-up step of an outcome
+//
+//easyjson:json
+type GoalRuleInput struct {
+	Kind      string     `json:"kind"`
+	SourceID  uuid.UUID  `json:"source_id"`
+	AccountID uuid.UUID  `json:"account_id"`
+	Amount    float64    `json:"amount"`
+	Period    string     `json:"period"`
+	DateStart *time.Time `json:"date_start"`
+}
+
+func (ri *GoalRuleInput) CheckValid() error {
+	if ri.SourceID == uuid.Nil || ri.AccountID == uuid.Nil {
+		return errInvalidRuleAccounts
+	}
+	if ri.Amount <= 0 {
+		return errInvalidRuleAmount
+	}
+
+	switch ri.Kind {
+	case models.GoalRuleSchedule:
+		if ri.Period != models.GoalPeriodWeek && ri.Period != models.GoalPeriodMonth {
+			return errInvalidRulePeriod
+		}
+		if ri.DateStart == nil || ri.DateStart.IsZero() {
+			return errInvalidRuleDate
+		}
+	case models.GoalRuleIncome:
+		if ri.Amount > GoalRulePercentMax {
+			return errInvalidRulePercent
+		}
+	case models.GoalRuleRoundUp:
+		if !roundUpSteps[ri.Amount] {
+			return errInvalidRuleStep
+		}
+	default:
+		return errInvalidRuleKind
+	}
+
+	return nil
+}
+
+func (ri *GoalRuleInput) ToRule(goalID uuid.UUID) *models.GoalRule {
+	return &models.GoalRule{
+		GoalID:    goalID,
+		Kind:      ri.Kind,
+		SourceID:  ri.SourceID,
+		AccountID: ri.AccountID,
+		Amount:    ri.Amount,
+		Period:    ri.Period,
+		DateStart: ri.DateStart,
+	}
+}
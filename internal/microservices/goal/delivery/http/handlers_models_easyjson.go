@@ -0,0 +1,270 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp(in *jlexer.Lexer, out *GoalRuleInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kind":
+			out.Kind = string(in.String())
+		case "source_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.SourceID).UnmarshalText(data))
+			}
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		case "amount":
+			out.Amount = float64(in.Float64())
+		case "period":
+			out.Period = string(in.String())
+		case "date_start":
+			if in.IsNull() {
+				in.Skip()
+				out.DateStart = nil
+			} else {
+				if out.DateStart == nil {
+					out.DateStart = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.DateStart).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp(out *jwriter.Writer, in GoalRuleInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"source_id\":"
+		out.RawString(prefix)
+		out.RawText((in.SourceID).MarshalText())
+	}
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix)
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Amount))
+	}
+	{
+		const prefix string = ",\"period\":"
+		out.RawString(prefix)
+		out.String(string(in.Period))
+	}
+	{
+		const prefix string = ",\"date_start\":"
+		out.RawString(prefix)
+		if in.DateStart == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.DateStart).MarshalJSON())
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GoalRuleInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GoalRuleInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GoalRuleInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GoalRuleInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp(l, v)
+}
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp1(in *jlexer.Lexer, out *GoalInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "total":
+			out.Total = float64(in.Float64())
+		case "date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Date).UnmarshalJSON(data))
+			}
+		case "accounts":
+			if in.IsNull() {
+				in.Skip()
+				out.Accounts = nil
+			} else {
+				in.Delim('[')
+				if out.Accounts == nil {
+					if !in.IsDelim(']') {
+						out.Accounts = make([]uuid.UUID, 0, 4)
+					} else {
+						out.Accounts = []uuid.UUID{}
+					}
+				} else {
+					out.Accounts = (out.Accounts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 uuid.UUID
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((v1).UnmarshalText(data))
+					}
+					out.Accounts = append(out.Accounts, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp1(out *jwriter.Writer, in GoalInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Total))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((in.Date).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"accounts\":"
+		out.RawString(prefix)
+		if in.Accounts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Accounts {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.RawText((v3).MarshalText())
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GoalInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GoalInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GoalInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GoalInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesGoalDeliveryHttp1(l, v)
+}
@@ -0,0 +1,410 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	genGoal "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestHandler_CreateGoal(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	id := uuid.New()
+	accountID := uuid.New()
+	payload := `{"name": "Отпуск", "total": 150000, "date": "2024-06-01T00:00:00Z", "accounts": ["` + accountID.String() + `", "` + accountID.String() + `"]}`
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		requestPayload string
+		expectedCode   int
+		expectedBody   string
+		mockUsecaseFn  func(mockUsecase *mocks.MockGoalServiceClient)
+	}{
+		{
+			name:           "Successful Goal Creation",
+			user:           user,
+			requestPayload: payload,
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"status":200,"body":{"goal_id":"` + id.String() + `"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, in *genGoal.Goal, _ ...interface{}) (*genGoal.CreateGoalResponse, error) {
+						assert.Equal(t, user.ID.String(), in.UserId)
+						assert.Equal(t, []string{accountID.String()}, in.Accounts)
+						return &genGoal.CreateGoalResponse{GoalId: id.String()}, nil
+					})
+			},
+		},
+		{
+			name:           "Unauthorized Request",
+			requestPayload: payload,
+			expectedCode:   http.StatusUnauthorized,
+			expectedBody:   `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockGoalServiceClient) {},
+		},
+		{
+			name:           "Without Accounts",
+			user:           user,
+			requestPayload: `{"name": "Отпуск", "total": 150000, "date": "2024-06-01T00:00:00Z"}`,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockGoalServiceClient) {},
+		},
+		{
+			name:           "Not An Accumulation Account",
+			user:           user,
+			requestPayload: payload,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"account ` + accountID.String() + ` is not an accumulation account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "account "+accountID.String()+" is not an accumulation account"))
+			},
+		},
+		{
+			name:           "Account Of Another User",
+			user:           user,
+			requestPayload: payload,
+			expectedCode:   http.StatusForbidden,
+			expectedBody:   `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.PermissionDenied, "forbidden"))
+			},
+		},
+		{
+			name:           "Error in Goal Creation",
+			user:           user,
+			requestPayload: payload,
+			expectedCode:   http.StatusInternalServerError,
+			expectedBody:   `{"status":500,"message":"can't create goal"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockGoalServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/goal/create", strings.NewReader(tt.requestPayload))
+			req.Header.Set("Content-Type", "application/json")
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.CreateGoal(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_DeleteGoal(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		goalID        string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(mockUsecase *mocks.MockGoalServiceClient)
+	}{
+		{
+			name:         "Successful Goal Deletion",
+			goalID:       id.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().DeleteGoal(gomock.Any(), &genGoal.DeleteRequest{GoalId: id.String(), UserId: user.ID.String()}).
+					Return(nil, nil)
+			},
+		},
+		{
+			name:          "Invalid Goal ID",
+			goalID:        "invalid",
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {},
+		},
+		{
+			name:         "No Such Goal",
+			goalID:       id.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such goal"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().DeleteGoal(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockGoalServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("DELETE", "/api/goal/"+tt.goalID+"/delete", nil)
+			req = mux.SetURLVars(req, map[string]string{goalID: tt.goalID})
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.DeleteGoal(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetGoals(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	goal := &genGoal.Goal{
+		Id:        uuid.New().String(),
+		UserId:    user.ID.String(),
+		Name:      "Отпуск",
+		Target:    1000,
+		Date:      timestamppb.New(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+		Accounts:  []string{uuid.New().String()},
+		Progress:  1000,
+		Completed: true,
+		Status:    models.GoalCompleted,
+	}
+
+	tests := []struct {
+		name          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(mockUsecase *mocks.MockGoalServiceClient)
+	}{
+		{
+			name:         "Successful Get Goals",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[{"id":"` + goal.Id + `","name":"Отпуск","description":"","total":1000,"date":"2024-06-01T00:00:00Z","accounts":["` + goal.Accounts[0] + `"],"progress":1000,"completed":true,"status":"completed"}]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().GetGoals(gomock.Any(), &genGoal.UserIdRequest{UserId: user.ID.String()}).
+					Return(&genGoal.GoalsResponse{Goals: []*genGoal.Goal{goal}}, nil)
+			},
+		},
+		{
+			name:         "Error in Get Goals",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get goals"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().GetGoals(gomock.Any(), gomock.Any()).Return(nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockGoalServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/goal/all", nil)
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetGoals(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_CreateRule(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	goalUUID := uuid.New()
+	id := uuid.New()
+	accounts := `"source_id": "` + uuid.New().String() + `", "account_id": "` + uuid.New().String() + `"`
+
+	tests := []struct {
+		name           string
+		requestPayload string
+		expectedCode   int
+		expectedBody   string
+		mockUsecaseFn  func(mockUsecase *mocks.MockGoalServiceClient)
+	}{
+		{
+			name:           "Successful Round-up Rule",
+			requestPayload: `{"kind": "round_up", "amount": 100, ` + accounts + `}`,
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"status":200,"body":{"rule_id":"` + id.String() + `"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().CreateRule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, in *genGoal.GoalRule, _ ...interface{}) (*genGoal.CreateRuleResponse, error) {
+						assert.Equal(t, goalUUID.String(), in.GoalId)
+						assert.Equal(t, user.ID.String(), in.UserId)
+						return &genGoal.CreateRuleResponse{RuleId: id.String()}, nil
+					})
+			},
+		},
+		{
+			name:           "Round-up Step Not Allowed",
+			requestPayload: `{"kind": "round_up", "amount": 50, ` + accounts + `}`,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockGoalServiceClient) {},
+		},
+		{
+			name:           "Percent Above 100",
+			requestPayload: `{"kind": "income", "amount": 120, ` + accounts + `}`,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockGoalServiceClient) {},
+		},
+		{
+			name:           "Schedule Without Period",
+			requestPayload: `{"kind": "schedule", "amount": 5000, "date_start": "2024-06-01T00:00:00Z", ` + accounts + `}`,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn:  func(mockUsecase *mocks.MockGoalServiceClient) {},
+		},
+		{
+			name:           "Account Not Linked To The Goal",
+			requestPayload: `{"kind": "schedule", "amount": 5000, "period": "month", "date_start": "2024-06-01T00:00:00Z", ` + accounts + `}`,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"status":400,"message":"account is not linked to the goal"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockGoalServiceClient) {
+				mockUsecase.EXPECT().CreateRule(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "account is not linked to the goal"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockGoalServiceClient(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/goal/"+goalUUID.String()+"/rule/create", strings.NewReader(tt.requestPayload))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{goalID: goalUUID.String()})
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.CreateRule(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_DeleteRule(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	id := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGoalServiceClient(ctrl)
+	mockService.EXPECT().DeleteRule(gomock.Any(), &genGoal.RuleIdRequest{RuleId: id.String(), UserId: user.ID.String()}).
+		Return(nil, status.Error(codes.NotFound, "not found"))
+
+	mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+	req := httptest.NewRequest("DELETE", "/api/goal/rule/"+id.String()+"/delete", nil)
+	req = mux.SetURLVars(req, map[string]string{ruleID: id.String()})
+	ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+	req = req.WithContext(ctx)
+
+	recorder := httptest.NewRecorder()
+
+	mockHandler.DeleteRule(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, `{"status":400,"message":"no such goal rule"}`, strings.TrimSpace(recorder.Body.String()))
+}
+
+func TestHandler_GetProjection(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	id := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockGoalServiceClient(ctrl)
+	mockService.EXPECT().GetProjection(gomock.Any(), &genGoal.GoalIdRequest{GoalId: id.String(), UserId: user.ID.String()}).
+		Return(&genGoal.GoalProjection{
+			GoalId:          id.String(),
+			Remaining:       60000,
+			MonthlyRequired: 10000,
+			MonthlyPace:     12000,
+			ExpectedDate:    timestamppb.New(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+			Status:          models.GoalOnTrack,
+		}, nil)
+
+	mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+	req := httptest.NewRequest("GET", "/api/goal/"+id.String()+"/projection", nil)
+	req = mux.SetURLVars(req, map[string]string{goalID: id.String()})
+	ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+	req = req.WithContext(ctx)
+
+	recorder := httptest.NewRecorder()
+
+	mockHandler.GetProjection(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `{"status":200,"body":{"goal_id":"`+id.String()+`","remaining":60000,"monthly_required":10000,"monthly_pace":12000,"expected_date":"2024-05-01T00:00:00Z","status":"on_track"}}`,
+		strings.TrimSpace(recorder.Body.String()))
+}
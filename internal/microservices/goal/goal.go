@@ -2,25 +2,45 @@ package goal
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
 )
 
-type Useace interface {
-	CreateGoal(ctx context.Context, goal GoalCreateRequest) (uuid.UUID, error)
-	UpdateGoal(ctx context.Context, goal *models.Goal) error
-	DeleteGoal(ctx context.Context, goalId uuid.UUID, userId uuid.UUID) error
-	GetGoals(ctx context.Context, userId uuid.UUID) ([]models.Goal, error)
+type Usecase interface {
+	CreateGoal(ctx context.Context, userID uuid.UUID, goal *models.Goal) (uuid.UUID, error)
+	UpdateGoal(ctx context.Context, userID uuid.UUID, goal *models.Goal) error
+	DeleteGoal(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) error
+	GetGoals(ctx context.Context, userID uuid.UUID) ([]models.Goal, error)
+
+	CheckGoalsState(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) // goals whose accounts reached the target
+	GetProjection(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) (*models.GoalProjection, error)
+
+	CreateRule(ctx context.Context, userID uuid.UUID, rule *models.GoalRule) (uuid.UUID, error)
+	GetRules(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) ([]models.GoalRule, error)
+	DeleteRule(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID) error
 
-	CheckGoalsState(ctx context.Context, userId uuid.UUID) ([]models.Goal, error) // check if any goals are completed
+	Contribute(ctx context.Context) error
 }
 
 type Repository interface {
-	CreateGoal(ctx context.Context, goal models.Goal) (uuid.UUID, error)
+	CreateGoal(ctx context.Context, goal *models.Goal) (uuid.UUID, error)
 	UpdateGoal(ctx context.Context, goal *models.Goal) error
-	DeleteGoal(ctx context.Context, goalId uuid.UUID) error
-	GetGoals(ctx context.Context, userId uuid.UUID) ([]models.Goal, error)
+	DeleteGoal(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) error
+	GetGoal(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) (*models.Goal, error)
+	GetGoals(ctx context.Context, userID uuid.UUID) ([]models.Goal, error)
+	IsAccumulationAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (bool, error)
+
+	CheckGoalsState(ctx context.Context, userID uuid.UUID) ([]models.Goal, error)
+	GetContributed(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.GoalContributed, error)
+
+	GetAccountRole(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (models.AccountRole, error)
+	CreateRule(ctx context.Context, rule *models.GoalRule) (uuid.UUID, error)
+	GetRules(ctx context.Context, goalID uuid.UUID) ([]models.GoalRule, error)
+	DeleteRule(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID) error
 
-	CheckGoalsState(ctx context.Context, userId uuid.UUID) ([]models.Goal, error) // check if any goals are completed
+	GetDueRules(ctx context.Context, date time.Time) ([]models.GoalRule, error)
+	GetRuleTriggers(ctx context.Context) ([]models.GoalRuleTrigger, error)
+	Contribute(ctx context.Context, rule *models.GoalRule, contribution *models.GoalContribution) (*models.Overdraft, error)
 }
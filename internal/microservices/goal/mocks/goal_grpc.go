@@ -0,0 +1,423 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery/grpc/generated/goal_grpc.pb.go
+
+// Package mock_goal is a generated GoMock package.
+package mock_goal
+
+import (
+	context "context"
+	reflect "reflect"
+
+	generated "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/delivery/grpc/generated"
+	gomock "github.com/golang/mock/gomock"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+)
+
+// MockGoalServiceClient is a mock of GoalServiceClient interface.
+type MockGoalServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalServiceClientMockRecorder
+}
+
+// MockGoalServiceClientMockRecorder is the mock recorder for MockGoalServiceClient.
+type MockGoalServiceClientMockRecorder struct {
+	mock *MockGoalServiceClient
+}
+
+// NewMockGoalServiceClient creates a new mock instance.
+func NewMockGoalServiceClient(ctrl *gomock.Controller) *MockGoalServiceClient {
+	mock := &MockGoalServiceClient{ctrl: ctrl}
+	mock.recorder = &MockGoalServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoalServiceClient) EXPECT() *MockGoalServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CheckGoalsState mocks base method.
+func (m *MockGoalServiceClient) CheckGoalsState(ctx context.Context, in *generated.UserIdRequest, opts ...grpc.CallOption) (*generated.GoalsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckGoalsState", varargs...)
+	ret0, _ := ret[0].(*generated.GoalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGoalsState indicates an expected call of CheckGoalsState.
+func (mr *MockGoalServiceClientMockRecorder) CheckGoalsState(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGoalsState", reflect.TypeOf((*MockGoalServiceClient)(nil).CheckGoalsState), varargs...)
+}
+
+// CreateGoal mocks base method.
+func (m *MockGoalServiceClient) CreateGoal(ctx context.Context, in *generated.Goal, opts ...grpc.CallOption) (*generated.CreateGoalResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateGoal", varargs...)
+	ret0, _ := ret[0].(*generated.CreateGoalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockGoalServiceClientMockRecorder) CreateGoal(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockGoalServiceClient)(nil).CreateGoal), varargs...)
+}
+
+// CreateRule mocks base method.
+func (m *MockGoalServiceClient) CreateRule(ctx context.Context, in *generated.GoalRule, opts ...grpc.CallOption) (*generated.CreateRuleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateRule", varargs...)
+	ret0, _ := ret[0].(*generated.CreateRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockGoalServiceClientMockRecorder) CreateRule(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockGoalServiceClient)(nil).CreateRule), varargs...)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockGoalServiceClient) DeleteGoal(ctx context.Context, in *generated.DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteGoal", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockGoalServiceClientMockRecorder) DeleteGoal(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockGoalServiceClient)(nil).DeleteGoal), varargs...)
+}
+
+// DeleteRule mocks base method.
+func (m *MockGoalServiceClient) DeleteRule(ctx context.Context, in *generated.RuleIdRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRule", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockGoalServiceClientMockRecorder) DeleteRule(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockGoalServiceClient)(nil).DeleteRule), varargs...)
+}
+
+// GetGoals mocks base method.
+func (m *MockGoalServiceClient) GetGoals(ctx context.Context, in *generated.UserIdRequest, opts ...grpc.CallOption) (*generated.GoalsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGoals", varargs...)
+	ret0, _ := ret[0].(*generated.GoalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *MockGoalServiceClientMockRecorder) GetGoals(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*MockGoalServiceClient)(nil).GetGoals), varargs...)
+}
+
+// GetProjection mocks base method.
+func (m *MockGoalServiceClient) GetProjection(ctx context.Context, in *generated.GoalIdRequest, opts ...grpc.CallOption) (*generated.GoalProjection, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetProjection", varargs...)
+	ret0, _ := ret[0].(*generated.GoalProjection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjection indicates an expected call of GetProjection.
+func (mr *MockGoalServiceClientMockRecorder) GetProjection(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjection", reflect.TypeOf((*MockGoalServiceClient)(nil).GetProjection), varargs...)
+}
+
+// GetRules mocks base method.
+func (m *MockGoalServiceClient) GetRules(ctx context.Context, in *generated.GoalIdRequest, opts ...grpc.CallOption) (*generated.RulesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRules", varargs...)
+	ret0, _ := ret[0].(*generated.RulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockGoalServiceClientMockRecorder) GetRules(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockGoalServiceClient)(nil).GetRules), varargs...)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockGoalServiceClient) UpdateGoal(ctx context.Context, in *generated.Goal, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateGoal", varargs...)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockGoalServiceClientMockRecorder) UpdateGoal(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockGoalServiceClient)(nil).UpdateGoal), varargs...)
+}
+
+// MockGoalServiceServer is a mock of GoalServiceServer interface.
+type MockGoalServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalServiceServerMockRecorder
+}
+
+// MockGoalServiceServerMockRecorder is the mock recorder for MockGoalServiceServer.
+type MockGoalServiceServerMockRecorder struct {
+	mock *MockGoalServiceServer
+}
+
+// NewMockGoalServiceServer creates a new mock instance.
+func NewMockGoalServiceServer(ctrl *gomock.Controller) *MockGoalServiceServer {
+	mock := &MockGoalServiceServer{ctrl: ctrl}
+	mock.recorder = &MockGoalServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoalServiceServer) EXPECT() *MockGoalServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CheckGoalsState mocks base method.
+func (m *MockGoalServiceServer) CheckGoalsState(arg0 context.Context, arg1 *generated.UserIdRequest) (*generated.GoalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckGoalsState", arg0, arg1)
+	ret0, _ := ret[0].(*generated.GoalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGoalsState indicates an expected call of CheckGoalsState.
+func (mr *MockGoalServiceServerMockRecorder) CheckGoalsState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGoalsState", reflect.TypeOf((*MockGoalServiceServer)(nil).CheckGoalsState), arg0, arg1)
+}
+
+// CreateGoal mocks base method.
+func (m *MockGoalServiceServer) CreateGoal(arg0 context.Context, arg1 *generated.Goal) (*generated.CreateGoalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", arg0, arg1)
+	ret0, _ := ret[0].(*generated.CreateGoalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockGoalServiceServerMockRecorder) CreateGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockGoalServiceServer)(nil).CreateGoal), arg0, arg1)
+}
+
+// CreateRule mocks base method.
+func (m *MockGoalServiceServer) CreateRule(arg0 context.Context, arg1 *generated.GoalRule) (*generated.CreateRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", arg0, arg1)
+	ret0, _ := ret[0].(*generated.CreateRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockGoalServiceServerMockRecorder) CreateRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockGoalServiceServer)(nil).CreateRule), arg0, arg1)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockGoalServiceServer) DeleteGoal(arg0 context.Context, arg1 *generated.DeleteRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockGoalServiceServerMockRecorder) DeleteGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockGoalServiceServer)(nil).DeleteGoal), arg0, arg1)
+}
+
+// DeleteRule mocks base method.
+func (m *MockGoalServiceServer) DeleteRule(arg0 context.Context, arg1 *generated.RuleIdRequest) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockGoalServiceServerMockRecorder) DeleteRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockGoalServiceServer)(nil).DeleteRule), arg0, arg1)
+}
+
+// GetGoals mocks base method.
+func (m *MockGoalServiceServer) GetGoals(arg0 context.Context, arg1 *generated.UserIdRequest) (*generated.GoalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoals", arg0, arg1)
+	ret0, _ := ret[0].(*generated.GoalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *MockGoalServiceServerMockRecorder) GetGoals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*MockGoalServiceServer)(nil).GetGoals), arg0, arg1)
+}
+
+// GetProjection mocks base method.
+func (m *MockGoalServiceServer) GetProjection(arg0 context.Context, arg1 *generated.GoalIdRequest) (*generated.GoalProjection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjection", arg0, arg1)
+	ret0, _ := ret[0].(*generated.GoalProjection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjection indicates an expected call of GetProjection.
+func (mr *MockGoalServiceServerMockRecorder) GetProjection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjection", reflect.TypeOf((*MockGoalServiceServer)(nil).GetProjection), arg0, arg1)
+}
+
+// GetRules mocks base method.
+func (m *MockGoalServiceServer) GetRules(arg0 context.Context, arg1 *generated.GoalIdRequest) (*generated.RulesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", arg0, arg1)
+	ret0, _ := ret[0].(*generated.RulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockGoalServiceServerMockRecorder) GetRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockGoalServiceServer)(nil).GetRules), arg0, arg1)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockGoalServiceServer) UpdateGoal(arg0 context.Context, arg1 *generated.Goal) (*empty.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoal", arg0, arg1)
+	ret0, _ := ret[0].(*empty.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockGoalServiceServerMockRecorder) UpdateGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockGoalServiceServer)(nil).UpdateGoal), arg0, arg1)
+}
+
+// mustEmbedUnimplementedGoalServiceServer mocks base method.
+func (m *MockGoalServiceServer) mustEmbedUnimplementedGoalServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedGoalServiceServer")
+}
+
+// mustEmbedUnimplementedGoalServiceServer indicates an expected call of mustEmbedUnimplementedGoalServiceServer.
+func (mr *MockGoalServiceServerMockRecorder) mustEmbedUnimplementedGoalServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedGoalServiceServer", reflect.TypeOf((*MockGoalServiceServer)(nil).mustEmbedUnimplementedGoalServiceServer))
+}
+
+// MockUnsafeGoalServiceServer is a mock of UnsafeGoalServiceServer interface.
+type MockUnsafeGoalServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeGoalServiceServerMockRecorder
+}
+
+// MockUnsafeGoalServiceServerMockRecorder is the mock recorder for MockUnsafeGoalServiceServer.
+type MockUnsafeGoalServiceServerMockRecorder struct {
+	mock *MockUnsafeGoalServiceServer
+}
+
+// NewMockUnsafeGoalServiceServer creates a new mock instance.
+func NewMockUnsafeGoalServiceServer(ctrl *gomock.Controller) *MockUnsafeGoalServiceServer {
+	mock := &MockUnsafeGoalServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeGoalServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeGoalServiceServer) EXPECT() *MockUnsafeGoalServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedGoalServiceServer mocks base method.
+func (m *MockUnsafeGoalServiceServer) mustEmbedUnimplementedGoalServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedGoalServiceServer")
+}
+
+// mustEmbedUnimplementedGoalServiceServer indicates an expected call of mustEmbedUnimplementedGoalServiceServer.
+func (mr *MockUnsafeGoalServiceServerMockRecorder) mustEmbedUnimplementedGoalServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedGoalServiceServer", reflect.TypeOf((*MockUnsafeGoalServiceServer)(nil).mustEmbedUnimplementedGoalServiceServer))
+}
@@ -0,0 +1,429 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: goal.go
+
+// Package mock_goal is a generated GoMock package.
+package mock_goal
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CheckGoalsState mocks base method.
+func (m *MockUsecase) CheckGoalsState(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckGoalsState", ctx, userID)
+	ret0, _ := ret[0].([]models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGoalsState indicates an expected call of CheckGoalsState.
+func (mr *MockUsecaseMockRecorder) CheckGoalsState(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGoalsState", reflect.TypeOf((*MockUsecase)(nil).CheckGoalsState), ctx, userID)
+}
+
+// Contribute mocks base method.
+func (m *MockUsecase) Contribute(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contribute", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Contribute indicates an expected call of Contribute.
+func (mr *MockUsecaseMockRecorder) Contribute(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contribute", reflect.TypeOf((*MockUsecase)(nil).Contribute), ctx)
+}
+
+// CreateGoal mocks base method.
+func (m *MockUsecase) CreateGoal(ctx context.Context, userID uuid.UUID, goal *models.Goal) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", ctx, userID, goal)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockUsecaseMockRecorder) CreateGoal(ctx, userID, goal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockUsecase)(nil).CreateGoal), ctx, userID, goal)
+}
+
+// CreateRule mocks base method.
+func (m *MockUsecase) CreateRule(ctx context.Context, userID uuid.UUID, rule *models.GoalRule) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, userID, rule)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockUsecaseMockRecorder) CreateRule(ctx, userID, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockUsecase)(nil).CreateRule), ctx, userID, rule)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockUsecase) DeleteGoal(ctx context.Context, userID, goalID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", ctx, userID, goalID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockUsecaseMockRecorder) DeleteGoal(ctx, userID, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockUsecase)(nil).DeleteGoal), ctx, userID, goalID)
+}
+
+// DeleteRule mocks base method.
+func (m *MockUsecase) DeleteRule(ctx context.Context, userID, ruleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, userID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockUsecaseMockRecorder) DeleteRule(ctx, userID, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockUsecase)(nil).DeleteRule), ctx, userID, ruleID)
+}
+
+// GetGoals mocks base method.
+func (m *MockUsecase) GetGoals(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoals", ctx, userID)
+	ret0, _ := ret[0].([]models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *MockUsecaseMockRecorder) GetGoals(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*MockUsecase)(nil).GetGoals), ctx, userID)
+}
+
+// GetProjection mocks base method.
+func (m *MockUsecase) GetProjection(ctx context.Context, userID, goalID uuid.UUID) (*models.GoalProjection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjection", ctx, userID, goalID)
+	ret0, _ := ret[0].(*models.GoalProjection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjection indicates an expected call of GetProjection.
+func (mr *MockUsecaseMockRecorder) GetProjection(ctx, userID, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjection", reflect.TypeOf((*MockUsecase)(nil).GetProjection), ctx, userID, goalID)
+}
+
+// GetRules mocks base method.
+func (m *MockUsecase) GetRules(ctx context.Context, userID, goalID uuid.UUID) ([]models.GoalRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, userID, goalID)
+	ret0, _ := ret[0].([]models.GoalRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockUsecaseMockRecorder) GetRules(ctx, userID, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockUsecase)(nil).GetRules), ctx, userID, goalID)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockUsecase) UpdateGoal(ctx context.Context, userID uuid.UUID, goal *models.Goal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoal", ctx, userID, goal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockUsecaseMockRecorder) UpdateGoal(ctx, userID, goal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockUsecase)(nil).UpdateGoal), ctx, userID, goal)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckGoalsState mocks base method.
+func (m *MockRepository) CheckGoalsState(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckGoalsState", ctx, userID)
+	ret0, _ := ret[0].([]models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGoalsState indicates an expected call of CheckGoalsState.
+func (mr *MockRepositoryMockRecorder) CheckGoalsState(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGoalsState", reflect.TypeOf((*MockRepository)(nil).CheckGoalsState), ctx, userID)
+}
+
+// Contribute mocks base method.
+func (m *MockRepository) Contribute(ctx context.Context, rule *models.GoalRule, contribution *models.GoalContribution) (*models.Overdraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contribute", ctx, rule, contribution)
+	ret0, _ := ret[0].(*models.Overdraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Contribute indicates an expected call of Contribute.
+func (mr *MockRepositoryMockRecorder) Contribute(ctx, rule, contribution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contribute", reflect.TypeOf((*MockRepository)(nil).Contribute), ctx, rule, contribution)
+}
+
+// CreateGoal mocks base method.
+func (m *MockRepository) CreateGoal(ctx context.Context, goal *models.Goal) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", ctx, goal)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockRepositoryMockRecorder) CreateGoal(ctx, goal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockRepository)(nil).CreateGoal), ctx, goal)
+}
+
+// CreateRule mocks base method.
+func (m *MockRepository) CreateRule(ctx context.Context, rule *models.GoalRule) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, rule)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRepositoryMockRecorder) CreateRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRepository)(nil).CreateRule), ctx, rule)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockRepository) DeleteGoal(ctx context.Context, userID, goalID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", ctx, userID, goalID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockRepositoryMockRecorder) DeleteGoal(ctx, userID, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockRepository)(nil).DeleteGoal), ctx, userID, goalID)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRepository) DeleteRule(ctx context.Context, userID, ruleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, userID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRepositoryMockRecorder) DeleteRule(ctx, userID, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRepository)(nil).DeleteRule), ctx, userID, ruleID)
+}
+
+// GetAccountRole mocks base method.
+func (m *MockRepository) GetAccountRole(ctx context.Context, userID, accountID uuid.UUID) (models.AccountRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountRole", ctx, userID, accountID)
+	ret0, _ := ret[0].(models.AccountRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountRole indicates an expected call of GetAccountRole.
+func (mr *MockRepositoryMockRecorder) GetAccountRole(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountRole", reflect.TypeOf((*MockRepository)(nil).GetAccountRole), ctx, userID, accountID)
+}
+
+// GetContributed mocks base method.
+func (m *MockRepository) GetContributed(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.GoalContributed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributed", ctx, userID, since)
+	ret0, _ := ret[0].([]models.GoalContributed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContributed indicates an expected call of GetContributed.
+func (mr *MockRepositoryMockRecorder) GetContributed(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributed", reflect.TypeOf((*MockRepository)(nil).GetContributed), ctx, userID, since)
+}
+
+// GetDueRules mocks base method.
+func (m *MockRepository) GetDueRules(ctx context.Context, date time.Time) ([]models.GoalRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRules", ctx, date)
+	ret0, _ := ret[0].([]models.GoalRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRules indicates an expected call of GetDueRules.
+func (mr *MockRepositoryMockRecorder) GetDueRules(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRules", reflect.TypeOf((*MockRepository)(nil).GetDueRules), ctx, date)
+}
+
+// GetGoal mocks base method.
+func (m *MockRepository) GetGoal(ctx context.Context, userID, goalID uuid.UUID) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoal", ctx, userID, goalID)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoal indicates an expected call of GetGoal.
+func (mr *MockRepositoryMockRecorder) GetGoal(ctx, userID, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoal", reflect.TypeOf((*MockRepository)(nil).GetGoal), ctx, userID, goalID)
+}
+
+// GetGoals mocks base method.
+func (m *MockRepository) GetGoals(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoals", ctx, userID)
+	ret0, _ := ret[0].([]models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *MockRepositoryMockRecorder) GetGoals(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*MockRepository)(nil).GetGoals), ctx, userID)
+}
+
+// GetRuleTriggers mocks base method.
+func (m *MockRepository) GetRuleTriggers(ctx context.Context) ([]models.GoalRuleTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleTriggers", ctx)
+	ret0, _ := ret[0].([]models.GoalRuleTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleTriggers indicates an expected call of GetRuleTriggers.
+func (mr *MockRepositoryMockRecorder) GetRuleTriggers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleTriggers", reflect.TypeOf((*MockRepository)(nil).GetRuleTriggers), ctx)
+}
+
+// GetRules mocks base method.
+func (m *MockRepository) GetRules(ctx context.Context, goalID uuid.UUID) ([]models.GoalRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, goalID)
+	ret0, _ := ret[0].([]models.GoalRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockRepositoryMockRecorder) GetRules(ctx, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockRepository)(nil).GetRules), ctx, goalID)
+}
+
+// IsAccumulationAccount mocks base method.
+func (m *MockRepository) IsAccumulationAccount(ctx context.Context, userID, accountID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccumulationAccount", ctx, userID, accountID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccumulationAccount indicates an expected call of IsAccumulationAccount.
+func (mr *MockRepositoryMockRecorder) IsAccumulationAccount(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccumulationAccount", reflect.TypeOf((*MockRepository)(nil).IsAccumulationAccount), ctx, userID, accountID)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockRepository) UpdateGoal(ctx context.Context, goal *models.Goal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoal", ctx, goal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockRepositoryMockRecorder) UpdateGoal(ctx, goal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockRepository)(nil).UpdateGoal), ctx, goal)
+}
@@ -2,28 +2,122 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
-	GoalGet = `SELECT user_id, "name", "description", target, "date" FROM goal WHERE id=$1;`
+	GoalCreate = `INSERT INTO Goal (user_id, "name", "description", target, "date")
+				  VALUES ($1, $2, $3, $4, $5)
+				  RETURNING id;`
 
-	GoalCreate = `INSERT INTO goal (user_id, "name", "description", target, "date")
-				      VALUES ($1, $2, $3, $4, $5)
-				      RETURNING id;`
+	GoalUpdate = `UPDATE Goal SET "name" = $1, "description" = $2, target = $3, "date" = $4 WHERE id = $5 AND user_id = $6;`
 
-	GoalUpdate = `UPDATE goal SET "name"=$1, "description"=$2, target=$3, "date"=$4 WHERE id=$5;`
+	GoalDelete = `DELETE FROM Goal WHERE id = $1 AND user_id = $2;`
 
-	GoalDelete = "DELETE FROM goal WHERE id = $1;"
+	// the rules of the unlinked accounts are deleted with them
+	GoalAccountsDelete = `DELETE FROM GoalAccount WHERE goal_id = $1 AND account_id <> ALL($2::uuid[]);`
 
-	GoalAll = `SELECT * FROM goal WHERE user_id = $1;`
+	GoalAccountsCreate = `INSERT INTO GoalAccount (goal_id, account_id) SELECT $1, unnest($2::uuid[])
+						  ON CONFLICT DO NOTHING;`
 
-	GoalAllDone = `SELECT * FROM goal WHERE user_id = $1 AND WHERE state='done';`
+	GoalAccountAccumulation = `SELECT COALESCE(a.accumulation, false)
+							   FROM Accounts a
+							   JOIN UserAccount ua ON ua.account_id = a.id
+							   WHERE ua.user_id = $1 AND a.id = $2;`
+
+	GoalAccountRole = `SELECT role FROM UserAccount WHERE user_id = $1 AND account_id = $2;`
+
+	// the progress of a goal is the balance of its accounts
+	goalSelect = `SELECT g.id, g.user_id, g."name", g."description", g.target, g."date",
+						COALESCE(array_agg(a.id ORDER BY a.id) FILTER (WHERE a.id IS NOT NULL), '{}'),
+						COALESCE(SUM(a.balance), 0)
+				  FROM Goal g
+				  LEFT JOIN GoalAccount ga ON ga.goal_id = g.id
+				  LEFT JOIN Accounts a ON a.id = ga.account_id
+				  WHERE g.user_id = $1`
+
+	GoalGet = goalSelect + ` AND g.id = $2
+				  GROUP BY g.id;`
+
+	GoalAll = goalSelect + `
+				  GROUP BY g.id
+				  ORDER BY g."date", g."name";`
+
+	GoalAllDone = goalSelect + `
+				  GROUP BY g.id
+				  HAVING COALESCE(SUM(a.balance), 0) >= g.target
+				  ORDER BY g."date", g."name";`
+
+	// contributions are the transfers to the goal accounts by the rules or by hand minus the transfers out,
+	// a transfer between two accounts of the goal counts for both and adds nothing
+	GoalContributed = `SELECT g.id, GREATEST(g.created_at, $2),
+							COALESCE(SUM(CASE WHEN t.account_income = ga.account_id THEN t.income ELSE -t.outcome END), 0)
+					   FROM Goal g
+					   LEFT JOIN GoalAccount ga ON ga.goal_id = g.id
+					   LEFT JOIN Transaction t ON (t.account_income = ga.account_id OR t.account_outcome = ga.account_id)
+							AND t.account_income <> t.account_outcome AND t.date >= GREATEST(g.created_at, $2)
+					   WHERE g.user_id = $1
+					   GROUP BY g.id;`
+
+	GoalRuleCreate = `INSERT INTO GoalRule (goal_id, kind, source_id, account_id, amount, period, date_start, next_date)
+					  VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $7)
+					  RETURNING id;`
+
+	GoalRuleDelete = `DELETE FROM GoalRule r USING Goal g WHERE r.id = $1 AND g.id = r.goal_id AND g.user_id = $2;`
+
+	ruleSelect = `SELECT r.id, r.goal_id, g.user_id, r.kind, r.source_id, r.account_id, r.amount,
+						COALESCE(r.period, ''), r.date_start, r.next_date`
+
+	ruleFrom = `
+				  FROM GoalRule r
+				  JOIN Goal g ON g.id = r.goal_id`
+
+	// the rules of archived accounts wait for the restore
+	ruleActive = `
+				  JOIN Accounts s ON s.id = r.source_id AND s.archived_at IS NULL
+				  JOIN Accounts a ON a.id = r.account_id AND a.archived_at IS NULL`
+
+	GoalRuleAll = ruleSelect + ruleFrom + `
+				  WHERE r.goal_id = $1
+				  ORDER BY r.created_at;`
+
+	GoalRuleDue = ruleSelect + ruleFrom + ruleActive + `
+				  WHERE r.kind = 'schedule' AND r.next_date <= $1;`
+
+	// salary incomes and not round outcomes of the source account made after the rule,
+	// transfers have different accounts so the contributions themselves are not counted
+	GoalRuleTriggers = ruleSelect + `, t.id, COALESCE(t.income, 0), COALESCE(t.outcome, 0)` + ruleFrom + ruleActive + `
+				  JOIN Transaction t ON t.account_income = r.source_id AND t.account_outcome = r.source_id AND t.date >= r.created_at
+				  WHERE (
+						(r.kind = 'income' AND t.income > 0 AND EXISTS (
+							SELECT 1 FROM TransactionCategory tc
+							JOIN category c ON c.id = tc.category_id
+							WHERE tc.transaction_id = t.id AND c."name" = $1))
+						OR (r.kind = 'round_up' AND t.outcome > 0 AND mod(t.outcome, r.amount) <> 0)
+				  )
+				  AND NOT EXISTS (
+						SELECT 1 FROM GoalContribution gc WHERE gc.rule_id = r.id AND gc.source_transaction_id = t.id)
+				  ORDER BY t.date;`
+
+	GoalTransferCreate = `INSERT INTO Transaction (user_id, account_income, account_outcome, income, outcome, date, payer, description)
+						  VALUES ($1, $2, $3, $4, $4, $5, '', $6)
+						  RETURNING id;`
+
+	GoalContributionCreate = `INSERT INTO GoalContribution (goal_id, rule_id, source_transaction_id, transaction_id, amount, "date")
+							  VALUES ($1, $2, $3, $4, $5, $6);`
+
+	GoalRuleAdvance = `UPDATE GoalRule SET next_date = $2 WHERE id = $1;`
+
+	goalTransferDescription = "Пополнение цели"
 )
 
 type Repository struct {
@@ -38,114 +132,395 @@ func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
 	}
 }
 
-func (r *Repository) CreateGoal(ctx context.Context, goal models.Goal) (uuid.UUID, error) {
-	// row := r.db.QueryRow(ctx, GoalCreate,
-	// 	goal.UserId,
-	// 	goal.Name,
-	// 	goal.Description,
-	// 	goal.Target,
-	// 	goal.Date,
-	// )
+func (r *Repository) CreateGoal(ctx context.Context, goal *models.Goal) (uuid.UUID, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.log.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
 
-	// var id uuid.UUID
-	// if err := row.Scan(&id); err != nil {
-	// 	return uuid.Nil, err
-	// }
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, GoalCreate,
+		goal.UserId,
+		goal.Name,
+		goal.Description,
+		goal.Target,
+		goal.Date,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create goal: %w", err)
+	}
 
-	//return id, nil
-	return uuid.Nil, nil
+	if _, err = tx.Exec(ctx, GoalAccountsCreate, id, goal.Accounts); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to link goal accounts: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+	return id, nil
 }
 
+// UpdateGoal replaces the goal and its accounts, NoSuchGoalError if the goal is of another user;
+// the accounts that stay linked keep their rules
 func (r *Repository) UpdateGoal(ctx context.Context, goal *models.Goal) error {
-	// _, err := r.db.Exec(ctx, GoalUpdate,
-	// 	goal.Name,
-	// 	goal.Description,
-	// 	goal.Target,
-	// 	goal.Date,
-	// 	goal.ID,
-	// )
-	// if err != nil {
-	// 	return fmt.Errorf("[repo] UpdateGoal: %w", err)
-	// }
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.log.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
 
+	tag, err := tx.Exec(ctx, GoalUpdate,
+		goal.Name,
+		goal.Description,
+		goal.Target,
+		goal.Date,
+		goal.ID,
+		goal.UserId,
+	)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to update goal: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = &models.NoSuchGoalError{GoalID: goal.ID}
+		return fmt.Errorf("[repo] %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, GoalAccountsDelete, goal.ID, goal.Accounts); err != nil {
+		return fmt.Errorf("[repo] failed to unlink goal accounts: %w", err)
+	}
+	if _, err = tx.Exec(ctx, GoalAccountsCreate, goal.ID, goal.Accounts); err != nil {
+		return fmt.Errorf("[repo] failed to link goal accounts: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
-func (r *Repository) DeleteGoal(ctx context.Context, goalId uuid.UUID) error {
-	_, err := r.db.Exec(ctx, GoalDelete, goalId)
+func (r *Repository) DeleteGoal(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, GoalDelete, goalID, userID)
 	if err != nil {
-		return fmt.Errorf("[repo] DeleteGoal: %w", err)
+		return fmt.Errorf("[repo] failed to delete goal: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.NoSuchGoalError{GoalID: goalID})
 	}
+	return nil
+}
+
+func (r *Repository) GetGoal(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) (*models.Goal, error) {
+	rows, err := r.db.Query(ctx, GoalGet, userID, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	goals, err := scanGoals(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchGoalError{GoalID: goalID})
+	}
+
+	return &goals[0], nil
+}
+
+func (r *Repository) GetGoals(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	return r.queryGoals(ctx, GoalAll, userID)
+}
+
+func (r *Repository) CheckGoalsState(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	return r.queryGoals(ctx, GoalAllDone, userID)
+}
+
+// GetContributed returns the contributions of every goal of the user since the date or the goal creation
+func (r *Repository) GetContributed(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.GoalContributed, error) {
+	rows, err := r.db.Query(ctx, GoalContributed, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	contributed := []models.GoalContributed{}
+	for rows.Next() {
+		var goal models.GoalContributed
+		if err := rows.Scan(&goal.GoalID, &goal.Since, &goal.Amount); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		contributed = append(contributed, goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return contributed, nil
+}
+
+// IsAccumulationAccount returns ForbiddenUserError if the user is not a member of the account
+func (r *Repository) IsAccumulationAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (bool, error) {
+	var accumulation bool
+
+	err := r.db.QueryRow(ctx, GoalAccountAccumulation, userID, accountID).Scan(&accumulation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("[repo] account of another user: %w", &models.ForbiddenUserError{})
+	}
+	if err != nil {
+		return false, fmt.Errorf("[repo] %w", err)
+	}
+
+	return accumulation, nil
+}
+
+func (r *Repository) queryGoals(ctx context.Context, query string, userID uuid.UUID) ([]models.Goal, error) {
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	return scanGoals(rows)
+}
 
+func scanGoals(rows pgx.Rows) ([]models.Goal, error) {
+	goals := []models.Goal{}
+	for rows.Next() {
+		var goal models.Goal
+		if err := rows.Scan(
+			&goal.ID,
+			&goal.UserId,
+			&goal.Name,
+			&goal.Description,
+			&goal.Target,
+			&goal.Date,
+			&goal.Accounts,
+			&goal.Progress,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		goal.Completed = goal.Progress >= goal.Target
+		goals = append(goals, goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return goals, nil
+}
+
+// GetAccountRole returns ForbiddenUserError if the user is not a member of the account
+func (r *Repository) GetAccountRole(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) (models.AccountRole, error) {
+	var role models.AccountRole
+
+	err := r.db.QueryRow(ctx, GoalAccountRole, userID, accountID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("[repo] account of another user: %w", &models.ForbiddenUserError{})
+	}
+	if err != nil {
+		return "", fmt.Errorf("[repo] %w", err)
+	}
+
+	return role, nil
+}
+
+func (r *Repository) CreateRule(ctx context.Context, rule *models.GoalRule) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	err := r.db.QueryRow(ctx, GoalRuleCreate,
+		rule.GoalID,
+		rule.Kind,
+		rule.SourceID,
+		rule.AccountID,
+		rule.Amount,
+		rule.Period,
+		rule.DateStart,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create goal rule: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetRules(ctx context.Context, goalID uuid.UUID) ([]models.GoalRule, error) {
+	rows, err := r.db.Query(ctx, GoalRuleAll, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	return scanRules(rows)
+}
+
+// DeleteRule keeps the contributions made by the rule, NoSuchGoalRuleError if the rule is of another user
+func (r *Repository) DeleteRule(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, GoalRuleDelete, ruleID, userID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to delete goal rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.NoSuchGoalRuleError{RuleID: ruleID})
+	}
 	return nil
 }
 
-func (r *Repository) GetGoals(ctx context.Context, userId uuid.UUID) ([]models.Goal, error) {
-	//var goals []models.Goal
-
-	// rows, err := r.db.Query(ctx, GoalAll, userId)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("[repo] Error no tags found: %v", err)
-	// }
-
-	// for rows.Next() {
-	// 	var goal models.Goal
-	// 	if err := rows.Scan(
-	// 		&goal.ID,
-	// 		&goal.UserId,
-	// 		&goal.Name,
-	// 		&goal.Description,
-	// 		&goal.Target,
-	// 		&goal.Date,
-	// 	 ); err != nil {
-	// 		return nil, err
-	// 	}
-
-	// 	goals = append(goals, goal)
-	// }
-
-	// if err := rows.Err(); err != nil {
-	// 	return nil, fmt.Errorf("[repo] Error rows error: %v", err)
-	// }
-
-	// if len(goals) == 0 {
-	// 	return nil, fmt.Errorf("[repo] Error no tags found: %v", err)
-	// }
-	//return goals, nil
-	return nil, nil
-}
-
-func (r *Repository) CheckGoalsState(ctx context.Context, userId uuid.UUID) ([]models.Goal, error) {
-	// var goals []models.Goal
-
-	// rows, err := r.db.Query(ctx, GoalAllDone, userId)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("[repo] Error no tags found: %v", err)
-	// }
-
-	// for rows.Next() {
-	// 	var goal models.Goal
-	// 	if err := rows.Scan(
-	// 		&goal.ID,
-	// 		&goal.UserId,
-	// 		&goal.Name,
-	// 		&goal.Description,
-	// 		&goal.Target,
-	// 		&goal.Date,
-	// 	); err != nil {
-	// 		return nil, err
-	// 	}
-
-	// 	goals = append(goals, goal)
-	// }
-
-	// if err := rows.Err(); err != nil {
-	// 	return nil, fmt.Errorf("[repo] Error rows error: %v", err)
-	// }
-
-	// if len(goals) == 0 {
-	// 	return nil, fmt.Errorf("[repo] Error no tags found: %v", err)
-	// }
-	// return goals, nil
-	return nil, nil
+// GetDueRules returns the scheduled rules with a transfer on the date or before it
+func (r *Repository) GetDueRules(ctx context.Context, date time.Time) ([]models.GoalRule, error) {
+	rows, err := r.db.Query(ctx, GoalRuleDue, date)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	return scanRules(rows)
+}
+
+// GetRuleTriggers returns the incomes and outcomes the income and round-up rules have not handled yet
+func (r *Repository) GetRuleTriggers(ctx context.Context) ([]models.GoalRuleTrigger, error) {
+	rows, err := r.db.Query(ctx, GoalRuleTriggers, models.GoalIncomeCategory)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	triggers := []models.GoalRuleTrigger{}
+	for rows.Next() {
+		var trigger models.GoalRuleTrigger
+		fields := append(ruleFields(&trigger.Rule), &trigger.TransactionID, &trigger.Income, &trigger.Outcome)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		triggers = append(triggers, trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return triggers, nil
+}
+
+// Contribute transfers the contribution from the source to the goal account and records it,
+// the contribution is recorded without a transaction when the overdraft policy of the source rejects
+// the charge and the overdraft the warn policy let through is returned; a scheduled rule moves to its next date
+func (r *Repository) Contribute(ctx context.Context, rule *models.GoalRule, contribution *models.GoalContribution) (*models.Overdraft, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.log.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	var overdraft *models.Overdraft
+	if contribution.Amount > 0 {
+		if overdraft, err = r.transfer(ctx, tx, rule, contribution); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, GoalContributionCreate,
+		rule.GoalID,
+		rule.ID,
+		contribution.SourceTransactionID,
+		contribution.TransactionID,
+		contribution.Amount,
+		contribution.Date,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to record goal contribution: %w", err)
+	}
+
+	if rule.Kind == models.GoalRuleSchedule {
+		if _, err = tx.Exec(ctx, GoalRuleAdvance, rule.ID, rule.NextDate); err != nil {
+			return nil, fmt.Errorf("[repo] failed to move goal rule date: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+	return overdraft, nil
+}
+
+func (r *Repository) transfer(ctx context.Context, tx pgx.Tx, rule *models.GoalRule, contribution *models.GoalContribution) (*models.Overdraft, error) {
+	overdraft, err := accountRep.ChargeAccount(ctx, tx, rule.SourceID, contribution.Amount)
+	var errOverdraft *models.OverdraftError
+	if errors.As(err, &errOverdraft) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := accountRep.ChargeAccount(ctx, tx, rule.AccountID, -contribution.Amount); err != nil {
+		return nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, GoalTransferCreate,
+		rule.UserID,
+		rule.AccountID,
+		rule.SourceID,
+		contribution.Amount,
+		contribution.Date,
+		goalTransferDescription,
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] failed to create transfer transaction: %w", err)
+	}
+	contribution.TransactionID = &id
+
+	if overdraft != nil {
+		overdraft.TransactionID = id
+	}
+	return overdraft, nil
+}
+
+func scanRules(rows pgx.Rows) ([]models.GoalRule, error) {
+	rules := []models.GoalRule{}
+	for rows.Next() {
+		var rule models.GoalRule
+		if err := rows.Scan(ruleFields(&rule)...); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return rules, nil
+}
+
+func ruleFields(rule *models.GoalRule) []interface{} {
+	return []interface{}{
+		&rule.ID,
+		&rule.GoalID,
+		&rule.UserID,
+		&rule.Kind,
+		&rule.SourceID,
+		&rule.AccountID,
+		&rule.Amount,
+		&rule.Period,
+		&rule.DateStart,
+		&rule.NextDate,
+	}
 }
@@ -0,0 +1,615 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var goalRowColumns = []string{"id", "user_id", "name", "description", "target", "date", "accounts", "progress"}
+
+func testGoal() models.Goal {
+	return models.Goal{
+		ID:          uuid.New(),
+		UserId:      uuid.New(),
+		Name:        "Отпуск",
+		Description: "море",
+		Target:      150000,
+		Date:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Accounts:    []uuid.UUID{uuid.New()},
+	}
+}
+
+func Test_CreateGoal(t *testing.T) {
+	goal := testGoal()
+
+	testCases := []struct {
+		name        string
+		createError error
+		linkError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			expected: goal.ID,
+		},
+		{
+			name:        "Create error",
+			createError: errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create goal: %w", errors.New("err")),
+		},
+		{
+			name:        "Link error",
+			linkError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to link goal accounts: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			rows := pgxmock.NewRows([]string{"id"})
+			if tc.createError == nil {
+				rows.AddRow(goal.ID)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(GoalCreate)).
+				WithArgs(goal.UserId, goal.Name, goal.Description, goal.Target, goal.Date).
+				WillReturnRows(rows).
+				WillReturnError(tc.createError)
+
+			if tc.createError == nil {
+				link := mock.ExpectExec(regexp.QuoteMeta(GoalAccountsCreate)).
+					WithArgs(goal.ID, goal.Accounts)
+				if tc.linkError != nil {
+					link.WillReturnError(tc.linkError)
+				} else {
+					link.WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				}
+			}
+
+			if tc.expectedErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			id, err := repo.CreateGoal(context.Background(), &goal)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, id)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_UpdateGoal(t *testing.T) {
+	goal := testGoal()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		updateError error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:        "Goal of another user",
+			result:      pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchGoalError{GoalID: goal.ID}),
+		},
+		{
+			name:        "Update error",
+			updateError: errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to update goal: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			update := mock.ExpectExec(regexp.QuoteMeta(GoalUpdate)).
+				WithArgs(goal.Name, goal.Description, goal.Target, goal.Date, goal.ID, goal.UserId)
+			if tc.updateError != nil {
+				update.WillReturnError(tc.updateError)
+			} else {
+				update.WillReturnResult(tc.result)
+			}
+
+			if tc.expectedErr == nil {
+				mock.ExpectExec(regexp.QuoteMeta(GoalAccountsDelete)).
+					WithArgs(goal.ID, goal.Accounts).
+					WillReturnResult(pgconn.CommandTag("DELETE 2"))
+				mock.ExpectExec(regexp.QuoteMeta(GoalAccountsCreate)).
+					WithArgs(goal.ID, goal.Accounts).
+					WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := repo.UpdateGoal(context.Background(), &goal)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_DeleteGoal(t *testing.T) {
+	goal := testGoal()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("DELETE 1"),
+		},
+		{
+			name:        "Goal of another user",
+			result:      pgconn.CommandTag("DELETE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchGoalError{GoalID: goal.ID}),
+		},
+		{
+			name:        "Exec error",
+			execError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to delete goal: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(GoalDelete)).
+				WithArgs(goal.ID, goal.UserId)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := repo.DeleteGoal(context.Background(), goal.UserId, goal.ID)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetGoals(t *testing.T) {
+	goal := testGoal()
+	completed := testGoal()
+	completed.UserId = goal.UserId
+
+	testCases := []struct {
+		name        string
+		query       string
+		get         func(*Repository) ([]models.Goal, error)
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    []models.Goal
+		expectedErr error
+	}{
+		{
+			name:  "Goals with progress",
+			query: GoalAll,
+			get: func(r *Repository) ([]models.Goal, error) {
+				return r.GetGoals(context.Background(), goal.UserId)
+			},
+			rows: pgxmock.NewRows(goalRowColumns).
+				AddRow(goal.ID, goal.UserId, goal.Name, goal.Description, goal.Target, goal.Date, goal.Accounts, 42000.5).
+				AddRow(completed.ID, completed.UserId, completed.Name, completed.Description, completed.Target, completed.Date, completed.Accounts, 150000.0),
+			expected: []models.Goal{
+				func() models.Goal { g := goal; g.Progress = 42000.5; return g }(),
+				func() models.Goal { g := completed; g.Progress = 150000; g.Completed = true; return g }(),
+			},
+		},
+		{
+			name:  "Completed goals",
+			query: GoalAllDone,
+			get: func(r *Repository) ([]models.Goal, error) {
+				return r.CheckGoalsState(context.Background(), goal.UserId)
+			},
+			rows:     pgxmock.NewRows(goalRowColumns),
+			expected: []models.Goal{},
+		},
+		{
+			name:  "Query error",
+			query: GoalAll,
+			get: func(r *Repository) ([]models.Goal, error) {
+				return r.GetGoals(context.Background(), goal.UserId)
+			},
+			rows:        pgxmock.NewRows(goalRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
+				WithArgs(goal.UserId).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := tc.get(repo)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_IsAccumulationAccount(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name:     "Accumulation",
+			rows:     pgxmock.NewRows([]string{"accumulation"}).AddRow(true),
+			expected: true,
+		},
+		{
+			name:        "Account of another user",
+			rows:        pgxmock.NewRows([]string{"accumulation"}),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] account of another user: %w", &models.ForbiddenUserError{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(GoalAccountAccumulation)).
+				WithArgs(userID, accountID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := repo.IsAccumulationAccount(context.Background(), userID, accountID)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+var ruleRowColumns = []string{"id", "goal_id", "user_id", "kind", "source_id", "account_id", "amount", "period", "date_start", "next_date"}
+
+func testRule(kind string) models.GoalRule {
+	rule := models.GoalRule{
+		ID:        uuid.New(),
+		GoalID:    uuid.New(),
+		UserID:    uuid.New(),
+		Kind:      kind,
+		SourceID:  uuid.New(),
+		AccountID: uuid.New(),
+		Amount:    10,
+	}
+	if kind == models.GoalRuleSchedule {
+		start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		next := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+		rule.Amount = 5000
+		rule.Period = models.GoalPeriodMonth
+		rule.DateStart = &start
+		rule.NextDate = &next
+	}
+	return rule
+}
+
+func Test_GetGoal(t *testing.T) {
+	goal := testGoal()
+
+	mock, _ := pgxmock.NewPool()
+	repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+	mock.ExpectQuery(regexp.QuoteMeta(GoalGet)).
+		WithArgs(goal.UserId, goal.ID).
+		WillReturnRows(pgxmock.NewRows(goalRowColumns))
+
+	_, err := repo.GetGoal(context.Background(), goal.UserId, goal.ID)
+	assert.Equal(t, fmt.Errorf("[repo] %w", &models.NoSuchGoalError{GoalID: goal.ID}), err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GoalGet)).
+		WithArgs(goal.UserId, goal.ID).
+		WillReturnRows(pgxmock.NewRows(goalRowColumns).
+			AddRow(goal.ID, goal.UserId, goal.Name, goal.Description, goal.Target, goal.Date, goal.Accounts, 100.0))
+
+	result, err := repo.GetGoal(context.Background(), goal.UserId, goal.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, goal.Accounts, result.Accounts)
+	assert.Equal(t, 100.0, result.Progress)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_CreateRule(t *testing.T) {
+	rule := testRule(models.GoalRuleSchedule)
+
+	mock, _ := pgxmock.NewPool()
+	repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+	mock.ExpectQuery(regexp.QuoteMeta(GoalRuleCreate)).
+		WithArgs(rule.GoalID, rule.Kind, rule.SourceID, rule.AccountID, rule.Amount, rule.Period, rule.DateStart).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(rule.ID))
+
+	id, err := repo.CreateRule(context.Background(), &rule)
+	assert.NoError(t, err)
+	assert.Equal(t, rule.ID, id)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_DeleteRule(t *testing.T) {
+	userID := uuid.New()
+	ruleID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("DELETE 1"),
+		},
+		{
+			name:        "Rule of another user",
+			result:      pgconn.CommandTag("DELETE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchGoalRuleError{RuleID: ruleID}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+			repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+			mock.ExpectExec(regexp.QuoteMeta(GoalRuleDelete)).
+				WithArgs(ruleID, userID).
+				WillReturnResult(tc.result)
+
+			err := repo.DeleteRule(context.Background(), userID, ruleID)
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetRuleTriggers(t *testing.T) {
+	rule := testRule(models.GoalRuleRoundUp)
+	transactionID := uuid.New()
+
+	mock, _ := pgxmock.NewPool()
+	repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+	mock.ExpectQuery(regexp.QuoteMeta(GoalRuleTriggers)).
+		WithArgs(models.GoalIncomeCategory).
+		WillReturnRows(pgxmock.NewRows(append(ruleRowColumns, "transaction_id", "income", "outcome")).
+			AddRow(rule.ID, rule.GoalID, rule.UserID, rule.Kind, rule.SourceID, rule.AccountID, rule.Amount, "", nil, nil,
+				transactionID, 0.0, 123.45))
+
+	triggers, err := repo.GetRuleTriggers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.GoalRuleTrigger{{Rule: rule, TransactionID: transactionID, Outcome: 123.45}}, triggers)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_Contribute(t *testing.T) {
+	transferID := uuid.New()
+	sourceTransactionID := uuid.New()
+	date := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	chargeColumns := []string{"id", "balance", "kind", "credit_limit", "overdraft_policy"}
+
+	testCases := []struct {
+		name              string
+		rule              models.GoalRule
+		contribution      models.GoalContribution
+		policy            models.OverdraftPolicy
+		charged           bool
+		recordError       error
+		expectedOverdraft bool
+		expectedErr       error
+	}{
+		{
+			name:         "Scheduled transfer",
+			rule:         testRule(models.GoalRuleSchedule),
+			contribution: models.GoalContribution{Amount: 5000, Date: date},
+			policy:       models.OverdraftReject,
+			charged:      true,
+		},
+		{
+			name:         "Round-up without funds",
+			rule:         testRule(models.GoalRuleRoundUp),
+			contribution: models.GoalContribution{SourceTransactionID: &sourceTransactionID, Amount: 6.55, Date: date},
+			policy:       models.OverdraftReject,
+		},
+		{
+			name:              "Round-up over the balance",
+			rule:              testRule(models.GoalRuleRoundUp),
+			contribution:      models.GoalContribution{SourceTransactionID: &sourceTransactionID, Amount: 6.55, Date: date},
+			policy:            models.OverdraftWarn,
+			charged:           true,
+			expectedOverdraft: true,
+		},
+		{
+			name:         "Already handled",
+			rule:         testRule(models.GoalRuleRoundUp),
+			contribution: models.GoalContribution{SourceTransactionID: &sourceTransactionID, Amount: 6.55, Date: date},
+			policy:       models.OverdraftAllow,
+			charged:      true,
+			recordError:  errors.New("duplicate key"),
+			expectedErr:  fmt.Errorf("[repo] failed to record goal contribution: %w", errors.New("duplicate key")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+			repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+			rule, contribution := tc.rule, tc.contribution
+
+			// the scheduled source has the amount, the round-up one is empty
+			balance := 0.0
+			if rule.Kind == models.GoalRuleSchedule {
+				balance = 10000
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(accountRep.AccountChargeLock)).
+				WithArgs(rule.SourceID).
+				WillReturnRows(pgxmock.NewRows(chargeColumns).
+					AddRow(rule.SourceID, balance, models.AccountDebit, 0.0, tc.policy))
+			if tc.charged {
+				mock.ExpectExec(regexp.QuoteMeta(accountRep.AccountCharge)).
+					WithArgs(contribution.Amount, rule.SourceID).
+					WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				mock.ExpectQuery(regexp.QuoteMeta(accountRep.AccountChargeLock)).
+					WithArgs(rule.AccountID).
+					WillReturnRows(pgxmock.NewRows(chargeColumns).
+						AddRow(rule.AccountID, 0.0, models.AccountDebit, 0.0, models.OverdraftAllow))
+				mock.ExpectExec(regexp.QuoteMeta(accountRep.AccountCharge)).
+					WithArgs(-contribution.Amount, rule.AccountID).
+					WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				mock.ExpectQuery(regexp.QuoteMeta(GoalTransferCreate)).
+					WithArgs(rule.UserID, rule.AccountID, rule.SourceID, contribution.Amount, contribution.Date, goalTransferDescription).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(transferID))
+			}
+
+			var transactionID *uuid.UUID
+			if tc.charged {
+				transactionID = &transferID
+			}
+			record := mock.ExpectExec(regexp.QuoteMeta(GoalContributionCreate)).
+				WithArgs(rule.GoalID, rule.ID, contribution.SourceTransactionID, transactionID, contribution.Amount, contribution.Date)
+			if tc.recordError != nil {
+				record.WillReturnError(tc.recordError)
+				mock.ExpectRollback()
+			} else {
+				record.WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				if rule.Kind == models.GoalRuleSchedule {
+					mock.ExpectExec(regexp.QuoteMeta(GoalRuleAdvance)).
+						WithArgs(rule.ID, rule.NextDate).
+						WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				}
+				mock.ExpectCommit()
+			}
+
+			overdraft, err := repo.Contribute(context.Background(), &rule, &contribution)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, transactionID, contribution.TransactionID)
+
+			var expectedOverdraft *models.Overdraft
+			if tc.expectedOverdraft {
+				expectedOverdraft = &models.Overdraft{
+					AccountID: rule.SourceID, TransactionID: transferID, Amount: contribution.Amount, Available: balance,
+				}
+			}
+			assert.Equal(t, expectedOverdraft, overdraft)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetContributed(t *testing.T) {
+	userID := uuid.New()
+	goalID := uuid.New()
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock, _ := pgxmock.NewPool()
+	repo := NewRepository(mock, *logger.NewLogger(context.TODO()))
+
+	mock.ExpectQuery(regexp.QuoteMeta(GoalContributed)).
+		WithArgs(userID, since).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "since", "amount"}).AddRow(goalID, since, 15000.0))
+
+	contributed, err := repo.GetContributed(context.Background(), userID, since)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.GoalContributed{{GoalID: goalID, Since: since, Amount: 15000}}, contributed)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+// contributionFor counts the transfer of an income or a round-up rule for the transaction
+func contributionFor(trigger *models.GoalRuleTrigger) float64 {
+	switch trigger.Rule.Kind {
+	case models.GoalRuleIncome:
+		return money.Round2(trigger.Income * trigger.Rule.Amount / 100)
+	case models.GoalRuleRoundUp:
+		// in kopecks so that 123.45 rounds up by exactly 6.55
+		outcome, step := math.Round(trigger.Outcome*100), math.Round(trigger.Rule.Amount*100)
+		rest := math.Mod(outcome, step)
+		if rest == 0 {
+			return 0
+		}
+		return (step - rest) / 100
+	}
+	return 0
+}
+
+// nextDate is the transfer after the rule's next date, monthly transfers keep the day of the first one
+func nextDate(rule *models.GoalRule) time.Time {
+	current := dates.TruncateDay(*rule.NextDate)
+	if rule.Period == models.GoalPeriodWeek {
+		return current.AddDate(0, 0, 7)
+	}
+
+	start := current
+	if rule.DateStart != nil {
+		start = dates.TruncateDay(*rule.DateStart)
+	}
+	months := (current.Year()-start.Year())*12 + int(current.Month()-start.Month())
+	return dates.AddMonths(start, months+1)
+}
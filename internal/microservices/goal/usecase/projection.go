@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+const (
+	// paceDays is how far back the contributions set the pace of a goal
+	paceDays    = 90
+	daysInMonth = 365.25 / 12
+	// a pace that needs longer than this has no expected date
+	maxProjectionDays = 100 * 365
+)
+
+// buildProjection counts the monthly saving the goal needs to be reached by its date and when
+// it is reached at the pace of its contributions
+func buildProjection(goal *models.Goal, contributed *models.GoalContributed, today time.Time) *models.GoalProjection {
+	projection := &models.GoalProjection{
+		GoalID:    goal.ID,
+		Remaining: money.Round2(math.Max(goal.Target-goal.Progress, 0)),
+	}
+	if contributed != nil {
+		projection.MonthlyPace = monthlyPace(contributed, today)
+	}
+
+	date := dates.TruncateDay(goal.Date)
+	if projection.Remaining > 0 {
+		// the last month and the overdue goal need the whole rest at once
+		monthsLeft := math.Max(date.Sub(today).Hours()/24/daysInMonth, 1)
+		projection.MonthlyRequired = money.Round2(projection.Remaining / monthsLeft)
+
+		if projection.MonthlyPace > 0 {
+			days := math.Ceil(projection.Remaining / projection.MonthlyPace * daysInMonth)
+			if days <= maxProjectionDays {
+				expected := today.AddDate(0, 0, int(days))
+				projection.ExpectedDate = &expected
+			}
+		}
+	}
+
+	switch {
+	case projection.Remaining == 0:
+		projection.Status = models.GoalCompleted
+	case date.Before(today):
+		projection.Status = models.GoalOverdue
+	case projection.ExpectedDate != nil && !projection.ExpectedDate.After(date):
+		projection.Status = models.GoalOnTrack
+	default:
+		projection.Status = models.GoalBehind
+	}
+
+	return projection
+}
+
+// monthlyPace spreads the contributions over the months they were made in, a goal younger
+// than a month counts as a month old
+func monthlyPace(contributed *models.GoalContributed, today time.Time) float64 {
+	days := math.Max(today.Sub(dates.TruncateDay(contributed.Since)).Hours()/24, daysInMonth)
+	return money.Round2(contributed.Amount / (days / daysInMonth))
+}
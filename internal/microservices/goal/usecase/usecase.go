@@ -3,26 +3,38 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
 )
 
 type Usecase struct {
-	goalRepo goal.Repository
-	log      logger.Logger
+	goalRepo       goal.Repository
+	anomalyService anomaly.Usecase
+	log            logger.Logger
 }
 
-func NewUsecase(gr goal.Repository, log logger.Logger) *Usecase {
+func NewUsecase(gr goal.Repository, log logger.Logger, au anomaly.Usecase) *Usecase {
 	return &Usecase{
-		goalRepo: gr,
-		log:      log,
+		goalRepo:       gr,
+		anomalyService: au,
+		log:            log,
 	}
 }
 
-func (u *Usecase) CreateGoal(ctx context.Context, goal models.Goal) (uuid.UUID, error) {
+// CreateGoal saves a goal up on accumulation accounts of the user
+func (u *Usecase) CreateGoal(ctx context.Context, userID uuid.UUID, goal *models.Goal) (uuid.UUID, error) {
+	if err := u.checkAccounts(ctx, userID, goal.Accounts); err != nil {
+		return uuid.Nil, err
+	}
+
+	goal.UserId = userID
+
 	goalId, err := u.goalRepo.CreateGoal(ctx, goal)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("[usecase] Error goal creation: %w", err)
@@ -31,7 +43,13 @@ func (u *Usecase) CreateGoal(ctx context.Context, goal models.Goal) (uuid.UUID,
 	return goalId, nil
 }
 
-func (u *Usecase) UpdateGoal(ctx context.Context, goal *models.Goal) error {
+func (u *Usecase) UpdateGoal(ctx context.Context, userID uuid.UUID, goal *models.Goal) error {
+	if err := u.checkAccounts(ctx, userID, goal.Accounts); err != nil {
+		return err
+	}
+
+	goal.UserId = userID
+
 	if err := u.goalRepo.UpdateGoal(ctx, goal); err != nil {
 		return fmt.Errorf("[usecase] update goal Error: %w", err)
 	}
@@ -39,28 +57,235 @@ func (u *Usecase) UpdateGoal(ctx context.Context, goal *models.Goal) error {
 	return nil
 }
 
-func (u *Usecase) DeleteGoal(ctx context.Context, goalId uuid.UUID) error {
-	if err := u.goalRepo.DeleteGoal(ctx, goalId); err != nil {
+func (u *Usecase) DeleteGoal(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) error {
+	if err := u.goalRepo.DeleteGoal(ctx, userID, goalID); err != nil {
 		return fmt.Errorf("[usecase] delete goal Error: %w", err)
 	}
 
 	return nil
 }
 
-func (u *Usecase) GetGoals(ctx context.Context, userId uuid.UUID) ([]models.Goal, error) {
-	goals, err := u.goalRepo.GetGoals(ctx, userId)
+func (u *Usecase) GetGoals(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	goals, err := u.goalRepo.GetGoals(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("[usecase] get goals Error: %w", err)
 	}
 
+	contributed, err := u.getContributed(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] get goals Error: %w", err)
+	}
+
+	today := dates.TruncateDay(time.Now())
+	for i := range goals {
+		goals[i].Status = buildProjection(&goals[i], contributed[goals[i].ID], today).Status
+	}
+
 	return goals, nil
 }
 
-func (u *Usecase) CheckGoalsState(ctx context.Context, userId uuid.UUID) ([]models.Goal, error) {
-	goals, err := u.goalRepo.CheckGoalsState(ctx, userId)
+func (u *Usecase) CheckGoalsState(ctx context.Context, userID uuid.UUID) ([]models.Goal, error) {
+	goals, err := u.goalRepo.CheckGoalsState(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("[usecase] check goals state Error: %w", err)
 	}
+	for i := range goals {
+		goals[i].Status = models.GoalCompleted
+	}
 
 	return goals, nil
 }
+
+// GetProjection tells whether the goal is on track at the pace of the contributions of the last months
+func (u *Usecase) GetProjection(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) (*models.GoalProjection, error) {
+	goal, err := u.goalRepo.GetGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get goal %w", err)
+	}
+
+	contributed, err := u.getContributed(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get goal contributions %w", err)
+	}
+
+	return buildProjection(goal, contributed[goalID], dates.TruncateDay(time.Now())), nil
+}
+
+func (u *Usecase) getContributed(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*models.GoalContributed, error) {
+	since := dates.TruncateDay(time.Now()).AddDate(0, 0, -paceDays)
+
+	contributed, err := u.goalRepo.GetContributed(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[uuid.UUID]*models.GoalContributed, len(contributed))
+	for i := range contributed {
+		byGoal[contributed[i].GoalID] = &contributed[i]
+	}
+	return byGoal, nil
+}
+
+// CreateRule funds an account of the goal from an account the user can edit
+func (u *Usecase) CreateRule(ctx context.Context, userID uuid.UUID, rule *models.GoalRule) (uuid.UUID, error) {
+	goal, err := u.goalRepo.GetGoal(ctx, userID, rule.GoalID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't get goal %w", err)
+	}
+
+	if !containsAccount(goal.Accounts, rule.AccountID) {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.GoalRuleOperationError{Reason: "account is not linked to the goal"})
+	}
+	if rule.SourceID == rule.AccountID {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.GoalRuleOperationError{Reason: "source account is the account of the goal"})
+	}
+
+	role, err := u.goalRepo.GetAccountRole(ctx, userID, rule.SourceID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't check account %w", err)
+	}
+	if !role.CanEdit() {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.ForbiddenUserError{})
+	}
+
+	if rule.Kind == models.GoalRuleSchedule {
+		if rule.DateStart == nil || dates.TruncateDay(*rule.DateStart).Before(dates.TruncateDay(time.Now())) {
+			return uuid.Nil, fmt.Errorf("[usecase] %w", &models.GoalRuleOperationError{Reason: "first transfer can't be in the past"})
+		}
+		start := dates.TruncateDay(*rule.DateStart)
+		rule.DateStart = &start
+	} else {
+		rule.Period = ""
+		rule.DateStart = nil
+	}
+
+	id, err := u.goalRepo.CreateRule(ctx, rule)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create goal rule %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetRules(ctx context.Context, userID uuid.UUID, goalID uuid.UUID) ([]models.GoalRule, error) {
+	if _, err := u.goalRepo.GetGoal(ctx, userID, goalID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't get goal %w", err)
+	}
+
+	rules, err := u.goalRepo.GetRules(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get goal rules %w", err)
+	}
+	return rules, nil
+}
+
+func (u *Usecase) DeleteRule(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID) error {
+	if err := u.goalRepo.DeleteRule(ctx, userID, ruleID); err != nil {
+		return fmt.Errorf("[usecase] can't delete goal rule %w", err)
+	}
+	return nil
+}
+
+// Contribute makes the transfers of the due scheduled rules, missed dates included, and of the
+// incomes and outcomes the other rules have not handled; a failure of one rule does not stop the others
+func (u *Usecase) Contribute(ctx context.Context) error {
+	today := dates.TruncateDay(time.Now())
+
+	rules, err := u.goalRepo.GetDueRules(ctx, today)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get due goal rules %w", err)
+	}
+
+	var failed, posted, skipped int
+	count := func(contribution *models.GoalContribution) {
+		switch {
+		case contribution.TransactionID != nil:
+			posted++
+		case contribution.Amount > 0:
+			skipped++
+		}
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		for !rule.NextDate.After(today) {
+			contribution := &models.GoalContribution{Amount: rule.Amount, Date: *rule.NextDate}
+			next := nextDate(rule)
+			rule.NextDate = &next
+
+			overdraft, err := u.goalRepo.Contribute(ctx, rule, contribution)
+			if err != nil {
+				u.log.Errorf("[usecase] can't contribute by goal rule %s: %v", rule.ID, err)
+				failed++
+				break
+			}
+			u.recordOverdraft(ctx, rule.UserID, overdraft)
+			count(contribution)
+		}
+	}
+
+	triggers, err := u.goalRepo.GetRuleTriggers(ctx)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get goal rule triggers %w", err)
+	}
+
+	for i := range triggers {
+		trigger := &triggers[i]
+		contribution := &models.GoalContribution{
+			SourceTransactionID: &trigger.TransactionID,
+			Amount:              contributionFor(trigger),
+			Date:                time.Now(),
+		}
+
+		overdraft, err := u.goalRepo.Contribute(ctx, &trigger.Rule, contribution)
+		if err != nil {
+			u.log.Errorf("[usecase] can't contribute by goal rule %s: %v", trigger.Rule.ID, err)
+			failed++
+			continue
+		}
+		u.recordOverdraft(ctx, trigger.Rule.UserID, overdraft)
+		count(contribution)
+	}
+
+	if posted > 0 || skipped > 0 {
+		u.log.Infof("[usecase] %d goal contributions posted, %d skipped for lack of funds", posted, skipped)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[usecase] %d goal contributions failed", failed)
+	}
+	return nil
+}
+
+// recordOverdraft raises the anomaly of an overdraft the warn policy let through,
+// the money is already moved so a failure is only logged
+func (u *Usecase) recordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) {
+	if overdraft == nil {
+		return
+	}
+	if err := u.anomalyService.RecordOverdraft(ctx, userID, overdraft); err != nil {
+		u.log.Errorf("[usecase] can't record overdraft of account %s: %v", overdraft.AccountID, err)
+	}
+}
+
+// checkAccounts allows only the accumulation accounts the user is a member of
+func (u *Usecase) checkAccounts(ctx context.Context, userID uuid.UUID, accounts []uuid.UUID) error {
+	for _, accountID := range accounts {
+		accumulation, err := u.goalRepo.IsAccumulationAccount(ctx, userID, accountID)
+		if err != nil {
+			return fmt.Errorf("[usecase] can't check account %w", err)
+		}
+		if !accumulation {
+			return fmt.Errorf("[usecase] %w", &models.NotAccumulationAccountError{AccountID: accountID})
+		}
+	}
+	return nil
+}
+
+func containsAccount(accounts []uuid.UUID, accountID uuid.UUID) bool {
+	for _, id := range accounts {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,441 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock_anomaly "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/goal/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_CreateGoal(t *testing.T) {
+	userID := uuid.New()
+	goalID := uuid.New()
+	savings := uuid.New()
+	card := uuid.New()
+
+	testCases := []struct {
+		name            string
+		accounts        []uuid.UUID
+		expected        uuid.UUID
+		expectedErr     error
+		notAccumulation bool
+		mockFn          func(*mock.MockRepository)
+	}{
+		{
+			name:     "Success",
+			accounts: []uuid.UUID{savings},
+			expected: goalID,
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().IsAccumulationAccount(gomock.Any(), userID, savings).Return(true, nil)
+				gr.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, goal *models.Goal) (uuid.UUID, error) {
+						assert.Equal(t, userID, goal.UserId)
+						return goalID, nil
+					})
+			},
+		},
+		{
+			name:            "Not an accumulation account",
+			accounts:        []uuid.UUID{savings, card},
+			notAccumulation: true,
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().IsAccumulationAccount(gomock.Any(), userID, savings).Return(true, nil)
+				gr.EXPECT().IsAccumulationAccount(gomock.Any(), userID, card).Return(false, nil)
+			},
+		},
+		{
+			name:        "Account of another user",
+			accounts:    []uuid.UUID{savings},
+			expectedErr: &models.ForbiddenUserError{},
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().IsAccumulationAccount(gomock.Any(), userID, savings).Return(false, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Create error",
+			accounts:    []uuid.UUID{savings},
+			expectedErr: errors.New("err"),
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().IsAccumulationAccount(gomock.Any(), userID, savings).Return(true, nil)
+				gr.EXPECT().CreateGoal(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_anomaly.NewMockUsecase(ctrl))
+
+			id, err := mockUsecase.CreateGoal(context.Background(), userID, &models.Goal{Accounts: tc.accounts, Target: 1000})
+
+			assert.Equal(t, tc.expected, id)
+			switch {
+			case tc.notAccumulation:
+				var errNotAccumulation *models.NotAccumulationAccountError
+				if assert.ErrorAs(t, err, &errNotAccumulation) {
+					assert.Equal(t, card, errNotAccumulation.AccountID)
+				}
+			case tc.expectedErr != nil:
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_UpdateGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_anomaly.NewMockUsecase(ctrl))
+
+	userID := uuid.New()
+	goal := &models.Goal{ID: uuid.New(), Accounts: []uuid.UUID{uuid.New()}}
+
+	mockRepo.EXPECT().IsAccumulationAccount(gomock.Any(), userID, goal.Accounts[0]).Return(true, nil)
+	mockRepo.EXPECT().UpdateGoal(gomock.Any(), goal).Return(nil)
+	assert.NoError(t, mockUsecase.UpdateGoal(context.Background(), userID, goal))
+	assert.Equal(t, userID, goal.UserId)
+
+	noSuch := &models.NoSuchGoalError{GoalID: goal.ID}
+	mockRepo.EXPECT().IsAccumulationAccount(gomock.Any(), userID, goal.Accounts[0]).Return(true, nil)
+	mockRepo.EXPECT().UpdateGoal(gomock.Any(), goal).Return(noSuch)
+	assert.ErrorIs(t, mockUsecase.UpdateGoal(context.Background(), userID, goal), noSuch)
+}
+
+func TestUsecase_GetGoals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_anomaly.NewMockUsecase(ctrl))
+
+	userID := uuid.New()
+	goals := []models.Goal{{ID: uuid.New(), Target: 1000, Progress: 1200, Completed: true, Date: time.Now().AddDate(0, 1, 0)}}
+
+	mockRepo.EXPECT().GetGoals(gomock.Any(), userID).Return(goals, nil)
+	mockRepo.EXPECT().GetContributed(gomock.Any(), userID, gomock.Any()).Return([]models.GoalContributed{}, nil)
+	result, err := mockUsecase.GetGoals(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.GoalCompleted, result[0].Status)
+
+	mockRepo.EXPECT().CheckGoalsState(gomock.Any(), userID).Return(goals, nil)
+	result, err = mockUsecase.CheckGoalsState(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, goals, result)
+
+	mockRepo.EXPECT().CheckGoalsState(gomock.Any(), userID).Return(nil, errors.New("err"))
+	_, err = mockUsecase.CheckGoalsState(context.Background(), userID)
+	assert.Error(t, err)
+}
+
+func TestUsecase_DeleteGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_anomaly.NewMockUsecase(ctrl))
+
+	userID := uuid.New()
+	goalID := uuid.New()
+
+	mockRepo.EXPECT().DeleteGoal(gomock.Any(), userID, goalID).Return(nil)
+	assert.NoError(t, mockUsecase.DeleteGoal(context.Background(), userID, goalID))
+
+	noSuch := &models.NoSuchGoalError{GoalID: goalID}
+	mockRepo.EXPECT().DeleteGoal(gomock.Any(), userID, goalID).Return(noSuch)
+	assert.ErrorIs(t, mockUsecase.DeleteGoal(context.Background(), userID, goalID), noSuch)
+}
+
+func TestUsecase_CreateRule(t *testing.T) {
+	userID := uuid.New()
+	ruleID := uuid.New()
+	savings := uuid.New()
+	card := uuid.New()
+	goal := &models.Goal{ID: uuid.New(), Accounts: []uuid.UUID{savings}}
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	testCases := []struct {
+		name        string
+		rule        models.GoalRule
+		expected    uuid.UUID
+		expectedErr error
+		mockFn      func(*mock.MockRepository)
+	}{
+		{
+			name:     "Scheduled transfer",
+			rule:     models.GoalRule{GoalID: goal.ID, Kind: models.GoalRuleSchedule, SourceID: card, AccountID: savings, Amount: 5000, Period: models.GoalPeriodMonth, DateStart: &tomorrow},
+			expected: ruleID,
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().GetGoal(gomock.Any(), userID, goal.ID).Return(goal, nil)
+				gr.EXPECT().GetAccountRole(gomock.Any(), userID, card).Return(models.AccountEditor, nil)
+				gr.EXPECT().CreateRule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, rule *models.GoalRule) (uuid.UUID, error) {
+						assert.True(t, rule.DateStart.Equal(dates.TruncateDay(tomorrow)))
+						return ruleID, nil
+					})
+			},
+		},
+		{
+			name:        "Account not linked to the goal",
+			rule:        models.GoalRule{GoalID: goal.ID, Kind: models.GoalRuleRoundUp, SourceID: savings, AccountID: card, Amount: 10},
+			expectedErr: &models.GoalRuleOperationError{Reason: "account is not linked to the goal"},
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().GetGoal(gomock.Any(), userID, goal.ID).Return(goal, nil)
+			},
+		},
+		{
+			name:        "Viewer of the source",
+			rule:        models.GoalRule{GoalID: goal.ID, Kind: models.GoalRuleIncome, SourceID: card, AccountID: savings, Amount: 10},
+			expectedErr: &models.ForbiddenUserError{},
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().GetGoal(gomock.Any(), userID, goal.ID).Return(goal, nil)
+				gr.EXPECT().GetAccountRole(gomock.Any(), userID, card).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:        "First transfer in the past",
+			rule:        models.GoalRule{GoalID: goal.ID, Kind: models.GoalRuleSchedule, SourceID: card, AccountID: savings, Amount: 5000, Period: models.GoalPeriodWeek, DateStart: &yesterday},
+			expectedErr: &models.GoalRuleOperationError{Reason: "first transfer can't be in the past"},
+			mockFn: func(gr *mock.MockRepository) {
+				gr.EXPECT().GetGoal(gomock.Any(), userID, goal.ID).Return(goal, nil)
+				gr.EXPECT().GetAccountRole(gomock.Any(), userID, card).Return(models.AccountOwner, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_anomaly.NewMockUsecase(ctrl))
+
+			id, err := mockUsecase.CreateRule(context.Background(), userID, &tc.rule)
+
+			assert.Equal(t, tc.expected, id)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_Contribute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAnomalyUsecase := mock_anomaly.NewMockUsecase(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAnomalyUsecase)
+
+	today := dates.TruncateDay(time.Now())
+	start := today.AddDate(0, 0, -14)
+	scheduled := models.GoalRule{ID: uuid.New(), UserID: uuid.New(), Kind: models.GoalRuleSchedule, Amount: 1000, Period: models.GoalPeriodWeek, DateStart: &start, NextDate: &start}
+	salary := models.GoalRuleTrigger{
+		Rule:          models.GoalRule{ID: uuid.New(), Kind: models.GoalRuleIncome, Amount: 10},
+		TransactionID: uuid.New(),
+		Income:        85000,
+	}
+
+	overdraft := &models.Overdraft{AccountID: uuid.New(), TransactionID: uuid.New(), Amount: 1000, Available: 300}
+
+	mockRepo.EXPECT().GetDueRules(gomock.Any(), today).Return([]models.GoalRule{scheduled}, nil)
+
+	var contributed []time.Time
+	mockRepo.EXPECT().Contribute(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, rule *models.GoalRule, contribution *models.GoalContribution) (*models.Overdraft, error) {
+			assert.Equal(t, 1000.0, contribution.Amount)
+			assert.Equal(t, contribution.Date.AddDate(0, 0, 7), *rule.NextDate)
+			contributed = append(contributed, contribution.Date)
+			// the last transfer goes over the balance of the source
+			if contribution.Date.Equal(today) {
+				return overdraft, nil
+			}
+			return nil, nil
+		})
+	mockAnomalyUsecase.EXPECT().RecordOverdraft(gomock.Any(), scheduled.UserID, overdraft).Return(errors.New("err"))
+
+	mockRepo.EXPECT().GetRuleTriggers(gomock.Any()).Return([]models.GoalRuleTrigger{salary}, nil)
+	mockRepo.EXPECT().Contribute(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, rule *models.GoalRule, contribution *models.GoalContribution) (*models.Overdraft, error) {
+			assert.Equal(t, salary.Rule.ID, rule.ID)
+			assert.Equal(t, salary.TransactionID, *contribution.SourceTransactionID)
+			assert.Equal(t, 8500.0, contribution.Amount)
+			return nil, errors.New("err")
+		})
+
+	err := mockUsecase.Contribute(context.Background())
+
+	assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 7), today}, contributed)
+	assert.EqualError(t, err, "[usecase] 1 goal contributions failed")
+}
+
+func TestContributionFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		trigger  models.GoalRuleTrigger
+		expected float64
+	}{
+		{
+			name:     "Percent of salary",
+			trigger:  models.GoalRuleTrigger{Rule: models.GoalRule{Kind: models.GoalRuleIncome, Amount: 7.5}, Income: 1234.56},
+			expected: 92.59,
+		},
+		{
+			name:     "Round-up to 10",
+			trigger:  models.GoalRuleTrigger{Rule: models.GoalRule{Kind: models.GoalRuleRoundUp, Amount: 10}, Outcome: 123.45},
+			expected: 6.55,
+		},
+		{
+			name:     "Round-up to 100",
+			trigger:  models.GoalRuleTrigger{Rule: models.GoalRule{Kind: models.GoalRuleRoundUp, Amount: 100}, Outcome: 1250},
+			expected: 50,
+		},
+		{
+			name:     "Round outcome",
+			trigger:  models.GoalRuleTrigger{Rule: models.GoalRule{Kind: models.GoalRuleRoundUp, Amount: 100}, Outcome: 300},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, contributionFor(&tc.trigger))
+		})
+	}
+}
+
+func TestNextDate(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	rule := &models.GoalRule{Period: models.GoalPeriodMonth, DateStart: &start, NextDate: &february}
+
+	// the end of month stays after a short month
+	assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), nextDate(rule))
+
+	rule.Period = models.GoalPeriodWeek
+	assert.Equal(t, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), nextDate(rule))
+}
+
+func TestBuildProjection(t *testing.T) {
+	today := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	goalID := uuid.New()
+	// a year ahead is 12 average months
+	goal := &models.Goal{ID: goalID, Target: 120000, Progress: 0, Date: today.AddDate(0, 0, 365)}
+
+	testCases := []struct {
+		name        string
+		goal        models.Goal
+		contributed *models.GoalContributed
+		expected    models.GoalProjection
+	}{
+		{
+			name:        "On track",
+			goal:        *goal,
+			contributed: &models.GoalContributed{GoalID: goalID, Since: today.AddDate(0, 0, -90), Amount: 36000},
+			expected: models.GoalProjection{
+				GoalID:          goalID,
+				Remaining:       120000,
+				MonthlyRequired: 10006.85,
+				MonthlyPace:     12175,
+				ExpectedDate:    func() *time.Time { d := today.AddDate(0, 0, 300); return &d }(),
+				Status:          models.GoalOnTrack,
+			},
+		},
+		{
+			name: "Behind without contributions",
+			goal: *goal,
+			expected: models.GoalProjection{
+				GoalID:          goalID,
+				Remaining:       120000,
+				MonthlyRequired: 10006.85,
+				Status:          models.GoalBehind,
+			},
+		},
+		{
+			name:        "Young goal counts as a month old",
+			goal:        models.Goal{ID: goalID, Target: 120000, Progress: 110000, Date: today.AddDate(0, 0, 10)},
+			contributed: &models.GoalContributed{GoalID: goalID, Since: today.AddDate(0, 0, -5), Amount: 5000},
+			expected: models.GoalProjection{
+				GoalID:          goalID,
+				Remaining:       10000,
+				MonthlyRequired: 10000,
+				MonthlyPace:     5000,
+				ExpectedDate:    func() *time.Time { d := today.AddDate(0, 0, 61); return &d }(),
+				Status:          models.GoalBehind,
+			},
+		},
+		{
+			name: "Overdue",
+			goal: models.Goal{ID: goalID, Target: 1000, Progress: 500, Date: today.AddDate(0, 0, -1)},
+			expected: models.GoalProjection{
+				GoalID:          goalID,
+				Remaining:       500,
+				MonthlyRequired: 500,
+				Status:          models.GoalOverdue,
+			},
+		},
+		{
+			name: "Completed",
+			goal: models.Goal{ID: goalID, Target: 1000, Progress: 1500, Date: today.AddDate(0, 0, -1)},
+			expected: models.GoalProjection{
+				GoalID: goalID,
+				Status: models.GoalCompleted,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, &tc.expected, buildProjection(&tc.goal, tc.contributed, today))
+		})
+	}
+}
+
+func TestUsecase_GetProjection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock_anomaly.NewMockUsecase(ctrl))
+
+	userID := uuid.New()
+	goalID := uuid.New()
+
+	mockRepo.EXPECT().GetGoal(gomock.Any(), userID, goalID).Return(nil, &models.NoSuchGoalError{GoalID: goalID})
+	_, err := mockUsecase.GetProjection(context.Background(), userID, goalID)
+	var errNoSuchGoal *models.NoSuchGoalError
+	assert.ErrorAs(t, err, &errNoSuchGoal)
+
+	goal := &models.Goal{ID: goalID, Target: 1000, Progress: 1000, Date: time.Now()}
+	mockRepo.EXPECT().GetGoal(gomock.Any(), userID, goalID).Return(goal, nil)
+	mockRepo.EXPECT().GetContributed(gomock.Any(), userID, gomock.Any()).Return([]models.GoalContributed{{GoalID: goalID, Amount: 1000}}, nil)
+	projection, err := mockUsecase.GetProjection(context.Background(), userID, goalID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.GoalCompleted, projection.Status)
+}
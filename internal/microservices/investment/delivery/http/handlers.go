@@ -0,0 +1,236 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	investmentService investment.Usecase
+	logger            logger.Logger
+}
+
+func NewHandler(iu investment.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		investmentService: iu,
+		logger:            l,
+	}
+}
+
+// @Summary		Create holding
+// @Tags		Investment
+// @Description	Add a security to the portfolio, an existing ticker is renamed
+// @Accept 		json
+// @Produce		json
+// @Param		holding	body		CreateHolding					true	"Ticker and name"
+// @Success		200		{object}	Response[HoldingCreateResponse]	"Holding created"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/investment/create [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var holdingInput CreateHolding
+	if err := easyjson.UnmarshalFromReader(r.Body, &holdingInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := holdingInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	holdingID, err := h.investmentService.CreateHolding(r.Context(), user.ID, holdingInput.ToHolding())
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, HoldingCreateServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, HoldingCreateResponse{HoldingID: holdingID})
+}
+
+// @Summary		Get portfolio
+// @Tags		Investment
+// @Description	Positions with the average cost, realized and unrealized P&L and dividends
+// @Produce		json
+// @Success		200		{object}	Response[models.Portfolio]	"Portfolio"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/investment/portfolio [get]
+func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	portfolio, err := h.investmentService.GetPortfolio(r.Context(), user.ID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, PortfolioGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, portfolio)
+}
+
+// @Summary		Add lot
+// @Tags		Investment
+// @Description	Record a buy or a sell of the holding
+// @Accept 		json
+// @Produce		json
+// @Param		holding_id	path		string		true	"Holding ID"
+// @Param		lot			body		CreateLot	true	"Lot"
+// @Success		200		{object}	Response[LotCreateResponse]	"Lot added"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/investment/{holding_id}/lot [post]
+func (h *Handler) AddLot(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	holdingID, err := commonHttp.GetIDFromRequest(holdingID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var lotInput CreateLot
+	if err := easyjson.UnmarshalFromReader(r.Body, &lotInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := lotInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	lotID, err := h.investmentService.AddLot(r.Context(), user.ID, lotInput.ToLot(holdingID))
+	if h.investmentError(w, err, LotCreateServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, LotCreateResponse{LotID: lotID})
+}
+
+// @Summary		Get lots
+// @Tags		Investment
+// @Description	Buys and sells of the holding by date
+// @Produce		json
+// @Param		holding_id	path		string	true	"Holding ID"
+// @Success		200		{object}	Response[[]models.InvestmentLot]	"Lots"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/investment/{holding_id}/lots [get]
+func (h *Handler) GetLots(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	holdingID, err := commonHttp.GetIDFromRequest(holdingID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	lots, err := h.investmentService.GetLots(r.Context(), user.ID, holdingID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, LotGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, lots)
+}
+
+// @Summary		Post dividend
+// @Tags		Investment
+// @Description	Record dividend income of the holding as a transaction on the account
+// @Accept 		json
+// @Produce		json
+// @Param		holding_id	path		string			true	"Holding ID"
+// @Param		dividend	body		CreateDividend	true	"Dividend"
+// @Success		200		{object}	Response[DividendCreateResponse]	"Dividend posted"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/investment/{holding_id}/dividend [post]
+func (h *Handler) PostDividend(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	holdingID, err := commonHttp.GetIDFromRequest(holdingID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var dividendInput CreateDividend
+	if err := easyjson.UnmarshalFromReader(r.Body, &dividendInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := dividendInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	dividendID, err := h.investmentService.PostDividend(r.Context(), user.ID, dividendInput.ToDividend(holdingID))
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	if h.investmentError(w, err, DividendCreateServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, DividendCreateResponse{DividendID: dividendID})
+}
+
+// investmentError writes the response for an error of a holding operation, false if there is no error
+func (h *Handler) investmentError(w http.ResponseWriter, err error, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	var errNoSuchHolding *models.NoSuchHoldingError
+	if errors.As(err, &errNoSuchHolding) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, HoldingNotSuch, h.logger)
+		return true
+	}
+
+	var errInvestmentOperation *models.InvestmentOperationError
+	if errors.As(err, &errInvestmentOperation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errInvestmentOperation.Reason, h.logger)
+		return true
+	}
+
+	commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.logger)
+	return true
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	holdingID = "holding_id"
+
+	HoldingCreateServerError  = "can't create holding"
+	PortfolioGetServerError   = "can't get portfolio"
+	LotCreateServerError      = "can't add lot"
+	LotGetServerError         = "can't get lots"
+	DividendCreateServerError = "can't post dividend"
+	HoldingNotSuch            = "no such holding"
+
+	HoldingNameMaxLen = 50
+)
+
+var (
+	tickerPattern = regexp.MustCompile(`^[A-Z0-9.\-]{1,20}$`)
+
+	errInvalidTicker   = errors.New("invalid ticker")
+	errInvalidName     = errors.New("name is too long")
+	errInvalidKind     = errors.New("kind must be buy or sell")
+	errInvalidQuantity = errors.New("quantity must be positive")
+	errInvalidPrice    = errors.New("invalid price or fee")
+	errInvalidAccount  = errors.New("account is required")
+	errInvalidAmount   = errors.New("amount must be positive")
+)
+
+type HoldingCreateResponse struct {
+	HoldingID uuid.UUID `json:"holding_id"`
+}
+
+type LotCreateResponse struct {
+	LotID uuid.UUID `json:"lot_id"`
+}
+
+type DividendCreateResponse struct {
+	DividendID uuid.UUID `json:"dividend_id"`
+}
+
+//easyjson:json
+type CreateHolding struct {
+	Ticker string `json:"ticker"`
+	Name   string `json:"name"`
+}
+
+//easyjson:json
+type CreateLot struct {
+	Kind     string    `json:"kind"`
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	Fee      float64   `json:"fee"`
+	Date     time.Time `json:"date"`
+}
+
+//easyjson:json
+type CreateDividend struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Amount    float64   `json:"amount"`
+	Date      time.Time `json:"date"`
+}
+
+// CheckValid upper-cases the ticker, it is the key of the price source
+func (ch *CreateHolding) CheckValid() error {
+	ch.Ticker = strings.ToUpper(strings.TrimSpace(ch.Ticker))
+	ch.Name = html.EscapeString(strings.TrimSpace(ch.Name))
+
+	switch {
+	case !tickerPattern.MatchString(ch.Ticker):
+		return errInvalidTicker
+	case utf8.RuneCountInString(ch.Name) > HoldingNameMaxLen:
+		return errInvalidName
+	}
+
+	return nil
+}
+
+func (ch *CreateHolding) ToHolding() *models.Holding {
+	return &models.Holding{
+		Ticker: ch.Ticker,
+		Name:   ch.Name,
+	}
+}
+
+// CheckValid fills the defaults: a lot bought or sold today
+func (cl *CreateLot) CheckValid() error {
+	if cl.Date.IsZero() {
+		cl.Date = time.Now()
+	}
+
+	switch {
+	case cl.Kind != models.LotBuy && cl.Kind != models.LotSell:
+		return errInvalidKind
+	case cl.Quantity <= 0:
+		return errInvalidQuantity
+	case cl.Price <= 0 || cl.Fee < 0:
+		return errInvalidPrice
+	}
+
+	return nil
+}
+
+func (cl *CreateLot) ToLot(holdingID uuid.UUID) *models.InvestmentLot {
+	return &models.InvestmentLot{
+		HoldingID: holdingID,
+		Kind:      cl.Kind,
+		Quantity:  cl.Quantity,
+		Price:     cl.Price,
+		Fee:       cl.Fee,
+		Date:      cl.Date,
+	}
+}
+
+// CheckValid fills the defaults: a dividend received today
+func (cd *CreateDividend) CheckValid() error {
+	if cd.Date.IsZero() {
+		cd.Date = time.Now()
+	}
+
+	switch {
+	case cd.AccountID == uuid.Nil:
+		return errInvalidAccount
+	case cd.Amount <= 0:
+		return errInvalidAmount
+	}
+
+	return nil
+}
+
+func (cd *CreateDividend) ToDividend(holdingID uuid.UUID) *models.InvestmentDividend {
+	return &models.InvestmentDividend{
+		HoldingID: holdingID,
+		AccountID: cd.AccountID,
+		Amount:    cd.Amount,
+		Date:      cd.Date,
+	}
+}
@@ -0,0 +1,272 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package http
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp(in *jlexer.Lexer, out *CreateLot) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kind":
+			out.Kind = string(in.String())
+		case "quantity":
+			out.Quantity = float64(in.Float64())
+		case "price":
+			out.Price = float64(in.Float64())
+		case "fee":
+			out.Fee = float64(in.Float64())
+		case "date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Date).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp(out *jwriter.Writer, in CreateLot) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"quantity\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Quantity))
+	}
+	{
+		const prefix string = ",\"price\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Price))
+	}
+	{
+		const prefix string = ",\"fee\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Fee))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((in.Date).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateLot) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateLot) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateLot) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateLot) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp(l, v)
+}
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp1(in *jlexer.Lexer, out *CreateHolding) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ticker":
+			out.Ticker = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp1(out *jwriter.Writer, in CreateHolding) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ticker\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Ticker))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateHolding) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateHolding) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateHolding) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateHolding) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp1(l, v)
+}
+func easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp2(in *jlexer.Lexer, out *CreateDividend) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		case "amount":
+			out.Amount = float64(in.Float64())
+		case "date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Date).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp2(out *jwriter.Writer, in CreateDividend) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Amount))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((in.Date).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateDividend) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateDividend) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF13216eaEncodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateDividend) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateDividend) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF13216eaDecodeGithubComGoParkMailRu20232HamsterInternalMicroservicesInvestmentDeliveryHttp2(l, v)
+}
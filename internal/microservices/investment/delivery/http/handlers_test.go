@@ -0,0 +1,370 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Create(t *testing.T) {
+	uuidTest := uuid.New()
+	holdingID := uuid.MustParse("3c1e7a52-9f0d-4b8e-a6c4-5d2f1b0e9a87")
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Create",
+			user:         user,
+			body:         `{"ticker":" sber ","name":"Сбербанк"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"holding_id":"3c1e7a52-9f0d-4b8e-a6c4-5d2f1b0e9a87"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateHolding(gomock.Any(), uuidTest, &models.Holding{Ticker: "SBER", Name: "Сбербанк"}).
+					Return(holdingID, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid ticker",
+			user:         user,
+			body:         `{"ticker":"SBER PREF"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         `{"ticker":"SBER"}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't create holding"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateHolding(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/investment/create", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Create(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_AddLot(t *testing.T) {
+	uuidTest := uuid.New()
+	holdingID := uuid.New()
+	lotID := uuid.MustParse("6b2d9e14-0a7c-4f3b-8e5d-1c9a7f2b4e60")
+	user := &models.User{ID: uuidTest}
+	validBody := `{"kind":"sell","quantity":5,"price":150,"fee":5,"date":"2023-04-01T00:00:00Z"}`
+	tests := []struct {
+		name          string
+		user          *models.User
+		holdingID     string
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to AddLot",
+			user:         user,
+			holdingID:    holdingID.String(),
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"lot_id":"6b2d9e14-0a7c-4f3b-8e5d-1c9a7f2b4e60"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().AddLot(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, lot *models.InvestmentLot) (uuid.UUID, error) {
+						assert.Equal(t, holdingID, lot.HoldingID)
+						assert.Equal(t, models.LotSell, lot.Kind)
+						return lotID, nil
+					})
+			},
+		},
+		{
+			name:         "Invalid holding id",
+			user:         user,
+			holdingID:    "holding",
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid kind",
+			user:         user,
+			holdingID:    holdingID.String(),
+			body:         `{"kind":"short","quantity":5,"price":150}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No such holding",
+			user:         user,
+			holdingID:    holdingID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such holding"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().AddLot(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[usecase] can't get holding from repository %w", &models.NoSuchHoldingError{HoldingID: holdingID}))
+			},
+		},
+		{
+			name:         "Sell exceeds the position",
+			user:         user,
+			holdingID:    holdingID.String(),
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"sell exceeds the quantity held"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().AddLot(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[usecase] %w", &models.InvestmentOperationError{Reason: "sell exceeds the quantity held"}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			holdingID:    holdingID.String(),
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't add lot"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().AddLot(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/investment/"+tt.holdingID+"/lot", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"holding_id": tt.holdingID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.AddLot(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_PostDividend(t *testing.T) {
+	uuidTest := uuid.New()
+	holdingID := uuid.New()
+	accountID := uuid.New()
+	dividendID := uuid.MustParse("d41f8b3a-2c6e-4a9d-b7e0-8f5c3a1d6b29")
+	user := &models.User{ID: uuidTest}
+	validBody := fmt.Sprintf(`{"account_id":"%s","amount":337.5,"date":"2023-07-20T00:00:00Z"}`, accountID)
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to PostDividend",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"dividend_id":"d41f8b3a-2c6e-4a9d-b7e0-8f5c3a1d6b29"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().PostDividend(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, dividend *models.InvestmentDividend) (uuid.UUID, error) {
+						assert.Equal(t, holdingID, dividend.HoldingID)
+						assert.Equal(t, accountID, dividend.AccountID)
+						return dividendID, nil
+					})
+			},
+		},
+		{
+			name:         "No account",
+			user:         user,
+			body:         `{"amount":337.5}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().PostDividend(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[usecase] account of another user %w", &models.ForbiddenUserError{}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't post dividend"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().PostDividend(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/investment/"+holdingID.String()+"/dividend", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"holding_id": holdingID.String()})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.PostDividend(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetPortfolio(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetPortfolio",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"value":100,"cost":100,"unrealized":0,"realized":0,"dividends":0,"holdings":[]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetPortfolio(gomock.Any(), uuidTest).
+					Return(&models.Portfolio{Value: 100, Cost: 100, Holdings: []models.HoldingPosition{}}, nil)
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get portfolio"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetPortfolio(gomock.Any(), uuidTest).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/investment/portfolio", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetPortfolio(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
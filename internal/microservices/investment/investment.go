@@ -0,0 +1,37 @@
+package investment
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	CreateHolding(ctx context.Context, userID uuid.UUID, holding *models.Holding) (uuid.UUID, error)
+	AddLot(ctx context.Context, userID uuid.UUID, lot *models.InvestmentLot) (uuid.UUID, error)
+	GetLots(ctx context.Context, userID uuid.UUID, holdingID uuid.UUID) ([]models.InvestmentLot, error)
+	PostDividend(ctx context.Context, userID uuid.UUID, dividend *models.InvestmentDividend) (uuid.UUID, error)
+	GetPortfolio(ctx context.Context, userID uuid.UUID) (*models.Portfolio, error)
+
+	SnapshotPortfolios(ctx context.Context) error
+}
+
+type Repository interface {
+	CreateHolding(ctx context.Context, holding *models.Holding) (uuid.UUID, error)
+	GetHoldings(ctx context.Context, userID uuid.UUID) ([]models.Holding, error)
+	GetHolding(ctx context.Context, userID uuid.UUID, holdingID uuid.UUID) (*models.Holding, error)
+	GetLots(ctx context.Context, userID uuid.UUID) ([]models.InvestmentLot, error)
+	CreateLot(ctx context.Context, lot *models.InvestmentLot) (uuid.UUID, error)
+	GetDividends(ctx context.Context, userID uuid.UUID) ([]models.InvestmentDividend, error)
+	PostDividend(ctx context.Context, holding *models.Holding, dividend *models.InvestmentDividend) (uuid.UUID, error)
+
+	GetInvestors(ctx context.Context) ([]uuid.UUID, error)
+	SavePortfolioSnapshot(ctx context.Context, userID uuid.UUID, date time.Time, value float64) error
+}
+
+// PriceSource supplies the last known prices, a ticker without a price is left out
+type PriceSource interface {
+	GetPrices(ctx context.Context, tickers []string) (map[string]models.SecurityPrice, error)
+}
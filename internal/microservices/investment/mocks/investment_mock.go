@@ -0,0 +1,322 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: investment.go
+
+// Package mock_investment is a generated GoMock package.
+package mock_investment
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddLot mocks base method.
+func (m *MockUsecase) AddLot(ctx context.Context, userID uuid.UUID, lot *models.InvestmentLot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLot", ctx, userID, lot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLot indicates an expected call of AddLot.
+func (mr *MockUsecaseMockRecorder) AddLot(ctx, userID, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLot", reflect.TypeOf((*MockUsecase)(nil).AddLot), ctx, userID, lot)
+}
+
+// CreateHolding mocks base method.
+func (m *MockUsecase) CreateHolding(ctx context.Context, userID uuid.UUID, holding *models.Holding) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHolding", ctx, userID, holding)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHolding indicates an expected call of CreateHolding.
+func (mr *MockUsecaseMockRecorder) CreateHolding(ctx, userID, holding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHolding", reflect.TypeOf((*MockUsecase)(nil).CreateHolding), ctx, userID, holding)
+}
+
+// GetLots mocks base method.
+func (m *MockUsecase) GetLots(ctx context.Context, userID, holdingID uuid.UUID) ([]models.InvestmentLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLots", ctx, userID, holdingID)
+	ret0, _ := ret[0].([]models.InvestmentLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLots indicates an expected call of GetLots.
+func (mr *MockUsecaseMockRecorder) GetLots(ctx, userID, holdingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLots", reflect.TypeOf((*MockUsecase)(nil).GetLots), ctx, userID, holdingID)
+}
+
+// GetPortfolio mocks base method.
+func (m *MockUsecase) GetPortfolio(ctx context.Context, userID uuid.UUID) (*models.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, userID)
+	ret0, _ := ret[0].(*models.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockUsecaseMockRecorder) GetPortfolio(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockUsecase)(nil).GetPortfolio), ctx, userID)
+}
+
+// PostDividend mocks base method.
+func (m *MockUsecase) PostDividend(ctx context.Context, userID uuid.UUID, dividend *models.InvestmentDividend) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostDividend", ctx, userID, dividend)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostDividend indicates an expected call of PostDividend.
+func (mr *MockUsecaseMockRecorder) PostDividend(ctx, userID, dividend interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostDividend", reflect.TypeOf((*MockUsecase)(nil).PostDividend), ctx, userID, dividend)
+}
+
+// SnapshotPortfolios mocks base method.
+func (m *MockUsecase) SnapshotPortfolios(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotPortfolios", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnapshotPortfolios indicates an expected call of SnapshotPortfolios.
+func (mr *MockUsecaseMockRecorder) SnapshotPortfolios(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotPortfolios", reflect.TypeOf((*MockUsecase)(nil).SnapshotPortfolios), ctx)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateHolding mocks base method.
+func (m *MockRepository) CreateHolding(ctx context.Context, holding *models.Holding) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHolding", ctx, holding)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHolding indicates an expected call of CreateHolding.
+func (mr *MockRepositoryMockRecorder) CreateHolding(ctx, holding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHolding", reflect.TypeOf((*MockRepository)(nil).CreateHolding), ctx, holding)
+}
+
+// CreateLot mocks base method.
+func (m *MockRepository) CreateLot(ctx context.Context, lot *models.InvestmentLot) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLot", ctx, lot)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLot indicates an expected call of CreateLot.
+func (mr *MockRepositoryMockRecorder) CreateLot(ctx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLot", reflect.TypeOf((*MockRepository)(nil).CreateLot), ctx, lot)
+}
+
+// GetDividends mocks base method.
+func (m *MockRepository) GetDividends(ctx context.Context, userID uuid.UUID) ([]models.InvestmentDividend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDividends", ctx, userID)
+	ret0, _ := ret[0].([]models.InvestmentDividend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDividends indicates an expected call of GetDividends.
+func (mr *MockRepositoryMockRecorder) GetDividends(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDividends", reflect.TypeOf((*MockRepository)(nil).GetDividends), ctx, userID)
+}
+
+// GetHolding mocks base method.
+func (m *MockRepository) GetHolding(ctx context.Context, userID, holdingID uuid.UUID) (*models.Holding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolding", ctx, userID, holdingID)
+	ret0, _ := ret[0].(*models.Holding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolding indicates an expected call of GetHolding.
+func (mr *MockRepositoryMockRecorder) GetHolding(ctx, userID, holdingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolding", reflect.TypeOf((*MockRepository)(nil).GetHolding), ctx, userID, holdingID)
+}
+
+// GetHoldings mocks base method.
+func (m *MockRepository) GetHoldings(ctx context.Context, userID uuid.UUID) ([]models.Holding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldings", ctx, userID)
+	ret0, _ := ret[0].([]models.Holding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldings indicates an expected call of GetHoldings.
+func (mr *MockRepositoryMockRecorder) GetHoldings(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldings", reflect.TypeOf((*MockRepository)(nil).GetHoldings), ctx, userID)
+}
+
+// GetInvestors mocks base method.
+func (m *MockRepository) GetInvestors(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvestors", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvestors indicates an expected call of GetInvestors.
+func (mr *MockRepositoryMockRecorder) GetInvestors(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvestors", reflect.TypeOf((*MockRepository)(nil).GetInvestors), ctx)
+}
+
+// GetLots mocks base method.
+func (m *MockRepository) GetLots(ctx context.Context, userID uuid.UUID) ([]models.InvestmentLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLots", ctx, userID)
+	ret0, _ := ret[0].([]models.InvestmentLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLots indicates an expected call of GetLots.
+func (mr *MockRepositoryMockRecorder) GetLots(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLots", reflect.TypeOf((*MockRepository)(nil).GetLots), ctx, userID)
+}
+
+// PostDividend mocks base method.
+func (m *MockRepository) PostDividend(ctx context.Context, holding *models.Holding, dividend *models.InvestmentDividend) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostDividend", ctx, holding, dividend)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostDividend indicates an expected call of PostDividend.
+func (mr *MockRepositoryMockRecorder) PostDividend(ctx, holding, dividend interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostDividend", reflect.TypeOf((*MockRepository)(nil).PostDividend), ctx, holding, dividend)
+}
+
+// SavePortfolioSnapshot mocks base method.
+func (m *MockRepository) SavePortfolioSnapshot(ctx context.Context, userID uuid.UUID, date time.Time, value float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePortfolioSnapshot", ctx, userID, date, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePortfolioSnapshot indicates an expected call of SavePortfolioSnapshot.
+func (mr *MockRepositoryMockRecorder) SavePortfolioSnapshot(ctx, userID, date, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePortfolioSnapshot", reflect.TypeOf((*MockRepository)(nil).SavePortfolioSnapshot), ctx, userID, date, value)
+}
+
+// MockPriceSource is a mock of PriceSource interface.
+type MockPriceSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceSourceMockRecorder
+}
+
+// MockPriceSourceMockRecorder is the mock recorder for MockPriceSource.
+type MockPriceSourceMockRecorder struct {
+	mock *MockPriceSource
+}
+
+// NewMockPriceSource creates a new mock instance.
+func NewMockPriceSource(ctrl *gomock.Controller) *MockPriceSource {
+	mock := &MockPriceSource{ctrl: ctrl}
+	mock.recorder = &MockPriceSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceSource) EXPECT() *MockPriceSourceMockRecorder {
+	return m.recorder
+}
+
+// GetPrices mocks base method.
+func (m *MockPriceSource) GetPrices(ctx context.Context, tickers []string) (map[string]models.SecurityPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrices", ctx, tickers)
+	ret0, _ := ret[0].(map[string]models.SecurityPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrices indicates an expected call of GetPrices.
+func (mr *MockPriceSourceMockRecorder) GetPrices(ctx, tickers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrices", reflect.TypeOf((*MockPriceSource)(nil).GetPrices), ctx, tickers)
+}
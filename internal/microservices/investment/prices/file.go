@@ -0,0 +1,101 @@
+package prices
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+// FileSource reads the prices from a local file that is updated outside of the service:
+// a JSON object {"SBER": {"price": 250.1, "date": "2023-12-01T00:00:00Z"}} or
+// a CSV with ticker,price,date rows. The file is read on every call, an empty path means no prices
+type FileSource struct {
+	path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+func (s *FileSource) GetPrices(ctx context.Context, tickers []string) (map[string]models.SecurityPrice, error) {
+	result := make(map[string]models.SecurityPrice, len(tickers))
+	if s.path == "" || len(tickers) == 0 {
+		return result, nil
+	}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("[prices] %w", err)
+	}
+	defer file.Close()
+
+	var all map[string]models.SecurityPrice
+	if strings.EqualFold(filepath.Ext(s.path), ".csv") {
+		all, err = readCSV(file)
+	} else {
+		all, err = readJSON(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[prices] can't read %s: %w", s.path, err)
+	}
+
+	for _, ticker := range tickers {
+		if price, ok := all[strings.ToUpper(ticker)]; ok {
+			result[ticker] = price
+		}
+	}
+	return result, nil
+}
+
+func readJSON(r io.Reader) (map[string]models.SecurityPrice, error) {
+	var raw map[string]models.SecurityPrice
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]models.SecurityPrice, len(raw))
+	for ticker, price := range raw {
+		all[strings.ToUpper(ticker)] = price
+	}
+	return all, nil
+}
+
+// readCSV skips the header and the rows it can't parse, the date column is optional
+func readCSV(r io.Reader) (map[string]models.SecurityPrice, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]models.SecurityPrice, len(records))
+	for _, record := range records {
+		if len(record) < 2 {
+			continue
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			continue
+		}
+
+		var date time.Time
+		if len(record) > 2 {
+			date, _ = time.Parse("2006-01-02", strings.TrimSpace(record[2]))
+		}
+
+		all[strings.ToUpper(strings.TrimSpace(record[0]))] = models.SecurityPrice{Price: price, Date: date}
+	}
+	return all, nil
+}
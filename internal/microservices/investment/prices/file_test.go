@@ -0,0 +1,74 @@
+package prices
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileSource_GetPrices(t *testing.T) {
+	date := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		path        string
+		expected    map[string]models.SecurityPrice
+		expectedErr bool
+	}{
+		{
+			name: "JSON",
+			path: writeFile(t, "prices.json", `{"sber": {"price": 250.1, "date": "2023-12-01T00:00:00Z"}, "GAZP": {"price": 160}}`),
+			expected: map[string]models.SecurityPrice{
+				"SBER": {Price: 250.1, Date: date},
+			},
+		},
+		{
+			name: "CSV",
+			path: writeFile(t, "prices.csv", "ticker,price,date\nSBER, 250.1, 2023-12-01\nbad,row\nYNDX,2500"),
+			expected: map[string]models.SecurityPrice{
+				"SBER": {Price: 250.1, Date: date},
+			},
+		},
+		{
+			name:     "No file configured",
+			path:     "",
+			expected: map[string]models.SecurityPrice{},
+		},
+		{
+			name:        "Missing file",
+			path:        filepath.Join(t.TempDir(), "missing.json"),
+			expectedErr: true,
+		},
+		{
+			name:        "Broken JSON",
+			path:        writeFile(t, "broken.json", `{"SBER":`),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prices, err := NewFileSource(tc.path).GetPrices(context.Background(), []string{"SBER", "VTBR"})
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, prices)
+		})
+	}
+}
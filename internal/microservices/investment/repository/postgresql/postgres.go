@@ -0,0 +1,277 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// a ticker is added once, adding it again renames the holding
+	HoldingCreate = `INSERT INTO Holding (user_id, ticker, name)
+					 VALUES ($1, $2, $3)
+					 ON CONFLICT (user_id, ticker) DO UPDATE SET name = EXCLUDED.name
+					 RETURNING id;`
+
+	HoldingGetAll = "SELECT id, user_id, ticker, name FROM Holding WHERE user_id = $1 ORDER BY ticker;"
+	HoldingGet    = "SELECT id, user_id, ticker, name FROM Holding WHERE id = $1 AND user_id = $2;"
+
+	InvestmentLotsGet = `SELECT l.id, l.holding_id, l.kind, l.quantity, l.price, l.fee, l.date
+						 FROM InvestmentLot l
+						 JOIN Holding h ON h.id = l.holding_id
+						 WHERE h.user_id = $1
+						 ORDER BY l.date, l.kind;`
+
+	InvestmentLotCreate = `INSERT INTO InvestmentLot (holding_id, kind, quantity, price, fee, date)
+						   VALUES ($1, $2, $3, $4, $5, $6)
+						   RETURNING id;`
+
+	InvestmentDividendsGet = `SELECT d.id, d.holding_id, d.account_id, d.transaction_id, d.amount, d.date
+							  FROM InvestmentDividend d
+							  JOIN Holding h ON h.id = d.holding_id
+							  WHERE h.user_id = $1
+							  ORDER BY d.date;`
+
+	InvestmentDividendTransaction = `INSERT INTO Transaction (user_id, account_income, account_outcome, income, outcome, date, payer, description)
+									 VALUES ($1, $2, $2, $3, 0, $4, $5, $6)
+									 RETURNING id;`
+
+	// a dividend has no category, only the total of the day is counted
+	InvestmentDailyTotals = `INSERT INTO DailyTotals (user_id, account_id, category_id, day, income, outcome)
+							 VALUES ($1, $2, '00000000-0000-0000-0000-000000000000', $3::date, $4, 0)
+							 ON CONFLICT (user_id, account_id, category_id, day) DO UPDATE
+							 SET income = DailyTotals.income + EXCLUDED.income;`
+
+	InvestmentDividendCreate = `INSERT INTO InvestmentDividend (holding_id, account_id, transaction_id, amount, date)
+								VALUES ($1, $2, $3, $4, $5)
+								RETURNING id;`
+
+	InvestmentInvestors = "SELECT DISTINCT user_id FROM Holding;"
+
+	PortfolioSnapshotSave = `INSERT INTO PortfolioSnapshot (user_id, date, value)
+							 VALUES ($1, $2, $3)
+							 ON CONFLICT (user_id, date) DO UPDATE SET value = EXCLUDED.value;`
+
+	dividendDescription = "Дивиденды"
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) CreateHolding(ctx context.Context, holding *models.Holding) (uuid.UUID, error) {
+	var id uuid.UUID
+	if err := r.db.QueryRow(ctx, HoldingCreate, holding.UserID, holding.Ticker, holding.Name).Scan(&id); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create holding: %w", err)
+	}
+	return id, nil
+}
+
+func (r *Repository) GetHoldings(ctx context.Context, userID uuid.UUID) ([]models.Holding, error) {
+	holdings := []models.Holding{}
+
+	rows, err := r.db.Query(ctx, HoldingGetAll, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var holding models.Holding
+		if err := rows.Scan(&holding.ID, &holding.UserID, &holding.Ticker, &holding.Name); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		holdings = append(holdings, holding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return holdings, nil
+}
+
+func (r *Repository) GetHolding(ctx context.Context, userID uuid.UUID, holdingID uuid.UUID) (*models.Holding, error) {
+	var holding models.Holding
+
+	err := r.db.QueryRow(ctx, HoldingGet, holdingID, userID).Scan(&holding.ID, &holding.UserID, &holding.Ticker, &holding.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchHoldingError{HoldingID: holdingID})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &holding, nil
+}
+
+func (r *Repository) GetLots(ctx context.Context, userID uuid.UUID) ([]models.InvestmentLot, error) {
+	lots := []models.InvestmentLot{}
+
+	rows, err := r.db.Query(ctx, InvestmentLotsGet, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lot models.InvestmentLot
+		if err := rows.Scan(
+			&lot.ID,
+			&lot.HoldingID,
+			&lot.Kind,
+			&lot.Quantity,
+			&lot.Price,
+			&lot.Fee,
+			&lot.Date,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return lots, nil
+}
+
+func (r *Repository) CreateLot(ctx context.Context, lot *models.InvestmentLot) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	err := r.db.QueryRow(ctx, InvestmentLotCreate,
+		lot.HoldingID,
+		lot.Kind,
+		lot.Quantity,
+		lot.Price,
+		lot.Fee,
+		lot.Date,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create lot: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetDividends(ctx context.Context, userID uuid.UUID) ([]models.InvestmentDividend, error) {
+	dividends := []models.InvestmentDividend{}
+
+	rows, err := r.db.Query(ctx, InvestmentDividendsGet, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dividend models.InvestmentDividend
+		if err := rows.Scan(
+			&dividend.ID,
+			&dividend.HoldingID,
+			&dividend.AccountID,
+			&dividend.TransactionID,
+			&dividend.Amount,
+			&dividend.Date,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		dividends = append(dividends, dividend)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return dividends, nil
+}
+
+// PostDividend records the dividend as income on the account, the ticker is the payer
+func (r *Repository) PostDividend(ctx context.Context, holding *models.Holding, dividend *models.InvestmentDividend) (uuid.UUID, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	var transactionID uuid.UUID
+	if err = tx.QueryRow(ctx, InvestmentDividendTransaction, holding.UserID, dividend.AccountID, dividend.Amount,
+		dividend.Date, holding.Ticker, dividendDescription).Scan(&transactionID); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create dividend transaction: %w", err)
+	}
+
+	// a dividend only adds to the account, the policy never finds an overdraft in it
+	if _, err = accountRep.ChargeAccount(ctx, tx, dividend.AccountID, -dividend.Amount); err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err = tx.Exec(ctx, InvestmentDailyTotals, holding.UserID, dividend.AccountID, dividend.Date, dividend.Amount); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to update daily totals: %w", err)
+	}
+
+	var id uuid.UUID
+	if err = tx.QueryRow(ctx, InvestmentDividendCreate, holding.ID, dividend.AccountID, transactionID,
+		dividend.Amount, dividend.Date).Scan(&id); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create dividend: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	dividend.TransactionID = transactionID
+	return id, nil
+}
+
+func (r *Repository) GetInvestors(ctx context.Context) ([]uuid.UUID, error) {
+	var users []uuid.UUID
+
+	rows, err := r.db.Query(ctx, InvestmentInvestors)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		users = append(users, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *Repository) SavePortfolioSnapshot(ctx context.Context, userID uuid.UUID, date time.Time, value float64) error {
+	if _, err := r.db.Exec(ctx, PortfolioSnapshotSave, userID, date, value); err != nil {
+		return fmt.Errorf("[repo] failed to save portfolio snapshot: %w", err)
+	}
+	return nil
+}
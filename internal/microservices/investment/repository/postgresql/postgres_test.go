@@ -0,0 +1,327 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	holdingRowColumns = []string{"id", "user_id", "ticker", "name"}
+	lotRowColumns     = []string{"id", "holding_id", "kind", "quantity", "price", "fee", "date"}
+)
+
+func testHolding() models.Holding {
+	return models.Holding{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Ticker: "SBER",
+		Name:   "Сбербанк",
+	}
+}
+
+func Test_CreateHolding(t *testing.T) {
+	holding := testHolding()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        pgxmock.NewRows([]string{"id"}).AddRow(holding.ID),
+			expected:    holding.ID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create holding: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(HoldingCreate)).
+				WithArgs(holding.UserID, holding.Ticker, holding.Name).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			id, err := repo.CreateHolding(context.Background(), &holding)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("Expected holding %s, but got: %s", tc.expected, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetHolding(t *testing.T) {
+	holding := testHolding()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        pgxmock.NewRows(holdingRowColumns).AddRow(holding.ID, holding.UserID, holding.Ticker, holding.Name),
+			expectedErr: nil,
+		},
+		{
+			name:        "No such holding",
+			rows:        pgxmock.NewRows(holdingRowColumns),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchHoldingError{HoldingID: holding.ID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(holdingRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(HoldingGet)).
+				WithArgs(holding.ID, holding.UserID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			actual, err := repo.GetHolding(context.Background(), holding.UserID, holding.ID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Equal(t, &holding, actual)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetLots(t *testing.T) {
+	userID := uuid.New()
+	holdingID := uuid.New()
+	date := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    []models.InvestmentLot
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(lotRowColumns).
+				AddRow(uuid.Nil, holdingID, models.LotBuy, 10.0, 250.0, 5.0, date),
+			expected: []models.InvestmentLot{
+				{HoldingID: holdingID, Kind: models.LotBuy, Quantity: 10, Price: 250, Fee: 5, Date: date},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(lotRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(InvestmentLotsGet)).
+				WithArgs(userID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			lots, err := repo.GetLots(context.Background(), userID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expected, lots)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_PostDividend(t *testing.T) {
+	accountID := uuid.New()
+	transactionID := uuid.New()
+	dividendID := uuid.New()
+	date := time.Date(2023, time.July, 20, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		execError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			expected:    dividendID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to update account balance: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			holding := testHolding()
+			dividend := models.InvestmentDividend{HoldingID: holding.ID, AccountID: accountID, Amount: 337.5, Date: date}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(InvestmentDividendTransaction)).
+				WithArgs(holding.UserID, accountID, 337.5, date, holding.Ticker, dividendDescription).
+				WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(transactionID))
+			mock.ExpectQuery(regexp.QuoteMeta(accountRep.AccountChargeLock)).
+				WithArgs(accountID).
+				WillReturnRows(pgxmock.NewRows([]string{"id", "balance", "kind", "credit_limit", "overdraft_policy"}).
+					AddRow(accountID, -100.0, models.AccountDebit, 0.0, models.OverdraftReject))
+			balance := mock.ExpectExec(regexp.QuoteMeta(accountRep.AccountCharge)).
+				WithArgs(-337.5, accountID)
+			if tc.execError != nil {
+				balance.WillReturnError(tc.execError)
+				mock.ExpectRollback()
+			} else {
+				balance.WillReturnResult(pgconn.CommandTag("UPDATE 1"))
+				mock.ExpectExec(regexp.QuoteMeta(InvestmentDailyTotals)).
+					WithArgs(holding.UserID, accountID, date, 337.5).
+					WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				mock.ExpectQuery(regexp.QuoteMeta(InvestmentDividendCreate)).
+					WithArgs(holding.ID, accountID, transactionID, 337.5, date).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(dividendID))
+				mock.ExpectCommit()
+			}
+
+			id, err := repo.PostDividend(context.Background(), &holding, &dividend)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("Expected dividend %s, but got: %s", tc.expected, id)
+			}
+			if tc.expectedErr == nil {
+				assert.Equal(t, transactionID, dividend.TransactionID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_SavePortfolioSnapshot(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to save portfolio snapshot: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(PortfolioSnapshotSave)).
+				WithArgs(userID, date, 12500.0)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+			}
+
+			err := repo.SavePortfolioSnapshot(context.Background(), userID, date, 12500)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
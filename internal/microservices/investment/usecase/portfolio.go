@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+// quantityEpsilon hides the float error left after selling the whole position
+const quantityEpsilon = 1e-9
+
+type position struct {
+	quantity float64
+	cost     float64
+	realized float64
+}
+
+// applyLots replays the lots with the average cost method: a buy adds its price and fee to the cost,
+// a sell takes the average cost of the sold part and realizes the rest. The lots are replayed by date,
+// buys of a day before its sells; false is returned when a sell exceeds the quantity held at that date
+func applyLots(lots []models.InvestmentLot) (map[uuid.UUID]*position, bool) {
+	sorted := make([]models.InvestmentLot, len(lots))
+	copy(sorted, lots)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		return sorted[i].Kind == models.LotBuy && sorted[j].Kind == models.LotSell
+	})
+
+	positions := make(map[uuid.UUID]*position)
+	for _, lot := range sorted {
+		p, ok := positions[lot.HoldingID]
+		if !ok {
+			p = &position{}
+			positions[lot.HoldingID] = p
+		}
+
+		if lot.Kind == models.LotBuy {
+			p.quantity += lot.Quantity
+			p.cost += lot.Quantity*lot.Price + lot.Fee
+			continue
+		}
+
+		if lot.Quantity > p.quantity+quantityEpsilon {
+			return nil, false
+		}
+		soldCost := p.cost * lot.Quantity / p.quantity
+		p.realized += lot.Quantity*lot.Price - lot.Fee - soldCost
+		p.cost -= soldCost
+		p.quantity -= lot.Quantity
+		if p.quantity < quantityEpsilon {
+			p.quantity, p.cost = 0, 0
+		}
+	}
+
+	return positions, true
+}
+
+// buildPortfolio values the positions at the market prices, a holding without a price is valued at cost
+func buildPortfolio(holdings []models.Holding, lots []models.InvestmentLot, dividends []models.InvestmentDividend, prices map[string]models.SecurityPrice) *models.Portfolio {
+	positions, _ := applyLots(lots)
+
+	dividendTotals := make(map[uuid.UUID]float64)
+	for _, dividend := range dividends {
+		dividendTotals[dividend.HoldingID] += dividend.Amount
+	}
+
+	portfolio := &models.Portfolio{Holdings: make([]models.HoldingPosition, 0, len(holdings))}
+	for _, holding := range holdings {
+		holdingPosition := models.HoldingPosition{Holding: holding, Dividends: money.Round2(dividendTotals[holding.ID])}
+
+		if p, ok := positions[holding.ID]; ok {
+			holdingPosition.Quantity = p.quantity
+			holdingPosition.Cost = money.Round2(p.cost)
+			holdingPosition.Realized = money.Round2(p.realized)
+			if p.quantity > 0 {
+				holdingPosition.AverageCost = round4(p.cost / p.quantity)
+			}
+		}
+
+		holdingPosition.Value = holdingPosition.Cost
+		if price, ok := prices[holding.Ticker]; ok {
+			priceDate := price.Date
+			holdingPosition.Price = price.Price
+			holdingPosition.PriceDate = &priceDate
+			holdingPosition.Value = money.Round2(holdingPosition.Quantity * price.Price)
+		}
+		holdingPosition.Unrealized = money.Round2(holdingPosition.Value - holdingPosition.Cost)
+
+		portfolio.Value += holdingPosition.Value
+		portfolio.Cost += holdingPosition.Cost
+		portfolio.Realized += holdingPosition.Realized
+		portfolio.Dividends += holdingPosition.Dividends
+		portfolio.Holdings = append(portfolio.Holdings, holdingPosition)
+	}
+
+	portfolio.Value = money.Round2(portfolio.Value)
+	portfolio.Cost = money.Round2(portfolio.Cost)
+	portfolio.Unrealized = money.Round2(portfolio.Value - portfolio.Cost)
+	portfolio.Realized = money.Round2(portfolio.Realized)
+	portfolio.Dividends = money.Round2(portfolio.Dividends)
+
+	return portfolio
+}
+
+func round4(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
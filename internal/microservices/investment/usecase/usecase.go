@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	investmentRepo investment.Repository
+	logger         logger.Logger
+	prices         investment.PriceSource
+	accountRepo    account.Repository
+}
+
+func NewUsecase(ir investment.Repository, log logger.Logger, ps investment.PriceSource, ar account.Repository) *Usecase {
+	return &Usecase{
+		investmentRepo: ir,
+		logger:         log,
+		prices:         ps,
+		accountRepo:    ar,
+	}
+}
+
+func (u *Usecase) CreateHolding(ctx context.Context, userID uuid.UUID, holding *models.Holding) (uuid.UUID, error) {
+	holding.UserID = userID
+
+	id, err := u.investmentRepo.CreateHolding(ctx, holding)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create holding %w", err)
+	}
+	return id, nil
+}
+
+// AddLot records a buy or a sell, a sell can't exceed the quantity held at its date
+func (u *Usecase) AddLot(ctx context.Context, userID uuid.UUID, lot *models.InvestmentLot) (uuid.UUID, error) {
+	if _, err := u.investmentRepo.GetHolding(ctx, userID, lot.HoldingID); err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't get holding from repository %w", err)
+	}
+
+	lot.Date = dates.TruncateDay(lot.Date)
+
+	if lot.Kind == models.LotSell {
+		lots, err := u.GetLots(ctx, userID, lot.HoldingID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if _, ok := applyLots(append(lots, *lot)); !ok {
+			return uuid.Nil, fmt.Errorf("[usecase] %w", &models.InvestmentOperationError{Reason: "sell exceeds the quantity held"})
+		}
+	}
+
+	id, err := u.investmentRepo.CreateLot(ctx, lot)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create lot %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetLots(ctx context.Context, userID uuid.UUID, holdingID uuid.UUID) ([]models.InvestmentLot, error) {
+	lots, err := u.investmentRepo.GetLots(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get lots from repository %w", err)
+	}
+
+	holdingLots := []models.InvestmentLot{}
+	for _, lot := range lots {
+		if lot.HoldingID == holdingID {
+			holdingLots = append(holdingLots, lot)
+		}
+	}
+	return holdingLots, nil
+}
+
+// PostDividend records the dividend as income on an account of the user
+func (u *Usecase) PostDividend(ctx context.Context, userID uuid.UUID, dividend *models.InvestmentDividend) (uuid.UUID, error) {
+	holding, err := u.investmentRepo.GetHolding(ctx, userID, dividend.HoldingID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't get holding from repository %w", err)
+	}
+
+	role, err := u.accountRepo.GetRole(ctx, dividend.AccountID, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't check account %w", err)
+	}
+	if !role.CanAdd(dividend.Amount) {
+		return uuid.Nil, fmt.Errorf("[usecase] %s can't post a dividend to the account %w", role, &models.ForbiddenUserError{})
+	}
+
+	dividend.Date = dates.TruncateDay(dividend.Date)
+
+	id, err := u.investmentRepo.PostDividend(ctx, holding, dividend)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't post dividend %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetPortfolio(ctx context.Context, userID uuid.UUID) (*models.Portfolio, error) {
+	holdings, err := u.investmentRepo.GetHoldings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get holdings from repository %w", err)
+	}
+
+	lots, err := u.investmentRepo.GetLots(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get lots from repository %w", err)
+	}
+
+	dividends, err := u.investmentRepo.GetDividends(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get dividends from repository %w", err)
+	}
+
+	prices, err := u.getPrices(ctx, holdings)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPortfolio(holdings, lots, dividends, prices), nil
+}
+
+// SnapshotPortfolios saves today's portfolio values for the net worth history,
+// a failure for one user does not stop the others
+func (u *Usecase) SnapshotPortfolios(ctx context.Context) error {
+	users, err := u.investmentRepo.GetInvestors(ctx)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get investors from repository %w", err)
+	}
+
+	today := dates.TruncateDay(time.Now())
+
+	var failed int
+	for _, userID := range users {
+		portfolio, err := u.GetPortfolio(ctx, userID)
+		if err == nil {
+			err = u.investmentRepo.SavePortfolioSnapshot(ctx, userID, today, portfolio.Value)
+		}
+		if err != nil {
+			u.logger.Errorf("[usecase] can't snapshot portfolio of user %s: %v", userID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[usecase] portfolio snapshot failed for %d of %d users", failed, len(users))
+	}
+	return nil
+}
+
+func (u *Usecase) getPrices(ctx context.Context, holdings []models.Holding) (map[string]models.SecurityPrice, error) {
+	tickers := make([]string, 0, len(holdings))
+	for _, holding := range holdings {
+		tickers = append(tickers, holding.Ticker)
+	}
+
+	prices, err := u.prices.GetPrices(ctx, tickers)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get prices %w", err)
+	}
+	return prices, nil
+}
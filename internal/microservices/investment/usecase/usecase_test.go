@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/investment/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	sberID = uuid.New()
+	gazpID = uuid.New()
+	day    = time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testLots() []models.InvestmentLot {
+	return []models.InvestmentLot{
+		{HoldingID: sberID, Kind: models.LotSell, Quantity: 5, Price: 150, Fee: 5, Date: day.AddDate(0, 1, 0)},
+		{HoldingID: sberID, Kind: models.LotBuy, Quantity: 10, Price: 100, Fee: 10, Date: day},
+		{HoldingID: sberID, Kind: models.LotBuy, Quantity: 10, Price: 120, Date: day.AddDate(0, 0, 10)},
+		{HoldingID: gazpID, Kind: models.LotBuy, Quantity: 2, Price: 50, Date: day},
+	}
+}
+
+func TestUsecase_CreateHolding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock.NewMockPriceSource(ctrl), mock_account.NewMockRepository(ctrl))
+
+	userID := uuid.New()
+
+	mockRepo.EXPECT().CreateHolding(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, holding *models.Holding) (uuid.UUID, error) {
+			assert.Equal(t, userID, holding.UserID)
+			return sberID, nil
+		})
+	id, err := mockUsecase.CreateHolding(context.Background(), userID, &models.Holding{Ticker: "SBER"})
+	assert.NoError(t, err)
+	assert.Equal(t, sberID, id)
+
+	mockRepo.EXPECT().CreateHolding(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+	_, err = mockUsecase.CreateHolding(context.Background(), userID, &models.Holding{})
+	assert.EqualError(t, err, "[usecase] can't create holding some error")
+}
+
+func TestUsecase_AddLot(t *testing.T) {
+	userID := uuid.New()
+	lotID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		lot         models.InvestmentLot
+		expected    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Buy in TestUsecase_AddLot",
+			lot:         models.InvestmentLot{HoldingID: sberID, Kind: models.LotBuy, Quantity: 1, Price: 100, Date: day.Add(15 * time.Hour)},
+			expected:    lotID,
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockRepository.EXPECT().CreateLot(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, lot *models.InvestmentLot) (uuid.UUID, error) {
+						assert.Equal(t, day, lot.Date)
+						return lotID, nil
+					})
+			},
+		},
+		{
+			name:        "Sell within the position in TestUsecase_AddLot",
+			lot:         models.InvestmentLot{HoldingID: sberID, Kind: models.LotSell, Quantity: 15, Price: 150, Date: day.AddDate(0, 2, 0)},
+			expected:    lotID,
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockRepository.EXPECT().GetLots(gomock.Any(), userID).Return(testLots(), nil)
+				mockRepository.EXPECT().CreateLot(gomock.Any(), gomock.Any()).Return(lotID, nil)
+			},
+		},
+		{
+			name:        "Sell exceeds the position in TestUsecase_AddLot",
+			lot:         models.InvestmentLot{HoldingID: sberID, Kind: models.LotSell, Quantity: 12, Price: 150, Date: day.AddDate(0, 0, 5)},
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] %w", &models.InvestmentOperationError{Reason: "sell exceeds the quantity held"}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockRepository.EXPECT().GetLots(gomock.Any(), userID).Return(testLots(), nil)
+			},
+		},
+		{
+			name:        "No such holding in TestUsecase_AddLot",
+			lot:         models.InvestmentLot{HoldingID: sberID, Kind: models.LotBuy},
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't get holding from repository %w", &models.NoSuchHoldingError{HoldingID: sberID}),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(nil, &models.NoSuchHoldingError{HoldingID: sberID})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock.NewMockPriceSource(ctrl), mock_account.NewMockRepository(ctrl))
+
+			lot := tc.lot
+			actual, err := mockUsecase.AddLot(context.Background(), userID, &lot)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUsecase_PostDividend(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	dividendID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		expected    uuid.UUID
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name:        "Success in TestUsecase_PostDividend",
+			expected:    dividendID,
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().PostDividend(gomock.Any(), &models.Holding{ID: sberID}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *models.Holding, dividend *models.InvestmentDividend) (uuid.UUID, error) {
+						assert.Equal(t, day, dividend.Date)
+						return dividendID, nil
+					})
+			},
+		},
+		{
+			name:        "Account of another user in TestUsecase_PostDividend",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't check account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountRole(""), &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:        "Viewer in TestUsecase_PostDividend",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] viewer can't post a dividend to the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:        "PostDividend Error in TestUsecase_PostDividend",
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[usecase] can't post dividend some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockAccountRepository *mock_account.MockRepository) {
+				mockRepository.EXPECT().GetHolding(gomock.Any(), userID, sberID).Return(&models.Holding{ID: sberID}, nil)
+				mockAccountRepository.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				mockRepository.EXPECT().PostDividend(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mock.NewMockPriceSource(ctrl), mockAccountRepo)
+
+			actual, err := mockUsecase.PostDividend(context.Background(), userID,
+				&models.InvestmentDividend{HoldingID: sberID, AccountID: accountID, Amount: 100, Date: day.Add(12 * time.Hour)})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUsecase_GetPortfolio(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockPrices := mock.NewMockPriceSource(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPrices, mock_account.NewMockRepository(ctrl))
+
+	userID := uuid.New()
+	holdings := []models.Holding{{ID: sberID, Ticker: "SBER"}, {ID: gazpID, Ticker: "GAZP"}}
+
+	mockRepo.EXPECT().GetHoldings(gomock.Any(), userID).Return(holdings, nil)
+	mockRepo.EXPECT().GetLots(gomock.Any(), userID).Return(testLots(), nil)
+	mockRepo.EXPECT().GetDividends(gomock.Any(), userID).Return([]models.InvestmentDividend{}, nil)
+	mockPrices.EXPECT().GetPrices(gomock.Any(), []string{"SBER", "GAZP"}).
+		Return(map[string]models.SecurityPrice{"SBER": {Price: 130, Date: day}}, nil)
+
+	portfolio, err := mockUsecase.GetPortfolio(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2050.0, portfolio.Value)
+
+	mockRepo.EXPECT().GetHoldings(gomock.Any(), userID).Return(holdings, nil)
+	mockRepo.EXPECT().GetLots(gomock.Any(), userID).Return(testLots(), nil)
+	mockRepo.EXPECT().GetDividends(gomock.Any(), userID).Return([]models.InvestmentDividend{}, nil)
+	mockPrices.EXPECT().GetPrices(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+
+	_, err = mockUsecase.GetPortfolio(context.Background(), userID)
+	assert.EqualError(t, err, "[usecase] can't get prices some error")
+}
+
+func TestUsecase_SnapshotPortfolios(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockPrices := mock.NewMockPriceSource(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPrices, mock_account.NewMockRepository(ctrl))
+
+	okUser, failedUser := uuid.New(), uuid.New()
+	holdings := []models.Holding{{ID: gazpID, Ticker: "GAZP"}}
+
+	mockRepo.EXPECT().GetInvestors(gomock.Any()).Return([]uuid.UUID{okUser, failedUser}, nil)
+	mockRepo.EXPECT().GetHoldings(gomock.Any(), okUser).Return(holdings, nil)
+	mockRepo.EXPECT().GetLots(gomock.Any(), okUser).Return(testLots(), nil)
+	mockRepo.EXPECT().GetDividends(gomock.Any(), okUser).Return([]models.InvestmentDividend{}, nil)
+	mockPrices.EXPECT().GetPrices(gomock.Any(), []string{"GAZP"}).Return(map[string]models.SecurityPrice{}, nil)
+	mockRepo.EXPECT().SavePortfolioSnapshot(gomock.Any(), okUser, dates.TruncateDay(time.Now()), 100.0).Return(nil)
+	mockRepo.EXPECT().GetHoldings(gomock.Any(), failedUser).Return(nil, errors.New("some error"))
+
+	err := mockUsecase.SnapshotPortfolios(context.Background())
+	assert.EqualError(t, err, "[usecase] portfolio snapshot failed for 1 of 2 users")
+}
+
+func TestApplyLots(t *testing.T) {
+	positions, ok := applyLots(testLots())
+	assert.True(t, ok)
+	assert.InDelta(t, 15, positions[sberID].quantity, 1e-9)
+	assert.InDelta(t, 1657.5, positions[sberID].cost, 1e-9)
+	assert.InDelta(t, 192.5, positions[sberID].realized, 1e-9)
+
+	// the whole position is sold the day it is bought
+	_, ok = applyLots([]models.InvestmentLot{
+		{HoldingID: sberID, Kind: models.LotSell, Quantity: 3, Price: 10, Date: day},
+		{HoldingID: sberID, Kind: models.LotBuy, Quantity: 3, Price: 10, Date: day},
+	})
+	assert.True(t, ok)
+
+	_, ok = applyLots([]models.InvestmentLot{
+		{HoldingID: sberID, Kind: models.LotBuy, Quantity: 3, Price: 10, Date: day.AddDate(0, 0, 1)},
+		{HoldingID: sberID, Kind: models.LotSell, Quantity: 3, Price: 10, Date: day},
+	})
+	assert.False(t, ok)
+}
+
+func TestBuildPortfolio(t *testing.T) {
+	holdings := []models.Holding{{ID: sberID, Ticker: "SBER"}, {ID: gazpID, Ticker: "GAZP"}}
+	dividends := []models.InvestmentDividend{{HoldingID: sberID, Amount: 60}, {HoldingID: sberID, Amount: 40}}
+	prices := map[string]models.SecurityPrice{"SBER": {Price: 130, Date: day}}
+
+	portfolio := buildPortfolio(holdings, testLots(), dividends, prices)
+
+	assert.Equal(t, &models.Portfolio{
+		Value:      2050,
+		Cost:       1757.5,
+		Unrealized: 292.5,
+		Realized:   192.5,
+		Dividends:  100,
+		Holdings: []models.HoldingPosition{
+			{
+				Holding:     holdings[0],
+				Quantity:    15,
+				AverageCost: 110.5,
+				Cost:        1657.5,
+				Price:       130,
+				PriceDate:   &day,
+				Value:       1950,
+				Unrealized:  292.5,
+				Realized:    192.5,
+				Dividends:   100,
+			},
+			{
+				Holding:     holdings[1],
+				Quantity:    2,
+				AverageCost: 50,
+				Cost:        100,
+				Value:       100,
+			},
+		},
+	}, portfolio)
+}
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	invitationService invitation.Usecase
+	logger            logger.Logger
+}
+
+func NewHandler(iu invitation.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		invitationService: iu,
+		logger:            l,
+	}
+}
+
+// @Summary		Invite to account
+// @Tags		Invitation
+// @Description	The owner invites a user by login, the user becomes a member after accepting
+// @Accept 		json
+// @Produce		json
+// @Param		invitation	body		CreateInvitation					true	"Invitation"
+// @Success		200		{object}	Response[InvitationCreateResponse]	"Invitation created"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  						"Forbidden user"
+// @Failure     404    	{object}  	ResponseError  						"No user found with this login"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/invitation/create [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var invitationInput CreateInvitation
+	if err := easyjson.UnmarshalFromReader(r.Body, &invitationInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := invitationInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	invitationID, err := h.invitationService.Invite(r.Context(), user.ID, invitationInput.ToInvitation())
+
+	var errNoSuchUser *models.NoSuchUserInLogin
+	if errors.As(err, &errNoSuchUser) {
+		commonHttp.ErrorResponse(w, http.StatusNotFound, err, InvitationUserNotFound, h.logger)
+		return
+	}
+
+	var errDuplicate *models.DuplicateError
+	if errors.As(err, &errDuplicate) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, InvitationUserIsMember, h.logger)
+		return
+	}
+
+	if h.invitationError(w, err, InvitationCreateServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, InvitationCreateResponse{InvitationID: invitationID})
+}
+
+// @Summary		Get pending invitations
+// @Tags		Invitation
+// @Description	Invitations of the user waiting for an answer, the latest first
+// @Produce		json
+// @Success		200		{object}	Response[[]models.Invitation]	"Invitations"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/invitation/pending [get]
+func (h *Handler) GetPending(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	invitations, err := h.invitationService.GetPending(r.Context(), user.ID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, InvitationGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, invitations)
+}
+
+// @Summary		Get account invitations
+// @Tags		Invitation
+// @Description	Every invitation to the account with its status, for the owner
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[[]models.Invitation]	"Invitations"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Forbidden user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/invitation/account/{account_id} [get]
+func (h *Handler) GetAccountInvitations(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	invitations, err := h.invitationService.GetAccountInvitations(r.Context(), user.ID, accountID)
+	if h.invitationError(w, err, InvitationGetServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, invitations)
+}
+
+// @Summary		Accept invitation
+// @Tags		Invitation
+// @Description	The invitee becomes a member of the account with the invited role
+// @Produce		json
+// @Param		invitation_id	path		string	true	"Invitation ID"
+// @Success		200		{object}	Response[NilBody]	"Invitation accepted"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/invitation/{invitation_id}/accept [put]
+func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.answer(w, r, h.invitationService.Accept, InvitationAcceptServerError)
+}
+
+// @Summary		Decline invitation
+// @Tags		Invitation
+// @Description	Decline a pending invitation
+// @Produce		json
+// @Param		invitation_id	path		string	true	"Invitation ID"
+// @Success		200		{object}	Response[NilBody]	"Invitation declined"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/invitation/{invitation_id}/decline [put]
+func (h *Handler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.answer(w, r, h.invitationService.Decline, InvitationDeclineServerError)
+}
+
+// @Summary		Revoke invitation
+// @Tags		Invitation
+// @Description	The owner cancels a pending invitation
+// @Produce		json
+// @Param		invitation_id	path		string	true	"Invitation ID"
+// @Success		200		{object}	Response[NilBody]	"Invitation revoked"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  		"Forbidden user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/invitation/{invitation_id}/revoke [put]
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	h.answer(w, r, h.invitationService.Revoke, InvitationRevokeServerError)
+}
+
+// answer runs an action of the user on the invitation from the path
+func (h *Handler) answer(w http.ResponseWriter, r *http.Request, action func(context.Context, uuid.UUID, uuid.UUID) error, serverError string) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	invitationID, err := commonHttp.GetIDFromRequest(invitationID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	if h.invitationError(w, action(r.Context(), user.ID, invitationID), serverError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// invitationError writes the response for an error of an invitation operation, false if there is no error
+func (h *Handler) invitationError(w http.ResponseWriter, err error, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return true
+	}
+
+	var errNoSuchInvitation *models.NoSuchInvitationError
+	if errors.As(err, &errNoSuchInvitation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, InvitationNotSuch, h.logger)
+		return true
+	}
+
+	var errInvitationOperation *models.InvitationOperationError
+	if errors.As(err, &errInvitationOperation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errInvitationOperation.Reason, h.logger)
+		return true
+	}
+
+	commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.logger)
+	return true
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	invitationID = "invitation_id"
+	accountID    = "account_id"
+
+	InvitationCreateServerError  = "can't create invitation"
+	InvitationGetServerError     = "can't get invitations"
+	InvitationAcceptServerError  = "can't accept invitation"
+	InvitationDeclineServerError = "can't decline invitation"
+	InvitationRevokeServerError  = "can't revoke invitation"
+	InvitationNotSuch            = "no such invitation"
+	InvitationUserNotFound       = "no user found with this login"
+	InvitationUserIsMember       = "this user has already been added to the account"
+)
+
+var (
+	errInvalidLogin   = errors.New("login is required")
+	errInvalidAccount = errors.New("account is required")
+)
+
+type InvitationCreateResponse struct {
+	InvitationID uuid.UUID `json:"invitation_id"`
+}
+
+//easyjson:json
+type CreateInvitation struct {
+	Login     string             `json:"login"`
+	AccountID uuid.UUID          `json:"account_id"`
+	Role      models.AccountRole `json:"role"` // editor if empty
+}
+
+func (ci *CreateInvitation) CheckValid() error {
+	ci.Login = strings.TrimSpace(ci.Login)
+
+	switch {
+	case ci.Login == "":
+		return errInvalidLogin
+	case ci.AccountID == uuid.Nil:
+		return errInvalidAccount
+	}
+
+	return nil
+}
+
+func (ci *CreateInvitation) ToInvitation() *models.Invitation {
+	return &models.Invitation{
+		AccountID:    ci.AccountID,
+		InviteeLogin: ci.Login,
+		Role:         ci.Role,
+	}
+}
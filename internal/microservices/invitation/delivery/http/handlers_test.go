@@ -0,0 +1,319 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Create(t *testing.T) {
+	uuidTest := uuid.New()
+	accountID := uuid.MustParse("0f4b8c2e-6d1a-4e7f-9b3c-2a5d8e1f7c40")
+	invitationID := uuid.MustParse("8d3a1f6e-2c7b-4a90-b5e4-7f1c0d9a3b26")
+	user := &models.User{ID: uuidTest}
+	validBody := `{"login":" friend ","account_id":"0f4b8c2e-6d1a-4e7f-9b3c-2a5d8e1f7c40","role":"viewer"}`
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Create",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"invitation_id":"8d3a1f6e-2c7b-4a90-b5e4-7f1c0d9a3b26"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Invite(gomock.Any(), uuidTest,
+					&models.Invitation{AccountID: accountID, InviteeLogin: "friend", Role: models.AccountViewer}).
+					Return(invitationID, nil)
+			},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+		{
+			name:          "Empty login",
+			user:          user,
+			body:          `{"login":" ","account_id":"0f4b8c2e-6d1a-4e7f-9b3c-2a5d8e1f7c40"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+		{
+			name:         "No such user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"status":404,"message":"no user found with this login"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Invite(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, &models.NoSuchUserInLogin{})
+			},
+		},
+		{
+			name:         "Already a member",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"this user has already been added to the account"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Invite(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, &models.DuplicateError{})
+			},
+		},
+		{
+			name:         "Already invited",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"user is already invited"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Invite(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, &models.InvitationOperationError{Reason: "user is already invited"})
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Invite(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't create invitation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Invite(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/invitation/create", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Create(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetPending(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetPending",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetPending(gomock.Any(), uuidTest).Return([]models.Invitation{}, nil)
+			},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get invitations"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetPending(gomock.Any(), uuidTest).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/invitation/pending", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetPending(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Answer(t *testing.T) {
+	uuidTest := uuid.New()
+	invitationID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		handler       func(*Handler) http.HandlerFunc
+		invitationID  string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Accept",
+			handler:      func(h *Handler) http.HandlerFunc { return h.Accept },
+			invitationID: invitationID.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Accept(gomock.Any(), uuidTest, invitationID).Return(nil)
+			},
+		},
+		{
+			name:         "Accept expired",
+			handler:      func(h *Handler) http.HandlerFunc { return h.Accept },
+			invitationID: invitationID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such invitation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Accept(gomock.Any(), uuidTest, invitationID).
+					Return(&models.NoSuchInvitationError{InvitationID: invitationID})
+			},
+		},
+		{
+			name:         "Decline",
+			handler:      func(h *Handler) http.HandlerFunc { return h.Decline },
+			invitationID: invitationID.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Decline(gomock.Any(), uuidTest, invitationID).Return(nil)
+			},
+		},
+		{
+			name:         "Revoke forbidden",
+			handler:      func(h *Handler) http.HandlerFunc { return h.Revoke },
+			invitationID: invitationID.String(),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Revoke(gomock.Any(), uuidTest, invitationID).Return(&models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Revoke answered",
+			handler:      func(h *Handler) http.HandlerFunc { return h.Revoke },
+			invitationID: invitationID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invitation is already answered"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Revoke(gomock.Any(), uuidTest, invitationID).
+					Return(&models.InvitationOperationError{Reason: "invitation is already answered"})
+			},
+		},
+		{
+			name:         "Revoke server error",
+			handler:      func(h *Handler) http.HandlerFunc { return h.Revoke },
+			invitationID: invitationID.String(),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't revoke invitation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Revoke(gomock.Any(), uuidTest, invitationID).Return(errors.New("some error"))
+			},
+		},
+		{
+			name:          "Invalid invitation id",
+			handler:       func(h *Handler) http.HandlerFunc { return h.Accept },
+			invitationID:  "invalid",
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/invitation/"+tt.invitationID, nil)
+			req = mux.SetURLVars(req, map[string]string{"invitation_id": tt.invitationID})
+
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+
+			tt.handler(mockHandler)(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
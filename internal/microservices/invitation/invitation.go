@@ -0,0 +1,28 @@
+package invitation
+
+import (
+	"context"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	Invite(ctx context.Context, inviterID uuid.UUID, invitation *models.Invitation) (uuid.UUID, error)
+	GetPending(ctx context.Context, userID uuid.UUID) ([]models.Invitation, error)
+	GetAccountInvitations(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) ([]models.Invitation, error)
+	Accept(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error
+	Decline(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error
+	Revoke(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error
+}
+
+type Repository interface {
+	CreateInvitation(ctx context.Context, invitation *models.Invitation) (uuid.UUID, error)
+	HasPendingInvitation(ctx context.Context, accountID uuid.UUID, inviteeID uuid.UUID) (bool, error)
+	GetInvitation(ctx context.Context, invitationID uuid.UUID) (*models.Invitation, error)
+	GetPendingInvitations(ctx context.Context, inviteeID uuid.UUID) ([]models.Invitation, error)
+	GetAccountInvitations(ctx context.Context, accountID uuid.UUID) ([]models.Invitation, error)
+	AcceptInvitation(ctx context.Context, invitationID uuid.UUID, inviteeID uuid.UUID) error
+	DeclineInvitation(ctx context.Context, invitationID uuid.UUID, inviteeID uuid.UUID) error
+	RevokeInvitation(ctx context.Context, invitationID uuid.UUID) error
+}
@@ -0,0 +1,264 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invitation.go
+
+// Package mock_invitation is a generated GoMock package.
+package mock_invitation
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockUsecase) Accept(ctx context.Context, userID, invitationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, userID, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockUsecaseMockRecorder) Accept(ctx, userID, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockUsecase)(nil).Accept), ctx, userID, invitationID)
+}
+
+// Decline mocks base method.
+func (m *MockUsecase) Decline(ctx context.Context, userID, invitationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, userID, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockUsecaseMockRecorder) Decline(ctx, userID, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockUsecase)(nil).Decline), ctx, userID, invitationID)
+}
+
+// GetAccountInvitations mocks base method.
+func (m *MockUsecase) GetAccountInvitations(ctx context.Context, userID, accountID uuid.UUID) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountInvitations", ctx, userID, accountID)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountInvitations indicates an expected call of GetAccountInvitations.
+func (mr *MockUsecaseMockRecorder) GetAccountInvitations(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInvitations", reflect.TypeOf((*MockUsecase)(nil).GetAccountInvitations), ctx, userID, accountID)
+}
+
+// GetPending mocks base method.
+func (m *MockUsecase) GetPending(ctx context.Context, userID uuid.UUID) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, userID)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockUsecaseMockRecorder) GetPending(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockUsecase)(nil).GetPending), ctx, userID)
+}
+
+// Invite mocks base method.
+func (m *MockUsecase) Invite(ctx context.Context, inviterID uuid.UUID, invitation *models.Invitation) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, inviterID, invitation)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockUsecaseMockRecorder) Invite(ctx, inviterID, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockUsecase)(nil).Invite), ctx, inviterID, invitation)
+}
+
+// Revoke mocks base method.
+func (m *MockUsecase) Revoke(ctx context.Context, userID, invitationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockUsecaseMockRecorder) Revoke(ctx, userID, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockUsecase)(nil).Revoke), ctx, userID, invitationID)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockRepository) AcceptInvitation(ctx context.Context, invitationID, inviteeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, invitationID, inviteeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockRepositoryMockRecorder) AcceptInvitation(ctx, invitationID, inviteeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockRepository)(nil).AcceptInvitation), ctx, invitationID, inviteeID)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, invitation)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockRepositoryMockRecorder) CreateInvitation(ctx, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockRepository)(nil).CreateInvitation), ctx, invitation)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockRepository) DeclineInvitation(ctx context.Context, invitationID, inviteeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", ctx, invitationID, inviteeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockRepositoryMockRecorder) DeclineInvitation(ctx, invitationID, inviteeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockRepository)(nil).DeclineInvitation), ctx, invitationID, inviteeID)
+}
+
+// GetAccountInvitations mocks base method.
+func (m *MockRepository) GetAccountInvitations(ctx context.Context, accountID uuid.UUID) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountInvitations", ctx, accountID)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountInvitations indicates an expected call of GetAccountInvitations.
+func (mr *MockRepositoryMockRecorder) GetAccountInvitations(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInvitations", reflect.TypeOf((*MockRepository)(nil).GetAccountInvitations), ctx, accountID)
+}
+
+// GetInvitation mocks base method.
+func (m *MockRepository) GetInvitation(ctx context.Context, invitationID uuid.UUID) (*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", ctx, invitationID)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockRepositoryMockRecorder) GetInvitation(ctx, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockRepository)(nil).GetInvitation), ctx, invitationID)
+}
+
+// GetPendingInvitations mocks base method.
+func (m *MockRepository) GetPendingInvitations(ctx context.Context, inviteeID uuid.UUID) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvitations", ctx, inviteeID)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvitations indicates an expected call of GetPendingInvitations.
+func (mr *MockRepositoryMockRecorder) GetPendingInvitations(ctx, inviteeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvitations", reflect.TypeOf((*MockRepository)(nil).GetPendingInvitations), ctx, inviteeID)
+}
+
+// HasPendingInvitation mocks base method.
+func (m *MockRepository) HasPendingInvitation(ctx context.Context, accountID, inviteeID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingInvitation", ctx, accountID, inviteeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPendingInvitation indicates an expected call of HasPendingInvitation.
+func (mr *MockRepositoryMockRecorder) HasPendingInvitation(ctx, accountID, inviteeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingInvitation", reflect.TypeOf((*MockRepository)(nil).HasPendingInvitation), ctx, accountID, inviteeID)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockRepository) RevokeInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", ctx, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockRepositoryMockRecorder) RevokeInvitation(ctx, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockRepository)(nil).RevokeInvitation), ctx, invitationID)
+}
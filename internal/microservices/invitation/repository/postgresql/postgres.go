@@ -0,0 +1,210 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	InvitationCreate = `INSERT INTO AccountInvitation (account_id, inviter_id, invitee_id, role, expires_at)
+						VALUES ($1, $2, $3, $4, $5) RETURNING id;`
+
+	InvitationPendingCheck = `SELECT EXISTS(SELECT 1 FROM AccountInvitation
+							  WHERE account_id = $1 AND invitee_id = $2 AND status = 'pending' AND expires_at > now());`
+
+	// a pending invitation past its expiration is shown as expired
+	invitationSelect = `SELECT i.id, i.account_id, COALESCE(a.mean_payment, ''), i.inviter_id, inviter.login,
+							   i.invitee_id, invitee.login, i.role,
+							   CASE WHEN i.status = 'pending' AND i.expires_at <= now() THEN 'expired' ELSE i.status END,
+							   i.created_at, i.expires_at
+						FROM AccountInvitation i
+						JOIN Accounts a ON a.id = i.account_id
+						JOIN Users inviter ON inviter.id = i.inviter_id
+						JOIN Users invitee ON invitee.id = i.invitee_id`
+
+	InvitationGet = invitationSelect + `
+						WHERE i.id = $1;`
+
+	InvitationsPendingGet = invitationSelect + `
+						WHERE i.invitee_id = $1 AND i.status = 'pending' AND i.expires_at > now()
+						ORDER BY i.created_at DESC;`
+
+	InvitationsAccountGet = invitationSelect + `
+						WHERE i.account_id = $1
+						ORDER BY i.created_at DESC;`
+
+	// only the invitee answers a pending invitation before it expires
+	InvitationAccept = `UPDATE AccountInvitation SET status = 'accepted', answered_at = now()
+						WHERE id = $1 AND invitee_id = $2 AND status = 'pending' AND expires_at > now()
+						RETURNING account_id, role;`
+	InvitationDecline = `UPDATE AccountInvitation SET status = 'declined', answered_at = now()
+						 WHERE id = $1 AND invitee_id = $2 AND status = 'pending' AND expires_at > now();`
+	InvitationRevoke = `UPDATE AccountInvitation SET status = 'revoked', answered_at = now()
+						WHERE id = $1 AND status = 'pending';`
+
+	InvitationMemberCreate = `INSERT INTO UserAccount (user_id, account_id, role) VALUES ($1, $2, $3)
+							  ON CONFLICT (user_id, account_id) DO NOTHING;`
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) CreateInvitation(ctx context.Context, invitation *models.Invitation) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, InvitationCreate,
+		invitation.AccountID,
+		invitation.InviterID,
+		invitation.InviteeID,
+		invitation.Role,
+		invitation.ExpiresAt,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create invitation: %w", err)
+	}
+	return id, nil
+}
+
+func (r *Repository) HasPendingInvitation(ctx context.Context, accountID uuid.UUID, inviteeID uuid.UUID) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, InvitationPendingCheck, accountID, inviteeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("[repo] %w", err)
+	}
+	return exists, nil
+}
+
+func (r *Repository) GetInvitation(ctx context.Context, invitationID uuid.UUID) (*models.Invitation, error) {
+	invitation, err := scanInvitation(r.db.QueryRow(ctx, InvitationGet, invitationID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchInvitationError{InvitationID: invitationID})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &invitation, nil
+}
+
+func (r *Repository) GetPendingInvitations(ctx context.Context, inviteeID uuid.UUID) ([]models.Invitation, error) {
+	return r.getInvitations(ctx, InvitationsPendingGet, inviteeID)
+}
+
+func (r *Repository) GetAccountInvitations(ctx context.Context, accountID uuid.UUID) ([]models.Invitation, error) {
+	return r.getInvitations(ctx, InvitationsAccountGet, accountID)
+}
+
+// AcceptInvitation makes the invitee a member of the account with the invited role
+func (r *Repository) AcceptInvitation(ctx context.Context, invitationID uuid.UUID, inviteeID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	var (
+		accountID uuid.UUID
+		role      models.AccountRole
+	)
+	err = tx.QueryRow(ctx, InvitationAccept, invitationID, inviteeID).Scan(&accountID, &role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("[repo] %w", &models.NoSuchInvitationError{InvitationID: invitationID})
+	}
+	if err != nil {
+		return fmt.Errorf("[repo] failed to accept invitation: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, InvitationMemberCreate, inviteeID, accountID, role); err != nil {
+		return fmt.Errorf("[repo] can't create accountUser: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeclineInvitation(ctx context.Context, invitationID uuid.UUID, inviteeID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, InvitationDecline, invitationID, inviteeID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to decline invitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.NoSuchInvitationError{InvitationID: invitationID})
+	}
+	return nil
+}
+
+func (r *Repository) RevokeInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, InvitationRevoke, invitationID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to revoke invitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.InvitationOperationError{Reason: "invitation is already answered"})
+	}
+	return nil
+}
+
+func (r *Repository) getInvitations(ctx context.Context, query string, id uuid.UUID) ([]models.Invitation, error) {
+	invitations := []models.Invitation{}
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return invitations, nil
+}
+
+func scanInvitation(row pgx.Row) (models.Invitation, error) {
+	var invitation models.Invitation
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.AccountID,
+		&invitation.AccountName,
+		&invitation.InviterID,
+		&invitation.InviterLogin,
+		&invitation.InviteeID,
+		&invitation.InviteeLogin,
+		&invitation.Role,
+		&invitation.Status,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+	)
+	return invitation, err
+}
@@ -0,0 +1,419 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var invitationRowColumns = []string{"id", "account_id", "mean_payment", "inviter_id", "inviter_login",
+	"invitee_id", "invitee_login", "role", "status", "created_at", "expires_at"}
+
+func testInvitation() models.Invitation {
+	createdAt := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	return models.Invitation{
+		ID:           uuid.New(),
+		AccountID:    uuid.New(),
+		AccountName:  "Семейный",
+		InviterID:    uuid.New(),
+		InviterLogin: "owner",
+		InviteeID:    uuid.New(),
+		InviteeLogin: "friend",
+		Role:         models.AccountEditor,
+		Status:       models.InvitationPending,
+		CreatedAt:    createdAt,
+		ExpiresAt:    createdAt.Add(7 * 24 * time.Hour),
+	}
+}
+
+func invitationRow(rows *pgxmock.Rows, i models.Invitation) *pgxmock.Rows {
+	return rows.AddRow(i.ID, i.AccountID, i.AccountName, i.InviterID, i.InviterLogin,
+		i.InviteeID, i.InviteeLogin, i.Role, i.Status, i.CreatedAt, i.ExpiresAt)
+}
+
+func Test_CreateInvitation(t *testing.T) {
+	invitation := testInvitation()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			rows:     pgxmock.NewRows([]string{"id"}).AddRow(invitation.ID),
+			expected: invitation.ID,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create invitation: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(InvitationCreate)).
+				WithArgs(invitation.AccountID, invitation.InviterID, invitation.InviteeID, invitation.Role, invitation.ExpiresAt).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			id, err := repo.CreateInvitation(context.Background(), &invitation)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, id)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_HasPendingInvitation(t *testing.T) {
+	accountID := uuid.New()
+	inviteeID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name:     "Pending",
+			rows:     pgxmock.NewRows([]string{"exists"}).AddRow(true),
+			expected: true,
+		},
+		{
+			name:     "Not invited",
+			rows:     pgxmock.NewRows([]string{"exists"}).AddRow(false),
+			expected: false,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"exists"}),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(InvitationPendingCheck)).
+				WithArgs(accountID, inviteeID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			pending, err := repo.HasPendingInvitation(context.Background(), accountID, inviteeID)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, pending)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetInvitation(t *testing.T) {
+	invitation := testInvitation()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    *models.Invitation
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			rows:     invitationRow(pgxmock.NewRows(invitationRowColumns), invitation),
+			expected: &invitation,
+		},
+		{
+			name:        "No such invitation",
+			rows:        pgxmock.NewRows(invitationRowColumns),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchInvitationError{InvitationID: invitation.ID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(invitationRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(InvitationGet)).
+				WithArgs(invitation.ID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := repo.GetInvitation(context.Background(), invitation.ID)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetPendingInvitations(t *testing.T) {
+	invitation := testInvitation()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    []models.Invitation
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			rows:     invitationRow(pgxmock.NewRows(invitationRowColumns), invitation),
+			expected: []models.Invitation{invitation},
+		},
+		{
+			name:     "No invitations",
+			rows:     pgxmock.NewRows(invitationRowColumns),
+			expected: []models.Invitation{},
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(invitationRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(InvitationsPendingGet)).
+				WithArgs(invitation.InviteeID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := repo.GetPendingInvitations(context.Background(), invitation.InviteeID)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_AcceptInvitation(t *testing.T) {
+	invitation := testInvitation()
+
+	testCases := []struct {
+		name        string
+		acceptError error
+		memberError error
+		expectedErr error
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name:        "No pending invitation",
+			acceptError: pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchInvitationError{InvitationID: invitation.ID}),
+		},
+		{
+			name:        "Member error",
+			memberError: errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] can't create accountUser: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			rows := pgxmock.NewRows([]string{"account_id", "role"})
+			if tc.acceptError == nil {
+				rows.AddRow(invitation.AccountID, invitation.Role)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(InvitationAccept)).
+				WithArgs(invitation.ID, invitation.InviteeID).
+				WillReturnRows(rows).
+				WillReturnError(tc.acceptError)
+
+			if tc.acceptError == nil {
+				member := mock.ExpectExec(regexp.QuoteMeta(InvitationMemberCreate)).
+					WithArgs(invitation.InviteeID, invitation.AccountID, invitation.Role)
+				if tc.memberError != nil {
+					member.WillReturnError(tc.memberError)
+				} else {
+					member.WillReturnResult(pgconn.CommandTag("INSERT 0 1"))
+				}
+			}
+
+			if tc.expectedErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err := repo.AcceptInvitation(context.Background(), invitation.ID, invitation.InviteeID)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_DeclineInvitation(t *testing.T) {
+	invitation := testInvitation()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:        "No pending invitation",
+			result:      pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchInvitationError{InvitationID: invitation.ID}),
+		},
+		{
+			name:        "Exec error",
+			execError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to decline invitation: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(InvitationDecline)).
+				WithArgs(invitation.ID, invitation.InviteeID)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := repo.DeclineInvitation(context.Background(), invitation.ID, invitation.InviteeID)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_RevokeInvitation(t *testing.T) {
+	invitationID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:        "Already answered",
+			result:      pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.InvitationOperationError{Reason: "invitation is already answered"}),
+		},
+		{
+			name:        "Exec error",
+			execError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to revoke invitation: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(InvitationRevoke)).
+				WithArgs(invitationID)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := repo.RevokeInvitation(context.Background(), invitationID)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+// invitationTTL is how long the invitee has to answer
+const invitationTTL = 7 * 24 * time.Hour
+
+type Usecase struct {
+	invitationRepo invitation.Repository
+	accountRepo    account.Repository
+	userRepo       user.Repository
+	logger         logger.Logger
+}
+
+func NewUsecase(
+	ir invitation.Repository,
+	log logger.Logger,
+	ar account.Repository,
+	ur user.Repository) *Usecase {
+	return &Usecase{
+		invitationRepo: ir,
+		accountRepo:    ar,
+		userRepo:       ur,
+		logger:         log,
+	}
+}
+
+// Invite sends an invitation to the user with InviteeLogin, only the owner of the account invites
+func (u *Usecase) Invite(ctx context.Context, inviterID uuid.UUID, invitation *models.Invitation) (uuid.UUID, error) {
+	if invitation.Role == "" {
+		invitation.Role = models.AccountEditor
+	}
+	if !invitation.Role.Assignable() {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.InvitationOperationError{Reason: "role must be editor, viewer or contributor"})
+	}
+
+	if err := u.checkOwner(ctx, invitation.AccountID, inviterID); err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't invite to the account: %w", err)
+	}
+
+	invitee, err := u.userRepo.GetUserByLogin(ctx, invitation.InviteeLogin)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't get user in login: %w", err)
+	}
+	if invitee.ID == inviterID {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.InvitationOperationError{Reason: "can't invite yourself"})
+	}
+
+	if err := u.accountRepo.CheckDuplicate(ctx, invitee.ID, invitation.AccountID); err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", err)
+	}
+
+	pending, err := u.invitationRepo.HasPendingInvitation(ctx, invitation.AccountID, invitee.ID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't check invitations %w", err)
+	}
+	if pending {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.InvitationOperationError{Reason: "user is already invited"})
+	}
+
+	invitation.InviterID = inviterID
+	invitation.InviteeID = invitee.ID
+	invitation.ExpiresAt = time.Now().Add(invitationTTL)
+
+	id, err := u.invitationRepo.CreateInvitation(ctx, invitation)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create invitation %w", err)
+	}
+	return id, nil
+}
+
+func (u *Usecase) GetPending(ctx context.Context, userID uuid.UUID) ([]models.Invitation, error) {
+	invitations, err := u.invitationRepo.GetPendingInvitations(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get invitations from repository %w", err)
+	}
+	return invitations, nil
+}
+
+// GetAccountInvitations returns every invitation to the account for its owner
+func (u *Usecase) GetAccountInvitations(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) ([]models.Invitation, error) {
+	if err := u.checkOwner(ctx, accountID, userID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't get invitations of the account: %w", err)
+	}
+
+	invitations, err := u.invitationRepo.GetAccountInvitations(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get invitations from repository %w", err)
+	}
+	return invitations, nil
+}
+
+func (u *Usecase) Accept(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error {
+	if err := u.invitationRepo.AcceptInvitation(ctx, invitationID, userID); err != nil {
+		return fmt.Errorf("[usecase] can't accept invitation %w", err)
+	}
+	return nil
+}
+
+func (u *Usecase) Decline(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error {
+	if err := u.invitationRepo.DeclineInvitation(ctx, invitationID, userID); err != nil {
+		return fmt.Errorf("[usecase] can't decline invitation %w", err)
+	}
+	return nil
+}
+
+// Revoke cancels a pending invitation, only the owner of the account does it
+func (u *Usecase) Revoke(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error {
+	invitation, err := u.invitationRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get invitation from repository %w", err)
+	}
+
+	if err := u.checkOwner(ctx, invitation.AccountID, userID); err != nil {
+		return fmt.Errorf("[usecase] can't revoke invitation: %w", err)
+	}
+
+	if err := u.invitationRepo.RevokeInvitation(ctx, invitationID); err != nil {
+		return fmt.Errorf("[usecase] can't revoke invitation %w", err)
+	}
+	return nil
+}
+
+func (u *Usecase) checkOwner(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error {
+	role, err := u.accountRepo.GetRole(ctx, accountID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.CanManage() {
+		return fmt.Errorf("[usecase] %s can't manage members: %w", role, &models.ForbiddenUserError{})
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/invitation/mocks"
+	mock_user "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_Invite(t *testing.T) {
+	ownerID := uuid.New()
+	accountID := uuid.New()
+	invitationID := uuid.New()
+	friend := &models.User{ID: uuid.New(), Login: "friend"}
+
+	testCases := []struct {
+		name         string
+		role         models.AccountRole
+		expected     uuid.UUID
+		expectedErr  error
+		forbidden    bool
+		operationErr string
+		mockFn       func(*mock.MockRepository, *mock_account.MockRepository, *mock_user.MockRepository)
+	}{
+		{
+			name:     "Success with default role",
+			expected: invitationID,
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository, ur *mock_user.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, ownerID).Return(models.AccountOwner, nil)
+				ur.EXPECT().GetUserByLogin(gomock.Any(), "friend").Return(friend, nil)
+				ar.EXPECT().CheckDuplicate(gomock.Any(), friend.ID, accountID).Return(nil)
+				ir.EXPECT().HasPendingInvitation(gomock.Any(), accountID, friend.ID).Return(false, nil)
+				ir.EXPECT().CreateInvitation(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, invitation *models.Invitation) (uuid.UUID, error) {
+						assert.Equal(t, models.AccountEditor, invitation.Role)
+						assert.Equal(t, ownerID, invitation.InviterID)
+						assert.Equal(t, friend.ID, invitation.InviteeID)
+						assert.WithinDuration(t, time.Now().Add(invitationTTL), invitation.ExpiresAt, time.Minute)
+						return invitationID, nil
+					})
+			},
+		},
+		{
+			name:         "Owner role",
+			role:         models.AccountOwner,
+			operationErr: "role must be editor, viewer or contributor",
+			mockFn:       func(*mock.MockRepository, *mock_account.MockRepository, *mock_user.MockRepository) {},
+		},
+		{
+			name:      "Not the owner",
+			role:      models.AccountViewer,
+			forbidden: true,
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository, ur *mock_user.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, ownerID).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:         "Invite yourself",
+			operationErr: "can't invite yourself",
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository, ur *mock_user.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, ownerID).Return(models.AccountOwner, nil)
+				ur.EXPECT().GetUserByLogin(gomock.Any(), "friend").Return(&models.User{ID: ownerID}, nil)
+			},
+		},
+		{
+			name:        "Already a member",
+			expectedErr: &models.DuplicateError{},
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository, ur *mock_user.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, ownerID).Return(models.AccountOwner, nil)
+				ur.EXPECT().GetUserByLogin(gomock.Any(), "friend").Return(friend, nil)
+				ar.EXPECT().CheckDuplicate(gomock.Any(), friend.ID, accountID).Return(&models.DuplicateError{})
+			},
+		},
+		{
+			name:         "Already invited",
+			operationErr: "user is already invited",
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository, ur *mock_user.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, ownerID).Return(models.AccountOwner, nil)
+				ur.EXPECT().GetUserByLogin(gomock.Any(), "friend").Return(friend, nil)
+				ar.EXPECT().CheckDuplicate(gomock.Any(), friend.ID, accountID).Return(nil)
+				ir.EXPECT().HasPendingInvitation(gomock.Any(), accountID, friend.ID).Return(true, nil)
+			},
+		},
+		{
+			name:        "Create error",
+			expectedErr: errors.New("err"),
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository, ur *mock_user.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, ownerID).Return(models.AccountOwner, nil)
+				ur.EXPECT().GetUserByLogin(gomock.Any(), "friend").Return(friend, nil)
+				ar.EXPECT().CheckDuplicate(gomock.Any(), friend.ID, accountID).Return(nil)
+				ir.EXPECT().HasPendingInvitation(gomock.Any(), accountID, friend.ID).Return(false, nil)
+				ir.EXPECT().CreateInvitation(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			mockUserRepo := mock_user.NewMockRepository(ctrl)
+			tc.mockFn(mockRepo, mockAccountRepo, mockUserRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo, mockUserRepo)
+
+			id, err := mockUsecase.Invite(context.Background(), ownerID,
+				&models.Invitation{AccountID: accountID, InviteeLogin: "friend", Role: tc.role})
+
+			assert.Equal(t, tc.expected, id)
+			switch {
+			case tc.forbidden:
+				var errForbidden *models.ForbiddenUserError
+				assert.ErrorAs(t, err, &errForbidden)
+			case tc.operationErr != "":
+				var errOperation *models.InvitationOperationError
+				if assert.ErrorAs(t, err, &errOperation) {
+					assert.Equal(t, tc.operationErr, errOperation.Reason)
+				}
+			case tc.expectedErr != nil:
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_GetAccountInvitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAccountRepo := mock_account.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo, mock_user.NewMockRepository(ctrl))
+
+	userID := uuid.New()
+	accountID := uuid.New()
+	invitations := []models.Invitation{{ID: uuid.New(), AccountID: accountID, Status: models.InvitationExpired}}
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+	mockRepo.EXPECT().GetAccountInvitations(gomock.Any(), accountID).Return(invitations, nil)
+	result, err := mockUsecase.GetAccountInvitations(context.Background(), userID, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, invitations, result)
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountContributor, nil)
+	_, err = mockUsecase.GetAccountInvitations(context.Background(), userID, accountID)
+	var errForbidden *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbidden)
+}
+
+func TestUsecase_Answer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()),
+		mock_account.NewMockRepository(ctrl), mock_user.NewMockRepository(ctrl))
+
+	userID := uuid.New()
+	invitationID := uuid.New()
+	noSuch := &models.NoSuchInvitationError{InvitationID: invitationID}
+
+	mockRepo.EXPECT().AcceptInvitation(gomock.Any(), invitationID, userID).Return(nil)
+	assert.NoError(t, mockUsecase.Accept(context.Background(), userID, invitationID))
+
+	mockRepo.EXPECT().AcceptInvitation(gomock.Any(), invitationID, userID).Return(noSuch)
+	assert.ErrorIs(t, mockUsecase.Accept(context.Background(), userID, invitationID), noSuch)
+
+	mockRepo.EXPECT().DeclineInvitation(gomock.Any(), invitationID, userID).Return(nil)
+	assert.NoError(t, mockUsecase.Decline(context.Background(), userID, invitationID))
+
+	mockRepo.EXPECT().DeclineInvitation(gomock.Any(), invitationID, userID).Return(noSuch)
+	assert.ErrorIs(t, mockUsecase.Decline(context.Background(), userID, invitationID), noSuch)
+}
+
+func TestUsecase_Revoke(t *testing.T) {
+	userID := uuid.New()
+	invitation := &models.Invitation{ID: uuid.New(), AccountID: uuid.New()}
+
+	testCases := []struct {
+		name      string
+		forbidden bool
+		wantErr   bool
+		mockFn    func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name: "Success",
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository) {
+				ir.EXPECT().GetInvitation(gomock.Any(), invitation.ID).Return(invitation, nil)
+				ar.EXPECT().GetRole(gomock.Any(), invitation.AccountID, userID).Return(models.AccountOwner, nil)
+				ir.EXPECT().RevokeInvitation(gomock.Any(), invitation.ID).Return(nil)
+			},
+		},
+		{
+			name:      "Not the owner",
+			forbidden: true,
+			wantErr:   true,
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository) {
+				ir.EXPECT().GetInvitation(gomock.Any(), invitation.ID).Return(invitation, nil)
+				ar.EXPECT().GetRole(gomock.Any(), invitation.AccountID, userID).Return(models.AccountEditor, nil)
+			},
+		},
+		{
+			name:    "No such invitation",
+			wantErr: true,
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository) {
+				ir.EXPECT().GetInvitation(gomock.Any(), invitation.ID).
+					Return(nil, &models.NoSuchInvitationError{InvitationID: invitation.ID})
+			},
+		},
+		{
+			name:    "Already answered",
+			wantErr: true,
+			mockFn: func(ir *mock.MockRepository, ar *mock_account.MockRepository) {
+				ir.EXPECT().GetInvitation(gomock.Any(), invitation.ID).Return(invitation, nil)
+				ar.EXPECT().GetRole(gomock.Any(), invitation.AccountID, userID).Return(models.AccountOwner, nil)
+				ir.EXPECT().RevokeInvitation(gomock.Any(), invitation.ID).
+					Return(&models.InvitationOperationError{Reason: "invitation is already answered"})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockFn(mockRepo, mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo, mock_user.NewMockRepository(ctrl))
+
+			err := mockUsecase.Revoke(context.Background(), userID, invitation.ID)
+
+			if tc.forbidden {
+				var errForbidden *models.ForbiddenUserError
+				assert.ErrorAs(t, err, &errForbidden)
+			}
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	payeeService payee.Usecase
+	logger       logger.Logger
+}
+
+func NewHandler(pu payee.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		payeeService: pu,
+		logger:       l,
+	}
+}
+
+// @Summary		Get payees
+// @Tags		Payee
+// @Description	Payees of the user with the raw payer aliases linked to them
+// @Produce		json
+// @Success		200		{object}	Response[[]models.Payee]	"Payees"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/payee/all [get]
+func (h *Handler) GetPayees(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	payees, err := h.payeeService.GetPayees(r.Context(), user.ID)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, PayeeGetServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, payees)
+}
+
+// @Summary		Merge payees
+// @Tags		Payee
+// @Description	Move the aliases and transactions of the sources to the target, the sources are deleted
+// @Accept 		json
+// @Produce		json
+// @Param		payees	body		MergePayees			true	"Target and sources"
+// @Success		200		{object}	Response[NilBody]	"Payees merged"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  		"Forbidden user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/payee/merge [post]
+func (h *Handler) Merge(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var mergeInput MergePayees
+	if err := easyjson.UnmarshalFromReader(r.Body, &mergeInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := mergeInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	err = h.payeeService.MergePayees(r.Context(), user.ID, mergeInput.TargetID, mergeInput.SourceIDs)
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, PayeeMergeServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Get top payees
+// @Tags		Payee
+// @Description	Payees with the biggest spending or the most payments for the period, transfers are not counted
+// @Produce		json
+// @Param		sort		query		string	false	"spend (default) or count"
+// @Param		limit		query		int		false	"Number of payees, 10 by default"
+// @Param		start_date	query		string	false	"Period start, a month before end_date by default"
+// @Param		end_date	query		string	false	"Period end, now by default"
+// @Success		200		{object}	Response[[]models.TopPayee]	"Top payees"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/payee/top [get]
+func (h *Handler) GetTopPayees(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	query, err := getTopPayeesQuery(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	payees, err := h.payeeService.GetTopPayees(r.Context(), user.ID, query)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, PayeeTopServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, payees)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	PayeeGetServerError   = "can't get payees"
+	PayeeMergeServerError = "can't merge payees"
+	PayeeTopServerError   = "can't get top payees"
+
+	TopPayeesDefaultLimit = 10
+	TopPayeesMaxLimit     = 100
+	TopPayeesDefaultDays  = 30
+)
+
+var (
+	errInvalidSort  = errors.New("invalid value for sort")
+	errInvalidLimit = errors.New("invalid value for limit")
+	errInvalidMerge = errors.New("target can't be merged into itself")
+	errNoPayees     = errors.New("target and sources are required")
+)
+
+//easyjson:json
+type MergePayees struct {
+	TargetID  uuid.UUID   `json:"target_id"`
+	SourceIDs []uuid.UUID `json:"source_ids"`
+}
+
+func (mp *MergePayees) CheckValid() error {
+	if mp.TargetID == uuid.Nil || len(mp.SourceIDs) == 0 {
+		return errNoPayees
+	}
+
+	seen := make(map[uuid.UUID]bool, len(mp.SourceIDs))
+	for _, id := range mp.SourceIDs {
+		if id == mp.TargetID {
+			return errInvalidMerge
+		}
+		if id == uuid.Nil || seen[id] {
+			return errNoPayees
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// getTopPayeesQuery reads sort, limit, start_date and end_date, by default it is the top by spend of the last month
+func getTopPayeesQuery(r *http.Request) (*models.TopPayeesQuery, error) {
+	period, err := commonHttp.GetQueryParam(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &models.TopPayeesQuery{
+		SortBy:    models.PayeeSortSpend,
+		Limit:     TopPayeesDefaultLimit,
+		StartDate: period.StartDate,
+		EndDate:   period.EndDate,
+	}
+
+	if sortBy := r.URL.Query().Get("sort"); sortBy != "" {
+		if sortBy != models.PayeeSortSpend && sortBy != models.PayeeSortCount {
+			return nil, errInvalidSort
+		}
+		query.SortBy = sortBy
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > TopPayeesMaxLimit {
+			return nil, errInvalidLimit
+		}
+		query.Limit = limit
+	}
+
+	if query.EndDate.IsZero() {
+		query.EndDate = time.Now()
+	}
+	if query.StartDate.IsZero() {
+		query.StartDate = query.EndDate.AddDate(0, 0, -TopPayeesDefaultDays)
+	}
+
+	return query, nil
+}
@@ -0,0 +1,307 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetPayees(t *testing.T) {
+	uuidTest := uuid.New()
+	payeeID := uuid.MustParse("3f1c2b7e-8d4a-4c6b-9e2f-1a7d5c3b9e40")
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetPayees",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[{"id":"3f1c2b7e-8d4a-4c6b-9e2f-1a7d5c3b9e40","name":"Пятерочка","aliases":["5ka","пятерочка"]}]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetPayees(gomock.Any(), uuidTest).Return([]models.Payee{
+					{ID: payeeID, Name: "Пятерочка", Aliases: []string{"5ka", "пятерочка"}},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get payees"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetPayees(gomock.Any(), uuidTest).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/payee/all", nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetPayees(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Merge(t *testing.T) {
+	uuidTest := uuid.New()
+	targetID := uuid.New()
+	sourceID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Merge",
+			user:         user,
+			body:         fmt.Sprintf(`{"target_id":"%s","source_ids":["%s"]}`, targetID, sourceID),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().MergePayees(gomock.Any(), uuidTest, targetID, []uuid.UUID{sourceID}).Return(nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid body",
+			user:         user,
+			body:         `{"target_id":`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Target in sources",
+			user:         user,
+			body:         fmt.Sprintf(`{"target_id":"%s","source_ids":["%s"]}`, targetID, targetID),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No sources",
+			user:         user,
+			body:         fmt.Sprintf(`{"target_id":"%s","source_ids":[]}`, targetID),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			body:         fmt.Sprintf(`{"target_id":"%s","source_ids":["%s"]}`, targetID, sourceID),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().MergePayees(gomock.Any(), uuidTest, targetID, []uuid.UUID{sourceID}).
+					Return(fmt.Errorf("[usecase] %w", &models.ForbiddenUserError{}))
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         fmt.Sprintf(`{"target_id":"%s","source_ids":["%s"]}`, targetID, sourceID),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't merge payees"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().MergePayees(gomock.Any(), uuidTest, targetID, []uuid.UUID{sourceID}).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/payee/merge", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Merge(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetTopPayees(t *testing.T) {
+	uuidTest := uuid.New()
+	payeeID := uuid.MustParse("3f1c2b7e-8d4a-4c6b-9e2f-1a7d5c3b9e40")
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetTopPayees",
+			user:         user,
+			queryParam:   "sort=count&limit=5&start_date=2023-11-01T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[{"id":"3f1c2b7e-8d4a-4c6b-9e2f-1a7d5c3b9e40","name":"Пятерочка","count":12,"spend":5400.5}]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetTopPayees(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error) {
+						assert.Equal(t, models.PayeeSortCount, query.SortBy)
+						assert.Equal(t, 5, query.Limit)
+						assert.Equal(t, 30*24.0, query.EndDate.Sub(query.StartDate).Hours())
+						return []models.TopPayee{{ID: payeeID, Name: "Пятерочка", Count: 12, Spend: 5400.5}}, nil
+					})
+			},
+		},
+		{
+			name:         "Default query",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":[]}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetTopPayees(gomock.Any(), uuidTest, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error) {
+						assert.Equal(t, models.PayeeSortSpend, query.SortBy)
+						assert.Equal(t, TopPayeesDefaultLimit, query.Limit)
+						assert.False(t, query.StartDate.IsZero())
+						return []models.TopPayee{}, nil
+					})
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid sort",
+			user:         user,
+			queryParam:   "sort=name",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid limit",
+			user:         user,
+			queryParam:   "limit=0",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get top payees"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetTopPayees(gomock.Any(), uuidTest, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/payee/top?"+tt.queryParam, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetTopPayees(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
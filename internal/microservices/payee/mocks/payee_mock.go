@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payee.go
+
+// Package mock_payee is a generated GoMock package.
+package mock_payee
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// BackfillPayees mocks base method.
+func (m *MockUsecase) BackfillPayees(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillPayees", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackfillPayees indicates an expected call of BackfillPayees.
+func (mr *MockUsecaseMockRecorder) BackfillPayees(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillPayees", reflect.TypeOf((*MockUsecase)(nil).BackfillPayees), ctx)
+}
+
+// GetPayees mocks base method.
+func (m *MockUsecase) GetPayees(ctx context.Context, userID uuid.UUID) ([]models.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayees", ctx, userID)
+	ret0, _ := ret[0].([]models.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayees indicates an expected call of GetPayees.
+func (mr *MockUsecaseMockRecorder) GetPayees(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayees", reflect.TypeOf((*MockUsecase)(nil).GetPayees), ctx, userID)
+}
+
+// GetTopPayees mocks base method.
+func (m *MockUsecase) GetTopPayees(ctx context.Context, userID uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopPayees", ctx, userID, query)
+	ret0, _ := ret[0].([]models.TopPayee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopPayees indicates an expected call of GetTopPayees.
+func (mr *MockUsecaseMockRecorder) GetTopPayees(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopPayees", reflect.TypeOf((*MockUsecase)(nil).GetTopPayees), ctx, userID, query)
+}
+
+// MergePayees mocks base method.
+func (m *MockUsecase) MergePayees(ctx context.Context, userID, targetID uuid.UUID, sourceIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePayees", ctx, userID, targetID, sourceIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePayees indicates an expected call of MergePayees.
+func (mr *MockUsecaseMockRecorder) MergePayees(ctx, userID, targetID, sourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePayees", reflect.TypeOf((*MockUsecase)(nil).MergePayees), ctx, userID, targetID, sourceIDs)
+}
+
+// ResolvePayee mocks base method.
+func (m *MockUsecase) ResolvePayee(ctx context.Context, userID uuid.UUID, payer string) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePayee", ctx, userID, payer)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePayee indicates an expected call of ResolvePayee.
+func (mr *MockUsecaseMockRecorder) ResolvePayee(ctx, userID, payer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePayee", reflect.TypeOf((*MockUsecase)(nil).ResolvePayee), ctx, userID, payer)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPayees mocks base method.
+func (m *MockRepository) GetPayees(ctx context.Context, userID uuid.UUID) ([]models.Payee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayees", ctx, userID)
+	ret0, _ := ret[0].([]models.Payee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayees indicates an expected call of GetPayees.
+func (mr *MockRepositoryMockRecorder) GetPayees(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayees", reflect.TypeOf((*MockRepository)(nil).GetPayees), ctx, userID)
+}
+
+// GetTopPayees mocks base method.
+func (m *MockRepository) GetTopPayees(ctx context.Context, userID uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopPayees", ctx, userID, query)
+	ret0, _ := ret[0].([]models.TopPayee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopPayees indicates an expected call of GetTopPayees.
+func (mr *MockRepositoryMockRecorder) GetTopPayees(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopPayees", reflect.TypeOf((*MockRepository)(nil).GetTopPayees), ctx, userID, query)
+}
+
+// GetUnlinkedPayers mocks base method.
+func (m *MockRepository) GetUnlinkedPayers(ctx context.Context) ([]models.PayeeRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnlinkedPayers", ctx)
+	ret0, _ := ret[0].([]models.PayeeRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnlinkedPayers indicates an expected call of GetUnlinkedPayers.
+func (mr *MockRepositoryMockRecorder) GetUnlinkedPayers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnlinkedPayers", reflect.TypeOf((*MockRepository)(nil).GetUnlinkedPayers), ctx)
+}
+
+// LinkTransactions mocks base method.
+func (m *MockRepository) LinkTransactions(ctx context.Context, ref models.PayeeRef, payeeID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkTransactions", ctx, ref, payeeID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkTransactions indicates an expected call of LinkTransactions.
+func (mr *MockRepositoryMockRecorder) LinkTransactions(ctx, ref, payeeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkTransactions", reflect.TypeOf((*MockRepository)(nil).LinkTransactions), ctx, ref, payeeID)
+}
+
+// MergePayees mocks base method.
+func (m *MockRepository) MergePayees(ctx context.Context, userID, targetID uuid.UUID, sourceIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePayees", ctx, userID, targetID, sourceIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePayees indicates an expected call of MergePayees.
+func (mr *MockRepositoryMockRecorder) MergePayees(ctx, userID, targetID, sourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePayees", reflect.TypeOf((*MockRepository)(nil).MergePayees), ctx, userID, targetID, sourceIDs)
+}
+
+// ResolvePayee mocks base method.
+func (m *MockRepository) ResolvePayee(ctx context.Context, userID uuid.UUID, alias, name string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePayee", ctx, userID, alias, name)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvePayee indicates an expected call of ResolvePayee.
+func (mr *MockRepositoryMockRecorder) ResolvePayee(ctx, userID, alias, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePayee", reflect.TypeOf((*MockRepository)(nil).ResolvePayee), ctx, userID, alias, name)
+}
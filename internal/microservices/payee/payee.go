@@ -0,0 +1,27 @@
+package payee
+
+import (
+	"context"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	GetPayees(ctx context.Context, userID uuid.UUID) ([]models.Payee, error)
+	MergePayees(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, sourceIDs []uuid.UUID) error
+	GetTopPayees(ctx context.Context, userID uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error)
+
+	ResolvePayee(ctx context.Context, userID uuid.UUID, payer string) (*uuid.UUID, error)
+	BackfillPayees(ctx context.Context) error
+}
+
+type Repository interface {
+	GetPayees(ctx context.Context, userID uuid.UUID) ([]models.Payee, error)
+	MergePayees(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, sourceIDs []uuid.UUID) error
+	GetTopPayees(ctx context.Context, userID uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error)
+
+	ResolvePayee(ctx context.Context, userID uuid.UUID, alias string, name string) (uuid.UUID, error)
+	GetUnlinkedPayers(ctx context.Context) ([]models.PayeeRef, error)
+	LinkTransactions(ctx context.Context, ref models.PayeeRef, payeeID uuid.UUID) (int64, error)
+}
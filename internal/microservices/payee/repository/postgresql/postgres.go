@@ -0,0 +1,216 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	PayeeGetAll = `SELECT p.id, p.name, COALESCE(array_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}')
+				   FROM Payee p
+				   LEFT JOIN PayeeAlias a ON a.payee_id = p.id
+				   WHERE p.user_id = $1
+				   GROUP BY p.id, p.name
+				   ORDER BY p.name;`
+
+	// the alias is looked up first, a new payee is created only for an unknown alias
+	PayeeResolve = `WITH existing AS (
+						SELECT payee_id FROM PayeeAlias WHERE user_id = $1 AND alias = $2
+					), created AS (
+						INSERT INTO Payee (user_id, "name")
+						SELECT $1, $3 WHERE NOT EXISTS (SELECT 1 FROM existing)
+						RETURNING id
+					), linked AS (
+						INSERT INTO PayeeAlias (user_id, alias, payee_id)
+						SELECT $1, $2, id FROM created
+						ON CONFLICT (user_id, alias) DO NOTHING
+					)
+					SELECT payee_id FROM existing
+					UNION ALL
+					SELECT id FROM created;`
+
+	PayeeCountOwned       = "SELECT COUNT(*) FROM Payee WHERE user_id = $1 AND id = ANY($2);"
+	PayeeMoveAliases      = "UPDATE PayeeAlias SET payee_id = $1 WHERE payee_id = ANY($2);"
+	PayeeMoveTransactions = "UPDATE Transaction SET payee_id = $1 WHERE payee_id = ANY($2);"
+	PayeeDelete           = "DELETE FROM Payee WHERE id = ANY($1);"
+
+	// spending only: transfers between accounts are skipped
+	payeeTop = `SELECT p.id, p.name, COUNT(t.id), COALESCE(SUM(t.outcome), 0)
+				FROM Transaction t
+				JOIN Payee p ON p.id = t.payee_id
+				WHERE p.user_id = $1
+				AND t.account_income = t.account_outcome
+				AND t.outcome > 0
+				AND t.date BETWEEN $2 AND $3
+				GROUP BY p.id, p.name
+				`
+	PayeeTopBySpend = payeeTop + "ORDER BY 4 DESC, 3 DESC LIMIT $4;"
+	PayeeTopByCount = payeeTop + "ORDER BY 3 DESC, 4 DESC LIMIT $4;"
+
+	PayeeUnlinked = `SELECT DISTINCT user_id, payer
+					 FROM Transaction
+					 WHERE payee_id IS NULL AND user_id IS NOT NULL AND COALESCE(payer, '') <> '';`
+
+	PayeeLinkTransactions = "UPDATE Transaction SET payee_id = $3 WHERE user_id = $1 AND payer = $2 AND payee_id IS NULL;"
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) GetPayees(ctx context.Context, userID uuid.UUID) ([]models.Payee, error) {
+	payees := []models.Payee{}
+
+	rows, err := r.db.Query(ctx, PayeeGetAll, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payee models.Payee
+		if err := rows.Scan(&payee.ID, &payee.Name, &payee.Aliases); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		payees = append(payees, payee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return payees, nil
+}
+
+func (r *Repository) ResolvePayee(ctx context.Context, userID uuid.UUID, alias string, name string) (uuid.UUID, error) {
+	var payeeID uuid.UUID
+
+	if err := r.db.QueryRow(ctx, PayeeResolve, userID, alias, name).Scan(&payeeID); err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to resolve payee: %w", err)
+	}
+
+	return payeeID, nil
+}
+
+// MergePayees moves the aliases and transactions of the sources to the target and deletes the sources
+func (r *Repository) MergePayees(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, sourceIDs []uuid.UUID) (err error) {
+	sources := make([]string, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		sources = append(sources, id.String())
+	}
+	all := append([]string{targetID.String()}, sources...)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("Rollback transaction Error: %v", errRollback)
+			}
+		}
+	}()
+
+	var owned int
+	if err = tx.QueryRow(ctx, PayeeCountOwned, userID, all).Scan(&owned); err != nil {
+		return fmt.Errorf("[repo] failed to check payees: %w", err)
+	}
+	if owned != len(all) {
+		return fmt.Errorf("[repo] payees of another user: %w", &models.ForbiddenUserError{})
+	}
+
+	if _, err = tx.Exec(ctx, PayeeMoveAliases, targetID, sources); err != nil {
+		return fmt.Errorf("[repo] failed to move aliases: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, PayeeMoveTransactions, targetID, sources); err != nil {
+		return fmt.Errorf("[repo] failed to move transactions: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, PayeeDelete, sources); err != nil {
+		return fmt.Errorf("[repo] failed to delete payees: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetTopPayees(ctx context.Context, userID uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error) {
+	payees := []models.TopPayee{}
+
+	sql := PayeeTopBySpend
+	if query.SortBy == models.PayeeSortCount {
+		sql = PayeeTopByCount
+	}
+
+	rows, err := r.db.Query(ctx, sql, userID, query.StartDate, query.EndDate, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payee models.TopPayee
+		if err := rows.Scan(&payee.ID, &payee.Name, &payee.Count, &payee.Spend); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		payees = append(payees, payee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return payees, nil
+}
+
+func (r *Repository) GetUnlinkedPayers(ctx context.Context) ([]models.PayeeRef, error) {
+	var refs []models.PayeeRef
+
+	rows, err := r.db.Query(ctx, PayeeUnlinked)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ref models.PayeeRef
+		if err := rows.Scan(&ref.UserID, &ref.Payer); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		refs = append(refs, ref)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return refs, nil
+}
+
+func (r *Repository) LinkTransactions(ctx context.Context, ref models.PayeeRef, payeeID uuid.UUID) (int64, error) {
+	tag, err := r.db.Exec(ctx, PayeeLinkTransactions, ref.UserID, ref.Payer, payeeID)
+	if err != nil {
+		return 0, fmt.Errorf("[repo] failed to link transactions: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
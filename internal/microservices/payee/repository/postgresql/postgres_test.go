@@ -0,0 +1,360 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+)
+
+func Test_GetPayees(t *testing.T) {
+	userID := uuid.New()
+
+	columns := []string{"id", "name", "aliases"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(uuid.New(), "Пятерочка", []string{"5ka", "пятерочка"}).
+				AddRow(uuid.New(), "Shop", []string{}),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(PayeeGetAll)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			payees, err := repo.GetPayees(context.Background(), userID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(payees) != tc.expectedLen {
+				t.Errorf("Expected %d payees, but got: %d", tc.expectedLen, len(payees))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_ResolvePayee(t *testing.T) {
+	userID := uuid.New()
+	payeeID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			rows:        pgxmock.NewRows([]string{"id"}).AddRow(payeeID),
+			expected:    payeeID,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to resolve payee: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(PayeeResolve)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, "pyaterochka", "PYATEROCHKA 1234").
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			resolved, err := repo.ResolvePayee(context.Background(), userID, "pyaterochka", "PYATEROCHKA 1234")
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if resolved != tc.expected {
+				t.Errorf("Expected payee %s, but got: %s", tc.expected, resolved)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_MergePayees(t *testing.T) {
+	userID := uuid.New()
+	targetID := uuid.New()
+	sourceID := uuid.New()
+	sources := []string{sourceID.String()}
+	all := []string{targetID.String(), sourceID.String()}
+
+	testCases := []struct {
+		name        string
+		owned       int
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			owned:       2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Payee of another user",
+			owned:       1,
+			expectedErr: fmt.Errorf("[repo] payees of another user: %w", &models.ForbiddenUserError{}),
+		},
+		{
+			name:        "Error",
+			owned:       2,
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to move aliases: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(PayeeCountOwned)).
+				WithArgs(userID, all).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(tc.owned))
+
+			switch {
+			case tc.owned != len(all):
+				mock.ExpectRollback()
+			case tc.execError != nil:
+				mock.ExpectExec(regexp.QuoteMeta(PayeeMoveAliases)).
+					WithArgs(targetID, sources).
+					WillReturnError(tc.execError)
+				mock.ExpectRollback()
+			default:
+				mock.ExpectExec(regexp.QuoteMeta(PayeeMoveAliases)).
+					WithArgs(targetID, sources).
+					WillReturnResult(pgconn.CommandTag("UPDATE 2"))
+				mock.ExpectExec(regexp.QuoteMeta(PayeeMoveTransactions)).
+					WithArgs(targetID, sources).
+					WillReturnResult(pgconn.CommandTag("UPDATE 5"))
+				mock.ExpectExec(regexp.QuoteMeta(PayeeDelete)).
+					WithArgs(sources).
+					WillReturnResult(pgconn.CommandTag("DELETE 1"))
+				mock.ExpectCommit()
+			}
+
+			err := repo.MergePayees(context.Background(), userID, targetID, []uuid.UUID{sourceID})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetTopPayees(t *testing.T) {
+	userID := uuid.New()
+	start := time.Now().AddDate(0, -1, 0)
+	end := time.Now()
+
+	columns := []string{"id", "name", "count", "spend"}
+
+	testCases := []struct {
+		name        string
+		sortBy      string
+		query       string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:   "By spend",
+			sortBy: models.PayeeSortSpend,
+			query:  PayeeTopBySpend,
+			rows: pgxmock.NewRows(columns).
+				AddRow(uuid.New(), "Пятерочка", 12, 5400.5),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "By count",
+			sortBy:      models.PayeeSortCount,
+			query:       PayeeTopByCount,
+			rows:        pgxmock.NewRows(columns),
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			sortBy:      models.PayeeSortSpend,
+			query:       PayeeTopBySpend,
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(tc.query)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, start, end, 10).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			payees, err := repo.GetTopPayees(context.Background(), userID, &models.TopPayeesQuery{
+				SortBy:    tc.sortBy,
+				Limit:     10,
+				StartDate: start,
+				EndDate:   end,
+			})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(payees) != tc.expectedLen {
+				t.Errorf("Expected %d payees, but got: %d", tc.expectedLen, len(payees))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetUnlinkedPayers(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+
+	logger := *logger.NewLogger(context.TODO())
+	repo := NewRepository(mock, logger)
+
+	mock.ExpectQuery(regexp.QuoteMeta(PayeeUnlinked)).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "payer"}).AddRow(uuid.New(), "Shop").AddRow(uuid.New(), "5ka"))
+
+	refs, err := repo.GetUnlinkedPayers(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(refs) != 2 {
+		t.Errorf("Expected 2 payers, but got: %d", len(refs))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_LinkTransactions(t *testing.T) {
+	ref := models.PayeeRef{UserID: uuid.New(), Payer: "Shop"}
+	payeeID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		execResult    pgconn.CommandTag
+		execError     error
+		expectedCount int64
+		expectedErr   error
+	}{
+		{
+			name:          "Success",
+			execResult:    pgconn.CommandTag("UPDATE 3"),
+			expectedCount: 3,
+			expectedErr:   nil,
+		},
+		{
+			name:        "Error",
+			execResult:  pgconn.CommandTag{},
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to link transactions: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectExec(regexp.QuoteMeta(PayeeLinkTransactions)).
+				WithArgs(ref.UserID, ref.Payer, payeeID).
+				WillReturnResult(tc.execResult).
+				WillReturnError(tc.execError)
+
+			count, err := repo.LinkTransactions(context.Background(), ref, payeeID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if count != tc.expectedCount {
+				t.Errorf("Expected %d transactions, but got: %d", tc.expectedCount, count)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
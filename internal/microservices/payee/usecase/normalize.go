@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// legal forms are dropped so "ООО Ромашка" and "Ромашка" are one payee
+var legalForms = map[string]bool{
+	"ооо": true, "оао": true, "зао": true, "пао": true, "ао": true, "ип": true,
+	"llc": true, "ltd": true, "inc": true,
+}
+
+// normalizeAlias maps a raw payer to the key payees are matched by:
+// case, punctuation, store numbers and legal forms do not matter,
+// an empty result means the payer can't be linked to a payee
+func normalizeAlias(payer string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r == 'ё' || r == 'Ё':
+			return 'е'
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		default:
+			return ' '
+		}
+	}, payer)
+
+	var words []string
+	for _, word := range strings.Fields(cleaned) {
+		if legalForms[word] || isNumber(word) {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+// PayeeNameMaxLen is the size of Payee.name in the schema
+const PayeeNameMaxLen = 30
+
+type Usecase struct {
+	payeeRepo payee.Repository
+	logger    logger.Logger
+}
+
+func NewUsecase(pr payee.Repository, log logger.Logger) *Usecase {
+	return &Usecase{
+		payeeRepo: pr,
+		logger:    log,
+	}
+}
+
+func (u *Usecase) GetPayees(ctx context.Context, userID uuid.UUID) ([]models.Payee, error) {
+	payees, err := u.payeeRepo.GetPayees(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get payees from repository %w", err)
+	}
+	return payees, nil
+}
+
+func (u *Usecase) MergePayees(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, sourceIDs []uuid.UUID) error {
+	if err := u.payeeRepo.MergePayees(ctx, userID, targetID, sourceIDs); err != nil {
+		return fmt.Errorf("[usecase] can't merge payees %w", err)
+	}
+	return nil
+}
+
+func (u *Usecase) GetTopPayees(ctx context.Context, userID uuid.UUID, query *models.TopPayeesQuery) ([]models.TopPayee, error) {
+	payees, err := u.payeeRepo.GetTopPayees(ctx, userID, query)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get top payees from repository %w", err)
+	}
+	return payees, nil
+}
+
+// ResolvePayee finds the payee of a raw payer string and creates it on first use,
+// nil is returned for a payer without letters or digits
+func (u *Usecase) ResolvePayee(ctx context.Context, userID uuid.UUID, payer string) (*uuid.UUID, error) {
+	alias := normalizeAlias(payer)
+	if alias == "" {
+		return nil, nil
+	}
+
+	payeeID, err := u.payeeRepo.ResolvePayee(ctx, userID, alias, payeeName(payer))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't resolve payee %w", err)
+	}
+	return &payeeID, nil
+}
+
+// BackfillPayees links the transactions created before payees existed,
+// a failure for one payer does not stop the others
+func (u *Usecase) BackfillPayees(ctx context.Context) error {
+	refs, err := u.payeeRepo.GetUnlinkedPayers(ctx)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get unlinked payers from repository %w", err)
+	}
+
+	var failed int
+	var linked int64
+	for _, ref := range refs {
+		payeeID, err := u.ResolvePayee(ctx, ref.UserID, ref.Payer)
+		if err != nil {
+			u.logger.Errorf("[usecase] can't resolve payer %q of user %s: %v", ref.Payer, ref.UserID, err)
+			failed++
+			continue
+		}
+		if payeeID == nil {
+			continue
+		}
+
+		count, err := u.payeeRepo.LinkTransactions(ctx, ref, *payeeID)
+		if err != nil {
+			u.logger.Errorf("[usecase] can't link payer %q of user %s: %v", ref.Payer, ref.UserID, err)
+			failed++
+			continue
+		}
+		linked += count
+	}
+
+	if linked > 0 {
+		u.logger.Infof("[usecase] %d transactions linked to payees", linked)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[usecase] payee backfill failed for %d of %d payers", failed, len(refs))
+	}
+	return nil
+}
+
+func payeeName(payer string) string {
+	name := []rune(strings.Join(strings.Fields(payer), " "))
+	if len(name) > PayeeNameMaxLen {
+		name = name[:PayeeNameMaxLen]
+	}
+	return string(name)
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_GetPayees(t *testing.T) {
+	testPayees := []models.Payee{{ID: uuid.New(), Name: "Пятерочка", Aliases: []string{"пятерочка"}}}
+
+	testCases := []struct {
+		name           string
+		expectedPayees []models.Payee
+		expectedErr    error
+		mockRepoFn     func(*mock.MockRepository)
+	}{
+		{
+			name:           "Successful TestUsecase_GetPayees",
+			expectedPayees: testPayees,
+			expectedErr:    nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetPayees(gomock.Any(), gomock.Any()).Return(testPayees, nil)
+			},
+		},
+		{
+			name:           "Error in TestUsecase_GetPayees",
+			expectedPayees: nil,
+			expectedErr:    fmt.Errorf("[usecase] can't get payees from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetPayees(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			payees, err := mockUsecase.GetPayees(context.Background(), uuid.New())
+
+			assert.Equal(t, tc.expectedPayees, payees)
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_MergePayees(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_MergePayees",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().MergePayees(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "Error in TestUsecase_MergePayees",
+			expectedErr: fmt.Errorf("[usecase] can't merge payees some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().MergePayees(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			err := mockUsecase.MergePayees(context.Background(), uuid.New(), uuid.New(), []uuid.UUID{uuid.New()})
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_GetTopPayees(t *testing.T) {
+	testPayees := []models.TopPayee{{ID: uuid.New(), Name: "Пятерочка", Count: 3, Spend: 1500}}
+
+	testCases := []struct {
+		name           string
+		expectedPayees []models.TopPayee
+		expectedErr    error
+		mockRepoFn     func(*mock.MockRepository)
+	}{
+		{
+			name:           "Successful TestUsecase_GetTopPayees",
+			expectedPayees: testPayees,
+			expectedErr:    nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTopPayees(gomock.Any(), gomock.Any(), gomock.Any()).Return(testPayees, nil)
+			},
+		},
+		{
+			name:           "Error in TestUsecase_GetTopPayees",
+			expectedPayees: nil,
+			expectedErr:    fmt.Errorf("[usecase] can't get top payees from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetTopPayees(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			payees, err := mockUsecase.GetTopPayees(context.Background(), uuid.New(), &models.TopPayeesQuery{SortBy: models.PayeeSortSpend, Limit: 10})
+
+			assert.Equal(t, tc.expectedPayees, payees)
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_ResolvePayee(t *testing.T) {
+	userID := uuid.New()
+	payeeID := uuid.New()
+
+	testCases := []struct {
+		name            string
+		payer           string
+		expectedPayeeID *uuid.UUID
+		expectedErr     error
+		mockRepoFn      func(*mock.MockRepository)
+	}{
+		{
+			name:            "Successful TestUsecase_ResolvePayee",
+			payer:           "ООО  PYATEROCHKA 1234",
+			expectedPayeeID: &payeeID,
+			expectedErr:     nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().ResolvePayee(gomock.Any(), userID, "pyaterochka", "ООО PYATEROCHKA 1234").Return(payeeID, nil)
+			},
+		},
+		{
+			name:            "Empty payer in TestUsecase_ResolvePayee",
+			payer:           " 1234 - ",
+			expectedPayeeID: nil,
+			expectedErr:     nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+			},
+		},
+		{
+			name:            "Error in TestUsecase_ResolvePayee",
+			payer:           "Shop",
+			expectedPayeeID: nil,
+			expectedErr:     fmt.Errorf("[usecase] can't resolve payee some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().ResolvePayee(gomock.Any(), userID, "shop", "Shop").Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			resolved, err := mockUsecase.ResolvePayee(context.Background(), userID, tc.payer)
+
+			assert.Equal(t, tc.expectedPayeeID, resolved)
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_BackfillPayees(t *testing.T) {
+	userID := uuid.New()
+	payeeID := uuid.New()
+	refs := []models.PayeeRef{
+		{UserID: userID, Payer: "Пятёрочка"},
+		{UserID: userID, Payer: "---"},
+		{UserID: userID, Payer: "Shop"},
+	}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_BackfillPayees",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUnlinkedPayers(gomock.Any()).Return(refs, nil)
+				mockRepository.EXPECT().ResolvePayee(gomock.Any(), userID, "пятерочка", "Пятёрочка").Return(payeeID, nil)
+				mockRepository.EXPECT().LinkTransactions(gomock.Any(), refs[0], payeeID).Return(int64(3), nil)
+				mockRepository.EXPECT().ResolvePayee(gomock.Any(), userID, "shop", "Shop").Return(payeeID, nil)
+				mockRepository.EXPECT().LinkTransactions(gomock.Any(), refs[2], payeeID).Return(int64(1), nil)
+			},
+		},
+		{
+			name:        "Payers Error in TestUsecase_BackfillPayees",
+			expectedErr: fmt.Errorf("[usecase] can't get unlinked payers from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUnlinkedPayers(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "One payer fails in TestUsecase_BackfillPayees",
+			expectedErr: fmt.Errorf("[usecase] payee backfill failed for 1 of 3 payers"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetUnlinkedPayers(gomock.Any()).Return(refs, nil)
+				mockRepository.EXPECT().ResolvePayee(gomock.Any(), userID, "пятерочка", "Пятёрочка").Return(uuid.Nil, errors.New("some error"))
+				mockRepository.EXPECT().ResolvePayee(gomock.Any(), userID, "shop", "Shop").Return(payeeID, nil)
+				mockRepository.EXPECT().LinkTransactions(gomock.Any(), refs[2], payeeID).Return(int64(1), nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+
+			err := mockUsecase.BackfillPayees(context.Background())
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeAlias(t *testing.T) {
+	testCases := []struct {
+		payer    string
+		expected string
+	}{
+		{payer: "PYATEROCHKA 1234", expected: "pyaterochka"},
+		{payer: "pyaterochka-5678", expected: "pyaterochka"},
+		{payer: "Пятёрочка", expected: "пятерочка"},
+		{payer: "ООО \"Пятерочка\"", expected: "пятерочка"},
+		{payer: "5ka", expected: "5ka"},
+		{payer: "Yandex.Go  LLC", expected: "yandex go"},
+		{payer: " 1234 ", expected: ""},
+		{payer: "", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.payer, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeAlias(tc.payer))
+		})
+	}
+}
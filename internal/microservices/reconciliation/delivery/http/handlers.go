@@ -0,0 +1,261 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/mailru/easyjson"
+)
+
+type Handler struct {
+	reconciliationService reconciliation.Usecase
+	logger                logger.Logger
+}
+
+func NewHandler(ru reconciliation.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		reconciliationService: ru,
+		logger:                l,
+	}
+}
+
+// @Summary		Start reconciliation
+// @Tags		Reconciliation
+// @Description	Open a reconciliation of the account against a bank statement
+// @Accept 		json
+// @Produce		json
+// @Param		reconciliation	body		StartReconciliation						true	"Statement"
+// @Success		200		{object}	Response[ReconciliationStartResponse]	"Reconciliation started"
+// @Failure		400		{object}	ResponseError							"Client error"
+// @Failure     401    	{object}    ResponseError  							"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  							"Forbidden user"
+// @Failure		500		{object}	ResponseError							"Server error"
+// @Router		/api/reconciliation/start [post]
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	var startInput StartReconciliation
+	if err := easyjson.UnmarshalFromReader(r.Body, &startInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := startInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	id, err := h.reconciliationService.Start(r.Context(), user.ID, startInput.ToReconciliation())
+	if h.reconciliationError(w, err, ReconciliationStartServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, ReconciliationStartResponse{ReconciliationID: id})
+}
+
+// @Summary		Get reconciliation
+// @Tags		Reconciliation
+// @Description	The reconciliation with the difference left, an open one lists the transactions up to the statement date
+// @Produce		json
+// @Param		reconciliation_id	path		string	true	"Reconciliation ID"
+// @Success		200		{object}	Response[models.Reconciliation]	"Reconciliation"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Forbidden user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/reconciliation/{reconciliation_id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(reconciliationID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Get(r.Context(), user.ID, id)
+	if h.reconciliationError(w, err, ReconciliationGetServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, reconciliation)
+}
+
+// @Summary		Get account reconciliations
+// @Tags		Reconciliation
+// @Description	Reconciliations of the account, the latest statement first
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Success		200		{object}	Response[[]models.Reconciliation]	"Reconciliations"
+// @Failure		400		{object}	ResponseError						"Client error"
+// @Failure     401    	{object}    ResponseError  						"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  						"Forbidden user"
+// @Failure		500		{object}	ResponseError						"Server error"
+// @Router		/api/reconciliation/account/{account_id} [get]
+func (h *Handler) GetAccountReconciliations(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	reconciliations, err := h.reconciliationService.GetAccountReconciliations(r.Context(), user.ID, accountID)
+	if h.reconciliationError(w, err, ReconciliationGetServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, reconciliations)
+}
+
+// @Summary		Clear transactions
+// @Tags		Reconciliation
+// @Description	Mark the transactions found in the statement, returns the reconciliation with the new difference
+// @Accept 		json
+// @Produce		json
+// @Param		reconciliation_id	path		string				true	"Reconciliation ID"
+// @Param		transactions		body		ClearTransactions	true	"Transactions"
+// @Success		200		{object}	Response[models.Reconciliation]	"Reconciliation"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Forbidden user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/reconciliation/{reconciliation_id}/clear [put]
+func (h *Handler) Clear(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(reconciliationID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	var clearInput ClearTransactions
+	if err := easyjson.UnmarshalFromReader(r.Body, &clearInput); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	if err := clearInput.CheckValid(); err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Clear(r.Context(), user.ID, id, clearInput.TransactionIDs, clearInput.Cleared)
+	if h.reconciliationError(w, err, ReconciliationClearServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, reconciliation)
+}
+
+// @Summary		Finish reconciliation
+// @Tags		Reconciliation
+// @Description	Lock the cleared transactions, the cleared balance has to meet the closing balance
+// @Produce		json
+// @Param		reconciliation_id	path		string	true	"Reconciliation ID"
+// @Success		200		{object}	Response[NilBody]	"Reconciliation finished"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  		"Forbidden user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/reconciliation/{reconciliation_id}/finish [put]
+func (h *Handler) Finish(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(reconciliationID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	if h.reconciliationError(w, h.reconciliationService.Finish(r.Context(), user.ID, id), ReconciliationFinishServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// @Summary		Cancel reconciliation
+// @Tags		Reconciliation
+// @Description	Drop the open reconciliation, the transactions keep their cleared marks
+// @Produce		json
+// @Param		reconciliation_id	path		string	true	"Reconciliation ID"
+// @Success		200		{object}	Response[NilBody]	"Reconciliation cancelled"
+// @Failure		400		{object}	ResponseError		"Client error"
+// @Failure     401    	{object}    ResponseError  		"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  		"Forbidden user"
+// @Failure		500		{object}	ResponseError		"Server error"
+// @Router		/api/reconciliation/{reconciliation_id}/cancel [put]
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	id, err := commonHttp.GetIDFromRequest(reconciliationID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	if h.reconciliationError(w, h.reconciliationService.Cancel(r.Context(), user.ID, id), ReconciliationCancelServerError) {
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
+}
+
+// reconciliationError writes the response for an error of a reconciliation operation, false if there is no error
+func (h *Handler) reconciliationError(w http.ResponseWriter, err error, serverError string) bool {
+	if err == nil {
+		return false
+	}
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return true
+	}
+
+	var errNoSuchReconciliation *models.NoSuchReconciliationError
+	if errors.As(err, &errNoSuchReconciliation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, ReconciliationNotSuch, h.logger)
+		return true
+	}
+
+	var errReconciliationOperation *models.ReconciliationOperationError
+	if errors.As(err, &errReconciliationOperation) {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errReconciliationOperation.Reason, h.logger)
+		return true
+	}
+
+	commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, serverError, h.logger)
+	return true
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	reconciliationID = "reconciliation_id"
+	accountID        = "account_id"
+
+	ReconciliationStartServerError  = "can't start reconciliation"
+	ReconciliationGetServerError    = "can't get reconciliation"
+	ReconciliationClearServerError  = "can't clear transactions"
+	ReconciliationFinishServerError = "can't finish reconciliation"
+	ReconciliationCancelServerError = "can't cancel reconciliation"
+	ReconciliationNotSuch           = "no such reconciliation"
+)
+
+var (
+	errInvalidAccount       = errors.New("account is required")
+	errInvalidStatementDate = errors.New("statement date is required")
+	errInvalidTransactions  = errors.New("transactions are required")
+)
+
+type ReconciliationStartResponse struct {
+	ReconciliationID uuid.UUID `json:"reconciliation_id"`
+}
+
+//easyjson:json
+type StartReconciliation struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	StatementDate  time.Time `json:"statement_date"`
+	ClosingBalance float64   `json:"closing_balance"`
+}
+
+func (sr *StartReconciliation) CheckValid() error {
+	switch {
+	case sr.AccountID == uuid.Nil:
+		return errInvalidAccount
+	case sr.StatementDate.IsZero():
+		return errInvalidStatementDate
+	}
+
+	return nil
+}
+
+func (sr *StartReconciliation) ToReconciliation() *models.Reconciliation {
+	return &models.Reconciliation{
+		AccountID:      sr.AccountID,
+		StatementDate:  sr.StatementDate,
+		ClosingBalance: sr.ClosingBalance,
+	}
+}
+
+// ClearTransactions marks the transactions as cleared, or back as uncleared with cleared false
+//
+//easyjson:json
+type ClearTransactions struct {
+	TransactionIDs []uuid.UUID `json:"transaction_ids"`
+	Cleared        bool        `json:"cleared"`
+}
+
+func (ct *ClearTransactions) CheckValid() error {
+	if len(ct.TransactionIDs) == 0 {
+		return errInvalidTransactions
+	}
+
+	// a transaction sent twice is cleared once
+	seen := make(map[uuid.UUID]bool, len(ct.TransactionIDs))
+	unique := ct.TransactionIDs[:0]
+	for _, id := range ct.TransactionIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	ct.TransactionIDs = unique
+
+	return nil
+}
@@ -0,0 +1,307 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Start(t *testing.T) {
+	uuidTest := uuid.New()
+	accountID := uuid.MustParse("0f4b8c2e-6d1a-4e7f-9b3c-2a5d8e1f7c40")
+	reconciliationID := uuid.MustParse("8d3a1f6e-2c7b-4a90-b5e4-7f1c0d9a3b26")
+	user := &models.User{ID: uuidTest}
+	validBody := `{"account_id":"0f4b8c2e-6d1a-4e7f-9b3c-2a5d8e1f7c40","statement_date":"2023-11-30T00:00:00Z","closing_balance":1250.5}`
+	tests := []struct {
+		name          string
+		user          *models.User
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Start",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"reconciliation_id":"8d3a1f6e-2c7b-4a90-b5e4-7f1c0d9a3b26"}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Start(gomock.Any(), uuidTest, &models.Reconciliation{
+					AccountID:      accountID,
+					StatementDate:  time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC),
+					ClosingBalance: 1250.5,
+				}).Return(reconciliationID, nil)
+			},
+		},
+		{
+			name:          "Unauthorized Request",
+			user:          nil,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+		{
+			name:          "No statement date",
+			user:          user,
+			body:          `{"account_id":"0f4b8c2e-6d1a-4e7f-9b3c-2a5d8e1f7c40","closing_balance":1250.5}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+		{
+			name:         "Already open",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"account already has an open reconciliation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Start(gomock.Any(), uuidTest, gomock.Any()).
+					Return(uuid.Nil, &models.ReconciliationOperationError{Reason: "account already has an open reconciliation"})
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Start(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			body:         validBody,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't start reconciliation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Start(gomock.Any(), uuidTest, gomock.Any()).Return(uuid.Nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("POST", "/api/reconciliation/start", strings.NewReader(tt.body))
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Start(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_Clear(t *testing.T) {
+	uuidTest := uuid.New()
+	reconciliationID := uuid.New()
+	transactionID := uuid.MustParse("3c9e2a71-5b8d-4f06-a1e3-9d7c4b2f8e15")
+	user := &models.User{ID: uuidTest}
+	validBody := `{"transaction_ids":["3c9e2a71-5b8d-4f06-a1e3-9d7c4b2f8e15","3c9e2a71-5b8d-4f06-a1e3-9d7c4b2f8e15"],"cleared":true}`
+	tests := []struct {
+		name          string
+		body          string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to Clear",
+			body:         validBody,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"id":"` + reconciliationID.String() + `","account_id":"00000000-0000-0000-0000-000000000000",` +
+				`"user_id":"00000000-0000-0000-0000-000000000000","statement_date":"0001-01-01T00:00:00Z","closing_balance":100,` +
+				`"status":"open","created_at":"0001-01-01T00:00:00Z","finished_at":null,"cleared_balance":100,"difference":0}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Clear(gomock.Any(), uuidTest, reconciliationID, []uuid.UUID{transactionID}, true).
+					Return(&models.Reconciliation{ID: reconciliationID, ClosingBalance: 100, Status: models.ReconciliationOpen, ClearedBalance: 100}, nil)
+			},
+		},
+		{
+			name:          "No transactions",
+			body:          `{"transaction_ids":[],"cleared":true}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedBody:  `{"status":400,"message":"invalid input body"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
+		},
+		{
+			name:         "Transaction out of the statement",
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"transactions are not in the statement or are already reconciled"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Clear(gomock.Any(), uuidTest, reconciliationID, gomock.Any(), true).
+					Return(nil, &models.ReconciliationOperationError{Reason: "transactions are not in the statement or are already reconciled"})
+			},
+		},
+		{
+			name:         "No such reconciliation",
+			body:         validBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"no such reconciliation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Clear(gomock.Any(), uuidTest, reconciliationID, gomock.Any(), true).
+					Return(nil, &models.NoSuchReconciliationError{ReconciliationID: reconciliationID})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/reconciliation/"+reconciliationID.String()+"/clear", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"reconciliation_id": reconciliationID.String()})
+
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.Clear(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_FinishCancel(t *testing.T) {
+	uuidTest := uuid.New()
+	reconciliationID := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name             string
+		handler          func(*Handler) http.HandlerFunc
+		reconciliationID string
+		expectedCode     int
+		expectedBody     string
+		mockUsecaseFn    func(*mocks.MockUsecase)
+	}{
+		{
+			name:             "Finish",
+			handler:          func(h *Handler) http.HandlerFunc { return h.Finish },
+			reconciliationID: reconciliationID.String(),
+			expectedCode:     http.StatusOK,
+			expectedBody:     `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Finish(gomock.Any(), uuidTest, reconciliationID).Return(nil)
+			},
+		},
+		{
+			name:             "Finish with difference",
+			handler:          func(h *Handler) http.HandlerFunc { return h.Finish },
+			reconciliationID: reconciliationID.String(),
+			expectedCode:     http.StatusBadRequest,
+			expectedBody:     `{"status":400,"message":"cleared transactions differ from the statement by 120.50"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Finish(gomock.Any(), uuidTest, reconciliationID).
+					Return(&models.ReconciliationOperationError{Reason: "cleared transactions differ from the statement by 120.50"})
+			},
+		},
+		{
+			name:             "Finish server error",
+			handler:          func(h *Handler) http.HandlerFunc { return h.Finish },
+			reconciliationID: reconciliationID.String(),
+			expectedCode:     http.StatusInternalServerError,
+			expectedBody:     `{"status":500,"message":"can't finish reconciliation"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Finish(gomock.Any(), uuidTest, reconciliationID).Return(errors.New("some error"))
+			},
+		},
+		{
+			name:             "Cancel forbidden",
+			handler:          func(h *Handler) http.HandlerFunc { return h.Cancel },
+			reconciliationID: reconciliationID.String(),
+			expectedCode:     http.StatusForbidden,
+			expectedBody:     `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Cancel(gomock.Any(), uuidTest, reconciliationID).Return(&models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:             "Cancel",
+			handler:          func(h *Handler) http.HandlerFunc { return h.Cancel },
+			reconciliationID: reconciliationID.String(),
+			expectedCode:     http.StatusOK,
+			expectedBody:     `{"status":200,"body":{}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().Cancel(gomock.Any(), uuidTest, reconciliationID).Return(nil)
+			},
+		},
+		{
+			name:             "Invalid reconciliation id",
+			handler:          func(h *Handler) http.HandlerFunc { return h.Finish },
+			reconciliationID: "invalid",
+			expectedCode:     http.StatusBadRequest,
+			expectedBody:     `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn:    func(mockUsecase *mocks.MockUsecase) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("PUT", "/api/reconciliation/"+tt.reconciliationID, nil)
+			req = mux.SetURLVars(req, map[string]string{"reconciliation_id": tt.reconciliationID})
+
+			ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, user)
+			req = req.WithContext(ctx)
+
+			recorder := httptest.NewRecorder()
+
+			tt.handler(mockHandler)(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reconciliation.go
+
+// Package mock_reconciliation is a generated GoMock package.
+package mock_reconciliation
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockUsecase) Cancel(ctx context.Context, userID, reconciliationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, userID, reconciliationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockUsecaseMockRecorder) Cancel(ctx, userID, reconciliationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockUsecase)(nil).Cancel), ctx, userID, reconciliationID)
+}
+
+// Clear mocks base method.
+func (m *MockUsecase) Clear(ctx context.Context, userID, reconciliationID uuid.UUID, transactionIDs []uuid.UUID, cleared bool) (*models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, userID, reconciliationID, transactionIDs, cleared)
+	ret0, _ := ret[0].(*models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockUsecaseMockRecorder) Clear(ctx, userID, reconciliationID, transactionIDs, cleared interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockUsecase)(nil).Clear), ctx, userID, reconciliationID, transactionIDs, cleared)
+}
+
+// Finish mocks base method.
+func (m *MockUsecase) Finish(ctx context.Context, userID, reconciliationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, userID, reconciliationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockUsecaseMockRecorder) Finish(ctx, userID, reconciliationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockUsecase)(nil).Finish), ctx, userID, reconciliationID)
+}
+
+// Get mocks base method.
+func (m *MockUsecase) Get(ctx context.Context, userID, reconciliationID uuid.UUID) (*models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID, reconciliationID)
+	ret0, _ := ret[0].(*models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUsecaseMockRecorder) Get(ctx, userID, reconciliationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsecase)(nil).Get), ctx, userID, reconciliationID)
+}
+
+// GetAccountReconciliations mocks base method.
+func (m *MockUsecase) GetAccountReconciliations(ctx context.Context, userID, accountID uuid.UUID) ([]models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountReconciliations", ctx, userID, accountID)
+	ret0, _ := ret[0].([]models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountReconciliations indicates an expected call of GetAccountReconciliations.
+func (mr *MockUsecaseMockRecorder) GetAccountReconciliations(ctx, userID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountReconciliations", reflect.TypeOf((*MockUsecase)(nil).GetAccountReconciliations), ctx, userID, accountID)
+}
+
+// Start mocks base method.
+func (m *MockUsecase) Start(ctx context.Context, userID uuid.UUID, reconciliation *models.Reconciliation) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userID, reconciliation)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockUsecaseMockRecorder) Start(ctx, userID, reconciliation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockUsecase)(nil).Start), ctx, userID, reconciliation)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelReconciliation mocks base method.
+func (m *MockRepository) CancelReconciliation(ctx context.Context, reconciliationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReconciliation", ctx, reconciliationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReconciliation indicates an expected call of CancelReconciliation.
+func (mr *MockRepositoryMockRecorder) CancelReconciliation(ctx, reconciliationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReconciliation", reflect.TypeOf((*MockRepository)(nil).CancelReconciliation), ctx, reconciliationID)
+}
+
+// CreateReconciliation mocks base method.
+func (m *MockRepository) CreateReconciliation(ctx context.Context, reconciliation *models.Reconciliation) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReconciliation", ctx, reconciliation)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReconciliation indicates an expected call of CreateReconciliation.
+func (mr *MockRepositoryMockRecorder) CreateReconciliation(ctx, reconciliation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReconciliation", reflect.TypeOf((*MockRepository)(nil).CreateReconciliation), ctx, reconciliation)
+}
+
+// FinishReconciliation mocks base method.
+func (m *MockRepository) FinishReconciliation(ctx context.Context, reconciliation *models.Reconciliation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishReconciliation", ctx, reconciliation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishReconciliation indicates an expected call of FinishReconciliation.
+func (mr *MockRepositoryMockRecorder) FinishReconciliation(ctx, reconciliation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishReconciliation", reflect.TypeOf((*MockRepository)(nil).FinishReconciliation), ctx, reconciliation)
+}
+
+// GetAccountReconciliations mocks base method.
+func (m *MockRepository) GetAccountReconciliations(ctx context.Context, accountID uuid.UUID) ([]models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountReconciliations", ctx, accountID)
+	ret0, _ := ret[0].([]models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountReconciliations indicates an expected call of GetAccountReconciliations.
+func (mr *MockRepositoryMockRecorder) GetAccountReconciliations(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountReconciliations", reflect.TypeOf((*MockRepository)(nil).GetAccountReconciliations), ctx, accountID)
+}
+
+// GetClearedBalance mocks base method.
+func (m *MockRepository) GetClearedBalance(ctx context.Context, accountID uuid.UUID, statementDate time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClearedBalance", ctx, accountID, statementDate)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClearedBalance indicates an expected call of GetClearedBalance.
+func (mr *MockRepositoryMockRecorder) GetClearedBalance(ctx, accountID, statementDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClearedBalance", reflect.TypeOf((*MockRepository)(nil).GetClearedBalance), ctx, accountID, statementDate)
+}
+
+// GetReconciliation mocks base method.
+func (m *MockRepository) GetReconciliation(ctx context.Context, reconciliationID uuid.UUID) (*models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliation", ctx, reconciliationID)
+	ret0, _ := ret[0].(*models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliation indicates an expected call of GetReconciliation.
+func (mr *MockRepositoryMockRecorder) GetReconciliation(ctx, reconciliationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliation", reflect.TypeOf((*MockRepository)(nil).GetReconciliation), ctx, reconciliationID)
+}
+
+// GetTransactions mocks base method.
+func (m *MockRepository) GetTransactions(ctx context.Context, accountID uuid.UUID, statementDate time.Time) ([]models.ReconciliationTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, accountID, statementDate)
+	ret0, _ := ret[0].([]models.ReconciliationTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockRepositoryMockRecorder) GetTransactions(ctx, accountID, statementDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockRepository)(nil).GetTransactions), ctx, accountID, statementDate)
+}
+
+// HasOpenReconciliation mocks base method.
+func (m *MockRepository) HasOpenReconciliation(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOpenReconciliation", ctx, accountID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOpenReconciliation indicates an expected call of HasOpenReconciliation.
+func (mr *MockRepositoryMockRecorder) HasOpenReconciliation(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOpenReconciliation", reflect.TypeOf((*MockRepository)(nil).HasOpenReconciliation), ctx, accountID)
+}
+
+// SetCleared mocks base method.
+func (m *MockRepository) SetCleared(ctx context.Context, accountID uuid.UUID, statementDate time.Time, transactionIDs []uuid.UUID, cleared bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCleared", ctx, accountID, statementDate, transactionIDs, cleared)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCleared indicates an expected call of SetCleared.
+func (mr *MockRepositoryMockRecorder) SetCleared(ctx, accountID, statementDate, transactionIDs, cleared interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCleared", reflect.TypeOf((*MockRepository)(nil).SetCleared), ctx, accountID, statementDate, transactionIDs, cleared)
+}
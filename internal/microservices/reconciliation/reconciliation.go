@@ -0,0 +1,30 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	Start(ctx context.Context, userID uuid.UUID, reconciliation *models.Reconciliation) (uuid.UUID, error)
+	Get(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) (*models.Reconciliation, error)
+	GetAccountReconciliations(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) ([]models.Reconciliation, error)
+	Clear(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID, transactionIDs []uuid.UUID, cleared bool) (*models.Reconciliation, error)
+	Finish(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) error
+	Cancel(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) error
+}
+
+type Repository interface {
+	CreateReconciliation(ctx context.Context, reconciliation *models.Reconciliation) (uuid.UUID, error)
+	HasOpenReconciliation(ctx context.Context, accountID uuid.UUID) (bool, error)
+	GetReconciliation(ctx context.Context, reconciliationID uuid.UUID) (*models.Reconciliation, error)
+	GetAccountReconciliations(ctx context.Context, accountID uuid.UUID) ([]models.Reconciliation, error)
+	GetTransactions(ctx context.Context, accountID uuid.UUID, statementDate time.Time) ([]models.ReconciliationTransaction, error)
+	GetClearedBalance(ctx context.Context, accountID uuid.UUID, statementDate time.Time) (float64, error)
+	SetCleared(ctx context.Context, accountID uuid.UUID, statementDate time.Time, transactionIDs []uuid.UUID, cleared bool) error
+	FinishReconciliation(ctx context.Context, reconciliation *models.Reconciliation) error
+	CancelReconciliation(ctx context.Context, reconciliationID uuid.UUID) error
+}
@@ -0,0 +1,262 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ReconciliationCreate = `INSERT INTO Reconciliation (account_id, user_id, statement_date, closing_balance)
+							VALUES ($1, $2, $3, $4) RETURNING id;`
+
+	ReconciliationOpenCheck = `SELECT EXISTS(SELECT 1 FROM Reconciliation WHERE account_id = $1 AND status = 'open');`
+
+	reconciliationSelect = `SELECT id, account_id, user_id, statement_date, closing_balance, status, created_at, finished_at
+							FROM Reconciliation`
+
+	ReconciliationGet = reconciliationSelect + `
+							WHERE id = $1;`
+
+	ReconciliationsAccountGet = reconciliationSelect + `
+							WHERE account_id = $1
+							ORDER BY statement_date DESC, created_at DESC;`
+
+	// the transactions of the account up to the statement date that are not locked yet,
+	// the amount is what the transaction brings to the account
+	ReconciliationTransactionsGet = `SELECT t.id, t.date, COALESCE(t.payer, ''), COALESCE(t.description, ''),
+										CASE WHEN t.account_income = $1 THEN COALESCE(t.income, 0) ELSE 0 END -
+										CASE WHEN t.account_outcome = $1 THEN COALESCE(t.outcome, 0) ELSE 0 END,
+										t.cleared
+									 FROM Transaction t
+									 WHERE (t.account_income = $1 OR t.account_outcome = $1)
+										AND t.reconciliation_id IS NULL AND t.date::date <= $2
+									 ORDER BY t.date, t.id;`
+
+	// the balance of the account without the transactions the statement doesn't have yet
+	ReconciliationClearedBalanceGet = `SELECT COALESCE(a.balance, 0) - COALESCE(SUM(
+											CASE WHEN t.account_income = a.id THEN COALESCE(t.income, 0) ELSE 0 END -
+											CASE WHEN t.account_outcome = a.id THEN COALESCE(t.outcome, 0) ELSE 0 END), 0)
+									   FROM Accounts a
+									   LEFT JOIN Transaction t ON (t.account_income = a.id OR t.account_outcome = a.id)
+											AND NOT (t.cleared AND t.date::date <= $2)
+									   WHERE a.id = $1
+									   GROUP BY a.id;`
+
+	ReconciliationSetCleared = `UPDATE Transaction SET cleared = $4
+								WHERE id = ANY($3) AND (account_income = $1 OR account_outcome = $1)
+									AND reconciliation_id IS NULL AND date::date <= $2;`
+
+	ReconciliationFinish = `UPDATE Reconciliation SET status = 'finished', finished_at = now()
+							WHERE id = $1 AND status = 'open';`
+	ReconciliationLock = `UPDATE Transaction SET reconciliation_id = $1
+						  WHERE (account_income = $2 OR account_outcome = $2) AND cleared
+							AND reconciliation_id IS NULL AND date::date <= $3;`
+	ReconciliationCancel = `UPDATE Reconciliation SET status = 'cancelled', finished_at = now()
+							WHERE id = $1 AND status = 'open';`
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) CreateReconciliation(ctx context.Context, reconciliation *models.Reconciliation) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, ReconciliationCreate,
+		reconciliation.AccountID,
+		reconciliation.UserID,
+		reconciliation.StatementDate,
+		reconciliation.ClosingBalance,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[repo] failed to create reconciliation: %w", err)
+	}
+	return id, nil
+}
+
+func (r *Repository) HasOpenReconciliation(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, ReconciliationOpenCheck, accountID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("[repo] %w", err)
+	}
+	return exists, nil
+}
+
+func (r *Repository) GetReconciliation(ctx context.Context, reconciliationID uuid.UUID) (*models.Reconciliation, error) {
+	reconciliation, err := scanReconciliation(r.db.QueryRow(ctx, ReconciliationGet, reconciliationID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchReconciliationError{ReconciliationID: reconciliationID})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &reconciliation, nil
+}
+
+func (r *Repository) GetAccountReconciliations(ctx context.Context, accountID uuid.UUID) ([]models.Reconciliation, error) {
+	rows, err := r.db.Query(ctx, ReconciliationsAccountGet, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	reconciliations := []models.Reconciliation{}
+	for rows.Next() {
+		reconciliation, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return reconciliations, nil
+}
+
+func (r *Repository) GetTransactions(ctx context.Context, accountID uuid.UUID, statementDate time.Time) ([]models.ReconciliationTransaction, error) {
+	rows, err := r.db.Query(ctx, ReconciliationTransactionsGet, accountID, statementDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []models.ReconciliationTransaction{}
+	for rows.Next() {
+		var transaction models.ReconciliationTransaction
+		if err := rows.Scan(
+			&transaction.ID,
+			&transaction.Date,
+			&transaction.Payer,
+			&transaction.Description,
+			&transaction.Amount,
+			&transaction.Cleared,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *Repository) GetClearedBalance(ctx context.Context, accountID uuid.UUID, statementDate time.Time) (float64, error) {
+	var balance float64
+	err := r.db.QueryRow(ctx, ReconciliationClearedBalanceGet, accountID, statementDate).Scan(&balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("[repo] %w", &models.NoSuchAccounts{})
+	}
+	if err != nil {
+		return 0, fmt.Errorf("[repo] failed to get cleared balance: %w", err)
+	}
+	return balance, nil
+}
+
+// SetCleared marks the transactions of the account up to the statement date, nothing changes
+// if one of them is of another account, later than the statement or already locked
+func (r *Repository) SetCleared(ctx context.Context, accountID uuid.UUID, statementDate time.Time, transactionIDs []uuid.UUID, cleared bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, ReconciliationSetCleared, accountID, statementDate, transactionIDs, cleared)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to clear transactions: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(transactionIDs)) {
+		err = &models.ReconciliationOperationError{Reason: "transactions are not in the statement or are already reconciled"}
+		return fmt.Errorf("[repo] %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// FinishReconciliation closes the reconciliation and locks its cleared transactions
+func (r *Repository) FinishReconciliation(ctx context.Context, reconciliation *models.Reconciliation) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, ReconciliationFinish, reconciliation.ID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to finish reconciliation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = &models.ReconciliationOperationError{Reason: "reconciliation is not open"}
+		return fmt.Errorf("[repo] %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, ReconciliationLock, reconciliation.ID, reconciliation.AccountID, reconciliation.StatementDate); err != nil {
+		return fmt.Errorf("[repo] failed to lock transactions: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) CancelReconciliation(ctx context.Context, reconciliationID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, ReconciliationCancel, reconciliationID)
+	if err != nil {
+		return fmt.Errorf("[repo] failed to cancel reconciliation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("[repo] %w", &models.ReconciliationOperationError{Reason: "reconciliation is not open"})
+	}
+	return nil
+}
+
+func scanReconciliation(row pgx.Row) (models.Reconciliation, error) {
+	var reconciliation models.Reconciliation
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.AccountID,
+		&reconciliation.UserID,
+		&reconciliation.StatementDate,
+		&reconciliation.ClosingBalance,
+		&reconciliation.Status,
+		&reconciliation.CreatedAt,
+		&reconciliation.FinishedAt,
+	)
+	return reconciliation, err
+}
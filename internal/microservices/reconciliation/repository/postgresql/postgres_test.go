@@ -0,0 +1,431 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var reconciliationRowColumns = []string{"id", "account_id", "user_id", "statement_date", "closing_balance",
+	"status", "created_at", "finished_at"}
+
+func testReconciliation() models.Reconciliation {
+	return models.Reconciliation{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		UserID:         uuid.New(),
+		StatementDate:  time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC),
+		ClosingBalance: 1250.5,
+		Status:         models.ReconciliationOpen,
+		CreatedAt:      time.Date(2023, 12, 2, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func reconciliationRow(rows *pgxmock.Rows, r models.Reconciliation) *pgxmock.Rows {
+	return rows.AddRow(r.ID, r.AccountID, r.UserID, r.StatementDate, r.ClosingBalance,
+		r.Status, r.CreatedAt, r.FinishedAt)
+}
+
+func Test_CreateReconciliation(t *testing.T) {
+	reconciliation := testReconciliation()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			rows:     pgxmock.NewRows([]string{"id"}).AddRow(reconciliation.ID),
+			expected: reconciliation.ID,
+		},
+		{
+			name:        "Error",
+			rows:        pgxmock.NewRows([]string{"id"}),
+			rowsError:   errors.New("err"),
+			expected:    uuid.Nil,
+			expectedErr: fmt.Errorf("[repo] failed to create reconciliation: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(ReconciliationCreate)).
+				WithArgs(reconciliation.AccountID, reconciliation.UserID, reconciliation.StatementDate, reconciliation.ClosingBalance).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			id, err := repo.CreateReconciliation(context.Background(), &reconciliation)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, id)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetReconciliation(t *testing.T) {
+	reconciliation := testReconciliation()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    *models.Reconciliation
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			rows:     reconciliationRow(pgxmock.NewRows(reconciliationRowColumns), reconciliation),
+			expected: &reconciliation,
+		},
+		{
+			name:        "No such reconciliation",
+			rows:        pgxmock.NewRows(reconciliationRowColumns),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchReconciliationError{ReconciliationID: reconciliation.ID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(reconciliationRowColumns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(ReconciliationGet)).
+				WithArgs(reconciliation.ID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := repo.GetReconciliation(context.Background(), reconciliation.ID)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetTransactions(t *testing.T) {
+	reconciliation := testReconciliation()
+	transaction := models.ReconciliationTransaction{
+		ID:          uuid.New(),
+		Date:        time.Date(2023, 11, 20, 0, 0, 0, 0, time.UTC),
+		Payer:       "Пятёрочка",
+		Description: "продукты",
+		Amount:      -320,
+		Cleared:     true,
+	}
+	columns := []string{"id", "date", "payer", "description", "amount", "cleared"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    []models.ReconciliationTransaction
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).AddRow(transaction.ID, transaction.Date, transaction.Payer,
+				transaction.Description, transaction.Amount, transaction.Cleared),
+			expected: []models.ReconciliationTransaction{transaction},
+		},
+		{
+			name:     "Nothing to clear",
+			rows:     pgxmock.NewRows(columns),
+			expected: []models.ReconciliationTransaction{},
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(ReconciliationTransactionsGet)).
+				WithArgs(reconciliation.AccountID, reconciliation.StatementDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := repo.GetTransactions(context.Background(), reconciliation.AccountID, reconciliation.StatementDate)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetClearedBalance(t *testing.T) {
+	reconciliation := testReconciliation()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    float64
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			rows:     pgxmock.NewRows([]string{"balance"}).AddRow(1250.5),
+			expected: 1250.5,
+		},
+		{
+			name:        "No such account",
+			rows:        pgxmock.NewRows([]string{"balance"}),
+			rowsError:   pgx.ErrNoRows,
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchAccounts{}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows([]string{"balance"}),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to get cleared balance: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectQuery(regexp.QuoteMeta(ReconciliationClearedBalanceGet)).
+				WithArgs(reconciliation.AccountID, reconciliation.StatementDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			result, err := repo.GetClearedBalance(context.Background(), reconciliation.AccountID, reconciliation.StatementDate)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_SetCleared(t *testing.T) {
+	reconciliation := testReconciliation()
+	transactionIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("UPDATE 2"),
+		},
+		{
+			name:   "Transaction out of the statement",
+			result: pgconn.CommandTag("UPDATE 1"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.ReconciliationOperationError{
+				Reason: "transactions are not in the statement or are already reconciled",
+			}),
+		},
+		{
+			name:        "Exec error",
+			execError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to clear transactions: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec(regexp.QuoteMeta(ReconciliationSetCleared)).
+				WithArgs(reconciliation.AccountID, reconciliation.StatementDate, transactionIDs, true)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			if tc.expectedErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err := repo.SetCleared(context.Background(), reconciliation.AccountID, reconciliation.StatementDate, transactionIDs, true)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_FinishReconciliation(t *testing.T) {
+	reconciliation := testReconciliation()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		lockError   error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:        "Not open",
+			result:      pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.ReconciliationOperationError{Reason: "reconciliation is not open"}),
+		},
+		{
+			name:        "Lock error",
+			result:      pgconn.CommandTag("UPDATE 1"),
+			lockError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to lock transactions: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(ReconciliationFinish)).
+				WithArgs(reconciliation.ID).
+				WillReturnResult(tc.result)
+
+			if tc.result.RowsAffected() != 0 {
+				lock := mock.ExpectExec(regexp.QuoteMeta(ReconciliationLock)).
+					WithArgs(reconciliation.ID, reconciliation.AccountID, reconciliation.StatementDate)
+				if tc.lockError != nil {
+					lock.WillReturnError(tc.lockError)
+				} else {
+					lock.WillReturnResult(pgconn.CommandTag("UPDATE 3"))
+				}
+			}
+
+			if tc.expectedErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err := repo.FinishReconciliation(context.Background(), &reconciliation)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_CancelReconciliation(t *testing.T) {
+	reconciliation := testReconciliation()
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:   "Success",
+			result: pgconn.CommandTag("UPDATE 1"),
+		},
+		{
+			name:        "Not open",
+			result:      pgconn.CommandTag("UPDATE 0"),
+			expectedErr: fmt.Errorf("[repo] %w", &models.ReconciliationOperationError{Reason: "reconciliation is not open"}),
+		},
+		{
+			name:        "Exec error",
+			execError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] failed to cancel reconciliation: %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(ReconciliationCancel)).
+				WithArgs(reconciliation.ID)
+			if tc.execError != nil {
+				exec.WillReturnError(tc.execError)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := repo.CancelReconciliation(context.Background(), reconciliation.ID)
+
+			assert.Equal(t, tc.expectedErr, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	reconciliationRepo reconciliation.Repository
+	accountRepo        account.Repository
+	logger             logger.Logger
+}
+
+func NewUsecase(
+	rr reconciliation.Repository,
+	log logger.Logger,
+	ar account.Repository) *Usecase {
+	return &Usecase{
+		reconciliationRepo: rr,
+		accountRepo:        ar,
+		logger:             log,
+	}
+}
+
+// Start opens a reconciliation of the account against the statement, an account has one open at a time
+func (u *Usecase) Start(ctx context.Context, userID uuid.UUID, reconciliation *models.Reconciliation) (uuid.UUID, error) {
+	if err := u.checkEditor(ctx, reconciliation.AccountID, userID); err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't reconcile the account: %w", err)
+	}
+
+	open, err := u.reconciliationRepo.HasOpenReconciliation(ctx, reconciliation.AccountID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't check reconciliations %w", err)
+	}
+	if open {
+		return uuid.Nil, fmt.Errorf("[usecase] %w", &models.ReconciliationOperationError{Reason: "account already has an open reconciliation"})
+	}
+
+	reconciliation.UserID = userID
+
+	id, err := u.reconciliationRepo.CreateReconciliation(ctx, reconciliation)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't create reconciliation %w", err)
+	}
+	return id, nil
+}
+
+// Get returns the reconciliation with the difference left, an open one lists the transactions to clear
+func (u *Usecase) Get(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) (*models.Reconciliation, error) {
+	reconciliation, err := u.getReconciliation(ctx, userID, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reconciliation.Status != models.ReconciliationOpen {
+		return reconciliation, nil
+	}
+
+	if err := u.fillBalance(ctx, reconciliation); err != nil {
+		return nil, err
+	}
+	return reconciliation, nil
+}
+
+func (u *Usecase) GetAccountReconciliations(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) ([]models.Reconciliation, error) {
+	if err := u.checkEditor(ctx, accountID, userID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't get reconciliations of the account: %w", err)
+	}
+
+	reconciliations, err := u.reconciliationRepo.GetAccountReconciliations(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get reconciliations from repository %w", err)
+	}
+	return reconciliations, nil
+}
+
+// Clear marks the transactions as cleared or not and returns the reconciliation with the new difference
+func (u *Usecase) Clear(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID, transactionIDs []uuid.UUID, cleared bool) (*models.Reconciliation, error) {
+	reconciliation, err := u.getOpenReconciliation(ctx, userID, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.reconciliationRepo.SetCleared(ctx, reconciliation.AccountID, reconciliation.StatementDate, transactionIDs, cleared)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't clear transactions %w", err)
+	}
+
+	if err := u.fillBalance(ctx, reconciliation); err != nil {
+		return nil, err
+	}
+	return reconciliation, nil
+}
+
+// Finish locks the cleared transactions once they meet the closing balance of the statement
+func (u *Usecase) Finish(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) error {
+	reconciliation, err := u.getOpenReconciliation(ctx, userID, reconciliationID)
+	if err != nil {
+		return err
+	}
+
+	clearedBalance, err := u.reconciliationRepo.GetClearedBalance(ctx, reconciliation.AccountID, reconciliation.StatementDate)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get cleared balance %w", err)
+	}
+	if difference := roundCents(reconciliation.ClosingBalance - clearedBalance); difference != 0 {
+		return fmt.Errorf("[usecase] %w", &models.ReconciliationOperationError{
+			Reason: fmt.Sprintf("cleared transactions differ from the statement by %.2f", difference),
+		})
+	}
+
+	if err := u.reconciliationRepo.FinishReconciliation(ctx, reconciliation); err != nil {
+		return fmt.Errorf("[usecase] can't finish reconciliation %w", err)
+	}
+	return nil
+}
+
+// Cancel drops the open reconciliation, the transactions keep their cleared marks
+func (u *Usecase) Cancel(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) error {
+	if _, err := u.getOpenReconciliation(ctx, userID, reconciliationID); err != nil {
+		return err
+	}
+
+	if err := u.reconciliationRepo.CancelReconciliation(ctx, reconciliationID); err != nil {
+		return fmt.Errorf("[usecase] can't cancel reconciliation %w", err)
+	}
+	return nil
+}
+
+func (u *Usecase) getReconciliation(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) (*models.Reconciliation, error) {
+	reconciliation, err := u.reconciliationRepo.GetReconciliation(ctx, reconciliationID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get reconciliation from repository %w", err)
+	}
+
+	if err := u.checkEditor(ctx, reconciliation.AccountID, userID); err != nil {
+		return nil, fmt.Errorf("[usecase] can't reconcile the account: %w", err)
+	}
+	return reconciliation, nil
+}
+
+func (u *Usecase) getOpenReconciliation(ctx context.Context, userID uuid.UUID, reconciliationID uuid.UUID) (*models.Reconciliation, error) {
+	reconciliation, err := u.getReconciliation(ctx, userID, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+	if reconciliation.Status != models.ReconciliationOpen {
+		return nil, fmt.Errorf("[usecase] %w", &models.ReconciliationOperationError{Reason: "reconciliation is " + reconciliation.Status})
+	}
+	return reconciliation, nil
+}
+
+func (u *Usecase) fillBalance(ctx context.Context, reconciliation *models.Reconciliation) error {
+	transactions, err := u.reconciliationRepo.GetTransactions(ctx, reconciliation.AccountID, reconciliation.StatementDate)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get transactions from repository %w", err)
+	}
+
+	clearedBalance, err := u.reconciliationRepo.GetClearedBalance(ctx, reconciliation.AccountID, reconciliation.StatementDate)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't get cleared balance %w", err)
+	}
+
+	reconciliation.Transactions = transactions
+	reconciliation.ClearedBalance = clearedBalance
+	reconciliation.Difference = roundCents(reconciliation.ClosingBalance - clearedBalance)
+	return nil
+}
+
+func (u *Usecase) checkEditor(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error {
+	role, err := u.accountRepo.GetRole(ctx, accountID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.CanEdit() {
+		return fmt.Errorf("[usecase] %s can't reconcile: %w", role, &models.ForbiddenUserError{})
+	}
+	return nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
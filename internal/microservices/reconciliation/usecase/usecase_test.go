@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/reconciliation/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testReconciliation(status string) *models.Reconciliation {
+	return &models.Reconciliation{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		StatementDate:  time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC),
+		ClosingBalance: 1000,
+		Status:         status,
+	}
+}
+
+func TestUsecase_Start(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	reconciliationID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		expected     uuid.UUID
+		expectedErr  error
+		forbidden    bool
+		operationErr string
+		mockFn       func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name:     "Success",
+			expected: reconciliationID,
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountEditor, nil)
+				rr.EXPECT().HasOpenReconciliation(gomock.Any(), accountID).Return(false, nil)
+				rr.EXPECT().CreateReconciliation(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, reconciliation *models.Reconciliation) (uuid.UUID, error) {
+						assert.Equal(t, userID, reconciliation.UserID)
+						return reconciliationID, nil
+					})
+			},
+		},
+		{
+			name:      "Viewer",
+			forbidden: true,
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountViewer, nil)
+			},
+		},
+		{
+			name:         "Already open",
+			operationErr: "account already has an open reconciliation",
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+				rr.EXPECT().HasOpenReconciliation(gomock.Any(), accountID).Return(true, nil)
+			},
+		},
+		{
+			name:        "Create error",
+			expectedErr: errors.New("err"),
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				ar.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+				rr.EXPECT().HasOpenReconciliation(gomock.Any(), accountID).Return(false, nil)
+				rr.EXPECT().CreateReconciliation(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("err"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockFn(mockRepo, mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo)
+
+			id, err := mockUsecase.Start(context.Background(), userID,
+				&models.Reconciliation{AccountID: accountID, ClosingBalance: 1000})
+
+			assert.Equal(t, tc.expected, id)
+			switch {
+			case tc.forbidden:
+				var errForbidden *models.ForbiddenUserError
+				assert.ErrorAs(t, err, &errForbidden)
+			case tc.operationErr != "":
+				var errOperation *models.ReconciliationOperationError
+				if assert.ErrorAs(t, err, &errOperation) {
+					assert.Equal(t, tc.operationErr, errOperation.Reason)
+				}
+			case tc.expectedErr != nil:
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAccountRepo := mock_account.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo)
+
+	userID := uuid.New()
+	open := testReconciliation(models.ReconciliationOpen)
+	transactions := []models.ReconciliationTransaction{{ID: uuid.New(), Amount: -250.1, Cleared: true}}
+
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountOwner, nil)
+	mockRepo.EXPECT().GetTransactions(gomock.Any(), open.AccountID, open.StatementDate).Return(transactions, nil)
+	mockRepo.EXPECT().GetClearedBalance(gomock.Any(), open.AccountID, open.StatementDate).Return(749.9, nil)
+	result, err := mockUsecase.Get(context.Background(), userID, open.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, transactions, result.Transactions)
+	assert.Equal(t, 749.9, result.ClearedBalance)
+	assert.Equal(t, 250.1, result.Difference)
+
+	finished := testReconciliation(models.ReconciliationFinished)
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), finished.ID).Return(finished, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), finished.AccountID, userID).Return(models.AccountEditor, nil)
+	result, err = mockUsecase.Get(context.Background(), userID, finished.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Transactions)
+
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountContributor, nil)
+	_, err = mockUsecase.Get(context.Background(), userID, open.ID)
+	var errForbidden *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbidden)
+}
+
+func TestUsecase_Clear(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAccountRepo := mock_account.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo)
+
+	userID := uuid.New()
+	open := testReconciliation(models.ReconciliationOpen)
+	transactionIDs := []uuid.UUID{uuid.New()}
+
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountEditor, nil)
+	mockRepo.EXPECT().SetCleared(gomock.Any(), open.AccountID, open.StatementDate, transactionIDs, true).Return(nil)
+	mockRepo.EXPECT().GetTransactions(gomock.Any(), open.AccountID, open.StatementDate).Return([]models.ReconciliationTransaction{}, nil)
+	mockRepo.EXPECT().GetClearedBalance(gomock.Any(), open.AccountID, open.StatementDate).Return(1000.0, nil)
+	result, err := mockUsecase.Clear(context.Background(), userID, open.ID, transactionIDs, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, result.Difference)
+
+	cancelled := testReconciliation(models.ReconciliationCancelled)
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), cancelled.ID).Return(cancelled, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), cancelled.AccountID, userID).Return(models.AccountEditor, nil)
+	_, err = mockUsecase.Clear(context.Background(), userID, cancelled.ID, transactionIDs, true)
+	var errOperation *models.ReconciliationOperationError
+	if assert.ErrorAs(t, err, &errOperation) {
+		assert.Equal(t, "reconciliation is cancelled", errOperation.Reason)
+	}
+}
+
+func TestUsecase_Finish(t *testing.T) {
+	userID := uuid.New()
+	open := testReconciliation(models.ReconciliationOpen)
+
+	testCases := []struct {
+		name         string
+		expectedErr  error
+		operationErr string
+		mockFn       func(*mock.MockRepository, *mock_account.MockRepository)
+	}{
+		{
+			name: "Success",
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				rr.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+				ar.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountEditor, nil)
+				rr.EXPECT().GetClearedBalance(gomock.Any(), open.AccountID, open.StatementDate).Return(999.999, nil)
+				rr.EXPECT().FinishReconciliation(gomock.Any(), open).Return(nil)
+			},
+		},
+		{
+			name:         "Difference left",
+			operationErr: "cleared transactions differ from the statement by 120.50",
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				rr.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+				ar.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountEditor, nil)
+				rr.EXPECT().GetClearedBalance(gomock.Any(), open.AccountID, open.StatementDate).Return(879.5, nil)
+			},
+		},
+		{
+			name:        "No such reconciliation",
+			expectedErr: &models.NoSuchReconciliationError{ReconciliationID: open.ID},
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				rr.EXPECT().GetReconciliation(gomock.Any(), open.ID).
+					Return(nil, &models.NoSuchReconciliationError{ReconciliationID: open.ID})
+			},
+		},
+		{
+			name:        "Finish error",
+			expectedErr: errors.New("err"),
+			mockFn: func(rr *mock.MockRepository, ar *mock_account.MockRepository) {
+				rr.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+				ar.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountEditor, nil)
+				rr.EXPECT().GetClearedBalance(gomock.Any(), open.AccountID, open.StatementDate).Return(1000.0, nil)
+				rr.EXPECT().FinishReconciliation(gomock.Any(), open).Return(errors.New("err"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mock_account.NewMockRepository(ctrl)
+			tc.mockFn(mockRepo, mockAccountRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo)
+
+			err := mockUsecase.Finish(context.Background(), userID, open.ID)
+
+			switch {
+			case tc.operationErr != "":
+				var errOperation *models.ReconciliationOperationError
+				if assert.ErrorAs(t, err, &errOperation) {
+					assert.Equal(t, tc.operationErr, errOperation.Reason)
+				}
+			case tc.expectedErr != nil:
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsecase_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAccountRepo := mock_account.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockAccountRepo)
+
+	userID := uuid.New()
+	open := testReconciliation(models.ReconciliationOpen)
+
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), open.ID).Return(open, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), open.AccountID, userID).Return(models.AccountOwner, nil)
+	mockRepo.EXPECT().CancelReconciliation(gomock.Any(), open.ID).Return(nil)
+	assert.NoError(t, mockUsecase.Cancel(context.Background(), userID, open.ID))
+
+	finished := testReconciliation(models.ReconciliationFinished)
+	mockRepo.EXPECT().GetReconciliation(gomock.Any(), finished.ID).Return(finished, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), finished.AccountID, userID).Return(models.AccountOwner, nil)
+	var errOperation *models.ReconciliationOperationError
+	assert.ErrorAs(t, mockUsecase.Cancel(context.Background(), userID, finished.ID), &errOperation)
+}
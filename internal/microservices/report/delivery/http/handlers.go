@@ -0,0 +1,217 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+type Handler struct {
+	reportService report.Usecase
+	logger        logger.Logger
+}
+
+func NewHandler(ru report.Usecase, l logger.Logger) *Handler {
+	return &Handler{
+		reportService: ru,
+		logger:        l,
+	}
+}
+
+// @Summary		Get balance forecast
+// @Tags		Report
+// @Description	Projects every account balance forward using regular categories, periodic payers and average spending
+// @Produce		json
+// @Param		days	query		int		false	"Forecast horizon in days (1-90, default 30)"
+// @Success		200		{object}	Response[models.Forecast]	"Balance forecast"
+// @Success		204		{object}	Response[string]			"User has no accounts"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/report/forecast [get]
+func (h *Handler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	days, err := getForecastDays(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	forecast, err := h.reportService.GetForecast(r.Context(), user.ID, days)
+
+	var errNoSuchAccounts *models.NoSuchAccounts
+	if errors.As(err, &errNoSuchAccounts) {
+		commonHttp.SuccessResponse(w, http.StatusNoContent, "")
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, ForecastServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, forecast)
+}
+
+// @Summary		Get balance history
+// @Tags		Report
+// @Description	Daily balance of every account and net worth split into savings and spending money
+// @Produce		json
+// @Param		start_date	query		string	false	"Start of the period (RFC3339), month before end_date by default"
+// @Param		end_date	query		string	false	"End of the period (RFC3339), now by default"
+// @Success		200		{object}	Response[models.BalanceHistory]	"Balance history"
+// @Success		204		{object}	Response[string]				"User has no accounts"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/report/balance-history [get]
+func (h *Handler) GetBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	startDate, endDate, err := getHistoryPeriod(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	history, err := h.reportService.GetBalanceHistory(r.Context(), user.ID, startDate, endDate)
+
+	var errNoSuchAccounts *models.NoSuchAccounts
+	if errors.As(err, &errNoSuchAccounts) {
+		commonHttp.SuccessResponse(w, http.StatusNoContent, "")
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, BalanceHistoryServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, history)
+}
+
+// @Summary		Get income and spending summary
+// @Tags		Report
+// @Description	Income and spending by day and by category, transactions of the user only, transfers between accounts are not counted
+// @Produce		json
+// @Param		start_date	query		string	false	"Start of the period (RFC3339), month before end_date by default"
+// @Param		end_date	query		string	false	"End of the period (RFC3339), now by default"
+// @Success		200		{object}	Response[models.Summary]	"Summary"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/report/summary [get]
+func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	startDate, endDate, err := getHistoryPeriod(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	summary, err := h.reportService.GetSummary(r.Context(), user.ID, startDate, endDate)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, SummaryServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, summary)
+}
+
+// @Summary		Get spending of the account members
+// @Tags		Report
+// @Description	Income and spending of every member of a shared account by category, available to any member
+// @Produce		json
+// @Param		account_id	path		string	true	"Account ID"
+// @Param		start_date	query		string	false	"Start of the period (RFC3339), month before end_date by default"
+// @Param		end_date	query		string	false	"End of the period (RFC3339), now by default"
+// @Success		200		{object}	Response[models.MembersReport]	"Members report"
+// @Failure		400		{object}	ResponseError					"Client error"
+// @Failure     401    	{object}    ResponseError  					"Unauthorized user"
+// @Failure     403    	{object}    ResponseError  					"Forbidden user"
+// @Failure		500		{object}	ResponseError					"Server error"
+// @Router		/api/report/account/{account_id}/members [get]
+func (h *Handler) GetMembersReport(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	startDate, endDate, err := getHistoryPeriod(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	report, err := h.reportService.GetMembersReport(r.Context(), user.ID, accountID, startDate, endDate)
+
+	var errForbiddenUser *models.ForbiddenUserError
+	if errors.As(err, &errForbiddenUser) {
+		commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+		return
+	}
+
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, MembersReportServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, report)
+}
+
+// @Summary		Get year in review
+// @Tags		Report
+// @Description	Yearly recap of the user's own transactions: totals, savings rate, top categories and payees, biggest purchase, longest no-spend streak and monthly trends
+// @Produce		json
+// @Param		year	query		int		false	"Year, the current one by default"
+// @Success		200		{object}	Response[models.YearReview]	"Year review"
+// @Failure		400		{object}	ResponseError				"Client error"
+// @Failure     401    	{object}    ResponseError  				"Unauthorized user"
+// @Failure		500		{object}	ResponseError				"Server error"
+// @Router		/api/report/year-review [get]
+func (h *Handler) GetYearReview(w http.ResponseWriter, r *http.Request) {
+	user, err := commonHttp.GetUserFromRequest(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
+		return
+	}
+
+	year, err := getReviewYear(r)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
+		return
+	}
+
+	review, err := h.reportService.GetYearReview(r.Context(), user.ID, year)
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, YearReviewServerError, h.logger)
+		return
+	}
+
+	commonHttp.SuccessResponse(w, http.StatusOK, review)
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/usecase"
+)
+
+const (
+	ForecastServerError       = "can't get forecast"
+	BalanceHistoryServerError = "can't get balance history"
+	SummaryServerError        = "can't get summary"
+	MembersReportServerError  = "can't get members report"
+	YearReviewServerError     = "can't get year review"
+)
+
+const accountID = "account_id"
+
+var (
+	errInvalidDays   = errors.New("invalid value for days")
+	errInvalidPeriod = errors.New("invalid period")
+	errInvalidYear   = errors.New("invalid year")
+)
+
+func getForecastDays(r *http.Request) (int, error) {
+	daysStr := r.URL.Query().Get("days")
+	if daysStr == "" {
+		return usecase.ForecastDefaultDays, nil
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 1 || days > usecase.ForecastMaxDays {
+		return 0, errInvalidDays
+	}
+
+	return days, nil
+}
+
+// getReviewYear reads year, the current one by default
+func getReviewYear(r *http.Request) (int, error) {
+	currentYear := time.Now().Year()
+
+	yearStr := r.URL.Query().Get("year")
+	if yearStr == "" {
+		return currentYear, nil
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year < usecase.YearReviewMinYear || year > currentYear {
+		return 0, errInvalidYear
+	}
+
+	return year, nil
+}
+
+// getHistoryPeriod reads start_date and end_date, by default it is the last month up to now
+func getHistoryPeriod(r *http.Request) (time.Time, time.Time, error) {
+	query, err := commonHttp.GetQueryParam(r)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	endDate := query.EndDate
+	if endDate.IsZero() {
+		endDate = time.Now()
+	}
+
+	startDate := query.StartDate
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, 0, -(usecase.BalanceHistoryDefaultDays - 1))
+	}
+
+	if startDate.After(endDate) || endDate.Sub(startDate) > usecase.BalanceHistoryMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errInvalidPeriod
+	}
+
+	return startDate, endDate, nil
+}
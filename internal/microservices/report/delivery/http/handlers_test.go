@@ -0,0 +1,521 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetForecast(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetForecast",
+			user:         user,
+			queryParam:   "days=7",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"days":7,"accounts":[],"events":[],"alerts":[]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetForecast(gomock.Any(), uuidTest, 7).Return(&models.Forecast{
+					Days:     7,
+					Accounts: []models.AccountForecast{},
+					Events:   []models.ForecastEvent{},
+					Alerts:   []models.ForecastAlert{},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				// No service calls are expected for unauthorized request.
+			},
+		},
+		{
+			name:         "Invalid days",
+			user:         user,
+			queryParam:   "days=1000",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No accounts",
+			user:         user,
+			expectedCode: http.StatusNoContent,
+			expectedBody: `{"status":204,"body":""}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetForecast(gomock.Any(), uuidTest, 30).Return(nil, &models.NoSuchAccounts{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get forecast"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetForecast(gomock.Any(), uuidTest, 30).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/report/forecast?"+tt.queryParam, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetForecast(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetBalanceHistory(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetBalanceHistory",
+			user:         user,
+			queryParam:   "start_date=2023-12-01T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"start_date":"2023-12-01T00:00:00Z","end_date":"2023-12-01T00:00:00Z","accounts":[],"net_worth":[]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				date := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+				mockUsecase.EXPECT().GetBalanceHistory(gomock.Any(), uuidTest, date, date).Return(&models.BalanceHistory{
+					StartDate: date,
+					EndDate:   date,
+					Accounts:  []models.AccountBalanceHistory{},
+					NetWorth:  []models.NetWorthPoint{},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				// No service calls are expected for unauthorized request.
+			},
+		},
+		{
+			name:         "Start after end",
+			user:         user,
+			queryParam:   "start_date=2023-12-02T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Too long period",
+			user:         user,
+			queryParam:   "start_date=2020-12-02T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "No accounts",
+			user:         user,
+			expectedCode: http.StatusNoContent,
+			expectedBody: `{"status":204,"body":""}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetBalanceHistory(gomock.Any(), uuidTest, gomock.Any(), gomock.Any()).Return(nil, &models.NoSuchAccounts{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get balance history"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetBalanceHistory(gomock.Any(), uuidTest, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/report/balance-history?"+tt.queryParam, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetBalanceHistory(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetSummary(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetSummary",
+			user:         user,
+			queryParam:   "start_date=2023-12-01T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"start_date":"2023-12-01T00:00:00Z","end_date":"2023-12-01T00:00:00Z","income":0,"outcome":100,"days":[{"date":"2023-12-01T00:00:00Z","income":0,"outcome":100}],"categories":[]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				date := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+				mockUsecase.EXPECT().GetSummary(gomock.Any(), uuidTest, date, date).Return(&models.Summary{
+					StartDate:  date,
+					EndDate:    date,
+					Outcome:    100,
+					Days:       []models.DayTotal{{Date: date, Outcome: 100}},
+					Categories: []models.CategoryTotal{},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Start after end",
+			user:         user,
+			queryParam:   "start_date=2023-12-02T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get summary"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetSummary(gomock.Any(), uuidTest, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/report/summary?"+tt.queryParam, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetSummary(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetMembersReport(t *testing.T) {
+	uuidTest := uuid.New()
+	accountID := uuid.MustParse("8c2f5a1e-3b7d-4e9a-a6c4-2d1f0b9e7a35")
+	memberID := uuid.MustParse("5d9e3c7a-1f2b-4a8e-b6d0-9c4e2a7f1b63")
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		accountID     string
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetMembersReport",
+			user:         user,
+			accountID:    accountID.String(),
+			queryParam:   "start_date=2023-12-01T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"account_id":"8c2f5a1e-3b7d-4e9a-a6c4-2d1f0b9e7a35","start_date":"2023-12-01T00:00:00Z","end_date":"2023-12-01T00:00:00Z","income":0,"outcome":100,"members":[{"user":{"id":"5d9e3c7a-1f2b-4a8e-b6d0-9c4e2a7f1b63","login":"owner","avatar_url":"00000000-0000-0000-0000-000000000000"},"income":0,"outcome":100,"share":100,"categories":[]}]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				date := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+				mockUsecase.EXPECT().GetMembersReport(gomock.Any(), uuidTest, accountID, date, date).Return(&models.MembersReport{
+					AccountID: accountID,
+					StartDate: date,
+					EndDate:   date,
+					Outcome:   100,
+					Members: []models.MemberBreakdown{{
+						User:       models.SharingUser{ID: memberID, Login: "owner"},
+						Outcome:    100,
+						Share:      100,
+						Categories: []models.CategoryTotal{},
+					}},
+				}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid account id",
+			user:         user,
+			accountID:    "invalid",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Start after end",
+			user:         user,
+			accountID:    accountID.String(),
+			queryParam:   "start_date=2023-12-02T00:00:00Z&end_date=2023-12-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Forbidden user",
+			user:         user,
+			accountID:    accountID.String(),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetMembersReport(gomock.Any(), uuidTest, accountID, gomock.Any(), gomock.Any()).
+					Return(nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			accountID:    accountID.String(),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get members report"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetMembersReport(gomock.Any(), uuidTest, accountID, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/report/account/"+tt.accountID+"/members?"+tt.queryParam, nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountID})
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetMembersReport(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
+
+func TestHandler_GetYearReview(t *testing.T) {
+	uuidTest := uuid.New()
+	user := &models.User{ID: uuidTest}
+	tests := []struct {
+		name          string
+		user          *models.User
+		queryParam    string
+		expectedCode  int
+		expectedBody  string
+		mockUsecaseFn func(*mocks.MockUsecase)
+	}{
+		{
+			name:         "Successful call to GetYearReview",
+			user:         user,
+			queryParam:   "year=2023",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"year":2023,"income":1000,"outcome":250,"savings_rate":75,"top_categories":[],"top_payees":[],"biggest_purchase":null,"no_spend_streak":null,"months":[]}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetYearReview(gomock.Any(), uuidTest, 2023).Return(&models.YearReview{
+					Year:          2023,
+					Income:        1000,
+					Outcome:       250,
+					SavingsRate:   75,
+					TopCategories: []models.CategoryTotal{},
+					TopPayees:     []models.TopPayee{},
+					Months:        []models.MonthTrend{},
+				}, nil)
+			},
+		},
+		{
+			name:         "Default year",
+			user:         user,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":200,"body":{"year":0,"income":0,"outcome":0,"savings_rate":0,"top_categories":null,"top_payees":null,"biggest_purchase":null,"no_spend_streak":null,"months":null}}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetYearReview(gomock.Any(), uuidTest, time.Now().Year()).Return(&models.YearReview{}, nil)
+			},
+		},
+		{
+			name:         "Unauthorized Request",
+			user:         nil,
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"status":401,"message":"unauthorized"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Future year",
+			user:         user,
+			queryParam:   "year=3000",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Invalid year",
+			user:         user,
+			queryParam:   "year=last",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"invalid url parameter"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+			},
+		},
+		{
+			name:         "Internal server error",
+			user:         user,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"status":500,"message":"can't get year review"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().GetYearReview(gomock.Any(), uuidTest, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockUsecase(ctrl)
+			tt.mockUsecaseFn(mockService)
+
+			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
+
+			req := httptest.NewRequest("GET", "/api/report/year-review?"+tt.queryParam, nil)
+
+			if tt.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			mockHandler.GetYearReview(recorder, req)
+
+			actual := strings.TrimSpace(recorder.Body.String())
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedBody, actual)
+		})
+	}
+}
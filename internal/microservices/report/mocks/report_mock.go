@@ -0,0 +1,342 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report.go
+
+// Package mock_report is a generated GoMock package.
+package mock_report
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// BackfillBalanceSnapshots mocks base method.
+func (m *MockUsecase) BackfillBalanceSnapshots(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillBalanceSnapshots", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackfillBalanceSnapshots indicates an expected call of BackfillBalanceSnapshots.
+func (mr *MockUsecaseMockRecorder) BackfillBalanceSnapshots(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillBalanceSnapshots", reflect.TypeOf((*MockUsecase)(nil).BackfillBalanceSnapshots), ctx)
+}
+
+// BackfillDailyTotals mocks base method.
+func (m *MockUsecase) BackfillDailyTotals(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillDailyTotals", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackfillDailyTotals indicates an expected call of BackfillDailyTotals.
+func (mr *MockUsecaseMockRecorder) BackfillDailyTotals(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillDailyTotals", reflect.TypeOf((*MockUsecase)(nil).BackfillDailyTotals), ctx)
+}
+
+// GetBalanceHistory mocks base method.
+func (m *MockUsecase) GetBalanceHistory(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.BalanceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceHistory", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].(*models.BalanceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceHistory indicates an expected call of GetBalanceHistory.
+func (mr *MockUsecaseMockRecorder) GetBalanceHistory(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceHistory", reflect.TypeOf((*MockUsecase)(nil).GetBalanceHistory), ctx, userID, startDate, endDate)
+}
+
+// GetForecast mocks base method.
+func (m *MockUsecase) GetForecast(ctx context.Context, userID uuid.UUID, days int) (*models.Forecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForecast", ctx, userID, days)
+	ret0, _ := ret[0].(*models.Forecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForecast indicates an expected call of GetForecast.
+func (mr *MockUsecaseMockRecorder) GetForecast(ctx, userID, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecast", reflect.TypeOf((*MockUsecase)(nil).GetForecast), ctx, userID, days)
+}
+
+// GetMembersReport mocks base method.
+func (m *MockUsecase) GetMembersReport(ctx context.Context, userID, accountID uuid.UUID, startDate, endDate time.Time) (*models.MembersReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersReport", ctx, userID, accountID, startDate, endDate)
+	ret0, _ := ret[0].(*models.MembersReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersReport indicates an expected call of GetMembersReport.
+func (mr *MockUsecaseMockRecorder) GetMembersReport(ctx, userID, accountID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersReport", reflect.TypeOf((*MockUsecase)(nil).GetMembersReport), ctx, userID, accountID, startDate, endDate)
+}
+
+// GetSummary mocks base method.
+func (m *MockUsecase) GetSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummary", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].(*models.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockUsecaseMockRecorder) GetSummary(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockUsecase)(nil).GetSummary), ctx, userID, startDate, endDate)
+}
+
+// GetYearReview mocks base method.
+func (m *MockUsecase) GetYearReview(ctx context.Context, userID uuid.UUID, year int) (*models.YearReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearReview", ctx, userID, year)
+	ret0, _ := ret[0].(*models.YearReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearReview indicates an expected call of GetYearReview.
+func (mr *MockUsecaseMockRecorder) GetYearReview(ctx, userID, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearReview", reflect.TypeOf((*MockUsecase)(nil).GetYearReview), ctx, userID, year)
+}
+
+// TakeBalanceSnapshots mocks base method.
+func (m *MockUsecase) TakeBalanceSnapshots(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeBalanceSnapshots", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeBalanceSnapshots indicates an expected call of TakeBalanceSnapshots.
+func (mr *MockUsecaseMockRecorder) TakeBalanceSnapshots(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeBalanceSnapshots", reflect.TypeOf((*MockUsecase)(nil).TakeBalanceSnapshots), ctx)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BackfillDailyTotals mocks base method.
+func (m *MockRepository) BackfillDailyTotals(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillDailyTotals", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillDailyTotals indicates an expected call of BackfillDailyTotals.
+func (mr *MockRepositoryMockRecorder) BackfillDailyTotals(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillDailyTotals", reflect.TypeOf((*MockRepository)(nil).BackfillDailyTotals), ctx)
+}
+
+// BackfillSnapshots mocks base method.
+func (m *MockRepository) BackfillSnapshots(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillSnapshots", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillSnapshots indicates an expected call of BackfillSnapshots.
+func (mr *MockRepositoryMockRecorder) BackfillSnapshots(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillSnapshots", reflect.TypeOf((*MockRepository)(nil).BackfillSnapshots), ctx, before)
+}
+
+// GetAccounts mocks base method.
+func (m *MockRepository) GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.ForecastAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", ctx, userID)
+	ret0, _ := ret[0].([]models.ForecastAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockRepositoryMockRecorder) GetAccounts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockRepository)(nil).GetAccounts), ctx, userID)
+}
+
+// GetBalanceSnapshots mocks base method.
+func (m *MockRepository) GetBalanceSnapshots(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceSnapshots", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]models.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceSnapshots indicates an expected call of GetBalanceSnapshots.
+func (mr *MockRepositoryMockRecorder) GetBalanceSnapshots(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSnapshots", reflect.TypeOf((*MockRepository)(nil).GetBalanceSnapshots), ctx, userID, startDate, endDate)
+}
+
+// GetBiggestPurchase mocks base method.
+func (m *MockRepository) GetBiggestPurchase(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBiggestPurchase", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].(*models.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBiggestPurchase indicates an expected call of GetBiggestPurchase.
+func (mr *MockRepositoryMockRecorder) GetBiggestPurchase(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBiggestPurchase", reflect.TypeOf((*MockRepository)(nil).GetBiggestPurchase), ctx, userID, startDate, endDate)
+}
+
+// GetCategoryTotals mocks base method.
+func (m *MockRepository) GetCategoryTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.CategoryTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTotals", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]models.CategoryTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTotals indicates an expected call of GetCategoryTotals.
+func (mr *MockRepositoryMockRecorder) GetCategoryTotals(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTotals", reflect.TypeOf((*MockRepository)(nil).GetCategoryTotals), ctx, userID, startDate, endDate)
+}
+
+// GetDayTotals mocks base method.
+func (m *MockRepository) GetDayTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.DayTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDayTotals", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]models.DayTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDayTotals indicates an expected call of GetDayTotals.
+func (mr *MockRepositoryMockRecorder) GetDayTotals(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDayTotals", reflect.TypeOf((*MockRepository)(nil).GetDayTotals), ctx, userID, startDate, endDate)
+}
+
+// GetMemberTotals mocks base method.
+func (m *MockRepository) GetMemberTotals(ctx context.Context, accountID uuid.UUID, startDate, endDate time.Time) ([]models.MemberTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberTotals", ctx, accountID, startDate, endDate)
+	ret0, _ := ret[0].([]models.MemberTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberTotals indicates an expected call of GetMemberTotals.
+func (mr *MockRepositoryMockRecorder) GetMemberTotals(ctx, accountID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberTotals", reflect.TypeOf((*MockRepository)(nil).GetMemberTotals), ctx, accountID, startDate, endDate)
+}
+
+// GetPortfolioSnapshots mocks base method.
+func (m *MockRepository) GetPortfolioSnapshots(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.PortfolioSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolioSnapshots", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]models.PortfolioSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolioSnapshots indicates an expected call of GetPortfolioSnapshots.
+func (mr *MockRepositoryMockRecorder) GetPortfolioSnapshots(ctx, userID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolioSnapshots", reflect.TypeOf((*MockRepository)(nil).GetPortfolioSnapshots), ctx, userID, startDate, endDate)
+}
+
+// GetTransactionHistory mocks base method.
+func (m *MockRepository) GetTransactionHistory(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.ForecastTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionHistory", ctx, userID, since)
+	ret0, _ := ret[0].([]models.ForecastTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionHistory indicates an expected call of GetTransactionHistory.
+func (mr *MockRepositoryMockRecorder) GetTransactionHistory(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionHistory", reflect.TypeOf((*MockRepository)(nil).GetTransactionHistory), ctx, userID, since)
+}
+
+// SnapshotBalances mocks base method.
+func (m *MockRepository) SnapshotBalances(ctx context.Context, day time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotBalances", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnapshotBalances indicates an expected call of SnapshotBalances.
+func (mr *MockRepositoryMockRecorder) SnapshotBalances(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotBalances", reflect.TypeOf((*MockRepository)(nil).SnapshotBalances), ctx, day)
+}
@@ -0,0 +1,36 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase interface {
+	GetForecast(ctx context.Context, userID uuid.UUID, days int) (*models.Forecast, error)
+	GetBalanceHistory(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.BalanceHistory, error)
+	GetSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.Summary, error)
+	GetMembersReport(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, startDate, endDate time.Time) (*models.MembersReport, error)
+	GetYearReview(ctx context.Context, userID uuid.UUID, year int) (*models.YearReview, error)
+
+	TakeBalanceSnapshots(ctx context.Context) error
+	BackfillBalanceSnapshots(ctx context.Context) error
+	BackfillDailyTotals(ctx context.Context) error
+}
+
+type Repository interface {
+	GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.ForecastAccount, error)
+	GetTransactionHistory(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.ForecastTransaction, error)
+	GetBalanceSnapshots(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.BalanceSnapshot, error)
+	GetPortfolioSnapshots(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.PortfolioSnapshot, error)
+	GetDayTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.DayTotal, error)
+	GetCategoryTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.CategoryTotal, error)
+	GetMemberTotals(ctx context.Context, accountID uuid.UUID, startDate, endDate time.Time) ([]models.MemberTotal, error)
+	GetBiggestPurchase(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.Purchase, error)
+
+	SnapshotBalances(ctx context.Context, day time.Time) error
+	BackfillSnapshots(ctx context.Context, before time.Time) (int64, error)
+	BackfillDailyTotals(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,437 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ReportAccountsGet = `SELECT a.id, a.balance, a.accumulation, a.balance_enabled, a.mean_payment
+						 FROM Accounts a
+						 JOIN UserAccount ua ON a.id = ua.account_id
+						 WHERE ua.user_id = $1 AND a.archived_at IS NULL;`
+
+	// one row per transaction, a regular category wins over the others
+	ReportTransactionHistory = `SELECT DISTINCT ON (t.id)
+									t.account_income,
+									t.account_outcome,
+									t.income,
+									t.outcome,
+									t.date,
+									COALESCE(t.payer, ''),
+									COALESCE(c.id, '00000000-0000-0000-0000-000000000000'),
+									COALESCE(c.name, ''),
+									COALESCE(c.regular, false)
+								FROM Transaction t
+								LEFT JOIN TransactionCategory tc ON tc.transaction_id = t.id
+								LEFT JOIN category c ON c.id = tc.category_id
+								WHERE (t.account_income IN (SELECT account_id FROM UserAccount WHERE user_id = $1)
+									OR t.account_outcome IN (SELECT account_id FROM UserAccount WHERE user_id = $1))
+								AND t.date >= $2
+								ORDER BY t.id, c.regular DESC NULLS LAST;`
+
+	// snapshots inside the period and the last one before it to know the starting balance
+	ReportBalanceSnapshotsGet = `(SELECT DISTINCT ON (s.account_id) s.account_id, s.date, s.balance
+								  FROM AccountBalanceSnapshot s
+								  JOIN UserAccount ua ON ua.account_id = s.account_id
+								  WHERE ua.user_id = $1 AND s.date < $2
+								  ORDER BY s.account_id, s.date DESC)
+								 UNION ALL
+								 (SELECT s.account_id, s.date, s.balance
+								  FROM AccountBalanceSnapshot s
+								  JOIN UserAccount ua ON ua.account_id = s.account_id
+								  WHERE ua.user_id = $1 AND s.date BETWEEN $2 AND $3)
+								 ORDER BY date;`
+
+	// the same for the portfolio value
+	ReportPortfolioSnapshotsGet = `(SELECT date, value
+									FROM PortfolioSnapshot
+									WHERE user_id = $1 AND date < $2
+									ORDER BY date DESC
+									LIMIT 1)
+								   UNION ALL
+								   (SELECT date, value
+									FROM PortfolioSnapshot
+									WHERE user_id = $1 AND date BETWEEN $2 AND $3)
+								   ORDER BY date;`
+
+	// an archived account keeps the snapshots it has, its balance does not move any more
+	ReportSnapshotBalances = `INSERT INTO AccountBalanceSnapshot (account_id, date, balance)
+							  SELECT id, $1, COALESCE(balance, 0) FROM Accounts
+							  WHERE archived_at IS NULL
+							  ON CONFLICT (account_id, date) DO UPDATE SET balance = EXCLUDED.balance;`
+
+	// walks back from the current balance: the balance at the end of a day is
+	// the current one without the transactions made after that day
+	ReportBackfillSnapshots = `INSERT INTO AccountBalanceSnapshot (account_id, date, balance)
+							   SELECT a.id, d.day::date,
+									COALESCE(a.balance, 0) - COALESCE((
+										SELECT SUM(CASE WHEN t.account_income = a.id THEN t.income ELSE 0 END)
+											- SUM(CASE WHEN t.account_outcome = a.id THEN t.outcome ELSE 0 END)
+										FROM Transaction t
+										WHERE (t.account_income = a.id OR t.account_outcome = a.id)
+										AND t.date >= d.day + interval '1 day'), 0)
+							   FROM Accounts a
+							   CROSS JOIN LATERAL generate_series(
+									(SELECT date_trunc('day', MIN(t.date)) FROM Transaction t
+									 WHERE t.account_income = a.id OR t.account_outcome = a.id),
+									$1::date - interval '1 day',
+									interval '1 day') AS d(day)
+							   ON CONFLICT (account_id, date) DO NOTHING;`
+
+	// the zero category row of daily totals is the total of the day. Only the user's own
+	// transactions are counted, the other members of a shared account are in ReportMemberTotalsGet
+	ReportDayTotalsGet = `SELECT d.day, SUM(d.income), SUM(d.outcome)
+						  FROM DailyTotals d
+						  WHERE d.account_id IN (SELECT account_id FROM UserAccount WHERE user_id = $1)
+						  AND d.user_id = $1
+						  AND d.category_id = '00000000-0000-0000-0000-000000000000'
+						  AND d.day BETWEEN $2 AND $3
+						  GROUP BY d.day
+						  ORDER BY d.day;`
+
+	ReportCategoryTotalsGet = `SELECT c.id, c.name, SUM(d.income), SUM(d.outcome)
+							   FROM DailyTotals d
+							   JOIN category c ON c.id = d.category_id
+							   WHERE d.account_id IN (SELECT account_id FROM UserAccount WHERE user_id = $1)
+							   AND d.user_id = $1
+							   AND d.day BETWEEN $2 AND $3
+							   GROUP BY c.id, c.name
+							   ORDER BY SUM(d.outcome) DESC, c.name;`
+
+	// totals of every author on the account: the zero category row and the existing categories
+	ReportMemberTotalsGet = `SELECT d.user_id, d.category_id, COALESCE(c.name, ''), SUM(d.income), SUM(d.outcome)
+							 FROM DailyTotals d
+							 LEFT JOIN category c ON c.id = d.category_id
+							 WHERE d.account_id = $1
+							 AND d.day BETWEEN $2 AND $3
+							 AND (d.category_id = '00000000-0000-0000-0000-000000000000' OR c.id IS NOT NULL)
+							 GROUP BY d.user_id, d.category_id, c.name
+							 ORDER BY SUM(d.outcome) DESC, c.name;`
+
+	ReportBiggestPurchaseGet = `SELECT t.id, t.date, t.outcome, COALESCE(t.payer, ''), COALESCE(t.description, '')
+								FROM Transaction t
+								WHERE t.account_outcome IN (SELECT account_id FROM UserAccount WHERE user_id = $1)
+								AND t.user_id = $1
+								AND t.account_income = t.account_outcome
+								AND t.outcome > 0
+								AND t.date BETWEEN $2 AND $3
+								ORDER BY t.outcome DESC, t.date
+								LIMIT 1;`
+
+	// writers wait for the rebuild, the ones committed before it are in the transactions it reads
+	ReportDailyTotalsLock = "LOCK TABLE DailyTotals IN EXCLUSIVE MODE;"
+
+	ReportDailyTotalsClear = "DELETE FROM DailyTotals;"
+
+	// counts every day of every (user, account, category) from the transactions again
+	ReportBackfillDailyTotals = `INSERT INTO DailyTotals (user_id, account_id, category_id, day, income, outcome)
+								 SELECT t.user_id, t.account_income, c.category_id, t.date::date,
+									SUM(COALESCE(t.income, 0)), SUM(COALESCE(t.outcome, 0))
+								 FROM Transaction t
+								 CROSS JOIN LATERAL (
+									SELECT '00000000-0000-0000-0000-000000000000'::uuid
+									UNION
+									SELECT tc.category_id FROM TransactionCategory tc
+									WHERE tc.transaction_id = t.id AND tc.category_id IS NOT NULL
+								 ) AS c(category_id)
+								 WHERE t.account_income = t.account_outcome AND t.user_id IS NOT NULL
+								 GROUP BY t.user_id, t.account_income, c.category_id, t.date::date;`
+)
+
+type Repository struct {
+	db     postgresql.DbConn
+	logger logger.Logger
+}
+
+func NewRepository(db postgresql.DbConn, log logger.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *Repository) GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.ForecastAccount, error) {
+	var accounts []models.ForecastAccount
+
+	rows, err := r.db.Query(ctx, ReportAccountsGet, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var account models.ForecastAccount
+		if err := rows.Scan(
+			&account.ID,
+			&account.Balance,
+			&account.Accumulation,
+			&account.BalanceEnabled,
+			&account.MeanPayment,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("[repo] %w", &models.NoSuchAccounts{UserID: userID})
+	}
+
+	return accounts, nil
+}
+
+func (r *Repository) GetTransactionHistory(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.ForecastTransaction, error) {
+	var transactions []models.ForecastTransaction
+
+	rows, err := r.db.Query(ctx, ReportTransactionHistory, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction models.ForecastTransaction
+		if err := rows.Scan(
+			&transaction.AccountIncomeID,
+			&transaction.AccountOutcomeID,
+			&transaction.Income,
+			&transaction.Outcome,
+			&transaction.Date,
+			&transaction.Payer,
+			&transaction.CategoryID,
+			&transaction.CategoryName,
+			&transaction.Regular,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *Repository) GetBalanceSnapshots(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.BalanceSnapshot, error) {
+	var snapshots []models.BalanceSnapshot
+
+	rows, err := r.db.Query(ctx, ReportBalanceSnapshotsGet, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var snapshot models.BalanceSnapshot
+		if err := rows.Scan(
+			&snapshot.AccountID,
+			&snapshot.Date,
+			&snapshot.Balance,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func (r *Repository) GetPortfolioSnapshots(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.PortfolioSnapshot, error) {
+	var snapshots []models.PortfolioSnapshot
+
+	rows, err := r.db.Query(ctx, ReportPortfolioSnapshotsGet, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var snapshot models.PortfolioSnapshot
+		if err := rows.Scan(&snapshot.Date, &snapshot.Value); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func (r *Repository) SnapshotBalances(ctx context.Context, day time.Time) error {
+	if _, err := r.db.Exec(ctx, ReportSnapshotBalances, day); err != nil {
+		return fmt.Errorf("[repo] failed to snapshot balances: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) BackfillSnapshots(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, ReportBackfillSnapshots, before)
+	if err != nil {
+		return 0, fmt.Errorf("[repo] failed to backfill snapshots: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repository) GetDayTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.DayTotal, error) {
+	var totals []models.DayTotal
+
+	rows, err := r.db.Query(ctx, ReportDayTotalsGet, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var total models.DayTotal
+		if err := rows.Scan(
+			&total.Date,
+			&total.Income,
+			&total.Outcome,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *Repository) GetCategoryTotals(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.CategoryTotal, error) {
+	totals := []models.CategoryTotal{}
+
+	rows, err := r.db.Query(ctx, ReportCategoryTotalsGet, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var total models.CategoryTotal
+		if err := rows.Scan(
+			&total.ID,
+			&total.Name,
+			&total.Income,
+			&total.Outcome,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *Repository) GetMemberTotals(ctx context.Context, accountID uuid.UUID, startDate, endDate time.Time) ([]models.MemberTotal, error) {
+	var totals []models.MemberTotal
+
+	rows, err := r.db.Query(ctx, ReportMemberTotalsGet, accountID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var total models.MemberTotal
+		if err := rows.Scan(
+			&total.UserID,
+			&total.CategoryID,
+			&total.CategoryName,
+			&total.Income,
+			&total.Outcome,
+		); err != nil {
+			return nil, fmt.Errorf("[repo] %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return totals, nil
+}
+
+// GetBiggestPurchase returns nil if nothing was spent in the period
+func (r *Repository) GetBiggestPurchase(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.Purchase, error) {
+	var purchase models.Purchase
+
+	err := r.db.QueryRow(ctx, ReportBiggestPurchaseGet, userID, startDate, endDate).Scan(
+		&purchase.ID,
+		&purchase.Date,
+		&purchase.Amount,
+		&purchase.Payer,
+		&purchase.Description,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[repo] %w", err)
+	}
+
+	return &purchase, nil
+}
+
+// BackfillDailyTotals rebuilds the whole table from the transactions in one transaction, so the
+// days written before the table existed are filled next to the ones kept up by the writers
+func (r *Repository) BackfillDailyTotals(ctx context.Context) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("[repo] failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.logger.Errorf("[repo] rollback failed: %v", errRollback)
+			}
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, ReportDailyTotalsLock); err != nil {
+		return 0, fmt.Errorf("[repo] failed to lock daily totals: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, ReportDailyTotalsClear); err != nil {
+		return 0, fmt.Errorf("[repo] failed to clear daily totals: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, ReportBackfillDailyTotals)
+	if err != nil {
+		return 0, fmt.Errorf("[repo] failed to backfill daily totals: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
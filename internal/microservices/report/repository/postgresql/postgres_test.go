@@ -0,0 +1,666 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetAccounts(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows([]string{"id", "balance", "accumulation", "balance_enabled", "mean_payment"}).
+				AddRow(accountID, 100.0, false, true, "Карта"),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "NoSuchAccounts",
+			rows:        pgxmock.NewRows([]string{"id", "balance", "accumulation", "balance_enabled", "mean_payment"}),
+			expectedErr: fmt.Errorf("[repo] %w", &models.NoSuchAccounts{UserID: userID}),
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows([]string{"id", "balance", "accumulation", "balance_enabled", "mean_payment"}),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportAccountsGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			accounts, err := repo.GetAccounts(context.Background(), userID)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(accounts) != tc.expectedLen {
+				t.Errorf("Expected %d accounts, but got: %d", tc.expectedLen, len(accounts))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetTransactionHistory(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	since := time.Now()
+
+	columns := []string{"account_income", "account_outcome", "income", "outcome", "date", "payer", "category_id", "name", "regular"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(accountID, accountID, 0.0, 100.0, since, "Shop", uuid.New(), "Продукты", false),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Empty",
+			rows:        pgxmock.NewRows(columns),
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportTransactionHistory)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, since).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			transactions, err := repo.GetTransactionHistory(context.Background(), userID, since)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(transactions) != tc.expectedLen {
+				t.Errorf("Expected %d transactions, but got: %d", tc.expectedLen, len(transactions))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetBalanceSnapshots(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	startDate := time.Now().AddDate(0, 0, -7)
+	endDate := time.Now()
+
+	columns := []string{"account_id", "date", "balance"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(accountID, startDate, 100.0).
+				AddRow(accountID, endDate, 200.0),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportBalanceSnapshotsGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, startDate, endDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			snapshots, err := repo.GetBalanceSnapshots(context.Background(), userID, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(snapshots) != tc.expectedLen {
+				t.Errorf("Expected %d snapshots, but got: %d", tc.expectedLen, len(snapshots))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetPortfolioSnapshots(t *testing.T) {
+	userID := uuid.New()
+	startDate := time.Now().AddDate(0, 0, -7)
+	endDate := time.Now()
+
+	columns := []string{"date", "value"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(startDate, 100.0).
+				AddRow(endDate, 200.0),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportPortfolioSnapshotsGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, startDate, endDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			snapshots, err := repo.GetPortfolioSnapshots(context.Background(), userID, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(snapshots) != tc.expectedLen {
+				t.Errorf("Expected %d snapshots, but got: %d", tc.expectedLen, len(snapshots))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_SnapshotBalances(t *testing.T) {
+	day := time.Now()
+
+	testCases := []struct {
+		name        string
+		execError   error
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			execError:   nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to snapshot balances: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportSnapshotBalances)
+			mock.ExpectExec(escapedQuery).
+				WithArgs(day).
+				WillReturnResult(pgconn.CommandTag("INSERT 0 3")).
+				WillReturnError(tc.execError)
+
+			err := repo.SnapshotBalances(context.Background(), day)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_BackfillSnapshots(t *testing.T) {
+	day := time.Now()
+
+	testCases := []struct {
+		name          string
+		execError     error
+		expectedCount int64
+		expectedErr   error
+	}{
+		{
+			name:          "Success",
+			execError:     nil,
+			expectedCount: 12,
+			expectedErr:   nil,
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to backfill snapshots: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportBackfillSnapshots)
+			mock.ExpectExec(escapedQuery).
+				WithArgs(day).
+				WillReturnResult(pgconn.CommandTag("INSERT 0 12")).
+				WillReturnError(tc.execError)
+
+			count, err := repo.BackfillSnapshots(context.Background(), day)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if count != tc.expectedCount {
+				t.Errorf("Expected %d snapshots, but got: %d", tc.expectedCount, count)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetDayTotals(t *testing.T) {
+	userID := uuid.New()
+	startDate := time.Now().AddDate(0, 0, -7)
+	endDate := time.Now()
+
+	columns := []string{"day", "income", "outcome"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(startDate, 0.0, 100.0).
+				AddRow(endDate, 3000.0, 250.0),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportDayTotalsGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, startDate, endDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			totals, err := repo.GetDayTotals(context.Background(), userID, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(totals) != tc.expectedLen {
+				t.Errorf("Expected %d totals, but got: %d", tc.expectedLen, len(totals))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetCategoryTotals(t *testing.T) {
+	userID := uuid.New()
+	startDate := time.Now().AddDate(0, 0, -7)
+	endDate := time.Now()
+
+	columns := []string{"id", "name", "income", "outcome"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(uuid.New(), "Продукты", 0.0, 350.0),
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportCategoryTotalsGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, startDate, endDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			totals, err := repo.GetCategoryTotals(context.Background(), userID, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(totals) != tc.expectedLen {
+				t.Errorf("Expected %d totals, but got: %d", tc.expectedLen, len(totals))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetMemberTotals(t *testing.T) {
+	accountID := uuid.New()
+	userID := uuid.New()
+	startDate := time.Now().AddDate(0, 0, -7)
+	endDate := time.Now()
+
+	columns := []string{"user_id", "category_id", "name", "income", "outcome"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(userID, uuid.Nil, "", 0.0, 350.0).
+				AddRow(userID, uuid.New(), "Продукты", 0.0, 350.0),
+			expectedLen: 2,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportMemberTotalsGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(accountID, startDate, endDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			totals, err := repo.GetMemberTotals(context.Background(), accountID, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if len(totals) != tc.expectedLen {
+				t.Errorf("Expected %d totals, but got: %d", tc.expectedLen, len(totals))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_GetBiggestPurchase(t *testing.T) {
+	userID := uuid.New()
+	purchaseID := uuid.New()
+	startDate := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(1, 0, 0)
+
+	columns := []string{"id", "date", "outcome", "payer", "description"}
+
+	testCases := []struct {
+		name        string
+		rows        *pgxmock.Rows
+		rowsError   error
+		expected    *models.Purchase
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(purchaseID, startDate, 85000.0, "DNS", "Ноутбук"),
+			expected:    &models.Purchase{ID: purchaseID, Date: startDate, Amount: 85000, Payer: "DNS", Description: "Ноутбук"},
+			expectedErr: nil,
+		},
+		{
+			name:        "No purchases",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   pgx.ErrNoRows,
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "Query error",
+			rows:        pgxmock.NewRows(columns),
+			rowsError:   errors.New("err"),
+			expectedErr: fmt.Errorf("[repo] %w", errors.New("err")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(ReportBiggestPurchaseGet)
+			mock.ExpectQuery(escapedQuery).
+				WithArgs(userID, startDate, endDate).
+				WillReturnRows(tc.rows).
+				WillReturnError(tc.rowsError)
+
+			purchase, err := repo.GetBiggestPurchase(context.Background(), userID, startDate, endDate)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			assert.Equal(t, tc.expected, purchase)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_BackfillDailyTotals(t *testing.T) {
+	testCases := []struct {
+		name          string
+		execError     error
+		expectedCount int64
+		expectedErr   error
+	}{
+		{
+			name:          "Success",
+			execError:     nil,
+			expectedCount: 8,
+			expectedErr:   nil,
+		},
+		{
+			name:        "Error",
+			execError:   errors.New("Some error"),
+			expectedErr: fmt.Errorf("[repo] failed to backfill daily totals: %w", errors.New("Some error")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(ReportDailyTotalsLock)).
+				WillReturnResult(pgconn.CommandTag("LOCK TABLE"))
+			mock.ExpectExec(regexp.QuoteMeta(ReportDailyTotalsClear)).
+				WillReturnResult(pgconn.CommandTag("DELETE 5"))
+			escapedQuery := regexp.QuoteMeta(ReportBackfillDailyTotals)
+			backfill := mock.ExpectExec(escapedQuery)
+			if tc.execError != nil {
+				backfill.WillReturnError(tc.execError)
+				mock.ExpectRollback()
+			} else {
+				backfill.WillReturnResult(pgconn.CommandTag("INSERT 0 8"))
+				mock.ExpectCommit()
+			}
+
+			count, err := repo.BackfillDailyTotals(context.Background())
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+
+			if count != tc.expectedCount {
+				t.Errorf("Expected %d rows, but got: %d", tc.expectedCount, count)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	ForecastDefaultDays = 30
+	ForecastMaxDays     = 90
+	ForecastHistoryDays = 90
+
+	periodicMinOccurrences = 3
+	periodicMinInterval    = 6
+	periodicMaxInterval    = 40
+	periodicMaxDeviation   = 0.25
+
+	ForecastSourceCategory = "regular_category"
+	ForecastSourcePayer    = "periodic_payer"
+)
+
+// accountFlow is a single balance change of one account caused by a transaction
+type accountFlow struct {
+	accountID    uuid.UUID
+	amount       float64
+	date         time.Time
+	payer        string
+	categoryID   uuid.UUID
+	categoryName string
+	regular      bool
+	transfer     bool
+}
+
+// recurringFlow is a projected repeating balance change
+type recurringFlow struct {
+	accountID uuid.UUID
+	amount    float64
+	next      time.Time
+	interval  int // in days, zero means monthly
+	source    string
+	name      string
+}
+
+func (f *recurringFlow) advance() {
+	if f.interval == 0 {
+		f.next = dates.AddMonths(f.next, 1)
+		return
+	}
+	f.next = f.next.AddDate(0, 0, f.interval)
+}
+
+func buildForecast(now time.Time, days int, accounts []models.ForecastAccount, history []models.ForecastTransaction) *models.Forecast {
+	today := dates.TruncateDay(now)
+
+	owned := make(map[uuid.UUID]bool, len(accounts))
+	for _, account := range accounts {
+		owned[account.ID] = true
+	}
+
+	flows := splitFlows(history, owned)
+	recurring, rest := detectRecurring(today, flows)
+	daily := discretionarySpending(rest)
+
+	forecast := &models.Forecast{
+		Days:     days,
+		Accounts: make([]models.AccountForecast, 0, len(accounts)),
+		Events:   []models.ForecastEvent{},
+		Alerts:   []models.ForecastAlert{},
+	}
+
+	balances := make(map[uuid.UUID]float64, len(accounts))
+	for _, account := range accounts {
+		balances[account.ID] = account.Balance
+		forecast.Accounts = append(forecast.Accounts, models.AccountForecast{
+			AccountID:      account.ID,
+			MeanPayment:    account.MeanPayment,
+			BalanceEnabled: account.BalanceEnabled,
+			StartBalance:   money.Round2(account.Balance),
+			DailySpending:  money.Round2(daily[account.ID]),
+			Points:         make([]models.ForecastPoint, 0, days),
+		})
+
+		// an account that is already below zero crosses it today
+		if account.BalanceEnabled && money.Round2(account.Balance) < 0 {
+			started := &forecast.Accounts[len(forecast.Accounts)-1]
+			forecast.Alerts = append(forecast.Alerts, models.ForecastAlert{
+				AccountID:   account.ID,
+				MeanPayment: account.MeanPayment,
+				Date:        today,
+				Balance:     started.StartBalance,
+			})
+			negativeDate := today
+			started.NegativeFirstDate = &negativeDate
+		}
+	}
+
+	for i := 1; i <= days; i++ {
+		date := today.AddDate(0, 0, i)
+
+		for j := range recurring {
+			for !recurring[j].next.After(date) {
+				if recurring[j].next.Equal(date) {
+					balances[recurring[j].accountID] += recurring[j].amount
+					forecast.Events = append(forecast.Events, models.ForecastEvent{
+						Date:      date,
+						AccountID: recurring[j].accountID,
+						Amount:    money.Round2(recurring[j].amount),
+						Source:    recurring[j].source,
+						Name:      recurring[j].name,
+					})
+				}
+				recurring[j].advance()
+			}
+		}
+
+		for j := range forecast.Accounts {
+			account := &forecast.Accounts[j]
+			previous := account.StartBalance
+			if len(account.Points) > 0 {
+				previous = account.Points[len(account.Points)-1].Balance
+			}
+
+			balances[account.AccountID] -= daily[account.AccountID]
+			balance := money.Round2(balances[account.AccountID])
+			account.Points = append(account.Points, models.ForecastPoint{Date: date, Balance: balance})
+
+			if account.BalanceEnabled && balance < 0 && previous >= 0 {
+				forecast.Alerts = append(forecast.Alerts, models.ForecastAlert{
+					AccountID:   account.AccountID,
+					MeanPayment: account.MeanPayment,
+					Date:        date,
+					Balance:     balance,
+				})
+				if account.NegativeFirstDate == nil {
+					negativeDate := date
+					account.NegativeFirstDate = &negativeDate
+				}
+			}
+		}
+	}
+
+	for j := range forecast.Accounts {
+		forecast.Accounts[j].EndBalance = money.Round2(balances[forecast.Accounts[j].AccountID])
+	}
+
+	return forecast
+}
+
+// splitFlows turns transactions into per account balance changes the same way
+// the transaction repository updates balances
+func splitFlows(history []models.ForecastTransaction, owned map[uuid.UUID]bool) []accountFlow {
+	var flows []accountFlow
+
+	add := func(t models.ForecastTransaction, accountID uuid.UUID, amount float64, transfer bool) {
+		if amount == 0 || !owned[accountID] {
+			return
+		}
+		flows = append(flows, accountFlow{
+			accountID:    accountID,
+			amount:       amount,
+			date:         dates.TruncateDay(t.Date),
+			payer:        normalizePayer(t.Payer),
+			categoryID:   t.CategoryID,
+			categoryName: t.CategoryName,
+			regular:      t.Regular,
+			transfer:     transfer,
+		})
+	}
+
+	for _, t := range history {
+		if t.AccountIncomeID == t.AccountOutcomeID {
+			add(t, t.AccountIncomeID, t.Income-t.Outcome, false)
+			continue
+		}
+		add(t, t.AccountIncomeID, t.Income, true)
+		add(t, t.AccountOutcomeID, -t.Outcome, true)
+	}
+
+	sort.SliceStable(flows, func(i, j int) bool { return flows[i].date.Before(flows[j].date) })
+	return flows
+}
+
+// detectRecurring finds flows of regular categories and periodic payers,
+// the flows it could not explain are returned as the rest
+func detectRecurring(today time.Time, flows []accountFlow) ([]recurringFlow, []accountFlow) {
+	type groupKey struct {
+		accountID uuid.UUID
+		key       string
+		income    bool
+	}
+
+	regular := make(map[groupKey][]accountFlow)
+	payers := make(map[groupKey][]accountFlow)
+	var order []groupKey
+	seen := make(map[groupKey]bool)
+
+	for _, flow := range flows {
+		var key groupKey
+		var groups map[groupKey][]accountFlow
+		switch {
+		case flow.regular:
+			key = groupKey{accountID: flow.accountID, key: flow.categoryID.String(), income: flow.amount > 0}
+			groups = regular
+		case flow.payer != "":
+			key = groupKey{accountID: flow.accountID, key: flow.payer, income: flow.amount > 0}
+			groups = payers
+		default:
+			continue
+		}
+		groups[key] = append(groups[key], flow)
+		if !seen[key] {
+			seen[key] = true
+			order = append(order, key)
+		}
+	}
+
+	var recurring []recurringFlow
+	explained := make(map[groupKey]bool)
+
+	for _, key := range order {
+		if group, ok := regular[key]; ok {
+			first, last := group[0], group[len(group)-1]
+			months := math.Ceil(today.Sub(first.date).Hours() / 24 / 30)
+			if months < 1 {
+				months = 1
+			}
+
+			var sum float64
+			for _, flow := range group {
+				sum += flow.amount
+			}
+
+			next := last.date
+			for !next.After(today) {
+				next = dates.AddMonths(next, 1)
+			}
+
+			recurring = append(recurring, recurringFlow{
+				accountID: key.accountID,
+				amount:    sum / months,
+				next:      next,
+				source:    ForecastSourceCategory,
+				name:      last.categoryName,
+			})
+			explained[key] = true
+			continue
+		}
+
+		group := payers[key]
+		interval, ok := periodicInterval(group)
+		if !ok {
+			continue
+		}
+
+		var sum float64
+		for _, flow := range group {
+			sum += flow.amount
+		}
+
+		next := group[len(group)-1].date
+		for !next.After(today) {
+			next = next.AddDate(0, 0, interval)
+		}
+
+		recurring = append(recurring, recurringFlow{
+			accountID: key.accountID,
+			amount:    sum / float64(len(group)),
+			next:      next,
+			interval:  interval,
+			source:    ForecastSourcePayer,
+			name:      group[0].payer,
+		})
+		explained[key] = true
+	}
+
+	var rest []accountFlow
+	for _, flow := range flows {
+		key := groupKey{accountID: flow.accountID, income: flow.amount > 0}
+		switch {
+		case flow.regular:
+			key.key = flow.categoryID.String()
+		case flow.payer != "":
+			key.key = flow.payer
+		}
+		if key.key != "" && explained[key] {
+			continue
+		}
+		rest = append(rest, flow)
+	}
+
+	return recurring, rest
+}
+
+// periodicInterval reports the mean interval in days between payments if they are regular enough
+func periodicInterval(group []accountFlow) (int, bool) {
+	if len(group) < periodicMinOccurrences {
+		return 0, false
+	}
+
+	intervals := make([]float64, 0, len(group)-1)
+	var sum float64
+	for i := 1; i < len(group); i++ {
+		interval := group[i].date.Sub(group[i-1].date).Hours() / 24
+		intervals = append(intervals, interval)
+		sum += interval
+	}
+
+	mean := sum / float64(len(intervals))
+	if mean < periodicMinInterval || mean > periodicMaxInterval {
+		return 0, false
+	}
+
+	var variance float64
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	deviation := math.Sqrt(variance/float64(len(intervals))) / mean
+	if deviation > periodicMaxDeviation {
+		return 0, false
+	}
+
+	return int(math.Round(mean)), true
+}
+
+// discretionarySpending is the average daily outcome per account that is not a transfer
+func discretionarySpending(flows []accountFlow) map[uuid.UUID]float64 {
+	daily := make(map[uuid.UUID]float64)
+	for _, flow := range flows {
+		if flow.transfer || flow.amount >= 0 {
+			continue
+		}
+		daily[flow.accountID] += -flow.amount / ForecastHistoryDays
+	}
+	return daily
+}
+
+func normalizePayer(payer string) string {
+	return strings.Join(strings.Fields(strings.ToLower(payer)), " ")
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	BalanceHistoryDefaultDays = 30
+	BalanceHistoryMaxDays     = 366
+)
+
+// buildBalanceHistory turns sparse snapshots into daily series. A day without a snapshot keeps
+// the previous balance, days before the first snapshot take the earliest known one and today
+// is always the current balance. The portfolio is counted from its first snapshot on.
+func buildBalanceHistory(now, startDate, endDate time.Time, accounts []models.ForecastAccount, snapshots []models.BalanceSnapshot, portfolio []models.PortfolioSnapshot) *models.BalanceHistory {
+	today := dates.TruncateDay(now)
+	start, end := dates.TruncateDay(startDate), dates.TruncateDay(endDate)
+	if end.After(today) {
+		end = today
+	}
+	if start.After(end) {
+		start = end
+	}
+
+	byAccount := make(map[uuid.UUID][]models.BalanceSnapshot, len(accounts))
+	for _, snapshot := range snapshots {
+		snapshot.Date = dates.TruncateDay(snapshot.Date)
+		byAccount[snapshot.AccountID] = append(byAccount[snapshot.AccountID], snapshot)
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+
+	history := &models.BalanceHistory{
+		StartDate: start,
+		EndDate:   end,
+		Accounts:  make([]models.AccountBalanceHistory, 0, len(accounts)),
+		NetWorth:  make([]models.NetWorthPoint, days),
+	}
+
+	for i := range history.NetWorth {
+		history.NetWorth[i].Date = start.AddDate(0, 0, i)
+	}
+
+	for _, account := range accounts {
+		accountSnapshots := byAccount[account.ID]
+
+		balance := account.Balance
+		if len(accountSnapshots) > 0 {
+			balance = accountSnapshots[0].Balance
+		}
+
+		accountHistory := models.AccountBalanceHistory{
+			AccountID:      account.ID,
+			MeanPayment:    account.MeanPayment,
+			Accumulation:   account.Accumulation,
+			BalanceEnabled: account.BalanceEnabled,
+			Points:         make([]models.BalancePoint, 0, days),
+		}
+
+		next := 0
+		for i := 0; i < days; i++ {
+			date := start.AddDate(0, 0, i)
+			for next < len(accountSnapshots) && !accountSnapshots[next].Date.After(date) {
+				balance = accountSnapshots[next].Balance
+				next++
+			}
+			if date.Equal(today) {
+				balance = account.Balance
+			}
+
+			accountHistory.Points = append(accountHistory.Points, models.BalancePoint{Date: date, Balance: money.Round2(balance)})
+
+			if !account.BalanceEnabled {
+				continue
+			}
+			point := &history.NetWorth[i]
+			point.Total += balance
+			if account.Accumulation {
+				point.Savings += balance
+			} else {
+				point.Spending += balance
+			}
+		}
+
+		history.Accounts = append(history.Accounts, accountHistory)
+	}
+
+	var investments float64
+	next := 0
+	for i := range history.NetWorth {
+		point := &history.NetWorth[i]
+		for next < len(portfolio) && !dates.TruncateDay(portfolio[next].Date).After(point.Date) {
+			investments = portfolio[next].Value
+			next++
+		}
+		point.Investments = money.Round2(investments)
+		point.Total += investments
+	}
+
+	for i := range history.NetWorth {
+		point := &history.NetWorth[i]
+		point.Total, point.Savings, point.Spending = money.Round2(point.Total), money.Round2(point.Savings), money.Round2(point.Spending)
+	}
+
+	return history
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+// buildMembersReport groups the totals by author, current members without spending are listed too,
+// former members keep their transactions but only their id is known
+func buildMembersReport(accountID uuid.UUID, startDate, endDate time.Time, members []models.SharingUser, totals []models.MemberTotal) *models.MembersReport {
+	report := &models.MembersReport{
+		AccountID: accountID,
+		StartDate: dates.TruncateDay(startDate),
+		EndDate:   dates.TruncateDay(endDate),
+		Members:   make([]models.MemberBreakdown, 0, len(members)),
+	}
+
+	index := make(map[uuid.UUID]int, len(members))
+	for _, member := range members {
+		index[member.ID] = len(report.Members)
+		report.Members = append(report.Members, models.MemberBreakdown{
+			User:       member,
+			Categories: []models.CategoryTotal{},
+		})
+	}
+
+	for _, total := range totals {
+		i, ok := index[total.UserID]
+		if !ok {
+			i = len(report.Members)
+			index[total.UserID] = i
+			report.Members = append(report.Members, models.MemberBreakdown{
+				User:       models.SharingUser{ID: total.UserID},
+				Categories: []models.CategoryTotal{},
+			})
+		}
+
+		member := &report.Members[i]
+		if total.CategoryID == uuid.Nil {
+			member.Income = money.Round2(total.Income)
+			member.Outcome = money.Round2(total.Outcome)
+			report.Income += member.Income
+			report.Outcome += member.Outcome
+			continue
+		}
+
+		member.Categories = append(member.Categories, models.CategoryTotal{
+			ID:      total.CategoryID,
+			Name:    total.CategoryName,
+			Income:  money.Round2(total.Income),
+			Outcome: money.Round2(total.Outcome),
+		})
+	}
+
+	report.Income = money.Round2(report.Income)
+	report.Outcome = money.Round2(report.Outcome)
+
+	if report.Outcome > 0 {
+		for i := range report.Members {
+			report.Members[i].Share = money.Round2(report.Members[i].Outcome / report.Outcome * 100)
+		}
+	}
+
+	return report
+}
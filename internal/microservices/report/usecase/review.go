@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+const (
+	// YearReviewTopLimit is the number of categories and payees in the review
+	YearReviewTopLimit = 5
+	YearReviewMinYear  = 2000
+)
+
+// yearBounds returns the first day and the last instant of the year, the database keeps microseconds
+func yearBounds(year int) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, 0).Add(-time.Microsecond)
+}
+
+// buildYearReview counts the months and days of the year up to now, the future ones are skipped
+func buildYearReview(now time.Time, year int, dayTotals []models.DayTotal, categories []models.CategoryTotal, payees []models.TopPayee, purchase *models.Purchase) *models.YearReview {
+	start, end := yearBounds(year)
+	last := dates.TruncateDay(end)
+	if today := dates.TruncateDay(now); today.Before(last) {
+		last = today
+	}
+
+	review := &models.YearReview{
+		Year:            year,
+		TopCategories:   make([]models.CategoryTotal, 0, YearReviewTopLimit),
+		TopPayees:       payees,
+		BiggestPurchase: purchase,
+		Months:          []models.MonthTrend{},
+	}
+	if review.TopPayees == nil {
+		review.TopPayees = []models.TopPayee{}
+	}
+	if purchase != nil {
+		review.BiggestPurchase.Amount = money.Round2(purchase.Amount)
+	}
+
+	for _, category := range categories {
+		if len(review.TopCategories) == YearReviewTopLimit {
+			break
+		}
+		if category.Outcome > 0 {
+			review.TopCategories = append(review.TopCategories, category)
+		}
+	}
+
+	byDay := make(map[time.Time]models.DayTotal, len(dayTotals))
+	for _, total := range dayTotals {
+		byDay[dates.TruncateDay(total.Date)] = total
+	}
+
+	var streak, longest models.NoSpendStreak
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+		total := byDay[day]
+		review.Income += total.Income
+		review.Outcome += total.Outcome
+
+		if day.Day() == 1 {
+			review.Months = append(review.Months, models.MonthTrend{Month: day})
+		}
+		month := &review.Months[len(review.Months)-1]
+		month.Income += total.Income
+		month.Outcome += total.Outcome
+
+		if total.Outcome > 0 {
+			streak = models.NoSpendStreak{}
+			continue
+		}
+		if streak.Days == 0 {
+			streak.StartDate = day
+		}
+		streak.Days++
+		streak.EndDate = day
+		if streak.Days > longest.Days {
+			longest = streak
+		}
+	}
+
+	if longest.Days > 0 {
+		review.NoSpendStreak = &longest
+	}
+
+	for i := range review.Months {
+		month := &review.Months[i]
+		month.Income = money.Round2(month.Income)
+		month.Outcome = money.Round2(month.Outcome)
+		if i > 0 && review.Months[i-1].Outcome > 0 {
+			previous := review.Months[i-1].Outcome
+			month.OutcomeChange = money.Round2((month.Outcome - previous) / previous * 100)
+		}
+	}
+
+	review.Income = money.Round2(review.Income)
+	review.Outcome = money.Round2(review.Outcome)
+	if review.Income > 0 {
+		review.SavingsRate = money.Round2((review.Income - review.Outcome) / review.Income * 100)
+	}
+
+	return review
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/money"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+)
+
+// buildSummary fills every day of the period, a day without transactions has zero totals
+func buildSummary(startDate, endDate time.Time, dayTotals []models.DayTotal, categories []models.CategoryTotal) *models.Summary {
+	start, end := dates.TruncateDay(startDate), dates.TruncateDay(endDate)
+
+	byDay := make(map[time.Time]models.DayTotal, len(dayTotals))
+	for _, total := range dayTotals {
+		byDay[dates.TruncateDay(total.Date)] = total
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+
+	summary := &models.Summary{
+		StartDate:  start,
+		EndDate:    end,
+		Days:       make([]models.DayTotal, 0, days),
+		Categories: categories,
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		total := byDay[day]
+		total.Date = day
+		total.Income = money.Round2(total.Income)
+		total.Outcome = money.Round2(total.Outcome)
+
+		summary.Income += total.Income
+		summary.Outcome += total.Outcome
+		summary.Days = append(summary.Days, total)
+	}
+
+	summary.Income = money.Round2(summary.Income)
+	summary.Outcome = money.Round2(summary.Outcome)
+
+	return summary
+}
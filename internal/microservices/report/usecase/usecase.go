@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+)
+
+type Usecase struct {
+	reportRepo report.Repository
+	userRepo   user.Repository
+	payeeRepo  payee.Repository
+	logger     logger.Logger
+}
+
+func NewUsecase(rr report.Repository, log logger.Logger, ur user.Repository, pr payee.Repository) *Usecase {
+	return &Usecase{
+		reportRepo: rr,
+		userRepo:   ur,
+		payeeRepo:  pr,
+		logger:     log,
+	}
+}
+
+func (u *Usecase) GetForecast(ctx context.Context, userID uuid.UUID, days int) (*models.Forecast, error) {
+	accounts, err := u.reportRepo.GetAccounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get accounts from repository %w", err)
+	}
+
+	now := time.Now()
+	history, err := u.reportRepo.GetTransactionHistory(ctx, userID, dates.TruncateDay(now).AddDate(0, 0, -ForecastHistoryDays))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get transaction history from repository %w", err)
+	}
+
+	return buildForecast(now, days, accounts, history), nil
+}
+
+func (u *Usecase) GetBalanceHistory(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.BalanceHistory, error) {
+	accounts, err := u.reportRepo.GetAccounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get accounts from repository %w", err)
+	}
+
+	snapshots, err := u.reportRepo.GetBalanceSnapshots(ctx, userID, dates.TruncateDay(startDate), dates.TruncateDay(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get balance snapshots from repository %w", err)
+	}
+
+	portfolio, err := u.reportRepo.GetPortfolioSnapshots(ctx, userID, dates.TruncateDay(startDate), dates.TruncateDay(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get portfolio snapshots from repository %w", err)
+	}
+
+	return buildBalanceHistory(time.Now(), startDate, endDate, accounts, snapshots, portfolio), nil
+}
+
+func (u *Usecase) GetSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (*models.Summary, error) {
+	dayTotals, err := u.reportRepo.GetDayTotals(ctx, userID, dates.TruncateDay(startDate), dates.TruncateDay(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get day totals from repository %w", err)
+	}
+
+	categories, err := u.reportRepo.GetCategoryTotals(ctx, userID, dates.TruncateDay(startDate), dates.TruncateDay(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get category totals from repository %w", err)
+	}
+
+	return buildSummary(startDate, endDate, dayTotals, categories), nil
+}
+
+// GetMembersReport is available to every member of the account
+func (u *Usecase) GetMembersReport(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, startDate, endDate time.Time) (*models.MembersReport, error) {
+	members, err := u.userRepo.GetSharingUsers(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get account members from repository %w", err)
+	}
+
+	if !isMember(members, userID) {
+		return nil, fmt.Errorf("[usecase] user is not a member of the account %w", &models.ForbiddenUserError{})
+	}
+
+	totals, err := u.reportRepo.GetMemberTotals(ctx, accountID, dates.TruncateDay(startDate), dates.TruncateDay(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get member totals from repository %w", err)
+	}
+
+	return buildMembersReport(accountID, startDate, endDate, members, totals), nil
+}
+
+func (u *Usecase) GetYearReview(ctx context.Context, userID uuid.UUID, year int) (*models.YearReview, error) {
+	start, end := yearBounds(year)
+
+	dayTotals, err := u.reportRepo.GetDayTotals(ctx, userID, start, dates.TruncateDay(end))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get day totals from repository %w", err)
+	}
+
+	categories, err := u.reportRepo.GetCategoryTotals(ctx, userID, start, dates.TruncateDay(end))
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get category totals from repository %w", err)
+	}
+
+	payees, err := u.payeeRepo.GetTopPayees(ctx, userID, &models.TopPayeesQuery{
+		SortBy:    models.PayeeSortSpend,
+		Limit:     YearReviewTopLimit,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get top payees from repository %w", err)
+	}
+
+	purchase, err := u.reportRepo.GetBiggestPurchase(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("[usecase] can't get biggest purchase from repository %w", err)
+	}
+
+	return buildYearReview(time.Now(), year, dayTotals, categories, payees, purchase), nil
+}
+
+func (u *Usecase) TakeBalanceSnapshots(ctx context.Context) error {
+	if err := u.reportRepo.SnapshotBalances(ctx, dates.TruncateDay(time.Now())); err != nil {
+		return fmt.Errorf("[usecase] can't snapshot balances %w", err)
+	}
+	return nil
+}
+
+func (u *Usecase) BackfillBalanceSnapshots(ctx context.Context) error {
+	count, err := u.reportRepo.BackfillSnapshots(ctx, dates.TruncateDay(time.Now()))
+	if err != nil {
+		return fmt.Errorf("[usecase] can't backfill balance snapshots %w", err)
+	}
+	u.logger.Infof("backfilled %d balance snapshots", count)
+	return nil
+}
+
+func (u *Usecase) BackfillDailyTotals(ctx context.Context) error {
+	count, err := u.reportRepo.BackfillDailyTotals(ctx)
+	if err != nil {
+		return fmt.Errorf("[usecase] can't backfill daily totals %w", err)
+	}
+	u.logger.Infof("backfilled %d daily totals", count)
+	return nil
+}
+
+func isMember(members []models.SharingUser, userID uuid.UUID) bool {
+	for _, member := range members {
+		if member.ID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,642 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/dates"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mockPayee "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/mocks"
+	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/report/mocks"
+	mockUser "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/mocks"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_GetForecast(t *testing.T) {
+	accountID := uuid.New()
+	accounts := []models.ForecastAccount{{ID: accountID, Balance: 100, BalanceEnabled: true, MeanPayment: "Карта"}}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_GetForecast",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(accounts, nil)
+				mockRepository.EXPECT().GetTransactionHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:        "Accounts Error in TestUsecase_GetForecast",
+			expectedErr: fmt.Errorf("[usecase] can't get accounts from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "History Error in TestUsecase_GetForecast",
+			expectedErr: fmt.Errorf("[usecase] can't get transaction history from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(accounts, nil)
+				mockRepository.EXPECT().GetTransactionHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, nil)
+
+			forecast, err := mockUsecase.GetForecast(context.Background(), uuid.New(), 30)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Len(t, forecast.Accounts, 1)
+				assert.Len(t, forecast.Accounts[0].Points, 30)
+			}
+		})
+	}
+}
+
+func TestBuildForecast(t *testing.T) {
+	now := time.Date(2023, time.December, 10, 15, 0, 0, 0, time.UTC)
+	card := uuid.New()
+	savings := uuid.New()
+	salary := uuid.New()
+
+	accounts := []models.ForecastAccount{
+		{ID: card, Balance: 1000, BalanceEnabled: true, MeanPayment: "Карта"},
+		{ID: savings, Balance: 500, Accumulation: true, MeanPayment: "Копилка"},
+	}
+
+	history := []models.ForecastTransaction{
+		// salary in a regular category
+		{AccountIncomeID: card, AccountOutcomeID: card, Income: 3000, Date: time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC), CategoryID: salary, CategoryName: "Зарплата", Regular: true},
+		{AccountIncomeID: card, AccountOutcomeID: card, Income: 3000, Date: time.Date(2023, time.November, 20, 0, 0, 0, 0, time.UTC), CategoryID: salary, CategoryName: "Зарплата", Regular: true},
+		// weekly payer
+		{AccountIncomeID: card, AccountOutcomeID: card, Outcome: 200, Date: time.Date(2023, time.November, 19, 0, 0, 0, 0, time.UTC), Payer: "Gym"},
+		{AccountIncomeID: card, AccountOutcomeID: card, Outcome: 200, Date: time.Date(2023, time.November, 26, 0, 0, 0, 0, time.UTC), Payer: "GYM"},
+		{AccountIncomeID: card, AccountOutcomeID: card, Outcome: 200, Date: time.Date(2023, time.December, 3, 0, 0, 0, 0, time.UTC), Payer: "gym"},
+		// discretionary spending
+		{AccountIncomeID: card, AccountOutcomeID: card, Outcome: 900, Date: time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC), Payer: "Shop"},
+		// transfer to savings is not spending
+		{AccountIncomeID: savings, AccountOutcomeID: card, Income: 100, Outcome: 100, Date: time.Date(2023, time.November, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	forecast := buildForecast(now, 30, accounts, history)
+
+	assert.Equal(t, 30, forecast.Days)
+	assert.Len(t, forecast.Accounts, 2)
+
+	cardForecast := forecast.Accounts[0]
+	assert.Equal(t, 10.0, cardForecast.DailySpending)
+	assert.Len(t, cardForecast.Points, 30)
+	assert.Equal(t, time.Date(2023, time.December, 11, 0, 0, 0, 0, time.UTC), cardForecast.Points[0].Date)
+
+	var salaryEvents, gymEvents int
+	for _, event := range forecast.Events {
+		switch event.Source {
+		case ForecastSourceCategory:
+			salaryEvents++
+			assert.Equal(t, time.Date(2023, time.December, 20, 0, 0, 0, 0, time.UTC), event.Date)
+			assert.Equal(t, 3000.0, event.Amount)
+		case ForecastSourcePayer:
+			gymEvents++
+			assert.Equal(t, -200.0, event.Amount)
+			assert.Equal(t, "gym", event.Name)
+		}
+	}
+	assert.Equal(t, 1, salaryEvents)
+	assert.Equal(t, 4, gymEvents)
+
+	// 1000 + 3000 salary - 4*200 gym - 30*10 spending
+	assert.Equal(t, 2900.0, cardForecast.EndBalance)
+	assert.Equal(t, 500.0, forecast.Accounts[1].EndBalance)
+	assert.Empty(t, forecast.Alerts)
+}
+
+func TestBuildForecast_NegativeAlert(t *testing.T) {
+	now := time.Date(2023, time.December, 10, 0, 0, 0, 0, time.UTC)
+	card := uuid.New()
+	cash := uuid.New()
+
+	accounts := []models.ForecastAccount{
+		{ID: card, Balance: 50, BalanceEnabled: true, MeanPayment: "Карта"},
+		{ID: cash, Balance: 50, BalanceEnabled: false, MeanPayment: "Наличка"},
+	}
+
+	history := []models.ForecastTransaction{
+		{AccountIncomeID: card, AccountOutcomeID: card, Outcome: 900, Date: time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{AccountIncomeID: cash, AccountOutcomeID: cash, Outcome: 900, Date: time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	forecast := buildForecast(now, 10, accounts, history)
+
+	assert.Len(t, forecast.Alerts, 1)
+	assert.Equal(t, card, forecast.Alerts[0].AccountID)
+	assert.Equal(t, time.Date(2023, time.December, 16, 0, 0, 0, 0, time.UTC), forecast.Alerts[0].Date)
+	assert.NotNil(t, forecast.Accounts[0].NegativeFirstDate)
+	assert.Nil(t, forecast.Accounts[1].NegativeFirstDate)
+}
+
+func TestBuildForecast_NegativeStart(t *testing.T) {
+	now := time.Date(2023, time.December, 10, 15, 0, 0, 0, time.UTC)
+	card := uuid.New()
+
+	// an income keeps the balance below zero all along, the crossing is today
+	accounts := []models.ForecastAccount{{ID: card, Balance: -200, BalanceEnabled: true, MeanPayment: "Карта"}}
+	history := []models.ForecastTransaction{
+		{AccountIncomeID: card, AccountOutcomeID: card, Income: 10, Payer: "Кешбэк", Date: time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	forecast := buildForecast(now, 10, accounts, history)
+
+	today := time.Date(2023, time.December, 10, 0, 0, 0, 0, time.UTC)
+	assert.Len(t, forecast.Alerts, 1)
+	assert.Equal(t, models.ForecastAlert{AccountID: card, MeanPayment: "Карта", Date: today, Balance: -200}, forecast.Alerts[0])
+	assert.Equal(t, &today, forecast.Accounts[0].NegativeFirstDate)
+}
+
+func TestPeriodicInterval(t *testing.T) {
+	day := func(d int) accountFlow {
+		return accountFlow{date: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, d)}
+	}
+
+	interval, ok := periodicInterval([]accountFlow{day(0), day(30), day(61)})
+	assert.True(t, ok)
+	assert.Equal(t, 31, interval)
+
+	_, ok = periodicInterval([]accountFlow{day(0), day(30)})
+	assert.False(t, ok)
+
+	_, ok = periodicInterval([]accountFlow{day(0), day(2), day(40)})
+	assert.False(t, ok)
+}
+
+func TestUsecase_GetBalanceHistory(t *testing.T) {
+	accounts := []models.ForecastAccount{{ID: uuid.New(), Balance: 100, BalanceEnabled: true, MeanPayment: "Карта"}}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_GetBalanceHistory",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(accounts, nil)
+				mockRepository.EXPECT().GetBalanceSnapshots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetPortfolioSnapshots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:        "Accounts Error in TestUsecase_GetBalanceHistory",
+			expectedErr: fmt.Errorf("[usecase] can't get accounts from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Snapshots Error in TestUsecase_GetBalanceHistory",
+			expectedErr: fmt.Errorf("[usecase] can't get balance snapshots from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(accounts, nil)
+				mockRepository.EXPECT().GetBalanceSnapshots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Portfolio Error in TestUsecase_GetBalanceHistory",
+			expectedErr: fmt.Errorf("[usecase] can't get portfolio snapshots from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(accounts, nil)
+				mockRepository.EXPECT().GetBalanceSnapshots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetPortfolioSnapshots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, nil)
+
+			now := time.Now()
+			history, err := mockUsecase.GetBalanceHistory(context.Background(), uuid.New(), now.AddDate(0, 0, -6), now)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Len(t, history.NetWorth, 7)
+				assert.Equal(t, 100.0, history.NetWorth[6].Total)
+			}
+		})
+	}
+}
+
+func TestUsecase_TakeBalanceSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, nil)
+
+	mockRepo.EXPECT().SnapshotBalances(gomock.Any(), dates.TruncateDay(time.Now())).Return(nil)
+	assert.NoError(t, mockUsecase.TakeBalanceSnapshots(context.Background()))
+
+	mockRepo.EXPECT().SnapshotBalances(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+	assert.EqualError(t, mockUsecase.TakeBalanceSnapshots(context.Background()), "[usecase] can't snapshot balances some error")
+}
+
+func TestUsecase_BackfillBalanceSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, nil)
+
+	mockRepo.EXPECT().BackfillSnapshots(gomock.Any(), dates.TruncateDay(time.Now())).Return(int64(10), nil)
+	assert.NoError(t, mockUsecase.BackfillBalanceSnapshots(context.Background()))
+
+	mockRepo.EXPECT().BackfillSnapshots(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("some error"))
+	assert.EqualError(t, mockUsecase.BackfillBalanceSnapshots(context.Background()), "[usecase] can't backfill balance snapshots some error")
+}
+
+func TestBuildBalanceHistory(t *testing.T) {
+	now := time.Date(2023, time.December, 10, 12, 0, 0, 0, time.UTC)
+	card := uuid.New()
+	savings := uuid.New()
+	hidden := uuid.New()
+
+	accounts := []models.ForecastAccount{
+		{ID: card, Balance: 300, BalanceEnabled: true, MeanPayment: "Карта"},
+		{ID: savings, Balance: 1000, Accumulation: true, BalanceEnabled: true, MeanPayment: "Копилка"},
+		{ID: hidden, Balance: 50, BalanceEnabled: false, MeanPayment: "Долг"},
+	}
+
+	day := func(d int) time.Time { return time.Date(2023, time.December, d, 0, 0, 0, 0, time.UTC) }
+
+	snapshots := []models.BalanceSnapshot{
+		// last one before the period
+		{AccountID: card, Date: day(5), Balance: 100},
+		{AccountID: card, Date: day(8), Balance: 200},
+		{AccountID: savings, Date: day(9), Balance: 900},
+	}
+
+	portfolio := []models.PortfolioSnapshot{
+		{Date: day(8), Value: 500},
+		{Date: day(10), Value: 450.5},
+	}
+
+	history := buildBalanceHistory(now, day(7), day(20), accounts, snapshots, portfolio)
+
+	// end is cut to today
+	assert.Equal(t, day(7), history.StartDate)
+	assert.Equal(t, day(10), history.EndDate)
+	assert.Len(t, history.NetWorth, 4)
+
+	cardPoints := history.Accounts[0].Points
+	assert.Equal(t, []float64{100, 200, 200, 300}, []float64{cardPoints[0].Balance, cardPoints[1].Balance, cardPoints[2].Balance, cardPoints[3].Balance})
+
+	// before the first snapshot the earliest known balance is used
+	savingsPoints := history.Accounts[1].Points
+	assert.Equal(t, []float64{900, 900, 900, 1000}, []float64{savingsPoints[0].Balance, savingsPoints[1].Balance, savingsPoints[2].Balance, savingsPoints[3].Balance})
+
+	// accounts without snapshots only know the current balance
+	assert.Equal(t, 50.0, history.Accounts[2].Points[0].Balance)
+
+	// the portfolio is counted from its first snapshot
+	assert.Equal(t, models.NetWorthPoint{Date: day(7), Total: 1000, Savings: 900, Spending: 100}, history.NetWorth[0])
+	assert.Equal(t, models.NetWorthPoint{Date: day(9), Total: 1600, Savings: 900, Spending: 200, Investments: 500}, history.NetWorth[2])
+	assert.Equal(t, models.NetWorthPoint{Date: day(10), Total: 1750.5, Savings: 1000, Spending: 300, Investments: 450.5}, history.NetWorth[3])
+}
+
+func TestUsecase_GetSummary(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_GetSummary",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetCategoryTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.CategoryTotal{}, nil)
+			},
+		},
+		{
+			name:        "Days Error in TestUsecase_GetSummary",
+			expectedErr: fmt.Errorf("[usecase] can't get day totals from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Categories Error in TestUsecase_GetSummary",
+			expectedErr: fmt.Errorf("[usecase] can't get category totals from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetCategoryTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, nil)
+
+			now := time.Now()
+			summary, err := mockUsecase.GetSummary(context.Background(), uuid.New(), now.AddDate(0, 0, -6), now)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Len(t, summary.Days, 7)
+			}
+		})
+	}
+}
+
+func TestUsecase_BackfillDailyTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, nil)
+
+	mockRepo.EXPECT().BackfillDailyTotals(gomock.Any()).Return(int64(10), nil)
+	assert.NoError(t, mockUsecase.BackfillDailyTotals(context.Background()))
+
+	mockRepo.EXPECT().BackfillDailyTotals(gomock.Any()).Return(int64(0), errors.New("some error"))
+	assert.EqualError(t, mockUsecase.BackfillDailyTotals(context.Background()), "[usecase] can't backfill daily totals some error")
+}
+
+func TestBuildSummary(t *testing.T) {
+	start := time.Date(2023, time.December, 1, 15, 0, 0, 0, time.UTC)
+	end := time.Date(2023, time.December, 4, 9, 0, 0, 0, time.UTC)
+	food := models.CategoryTotal{ID: uuid.New(), Name: "Продукты", Outcome: 300.5}
+
+	summary := buildSummary(start, end, []models.DayTotal{
+		{Date: time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC), Outcome: 100.25},
+		{Date: time.Date(2023, time.December, 3, 0, 0, 0, 0, time.UTC), Income: 5000, Outcome: 200.25},
+	}, []models.CategoryTotal{food})
+
+	assert.Equal(t, time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC), summary.StartDate)
+	assert.Len(t, summary.Days, 4)
+	assert.Equal(t, 0.0, summary.Days[1].Outcome)
+	assert.Equal(t, time.Date(2023, time.December, 2, 0, 0, 0, 0, time.UTC), summary.Days[1].Date)
+	assert.Equal(t, 5000.0, summary.Income)
+	assert.Equal(t, 300.5, summary.Outcome)
+	assert.Equal(t, []models.CategoryTotal{food}, summary.Categories)
+}
+
+func TestUsecase_GetMembersReport(t *testing.T) {
+	userID := uuid.New()
+	accountID := uuid.New()
+	members := []models.SharingUser{{ID: userID, Login: "owner"}}
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mockUser.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_GetMembersReport",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockUserRepository *mockUser.MockRepository) {
+				mockUserRepository.EXPECT().GetSharingUsers(gomock.Any(), accountID).Return(members, nil)
+				mockRepository.EXPECT().GetMemberTotals(gomock.Any(), accountID, gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:        "Members Error in TestUsecase_GetMembersReport",
+			expectedErr: fmt.Errorf("[usecase] can't get account members from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockUserRepository *mockUser.MockRepository) {
+				mockUserRepository.EXPECT().GetSharingUsers(gomock.Any(), accountID).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Forbidden user in TestUsecase_GetMembersReport",
+			expectedErr: fmt.Errorf("[usecase] user is not a member of the account %w", &models.ForbiddenUserError{}),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockUserRepository *mockUser.MockRepository) {
+				mockUserRepository.EXPECT().GetSharingUsers(gomock.Any(), accountID).Return([]models.SharingUser{{ID: uuid.New()}}, nil)
+			},
+		},
+		{
+			name:        "Totals Error in TestUsecase_GetMembersReport",
+			expectedErr: fmt.Errorf("[usecase] can't get member totals from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockUserRepository *mockUser.MockRepository) {
+				mockUserRepository.EXPECT().GetSharingUsers(gomock.Any(), accountID).Return(members, nil)
+				mockRepository.EXPECT().GetMemberTotals(gomock.Any(), accountID, gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockUserRepo := mockUser.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockUserRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockUserRepo, nil)
+
+			now := time.Now()
+			report, err := mockUsecase.GetMembersReport(context.Background(), userID, accountID, now.AddDate(0, 0, -6), now)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Len(t, report.Members, 1)
+			}
+		})
+	}
+}
+
+func TestBuildMembersReport(t *testing.T) {
+	accountID := uuid.New()
+	owner := models.SharingUser{ID: uuid.New(), Login: "owner"}
+	guest := models.SharingUser{ID: uuid.New(), Login: "guest"}
+	former := uuid.New()
+	food := uuid.New()
+
+	report := buildMembersReport(accountID, time.Now().AddDate(0, 0, -6), time.Now(), []models.SharingUser{owner, guest}, []models.MemberTotal{
+		{UserID: owner.ID, CategoryID: uuid.Nil, Income: 1000, Outcome: 300},
+		{UserID: owner.ID, CategoryID: food, CategoryName: "Продукты", Outcome: 300},
+		{UserID: former, CategoryID: uuid.Nil, Outcome: 100},
+	})
+
+	assert.Equal(t, accountID, report.AccountID)
+	assert.Equal(t, 400.0, report.Outcome)
+	assert.Equal(t, 1000.0, report.Income)
+	assert.Len(t, report.Members, 3)
+
+	assert.Equal(t, owner, report.Members[0].User)
+	assert.Equal(t, 75.0, report.Members[0].Share)
+	assert.Equal(t, []models.CategoryTotal{{ID: food, Name: "Продукты", Outcome: 300}}, report.Members[0].Categories)
+
+	assert.Equal(t, guest, report.Members[1].User)
+	assert.Equal(t, 0.0, report.Members[1].Share)
+	assert.Empty(t, report.Members[1].Categories)
+
+	assert.Equal(t, former, report.Members[2].User.ID)
+	assert.Equal(t, 25.0, report.Members[2].Share)
+}
+
+func TestUsecase_GetYearReview(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockRepoFn  func(*mock.MockRepository, *mockPayee.MockRepository)
+	}{
+		{
+			name:        "Successful TestUsecase_GetYearReview",
+			expectedErr: nil,
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockPayeeRepository *mockPayee.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetCategoryTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockPayeeRepository.EXPECT().GetTopPayees(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetBiggestPurchase(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:        "Days Error in TestUsecase_GetYearReview",
+			expectedErr: fmt.Errorf("[usecase] can't get day totals from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockPayeeRepository *mockPayee.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Categories Error in TestUsecase_GetYearReview",
+			expectedErr: fmt.Errorf("[usecase] can't get category totals from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockPayeeRepository *mockPayee.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetCategoryTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Payees Error in TestUsecase_GetYearReview",
+			expectedErr: fmt.Errorf("[usecase] can't get top payees from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockPayeeRepository *mockPayee.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetCategoryTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockPayeeRepository.EXPECT().GetTopPayees(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Purchase Error in TestUsecase_GetYearReview",
+			expectedErr: fmt.Errorf("[usecase] can't get biggest purchase from repository some error"),
+			mockRepoFn: func(mockRepository *mock.MockRepository, mockPayeeRepository *mockPayee.MockRepository) {
+				mockRepository.EXPECT().GetDayTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetCategoryTotals(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockPayeeRepository.EXPECT().GetTopPayees(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepository.EXPECT().GetBiggestPurchase(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockPayeeRepo := mockPayee.NewMockRepository(ctrl)
+			tc.mockRepoFn(mockRepo, mockPayeeRepo)
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), nil, mockPayeeRepo)
+
+			review, err := mockUsecase.GetYearReview(context.Background(), uuid.New(), 2023)
+
+			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil {
+				assert.Len(t, review.Months, 12)
+			}
+		})
+	}
+}
+
+func TestBuildYearReview(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2023, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	categories := []models.CategoryTotal{
+		{ID: uuid.New(), Name: "Продукты", Outcome: 3000},
+		{ID: uuid.New(), Name: "Зарплата", Income: 10000},
+	}
+	purchase := &models.Purchase{ID: uuid.New(), Date: day(time.February, 1), Amount: 2000}
+
+	dayTotals := []models.DayTotal{{Date: day(time.February, 1), Income: 10000, Outcome: 2000}}
+	// every day of January has spending except the 10th-14th
+	for d := 1; d <= 31; d++ {
+		if d < 10 || d > 14 {
+			dayTotals = append(dayTotals, models.DayTotal{Date: day(time.January, d), Outcome: 10})
+		}
+	}
+
+	t.Run("Past year", func(t *testing.T) {
+		review := buildYearReview(day(time.December, 31).AddDate(1, 0, 0), 2023, dayTotals, categories, nil, purchase)
+
+		assert.Equal(t, 2023, review.Year)
+		assert.Equal(t, 10000.0, review.Income)
+		assert.Equal(t, 2260.0, review.Outcome)
+		assert.Equal(t, 77.4, review.SavingsRate)
+		assert.Equal(t, categories[:1], review.TopCategories)
+		assert.Equal(t, []models.TopPayee{}, review.TopPayees)
+		assert.Equal(t, purchase, review.BiggestPurchase)
+
+		assert.Len(t, review.Months, 12)
+		assert.Equal(t, models.MonthTrend{Month: day(time.January, 1), Outcome: 260}, review.Months[0])
+		assert.Equal(t, 669.23, review.Months[1].OutcomeChange)
+		assert.Equal(t, -100.0, review.Months[2].OutcomeChange)
+		assert.Equal(t, 0.0, review.Months[3].OutcomeChange)
+
+		// February 2nd up to the end of the year
+		assert.Equal(t, &models.NoSpendStreak{Days: 333, StartDate: day(time.February, 2), EndDate: day(time.December, 31)}, review.NoSpendStreak)
+	})
+
+	t.Run("Current year", func(t *testing.T) {
+		review := buildYearReview(day(time.January, 31).Add(15*time.Hour), 2023, dayTotals[1:], categories, nil, nil)
+
+		assert.Len(t, review.Months, 1)
+		assert.Equal(t, 0.0, review.SavingsRate)
+		assert.Nil(t, review.BiggestPurchase)
+		assert.Equal(t, &models.NoSpendStreak{Days: 5, StartDate: day(time.January, 10), EndDate: day(time.January, 14)}, review.NoSpendStreak)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
@@ -159,6 +160,24 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 
 	transactionID, err := h.transactionService.CreateTransaction(r.Context(), transactionInput.ToTransaction(user))
 	if err != nil {
+		var errForbiddenUser *models.ForbiddenUserError
+		if errors.As(err, &errForbiddenUser) {
+			commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
+			return
+		}
+
+		var errOverdraft *models.OverdraftError
+		if errors.As(err, &errOverdraft) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errOverdraft.Error(), h.logger)
+			return
+		}
+
+		var errAccountOperation *models.AccountOperationError
+		if errors.As(err, &errAccountOperation) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errAccountOperation.Error(), h.logger)
+			return
+		}
+
 		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, TransactionNotCreate, h.logger)
 		return
 	}
@@ -210,6 +229,24 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		var errOverdraft *models.OverdraftError
+		if errors.As(err, &errOverdraft) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errOverdraft.Error(), h.logger)
+			return
+		}
+
+		var errReconciled *models.ReconciledTransactionError
+		if errors.As(err, &errReconciled) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errReconciled.Error(), h.logger)
+			return
+		}
+
+		var errAccountOperation *models.AccountOperationError
+		if errors.As(err, &errAccountOperation) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errAccountOperation.Error(), h.logger)
+			return
+		}
+
 		if err != nil {
 			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, TransactionCreateServerError, h.logger)
 			return
@@ -258,6 +295,18 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 			commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
 			return
 		}
+
+		var errReconciled *models.ReconciledTransactionError
+		if errors.As(err, &errReconciled) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errReconciled.Error(), h.logger)
+			return
+		}
+
+		var errAccountOperation *models.AccountOperationError
+		if errors.As(err, &errAccountOperation) {
+			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, errAccountOperation.Error(), h.logger)
+			return
+		}
 		if err != nil {
 			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, TransactionDeleteServerError, h.logger)
 			return
@@ -420,21 +469,28 @@ func (h *Handler) ImportTransactions(w http.ResponseWriter, r *http.Request) {
 	// Create a new CSV reader reading from the file
 	reader := csv.NewReader(file)
 
-	var errNoSuchAccounts *models.NoSuchAccounts
+	accounts, err := h.client.List(r.Context(), &genAccount.ListRequest{UserId: user.ID.String()})
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, "Error getting accounts", h.logger)
+		return
+	}
 
-	accounts, err := h.userService.GetAccounts(r.Context(), user.ID)
-	if errors.As(err, &errNoSuchAccounts) {
-		h.logger.Info(errNoSuchAccounts)
-	} else if err != nil {
+	// an archived account takes no transactions, a name of one is not taken for a new account either
+	archivedAccounts, err := h.client.List(r.Context(), &genAccount.ListRequest{UserId: user.ID.String(), Archived: true})
+	if err != nil {
 		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, "Error getting accounts", h.logger)
 		return
 	}
 
+	archived := make(map[string]struct{}, len(archivedAccounts.Accounts))
+	for _, account := range archivedAccounts.Accounts {
+		archived[account.MeanPayment] = struct{}{}
+	}
+
 	accountCache := sync.Map{}
-	if len(accounts) != 0 {
-		for _, account := range accounts {
-			accountCache.Store(account.MeanPayment, account.ID)
-		}
+	for _, account := range accounts.Accounts {
+		accountID, _ := uuid.Parse(account.Id)
+		accountCache.Store(account.MeanPayment, accountID)
 	}
 
 	// Iterate through the records
@@ -473,6 +529,17 @@ func (h *Handler) ImportTransactions(w http.ResponseWriter, r *http.Request) {
 		payer := record[5]
 		description := record[6]
 
+		for _, name := range []string{accountIncome, accountOutcome} {
+			if _, ok := accountCache.Load(name); ok {
+				continue
+			}
+			if _, ok := archived[name]; ok {
+				commonHttp.ErrorResponse(w, http.StatusBadRequest, fmt.Errorf("account %q is archived", name),
+					fmt.Sprintf("account %s is archived, restore it to import", name), h.logger)
+				return
+			}
+		}
+
 		var accountIncomeId uuid.UUID
 		if value, ok := accountCache.Load(accountIncome); ok {
 			accountIncomeId = value.(uuid.UUID)
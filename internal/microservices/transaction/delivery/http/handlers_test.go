@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -365,6 +366,43 @@ func TestHandler_CreateTransaction(t *testing.T) {
 				mockUsecase.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(uuidTest, errors.New("transaction not created"))
 			},
 		},
+		{
+			name: "Transaction Creation Forbidden",
+			user: user,
+			requestBody: strings.NewReader(`{
+				"account_income": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"account_outcome": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"categories": [],
+				"date": "2023-10-02T15:30:00Z",
+				"description": "string",
+				"income": 100,
+				"outcome": 0
+			  }`),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"status":403,"message":"user has no rights"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(uuid.Nil, &models.ForbiddenUserError{})
+			},
+		},
+		{
+			name: "Overdraft Rejected",
+			user: user,
+			requestBody: strings.NewReader(`{
+				"account_income": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"account_outcome": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"categories": [],
+				"date": "2023-10-02T15:30:00Z",
+				"description": "string",
+				"income": 0,
+				"outcome": 100
+			  }`),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"not enough funds on account 7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6: 42.00 available"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: uuid.MustParse("7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6"), Available: 42}))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -526,6 +564,26 @@ func TestHandler_UpdateTransaction(t *testing.T) {
 				mockUsecase.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).Return(&errorNoSuchUserForbidden)
 			},
 		},
+		{
+			name: "Overdraft Rejected",
+			user: user,
+			requestBody: strings.NewReader(`{
+				"transaction_id": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"account_income": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"account_outcome": "7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6",
+				"categories": [],
+				"date": "2023-10-02T15:30:00Z",
+				"description": "string",
+				"income": 0,
+				"outcome": 100
+			}`),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":400,"message":"not enough funds on account 7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6: 42.00 available"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: uuid.MustParse("7c62a6ef-2c4c-48c1-8a98-825fb6a3f0e6"), Available: 42}))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -644,6 +702,20 @@ func TestHandler_TransactionDelete(t *testing.T) {
 				return context.WithValue(ctx, models.ContextKeyUserType{}, user)
 			},
 		},
+		{
+			name:         "Reconciled transaction",
+			userID:       uuidTest.String(),
+			expectedCode: http.StatusBadRequest,
+			flag:         true,
+			expectedBody: `{"status":400,"message":"transaction ` + uuidTest.String() + ` is reconciled and can't be changed"}`,
+			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
+				mockUsecase.EXPECT().DeleteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("[usecase] %w", &models.ReconciledTransactionError{TransactionID: uuidTest}))
+			},
+			funcCtxUser: func(user *models.User, ctx context.Context) context.Context {
+				return context.WithValue(ctx, models.ContextKeyUserType{}, user)
+			},
+		},
 		{
 			name:         "Unauthorized",
 			userID:       uuid.New().String(),
@@ -148,10 +148,10 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // CheckForbidden mocks base method.
-func (m *MockRepository) CheckForbidden(ctx context.Context, transactinID uuid.UUID) (uuid.UUID, error) {
+func (m *MockRepository) CheckForbidden(ctx context.Context, transactinID uuid.UUID) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CheckForbidden", ctx, transactinID)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret0, _ := ret[0].(*models.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -163,12 +163,13 @@ func (mr *MockRepositoryMockRecorder) CheckForbidden(ctx, transactinID interface
 }
 
 // CreateTransaction mocks base method.
-func (m *MockRepository) CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, error) {
+func (m *MockRepository) CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, *models.Overdraft, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateTransaction", ctx, transaction)
 	ret0, _ := ret[0].(uuid.UUID)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*models.Overdraft)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // CreateTransaction indicates an expected call of CreateTransaction.
@@ -237,11 +238,12 @@ func (mr *MockRepositoryMockRecorder) GetTransactionForExport(ctx, userId, query
 }
 
 // UpdateTransaction mocks base method.
-func (m *MockRepository) UpdateTransaction(ctx context.Context, transaction *models.Transaction) error {
+func (m *MockRepository) UpdateTransaction(ctx context.Context, transaction *models.Transaction) (*models.Overdraft, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateTransaction", ctx, transaction)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*models.Overdraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTransaction indicates an expected call of UpdateTransaction.
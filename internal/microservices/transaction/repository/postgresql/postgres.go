@@ -9,13 +9,14 @@ import (
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/cmd/api/init/db/postgresql"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 )
 
 const (
-	transactionCreate  = "INSERT INTO transaction (user_id, account_income, account_outcome, income, outcome, date, payer, description) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;"
+	transactionCreate  = "INSERT INTO transaction (user_id, account_income, account_outcome, income, outcome, date, payer, description, payee_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id;"
 	transactionGetFeed = `
     	SELECT 
 			t.id, 
@@ -32,9 +33,9 @@ const (
 		WHERE ua.user_id = $1
 	`
 
-	transactionUpdate         = "UPDATE transaction set account_income=$2, account_outcome=$3, income=$4, outcome=$5, date=$6, payer=$7, description=$8 WHERE id = $1;"
+	transactionUpdate         = "UPDATE transaction set account_income=$2, account_outcome=$3, income=$4, outcome=$5, date=$6, payer=$7, description=$8, payee_id=$9 WHERE id = $1;"
 	transactionGet            = "SELECT income, outcome, account_income, account_outcome FROM transaction WHERE id = $1;"
-	TransactionGetUserByID    = "SELECT user_id FROM transaction WHERE id = $1;"
+	TransactionGetUserByID    = "SELECT user_id, account_income, account_outcome, reconciliation_id IS NOT NULL FROM transaction WHERE id = $1;"
 	transactionDelete         = "DELETE FROM transaction WHERE id = $1;"
 	transactionGetCategory    = "SELECT tc.category_id, c.name AS category_name FROM TransactionCategory tc JOIN category c ON tc.category_id = c.id WHERE tc.transaction_id = $1;"
 	transactionCreateCategory = "INSERT INTO transactionCategory (transaction_id, category_id) VALUES ($1, $2);"
@@ -43,6 +44,23 @@ const (
 	transactionCheck          = "SELECT EXISTS( SELECT id FROM transaction WHERE id = $1);"
 	transactionCount          = "SELECT COUNT(*) FROM transaction WHERE user_id = $1;"
 
+	// adds (sign 1) or removes (sign -1) the transaction from the daily totals of the day,
+	// of the whole day and of each of its categories; transfers between accounts are not counted
+	transactionApplyDailyTotals = `INSERT INTO DailyTotals (user_id, account_id, category_id, day, income, outcome)
+									SELECT t.user_id, t.account_income, c.category_id, t.date::date,
+										$2::numeric * COALESCE(t.income, 0), $2::numeric * COALESCE(t.outcome, 0)
+									FROM Transaction t
+									CROSS JOIN LATERAL (
+										SELECT '00000000-0000-0000-0000-000000000000'::uuid
+										UNION
+										SELECT tc.category_id FROM TransactionCategory tc
+										WHERE tc.transaction_id = t.id AND tc.category_id IS NOT NULL
+									) AS c(category_id)
+									WHERE t.id = $1 AND t.account_income = t.account_outcome AND t.user_id IS NOT NULL
+									ON CONFLICT (user_id, account_id, category_id, day) DO UPDATE
+									SET income = DailyTotals.income + EXCLUDED.income,
+										outcome = DailyTotals.outcome + EXCLUDED.outcome;`
+
 	transactionGetFeedForExport = ` SELECT 
 										t.id,  
 										a_income.mean_payment AS account_income_name,
@@ -60,6 +78,11 @@ const (
 	`
 )
 
+const (
+	dailyTotalsAdd    = 1.0
+	dailyTotalsRemove = -1.0
+)
+
 type transactionRep struct {
 	db     postgresql.DbConn
 	logger logger.Logger
@@ -193,10 +216,11 @@ func (r *transactionRep) getCategoriesForTransaction(ctx context.Context, transa
 	return categoryIDs, nil
 }
 
-func (r *transactionRep) CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, error) {
+// CreateTransaction returns the overdraft the warn policy of the outcome account let through, if any
+func (r *transactionRep) CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, *models.Overdraft, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to start transaction: %w", err)
+		return uuid.Nil, nil, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer func() {
 		if err != nil {
@@ -209,22 +233,28 @@ func (r *transactionRep) CreateTransaction(ctx context.Context, transaction *mod
 
 	id, err := r.insertTransaction(ctx, tx, transaction)
 	if err != nil {
-		return id, err
+		return id, nil, err
 	}
+	transaction.ID = id
 
-	if err = r.updateAccountBalances(ctx, tx, transaction); err != nil {
-		return id, err
+	overdraft, err := r.updateAccountBalances(ctx, tx, transaction)
+	if err != nil {
+		return id, nil, err
 	}
 
 	if err = r.insertCategories(ctx, tx, id, transaction.Categories); err != nil {
-		return id, err
+		return id, nil, err
+	}
+
+	if err = r.applyDailyTotals(ctx, tx, id, dailyTotalsAdd); err != nil {
+		return id, nil, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		return id, fmt.Errorf("[repo] failed to commit transaction: %w", err)
+		return id, nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
 	}
 
-	return id, nil
+	return id, overdraft, nil
 }
 
 func (r *transactionRep) insertTransaction(ctx context.Context, tx pgx.Tx, transaction *models.Transaction) (uuid.UUID, error) {
@@ -237,6 +267,7 @@ func (r *transactionRep) insertTransaction(ctx context.Context, tx pgx.Tx, trans
 		transaction.Date,
 		transaction.Payer,
 		transaction.Description,
+		transaction.PayeeID,
 	)
 
 	var id uuid.UUID
@@ -247,16 +278,29 @@ func (r *transactionRep) insertTransaction(ctx context.Context, tx pgx.Tx, trans
 	return id, nil
 }
 
-func (r *transactionRep) updateAccountBalances(ctx context.Context, tx pgx.Tx, transaction *models.Transaction) error {
+// updateAccountBalances puts the income on the income account and charges the outcome
+// to the outcome account by its overdraft policy
+func (r *transactionRep) updateAccountBalances(ctx context.Context, tx pgx.Tx, transaction *models.Transaction) (*models.Overdraft, error) {
 	if err := r.updateAccountBalance(ctx, tx, transaction.AccountIncomeID, -transaction.Income); err != nil {
-		return fmt.Errorf("[repo] failed to update old AccountIncome balance: %w", err)
+		return nil, fmt.Errorf("[repo] failed to update old AccountIncome balance: %w", err)
 	}
 
-	if err := r.updateAccountBalance(ctx, tx, transaction.AccountOutcomeID, transaction.Outcome); err != nil {
-		return fmt.Errorf("[repo] failed to update old AccountIncome balance: %w", err)
+	if transaction.Outcome <= 0 {
+		if err := r.updateAccountBalance(ctx, tx, transaction.AccountOutcomeID, transaction.Outcome); err != nil {
+			return nil, fmt.Errorf("[repo] failed to update old AccountIncome balance: %w", err)
+		}
+		return nil, nil
 	}
 
-	return nil
+	overdraft, err := accountRep.ChargeAccount(ctx, tx, transaction.AccountOutcomeID, transaction.Outcome)
+	if err != nil {
+		return nil, err
+	}
+	if overdraft != nil {
+		overdraft.TransactionID = transaction.ID
+	}
+
+	return overdraft, nil
 }
 
 func (r *transactionRep) updateAccountBalance(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, amount float64) error {
@@ -278,10 +322,18 @@ func (r *transactionRep) insertCategories(ctx context.Context, tx pgx.Tx, transa
 	return nil
 }
 
-func (r *transactionRep) UpdateTransaction(ctx context.Context, transaction *models.Transaction) error {
+func (r *transactionRep) applyDailyTotals(ctx context.Context, tx pgx.Tx, transactionID uuid.UUID, sign float64) error {
+	if _, err := tx.Exec(ctx, transactionApplyDailyTotals, transactionID, sign); err != nil {
+		return fmt.Errorf("[repo] failed to update daily totals: %w", err)
+	}
+	return nil
+}
+
+// UpdateTransaction returns the overdraft the warn policy of the outcome account let through, if any
+func (r *transactionRep) UpdateTransaction(ctx context.Context, transaction *models.Transaction) (*models.Overdraft, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("[repo] failed to start transaction: %w", err)
+		return nil, fmt.Errorf("[repo] failed to start transaction: %w", err)
 	}
 
 	defer func() {
@@ -295,34 +347,43 @@ func (r *transactionRep) UpdateTransaction(ctx context.Context, transaction *mod
 
 	existingIncome, existingOutcome, existingAccountIncomeID, existingAccountOutcomeID, err := r.getTransactionInfo(ctx, tx, transaction.ID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err = r.applyDailyTotals(ctx, tx, transaction.ID, dailyTotalsRemove); err != nil {
+		return nil, err
 	}
 
 	if err = r.deleteAccountBalance(ctx, tx, existingIncome, existingOutcome, existingAccountIncomeID, existingAccountOutcomeID); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = r.updateAccountBalances(ctx, tx, transaction); err != nil {
-		return err
+	overdraft, err := r.updateAccountBalances(ctx, tx, transaction)
+	if err != nil {
+		return nil, err
 	}
 
 	if err = r.updateTransactionInfo(ctx, tx, transaction); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = r.deleteExistingCategoryAssociations(ctx, tx, transaction.ID); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = r.insertCategories(ctx, tx, transaction.ID, transaction.Categories); err != nil {
-		return err
+		return nil, err
+	}
+
+	if err = r.applyDailyTotals(ctx, tx, transaction.ID, dailyTotalsAdd); err != nil {
+		return nil, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("[repo] failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("[repo] failed to commit transaction: %w", err)
 	}
 
-	return nil
+	return overdraft, nil
 }
 
 func (r *transactionRep) updateTransactionInfo(ctx context.Context, tx pgx.Tx, transaction *models.Transaction) error {
@@ -335,6 +396,7 @@ func (r *transactionRep) updateTransactionInfo(ctx context.Context, tx pgx.Tx, t
 		transaction.Date,
 		transaction.Payer,
 		transaction.Description,
+		transaction.PayeeID,
 	)
 	if err != nil {
 		return fmt.Errorf("[repo] failed to update transaction information: %w", err)
@@ -397,6 +459,10 @@ func (r *transactionRep) DeleteTransaction(ctx context.Context, transactionID uu
 		return err
 	}
 
+	if err = r.applyDailyTotals(ctx, tx, transactionID, dailyTotalsRemove); err != nil {
+		return err
+	}
+
 	if err = r.deleteAccountBalance(ctx, tx, existingIncome, existingOutcome, existingAccountIncomeID, existingAccountOutcomeID); err != nil {
 		return err
 	}
@@ -417,18 +483,20 @@ func (r *transactionRep) DeleteTransaction(ctx context.Context, transactionID uu
 	return nil
 }
 
-func (r *transactionRep) CheckForbidden(ctx context.Context, transactionID uuid.UUID) (uuid.UUID, error) { // need test
-	var userID uuid.UUID
+// CheckForbidden returns the author and the accounts of the transaction to check the rights on
+// and whether a reconciliation locked it
+func (r *transactionRep) CheckForbidden(ctx context.Context, transactionID uuid.UUID) (*models.Transaction, error) { // need test
+	transaction := models.Transaction{ID: transactionID}
 	row := r.db.QueryRow(ctx, TransactionGetUserByID, transactionID)
 
-	err := row.Scan(&userID)
+	err := row.Scan(&transaction.UserID, &transaction.AccountIncomeID, &transaction.AccountOutcomeID, &transaction.Reconciled)
 	if errors.Is(err, sql.ErrNoRows) {
-		return userID, fmt.Errorf("[repo] %w: %v", &models.NoSuchTransactionError{UserID: transactionID}, err)
+		return nil, fmt.Errorf("[repo] %w: %v", &models.NoSuchTransactionError{UserID: transactionID}, err)
 	} else if err != nil {
-		return userID,
+		return nil,
 			fmt.Errorf("[repo] failed request db %s, %w", TransactionGetUserByID, err)
 	}
-	return userID, nil
+	return &transaction, nil
 }
 
 func (r *transactionRep) GetTransactionForExport(ctx context.Context, userId uuid.UUID, queryGet *models.QueryListOptions) ([]models.TransactionExport, error) {
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	accountRep "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/repository/postgresql"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
 )
 
@@ -338,7 +340,8 @@ func TestInsertTransaction(t *testing.T) {
 				test.transaction.Outcome,
 				test.transaction.Date,
 				test.transaction.Payer,
-				test.transaction.Description).
+				test.transaction.Description,
+				test.transaction.PayeeID).
 				WillReturnError(test.errRows).
 				WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(test.returnRows))
 			//mock.ExpectCommit()
@@ -408,46 +411,154 @@ func TestUpdateAccountBalance(t *testing.T) {
 	}
 }
 
+func TestApplyDailyTotals(t *testing.T) {
+	transactionID := uuid.New()
+	tests := []struct {
+		name    string
+		sign    float64
+		execErr error
+		err     error
+	}{
+		{
+			name: "Add",
+			sign: dailyTotalsAdd,
+			err:  nil,
+		},
+		{
+			name: "Remove",
+			sign: dailyTotalsRemove,
+			err:  nil,
+		},
+		{
+			name:    "Error",
+			sign:    dailyTotalsAdd,
+			execErr: errors.New("err"),
+			err:     fmt.Errorf("[repo] failed to update daily totals: %w", errors.New("err")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+
+			logger := *logger.NewLogger(context.TODO())
+			repo := NewRepository(mock, logger)
+
+			escapedQuery := regexp.QuoteMeta(transactionApplyDailyTotals)
+			mock.ExpectExec(escapedQuery).
+				WithArgs(transactionID, test.sign).
+				WillReturnResult(pgxmock.NewResult("INSERT", 2)).
+				WillReturnError(test.execErr)
+
+			err := repo.applyDailyTotals(context.Background(), mock, transactionID, test.sign)
+
+			if (test.err == nil && err != nil) || (test.err != nil && err == nil) || (test.err != nil && err != nil && test.err.Error() != err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestUpdateAccountBalances(t *testing.T) {
+	accountID := uuid.New()
 	transactionID := uuid.New()
+	userID := uuid.New()
+	columns := []string{"id", "balance", "kind", "credit_limit", "overdraft_policy"}
+
 	tests := []struct {
 		name        string
 		transaction models.Transaction
-		errRows     error
-		returnRows  uuid.UUID
-		expected    uuid.UUID
+		charged     *pgxmock.Rows
+		chargeErr   error
+		overdraft   *models.Overdraft
 		err         error
 	}{
 		{
-			name:        "ValidTransaction",
-			transaction: models.Transaction{Income: 10, Outcome: 10, AccountIncomeID: transactionID, AccountOutcomeID: transactionID},
-			returnRows:  transactionID,
-			expected:    transactionID,
-			err:         fmt.Errorf("[repo] failed to update old AccountIncome balance: all expectations were already fulfilled, call to ExecQuery 'UPDATE accounts SET balance = balance - $1 WHERE id = $2;' with args [10 %s] was not expected", transactionID.String()),
+			name:        "Income",
+			transaction: models.Transaction{Income: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+		},
+		{
+			name:        "Enough funds",
+			transaction: models.Transaction{Outcome: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns).AddRow(accountID, 15.0, models.AccountDebit, 0.0, models.OverdraftReject),
+		},
+		{
+			name:        "Within credit limit",
+			transaction: models.Transaction{Outcome: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns).AddRow(accountID, -300.0, models.AccountCredit, 500.0, models.OverdraftReject),
+		},
+		{
+			name:        "Rejected overdraft",
+			transaction: models.Transaction{Outcome: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns).AddRow(accountID, 6.0, models.AccountDebit, 0.0, models.OverdraftReject),
+			err:         fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: accountID, Available: 6}),
+		},
+		{
+			name:        "Credit limit exceeded",
+			transaction: models.Transaction{Outcome: 100, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns).AddRow(accountID, -450.0, models.AccountCredit, 500.0, models.OverdraftReject),
+			err:         fmt.Errorf("[repo] %w", &models.OverdraftError{AccountID: accountID, Available: 50}),
+		},
+		{
+			name:        "Warned overdraft",
+			transaction: models.Transaction{ID: transactionID, UserID: userID, Outcome: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns).AddRow(accountID, 6.0, models.AccountDebit, 0.0, models.OverdraftWarn),
+			overdraft:   &models.Overdraft{AccountID: accountID, TransactionID: transactionID, Amount: 10, Available: 6},
+		},
+		{
+			name:        "Allowed overdraft",
+			transaction: models.Transaction{Outcome: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns).AddRow(accountID, 6.0, models.AccountDebit, 0.0, models.OverdraftAllow),
+		},
+		{
+			name:        "No such account",
+			transaction: models.Transaction{Outcome: 10, AccountIncomeID: accountID, AccountOutcomeID: accountID},
+			charged:     pgxmock.NewRows(columns),
+			chargeErr:   pgx.ErrNoRows,
+			err:         fmt.Errorf("[repo] %w", &models.NoSuchAccounts{}),
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			mock, _ := pgxmock.NewPool()
-			ctl := gomock.NewController(t)
-			defer ctl.Finish()
 
 			logger := *logger.NewLogger(context.TODO())
 			repo := NewRepository(mock, logger)
 
-			escapedQuery := regexp.QuoteMeta(transactionUpdateAccount)
-			//"UPDATE accounts SET balance = balance - $1 WHERE id = $2;"
-			mock.ExpectExec(escapedQuery).
+			mock.ExpectExec(regexp.QuoteMeta(transactionUpdateAccount)).
+				WithArgs(-test.transaction.Income, accountID).
 				WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			if test.charged == nil {
+				mock.ExpectExec(regexp.QuoteMeta(transactionUpdateAccount)).
+					WithArgs(test.transaction.Outcome, accountID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(accountRep.AccountChargeLock)).
+					WithArgs(accountID).
+					WillReturnRows(test.charged).
+					WillReturnError(test.chargeErr)
+				if test.err == nil {
+					mock.ExpectExec(regexp.QuoteMeta(accountRep.AccountCharge)).
+						WithArgs(test.transaction.Outcome, accountID).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				}
+			}
 
-			//mock.ExpectCommit()
-			err := repo.updateAccountBalances(context.Background(), mock, &test.transaction)
+			overdraft, err := repo.updateAccountBalances(context.Background(), mock, &test.transaction)
 
 			if (test.err == nil && err != nil) || (test.err != nil && err == nil) || (test.err != nil && err != nil && test.err.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", test.err, err)
 			}
 
+			if !reflect.DeepEqual(overdraft, test.overdraft) {
+				t.Errorf("Expected overdraft: %v, but got: %v", test.overdraft, overdraft)
+			}
+
 			if err := mock.ExpectationsWereMet(); err != nil {
 				t.Errorf("There were unfulfilled expectations: %s", err)
 			}
@@ -544,7 +655,7 @@ func TestUpdateTransactionInfo(t *testing.T) {
 
 			escapedQuery := regexp.QuoteMeta(transactionUpdate)
 			//"UPDATE accounts SET balance = balance - $1 WHERE id = $2;"
-			// "UPDATE transaction set account_income=$2, account_outcome=$3, income=$4, outcome=$5, date=$6, payer=$7, description=$8 WHERE id = $1;"
+			// "UPDATE transaction set account_income=$2, account_outcome=$3, income=$4, outcome=$5, date=$6, payer=$7, description=$8, payee_id=$9 WHERE id = $1;"
 
 			mock.ExpectExec(escapedQuery).
 				WithArgs(test.transaction.AccountIncomeID,
@@ -554,7 +665,8 @@ func TestUpdateTransactionInfo(t *testing.T) {
 					test.transaction.Outcome,
 					test.transaction.Date,
 					test.transaction.Payer,
-					test.transaction.Description).
+					test.transaction.Description,
+					test.transaction.PayeeID).
 				WillReturnResult(pgxmock.NewResult("UPDATE", 1)).
 				WillReturnError(test.rowsErr)
 
@@ -606,7 +718,7 @@ func TestDeleteExistingCategoryAssociations(t *testing.T) {
 
 			escapedQuery := regexp.QuoteMeta(transactionDeleteCategory)
 			//"UPDATE accounts SET balance = balance - $1 WHERE id = $2;"
-			// "UPDATE transaction set account_income=$2, account_outcome=$3, income=$4, outcome=$5, date=$6, payer=$7, description=$8 WHERE id = $1;"
+			// "UPDATE transaction set account_income=$2, account_outcome=$3, income=$4, outcome=$5, date=$6, payer=$7, description=$8, payee_id=$9 WHERE id = $1;"
 
 			mock.ExpectExec(escapedQuery).
 				WithArgs(transactionID).
@@ -677,38 +789,44 @@ func TestDeleteAccountBalance(t *testing.T) {
 
 func TestCheckForbidden(t *testing.T) {
 	transactionID := uuid.New()
+	userID := uuid.New()
+	accountID := uuid.New()
 	tests := []struct {
 		name        string
 		transaction uuid.UUID
 		errRows     error
 
 		returnRows *pgxmock.Rows
-		expected   uuid.UUID
+		expected   *models.Transaction
 		err        error
 	}{
 		{
 			name:        "ValidTransaction",
 			transaction: transactionID,
-			returnRows:  pgxmock.NewRows([]string{"user_id"}).AddRow(transactionID),
-			expected:    transactionID,
+			returnRows:  pgxmock.NewRows([]string{"user_id", "account_income", "account_outcome", "reconciled"}).AddRow(userID, accountID, accountID, false),
+			expected:    &models.Transaction{ID: transactionID, UserID: userID, AccountIncomeID: accountID, AccountOutcomeID: accountID},
 			errRows:     nil,
 			err:         nil,
 		},
 		{
 			name:        "ValidTransaction",
 			transaction: transactionID,
-			returnRows:  pgxmock.NewRows([]string{"user_id"}).AddRow(transactionID),
-			expected:    transactionID,
+			returnRows:  pgxmock.NewRows([]string{"user_id", "account_income", "account_outcome", "reconciled"}).AddRow(userID, accountID, accountID, false),
 			errRows:     sql.ErrNoRows,
 			err:         fmt.Errorf("[repo] No Such transaction: %s doesn't exist: sql: no rows in result set", transactionID.String()),
 		},
 		{
 			name:        "ValidTransaction",
 			transaction: transactionID,
-			returnRows:  pgxmock.NewRows([]string{"user_id"}).AddRow(transactionID),
-			expected:    transactionID,
+			returnRows:  pgxmock.NewRows([]string{"user_id", "account_income", "account_outcome", "reconciled"}).AddRow(userID, accountID, accountID, false),
 			errRows:     errors.New("err"),
-			err:         fmt.Errorf("[repo] failed request db SELECT user_id FROM transaction WHERE id = $1;, err"),
+			err:         fmt.Errorf("[repo] failed request db SELECT user_id, account_income, account_outcome, reconciliation_id IS NOT NULL FROM transaction WHERE id = $1;, err"),
+		},
+		{
+			name:        "ReconciledTransaction",
+			transaction: transactionID,
+			returnRows:  pgxmock.NewRows([]string{"user_id", "account_income", "account_outcome", "reconciled"}).AddRow(userID, accountID, accountID, true),
+			expected:    &models.Transaction{ID: transactionID, UserID: userID, AccountIncomeID: accountID, AccountOutcomeID: accountID, Reconciled: true},
 		},
 	}
 
@@ -727,12 +845,16 @@ func TestCheckForbidden(t *testing.T) {
 				WillReturnRows(test.returnRows).
 				WillReturnError(test.errRows)
 
-			_, err := repo.CheckForbidden(context.Background(), test.transaction)
+			transaction, err := repo.CheckForbidden(context.Background(), test.transaction)
 
 			if (test.err == nil && err != nil) || (test.err != nil && err == nil) || (test.err != nil && err != nil && test.err.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", test.err, err)
 			}
 
+			if !reflect.DeepEqual(transaction, test.expected) {
+				t.Errorf("Expected transaction %v, but got: %v", test.expected, transaction)
+			}
+
 			if err := mock.ExpectationsWereMet(); err != nil {
 				t.Errorf("There were unfulfilled expectations: %s", err)
 			}
@@ -21,12 +21,12 @@ type Usecase interface {
 
 type Repository interface {
 	DeleteTransaction(ctx context.Context, transactionID uuid.UUID, userID uuid.UUID) error
-	CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, error)
+	CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, *models.Overdraft, error)
 	GetFeed(ctx context.Context, userID uuid.UUID, query *models.QueryListOptions) ([]models.Transaction, error)
 	GetCount(ctx context.Context, userID uuid.UUID) (int, error)
 	// GetTransaction(ctx context.Context, transaction models.Transaction) *models.Transaction
-	UpdateTransaction(ctx context.Context, transaction *models.Transaction) error
-	CheckForbidden(ctx context.Context, transactinID uuid.UUID) (uuid.UUID, error)
+	UpdateTransaction(ctx context.Context, transaction *models.Transaction) (*models.Overdraft, error)
+	CheckForbidden(ctx context.Context, transactinID uuid.UUID) (*models.Transaction, error)
 	//Check(ctx context.Context, transactionID uuid.UUID) error
 
 	GetTransactionForExport(ctx context.Context, userId uuid.UUID, query *models.QueryListOptions) ([]models.TransactionExport, error)
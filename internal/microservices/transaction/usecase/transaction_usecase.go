@@ -5,6 +5,9 @@ import (
 	"fmt"
 
 	logging "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/transaction"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/google/uuid"
@@ -12,14 +15,23 @@ import (
 
 type Usecase struct {
 	transactionRepo transaction.Repository
+	payeeService    payee.Usecase
+	accountRepo     account.Repository
+	anomalyService  anomaly.Usecase
 	logger          logging.Logger
 }
 
 func NewUsecase(
 	tr transaction.Repository,
-	log logging.Logger) *Usecase {
+	log logging.Logger,
+	pu payee.Usecase,
+	ar account.Repository,
+	au anomaly.Usecase) *Usecase {
 	return &Usecase{
 		transactionRepo: tr,
+		payeeService:    pu,
+		accountRepo:     ar,
+		anomalyService:  au,
 		logger:          log,
 	}
 }
@@ -42,39 +54,60 @@ func (t *Usecase) GetCount(ctx context.Context, userID uuid.UUID) (int, error) {
 }
 
 func (t *Usecase) CreateTransaction(ctx context.Context, transaction *models.Transaction) (uuid.UUID, error) {
-	transactionID, err := t.transactionRepo.CreateTransaction(ctx, transaction)
+	if err := t.checkAccounts(ctx, transaction.UserID, transaction, models.AccountRole.CanAdd); err != nil {
+		return uuid.Nil, fmt.Errorf("[usecase] can't be created by user: %w", err)
+	}
+
+	t.resolvePayee(ctx, transaction)
+
+	transactionID, overdraft, err := t.transactionRepo.CreateTransaction(ctx, transaction)
 	if err != nil {
 		return transactionID, fmt.Errorf("[usecase] can't create transaction into repository: %w", err)
 	}
+	t.recordOverdraft(ctx, transaction.UserID, overdraft)
 
 	return transactionID, nil
 }
 
 func (t *Usecase) UpdateTransaction(ctx context.Context, transaction *models.Transaction) error {
-	userIDCheck, err := t.transactionRepo.CheckForbidden(ctx, transaction.ID)
+	existing, err := t.transactionRepo.CheckForbidden(ctx, transaction.ID)
 	if err != nil {
 		return fmt.Errorf("[usecase] can't find transaction in repository %w", err)
 	}
+	if existing.Reconciled {
+		return fmt.Errorf("[usecase] %w", &models.ReconciledTransactionError{TransactionID: transaction.ID})
+	}
 
-	if userIDCheck != transaction.UserID {
-		return fmt.Errorf("[usecase] can't be update by user: %w", &models.ForbiddenUserError{})
+	// the transaction is moved out of the old accounts into the new ones
+	if err := t.checkAccounts(ctx, transaction.UserID, existing, canEdit); err != nil {
+		return fmt.Errorf("[usecase] can't be update by user: %w", err)
+	}
+	if err := t.checkAccounts(ctx, transaction.UserID, transaction, canEdit); err != nil {
+		return fmt.Errorf("[usecase] can't be update by user: %w", err)
 	}
 
-	if err := t.transactionRepo.UpdateTransaction(ctx, transaction); err != nil {
+	t.resolvePayee(ctx, transaction)
+
+	overdraft, err := t.transactionRepo.UpdateTransaction(ctx, transaction)
+	if err != nil {
 		return fmt.Errorf("[usecase] can't update transaction %w", err)
 	}
+	t.recordOverdraft(ctx, transaction.UserID, overdraft)
 
 	return nil
 }
 
 func (t *Usecase) DeleteTransaction(ctx context.Context, transactionID uuid.UUID, userID uuid.UUID) error {
-	userIDCheck, err := t.transactionRepo.CheckForbidden(ctx, transactionID)
+	existing, err := t.transactionRepo.CheckForbidden(ctx, transactionID)
 	if err != nil {
 		return fmt.Errorf("[usecase] can't find transaction in repository %w", err)
 	}
+	if existing.Reconciled {
+		return fmt.Errorf("[usecase] %w", &models.ReconciledTransactionError{TransactionID: transactionID})
+	}
 
-	if userIDCheck != userID {
-		return fmt.Errorf("[usecase] can't be deleted by user: %w", &models.ForbiddenUserError{})
+	if err := t.checkAccounts(ctx, userID, existing, canEdit); err != nil {
+		return fmt.Errorf("[usecase] can't be deleted by user: %w", err)
 	}
 
 	err = t.transactionRepo.DeleteTransaction(ctx, transactionID, userID)
@@ -92,3 +125,72 @@ func (u *Usecase) GetTransactionForExport(ctx context.Context, userId uuid.UUID,
 	}
 	return transaction, nil
 }
+
+// resolvePayee links the transaction to the payee of its payer, on failure the transaction
+// is saved without a payee and gets linked later by the payee backfill job
+func (t *Usecase) resolvePayee(ctx context.Context, transaction *models.Transaction) {
+	payeeID, err := t.payeeService.ResolvePayee(ctx, transaction.UserID, transaction.Payer)
+	if err != nil {
+		t.logger.Errorf("[usecase] can't resolve payee %q: %v", transaction.Payer, err)
+		return
+	}
+	transaction.PayeeID = payeeID
+}
+
+// recordOverdraft raises the anomaly of an overdraft the warn policy let through,
+// the transaction is already saved so a failure is only logged
+func (t *Usecase) recordOverdraft(ctx context.Context, userID uuid.UUID, overdraft *models.Overdraft) {
+	if overdraft == nil {
+		return
+	}
+	if err := t.anomalyService.RecordOverdraft(ctx, userID, overdraft); err != nil {
+		t.logger.Errorf("[usecase] can't record overdraft of account %s: %v", overdraft.AccountID, err)
+	}
+}
+
+// checkAccounts checks the role of the user on the accounts of the transaction, allowed gets
+// the income the transaction brings to the account, and refuses archived accounts.
+// A transaction without accounts is checked by its author
+func (t *Usecase) checkAccounts(ctx context.Context, userID uuid.UUID, transaction *models.Transaction, allowed func(models.AccountRole, float64) bool) error {
+	if transaction.AccountIncomeID == uuid.Nil && transaction.AccountOutcomeID == uuid.Nil {
+		if transaction.UserID != userID {
+			return &models.ForbiddenUserError{}
+		}
+		return nil
+	}
+
+	if transaction.AccountIncomeID != uuid.Nil {
+		if err := t.checkRole(ctx, userID, transaction.AccountIncomeID, transaction.Income, allowed); err != nil {
+			return err
+		}
+	}
+	if transaction.AccountOutcomeID != uuid.Nil && transaction.AccountOutcomeID != transaction.AccountIncomeID {
+		if err := t.checkRole(ctx, userID, transaction.AccountOutcomeID, 0, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Usecase) checkRole(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, income float64, allowed func(models.AccountRole, float64) bool) error {
+	role, err := t.accountRepo.GetRole(ctx, accountID, userID)
+	if err != nil {
+		return err
+	}
+	if !allowed(role, income) {
+		return fmt.Errorf("%s of account %s: %w", role, accountID, &models.ForbiddenUserError{})
+	}
+
+	archived, err := t.accountRepo.IsArchived(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return fmt.Errorf("account %s: %w", accountID, &models.AccountOperationError{Reason: "account is archived, restore it first"})
+	}
+	return nil
+}
+
+func canEdit(role models.AccountRole, _ float64) bool {
+	return role.CanEdit()
+}
@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	mockAccount "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
+	mockAnomaly "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/anomaly/mocks"
+	mockPayee "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/payee/mocks"
 	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/transaction/mocks"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/golang/mock/gomock"
@@ -47,7 +50,7 @@ func TestUsecase_GetFeed(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayee.NewMockUsecase(ctrl), mockAccount.NewMockRepository(ctrl), mockAnomaly.NewMockUsecase(ctrl))
 
 			userID := uuid.New()
 
@@ -94,7 +97,7 @@ func TestUsecase_GetCount(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayee.NewMockUsecase(ctrl), mockAccount.NewMockRepository(ctrl), mockAnomaly.NewMockUsecase(ctrl))
 
 			userID := uuid.New()
 
@@ -110,26 +113,55 @@ func TestUsecase_GetCount(t *testing.T) {
 
 func TestUsecase_CreateTransaction(t *testing.T) {
 	userIdTest := uuid.New()
+	payeeIdTest := uuid.New()
+	overdraft := &models.Overdraft{AccountID: uuid.New(), TransactionID: userIdTest, Amount: 100, Available: 40}
 	testCases := []struct {
 		name                  string
+		overdraft             *models.Overdraft
 		expectedTransactionID uuid.UUID
+		expectedPayeeID       *uuid.UUID
 		expectedErr           error
-		mockRepoFn            func(*mock.MockRepository)
+		mockRepoFn            func(*mock.MockRepository, *mockPayee.MockUsecase)
 	}{
 		{
 			name:                  "Successful TestUsecase_CreateTransaction",
 			expectedTransactionID: userIdTest,
+			expectedPayeeID:       &payeeIdTest,
 			expectedErr:           nil,
-			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil)
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), gomock.Any(), "Shop").Return(&payeeIdTest, nil)
+				mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, nil)
+			},
+		},
+		{
+			name:                  "Payee error in TestUsecase_CreateTransaction",
+			expectedTransactionID: userIdTest,
+			expectedPayeeID:       nil,
+			expectedErr:           nil,
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), gomock.Any(), "Shop").Return(nil, errors.New("some error"))
+				mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, nil)
+			},
+		},
+		{
+			name:                  "Overdraft in TestUsecase_CreateTransaction",
+			overdraft:             overdraft,
+			expectedTransactionID: userIdTest,
+			expectedPayeeID:       &payeeIdTest,
+			expectedErr:           nil,
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), gomock.Any(), "Shop").Return(&payeeIdTest, nil)
+				mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, overdraft, nil)
 			},
 		},
 		{
 			name:                  "Error in TestUsecase_CreateTransaction",
 			expectedErr:           fmt.Errorf("[usecase] can't create transaction into repository: some error"),
 			expectedTransactionID: userIdTest,
-			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, errors.New("some error"))
+			expectedPayeeID:       &payeeIdTest,
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), gomock.Any(), "Shop").Return(&payeeIdTest, nil)
+				mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, errors.New("some error"))
 			},
 		},
 	}
@@ -140,13 +172,19 @@ func TestUsecase_CreateTransaction(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock.NewMockRepository(ctrl)
-			tc.mockRepoFn(mockRepo)
+			mockPayeeService := mockPayee.NewMockUsecase(ctrl)
+			tc.mockRepoFn(mockRepo, mockPayeeService)
+			mockAnomalyService := mockAnomaly.NewMockUsecase(ctrl)
+			if tc.overdraft != nil {
+				mockAnomalyService.EXPECT().RecordOverdraft(gomock.Any(), userIdTest, tc.overdraft).Return(nil)
+			}
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayeeService, mockAccount.NewMockRepository(ctrl), mockAnomalyService)
 
-			transaction := models.Transaction{}
+			transaction := models.Transaction{UserID: userIdTest, Payer: "Shop"}
 			transactionID, err := mockUsecase.CreateTransaction(context.Background(), &transaction)
 			assert.Equal(t, tc.expectedTransactionID, transactionID)
+			assert.Equal(t, tc.expectedPayeeID, transaction.PayeeID)
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
 				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
 			}
@@ -159,38 +197,47 @@ func TestUsecase_UpdateTransaction(t *testing.T) {
 	testCases := []struct {
 		name        string
 		expectedErr error
-		mockRepoFn  func(*mock.MockRepository)
+		mockRepoFn  func(*mock.MockRepository, *mockPayee.MockUsecase)
 	}{
 		{
 			name:        "Successful",
 			expectedErr: nil,
-			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(userIdTest, nil)
-				mockRepositry.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).Return(nil)
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: userIdTest}, nil)
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), userIdTest, gomock.Any()).Return(nil, nil)
+				mockRepositry.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
 			},
 		},
 		{
 			name:        "Error in userIDCheck != transaction.UserID",
 			expectedErr: fmt.Errorf("[usecase] can't be update by user: user has no rights"),
-			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
-				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, errors.New("some error"))
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: uuid.New()}, nil)
+				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, errors.New("some error"))
 			},
 		},
 		{
 			name:        "Error in can't find transaction in repository",
 			expectedErr: fmt.Errorf("[usecase] can't find transaction in repository some err"),
-			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(uuid.New(), errors.New("some err"))
-				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, errors.New("some error"))
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(nil, errors.New("some err"))
+				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, errors.New("some error"))
 			},
 		},
 		{
 			name:        "Error in can't find transaction in repository",
 			expectedErr: fmt.Errorf("[usecase] can't update transaction some error"),
-			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(userIdTest, nil)
-				mockRepositry.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: userIdTest}, nil)
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), userIdTest, gomock.Any()).Return(nil, nil)
+				mockRepositry.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+		},
+		{
+			name:        "Reconciled transaction",
+			expectedErr: fmt.Errorf("[usecase] transaction %s is reconciled and can't be changed", uuid.Nil),
+			mockRepoFn: func(mockRepositry *mock.MockRepository, mockPayeeService *mockPayee.MockUsecase) {
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: userIdTest, Reconciled: true}, nil)
 			},
 		},
 	}
@@ -201,9 +248,10 @@ func TestUsecase_UpdateTransaction(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := mock.NewMockRepository(ctrl)
-			tc.mockRepoFn(mockRepo)
+			mockPayeeService := mockPayee.NewMockUsecase(ctrl)
+			tc.mockRepoFn(mockRepo, mockPayeeService)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayeeService, mockAccount.NewMockRepository(ctrl), mockAnomaly.NewMockUsecase(ctrl))
 
 			transaction := models.Transaction{UserID: userIdTest}
 			err := mockUsecase.UpdateTransaction(context.Background(), &transaction)
@@ -225,7 +273,7 @@ func TestUsecase_DeleteTransaction(t *testing.T) {
 			name:        "Successful",
 			expectedErr: nil,
 			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(userIdTest, nil)
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: userIdTest}, nil)
 				mockRepositry.EXPECT().DeleteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
@@ -233,26 +281,33 @@ func TestUsecase_DeleteTransaction(t *testing.T) {
 			name:        "Error in userIDCheck != transaction.UserID",
 			expectedErr: fmt.Errorf("[usecase] can't be deleted by user: user has no rights"),
 			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
-				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, errors.New("some error"))
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: uuid.New()}, nil)
+				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, errors.New("some error"))
 			},
 		},
 		{
 			name:        "Error in can't find transaction in repository",
 			expectedErr: fmt.Errorf("[usecase] can't find transaction in repository some err"),
 			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(uuid.New(), errors.New("some err"))
-				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, errors.New("some error"))
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(nil, errors.New("some err"))
+				//mockRepositry.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(userIdTest, nil, errors.New("some error"))
 			},
 		},
 		{
 			name:        "Error in can't find transaction in repository",
 			expectedErr: fmt.Errorf("[usecase] can`t be deleted from repository"),
 			mockRepoFn: func(mockRepositry *mock.MockRepository) {
-				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(userIdTest, nil)
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: userIdTest}, nil)
 				mockRepositry.EXPECT().DeleteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 			},
 		},
+		{
+			name:        "Reconciled transaction",
+			expectedErr: fmt.Errorf("[usecase] transaction %s is reconciled and can't be changed", userIdTest),
+			mockRepoFn: func(mockRepositry *mock.MockRepository) {
+				mockRepositry.EXPECT().CheckForbidden(gomock.Any(), gomock.Any()).Return(&models.Transaction{UserID: userIdTest, Reconciled: true}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -263,7 +318,7 @@ func TestUsecase_DeleteTransaction(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayee.NewMockUsecase(ctrl), mockAccount.NewMockRepository(ctrl), mockAnomaly.NewMockUsecase(ctrl))
 
 			err := mockUsecase.DeleteTransaction(context.Background(), userIdTest, userIdTest)
 			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
@@ -306,7 +361,7 @@ func TestUsecase_GetTransactionForExport(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayee.NewMockUsecase(ctrl), mockAccount.NewMockRepository(ctrl), mockAnomaly.NewMockUsecase(ctrl))
 
 			userID := uuid.New()
 			query := &models.QueryListOptions{}
@@ -320,3 +375,131 @@ func TestUsecase_GetTransactionForExport(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_AccountRoles(t *testing.T) {
+	userID := uuid.New()
+	cardID := uuid.New()
+	savingsID := uuid.New()
+
+	spending := models.Transaction{UserID: userID, AccountIncomeID: cardID, AccountOutcomeID: cardID, Outcome: 300, Payer: "Shop"}
+	salary := models.Transaction{UserID: userID, AccountIncomeID: cardID, AccountOutcomeID: cardID, Income: 5000, Payer: "Work"}
+	transfer := models.Transaction{UserID: userID, AccountIncomeID: savingsID, AccountOutcomeID: cardID, Income: 1000, Outcome: 1000}
+
+	testCases := []struct {
+		name        string
+		transaction models.Transaction
+		roles       map[uuid.UUID]models.AccountRole
+		forbidden   bool
+	}{
+		{
+			name:        "Contributor adds outcome",
+			transaction: spending,
+			roles:       map[uuid.UUID]models.AccountRole{cardID: models.AccountContributor},
+			forbidden:   false,
+		},
+		{
+			name:        "Contributor adds income",
+			transaction: salary,
+			roles:       map[uuid.UUID]models.AccountRole{cardID: models.AccountContributor},
+			forbidden:   true,
+		},
+		{
+			name:        "Viewer adds outcome",
+			transaction: spending,
+			roles:       map[uuid.UUID]models.AccountRole{cardID: models.AccountViewer},
+			forbidden:   true,
+		},
+		{
+			name:        "Contributor transfers to the own account",
+			transaction: transfer,
+			roles:       map[uuid.UUID]models.AccountRole{savingsID: models.AccountOwner, cardID: models.AccountContributor},
+			forbidden:   false,
+		},
+		{
+			name:        "Transfer to a viewed account",
+			transaction: transfer,
+			roles:       map[uuid.UUID]models.AccountRole{savingsID: models.AccountViewer},
+			forbidden:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepository(ctrl)
+			mockAccountRepo := mockAccount.NewMockRepository(ctrl)
+			mockPayeeService := mockPayee.NewMockUsecase(ctrl)
+
+			mockAccountRepo.EXPECT().GetRole(gomock.Any(), gomock.Any(), userID).
+				DoAndReturn(func(_ context.Context, accountID uuid.UUID, _ uuid.UUID) (models.AccountRole, error) {
+					return tc.roles[accountID], nil
+				}).AnyTimes()
+			mockAccountRepo.EXPECT().IsArchived(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+			if !tc.forbidden {
+				mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), userID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(uuid.New(), nil, nil)
+			}
+
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayeeService, mockAccountRepo, mockAnomaly.NewMockUsecase(ctrl))
+
+			transaction := tc.transaction
+			_, err := mockUsecase.CreateTransaction(context.Background(), &transaction)
+
+			var errForbiddenUser *models.ForbiddenUserError
+			assert.Equal(t, tc.forbidden, errors.As(err, &errForbiddenUser))
+		})
+	}
+}
+
+func TestUsecase_EditSharedTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAccountRepo := mockAccount.NewMockRepository(ctrl)
+	mockPayeeService := mockPayee.NewMockUsecase(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayeeService, mockAccountRepo, mockAnomaly.NewMockUsecase(ctrl))
+
+	editorID := uuid.New()
+	contributorID := uuid.New()
+	accountID := uuid.New()
+	// added by the owner of the account
+	existing := &models.Transaction{ID: uuid.New(), UserID: uuid.New(), AccountIncomeID: accountID, AccountOutcomeID: accountID, Outcome: 100}
+
+	mockRepo.EXPECT().CheckForbidden(gomock.Any(), existing.ID).Return(existing, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, editorID).Return(models.AccountEditor, nil).Times(2)
+	mockAccountRepo.EXPECT().IsArchived(gomock.Any(), accountID).Return(false, nil).Times(2)
+	mockPayeeService.EXPECT().ResolvePayee(gomock.Any(), editorID, gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
+	err := mockUsecase.UpdateTransaction(context.Background(),
+		&models.Transaction{ID: existing.ID, UserID: editorID, AccountIncomeID: accountID, AccountOutcomeID: accountID, Outcome: 120})
+	assert.NoError(t, err)
+
+	mockRepo.EXPECT().CheckForbidden(gomock.Any(), existing.ID).Return(existing, nil)
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, contributorID).Return(models.AccountContributor, nil)
+	err = mockUsecase.DeleteTransaction(context.Background(), existing.ID, contributorID)
+	var errForbiddenUser *models.ForbiddenUserError
+	assert.ErrorAs(t, err, &errForbiddenUser)
+}
+
+func TestUsecase_ArchivedAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockAccountRepo := mockAccount.NewMockRepository(ctrl)
+	mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockPayee.NewMockUsecase(ctrl), mockAccountRepo, mockAnomaly.NewMockUsecase(ctrl))
+
+	userID := uuid.New()
+	accountID := uuid.New()
+
+	mockAccountRepo.EXPECT().GetRole(gomock.Any(), accountID, userID).Return(models.AccountOwner, nil)
+	mockAccountRepo.EXPECT().IsArchived(gomock.Any(), accountID).Return(true, nil)
+	_, err := mockUsecase.CreateTransaction(context.Background(),
+		&models.Transaction{UserID: userID, AccountIncomeID: accountID, AccountOutcomeID: accountID, Outcome: 100})
+
+	var errAccountOperation *models.AccountOperationError
+	assert.ErrorAs(t, err, &errAccountOperation)
+}
@@ -11,6 +11,8 @@ import (
 
 	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	genAccount "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/grpc/generated"
+	account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/http"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/delivery/http/transfer_models"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
@@ -18,17 +20,20 @@ import (
 )
 
 const (
-	accountID = "account_id"
+	accountID  = "account_id"
+	transferID = "transfer_id"
 )
 
 type Handler struct {
 	userService user.Usecase
+	client      genAccount.AccountServiceClient
 	logger      logger.Logger
 }
 
-func NewHandler(uu user.Usecase, l logger.Logger) *Handler {
+func NewHandler(uu user.Usecase, client genAccount.AccountServiceClient, l logger.Logger) *Handler {
 	return &Handler{
 		userService: uu,
+		client:      client,
 		logger:      l,
 	}
 }
@@ -158,44 +163,6 @@ func (h *Handler) GetCurrentBudget(w http.ResponseWriter, r *http.Request) {
 	commonHttp.SuccessResponse[transfer_models.BudgetActualResponse](w, http.StatusOK, response)
 }
 
-// @Summary		Get User Accounts
-// @Tags			User
-// @Description	Get User accounts
-// @Produce		json
-// @Success		200		{object}	Response[transfer_models.Account]	     	"Show actual accounts"
-// @Success		204		{object}	Response[string]	     	"Show actual accounts"
-// @Failure		400		{object}	ResponseError		"Client error"
-// @Failure     401    	{object}  	ResponseError  		"Unauthorized user"
-// @Failure     403    	{object}  	ResponseError  		"Forbidden user"
-// @Failure		500		{object}	ResponseError		"Server error"
-// @Router		/api/user/accounts/all [get]
-func (h *Handler) GetAccounts(w http.ResponseWriter, r *http.Request) {
-	user, err := commonHttp.GetUserFromRequest(r)
-
-	if err != nil {
-		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
-		return
-	}
-
-	accountInfo, err := h.userService.GetAccounts(r.Context(), user.ID)
-
-	var errNoSuchAccounts *models.NoSuchAccounts
-
-	if errors.As(err, &errNoSuchAccounts) {
-		h.logger.Info(err.Error())
-		commonHttp.SuccessResponse(w, http.StatusNoContent, "")
-		return
-	}
-
-	if err != nil {
-		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, transfer_models.AccountNotFound, h.logger)
-		return
-	}
-
-	response := transfer_models.Account{AccountMas: accountInfo}
-	commonHttp.SuccessResponse[transfer_models.Account](w, http.StatusOK, response)
-}
-
 // @Summary		Get Feed
 // @Tags			User
 // @Description	Get Feed user info
@@ -218,10 +185,8 @@ func (h *Handler) GetFeed(w http.ResponseWriter, r *http.Request) {
 
 	var errNoSuchPlannedBudgetError *models.NoSuchPlannedBudgetError
 	var errNoSuchUserIdBalanceError *models.NoSuchUserIdBalanceError
-	var errNoSuchAccounts *models.NoSuchAccounts
 
-	if errors.As(err, &errNoSuchAccounts) ||
-		errors.As(err, &errNoSuchPlannedBudgetError) ||
+	if errors.As(err, &errNoSuchPlannedBudgetError) ||
 		errors.As(err, &errNoSuchUserIdBalanceError) {
 
 		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, transfer_models.UserFeedNotFound, h.logger)
@@ -233,6 +198,13 @@ func (h *Handler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	accounts, err := h.client.List(r.Context(), &genAccount.ListRequest{UserId: user.ID.String()})
+	if err != nil {
+		commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, transfer_models.UserFeedServerError, h.logger)
+		return
+	}
+	dataFeed.AccountMas = account.AccountsFromProto(accounts.Accounts)
+
 	commonHttp.SuccessResponse(w, http.StatusOK, dataFeed)
 }
 
@@ -370,141 +342,3 @@ func (h *Handler) UpdatePhoto(w http.ResponseWriter, r *http.Request) { // need
 
 	commonHttp.SuccessResponse(w, http.StatusOK, transfer_models.PhotoUpdate{Path: name})
 }
-
-// @Summary		POST 	Add User in Account
-// @Tags				User
-// @Description	Post 	User
-// @Produce		json
-// @Param		User	body		models.AddUserAccount		 true		    "Add user in account"
-// @Success		200		{object}	Response[NilBody]				    "Update account"
-// @Failure		400		{object}	ResponseError						"Client error"
-// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
-// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
-// @Failure     404    	{object}  	ResponseError  						"No user found with this login"
-// @Failure		500		{object}	ResponseError						"Server error"
-// @Router		/api/user/addUserInAccount [post]
-func (h *Handler) AddUserInAccount(w http.ResponseWriter, r *http.Request) {
-	user, err := commonHttp.GetUserFromRequest(r)
-
-	if err != nil {
-		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
-		return
-	}
-
-	var accountInput models.AddUserAccount
-
-	if err := easyjson.UnmarshalFromReader(r.Body, &accountInput); err != nil {
-		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
-		return
-	}
-
-	if err := h.userService.AddUserInAccount(r.Context(), accountInput, user.ID); err != nil {
-		var errNoSuchUser *models.NoSuchUserInLogin
-		if errors.As(err, &errNoSuchUser) {
-			commonHttp.ErrorResponse(w, http.StatusNotFound, err, transfer_models.UserNotFoundLogin, h.logger)
-			return
-		}
-
-		var errForbiddenUser *models.ForbiddenUserError
-		if errors.As(err, &errForbiddenUser) {
-			commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
-			return
-		}
-
-		var errDuplicate *models.DuplicateError
-		if errors.As(err, &errDuplicate) {
-			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, transfer_models.UserDuplicate, h.logger)
-			return
-		}
-
-		if err != nil {
-			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, transfer_models.UserServerError, h.logger)
-			return
-		}
-	}
-
-	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
-}
-
-// @Summary		PUT 	Unsibscribe in Account
-// @Tags				User
-// @Description	Post 	User
-// @Produce		json
-// @Success		200		{object}	Response[NilBody]				    "Unsibscribe in Account"
-// @Failure		400		{object}	ResponseError						"Client error"
-// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
-// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
-// @Failure		500		{object}	ResponseError						"Server error"
-// @Router		/api/user/unsubscribeAccount/{account_id} [put]
-func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
-	accountID, err := commonHttp.GetIDFromRequest(accountID, r)
-
-	if err != nil {
-		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidURLParameter, h.logger)
-		return
-	}
-	user, err := commonHttp.GetUserFromRequest(r)
-
-	if err != nil {
-		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
-		return
-	}
-
-	if err := h.userService.Unsubscribe(r.Context(), accountID, user.ID); err != nil {
-		if err != nil {
-			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, transfer_models.UserServerError, h.logger)
-			return
-		}
-	}
-
-	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
-
-}
-
-// @Summary		DELETE 	Delete user in Account
-// @Tags				User
-// @Description	Post 	User
-// @Produce		json
-// @Param		User	body		models.DeleteInAccount		 true		    "Delete user in Account"
-// @Success		200		{object}	Response[NilBody]				    "Unsibscribe in Account"
-// @Failure		400		{object}	ResponseError						"Client error"
-// @Failure     401    	{object}  	ResponseError  						"Unauthorized user"
-// @Failure     403    	{object}  	ResponseError  						"Forbidden user"
-// @Failure		500		{object}	ResponseError						"Server error"
-// @Router		/api/user/deleteUserInAccount [delete]
-func (h *Handler) DeleteUserInAccount(w http.ResponseWriter, r *http.Request) {
-	user, err := commonHttp.GetUserFromRequest(r)
-
-	if err != nil {
-		commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), h.logger)
-		return
-	}
-
-	var accountDelete models.DeleteInAccount
-
-	if err := easyjson.UnmarshalFromReader(r.Body, &accountDelete); err != nil {
-		commonHttp.ErrorResponse(w, http.StatusBadRequest, err, commonHttp.InvalidBodyRequest, h.logger)
-		return
-	}
-
-	if err := h.userService.DeleteUserInAccount(r.Context(), accountDelete.UserID, accountDelete.AccountID, user.ID); err != nil {
-		var errNoSuchUser *models.NoSuchUserInLogin
-		if errors.As(err, &errNoSuchUser) {
-			commonHttp.ErrorResponse(w, http.StatusBadRequest, err, transfer_models.UserNotFound, h.logger)
-			return
-		}
-
-		var errForbiddenUser *models.ForbiddenUserError
-		if errors.As(err, &errForbiddenUser) {
-			commonHttp.ErrorResponse(w, http.StatusForbidden, err, commonHttp.ForbiddenUser, h.logger)
-			return
-		}
-
-		if err != nil {
-			commonHttp.ErrorResponse(w, http.StatusInternalServerError, err, transfer_models.UserServerError, h.logger)
-			return
-		}
-	}
-
-	commonHttp.SuccessResponse(w, http.StatusOK, commonHttp.NilBody{})
-}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/gorilla/mux"
 	"image"
 	"image/color"
 	"image/png"
@@ -17,6 +16,8 @@ import (
 	"testing"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	genAccount "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/delivery/grpc/generated"
+	mockAccount "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/delivery/http/transfer_models"
 	mocks "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/mocks"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
@@ -102,7 +103,7 @@ func TestHandler_GetUserBalance(t *testing.T) {
 			mockUsecase := mocks.NewMockUsecase(ctrl)
 			tt.mockUsecaseFn(mockUsecase)
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, nil, *logger.NewLogger(context.TODO()))
 
 			url := "/api/user/" + user.ID.String() + "/balance"
 			req := httptest.NewRequest("GET", url, nil)
@@ -196,7 +197,7 @@ func TestHandler_GetPlannedBudget(t *testing.T) {
 			mockUsecase := mocks.NewMockUsecase(ctrl)
 			tt.mockUsecaseFn(mockUsecase)
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, nil, *logger.NewLogger(context.TODO()))
 
 			url := "/api/user/" + user.ID.String() + "/planned-budget"
 			req := httptest.NewRequest("GET", url, nil)
@@ -275,7 +276,7 @@ func TestHandler_GetCurrentBudget(t *testing.T) {
 			mockUsecase := mocks.NewMockUsecase(ctrl)
 			tt.mockUsecaseFn(mockUsecase)
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, nil, *logger.NewLogger(context.TODO()))
 
 			url := "/api/user/" + tt.userID + "/current_budget"
 			req := httptest.NewRequest("GET", url, nil)
@@ -295,7 +296,7 @@ func TestHandler_GetCurrentBudget(t *testing.T) {
 	}
 }
 
-func TestHandler_GetAccounts(t *testing.T) {
+func TestHandler_GetFeed(t *testing.T) {
 	uuidTest := uuid.New()
 	user := &models.User{ID: uuidTest}
 	tests := []struct {
@@ -305,109 +306,37 @@ func TestHandler_GetAccounts(t *testing.T) {
 		funcCtxUser   func(*models.User, context.Context) context.Context
 		expectedBody  string
 		mockUsecaseFn func(*mocks.MockUsecase)
+		mockClientFn  func(*mockAccount.MockAccountServiceClient)
 	}{
 		{
-			name:         "Successful call to GetAccounts",
+			name:         "Successful call to GetFeed",
 			userID:       uuid.New().String(),
 			expectedCode: http.StatusOK,
 			funcCtxUser: func(user *models.User, ctx context.Context) context.Context {
 				return context.WithValue(ctx, models.ContextKeyUserType{}, user)
 			},
-			expectedBody: `{"status":200,"body":{"accounts":[]}}`,
+			expectedBody: `{"status":200,"body":{"accounts":[],"balance":0,"planned_budget":0,"actual_budget":0}}`,
 			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, nil)
-			},
-		},
-		{
-			name:         "Unauthorized Request",
-			userID:       "invalidUserID",
-			expectedCode: http.StatusUnauthorized,
-			funcCtxUser: func(user *models.User, ctx context.Context) context.Context {
-				return context.Background()
-			},
-			expectedBody: `{"status":401,"message":"unauthorized"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				// No expectations for mockUsecase.
-			},
-		},
-		{
-			name:         "No accounts found",
-			userID:       uuidTest.String(),
-			expectedCode: http.StatusNoContent,
-			funcCtxUser: func(user *models.User, ctx context.Context) context.Context {
-				return context.WithValue(ctx, models.ContextKeyUserType{}, user)
+				userFeed := &transfer_models.UserFeed{}
+				mockUsecase.EXPECT().GetFeed(gomock.Any(), gomock.Any()).Return(userFeed, nil)
 			},
-			expectedBody: `{"status":204,"body":""}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				expectedError := models.NoSuchAccounts{}
-				mockUsecase.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, &expectedError)
+			mockClientFn: func(mockClient *mockAccount.MockAccountServiceClient) {
+				mockClient.EXPECT().List(gomock.Any(), &genAccount.ListRequest{UserId: uuidTest.String()}).Return(&genAccount.ListResponse{}, nil)
 			},
 		},
 		{
-			name:         "Internal server error",
+			name:         "Account service error",
 			userID:       uuid.New().String(),
 			expectedCode: http.StatusInternalServerError,
 			funcCtxUser: func(user *models.User, ctx context.Context) context.Context {
 				return context.WithValue(ctx, models.ContextKeyUserType{}, user)
 			},
-			expectedBody: `{"status":500,"message":"no such account"}`,
+			expectedBody: `{"status":500,"message":"can't get feed info"}`,
 			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				internalError := errors.New("internal server error")
-				mockUsecase.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, internalError)
+				mockUsecase.EXPECT().GetFeed(gomock.Any(), gomock.Any()).Return(&transfer_models.UserFeed{}, nil)
 			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockUsecase := mocks.NewMockUsecase(ctrl)
-			tt.mockUsecaseFn(mockUsecase)
-
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
-
-			url := "/api/user/" + tt.userID + "/accounts"
-			req := httptest.NewRequest("GET", url, nil)
-			ctx := tt.funcCtxUser(user, req.Context())
-
-			req = req.WithContext(ctx)
-			recorder := httptest.NewRecorder()
-			req = req.WithContext(ctx)
-
-			mockHandler.GetAccounts(recorder, req)
-
-			actual := strings.TrimSpace(recorder.Body.String())
-
-			assert.Equal(t, tt.expectedCode, recorder.Code)
-			assert.Equal(t, tt.expectedBody, actual)
-		})
-	}
-}
-
-func TestHandler_GetFeed(t *testing.T) {
-	uuidTest := uuid.New()
-	user := &models.User{ID: uuidTest}
-	tests := []struct {
-		name          string
-		userID        string
-		expectedCode  int
-		funcCtxUser   func(*models.User, context.Context) context.Context
-		expectedBody  string
-		mockUsecaseFn func(*mocks.MockUsecase)
-	}{
-		{
-			name:         "Successful call to GetFeed",
-			userID:       uuid.New().String(),
-			expectedCode: http.StatusOK,
-			funcCtxUser: func(user *models.User, ctx context.Context) context.Context {
-				return context.WithValue(ctx, models.ContextKeyUserType{}, user)
-			},
-			expectedBody: `{"status":200,"body":{"accounts":null,"balance":0,"planned_budget":0,"actual_budget":0}}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				userFeed := &transfer_models.UserFeed{}
-				mockUsecase.EXPECT().GetFeed(gomock.Any(), gomock.Any()).Return(userFeed, nil)
+			mockClientFn: func(mockClient *mockAccount.MockAccountServiceClient) {
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
 			},
 		},
 		{
@@ -431,7 +360,7 @@ func TestHandler_GetFeed(t *testing.T) {
 			},
 			expectedBody: `{"status":400,"message":"no such feed info"}`,
 			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				expectedError := models.NoSuchAccounts{}
+				expectedError := models.NoSuchPlannedBudgetError{}
 				userFeed := &transfer_models.UserFeed{}
 				mockUsecase.EXPECT().GetFeed(gomock.Any(), gomock.Any()).Return(userFeed, &expectedError)
 			},
@@ -459,8 +388,12 @@ func TestHandler_GetFeed(t *testing.T) {
 
 			mockUsecase := mocks.NewMockUsecase(ctrl)
 			tt.mockUsecaseFn(mockUsecase)
+			mockClient := mockAccount.NewMockAccountServiceClient(ctrl)
+			if tt.mockClientFn != nil {
+				tt.mockClientFn(mockClient)
+			}
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, mockClient, *logger.NewLogger(context.TODO()))
 
 			url := "/api/user/" + tt.userID + "/accounts"
 			req := httptest.NewRequest("GET", url, nil)
@@ -515,7 +448,7 @@ func TestHandler_GetUser(t *testing.T) {
 
 			mockUsecase := mocks.NewMockUsecase(ctrl)
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, nil, *logger.NewLogger(context.TODO()))
 
 			url := "/api/user/" + tt.userID + "/accounts"
 			req := httptest.NewRequest("GET", url, nil)
@@ -629,7 +562,7 @@ func TestHandler_Update(t *testing.T) {
 			mockUsecase := mocks.NewMockUsecase(ctrl)
 			tt.mockUsecaseFn(mockUsecase)
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, nil, *logger.NewLogger(context.TODO()))
 
 			url := "/api/user/update"
 			req := httptest.NewRequest("PUT", url, tt.requestBody)
@@ -1036,7 +969,7 @@ func TestUserHandler_UpdateProfilePhoto(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 
-			mockHandler := NewHandler(mockUsecase, *logger.NewLogger(context.TODO()))
+			mockHandler := NewHandler(mockUsecase, nil, *logger.NewLogger(context.TODO()))
 
 			w := httptest.NewRecorder()
 			r := test.mock()
@@ -1049,325 +982,3 @@ func TestUserHandler_UpdateProfilePhoto(t *testing.T) {
 		})
 	}
 }
-
-func TestHandler_AddUserInAccount(t *testing.T) {
-	uuidTest := uuid.New()
-	user := &models.User{ID: uuidTest}
-
-	tests := []struct {
-		name          string
-		user          *models.User
-		requestBody   io.Reader
-		expectedCode  int
-		expectedBody  string
-		mockUsecaseFn func(*mocks.MockUsecase)
-	}{
-		{
-			name:          "Invalid JSON body",
-			user:          user,
-			requestBody:   strings.NewReader("invalid json data"),
-			expectedCode:  http.StatusBadRequest,
-			expectedBody:  `{"status":400,"message":"invalid input body"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
-		},
-		{
-			name: "Successful Add User in Account",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"field1": "value1",
-				"field2": "value2"
-			}`),
-			expectedCode: http.StatusOK,
-			expectedBody: `{"status":200,"body":{}}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().AddUserInAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			},
-		},
-		{
-			name: "Unauthorized Request",
-			user: nil,
-			requestBody: strings.NewReader(`{
-				"field1": "value1",
-				"field2": "value2"
-			}`),
-			expectedCode:  http.StatusUnauthorized,
-			expectedBody:  `{"status":401,"message":"unauthorized"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
-		},
-		{
-			name: "Error adding user in account - Not Found",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"field1": "value1",
-				"field2": "value2"
-			}`),
-			expectedCode: http.StatusNotFound,
-			expectedBody: `{"status":404,"message":"no user found with this login"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().AddUserInAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.NoSuchUserInLogin{})
-			},
-		},
-		{
-			name: "Error adding user in account - Forbidden",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"field1": "value1",
-				"field2": "value2"
-			}`),
-			expectedCode: http.StatusForbidden,
-			expectedBody: `{"status":403,"message":"user has no rights"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().AddUserInAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.ForbiddenUserError{})
-			},
-		},
-		{
-			name: "Error adding user in account - Duplicate",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"field1": "value1",
-				"field2": "value2"
-			}`),
-			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"status":400,"message":"this user has already been added to the account"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().AddUserInAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.DuplicateError{})
-			},
-		},
-		{
-			name: "Error adding user in account - Internal Server Error",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"field1": "value1",
-				"field2": "value2"
-			}`),
-			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"status":500,"message":"can't get user"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().AddUserInAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal server error"))
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockService := mocks.NewMockUsecase(ctrl)
-			tt.mockUsecaseFn(mockService)
-
-			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
-
-			req := httptest.NewRequest("POST", "/api/user/add", tt.requestBody)
-
-			if tt.user != nil {
-				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
-				req = req.WithContext(ctx)
-			}
-
-			recorder := httptest.NewRecorder()
-
-			mockHandler.AddUserInAccount(recorder, req)
-
-			actual := strings.TrimSpace(recorder.Body.String())
-
-			assert.Equal(t, tt.expectedCode, recorder.Code)
-			assert.Equal(t, tt.expectedBody, actual)
-		})
-	}
-}
-
-func TestHandler_Unsubscribe(t *testing.T) {
-	uuidTest := uuid.New()
-	user := &models.User{ID: uuidTest}
-	//accountID := uuid.New()
-
-	tests := []struct {
-		name          string
-		user          *models.User
-		accountID     string
-		expectedCode  int
-		expectedBody  string
-		mockUsecaseFn func(*mocks.MockUsecase)
-	}{
-		{
-			name:          "Invalid URL parameter",
-			user:          user,
-			accountID:     uuid.Nil.String(),
-			expectedCode:  http.StatusBadRequest,
-			expectedBody:  `{"status":400,"message":"invalid url parameter"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
-		},
-		{
-			name:          "Unauthorized Request",
-			user:          nil,
-			accountID:     "account_id",
-			expectedCode:  http.StatusUnauthorized,
-			expectedBody:  `{"status":401,"message":"unauthorized"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
-		},
-		{
-			name:         "Successful Unsubscribe",
-			user:         user,
-			accountID:    "account_id",
-			expectedCode: http.StatusOK,
-			expectedBody: `{"status":200,"body":{}}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			},
-		},
-		{
-			name:         "Error unsubscribing user - Internal Server Error",
-			user:         user,
-			accountID:    "account_id",
-			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"status":500,"message":"can't get user"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().Unsubscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal server error"))
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockService := mocks.NewMockUsecase(ctrl)
-			tt.mockUsecaseFn(mockService)
-
-			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
-
-			url := "/api/account/delete"
-			req := httptest.NewRequest("GET", url, nil)
-			req = mux.SetURLVars(req, map[string]string{tt.accountID: uuidTest.String()})
-
-			// Create a new request with the specified URL
-
-			if tt.user != nil {
-				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
-				req = req.WithContext(ctx)
-			}
-
-			recorder := httptest.NewRecorder()
-
-			mockHandler.Unsubscribe(recorder, req)
-			actual := strings.TrimSpace(recorder.Body.String())
-
-			assert.Equal(t, tt.expectedCode, recorder.Code)
-			assert.Equal(t, tt.expectedBody, actual)
-		})
-	}
-}
-
-func TestHandler_DeleteUserInAccount(t *testing.T) {
-	uuidTest := uuid.New()
-	user := &models.User{ID: uuidTest}
-	accountID := uuid.New()
-
-	tests := []struct {
-		name          string
-		user          *models.User
-		requestBody   io.Reader
-		expectedCode  int
-		expectedBody  string
-		mockUsecaseFn func(*mocks.MockUsecase)
-	}{
-		{
-			name:          "Unauthorized Request",
-			user:          nil,
-			requestBody:   strings.NewReader(`{"user_id": "testuser", "account_id": "account123"}`),
-			expectedCode:  http.StatusUnauthorized,
-			expectedBody:  `{"status":401,"message":"unauthorized"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
-		},
-		{
-			name: "Successful User Deletion in Account",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"user_id": "` + user.ID.String() + `",
-				"account_id": "` + accountID.String() + `"
-			}`),
-			expectedCode: http.StatusOK,
-			expectedBody: `{"status":200,"body":{}}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().DeleteUserInAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			},
-		},
-		{
-			name: "User Not Found - Bad Request",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"user_id": "` + uuid.New().String() + `",
-				"account_id": "` + accountID.String() + `"
-			}`),
-			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"status":400,"message":"no such user"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().DeleteUserInAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.NoSuchUserInLogin{})
-			},
-		},
-		{
-			name: "User Not Found - Bad Request",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"user_id": "` + uuid.New().String() + `",
-				"account_id": "` + accountID.String() + `"
-			}`),
-			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"status":500,"message":"can't get user"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().DeleteUserInAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some"))
-			},
-		},
-		{
-			name: "Forbidden User - Forbidden",
-			user: user,
-			requestBody: strings.NewReader(`{
-				"user_id": "` + user.ID.String() + `",
-				"account_id": "` + uuid.New().String() + `"
-			}`),
-			expectedCode: http.StatusForbidden,
-			expectedBody: `{"status":403,"message":"user has no rights"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {
-				mockUsecase.EXPECT().DeleteUserInAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.ForbiddenUserError{})
-			},
-		},
-		{
-			name:          "Invalid JSON body",
-			user:          user,
-			requestBody:   strings.NewReader("invalid json data"),
-			expectedCode:  http.StatusBadRequest,
-			expectedBody:  `{"status":400,"message":"invalid input body"}`,
-			mockUsecaseFn: func(mockUsecase *mocks.MockUsecase) {},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockService := mocks.NewMockUsecase(ctrl)
-			tt.mockUsecaseFn(mockService)
-
-			mockHandler := NewHandler(mockService, *logger.NewLogger(context.TODO()))
-
-			req := httptest.NewRequest("DELETE", "/api/user/delete", tt.requestBody)
-
-			if tt.user != nil {
-				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tt.user)
-				req = req.WithContext(ctx)
-			}
-
-			recorder := httptest.NewRecorder()
-
-			mockHandler.DeleteUserInAccount(recorder, req)
-
-			actual := strings.TrimSpace(recorder.Body.String())
-
-			assert.Equal(t, tt.expectedCode, recorder.Code)
-			assert.Equal(t, tt.expectedBody, actual)
-		})
-	}
-}
@@ -37,49 +37,6 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
-// AddUserInAccount mocks base method.
-func (m *MockUsecase) AddUserInAccount(ctx context.Context, accountInput models.AddUserAccount, adminID uuid.UUID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUserInAccount", ctx, accountInput, adminID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// AddUserInAccount indicates an expected call of AddUserInAccount.
-func (mr *MockUsecaseMockRecorder) AddUserInAccount(ctx, accountInput, adminID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserInAccount", reflect.TypeOf((*MockUsecase)(nil).AddUserInAccount), ctx, accountInput, adminID)
-}
-
-// DeleteUserInAccount mocks base method.
-func (m *MockUsecase) DeleteUserInAccount(ctx context.Context, userID, accountID, adminID uuid.UUID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserInAccount", ctx, userID, accountID, adminID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteUserInAccount indicates an expected call of DeleteUserInAccount.
-func (mr *MockUsecaseMockRecorder) DeleteUserInAccount(ctx, userID, accountID, adminID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserInAccount", reflect.TypeOf((*MockUsecase)(nil).DeleteUserInAccount), ctx, userID, accountID, adminID)
-}
-
-// GetAccounts mocks base method.
-func (m *MockUsecase) GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.Accounts, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccounts", ctx, userID)
-	ret0, _ := ret[0].([]models.Accounts)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetAccounts indicates an expected call of GetAccounts.
-func (mr *MockUsecaseMockRecorder) GetAccounts(ctx, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockUsecase)(nil).GetAccounts), ctx, userID)
-}
-
 // GetCurrentBudget mocks base method.
 func (m *MockUsecase) GetCurrentBudget(ctx context.Context, userID uuid.UUID) (float64, error) {
 	m.ctrl.T.Helper()
@@ -140,20 +97,6 @@ func (mr *MockUsecaseMockRecorder) GetUserBalance(ctx, userID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockUsecase)(nil).GetUserBalance), ctx, userID)
 }
 
-// Unsubscribe mocks base method.
-func (m *MockUsecase) Unsubscribe(ctx context.Context, accountID, userID uuid.UUID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Unsubscribe", ctx, accountID, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Unsubscribe indicates an expected call of Unsubscribe.
-func (mr *MockUsecaseMockRecorder) Unsubscribe(ctx, accountID, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockUsecase)(nil).Unsubscribe), ctx, accountID, userID)
-}
-
 // UpdatePhoto mocks base method.
 func (m *MockUsecase) UpdatePhoto(ctx context.Context, usserID uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -221,21 +164,6 @@ func (mr *MockRepositoryMockRecorder) CreateUser(ctx, user interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), ctx, user)
 }
 
-// GetAccounts mocks base method.
-func (m *MockRepository) GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.Accounts, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccounts", ctx, userID)
-	ret0, _ := ret[0].([]models.Accounts)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetAccounts indicates an expected call of GetAccounts.
-func (mr *MockRepositoryMockRecorder) GetAccounts(ctx, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockRepository)(nil).GetAccounts), ctx, userID)
-}
-
 // GetByID mocks base method.
 func (m *MockRepository) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -281,6 +209,21 @@ func (mr *MockRepositoryMockRecorder) GetPlannedBudget(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlannedBudget", reflect.TypeOf((*MockRepository)(nil).GetPlannedBudget), ctx, userID)
 }
 
+// GetSharingUsers mocks base method.
+func (m *MockRepository) GetSharingUsers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharingUsers", ctx, accountID)
+	ret0, _ := ret[0].([]models.SharingUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharingUsers indicates an expected call of GetSharingUsers.
+func (mr *MockRepositoryMockRecorder) GetSharingUsers(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharingUsers", reflect.TypeOf((*MockRepository)(nil).GetSharingUsers), ctx, accountID)
+}
+
 // GetUserBalance mocks base method.
 func (m *MockRepository) GetUserBalance(ctx context.Context, userID uuid.UUID) (float64, error) {
 	m.ctrl.T.Helper()
@@ -17,7 +17,8 @@ const (
 	SharingUserGet = `SELECT
 						u.id,
 						u.login,
-						u.avatar_url
+						u.avatar_url,
+						ua.role
 					FROM Users u
 					JOIN UserAccount ua ON u.id = ua.user_id
 					WHERE ua.account_id = $1;`
@@ -33,20 +34,16 @@ const (
 							FROM Accounts a
 							JOIN UserAccount ua ON a.id = ua.account_id
 							WHERE ua.user_id = $1 
-							AND a.balance_enabled = true;` // TODO: move accounts
-
-	AccountGet = `SELECT a.*
-				  FROM Accounts a
-				  JOIN UserAccount ua ON a.id = ua.account_id
-				  WHERE ua.user_id = $1` // TODO: move accounts
+							AND a.balance_enabled = true
+							AND a.archived_at IS NULL;` // TODO: move accounts
 
+	// daily totals hold spending only, the zero category row is the total of the day
 	ActualBudgetCalculation = `SELECT SUM(outcome) AS total_sum
-								FROM transaction
-								WHERE date_part('month', date) = date_part('month', CURRENT_DATE)
-								AND date_part('year', date) = date_part('year', CURRENT_DATE)
-								AND outcome > 0
-								AND account_income = account_outcome
-								AND user_id = $1;`
+								FROM DailyTotals
+								WHERE user_id = $1
+								AND category_id = '00000000-0000-0000-0000-000000000000'
+								AND day >= date_trunc('month', CURRENT_DATE)
+								AND day < date_trunc('month', CURRENT_DATE) + interval '1 month';`
 )
 
 type UserRep struct {
@@ -150,50 +147,8 @@ func (r *UserRep) GetCurrentBudget(ctx context.Context, userID uuid.UUID) (float
 	return 0, nil
 }
 
-func (r *UserRep) GetAccounts(ctx context.Context, user_id uuid.UUID) ([]models.Accounts, error) {
-	var accounts []models.Accounts
-
-	rows, err := r.db.Query(ctx, AccountGet, user_id)
-	if err != nil {
-		return nil, fmt.Errorf("[repo] %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var account models.Accounts
-
-		if err := rows.Scan(
-			&account.ID,
-			&account.Balance,
-			&account.SharingID,
-			&account.Accumulation,
-			&account.BalanceEnabled,
-			&account.MeanPayment,
-		); err != nil {
-			return nil, fmt.Errorf("[repo] %w", err)
-		}
-
-		sharingUsers, err := r.getSharingUsers(ctx, account.ID)
-		if err != nil {
-			return nil, fmt.Errorf("[repo] %w", err)
-		}
-
-		account.Users = sharingUsers
-		accounts = append(accounts, account)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("[repo] %w", err)
-	}
-
-	if len(accounts) == 0 {
-		return nil, fmt.Errorf("[repo] %w: %v", &models.NoSuchAccounts{UserID: user_id}, err)
-	}
-
-	return accounts, nil
-}
-
-func (r *UserRep) getSharingUsers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error) {
+// GetSharingUsers returns the members of the account
+func (r *UserRep) GetSharingUsers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error) {
 	var sharingUsers []models.SharingUser
 
 	rows, err := r.db.Query(ctx, SharingUserGet, accountID)
@@ -209,6 +164,7 @@ func (r *UserRep) getSharingUsers(ctx context.Context, accountID uuid.UUID) ([]m
 			&sharingUser.ID,
 			&sharingUser.Login,
 			&sharingUser.AvatarURL,
+			&sharingUser.Role,
 		); err != nil {
 			return nil, fmt.Errorf("[repo] %w", err)
 		}
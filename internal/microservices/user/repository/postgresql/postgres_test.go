@@ -415,13 +415,7 @@ func TestGetCurrentBudget(t *testing.T) {
 			logger := *logger.NewLogger(context.TODO())
 			repo := NewRepository(mock, logger)
 
-			escapedQuery := regexp.QuoteMeta(`SELECT SUM(outcome) AS total_sum
-			FROM transaction
-			WHERE date_part('month', date) = date_part('month', CURRENT_DATE)
-			  AND date_part('year', date) = date_part('year', CURRENT_DATE)
-			AND outcome > 0
-			AND account_income = account_outcome
-			AND user_id = $1;`)
+			escapedQuery := regexp.QuoteMeta(ActualBudgetCalculation)
 
 			mock.ExpectQuery(escapedQuery).
 				WithArgs(userID).
@@ -445,104 +439,6 @@ func TestGetCurrentBudget(t *testing.T) {
 	}
 }
 
-// func TestGetAccounts(t *testing.T) {
-// 	userID := uuid.New()
-// 	tests := []struct {
-// 		name     string
-// 		rows     *pgxmock.Rows
-// 		err      error
-// 		rowsErr  error
-// 		expected []models.Accounts
-// 	}{
-// 		{
-// 			name: "ValidAccounts",
-// 			rows: pgxmock.NewRows([]string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment"}).
-// 				AddRow(userID, 100.0, userID, true, false, "Кошелек").
-// 				AddRow(userID, 200.0, userID, true, false, "Наличка"),
-// 			err: nil,
-// 			expected: []models.Accounts{
-// 				{
-// 					ID:             userID,
-// 					Balance:        100.0,
-// 					SharingID:      userID,
-// 					Accumulation:   true,
-// 					BalanceEnabled: false,
-// 					MeanPayment:    "Кошелек",
-// 				},
-// 				{
-// 					ID:             userID,
-// 					Balance:        200.0,
-// 					SharingID:      userID,
-// 					Accumulation:   true,
-// 					BalanceEnabled: false,
-// 					MeanPayment:    "Наличка",
-// 				},
-// 			},
-// 		},
-// 		{
-// 			name: "ValidAccounts",
-// 			rows: pgxmock.NewRows([]string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment"}).
-// 				AddRow("fff", 100.0, userID, true, false, "Кошелек").
-// 				AddRow(userID, 200.0, userID, true, false, "Наличка"),
-// 			err:      fmt.Errorf("[repo] Scanning value error for column 'id': Scan: invalid UUID length: 3"),
-// 			expected: nil,
-// 		},
-// 		{
-// 			name:     "NoAccountsFound",
-// 			rows:     pgxmock.NewRows([]string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment"}),
-// 			rowsErr:  nil,
-// 			err:      fmt.Errorf("[repo] No Such Accounts from user: %s doesn't exist: <nil>", userID.String()),
-// 			expected: nil,
-// 		},
-// 		{
-// 			name:     "Rows error",
-// 			rows:     pgxmock.NewRows([]string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment"}).RowError(0, errors.New("err")),
-// 			rowsErr:  nil,
-// 			err:      fmt.Errorf("[repo] %w", errors.New("err")),
-// 			expected: nil,
-// 		},
-// 		{
-// 			name:     "DatabaseError",
-// 			rows:     pgxmock.NewRows([]string{"id", "balance", "sharing_id", "accumulation", "balance_enabled", "mean_payment"}),
-// 			rowsErr:  errors.New("database error"),
-// 			err:      fmt.Errorf("[repo] %w", errors.New("database error")),
-// 			expected: nil,
-// 		},
-// 	}
-
-// 	for _, test := range tests {
-// 		t.Run(test.name, func(t *testing.T) {
-// 			mock, _ := pgxmock.NewPool()
-// 			ctl := gomock.NewController(t)
-// 			defer ctl.Finish()
-
-// 			logger := *logger.NewLogger(context.TODO())
-// 			repo := NewRepository(mock, logger)
-
-// 			escapedQuery := regexp.QuoteMeta(AccountGet)
-
-// 			mock.ExpectQuery(escapedQuery).
-// 				WithArgs(userID).
-// 				WillReturnRows(test.rows).
-// 				WillReturnError(test.rowsErr)
-
-// 			accounts, err := repo.GetAccounts(context.Background(), userID)
-
-// 			if !reflect.DeepEqual(test.expected, accounts) {
-// 				t.Errorf("Expected accounts: %+v, but got: %+v", test.expected, accounts)
-// 			}
-
-// 			if (test.err == nil && err != nil) || (test.err != nil && err == nil) || (test.err != nil && err != nil && test.err.Error() != err.Error()) {
-// 				t.Errorf("Expected error: %v, but got: %v", test.err, err)
-// 			}
-
-// 			if err := mock.ExpectationsWereMet(); err != nil {
-// 				t.Errorf("There were unfulfilled expectations: %s", err)
-// 			}
-// 		})
-// 	}
-// }
-
 // func TestCheckUser(t *testing.T) {
 // 	userID := uuid.New()
 // 	tests := []struct {
@@ -7,7 +7,6 @@ import (
 	"testing"
 
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
-	mock_account "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/account/mocks"
 	mock "github.com/go-park-mail-ru/2023_2_Hamster/internal/microservices/user/mocks"
 	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
 	"github.com/golang/mock/gomock"
@@ -47,9 +46,7 @@ func TestUsecase_GetUserBalance(t *testing.T) {
 
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
 			userID := uuid.New()
 
@@ -95,9 +92,7 @@ func TestUsecase_GetPlannedBudget(t *testing.T) {
 
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
 			userID := uuid.New()
 
@@ -111,60 +106,6 @@ func TestUsecase_GetPlannedBudget(t *testing.T) {
 	}
 }
 
-func TestUsecase_GetAccounts(t *testing.T) {
-	uuidTest := uuid.New()
-	testCases := []struct {
-		name             string
-		expectedAccounts []models.Accounts
-		expectedErr      error
-		mockRepoFn       func(*mock.MockRepository)
-	}{
-		{
-			name: "Successful accounts retrieval",
-			expectedAccounts: []models.Accounts{
-				{ID: uuidTest, Balance: 100.0, MeanPayment: "Account1"},
-				{ID: uuidTest, Balance: 200.0, MeanPayment: "Account2"},
-			},
-			expectedErr: nil,
-			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{
-					{ID: uuidTest, Balance: 100.0, MeanPayment: "Account1"},
-					{ID: uuidTest, Balance: 200.0, MeanPayment: "Account2"}}, nil)
-			},
-		},
-		{
-			name:             "Error in accounts retrieval",
-			expectedAccounts: nil,
-			expectedErr:      fmt.Errorf("[usecase] can't get accounts from repository some error"),
-			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
-			},
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockRepo := mock.NewMockRepository(ctrl)
-			tc.mockRepoFn(mockRepo)
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
-
-			userID := uuid.New()
-
-			accounts, err := mockUsecase.GetAccounts(context.Background(), userID)
-
-			assert.Equal(t, tc.expectedAccounts, accounts)
-			if (tc.expectedErr == nil && err != nil) || (tc.expectedErr != nil && err == nil) || (tc.expectedErr != nil && err != nil && tc.expectedErr.Error() != err.Error()) {
-				t.Errorf("Expected error: %v, but got: %v", tc.expectedErr, err)
-			}
-		})
-	}
-}
-
 func TestUsecase_GetCurrentBudget(t *testing.T) {
 	testCases := []struct {
 		name                  string
@@ -207,9 +148,7 @@ func TestUsecase_GetCurrentBudget(t *testing.T) {
 
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
 			userID := uuid.New()
 
@@ -299,16 +238,6 @@ func TestUsecase_GetFeed(t *testing.T) {
 				mockRepository.EXPECT().GetUserBalance(gomock.Any(), gomock.Any()).Return(0.0, errors.New("some erros"))
 			},
 		},
-		{
-			name: "Error getUser account",
-			//expectedFeed: &transfer_models.UserFeed{Account: []models.Accounts{}},
-			expectedErr: fmt.Errorf("[usecase] can't get accounts from repository some error"),
-			mockRepoFn: func(mockRepository *mock.MockRepository) {
-				mockRepository.EXPECT().GetUserBalance(gomock.Any(), gomock.Any()).Return(0.0, nil)
-				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, errors.New("some error"))
-
-			},
-		},
 		{
 			name: "Error in getUser planned budget",
 			//expectedFeed: &transfer_models.UserFeed{},
@@ -318,7 +247,6 @@ func TestUsecase_GetFeed(t *testing.T) {
 				//mockRepository.EXPECT().GetPlannedBudget(gomock.Any(), gomock.Any()).Return(0.0, nil)
 				mockRepository.EXPECT().GetPlannedBudget(gomock.Any(), gomock.Any()).Return(0.0, errors.New("err"))
 				//mockRepository.EXPECT().GetCurrentBudget(gomock.Any(), gomock.Any()).Return(0.0, nil)
-				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, nil)
 			},
 		},
 		{
@@ -329,7 +257,6 @@ func TestUsecase_GetFeed(t *testing.T) {
 				mockRepository.EXPECT().GetUserBalance(gomock.Any(), gomock.Any()).Return(0.0, nil)
 				mockRepository.EXPECT().GetPlannedBudget(gomock.Any(), gomock.Any()).Return(0.0, nil)
 				mockRepository.EXPECT().GetCurrentBudget(gomock.Any(), gomock.Any()).Return(0.0, errors.New("err"))
-				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, nil)
 			},
 		},
 		{
@@ -341,7 +268,6 @@ func TestUsecase_GetFeed(t *testing.T) {
 				mockRepository.EXPECT().GetPlannedBudget(gomock.Any(), gomock.Any()).Return(0.0, nil)
 				mockRepository.EXPECT().GetPlannedBudget(gomock.Any(), gomock.Any()).Return(0.0, nil)
 				mockRepository.EXPECT().GetCurrentBudget(gomock.Any(), gomock.Any()).Return(0.0, nil)
-				mockRepository.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Return([]models.Accounts{}, nil)
 			},
 		},
 	}
@@ -353,9 +279,7 @@ func TestUsecase_GetFeed(t *testing.T) {
 
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
 			_, err := mockUsecase.GetFeed(context.Background(), testUserID)
 
@@ -396,9 +320,7 @@ func TestUsecase_UpdateUser(t *testing.T) {
 
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
 			user := &models.User{}
 
@@ -441,9 +363,7 @@ func TestUsecase_UpdatePhoto(t *testing.T) {
 			mockRepo := mock.NewMockRepository(ctrl)
 			tc.mockRepoFn(mockRepo)
 
-			mockRepoa := mock_account.NewMockRepository(ctrl)
-
-			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()), mockRepoa)
+			mockUsecase := NewUsecase(mockRepo, *logger.NewLogger(context.TODO()))
 
 			userID := uuid.New()
 
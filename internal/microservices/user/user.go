@@ -14,14 +14,10 @@ type Usecase interface {
 	GetUserBalance(ctx context.Context, userID uuid.UUID) (float64, error)
 	GetPlannedBudget(ctx context.Context, userID uuid.UUID) (float64, error)
 	GetCurrentBudget(ctx context.Context, userID uuid.UUID) (float64, error)
-	GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.Accounts, error)
 	GetFeed(ctx context.Context, userID uuid.UUID) (*transfer_models.UserFeed, error)
 	//GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
 	UpdatePhoto(ctx context.Context, usserID uuid.UUID) (uuid.UUID, error)
-	AddUserInAccount(ctx context.Context, accountInput models.AddUserAccount, adminID uuid.UUID) error
-	Unsubscribe(ctx context.Context, accountID uuid.UUID, userID uuid.UUID) error
-	DeleteUserInAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID, adminID uuid.UUID) error
 }
 
 type Repository interface {
@@ -33,7 +29,7 @@ type Repository interface {
 	GetUserBalance(ctx context.Context, userID uuid.UUID) (float64, error) // TODO: transfer account repostiory
 	GetPlannedBudget(ctx context.Context, userID uuid.UUID) (float64, error)
 	GetCurrentBudget(ctx context.Context, userID uuid.UUID) (float64, error)
-	GetAccounts(ctx context.Context, userID uuid.UUID) ([]models.Accounts, error) // TODO: transfer account repository
+	GetSharingUsers(ctx context.Context, accountID uuid.UUID) ([]models.SharingUser, error)
 	// IncreaseUserVersion(ctx context.Context, ctx context.Context, userID uuid.UUID) error
 	UpdateUser(ctx context.Context, user *models.User) error
 	//CheckUser(ctx context.Context, userID uuid.UUID) error
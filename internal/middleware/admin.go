@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	commonHttp "github.com/go-park-mail-ru/2023_2_Hamster/internal/common/http"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/google/uuid"
+)
+
+var errNotAdmin = errors.New("user is not an admin")
+
+// AdminMiddleware lets through the users with the ids from the configuration,
+// it goes after the Authentication. Ids are given by the server, so unlike a login
+// an admin entry can't be taken by registering a free name
+type AdminMiddleware struct {
+	ids    map[uuid.UUID]struct{}
+	logger logger.Logger
+}
+
+// NewAdminMiddleware takes the comma separated admin user ids, an empty list closes the admin routes
+func NewAdminMiddleware(ids string, l logger.Logger) *AdminMiddleware {
+	m := &AdminMiddleware{
+		ids:    make(map[uuid.UUID]struct{}),
+		logger: l,
+	}
+
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			l.Errorf("[middleware] skipped admin id %q: %v", id, err)
+			continue
+		}
+		m.ids[userID] = struct{}{}
+	}
+	return m
+}
+
+func (m *AdminMiddleware) Admin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := commonHttp.GetUserFromRequest(r)
+		if err != nil {
+			commonHttp.ErrorResponse(w, http.StatusUnauthorized, err, commonHttp.ErrUnauthorized.Error(), m.logger)
+			return
+		}
+
+		if _, ok := m.ids[user.ID]; !ok {
+			commonHttp.ErrorResponse(w, http.StatusForbidden, errNotAdmin, commonHttp.ForbiddenUser, m.logger)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
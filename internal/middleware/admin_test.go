@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/common/logger"
+	"github.com/go-park-mail-ru/2023_2_Hamster/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin(t *testing.T) {
+	adminID := uuid.New()
+	rootID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		ids          string
+		user         *models.User
+		expectedCode int
+	}{
+		{
+			name:         "Admin",
+			ids:          rootID.String() + ", " + adminID.String(),
+			user:         &models.User{ID: adminID, Login: "hamster"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Not admin",
+			ids:          rootID.String() + "," + adminID.String(),
+			user:         &models.User{ID: uuid.New(), Login: "mouse"},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Login of an admin",
+			ids:          adminID.String(),
+			user:         &models.User{ID: uuid.New(), Login: adminID.String()},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Invalid id skipped",
+			ids:          "hamster," + adminID.String(),
+			user:         &models.User{ID: adminID, Login: "hamster"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "No admins configured",
+			ids:          "",
+			user:         &models.User{},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Unauthorized",
+			ids:          rootID.String(),
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, r *http.Request) {}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/balance/check", nil)
+			if tc.user != nil {
+				ctx := context.WithValue(req.Context(), models.ContextKeyUserType{}, tc.user)
+				req = req.WithContext(ctx)
+			}
+
+			res := httptest.NewRecorder()
+
+			testM := NewAdminMiddleware(tc.ids, *logger.NewLogger(context.TODO()))
+			testM.Admin(http.HandlerFunc(handler)).ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code)
+		})
+	}
+}
@@ -26,6 +26,11 @@ func (r *ResponseWriterWrap) Write(bytes []byte) (int, error) {
 	return r.ResponseWriter.Write(bytes)
 }
 
+// Unwrap lets http.ResponseController flush event streams through the wrapper
+func (r *ResponseWriterWrap) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 type LoggingMiddleware struct {
 	log logger.Logger
 }
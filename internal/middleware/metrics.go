@@ -16,6 +16,7 @@ const (
 	ServiceAuthName     = "auth"
 	ServiceAccountName  = "account"
 	ServiceCategoryName = "category"
+	ServiceGoalName     = "goal"
 )
 
 var (
@@ -44,6 +45,10 @@ func (w *writer) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 type MetricsMiddleware struct {
 	metric      *prometheus.GaugeVec
 	counter     *prometheus.CounterVec
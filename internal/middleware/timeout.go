@@ -6,9 +6,21 @@ import (
 	"time"
 )
 
-func Timeout(timeout time.Duration) func(next http.Handler) http.Handler {
+// Timeout cancels the request after the timeout, the streams are the paths of event streams
+// which stay open until the client leaves
+func Timeout(timeout time.Duration, streams ...string) func(next http.Handler) http.Handler {
+	exempt := make(map[string]bool, len(streams))
+	for _, path := range streams {
+		exempt[path] = true
+	}
+
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
+			if exempt[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			ctx, cancel := context.WithTimeout(r.Context(), timeout)
 			defer func() {
 				cancel()
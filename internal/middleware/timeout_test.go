@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+	testCases := []struct {
+		name         string
+		path         string
+		accept       string
+		expectedCode int
+	}{
+		{
+			name:         "Request timed out",
+			path:         "/api/account/list",
+			accept:       "application/json",
+			expectedCode: http.StatusGatewayTimeout,
+		},
+		{
+			name:         "Event stream is not limited",
+			path:         "/api/account/watch",
+			accept:       "text/event-stream",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Event stream header on another path is limited",
+			path:         "/api/account/feed",
+			accept:       "text/event-stream",
+			expectedCode: http.StatusGatewayTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := Timeout(10*time.Millisecond, "/api/account/watch")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(50 * time.Millisecond):
+					w.WriteHeader(http.StatusOK)
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.Header.Set("Accept", tc.accept)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Accounts struct {
 	ID             uuid.UUID     `json:"id"`
@@ -9,7 +14,159 @@ type Accounts struct {
 	SharingID      uuid.UUID     `json:"sharing_id"`
 	BalanceEnabled bool          `json:"balance_enabled"`
 	MeanPayment    string        `json:"mean_payment"`
+	ArchivedAt     *time.Time    `json:"archived_at"`
 	Users          []SharingUser `json:"users"`
+
+	Kind            AccountKind     `json:"kind"`
+	CreditLimit     float64         `json:"credit_limit"`
+	GracePeriod     int             `json:"grace_period"`  // days
+	StatementDay    int             `json:"statement_day"` // day of the month, 0 for a debit account
+	OverdraftPolicy OverdraftPolicy `json:"overdraft_policy"`
+}
+
+// Available is what can still be spent from the account, a credit card goes below zero down to its limit
+func (a *Accounts) Available() float64 {
+	if a.Kind == AccountCredit {
+		return a.Balance + a.CreditLimit
+	}
+	return a.Balance
+}
+
+// Normalize fills the kind and the policy the client left out, accounts are debit and allow overdrafts by default
+func (a *Accounts) Normalize() {
+	if a.Kind == "" {
+		a.Kind = AccountDebit
+	}
+	if a.OverdraftPolicy == "" {
+		a.OverdraftPolicy = OverdraftAllow
+	}
+}
+
+// CheckCredit validates the credit terms against the kind of the account
+func (a *Accounts) CheckCredit() error {
+	if !a.OverdraftPolicy.Valid() {
+		return &AccountOperationError{Reason: fmt.Sprintf("unknown overdraft policy %q", a.OverdraftPolicy)}
+	}
+
+	switch a.Kind {
+	case AccountDebit:
+		if a.CreditLimit != 0 || a.GracePeriod != 0 || a.StatementDay != 0 {
+			return &AccountOperationError{Reason: "a debit account has no credit terms"}
+		}
+	case AccountCredit:
+		if a.CreditLimit <= 0 {
+			return &AccountOperationError{Reason: "credit limit must be positive"}
+		}
+		if a.GracePeriod < 0 {
+			return &AccountOperationError{Reason: "grace period can't be negative"}
+		}
+		if a.StatementDay < 1 || a.StatementDay > 28 {
+			return &AccountOperationError{Reason: "statement day must be between 1 and 28"}
+		}
+	default:
+		return &AccountOperationError{Reason: fmt.Sprintf("unknown account kind %q", a.Kind)}
+	}
+	return nil
+}
+
+// AccountKind tells a debit account from a credit card
+type AccountKind string
+
+const (
+	AccountDebit  AccountKind = "debit"
+	AccountCredit AccountKind = "credit"
+)
+
+// OverdraftPolicy is what happens to an outcome that exceeds the available funds of the account
+type OverdraftPolicy string
+
+const (
+	// OverdraftReject fails the transaction
+	OverdraftReject OverdraftPolicy = "reject"
+	// OverdraftWarn keeps the transaction and raises an overdraft anomaly
+	OverdraftWarn OverdraftPolicy = "warn"
+	// OverdraftAllow keeps the transaction silently
+	OverdraftAllow OverdraftPolicy = "allow"
+)
+
+func (p OverdraftPolicy) Valid() bool {
+	switch p {
+	case OverdraftReject, OverdraftWarn, OverdraftAllow:
+		return true
+	}
+	return false
+}
+
+// Overdraft is a charge the warn policy let through although it exceeded the available funds,
+// Available is what the account had before the charge
+type Overdraft struct {
+	AccountID     uuid.UUID
+	TransactionID uuid.UUID
+	Amount        float64
+	Available     float64
+}
+
+// AccountBalance is a balance pushed to the watchers of the account
+//
+//easyjson:json
+type AccountBalance struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Balance   float64   `json:"balance"`
+}
+
+// AccountRole is what a member can do with a shared account
+type AccountRole string
+
+const (
+	// AccountOwner manages the members, archives and deletes the account
+	AccountOwner AccountRole = "owner"
+	// AccountEditor changes the account and any of its transactions
+	AccountEditor AccountRole = "editor"
+	// AccountViewer only sees the account and its transactions
+	AccountViewer AccountRole = "viewer"
+	// AccountContributor only adds outcome transactions
+	AccountContributor AccountRole = "contributor"
+)
+
+func (r AccountRole) Valid() bool {
+	switch r {
+	case AccountOwner, AccountEditor, AccountViewer, AccountContributor:
+		return true
+	}
+	return false
+}
+
+// Assignable reports whether the role can be given to a member, the account has one owner
+func (r AccountRole) Assignable() bool {
+	return r.Valid() && r != AccountOwner
+}
+
+func (r AccountRole) CanManage() bool {
+	return r == AccountOwner
+}
+
+func (r AccountRole) CanEdit() bool {
+	return r == AccountOwner || r == AccountEditor
+}
+
+// CanAdd reports whether the member can add a transaction with the income to the account
+func (r AccountRole) CanAdd(income float64) bool {
+	return r.CanEdit() || (r == AccountContributor && income == 0)
+}
+
+// AccountDeletePreview is what a hard delete of the account removes along with it
+type AccountDeletePreview struct {
+	Transactions    int `json:"transactions"`
+	Members         int `json:"members"`
+	Deposits        int `json:"deposits"`
+	Credits         int `json:"credits"`
+	DebtRepayments  int `json:"debt_repayments"`
+	Dividends       int `json:"dividends"`
+	Goals           int `json:"goals"`
+	GoalRules       int `json:"goal_rules"`
+	Reconciliations int `json:"reconciliations"`
+	Snapshots       int `json:"snapshots"`
+	Invitations     int `json:"invitations"`
 }
 
 type AccounstTransfer struct {
@@ -21,13 +178,20 @@ type AccounstTransfer struct {
 }
 
 //easyjson:json
-type AddUserAccount struct {
-	Login     string    `json:"login"`
+type DeleteInAccount struct {
+	UserID    uuid.UUID `json:"user_id"`
 	AccountID uuid.UUID `json:"account_id"`
 }
 
 //easyjson:json
-type DeleteInAccount struct {
-	UserID    uuid.UUID `json:"user_id"`
+type SetAccountRole struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	AccountID uuid.UUID   `json:"account_id"`
+	Role      AccountRole `json:"role"`
+}
+
+//easyjson:json
+type TransferOwnership struct {
 	AccountID uuid.UUID `json:"account_id"`
+	UserID    uuid.UUID `json:"user_id"` // the successor, a member of the account
 }
@@ -17,7 +17,84 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *jlexer.Lexer, out *DeleteInAccount) {
+func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *jlexer.Lexer, out *TransferOwnership) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(out *jwriter.Writer, in TransferOwnership) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferOwnership) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferOwnership) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferOwnership) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferOwnership) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(l, v)
+}
+func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(in *jlexer.Lexer, out *SetAccountRole) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -44,6 +121,8 @@ func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *j
 			if data := in.UnsafeBytes(); in.Ok() {
 				in.AddError((out.AccountID).UnmarshalText(data))
 			}
+		case "role":
+			out.Role = AccountRole(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -54,7 +133,89 @@ func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *j
 		in.Consumed()
 	}
 }
-func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(out *jwriter.Writer, in DeleteInAccount) {
+func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels1(out *jwriter.Writer, in SetAccountRole) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.UserID).MarshalText())
+	}
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix)
+		out.RawText((in.AccountID).MarshalText())
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SetAccountRole) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SetAccountRole) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SetAccountRole) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SetAccountRole) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(l, v)
+}
+func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels2(in *jlexer.Lexer, out *DeleteInAccount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "account_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AccountID).UnmarshalText(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels2(out *jwriter.Writer, in DeleteInAccount) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -74,27 +235,27 @@ func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(out *
 // MarshalJSON supports json.Marshaler interface
 func (v DeleteInAccount) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(&w, v)
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DeleteInAccount) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels(w, v)
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DeleteInAccount) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(&r, v)
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DeleteInAccount) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels(l, v)
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels2(l, v)
 }
-func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(in *jlexer.Lexer, out *AddUserAccount) {
+func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels3(in *jlexer.Lexer, out *AccountBalance) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -113,12 +274,12 @@ func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(in *
 			continue
 		}
 		switch key {
-		case "login":
-			out.Login = string(in.String())
 		case "account_id":
 			if data := in.UnsafeBytes(); in.Ok() {
 				in.AddError((out.AccountID).UnmarshalText(data))
 			}
+		case "balance":
+			out.Balance = float64(in.Float64())
 		default:
 			in.SkipRecursive()
 		}
@@ -129,43 +290,43 @@ func easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(in *
 		in.Consumed()
 	}
 }
-func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels1(out *jwriter.Writer, in AddUserAccount) {
+func easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels3(out *jwriter.Writer, in AccountBalance) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"login\":"
+		const prefix string = ",\"account_id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Login))
+		out.RawText((in.AccountID).MarshalText())
 	}
 	{
-		const prefix string = ",\"account_id\":"
+		const prefix string = ",\"balance\":"
 		out.RawString(prefix)
-		out.RawText((in.AccountID).MarshalText())
+		out.Float64(float64(in.Balance))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v AddUserAccount) MarshalJSON() ([]byte, error) {
+func (v AccountBalance) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels1(&w, v)
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v AddUserAccount) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels1(w, v)
+func (v AccountBalance) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComGoParkMailRu20232HamsterInternalModels3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *AddUserAccount) UnmarshalJSON(data []byte) error {
+func (v *AccountBalance) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(&r, v)
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *AddUserAccount) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels1(l, v)
+func (v *AccountBalance) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComGoParkMailRu20232HamsterInternalModels3(l, v)
 }
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Anomaly struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"-"`
+	Kind          string     `json:"kind"`
+	Fingerprint   string     `json:"-"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	CategoryID    *uuid.UUID `json:"category_id,omitempty"`
+	Amount        float64    `json:"amount"`
+	Expected      float64    `json:"expected"`
+	Explanation   string     `json:"explanation"`
+	DetectedAt    time.Time  `json:"detected_at"`
+	Dismissed     bool       `json:"dismissed"`
+}
+
+// AnomalyTransaction is an outcome checked by the anomaly detection
+type AnomalyTransaction struct {
+	ID           uuid.UUID
+	Outcome      float64
+	Date         time.Time
+	Payer        string
+	CategoryID   uuid.UUID
+	CategoryName string
+}
@@ -0,0 +1,22 @@
+package models
+
+import "github.com/google/uuid"
+
+// BalanceCheck compares the stored balance of the account with the one recomputed
+// from the opening balance and the transactions
+type BalanceCheck struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	Stored     float64   `json:"stored"`
+	Opening    *float64  `json:"opening_balance"`
+	Income     float64   `json:"income"`
+	Outcome    float64   `json:"outcome"`
+	Expected   float64   `json:"expected"`
+	Difference float64   `json:"difference"`
+}
+
+type BalanceReport struct {
+	Accounts      int            `json:"accounts"`
+	Unverifiable  []BalanceCheck `json:"unverifiable"` // without an opening balance, never repaired
+	Discrepancies []BalanceCheck `json:"discrepancies"`
+	Repaired      bool           `json:"repaired"`
+}
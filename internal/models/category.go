@@ -21,3 +21,43 @@ func (c *Category) CategoryValidate() error {
 	_, err := valid.ValidateStruct(c)
 	return err
 }
+
+// CategoryMaxDepth is the deepest nesting of categories, the root ones are on the first level
+const CategoryMaxDepth = 3
+
+// CategoryNode is a category of the tree with the totals of its own transactions
+// and the totals rolled up over its subtree
+//
+//easyjson:json
+type CategoryNode struct {
+	Category
+	Income       float64        `json:"income"`
+	Outcome      float64        `json:"outcome"`
+	TotalIncome  float64        `json:"total_income"`
+	TotalOutcome float64        `json:"total_outcome"`
+	Children     []CategoryNode `json:"children"`
+}
+
+// CategoryMerge is the result of merging categories into the target one
+type CategoryMerge struct {
+	TargetID     uuid.UUID `json:"target_id"`
+	Transactions int64     `json:"transactions"` // moved to the target
+	Children     int64     `json:"children"`
+	Anomalies    int64     `json:"anomalies"` // moved to the target
+}
+
+// ways to delete a category that has transactions or children
+const (
+	CategoryDeleteRefuse   = "refuse"   // fails if the category is in use
+	CategoryDeleteReassign = "reassign" // transactions and children go to the target category
+	CategoryDeleteMoveUp   = "move_up"  // transactions and children go to the parent, a root category can't have transactions
+)
+
+// CategoryDeletion is the result of deleting a category
+type CategoryDeletion struct {
+	Strategy     string `json:"strategy"`
+	Transactions int64  `json:"transactions"` // reassigned to the target
+	Unlinked     int64  `json:"unlinked"`     // transactions left without the category
+	Children     int64  `json:"children"`
+	Anomalies    int64  `json:"anomalies"` // moved to the target or deleted with the category
+}
@@ -17,7 +17,188 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *jlexer.Lexer, out *Category) {
+func easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *jlexer.Lexer, out *CategoryNode) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "income":
+			out.Income = float64(in.Float64())
+		case "outcome":
+			out.Outcome = float64(in.Float64())
+		case "total_income":
+			out.TotalIncome = float64(in.Float64())
+		case "total_outcome":
+			out.TotalOutcome = float64(in.Float64())
+		case "children":
+			if in.IsNull() {
+				in.Skip()
+				out.Children = nil
+			} else {
+				in.Delim('[')
+				if out.Children == nil {
+					if !in.IsDelim(']') {
+						out.Children = make([]CategoryNode, 0, 0)
+					} else {
+						out.Children = []CategoryNode{}
+					}
+				} else {
+					out.Children = (out.Children)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 CategoryNode
+					(v1).UnmarshalEasyJSON(in)
+					out.Children = append(out.Children, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "parent_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ParentID).UnmarshalText(data))
+			}
+		case "name":
+			out.Name = string(in.String())
+		case "show_income":
+			out.ShowIncome = bool(in.Bool())
+		case "show_outcome":
+			out.ShowOutcome = bool(in.Bool())
+		case "regular":
+			out.Regular = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(out *jwriter.Writer, in CategoryNode) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"income\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Income))
+	}
+	{
+		const prefix string = ",\"outcome\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Outcome))
+	}
+	{
+		const prefix string = ",\"total_income\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.TotalIncome))
+	}
+	{
+		const prefix string = ",\"total_outcome\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.TotalOutcome))
+	}
+	{
+		const prefix string = ",\"children\":"
+		out.RawString(prefix)
+		if in.Children == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Children {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	{
+		const prefix string = ",\"parent_id\":"
+		out.RawString(prefix)
+		out.RawText((in.ParentID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"show_income\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ShowIncome))
+	}
+	{
+		const prefix string = ",\"show_outcome\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ShowOutcome))
+	}
+	{
+		const prefix string = ",\"regular\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Regular))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CategoryNode) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CategoryNode) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CategoryNode) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CategoryNode) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(l, v)
+}
+func easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels1(in *jlexer.Lexer, out *Category) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -66,7 +247,7 @@ func easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(in *j
 		in.Consumed()
 	}
 }
-func easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(out *jwriter.Writer, in Category) {
+func easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels1(out *jwriter.Writer, in Category) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -111,23 +292,23 @@ func easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(out *
 // MarshalJSON supports json.Marshaler interface
 func (v Category) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(&w, v)
+	easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Category) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels(w, v)
+	easyjson6a91a67cEncodeGithubComGoParkMailRu20232HamsterInternalModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Category) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(&r, v)
+	easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Category) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels(l, v)
+	easyjson6a91a67cDecodeGithubComGoParkMailRu20232HamsterInternalModels1(l, v)
 }
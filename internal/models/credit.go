@@ -1,26 +1,68 @@
 package models
 
-// import (
-// 	"time"
-
-// 	valid "github.com/asaskevich/govalidator"
-// 	"github.com/google/uuid"
-// )
-
-// type Credit struct {
-// 	ID          uuid.UUID `json:"id" valid:"-"`
-// 	AccountID   uint      `json:"account_id" valid:"-"`
-// 	Total       float64   `json:"total" valid:"required,greaterzero"`
-// 	DateStart   time.Time `json:"date_start" valid:"isdate"`
-// 	DateEnd     time.Time `json:"date_end" valid:"isdate"`
-// 	IsAnnuity   bool      `json:"is_annuity" valid:"required"`
-// 	Creditor    string    `json:"creditor" valid:"-"`
-// 	Description string    `json:"description" valid:"-"`
-// 	Payments    int       `json:"payments" valid:"-"`
-// 	Bank        string    `json:"bank" valid:"-"`
-// }
-
-// func (c *Credit) CreditValidate() error {
-// 	_, err := valid.ValidateStruct(c)
-// 	return err
-// }
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// An early repayment either shortens the term or lowers the monthly payment
+const (
+	RepaymentReduceTerm    = "term"
+	RepaymentReducePayment = "payment"
+)
+
+type Credit struct {
+	ID           uuid.UUID `json:"id"`
+	UserID       uuid.UUID `json:"-"`
+	AccountID    uuid.UUID `json:"account_id"`
+	Total        float64   `json:"total"`
+	InterestRate float64   `json:"interest_rate"`
+	DateStart    time.Time `json:"date_start"`
+	Payments     int       `json:"payments"`
+	IsAnnuity    bool      `json:"is_annuity"`
+	Creditor     string    `json:"creditor"`
+	Bank         string    `json:"bank"`
+	Description  string    `json:"description"`
+}
+
+type CreditRepayment struct {
+	ID            uuid.UUID  `json:"id"`
+	Date          time.Time  `json:"date"`
+	Amount        float64    `json:"amount"`
+	Reduce        string     `json:"reduce"`
+	TransactionID *uuid.UUID `json:"transaction_id"`
+}
+
+type CreditPaymentLink struct {
+	Number        int       `json:"number"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+// CreditPayment is a row of the schedule, a paid one is linked to its transaction
+type CreditPayment struct {
+	Number        int        `json:"number"`
+	Date          time.Time  `json:"date"`
+	Payment       float64    `json:"payment"`
+	Principal     float64    `json:"principal"`
+	Interest      float64    `json:"interest"`
+	Balance       float64    `json:"balance"`
+	TransactionID *uuid.UUID `json:"transaction_id"`
+}
+
+type CreditSchedule struct {
+	Credit             Credit            `json:"credit"`
+	Payments           []CreditPayment   `json:"payments"`
+	Repayments         []CreditRepayment `json:"repayments"`
+	InterestTotal      float64           `json:"interest_total"`
+	InterestPaid       float64           `json:"interest_paid"`
+	PrincipalPaid      float64           `json:"principal_paid"`
+	RemainingPrincipal float64           `json:"remaining_principal"`
+}
+
+type CreditOverview struct {
+	Credit             Credit         `json:"credit"`
+	InterestPaid       float64        `json:"interest_paid"`
+	RemainingPrincipal float64        `json:"remaining_principal"`
+	NextPayment        *CreditPayment `json:"next_payment"`
+}
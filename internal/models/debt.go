@@ -1,22 +1,55 @@
 package models
 
-// import (
-// 	"time"
+import (
+	"time"
 
-// 	valid "github.com/asaskevich/govalidator"
-// 	"github.com/google/uuid"
-// )
+	"github.com/google/uuid"
+)
 
-// type Debt struct {
-// 	ID          uuid.UUID `json:"id" valid:"-"`
-// 	UserID      uint      `json:"user_id" valid:"-"`
-// 	Total       float64   `json:"total" valid:"required,greaterzero"`
-// 	Date        time.Time `json:"date" valid:"isdate"`
-// 	Creditor    string    `json:"creditor"`
-// 	Description string    `json:"description" valid:"-"`
-// }
+// Direction of a debt: money lent is owed to the user, money borrowed is owed by the user
+const (
+	DebtLent     = "lent"
+	DebtBorrowed = "borrowed"
+)
 
-// func (d *Debt) DebtValidate() error {
-// 	_, err := valid.ValidateStruct(d)
-// 	return err
-// }
+type Debt struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"-"`
+	Counterparty string     `json:"counterparty"`
+	Direction    string     `json:"direction"`
+	Total        float64    `json:"total"`
+	Repaid       float64    `json:"repaid"`
+	Outstanding  float64    `json:"outstanding"`
+	Date         time.Time  `json:"date"`
+	DueDate      *time.Time `json:"due_date"`
+	Description  string     `json:"description"`
+	ClosedAt     *time.Time `json:"closed_at"`
+}
+
+// DebtRepayment is a partial repayment posted as a transaction on the account
+type DebtRepayment struct {
+	ID            uuid.UUID `json:"id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Amount        float64   `json:"amount"`
+	Date          time.Time `json:"date"`
+}
+
+// DebtCounterparty is the outstanding balance with one person: Net is positive when they owe the user
+type DebtCounterparty struct {
+	Counterparty string     `json:"counterparty"`
+	Lent         float64    `json:"lent"`
+	Borrowed     float64    `json:"borrowed"`
+	Net          float64    `json:"net"`
+	Overdue      float64    `json:"overdue"`
+	NextDue      *time.Time `json:"next_due"`
+}
+
+type DebtSummary struct {
+	Lent            float64            `json:"lent"`
+	Borrowed        float64            `json:"borrowed"`
+	Net             float64            `json:"net"`
+	OverdueLent     float64            `json:"overdue_lent"`
+	OverdueBorrowed float64            `json:"overdue_borrowed"`
+	Counterparties  []DebtCounterparty `json:"counterparties"`
+}
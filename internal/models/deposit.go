@@ -1,23 +1,54 @@
 package models
 
-// import (
-// 	"time"
+import (
+	"time"
 
-// 	valid "github.com/asaskevich/govalidator"
-// 	"github.com/google/uuid"
-// )
+	"github.com/google/uuid"
+)
 
-// type Deposit struct {
-// 	ID           uuid.UUID `json:"id" valid:"-"`
-// 	AccountID    uint      `json:"account_id" valid:"-"`
-// 	Total        float64   `json:"total" valid:"required,greaterzero"`
-// 	DateStart    time.Time `json:"date_start" valid:"isdate"`
-// 	DateEnd      time.Time `json:"date_end" valid:"isdate"`
-// 	InterestRate float64   `json:"interest_rate" valid:"required"`
-// 	Bank         string    `json:"bank"`
-// }
+// Capitalization is how often the interest is added to the deposit,
+// without capitalization the simple interest is paid at the end of the term
+const (
+	CapitalizationNone      = "none"
+	CapitalizationMonthly   = "monthly"
+	CapitalizationQuarterly = "quarterly"
+	CapitalizationYearly    = "yearly"
+)
 
-// func (d *Deposit) DepositValidate() error {
-// 	_, err := valid.ValidateStruct(d)
-// 	return err
-// }
+type Deposit struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"-"`
+	AccountID      uuid.UUID  `json:"account_id"`
+	Bank           string     `json:"bank"`
+	Total          float64    `json:"total"`
+	InterestRate   float64    `json:"interest_rate"`
+	EarlyRate      float64    `json:"early_rate"`
+	Capitalization string     `json:"capitalization"`
+	DateStart      time.Time  `json:"date_start"`
+	DateEnd        time.Time  `json:"date_end"`
+	Accrued        float64    `json:"accrued"`
+	AccruedUntil   time.Time  `json:"accrued_until"`
+	ClosedAt       *time.Time `json:"closed_at"`
+}
+
+type DepositPayout struct {
+	Date     time.Time `json:"date"`
+	Interest float64   `json:"interest"`
+	Balance  float64   `json:"balance"`
+	Posted   bool      `json:"posted"`
+}
+
+type DepositProjection struct {
+	DepositID    uuid.UUID       `json:"deposit_id"`
+	Total        float64         `json:"total"`
+	Interest     float64         `json:"interest"`
+	FinalBalance float64         `json:"final_balance"`
+	Payouts      []DepositPayout `json:"payouts"`
+}
+
+// DepositWithdrawal is the result of an early closing: the interest is recounted with the early rate
+// and the difference with the already accrued interest is posted to the account
+type DepositWithdrawal struct {
+	Interest   float64 `json:"interest"`
+	Adjustment float64 `json:"adjustment"`
+}
@@ -45,6 +45,121 @@ type NoSuchAccounts struct {
 	UserID uuid.UUID
 }
 
+type NoSuchAnomalyError struct {
+	AnomalyID uuid.UUID
+}
+
+type NoSuchPayeeError struct {
+	PayeeID uuid.UUID
+}
+
+type NoSuchDepositError struct {
+	DepositID uuid.UUID
+}
+
+// DepositChangedError is an interest posting or a withdrawal that lost the race
+// with another one on the same deposit
+type DepositChangedError struct {
+	DepositID uuid.UUID
+}
+
+type NotAccumulationAccountError struct {
+	AccountID uuid.UUID
+}
+
+type NoSuchCreditError struct {
+	CreditID uuid.UUID
+}
+
+// CreditOperationError is a payment link or a repayment that does not fit the credit schedule
+type CreditOperationError struct {
+	Reason string
+}
+
+type NoSuchDebtError struct {
+	DebtID uuid.UUID
+}
+
+// DebtOperationError is a repayment that does not fit the debt
+type DebtOperationError struct {
+	Reason string
+}
+
+type NoSuchHoldingError struct {
+	HoldingID uuid.UUID
+}
+
+// InvestmentOperationError is a lot or a dividend that does not fit the holding
+type InvestmentOperationError struct {
+	Reason string
+}
+
+type NoSuchInvitationError struct {
+	InvitationID uuid.UUID
+}
+
+// InvitationOperationError is an invitation that can't be sent or revoked
+type InvitationOperationError struct {
+	Reason string
+}
+
+// AccountOperationError is an archive, restore, delete or member change that does not fit the account state
+type AccountOperationError struct {
+	Reason string
+}
+
+// AccountWatchLimitError is a balance stream over the number of streams a user can keep open
+type AccountWatchLimitError struct {
+	Limit int
+}
+
+type NoSuchReconciliationError struct {
+	ReconciliationID uuid.UUID
+}
+
+// ReconciliationOperationError is a clearing or a finish that does not fit the reconciliation
+type ReconciliationOperationError struct {
+	Reason string
+}
+
+// ReconciledTransactionError is a change of a transaction locked by a finished reconciliation
+type ReconciledTransactionError struct {
+	TransactionID uuid.UUID
+}
+
+type NoSuchGoalError struct {
+	GoalID uuid.UUID
+}
+
+type NoSuchGoalRuleError struct {
+	RuleID uuid.UUID
+}
+
+// GoalRuleOperationError is a rule that does not fit the goal or its accounts
+type GoalRuleOperationError struct {
+	Reason string
+}
+
+type NoSuchCategoryError struct {
+	CategoryID uuid.UUID
+}
+
+// CategoryOperationError is a request the categories can't be changed by
+type CategoryOperationError struct {
+	Reason string
+}
+
+// CategoryHierarchyError is a parent that makes a cycle or nests the categories too deep
+type CategoryHierarchyError struct {
+	Reason string
+}
+
+// OverdraftError is an outcome rejected by the overdraft policy of the account
+type OverdraftError struct {
+	AccountID uuid.UUID
+	Available float64
+}
+
 type ForbiddenUserError struct{}
 
 func (e *ForbiddenUserError) Error() string {
@@ -75,6 +190,106 @@ func (e *NoSuchTransactionError) Error() string {
 	return fmt.Sprintf("No Such transaction: %s doesn't exist", e.UserID.String())
 }
 
+func (e *NoSuchAnomalyError) Error() string {
+	return fmt.Sprintf("No Such anomaly: %s doesn't exist", e.AnomalyID.String())
+}
+
+func (e *NoSuchPayeeError) Error() string {
+	return fmt.Sprintf("No Such payee: %s doesn't exist", e.PayeeID.String())
+}
+
+func (e *NoSuchDepositError) Error() string {
+	return fmt.Sprintf("No Such deposit: %s doesn't exist", e.DepositID.String())
+}
+
+func (e *DepositChangedError) Error() string {
+	return fmt.Sprintf("deposit %s was changed by another operation", e.DepositID.String())
+}
+
+func (e *NotAccumulationAccountError) Error() string {
+	return fmt.Sprintf("account %s is not an accumulation account", e.AccountID.String())
+}
+
+func (e *NoSuchCreditError) Error() string {
+	return fmt.Sprintf("No Such credit: %s doesn't exist", e.CreditID.String())
+}
+
+func (e *CreditOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *NoSuchDebtError) Error() string {
+	return fmt.Sprintf("No Such debt: %s doesn't exist", e.DebtID.String())
+}
+
+func (e *DebtOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *NoSuchHoldingError) Error() string {
+	return fmt.Sprintf("No Such holding: %s doesn't exist", e.HoldingID.String())
+}
+
+func (e *InvestmentOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *AccountOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *AccountWatchLimitError) Error() string {
+	return fmt.Sprintf("too many balance streams, at most %d can be open", e.Limit)
+}
+
+func (e *NoSuchReconciliationError) Error() string {
+	return fmt.Sprintf("No Such reconciliation: %s doesn't exist", e.ReconciliationID.String())
+}
+
+func (e *ReconciliationOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *ReconciledTransactionError) Error() string {
+	return fmt.Sprintf("transaction %s is reconciled and can't be changed", e.TransactionID.String())
+}
+
+func (e *NoSuchGoalError) Error() string {
+	return fmt.Sprintf("No Such goal: %s doesn't exist", e.GoalID.String())
+}
+
+func (e *NoSuchGoalRuleError) Error() string {
+	return fmt.Sprintf("No Such goal rule: %s doesn't exist", e.RuleID.String())
+}
+
+func (e *GoalRuleOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *NoSuchCategoryError) Error() string {
+	return fmt.Sprintf("No Such category: %s doesn't exist", e.CategoryID.String())
+}
+
+func (e *CategoryOperationError) Error() string {
+	return e.Reason
+}
+
+func (e *CategoryHierarchyError) Error() string {
+	return e.Reason
+}
+
+func (e *OverdraftError) Error() string {
+	return fmt.Sprintf("not enough funds on account %s: %.2f available", e.AccountID.String(), e.Available)
+}
+
+func (e *NoSuchInvitationError) Error() string {
+	return fmt.Sprintf("No Such invitation: %s doesn't exist", e.InvitationID.String())
+}
+
+func (e *InvitationOperationError) Error() string {
+	return e.Reason
+}
+
 func (e *NoSuchUserInLogin) Error() string {
 	return fmt.Sprintf("No Such user in login %s doesn't exist", e.Login)
 }
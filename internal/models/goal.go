@@ -3,20 +3,93 @@ package models
 import (
 	"time"
 
-	valid "github.com/asaskevich/govalidator"
 	"github.com/google/uuid"
 )
 
+// Goal is a sum the user saves up by the date on the linked accumulation accounts
 type Goal struct {
-	ID          uuid.UUID `json:"id" valid:"-"`
-	UserId      uuid.UUID `json:"user_id" valid:"-"`
-	Name        string    `json:"name" valid:"required"`
-	Description string    `json:"description" valid:"-"`
-	Target      float64   `json:"total" valid:"required,greaterzero"`
-	Date        time.Time `json:"date" valid:"isdate"`
+	ID          uuid.UUID   `json:"id"`
+	UserId      uuid.UUID   `json:"-"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Target      float64     `json:"total"`
+	Date        time.Time   `json:"date"`
+	Accounts    []uuid.UUID `json:"accounts"`
+	Progress    float64     `json:"progress"` // the balance of the linked accounts
+	Completed   bool        `json:"completed"`
+	Status      string      `json:"status"`
 }
 
-func (g *Goal) GoalValidate() error {
-	_, err := valid.ValidateStruct(g)
-	return err
+const (
+	GoalCompleted = "completed"
+	// GoalOnTrack reaches the target by the date at the current pace
+	GoalOnTrack = "on_track"
+	GoalBehind  = "behind"
+	// GoalOverdue is not completed by its date
+	GoalOverdue = "overdue"
+)
+
+// GoalContributed is what was transferred to the accounts of the goal since the date, minus the transfers out
+type GoalContributed struct {
+	GoalID uuid.UUID
+	Since  time.Time
+	Amount float64
+}
+
+// GoalProjection tells how the goal is going at the pace of its contributions
+type GoalProjection struct {
+	GoalID          uuid.UUID  `json:"goal_id"`
+	Remaining       float64    `json:"remaining"`
+	MonthlyRequired float64    `json:"monthly_required"` // to reach the target by the date
+	MonthlyPace     float64    `json:"monthly_pace"`
+	ExpectedDate    *time.Time `json:"expected_date,omitempty"` // none if the pace does not bring the goal closer
+	Status          string     `json:"status"`
+}
+
+const (
+	// GoalRuleSchedule transfers the amount every period starting from the date
+	GoalRuleSchedule = "schedule"
+	// GoalRuleIncome transfers the amount percent of every salary income on the source account
+	GoalRuleIncome = "income"
+	// GoalRuleRoundUp transfers what rounds every outcome of the source account up to the amount
+	GoalRuleRoundUp = "round_up"
+
+	GoalPeriodWeek  = "week"
+	GoalPeriodMonth = "month"
+
+	// GoalIncomeCategory is the category of the incomes the income rule takes a percent of
+	GoalIncomeCategory = "Зарплата"
+)
+
+// GoalRule funds the goal with transfers from the source account to an account of the goal
+type GoalRule struct {
+	ID        uuid.UUID  `json:"id"`
+	GoalID    uuid.UUID  `json:"goal_id"`
+	UserID    uuid.UUID  `json:"-"`
+	Kind      string     `json:"kind"`
+	SourceID  uuid.UUID  `json:"source_id"`
+	AccountID uuid.UUID  `json:"account_id"`
+	Amount    float64    `json:"amount"`
+	Period    string     `json:"period,omitempty"`
+	DateStart *time.Time `json:"date_start,omitempty"`
+	NextDate  *time.Time `json:"next_date,omitempty"`
+}
+
+// GoalRuleTrigger is an income or an outcome of the source account the rule has not handled yet
+type GoalRuleTrigger struct {
+	Rule          GoalRule
+	TransactionID uuid.UUID
+	Income        float64
+	Outcome       float64
+}
+
+// GoalContribution is a transfer made by a rule, without TransactionID it was skipped for lack of funds
+type GoalContribution struct {
+	ID                  uuid.UUID  `json:"id"`
+	GoalID              uuid.UUID  `json:"goal_id"`
+	RuleID              uuid.UUID  `json:"rule_id"`
+	SourceTransactionID *uuid.UUID `json:"source_transaction_id,omitempty"`
+	TransactionID       *uuid.UUID `json:"transaction_id,omitempty"`
+	Amount              float64    `json:"amount"`
+	Date                time.Time  `json:"date"`
 }
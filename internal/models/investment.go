@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LotBuy  = "buy"
+	LotSell = "sell"
+)
+
+// Holding is a security of the user, the position is calculated from its lots
+type Holding struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"-"`
+	Ticker string    `json:"ticker"`
+	Name   string    `json:"name"`
+}
+
+type InvestmentLot struct {
+	ID        uuid.UUID `json:"id"`
+	HoldingID uuid.UUID `json:"holding_id"`
+	Kind      string    `json:"kind"`
+	Quantity  float64   `json:"quantity"`
+	Price     float64   `json:"price"`
+	Fee       float64   `json:"fee"`
+	Date      time.Time `json:"date"`
+}
+
+// InvestmentDividend is dividend income posted as a transaction on the account
+type InvestmentDividend struct {
+	ID            uuid.UUID `json:"id"`
+	HoldingID     uuid.UUID `json:"holding_id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Amount        float64   `json:"amount"`
+	Date          time.Time `json:"date"`
+}
+
+// SecurityPrice is the last known price of a ticker
+type SecurityPrice struct {
+	Price float64   `json:"price"`
+	Date  time.Time `json:"date"`
+}
+
+// HoldingPosition is valued at the market price, a holding without a price is valued at cost
+type HoldingPosition struct {
+	Holding
+	Quantity    float64    `json:"quantity"`
+	AverageCost float64    `json:"average_cost"`
+	Cost        float64    `json:"cost"`
+	Price       float64    `json:"price"`
+	PriceDate   *time.Time `json:"price_date"`
+	Value       float64    `json:"value"`
+	Unrealized  float64    `json:"unrealized"`
+	Realized    float64    `json:"realized"`
+	Dividends   float64    `json:"dividends"`
+}
+
+type Portfolio struct {
+	Value      float64           `json:"value"`
+	Cost       float64           `json:"cost"`
+	Unrealized float64           `json:"unrealized"`
+	Realized   float64           `json:"realized"`
+	Dividends  float64           `json:"dividends"`
+	Holdings   []HoldingPosition `json:"holdings"`
+}
+
+type PortfolioSnapshot struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status of an invitation, a pending one past ExpiresAt is shown as expired
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// Invitation to share an account, the invitee becomes a member with the role on accept
+type Invitation struct {
+	ID           uuid.UUID   `json:"id"`
+	AccountID    uuid.UUID   `json:"account_id"`
+	AccountName  string      `json:"account_name"`
+	InviterID    uuid.UUID   `json:"inviter_id"`
+	InviterLogin string      `json:"inviter_login"`
+	InviteeID    uuid.UUID   `json:"invitee_id"`
+	InviteeLogin string      `json:"invitee_login"`
+	Role         AccountRole `json:"role"`
+	Status       string      `json:"status"`
+	CreatedAt    time.Time   `json:"created_at"`
+	ExpiresAt    time.Time   `json:"expires_at"`
+}
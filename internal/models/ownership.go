@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status of an ownership transfer, a new nomination cancels the pending one
+const (
+	OwnershipTransferPending   = "pending"
+	OwnershipTransferAccepted  = "accepted"
+	OwnershipTransferDeclined  = "declined"
+	OwnershipTransferCancelled = "cancelled"
+)
+
+// OwnershipTransfer hands the account over to a member after the member confirms,
+// with Leave the former owner leaves the account once it is accepted
+type OwnershipTransfer struct {
+	ID          uuid.UUID  `json:"id"`
+	AccountID   uuid.UUID  `json:"account_id"`
+	AccountName string     `json:"account_name"`
+	FromUserID  uuid.UUID  `json:"from_user_id"`
+	FromLogin   string     `json:"from_login"`
+	ToUserID    uuid.UUID  `json:"to_user_id"`
+	ToLogin     string     `json:"to_login"`
+	Leave       bool       `json:"leave"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	AnsweredAt  *time.Time `json:"answered_at,omitempty"`
+}
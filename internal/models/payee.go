@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payee groups the raw payer strings of one merchant
+type Payee struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Aliases []string  `json:"aliases"`
+}
+
+type TopPayee struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+	Spend float64   `json:"spend"`
+}
+
+// PayeeRef is a payer of a user which is not linked to a payee yet
+type PayeeRef struct {
+	UserID uuid.UUID
+	Payer  string
+}
+
+const (
+	PayeeSortSpend = "spend"
+	PayeeSortCount = "count"
+)
+
+type TopPayeesQuery struct {
+	SortBy    string
+	Limit     int
+	StartDate time.Time
+	EndDate   time.Time
+}
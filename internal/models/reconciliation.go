@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status of a reconciliation, an account has one open reconciliation at a time
+const (
+	ReconciliationOpen      = "open"
+	ReconciliationFinished  = "finished"
+	ReconciliationCancelled = "cancelled"
+)
+
+// Reconciliation matches the account against a bank statement. The user clears the transactions
+// of the statement until the cleared balance meets the closing balance, finishing it locks them
+type Reconciliation struct {
+	ID             uuid.UUID  `json:"id"`
+	AccountID      uuid.UUID  `json:"account_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	StatementDate  time.Time  `json:"statement_date"`
+	ClosingBalance float64    `json:"closing_balance"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+
+	ClearedBalance float64                     `json:"cleared_balance"`
+	Difference     float64                     `json:"difference"` // closing balance minus cleared balance
+	Transactions   []ReconciliationTransaction `json:"transactions,omitempty"`
+}
+
+// ReconciliationTransaction is a transaction of the account up to the statement date that is not reconciled yet
+type ReconciliationTransaction struct {
+	ID          uuid.UUID `json:"id"`
+	Date        time.Time `json:"date"`
+	Payer       string    `json:"payer"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"` // what the transaction brings to the account, negative for an outcome
+	Cleared     bool      `json:"cleared"`
+}
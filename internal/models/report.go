@@ -0,0 +1,191 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ForecastAccount is the starting point of a balance projection
+type ForecastAccount struct {
+	ID             uuid.UUID `json:"id"`
+	Balance        float64   `json:"balance"`
+	Accumulation   bool      `json:"accumulation"`
+	BalanceEnabled bool      `json:"balance_enabled"`
+	MeanPayment    string    `json:"mean_payment"`
+}
+
+// ForecastTransaction is a history row used to detect recurring patterns
+type ForecastTransaction struct {
+	AccountIncomeID  uuid.UUID
+	AccountOutcomeID uuid.UUID
+	Income           float64
+	Outcome          float64
+	Date             time.Time
+	Payer            string
+	CategoryID       uuid.UUID
+	CategoryName     string
+	Regular          bool
+}
+
+type ForecastPoint struct {
+	Date    time.Time `json:"date"`
+	Balance float64   `json:"balance"`
+}
+
+type ForecastEvent struct {
+	Date      time.Time `json:"date"`
+	AccountID uuid.UUID `json:"account_id"`
+	Amount    float64   `json:"amount"`
+	Source    string    `json:"source"`
+	Name      string    `json:"name"`
+}
+
+type ForecastAlert struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	MeanPayment string    `json:"mean_payment"`
+	Date        time.Time `json:"date"`
+	Balance     float64   `json:"balance"`
+}
+
+type AccountForecast struct {
+	AccountID         uuid.UUID       `json:"account_id"`
+	MeanPayment       string          `json:"mean_payment"`
+	BalanceEnabled    bool            `json:"balance_enabled"`
+	StartBalance      float64         `json:"start_balance"`
+	DailySpending     float64         `json:"daily_spending"`
+	EndBalance        float64         `json:"end_balance"`
+	Points            []ForecastPoint `json:"points"`
+	NegativeFirstDate *time.Time      `json:"negative_first_date,omitempty"`
+}
+
+type Forecast struct {
+	Days     int               `json:"days"`
+	Accounts []AccountForecast `json:"accounts"`
+	Events   []ForecastEvent   `json:"events"`
+	Alerts   []ForecastAlert   `json:"alerts"`
+}
+
+// BalanceSnapshot is the balance of an account at the end of a day
+type BalanceSnapshot struct {
+	AccountID uuid.UUID
+	Date      time.Time
+	Balance   float64
+}
+
+type BalancePoint struct {
+	Date    time.Time `json:"date"`
+	Balance float64   `json:"balance"`
+}
+
+type AccountBalanceHistory struct {
+	AccountID      uuid.UUID      `json:"account_id"`
+	MeanPayment    string         `json:"mean_payment"`
+	Accumulation   bool           `json:"accumulation"`
+	BalanceEnabled bool           `json:"balance_enabled"`
+	Points         []BalancePoint `json:"points"`
+}
+
+// NetWorthPoint sums balance enabled accounts and the investment portfolio,
+// savings are the accumulation accounts
+type NetWorthPoint struct {
+	Date        time.Time `json:"date"`
+	Total       float64   `json:"total"`
+	Savings     float64   `json:"savings"`
+	Spending    float64   `json:"spending"`
+	Investments float64   `json:"investments"`
+}
+
+type BalanceHistory struct {
+	StartDate time.Time               `json:"start_date"`
+	EndDate   time.Time               `json:"end_date"`
+	Accounts  []AccountBalanceHistory `json:"accounts"`
+	NetWorth  []NetWorthPoint         `json:"net_worth"`
+}
+
+// DayTotal is the income and spending of a day, transfers between accounts are not counted
+type DayTotal struct {
+	Date    time.Time `json:"date"`
+	Income  float64   `json:"income"`
+	Outcome float64   `json:"outcome"`
+}
+
+type CategoryTotal struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Income  float64   `json:"income"`
+	Outcome float64   `json:"outcome"`
+}
+
+// Summary counts the transactions the user made, spending of the other members of shared accounts
+// is in MembersReport
+type Summary struct {
+	StartDate  time.Time       `json:"start_date"`
+	EndDate    time.Time       `json:"end_date"`
+	Income     float64         `json:"income"`
+	Outcome    float64         `json:"outcome"`
+	Days       []DayTotal      `json:"days"`
+	Categories []CategoryTotal `json:"categories"`
+}
+
+// MemberTotal is the income and spending of one author on an account, the zero category is the total
+type MemberTotal struct {
+	UserID       uuid.UUID
+	CategoryID   uuid.UUID
+	CategoryName string
+	Income       float64
+	Outcome      float64
+}
+
+type MemberBreakdown struct {
+	User       SharingUser     `json:"user"`
+	Income     float64         `json:"income"`
+	Outcome    float64         `json:"outcome"`
+	Share      float64         `json:"share"`
+	Categories []CategoryTotal `json:"categories"`
+}
+
+type MembersReport struct {
+	AccountID uuid.UUID         `json:"account_id"`
+	StartDate time.Time         `json:"start_date"`
+	EndDate   time.Time         `json:"end_date"`
+	Income    float64           `json:"income"`
+	Outcome   float64           `json:"outcome"`
+	Members   []MemberBreakdown `json:"members"`
+}
+
+type Purchase struct {
+	ID          uuid.UUID `json:"id"`
+	Date        time.Time `json:"date"`
+	Amount      float64   `json:"amount"`
+	Payer       string    `json:"payer"`
+	Description string    `json:"description"`
+}
+
+// NoSpendStreak is the longest run of days without spending
+type NoSpendStreak struct {
+	Days      int       `json:"days"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// MonthTrend has the change of spending against the previous month in percent
+type MonthTrend struct {
+	Month         time.Time `json:"month"`
+	Income        float64   `json:"income"`
+	Outcome       float64   `json:"outcome"`
+	OutcomeChange float64   `json:"outcome_change"`
+}
+
+// YearReview counts the transactions the user made, like Summary
+type YearReview struct {
+	Year            int             `json:"year"`
+	Income          float64         `json:"income"`
+	Outcome         float64         `json:"outcome"`
+	SavingsRate     float64         `json:"savings_rate"`
+	TopCategories   []CategoryTotal `json:"top_categories"`
+	TopPayees       []TopPayee      `json:"top_payees"`
+	BiggestPurchase *Purchase       `json:"biggest_purchase"`
+	NoSpendStreak   *NoSpendStreak  `json:"no_spend_streak"`
+	Months          []MonthTrend    `json:"months"`
+}
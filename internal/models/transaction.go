@@ -16,8 +16,10 @@ type Transaction struct {
 	Outcome          float64        `json:"outcome" valid:"required"`
 	Date             time.Time      `json:"date" valid:"isdate"`
 	Payer            string         `json:"payer" valid:"-"`
+	PayeeID          *uuid.UUID     `json:"payee_id,omitempty" valid:"-"`
 	Description      string         `json:"description" valid:"-"`
 	Categories       []CategoryName `json:"categories" valid:"-"`
+	Reconciled       bool           `json:"-" valid:"-"` // locked by a finished reconciliation
 }
 
 type CategoryName struct {
@@ -18,9 +18,10 @@ type User struct {
 }
 
 type SharingUser struct {
-	ID        uuid.UUID `json:"id"`
-	Login     string    `json:"login"`
-	AvatarURL uuid.UUID `json:"avatar_url"`
+	ID        uuid.UUID   `json:"id"`
+	Login     string      `json:"login"`
+	AvatarURL uuid.UUID   `json:"avatar_url"`
+	Role      AccountRole `json:"role,omitempty"` // empty for a user who has left the account
 }
 
 type ContextKeyUserType struct{}